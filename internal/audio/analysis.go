@@ -0,0 +1,110 @@
+package audio
+
+import (
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/go-audio/wav"
+
+	onset "github.com/schollz/onsets"
+
+	"github.com/schollz/collidertracker/internal/getbpm"
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/music"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// AnalyzeFile detects the BPM, chords and key of a sample file again and re-slices it with the
+// onset detection preset of the file or into equal slices, as its slice type says. The settings chosen for the
+// file (slice count, onset preset, playthrough, sync, kit and warp markers) are kept; a file without metadata
+// gets the defaults it would get when assigned. It only reads the model's settings, so it can
+// run off the UI goroutine.
+func AnalyzeFile(file string, metadata types.FileMetadata, hasMetadata bool, projectDir string) (types.FileMetadata, error) {
+	waveformFile, err := ConvertToWaveformFile(file, projectDir)
+	if err != nil {
+		return metadata, fmt.Errorf("waveform file: %w", err)
+	}
+	metadata.WaveformFile = waveformFile
+
+	beats, bpm, err := getbpm.GetBPM(waveformFile)
+	if err != nil {
+		return metadata, fmt.Errorf("bpm: %w", err)
+	}
+	metadata.BPM = float32(bpm)
+	if metadata.Chords, metadata.Key, err = DetectChords(waveformFile, bpm); err != nil {
+		logging.Storage.Warnf("Chord detection failed for %s: %v", file, err)
+	}
+	if !hasMetadata {
+		metadata.Slices = int(2 * math.Round(beats))
+		metadata.SyncToBPM = 1
+	}
+	if metadata.Slices <= 0 {
+		metadata.Slices = 16
+	}
+
+	if metadata.SliceType == 1 {
+		result, err := onset.AnalyzeSlices(waveformFile, model.OnsetOptions(metadata.Slices, metadata.OnsetPreset))
+		if err != nil {
+			return metadata, fmt.Errorf("onsets: %w", err)
+		}
+		metadata.Onsets = result.Onsets
+		return metadata, nil
+	}
+	audioLength, _, _, err := getbpm.Length(waveformFile)
+	if err != nil {
+		return metadata, fmt.Errorf("length: %w", err)
+	}
+	metadata.Onsets = model.EqualSliceOnsets(audioLength, metadata.Slices)
+	return metadata, nil
+}
+
+// Chord detection listens to at most maxChordBars bars of a sample, at about chordRate samples
+// a second: enough for notes up to B6
+const (
+	maxChordBars = 64
+	chordRate    = 8000
+)
+
+// DetectChords estimates the chord of each bar of four beats at bpm in a waveform file (a WAV
+// file) and the key the chords are in. Both are empty when no chord is heard, as in drum loops.
+func DetectChords(waveformFile string, bpm float64) ([]string, string, error) {
+	if bpm <= 0 {
+		return nil, "", fmt.Errorf("no BPM")
+	}
+	f, err := os.Open(waveformFile)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+	decoder := wav.NewDecoder(f)
+	if !decoder.IsValidFile() {
+		return nil, "", fmt.Errorf("not a WAV file")
+	}
+	buf, err := decoder.FullPCMBuffer()
+	if err != nil {
+		return nil, "", err
+	}
+	if buf.Format == nil || buf.Format.NumChannels <= 0 || buf.Format.SampleRate <= 0 || buf.SourceBitDepth <= 0 {
+		return nil, "", fmt.Errorf("no audio format")
+	}
+
+	// Average groups of frames of the first channel down to about chordRate
+	channels, rate := buf.Format.NumChannels, buf.Format.SampleRate
+	factor := max(1, rate/chordRate)
+	bar := 4 * 60 / bpm
+	frames := min(len(buf.Data)/channels, int(maxChordBars*bar*float64(rate)))
+	scale := math.Pow(2, float64(buf.SourceBitDepth-1))
+	samples := make([]float64, 0, frames/factor)
+	for start := 0; start+factor <= frames; start += factor {
+		sum := 0.0
+		for i := start; i < start+factor; i++ {
+			sum += float64(buf.Data[i*channels])
+		}
+		samples = append(samples, sum/float64(factor)/scale)
+	}
+
+	chords, key := music.ChordsAndKey(samples, rate/factor, bar)
+	return chords, key, nil
+}
@@ -2,7 +2,6 @@ package audio
 
 import (
 	"fmt"
-	"log"
 	"math"
 	"os"
 	"path/filepath"
@@ -10,6 +9,7 @@ import (
 
 	"github.com/schollz/audiomorph"
 	"github.com/schollz/collidertracker/internal/getbpm"
+	"github.com/schollz/collidertracker/internal/logging"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/storage"
 	"github.com/schollz/collidertracker/internal/types"
@@ -35,7 +35,7 @@ func ConvertToWaveformFile(inputPath string, projectDir string) (string, error)
 		sourceInfo, err := os.Stat(inputPath)
 		if err == nil && info.ModTime().After(sourceInfo.ModTime()) {
 			// Converted file exists and is up to date
-			log.Printf("Using existing waveform file: %s", outputPath)
+			logging.Storage.Debugf("Using existing waveform file: %s", outputPath)
 			return outputPath, nil
 		}
 	}
@@ -51,7 +51,7 @@ func ConvertToWaveformFile(inputPath string, projectDir string) (string, error)
 		return "", fmt.Errorf("failed to encode WAV file: %w", err)
 	}
 
-	log.Printf("Converted audio file for waveform: %s -> %s", inputPath, outputPath)
+	logging.Storage.Debugf("Converted audio file for waveform: %s -> %s", inputPath, outputPath)
 	return outputPath, nil
 }
 
@@ -67,8 +67,12 @@ func PlayFile(m *model.Model) {
 		return
 	}
 
-	// Get full path for the file
-	fullPath := filepath.Join(m.CurrentDir, filename)
+	PlayPath(m, filepath.Join(m.CurrentDir, filename))
+}
+
+// PlayPath starts previewing an audio file, or stops it if it is the one playing
+func PlayPath(m *model.Model, fullPath string) {
+	filename := filepath.Base(fullPath)
 
 	// Check if this specific file is currently playing
 	if m.CurrentlyPlayingFile == fullPath {
@@ -76,19 +80,19 @@ func PlayFile(m *model.Model) {
 		m.CurrentlyPlayingFile = ""
 		m.IsPlaying = false
 		m.SendOSCPlaybackMessage(fullPath, false)
-		log.Printf("File playback stopped: %s", filename)
+		logging.Storage.Debugf("File playback stopped: %s", filename)
 	} else {
 		// Different file or no file playing, so start playing this one
 		// First stop any currently playing file
 		if m.CurrentlyPlayingFile != "" {
 			m.SendOSCPlaybackMessage(m.CurrentlyPlayingFile, false)
-			log.Printf("Stopping previously playing file: %s", m.CurrentlyPlayingFile)
+			logging.Storage.Debugf("Stopping previously playing file: %s", m.CurrentlyPlayingFile)
 		}
 		// Now start the new file
 		m.CurrentlyPlayingFile = fullPath
 		m.IsPlaying = true
 		m.SendOSCPlaybackMessage(fullPath, true)
-		log.Printf("File playback started: %s", filename)
+		logging.Storage.Debugf("File playback started: %s", filename)
 	}
 }
 
@@ -117,15 +121,20 @@ func SelectFile(m *model.Model) {
 	}
 
 	// Select audio file - store the full path
-	fullPath := filepath.Join(m.CurrentDir, selected)
+	AssignFile(m, filepath.Join(m.CurrentDir, selected))
+}
+
+// AssignFile sets an audio file on the phrase row being edited (FileSelectRow) and prepares its
+// metadata, converting it first when the import setting asks for it
+func AssignFile(m *model.Model, fullPath string) {
+	fullPath = importSample(m, fullPath)
 	fileIndex := m.AppendPhrasesFile(fullPath)
-	phrasesData := m.GetCurrentPhrasesData()
-	(*phrasesData)[m.CurrentPhrase][m.FileSelectRow][int(types.ColFilename)] = fileIndex
+	m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.FileSelectRow, types.ColFilename, fileIndex)
 
 	// Convert file for waveform visualization
-	waveformFile, err := ConvertToWaveformFile(fullPath, m.SaveFolder)
+	waveformFile, err := ConvertToWaveformFile(fullPath, m.ProjectFolder())
 	if err != nil {
-		log.Printf("Warning: Failed to create waveform file for %s: %v", fullPath, err)
+		logging.Storage.Warnf("Warning: Failed to create waveform file for %s: %v", fullPath, err)
 		// Continue anyway - waveform visualization will be unavailable but file can still be used
 		waveformFile = "" // Empty string indicates no waveform file available
 	}
@@ -152,8 +161,17 @@ func SelectFile(m *model.Model) {
 		}
 		// Generate equal slices for the default Even mode
 		m.GenerateEqualSlices(fullPath)
+		if waveformFile != "" {
+			if chords, key, err := DetectChords(waveformFile, bpm); err == nil {
+				metadata := m.FileMetadata[fullPath]
+				metadata.Chords, metadata.Key = chords, key
+				m.FileMetadata[fullPath] = metadata
+			} else {
+				logging.Storage.Warnf("Chord detection failed for %s: %v", fullPath, err)
+			}
+		}
 	} else {
-		log.Printf("Could not get BPM for %s: %v", fullPath, err)
+		logging.Storage.Warnf("Could not get BPM for %s: %v", fullPath, err)
 		// Still store the waveform file path even if BPM detection failed
 		m.FileMetadata[fullPath] = types.FileMetadata{
 			BPM:          120.0, // Default BPM
@@ -164,10 +182,14 @@ func SelectFile(m *model.Model) {
 			WaveformFile: waveformFile,
 		}
 	}
+	if warning := m.SampleRateMismatch(fullPath); warning != "" {
+		logging.Storage.Warnf("Warning: %s: %s", fullPath, warning)
+		m.Notice = warning
+	}
 
 	// Track this as the last edited row so "S" key will work
 	m.LastEditRow = m.FileSelectRow
 
-	log.Printf("Selected file %s (full path: %s) for phrase %d row %d", selected, fullPath, m.CurrentPhrase, m.FileSelectRow)
-	storage.AutoSave(m)
+	logging.Storage.Debugf("Selected file %s (full path: %s) for phrase %d row %d", filepath.Base(fullPath), fullPath, m.CurrentPhrase, m.FileSelectRow)
+	m.Publish(model.Event{Kind: model.EventSettings})
 }
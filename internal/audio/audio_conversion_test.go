@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/schollz/audiomorph"
 )
 
 func TestConvertToWaveformFile(t *testing.T) {
@@ -45,3 +47,40 @@ func TestConvertToWaveformFile(t *testing.T) {
 		t.Errorf("Expected same waveform file path on second call, got %s and %s", waveformFile, waveformFile2)
 	}
 }
+
+func TestImportSample(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := "../getbpm/Break120.wav"
+	original, err := audiomorph.DecodeFile(testFile)
+	if err != nil {
+		t.Fatalf("DecodeFile failed: %v", err)
+	}
+
+	// A sample that already fits is used as it is
+	imported, err := ImportSample(testFile, tmpDir, original.SampleRate, false)
+	if err != nil {
+		t.Fatalf("ImportSample failed: %v", err)
+	}
+	if imported != testFile {
+		t.Errorf("Expected the sample itself, got %s", imported)
+	}
+
+	// Resampled and folded to mono into the project folder
+	imported, err = ImportSample(testFile, tmpDir, 22050, true)
+	if err != nil {
+		t.Fatalf("ImportSample failed: %v", err)
+	}
+	if filepath.Dir(imported) != tmpDir {
+		t.Errorf("Expected the copy in %s, got %s", tmpDir, imported)
+	}
+	converted, err := audiomorph.DecodeFile(imported)
+	if err != nil {
+		t.Fatalf("DecodeFile of the copy failed: %v", err)
+	}
+	if converted.SampleRate != 22050 || converted.NumChannels != 1 {
+		t.Errorf("Expected 22050 Hz mono, got %d Hz with %d channels", converted.SampleRate, converted.NumChannels)
+	}
+	if diff := converted.Duration - original.Duration; diff > 0.01 || diff < -0.01 {
+		t.Errorf("Expected the duration to be kept, got %.3fs for %.3fs", converted.Duration, original.Duration)
+	}
+}
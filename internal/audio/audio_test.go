@@ -1,9 +1,13 @@
 package audio
 
 import (
+	"math"
+	"os"
 	"path/filepath"
 	"testing"
 
+	goaudio "github.com/go-audio/audio"
+	"github.com/go-audio/wav"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/schollz/collidertracker/internal/model"
@@ -179,3 +183,34 @@ func TestSelectFile(t *testing.T) {
 		assert.Contains(t, m.SamplerPhrasesFiles, fullPath)
 	})
 }
+
+func TestDetectChords(t *testing.T) {
+	// Two bars at 120 BPM of a C major triad, then two of A minor
+	const rate = 44100
+	var data []int
+	for _, chord := range [][]float64{{261.63, 329.63, 392.00}, {220.00, 261.63, 329.63}} {
+		for i := 0; i < 4*rate; i++ {
+			v := 0.0
+			for _, freq := range chord {
+				v += 0.2 * math.Sin(2*math.Pi*freq*float64(i)/rate)
+			}
+			data = append(data, int(v*32767))
+		}
+	}
+	path := filepath.Join(t.TempDir(), "loop.wav")
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	encoder := wav.NewEncoder(f, rate, 16, 1, 1)
+	assert.NoError(t, encoder.Write(&goaudio.IntBuffer{Format: &goaudio.Format{NumChannels: 1, SampleRate: rate}, Data: data, SourceBitDepth: 16}))
+	assert.NoError(t, encoder.Close())
+	assert.NoError(t, f.Close())
+
+	chords, key, err := DetectChords(path, 120)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"C", "C", "Am", "Am"}, chords)
+	assert.Equal(t, "C major", key)
+
+	// A file that is not there is an error
+	_, _, err = DetectChords(filepath.Join(t.TempDir(), "missing.wav"), 120)
+	assert.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package audio
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	cueSheetSuffix = ".cue"
+	chaptersSuffix = ".chapters.txt"
+	cueFrames      = 75 // Cue sheet times count CD frames, 75 to the second
+)
+
+// CueSheetPath returns where the cue sheet of a WAV file is written
+func CueSheetPath(wavPath string) string {
+	return strings.TrimSuffix(wavPath, filepath.Ext(wavPath)) + cueSheetSuffix
+}
+
+// ChaptersPath returns where the chapter list of a WAV file is written
+func ChaptersPath(wavPath string) string {
+	return strings.TrimSuffix(wavPath, filepath.Ext(wavPath)) + chaptersSuffix
+}
+
+// WriteTracklist writes the markers of a WAV file next to it as a tracklist, for uploading the
+// recording as a mix: a cue sheet with a track for each marker, titled after the project, and
+// a chapter list ("1:05 DROP") to paste into a video or mix description. The first track
+// starts at the top of the file, taking in any silence before its marker.
+func WriteTracklist(wavPath, title string, markers []Marker) error {
+	if len(markers) == 0 {
+		return nil
+	}
+	var cue, chapters strings.Builder
+	fmt.Fprintf(&cue, "TITLE %s\n", cueString(title))
+	fmt.Fprintf(&cue, "FILE %s WAVE\n", cueString(filepath.Base(wavPath)))
+	for i, marker := range markers {
+		seconds := max(0, marker.Seconds)
+		if i == 0 {
+			seconds = 0
+		}
+		frames := int(math.Round(seconds * cueFrames))
+		fmt.Fprintf(&cue, "  TRACK %02d AUDIO\n", i+1)
+		fmt.Fprintf(&cue, "    TITLE %s\n", cueString(marker.Name))
+		fmt.Fprintf(&cue, "    INDEX 01 %02d:%02d:%02d\n", frames/cueFrames/60, frames/cueFrames%60, frames%cueFrames)
+		fmt.Fprintf(&chapters, "%s %s\n", chapterTime(seconds), marker.Name)
+	}
+	if err := os.WriteFile(CueSheetPath(wavPath), []byte(cue.String()), 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(ChaptersPath(wavPath), []byte(chapters.String()), 0644)
+}
+
+// MoveTracklist moves the tracklist of a WAV file that was renamed, pointing its cue sheet
+// at the new name. A file without a tracklist is left alone.
+func MoveTracklist(oldPath, newPath string) error {
+	cue, err := os.ReadFile(CueSheetPath(oldPath))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	oldFile := "FILE " + cueString(filepath.Base(oldPath))
+	cue = []byte(strings.Replace(string(cue), oldFile, "FILE "+cueString(filepath.Base(newPath)), 1))
+	if err := os.WriteFile(CueSheetPath(newPath), cue, 0644); err != nil {
+		return err
+	}
+	os.Remove(CueSheetPath(oldPath))
+	return os.Rename(ChaptersPath(oldPath), ChaptersPath(newPath))
+}
+
+// RemoveTracklist removes the tracklist of a WAV file, if it has one
+func RemoveTracklist(wavPath string) {
+	os.Remove(CueSheetPath(wavPath))
+	os.Remove(ChaptersPath(wavPath))
+}
+
+// cueString quotes a cue sheet value, which can't hold double quotes
+func cueString(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "'") + `"`
+}
+
+// chapterTime formats a chapter start as m:ss, or h:mm:ss from an hour on
+func chapterTime(seconds float64) string {
+	s := int(seconds)
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
@@ -0,0 +1,74 @@
+package audio
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteTracklist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.wav")
+	markers := []Marker{{Name: "Start", Seconds: 2}, {Name: `The "DROP"`, Seconds: 65.5}, {Name: "Start 2", Seconds: 3725}}
+	if err := WriteTracklist(path, "my song", markers); err != nil {
+		t.Fatalf("WriteTracklist failed: %v", err)
+	}
+
+	// The first track takes in the pre-roll
+	cue, err := os.ReadFile(CueSheetPath(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantCue := `TITLE "my song"
+FILE "session.wav" WAVE
+  TRACK 01 AUDIO
+    TITLE "Start"
+    INDEX 01 00:00:00
+  TRACK 02 AUDIO
+    TITLE "The 'DROP'"
+    INDEX 01 01:05:38
+  TRACK 03 AUDIO
+    TITLE "Start 2"
+    INDEX 01 62:05:00
+`
+	if string(cue) != wantCue {
+		t.Errorf("Cue sheet is\n%s\nwant\n%s", cue, wantCue)
+	}
+
+	chapters, err := os.ReadFile(ChaptersPath(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantChapters := "0:00 Start\n1:05 The \"DROP\"\n1:02:05 Start 2\n"
+	if string(chapters) != wantChapters {
+		t.Errorf("Chapters are\n%s\nwant\n%s", chapters, wantChapters)
+	}
+
+	// Renaming the recording moves its tracklist along
+	renamed := filepath.Join(filepath.Dir(path), "live.wav")
+	if err := MoveTracklist(path, renamed); err != nil {
+		t.Fatalf("MoveTracklist failed: %v", err)
+	}
+	if cue, _ := os.ReadFile(CueSheetPath(renamed)); !strings.Contains(string(cue), "FILE \"live.wav\" WAVE\n") {
+		t.Errorf("Moved cue sheet is\n%s", cue)
+	}
+	if _, err := os.Stat(ChaptersPath(renamed)); err != nil {
+		t.Errorf("Chapters were not moved: %v", err)
+	}
+	if _, err := os.Stat(CueSheetPath(path)); !os.IsNotExist(err) {
+		t.Errorf("The old cue sheet is left behind")
+	}
+	RemoveTracklist(renamed)
+	if _, err := os.Stat(ChaptersPath(renamed)); !os.IsNotExist(err) {
+		t.Errorf("RemoveTracklist left the chapters")
+	}
+
+	// No markers, no tracklist
+	other := filepath.Join(t.TempDir(), "other.wav")
+	if err := WriteTracklist(other, "my song", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(CueSheetPath(other)); !os.IsNotExist(err) {
+		t.Errorf("A take without markers got a cue sheet")
+	}
+}
@@ -0,0 +1,95 @@
+package audio
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/schollz/audiomorph"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// ImportSample returns the file a sample should be assigned from: the sample itself when it
+// already fits, or a WAV copy in the project folder converted to sampleRate (0 keeps the rate)
+// and folded to mono when mono is set. A copy that is newer than the sample is reused.
+func ImportSample(inputPath, projectDir string, sampleRate int, mono bool) (string, error) {
+	sample, err := audiomorph.DecodeFile(inputPath)
+	if err != nil {
+		return inputPath, fmt.Errorf("failed to decode audio file: %w", err)
+	}
+	resample := sampleRate > 0 && sample.SampleRate != sampleRate
+	fold := mono && sample.NumChannels > 1
+	if !resample && !fold {
+		return inputPath, nil
+	}
+
+	// Name the copy after what was converted, so it never replaces the sample itself
+	baseName := filepath.Base(inputPath)
+	name := strings.TrimSuffix(baseName, filepath.Ext(baseName))
+	if resample {
+		name += fmt.Sprintf("_%dhz", sampleRate)
+	}
+	if fold {
+		name += "_mono"
+	}
+	outputPath := filepath.Join(projectDir, name+".wav")
+	if info, err := os.Stat(outputPath); err == nil {
+		if sourceInfo, err := os.Stat(inputPath); err == nil && info.ModTime().After(sourceInfo.ModTime()) {
+			logging.Storage.Debugf("Using existing imported file: %s", outputPath)
+			return outputPath, nil
+		}
+	}
+
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		return inputPath, fmt.Errorf("failed to create project directory: %w", err)
+	}
+	if fold {
+		foldToMono(sample)
+	}
+	var options []audiomorph.Option
+	if resample {
+		options = append(options, audiomorph.OptionSampleRate(sampleRate), audiomorph.OptionInterpolationMethod("cubic"))
+	}
+	if err := audiomorph.EncodeFile(sample, outputPath, options...); err != nil {
+		return inputPath, fmt.Errorf("failed to encode WAV file: %w", err)
+	}
+	logging.Storage.Debugf("Imported %s as %s", inputPath, outputPath)
+	return outputPath, nil
+}
+
+// foldToMono mixes all channels of decoded audio into one
+func foldToMono(sample *audiomorph.Audio) {
+	mixed := make([]int, len(sample.Data[0]))
+	for i := range mixed {
+		sum := 0
+		for _, channel := range sample.Data {
+			sum += channel[i]
+		}
+		mixed[i] = sum / len(sample.Data)
+	}
+	sample.Data = [][]int{mixed}
+	sample.NumChannels = 1
+}
+
+// importSample converts a sample being assigned as the import setting asks, returning the
+// file to assign (the sample itself when nothing is converted or the conversion fails)
+func importSample(m *model.Model, fullPath string) string {
+	if m.ImportMode == types.ImportModeOff {
+		return fullPath
+	}
+	sampleRate := 0
+	if m.ImportMode == types.ImportModeRate || m.ImportMode == types.ImportModeBoth {
+		sampleRate, _ = m.ServerAudioInfo() // Unknown (0) until the server has reported it
+	}
+	mono := m.ImportMode == types.ImportModeMono || m.ImportMode == types.ImportModeBoth
+	imported, err := ImportSample(fullPath, m.ProjectFolder(), sampleRate, mono)
+	if err != nil {
+		logging.Storage.Warnf("Warning: Failed to import %s: %v", fullPath, err)
+		return fullPath
+	}
+	return imported
+}
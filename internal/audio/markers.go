@@ -0,0 +1,127 @@
+package audio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// Marker is a named position in a recording
+type Marker struct {
+	Name    string
+	Seconds float64 // From the start of the recording
+}
+
+// AddMarkers writes markers into a WAV file, in place, as a cue chunk with a label for each
+// cue, which audio editors show as markers to jump between. Cues and labels the file already
+// has are replaced.
+func AddMarkers(path string, markers []Marker) error {
+	if len(markers) == 0 {
+		return nil
+	}
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	chunks, format, data, err := readChunks(in, info.Size(), path)
+	if err != nil {
+		return err
+	}
+	sampleRate := float64(binary.LittleEndian.Uint32(format[4:8]))
+	if sampleRate <= 0 {
+		return fmt.Errorf("%s has no audio format", filepath.Base(path))
+	}
+
+	// Keep every chunk but the old cues and labels
+	var kept []wavChunk
+	keptData := -1
+	for i, chunk := range chunks {
+		if chunk.id == "cue " || (chunk.id == "LIST" && isLabelList(in, chunk)) {
+			continue
+		}
+		if i == data {
+			keptData = len(kept)
+		}
+		kept = append(kept, chunk)
+	}
+	cues, labels := cueChunks(markers, sampleRate)
+	riffSize := int64(4 + len(cues) + len(labels))
+	for _, chunk := range kept {
+		riffSize += 8 + chunk.size + chunk.size%2
+	}
+	if riffSize > math.MaxUint32 {
+		return fmt.Errorf("%s would be too long for a WAV file", filepath.Base(path))
+	}
+
+	tmp := path + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(out)
+	err = writePadded(w, in, kept, keptData, riffSize, 0, 0)
+	if err == nil {
+		_, err = w.Write(cues)
+	}
+	if err == nil {
+		_, err = w.Write(labels)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	in.Close()
+	return os.Rename(tmp, path)
+}
+
+// isLabelList reports whether a LIST chunk holds cue labels (an adtl list)
+func isLabelList(in *os.File, chunk wavChunk) bool {
+	listType := make([]byte, 4)
+	_, err := in.ReadAt(listType, chunk.offset+8)
+	return err == nil && string(listType) == "adtl"
+}
+
+// cueChunks builds the cue chunk and the LIST chunk labelling its cues, with headers. Cue ids
+// start at 1.
+func cueChunks(markers []Marker, sampleRate float64) (cues, labels []byte) {
+	var cue bytes.Buffer
+	cue.WriteString("cue ")
+	binary.Write(&cue, binary.LittleEndian, uint32(4+24*len(markers)))
+	binary.Write(&cue, binary.LittleEndian, uint32(len(markers)))
+	var list bytes.Buffer
+	list.WriteString("adtl")
+	for i, marker := range markers {
+		frame := uint32(math.Round(max(0, marker.Seconds) * sampleRate))
+		binary.Write(&cue, binary.LittleEndian, []uint32{uint32(i + 1), frame})
+		cue.WriteString("data")
+		binary.Write(&cue, binary.LittleEndian, []uint32{0, 0, frame})
+
+		text := append([]byte(marker.Name), 0)
+		list.WriteString("labl")
+		binary.Write(&list, binary.LittleEndian, []uint32{uint32(4 + len(text)), uint32(i + 1)})
+		list.Write(text)
+		if len(text)%2 == 1 {
+			list.WriteByte(0)
+		}
+	}
+	var label bytes.Buffer
+	label.WriteString("LIST")
+	binary.Write(&label, binary.LittleEndian, uint32(list.Len()))
+	label.Write(list.Bytes())
+	return cue.Bytes(), label.Bytes()
+}
@@ -0,0 +1,68 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAddMarkers(t *testing.T) {
+	samples := []int16{1, 2, 3, 4, 5, 6}
+	path := filepath.Join(t.TempDir(), "take.wav")
+	if err := os.WriteFile(path, testWAV(samples, 12), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// At 1 kHz, 2 ms is frame 2
+	markers := []Marker{{Name: "Start", Seconds: 0}, {Name: "DROP", Seconds: 0.002}}
+	if err := AddMarkers(path, markers); err != nil {
+		t.Fatalf("AddMarkers failed: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := testWAV(samples, 12)
+	var want bytes.Buffer
+	want.Write(original)
+	want.WriteString("cue ")
+	binary.Write(&want, binary.LittleEndian, []uint32{52, 2})
+	binary.Write(&want, binary.LittleEndian, []uint32{1, 0})
+	want.WriteString("data")
+	binary.Write(&want, binary.LittleEndian, []uint32{0, 0, 0})
+	binary.Write(&want, binary.LittleEndian, []uint32{2, 2})
+	want.WriteString("data")
+	binary.Write(&want, binary.LittleEndian, []uint32{0, 0, 2})
+	want.WriteString("LIST")
+	binary.Write(&want, binary.LittleEndian, []uint32{40})
+	want.WriteString("adtl")
+	want.WriteString("labl")
+	binary.Write(&want, binary.LittleEndian, []uint32{10, 1})
+	want.WriteString("Start\x00")
+	want.WriteString("labl")
+	binary.Write(&want, binary.LittleEndian, []uint32{9, 2})
+	want.WriteString("DROP\x00\x00")
+	wantBytes := want.Bytes()
+	binary.LittleEndian.PutUint32(wantBytes[4:8], uint32(len(wantBytes)-8))
+	if !bytes.Equal(got, wantBytes) {
+		t.Errorf("Marked file is\n%v\nwant\n%v", got, wantBytes)
+	}
+
+	// Marking again replaces the markers
+	if err := AddMarkers(path, markers); err != nil {
+		t.Fatalf("AddMarkers failed: %v", err)
+	}
+	if again, _ := os.ReadFile(path); !bytes.Equal(again, wantBytes) {
+		t.Errorf("Marking again changed the file")
+	}
+
+	// The pre-roll keeps the markers, which are placed after it
+	if err := PadStart(path, 0.001); err != nil {
+		t.Fatalf("PadStart failed: %v", err)
+	}
+	if padded, _ := os.ReadFile(path); !bytes.HasSuffix(padded, wantBytes[len(original):]) {
+		t.Errorf("PadStart dropped the markers")
+	}
+}
@@ -0,0 +1,162 @@
+package audio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// wavChunk is a chunk of a RIFF file: where its header starts and how long its body is
+type wavChunk struct {
+	id     string
+	offset int64
+	size   int64
+}
+
+// PadStart puts seconds of silence at the start of a WAV file, in place. It works on the
+// chunks of the file, so any sample format SuperCollider records (int or float) is kept as is.
+func PadStart(path string, seconds float64) error {
+	if seconds <= 0 {
+		return nil
+	}
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	chunks, format, data, err := readChunks(in, info.Size(), path)
+	if err != nil {
+		return err
+	}
+	sampleRate := int64(binary.LittleEndian.Uint32(format[4:8]))
+	blockAlign := int64(binary.LittleEndian.Uint16(format[12:14]))
+	if sampleRate <= 0 || blockAlign <= 0 {
+		return fmt.Errorf("%s has no audio format", filepath.Base(path))
+	}
+	chunks[data].size -= chunks[data].size % blockAlign
+
+	// Unsigned 8-bit PCM is silent at its midpoint, every other format at zero
+	silence := byte(0)
+	if binary.LittleEndian.Uint16(format[0:2]) == 1 && binary.LittleEndian.Uint16(format[14:16]) == 8 {
+		silence = 0x80
+	}
+	padding := int64(math.Round(seconds*float64(sampleRate))) * blockAlign
+	riffSize := int64(4)
+	for i, chunk := range chunks {
+		size := chunk.size
+		if i == data {
+			size += padding
+		}
+		riffSize += 8 + size + size%2
+	}
+	if riffSize > math.MaxUint32 {
+		return fmt.Errorf("%s would be too long for a WAV file", filepath.Base(path))
+	}
+
+	tmp := path + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(out)
+	err = writePadded(w, in, chunks, data, riffSize, padding, silence)
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	in.Close()
+	return os.Rename(tmp, path)
+}
+
+// readChunks lists the chunks of a WAV file, with the body of its format chunk and the index
+// of its data chunk
+func readChunks(in io.ReaderAt, size int64, path string) (chunks []wavChunk, format []byte, data int, err error) {
+	header := make([]byte, 12)
+	if _, err := in.ReadAt(header, 0); err != nil || string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return nil, nil, -1, fmt.Errorf("%s is not a WAV file", filepath.Base(path))
+	}
+	data = -1
+	for offset := int64(12); offset+8 <= size; {
+		chunkHeader := make([]byte, 8)
+		if _, err := in.ReadAt(chunkHeader, offset); err != nil {
+			return nil, nil, -1, err
+		}
+		chunk := wavChunk{id: string(chunkHeader[0:4]), offset: offset, size: int64(binary.LittleEndian.Uint32(chunkHeader[4:8]))}
+		if chunk.id == "data" && (chunk.size == 0 || offset+8+chunk.size > size) {
+			// A recording that was not closed properly: its samples run to the end of the file
+			chunk.size = size - offset - 8
+		}
+		if chunk.id == "fmt " && chunk.size >= 16 {
+			format = make([]byte, 16)
+			if _, err := in.ReadAt(format, offset+8); err != nil {
+				return nil, nil, -1, err
+			}
+		}
+		if chunk.id == "data" && data < 0 {
+			data = len(chunks)
+		}
+		chunks = append(chunks, chunk)
+		offset += 8 + chunk.size + chunk.size%2
+	}
+	if format == nil || data < 0 {
+		return nil, nil, -1, fmt.Errorf("%s has no audio", filepath.Base(path))
+	}
+	return chunks, format, data, nil
+}
+
+// writePadded writes the chunks of a WAV file with padding bytes of silence before the samples
+// of its data chunk
+func writePadded(w io.Writer, in io.ReaderAt, chunks []wavChunk, data int, riffSize, padding int64, silence byte) error {
+	header := make([]byte, 12)
+	copy(header, "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(riffSize))
+	copy(header[8:], "WAVE")
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	for i, chunk := range chunks {
+		size := chunk.size
+		if i == data {
+			size += padding
+		}
+		chunkHeader := make([]byte, 8)
+		copy(chunkHeader, chunk.id)
+		binary.LittleEndian.PutUint32(chunkHeader[4:8], uint32(size))
+		if _, err := w.Write(chunkHeader); err != nil {
+			return err
+		}
+		if i == data {
+			block := bytes.Repeat([]byte{silence}, 4096)
+			for left := padding; left > 0; left -= int64(len(block)) {
+				if _, err := w.Write(block[:min(left, int64(len(block)))]); err != nil {
+					return err
+				}
+			}
+		}
+		if _, err := io.Copy(w, io.NewSectionReader(in, chunk.offset+8, chunk.size)); err != nil {
+			return err
+		}
+		if size%2 == 1 {
+			if _, err := w.Write([]byte{0}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
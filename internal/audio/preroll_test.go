@@ -0,0 +1,92 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testWAV builds a stereo 16-bit WAV at 1 kHz with a LIST chunk after the samples
+func testWAV(frames []int16, dataSize uint32) []byte {
+	var body bytes.Buffer
+	body.WriteString("WAVE")
+	body.WriteString("fmt ")
+	binary.Write(&body, binary.LittleEndian, []uint32{16})
+	binary.Write(&body, binary.LittleEndian, []uint16{1, 2})
+	binary.Write(&body, binary.LittleEndian, []uint32{1000, 4000})
+	binary.Write(&body, binary.LittleEndian, []uint16{4, 16})
+	body.WriteString("data")
+	binary.Write(&body, binary.LittleEndian, dataSize)
+	binary.Write(&body, binary.LittleEndian, frames)
+	if dataSize != 0 {
+		body.WriteString("LIST")
+		binary.Write(&body, binary.LittleEndian, []uint32{3})
+		body.WriteString("abc\x00")
+	}
+	var file bytes.Buffer
+	file.WriteString("RIFF")
+	binary.Write(&file, binary.LittleEndian, uint32(body.Len()))
+	file.Write(body.Bytes())
+	return file.Bytes()
+}
+
+func TestPadStart(t *testing.T) {
+	samples := []int16{100, -100, 200, -200, 300, -300}
+	path := filepath.Join(t.TempDir(), "take.wav")
+	if err := os.WriteFile(path, testWAV(samples, 12), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// 5 ms at 1 kHz is 5 frames of silence
+	if err := PadStart(path, 0.005); err != nil {
+		t.Fatalf("PadStart failed: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := testWAV(append(make([]int16, 10), samples...), 32)
+	if !bytes.Equal(got, want) {
+		t.Errorf("Padded file is\n%v\nwant\n%v", got, want)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("Temporary file was left behind")
+	}
+
+	// No pre-roll leaves the file alone
+	if err := PadStart(path, 0); err != nil {
+		t.Fatalf("PadStart without pre-roll failed: %v", err)
+	}
+	if again, _ := os.ReadFile(path); !bytes.Equal(again, want) {
+		t.Errorf("PadStart without pre-roll changed the file")
+	}
+}
+
+func TestPadStartUnfinishedRecording(t *testing.T) {
+	// A recording that was not closed still has a data size of zero
+	samples := []int16{1, 2, 3, 4}
+	path := filepath.Join(t.TempDir(), "take.wav")
+	if err := os.WriteFile(path, testWAV(samples, 0), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := PadStart(path, 0.001); err != nil {
+		t.Fatalf("PadStart failed: %v", err)
+	}
+	got, _ := os.ReadFile(path)
+	want := testWAV([]int16{0, 0, 1, 2, 3, 4}, 12)
+	// The unfinished file had no LIST chunk
+	want = want[:len(want)-12]
+	binary.LittleEndian.PutUint32(want[4:8], uint32(len(want)-8))
+	if !bytes.Equal(got, want) {
+		t.Errorf("Padded file is\n%v\nwant\n%v", got, want)
+	}
+
+	if err := os.WriteFile(path, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := PadStart(path, 1); err == nil {
+		t.Errorf("Expected an error for a file that is not a WAV")
+	}
+}
@@ -0,0 +1,263 @@
+// Package demo packs a project into a demo that opens straight into playback: a zip archive,
+// or a copy of the collidertracker binary with the archive appended to it
+package demo
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/storage"
+)
+
+// ManifestName is the file that marks an archive as a demo
+const ManifestName = "collidertracker-demo.json"
+
+// Manifest describes the project packed in a demo
+type Manifest struct {
+	Project string `json:"project"` // Name of the project folder the demo was made from
+	Samples bool   `json:"samples"` // Whether the samples and impulse responses are included
+	Version string `json:"version"` // Version of collidertracker that made the demo
+}
+
+// Export packs the project in projectDir into a demo at out. An out ending in .zip is a plain
+// archive that --demo opens; anything else is a copy of the running binary that plays the demo
+// when started. The song and the project's synths are always included, the samples, their
+// metadata and the impulse responses only with samples. Recordings, clips, snapshots and
+// backups are left out.
+func Export(projectDir, out string, samples bool, version string) error {
+	if _, err := os.Stat(filepath.Join(projectDir, "data.json.gz")); err != nil {
+		return fmt.Errorf("%s has no saved song", projectDir)
+	}
+	if storage.IsProjectLocked(projectDir) {
+		return fmt.Errorf("%s is password protected, unlock it before exporting a demo", projectDir)
+	}
+	files, err := projectFiles(projectDir, samples)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(out), ".demo-*")
+	if err != nil {
+		return fmt.Errorf("cannot write next to %s: %v", out, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	var offset int64
+	binary := !strings.EqualFold(filepath.Ext(out), ".zip")
+	if binary {
+		if offset, err = copyExecutable(tmp); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	manifest := Manifest{Project: filepath.Base(filepath.Clean(projectDir)), Samples: samples, Version: version}
+	err = writeArchive(tmp, offset, projectDir, files, manifest)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %v", out, err)
+	}
+	if binary {
+		if err := os.Chmod(tmp.Name(), 0755); err != nil {
+			return err
+		}
+	}
+	return os.Rename(tmp.Name(), out)
+}
+
+// projectFiles lists the files of a project that go into a demo, relative to the project
+func projectFiles(projectDir string, samples bool) ([]string, error) {
+	entries, err := os.ReadDir(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{"data.json.gz"}
+	folders := []string{model.SynthsFolderName}
+	if samples {
+		for _, entry := range entries {
+			if entry.Type().IsRegular() && (storage.IsAudioFile(entry.Name()) || strings.HasSuffix(entry.Name(), ".metadata.json")) {
+				files = append(files, entry.Name())
+			}
+		}
+		folders = append(folders, model.ImpulsesFolderName)
+	}
+	for _, folder := range folders {
+		err := filepath.WalkDir(filepath.Join(projectDir, folder), func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return filepath.SkipDir
+				}
+				return err
+			}
+			if d.Type().IsRegular() {
+				rel, err := filepath.Rel(projectDir, path)
+				if err != nil {
+					return err
+				}
+				files = append(files, rel)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// copyExecutable copies the running binary to dest and returns its size, where the archive
+// starts. A binary that already carries a demo cannot be copied, its archive would come along.
+func copyExecutable(dest io.Writer) (int64, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return 0, fmt.Errorf("failed to find the running binary: %v", err)
+	}
+	if _, ok := embeddedManifest(exe); ok {
+		return 0, fmt.Errorf("%s is a demo itself, export from a plain collidertracker binary", exe)
+	}
+	src, err := os.Open(exe)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the running binary: %v", err)
+	}
+	defer src.Close()
+	n, err := io.Copy(dest, src)
+	if err != nil {
+		return 0, fmt.Errorf("failed to copy the running binary: %v", err)
+	}
+	return n, nil
+}
+
+// writeArchive writes the manifest and the project's files as a zip starting at offset in w
+func writeArchive(w io.Writer, offset int64, projectDir string, files []string, manifest Manifest) error {
+	zw := zip.NewWriter(w)
+	zw.SetOffset(offset)
+	mf, err := zw.Create(ManifestName)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(mf).Encode(manifest); err != nil {
+		return err
+	}
+	for _, name := range files {
+		if err := addFile(zw, projectDir, name); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// addFile stores a project file in the archive under its slash-separated relative path
+func addFile(zw *zip.Writer, projectDir, name string) error {
+	src, err := os.Open(filepath.Join(projectDir, name))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name, header.Method = filepath.ToSlash(name), zip.Deflate
+	dest, err := zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dest, src)
+	return err
+}
+
+// Embedded reports whether the running binary carries a demo
+func Embedded() bool {
+	exe, err := os.Executable()
+	if err != nil {
+		return false
+	}
+	_, ok := embeddedManifest(exe)
+	return ok
+}
+
+// embeddedManifest reads the manifest of the demo in path, a demo binary or archive
+func embeddedManifest(path string) (Manifest, bool) {
+	var manifest Manifest
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return manifest, false
+	}
+	defer r.Close()
+	f, err := r.Open(ManifestName)
+	if err != nil {
+		return manifest, false
+	}
+	defer f.Close()
+	return manifest, json.NewDecoder(f).Decode(&manifest) == nil
+}
+
+// Open unpacks the demo in path, a demo binary or archive, into a new temporary folder and
+// returns the project folder. Changes are saved there, so the demo itself never changes.
+func Open(path string) (string, Manifest, error) {
+	manifest, ok := embeddedManifest(path)
+	if !ok {
+		return "", manifest, fmt.Errorf("%s is not a collidertracker demo", path)
+	}
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return "", manifest, err
+	}
+	defer r.Close()
+
+	dir, err := os.MkdirTemp("", "collidertracker-demo-")
+	if err != nil {
+		return "", manifest, err
+	}
+	name := filepath.Base(manifest.Project)
+	if name == "." || name == ".." || name == string(filepath.Separator) {
+		name = "demo"
+	}
+	projectDir := filepath.Join(dir, name)
+	for _, f := range r.File {
+		if f.Name == ManifestName || f.FileInfo().IsDir() {
+			continue
+		}
+		if err := extract(f, projectDir); err != nil {
+			os.RemoveAll(dir)
+			return "", manifest, fmt.Errorf("failed to unpack %s: %v", path, err)
+		}
+	}
+	return projectDir, manifest, nil
+}
+
+// extract writes an archived file under dir, refusing names that would land outside it
+func extract(f *zip.File, dir string) error {
+	dest := filepath.Join(dir, filepath.FromSlash(f.Name))
+	if rel, err := filepath.Rel(dir, dest); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("%s is outside the project", f.Name)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	src, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, src)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
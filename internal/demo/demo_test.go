@@ -0,0 +1,79 @@
+package demo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeProject makes a project folder with a song, a synth, a sample and a recording
+func writeProject(t *testing.T) string {
+	dir := filepath.Join(t.TempDir(), "song")
+	files := map[string]string{
+		"data.json.gz":          "song",
+		"data.json.gz.bak":      "old song",
+		"kick.wav":              "kick",
+		"kick.metadata.json":    "{}",
+		"synths/pad.scd":        "pad",
+		"impulses/hall.wav":     "hall",
+		"recordings/take-1.wav": "take",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestExportAndOpen(t *testing.T) {
+	project := writeProject(t)
+	out := t.TempDir()
+
+	t.Run("archive without samples", func(t *testing.T) {
+		path := filepath.Join(out, "song.zip")
+		assert.NoError(t, Export(project, path, false, "v1"))
+
+		dir, manifest, err := Open(path)
+		assert.NoError(t, err)
+		defer os.RemoveAll(filepath.Dir(dir))
+		assert.Equal(t, Manifest{Project: "song", Samples: false, Version: "v1"}, manifest)
+		assert.Equal(t, "song", filepath.Base(dir))
+		assert.FileExists(t, filepath.Join(dir, "data.json.gz"))
+		assert.FileExists(t, filepath.Join(dir, "synths", "pad.scd"))
+		assert.NoFileExists(t, filepath.Join(dir, "kick.wav"))
+		assert.NoFileExists(t, filepath.Join(dir, "impulses", "hall.wav"))
+		assert.NoFileExists(t, filepath.Join(dir, "data.json.gz.bak"))
+	})
+
+	t.Run("binary with samples", func(t *testing.T) {
+		path := filepath.Join(out, "song-demo")
+		assert.NoError(t, Export(project, path, true, "v1"))
+		info, err := os.Stat(path)
+		assert.NoError(t, err)
+		assert.NotZero(t, info.Mode()&0100, "The demo binary is executable")
+
+		dir, manifest, err := Open(path)
+		assert.NoError(t, err)
+		defer os.RemoveAll(filepath.Dir(dir))
+		assert.True(t, manifest.Samples)
+		content, err := os.ReadFile(filepath.Join(dir, "kick.wav"))
+		assert.NoError(t, err)
+		assert.Equal(t, "kick", string(content))
+		assert.FileExists(t, filepath.Join(dir, "kick.metadata.json"))
+		assert.FileExists(t, filepath.Join(dir, "impulses", "hall.wav"))
+		assert.NoFileExists(t, filepath.Join(dir, "recordings", "take-1.wav"))
+	})
+
+	t.Run("not a demo", func(t *testing.T) {
+		_, _, err := Open(filepath.Join(project, "kick.wav"))
+		assert.Error(t, err)
+		assert.False(t, Embedded(), "The test binary carries no demo")
+	})
+
+	t.Run("no song", func(t *testing.T) {
+		assert.Error(t, Export(t.TempDir(), filepath.Join(out, "empty.zip"), false, "v1"))
+	})
+}
@@ -15,25 +15,33 @@ import (
 )
 
 func GetBPM(name string) (beats float64, bpm float64, err error) {
-	beats, bpm, err = parseName(name)
+	duration, _, _, err := Length(name)
+	if err != nil {
+		return
+	}
+	beats, bpm = GetBPMOfLength(name, duration)
+	return
+}
+
+// GetBPMOfLength returns the beats and BPM of an audio file of the given duration in seconds:
+// from its name when the name has them, or else guessed from the duration. It does not read
+// the file, so it works for any format.
+func GetBPMOfLength(name string, duration float64) (beats float64, bpm float64) {
+	beats, bpm, err := parseName(name, duration)
 	nonSixteenBeats := math.Mod(beats, 16) != 0
 	if err != nil || bpm < 100 || bpm > 200 || nonSixteenBeats {
-		beats, bpm, err = guessBPM(name)
+		beats, bpm = guessBPM(duration)
 	}
 	return
 }
 
-func parseName(name string) (beats float64, bpm float64, err error) {
+func parseName(name string, duration float64) (beats float64, bpm float64, err error) {
 	_, fname := filepath.Split(name)
 	fname = strings.ToLower(fname)
 	rBeats, _ := regexp.Compile(`\w+[beats](\d+)`)
 	rBPM, _ := regexp.Compile(`\w+[bpm]([0-9]+)`)
 	rBPM2 := regexp.MustCompile("[0-9]+")
 	foo := rBPM.FindStringSubmatch(fname)
-	duration, _, _, err := Length(name)
-	if err != nil {
-		return
-	}
 
 	if len(foo) < 2 {
 		err = fmt.Errorf("could not find bpm: %s", name)
@@ -65,12 +73,7 @@ func parseName(name string) (beats float64, bpm float64, err error) {
 	return
 }
 
-func guessBPM(fname string) (beats float64, bpm float64, err error) {
-	duration, _, _, err := Length(fname)
-	if err != nil {
-		return
-	}
-
+func guessBPM(duration float64) (beats float64, bpm float64) {
 	multiple := 2.0
 	if os.Getenv("MULTIPLE") != "" {
 		multiple, _ = strconv.ParseFloat(os.Getenv("MULTIPLE"), 64)
@@ -0,0 +1,114 @@
+// Package i18n translates the user interface. Messages are looked up by their English text in
+// the catalog of the chosen locale, one JSON file per locale in the locales folder; text
+// missing from a catalog stays in English, so translations can be partial.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultLocale is the language the messages are written in
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// catalogs maps each translated locale to its messages, by English text
+var catalogs = map[string]map[string]string{}
+
+// current is the catalog in use, nil for English
+var current atomic.Pointer[map[string]string]
+
+// locale is the locale in use
+var locale atomic.Value
+
+func init() {
+	files, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("Failed to list locales: %v", err))
+	}
+	for _, file := range files {
+		data, err := localeFiles.ReadFile(path.Join("locales", file.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("Failed to read locale %s: %v", file.Name(), err))
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("Failed to load locale %s: %v", file.Name(), err))
+		}
+		catalogs[strings.TrimSuffix(file.Name(), ".json")] = messages
+	}
+	locale.Store(DefaultLocale)
+}
+
+// Locales returns the locales the interface is available in, English first
+func Locales() []string {
+	locales := make([]string, 0, len(catalogs)+1)
+	for name := range catalogs {
+		locales = append(locales, name)
+	}
+	sort.Strings(locales)
+	return append([]string{DefaultLocale}, locales...)
+}
+
+// SetLocale switches the interface to a locale such as "es" or "es_ES.UTF-8", falling back to
+// English for locales without a catalog. It reports whether the locale is available.
+func SetLocale(name string) bool {
+	name = baseLocale(name)
+	if name == DefaultLocale || name == "" {
+		current.Store(nil)
+		locale.Store(DefaultLocale)
+		return name == DefaultLocale
+	}
+	messages, ok := catalogs[name]
+	if !ok {
+		current.Store(nil)
+		locale.Store(DefaultLocale)
+		return false
+	}
+	current.Store(&messages)
+	locale.Store(name)
+	return true
+}
+
+// Locale returns the locale in use
+func Locale() string {
+	return locale.Load().(string)
+}
+
+// DetectLocale returns the locale of the environment (LC_ALL, LC_MESSAGES or LANG), or ""
+func DetectLocale() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(env); value != "" && value != "C" && value != "POSIX" {
+			return baseLocale(value)
+		}
+	}
+	return ""
+}
+
+// baseLocale reduces a locale such as "es_ES.UTF-8" to its language
+func baseLocale(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if i := strings.IndexAny(name, "_-.@"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// T translates a message to the locale in use. Messages with fmt verbs are translated before
+// formatting, so translations keep the verbs in the same order.
+func T(message string) string {
+	if messages := current.Load(); messages != nil {
+		if translated, ok := (*messages)[message]; ok && translated != "" {
+			return translated
+		}
+	}
+	return message
+}
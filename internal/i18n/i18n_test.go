@@ -0,0 +1,63 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetLocale(t *testing.T) {
+	defer SetLocale(DefaultLocale)
+
+	assert.Equal(t, []string{"en", "es"}, Locales())
+	assert.Equal(t, "Notes", T("Notes"))
+
+	assert.True(t, SetLocale("es_ES.UTF-8"))
+	assert.Equal(t, "es", Locale())
+	assert.Equal(t, "Notas", T("Notes"))
+	assert.Equal(t, "not in the catalog", T("not in the catalog"), "Missing messages stay in English")
+
+	assert.False(t, SetLocale("xx"))
+	assert.Equal(t, DefaultLocale, Locale())
+	assert.Equal(t, "Notes", T("Notes"))
+}
+
+func TestDetectLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "C")
+	t.Setenv("LANG", "es_MX.UTF-8")
+	assert.Equal(t, "es", DetectLocale())
+	t.Setenv("LANG", "")
+	assert.Equal(t, "", DetectLocale())
+}
+
+// fmtVerb matches the fmt verbs of a message
+var fmtVerb = regexp.MustCompile(`%[-+# 0]*\d*(\.\d+)?[a-zA-Z%]`)
+
+func TestCatalogs(t *testing.T) {
+	// Translations keep the fmt verbs of their message, in order
+	for locale, messages := range catalogs {
+		for message, translated := range messages {
+			assert.Equal(t, fmtVerb.FindAllString(message, -1), fmtVerb.FindAllString(translated, -1),
+				"%s: %q", locale, message)
+		}
+	}
+
+	// Every message the views translate has a Spanish translation
+	files, err := filepath.Glob(filepath.Join("..", "views", "*.go"))
+	assert.NoError(t, err)
+	call := regexp.MustCompile(`i18n\.T\(("(?:[^"\\]|\\.)*")\)`)
+	for _, file := range files {
+		source, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		for _, match := range call.FindAllStringSubmatch(string(source), -1) {
+			message, err := strconv.Unquote(match[1])
+			assert.NoError(t, err)
+			assert.Contains(t, catalogs["es"], message, filepath.Base(file))
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package input
+
+import (
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/audio"
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// AnalysisDoneMsg carries the result of analyzing one file of a batch back to the UI goroutine
+type AnalysisDoneMsg struct {
+	Job      *model.SampleAnalysis // Batch the file belongs to
+	File     string                // File analyzed
+	Metadata types.FileMetadata    // Metadata detected for it
+	Err      error                 // Why the analysis failed (nil if it worked)
+}
+
+// ToggleSampleAnalysis re-analyzes the BPM and slices of every sample of the project (in the
+// file browser, of the project samples in the folder being browsed) one file at a time in the
+// background, or cancels the analysis in progress. In eco mode the analysis waits for it to end.
+func ToggleSampleAnalysis(m *model.Model) tea.Cmd {
+	if m.Analysis != nil {
+		logging.Storage.Debugf("Sample analysis cancelled after %d of %d files", m.Analysis.Done, len(m.Analysis.Files))
+		m.Notice = fmt.Sprintf("Sample analysis cancelled after %d of %d files", m.Analysis.Done, len(m.Analysis.Files))
+		m.Analysis = nil
+		return nil
+	}
+
+	folder := ""
+	if m.ViewMode == types.FileView {
+		folder = m.CurrentDir
+	}
+	files := m.ProjectSampleFiles(folder)
+	if len(files) == 0 {
+		m.Notice = "No project samples to analyze"
+		if folder != "" {
+			m.Notice = "No project samples in " + filepath.Base(folder)
+		}
+		return nil
+	}
+
+	snapshotBefore(m, "sample analysis")
+	m.Analysis = &model.SampleAnalysis{Files: files}
+	logging.Storage.Debugf("Sample analysis started: %d files", len(files))
+	if m.EcoMode {
+		m.Analysis.Paused = true
+		m.Notice = "Sample analysis paused until eco mode is off"
+		return nil
+	}
+	return analyzeNextSample(m)
+}
+
+// analyzeNextSample returns a command that analyzes the next file of the batch off the UI goroutine
+func analyzeNextSample(m *model.Model) tea.Cmd {
+	job := m.Analysis
+	file := job.Files[job.Done]
+	metadata, hasMetadata := m.FileMetadata[file]
+	projectDir := m.ProjectFolder()
+	return func() tea.Msg {
+		analyzed, err := audio.AnalyzeFile(file, metadata, hasMetadata, projectDir)
+		return AnalysisDoneMsg{Job: job, File: file, Metadata: analyzed, Err: err}
+	}
+}
+
+// HandleAnalysisDone stores the metadata detected for a file and starts on the next one, unless
+// eco mode pauses the batch. When the batch is complete it reports how it went and saves.
+func HandleAnalysisDone(m *model.Model, msg AnalysisDoneMsg) tea.Cmd {
+	job := m.Analysis
+	if job == nil || msg.Job != job {
+		return nil // Cancelled
+	}
+	if msg.Err != nil {
+		job.Failed++
+		logging.Storage.Warnf("Sample analysis failed for %s: %v", msg.File, msg.Err)
+	} else {
+		m.FileMetadata[msg.File] = msg.Metadata
+		logging.Storage.Debugf("Sample analysis: %s %.2f BPM, %d slices", filepath.Base(msg.File), msg.Metadata.BPM, len(msg.Metadata.Onsets))
+	}
+	job.Done++
+	if job.Done < len(job.Files) {
+		if m.EcoMode {
+			job.Paused = true
+			return nil
+		}
+		return analyzeNextSample(m)
+	}
+
+	m.Analysis = nil
+	m.Notice = fmt.Sprintf("Analyzed %d samples", job.Done-job.Failed)
+	if job.Failed > 0 {
+		m.Notice += fmt.Sprintf(", %d failed", job.Failed)
+	}
+	logging.Storage.Debugf("Sample analysis finished: %d files, %d failed", job.Done, job.Failed)
+	m.Publish(model.Event{Kind: model.EventSettings})
+	return nil
+}
@@ -0,0 +1,173 @@
+package input
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// trackBankMoves maps the chains and phrases a track uses outside its bank to free IDs inside it
+type trackBankMoves struct {
+	chains  map[int]int
+	phrases map[int]int
+}
+
+// SetTrackBanks switches between a chain and phrase pool shared by all tracks and per-track
+// banks. Switching to per-track banks first moves every chain and phrase a track uses into
+// its own bank (chains and phrases used by several tracks are copied into each), and keeps
+// the shared pool when a bank has too few free IDs. It reports whether the mode changed.
+func SetTrackBanks(m *model.Model, perTrack bool) bool {
+	if m.PerTrackBanks == perTrack {
+		return false
+	}
+	if perTrack {
+		m.PerTrackBanks = true // Moves are planned against the per-track bank ranges
+		moves, track, ok := planTrackBanks(m)
+		m.PerTrackBanks = false
+		if !ok {
+			m.Notice = fmt.Sprintf("Track %d uses more chains or phrases than fit in its bank", track+1)
+			return false
+		}
+		snapshotBefore(m, "bank migration")
+		applyTrackBanks(m, moves)
+	}
+	m.PerTrackBanks = perTrack
+	logging.UI.Debugf("Chain and phrase banks: %s", model.BankModeName(perTrack))
+	m.Notice = "Banks: " + model.BankModeName(perTrack)
+	m.Publish(model.Event{Kind: model.EventSettings})
+	return true
+}
+
+// planTrackBanks plans moving the chains and phrases of every track into its bank without
+// changing anything, or returns the first track whose bank is too small
+func planTrackBanks(m *model.Model) ([]trackBankMoves, int, bool) {
+	currentTrack := m.CurrentTrack
+	defer func() { m.CurrentTrack = currentTrack }()
+
+	moves := make([]trackBankMoves, types.NumTracks)
+	for track := range moves {
+		m.CurrentTrack = track // The unused checks follow the current track's pool
+		chains, phrases := trackChainsAndPhrases(m, track)
+		var chainsOK, phrasesOK bool
+		moves[track].chains, chainsOK = planBankMoves(m, track, chains, IsChainUnused)
+		moves[track].phrases, phrasesOK = planBankMoves(m, track, phrases, IsPhraseUnused)
+		if !chainsOK || !phrasesOK {
+			return nil, track, false
+		}
+	}
+	return moves, -1, true
+}
+
+// applyTrackBanks moves the chains and phrases of every track into its bank as planned
+func applyTrackBanks(m *model.Model, moves []trackBankMoves) {
+	currentTrack := m.CurrentTrack
+	defer func() { m.CurrentTrack = currentTrack }()
+
+	// Chains are copied from the originals before any chain is rewritten, so a chain several
+	// tracks share is copied the same way into each bank
+	for track, move := range moves {
+		for chain, newChain := range move.chains {
+			for slot := 0; slot < types.ChainRows; slot++ {
+				m.SetChainCell(track, newChain, slot, m.GetChainCell(track, chain, slot))
+				m.SetChainTranspose(track, newChain, slot, m.GetChainTranspose(track, chain, slot))
+				m.SetChainFX(track, newChain, slot, m.GetChainFX(track, chain, slot))
+			}
+		}
+		for row := 0; row < types.SongRows; row++ {
+			if newChain, ok := move.chains[m.GetSongCell(track, row)]; ok {
+				m.SetSongCell(track, row, newChain)
+			}
+		}
+	}
+	for track, move := range moves {
+		for phrase, newPhrase := range move.phrases {
+			copyPhrase(m, track, phrase, newPhrase)
+		}
+		chains, _ := trackChainsAndPhrases(m, track)
+		for chain := range chains {
+			for slot := 0; slot < types.ChainRows; slot++ {
+				if newPhrase, ok := move.phrases[m.GetChainCell(track, chain, slot)]; ok {
+					m.SetChainCell(track, chain, slot, newPhrase)
+				}
+			}
+		}
+	}
+
+	// Free the originals nothing uses any more, so they do not fill another track's bank
+	for track, move := range moves {
+		m.CurrentTrack = track
+		for chain := range move.chains {
+			if !chainInSong(m, chain) {
+				for slot := 0; slot < types.ChainRows; slot++ {
+					m.SetChainCell(track, chain, slot, -1)
+				}
+			}
+		}
+	}
+	for track, move := range moves {
+		m.CurrentTrack = track
+		for phrase := range move.phrases {
+			if IsPhraseUnused(m, phrase) || phraseInPool(m, phrase) {
+				continue
+			}
+			for row := 0; row < types.PhraseRows; row++ {
+				m.SetPhraseCell(track, phrase, row, types.ColDeltaTime, -1)
+			}
+		}
+	}
+}
+
+// planBankMoves picks a free ID in a track's bank for every used chain or phrase outside it
+func planBankMoves(m *model.Model, track int, used map[int]bool, isUnused func(*model.Model, int) bool) (map[int]int, bool) {
+	first, last := m.BankRange(track)
+	var free []int
+	for id := first; id <= last; id++ {
+		if !used[id] && isUnused(m, id) {
+			free = append(free, id)
+		}
+	}
+	moves := make(map[int]int)
+	ids := make([]int, 0, len(used))
+	for id := range used {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		if m.InBank(track, id) {
+			continue
+		}
+		if len(free) == 0 {
+			return nil, false
+		}
+		moves[id] = free[0]
+		free = free[1:]
+	}
+	return moves, true
+}
+
+// chainInSong reports whether any track's song column uses a chain
+func chainInSong(m *model.Model, chain int) bool {
+	for track := 0; track < types.NumTracks; track++ {
+		for row := 0; row < types.SongRows; row++ {
+			if m.GetSongCell(track, row) == chain {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// phraseInPool reports whether a chain of the current track's pool uses a phrase
+func phraseInPool(m *model.Model, phrase int) bool {
+	for chain := 0; chain < types.NumChains; chain++ {
+		for row := 0; row < types.ChainRows; row++ {
+			if m.GetChainCell(m.CurrentTrack, chain, row) == phrase {
+				return true
+			}
+		}
+	}
+	return false
+}
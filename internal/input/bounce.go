@@ -0,0 +1,175 @@
+package input
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/loudness"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// bounceTail is how long recording continues after the last repetition (release and reverb)
+const bounceTail = 2 * time.Second
+
+// loudnessDelay gives SuperCollider time to close a finished bounce before it is measured
+const loudnessDelay = time.Second
+
+// BounceTailDoneMsg ends a loop bounce once the tail has been recorded
+type BounceTailDoneMsg struct{}
+
+// LoudnessDoneMsg carries the loudness of a finished loop bounce
+type LoudnessDoneMsg struct {
+	File          string
+	Report        loudness.Report
+	Normalization *loudness.Normalization // nil when the bounce was left as rendered
+	Err           error
+}
+
+// ToggleLoopBounce plays the phrase or chain being viewed BounceRepeats times into a WAV
+// in the recordings folder, or cancels a bounce in progress
+func ToggleLoopBounce(m *model.Model) tea.Cmd {
+	if m.Bounce != nil {
+		logging.Storage.Debugf("Loop bounce cancelled: %s", m.Bounce.File)
+		if m.IsPlaying {
+			stopPlayback(m) // also finishes the bounce
+		}
+		if m.Bounce != nil {
+			finishLoopBounce(m)
+		}
+		return nil
+	}
+	if m.ViewMode != types.PhraseView && m.ViewMode != types.ChainView {
+		return nil
+	}
+	if m.SessionRecording || m.SessionPunchArmed {
+		logging.Storage.Debugf("Loop bounce unavailable while session recording")
+		return nil
+	}
+	loopTicks := m.CurrentLoopTicks()
+	if loopTicks <= 0 {
+		logging.Storage.Debugf("Nothing to bounce: loop is empty")
+		return nil
+	}
+
+	// Start from a clean transport
+	if m.IsPlaying {
+		stopPlayback(m)
+	}
+
+	kind, id := "phrase", m.CurrentPhrase
+	if m.ViewMode == types.ChainView {
+		kind, id = "chain", m.CurrentChain
+	}
+	if err := os.MkdirAll(m.RecordingsFolder(), 0755); err != nil {
+		logging.Storage.Errorf("Error creating recordings folder: %v", err)
+	}
+	filename := filepath.Join(m.RecordingsFolder(),
+		fmt.Sprintf("loop-%s%02X-%s.wav", kind, id, time.Now().Format("2006-01-02-15-04-05")))
+
+	m.Bounce = &model.LoopBounce{File: filename, TicksLeft: m.BounceRepeats * loopTicks}
+	m.SendOSCSessionRecordMessage(filename, true)
+	logging.Storage.Debugf("Loop bounce started: %s (%d x %d ticks)", filename, m.BounceRepeats, loopTicks)
+	return TogglePlaybackFromTop(m)
+}
+
+// ProcessLoopBounce counts down a bounce for each playback tick. After the last
+// repetition it stops playback and returns a command that ends the recording after the tail.
+func ProcessLoopBounce(m *model.Model) tea.Cmd {
+	if m.Bounce == nil || m.Bounce.Tail {
+		return nil
+	}
+	m.Bounce.TicksLeft--
+	if m.Bounce.TicksLeft > 0 {
+		return nil
+	}
+	if m.Bounce.Clip {
+		// A clip ends with the loop so it repeats seamlessly
+		logging.Storage.Debugf("Exported audio clip %s", m.Bounce.File)
+		m.Notice = "Exported " + filepath.Base(m.Bounce.File)
+		stopPlayback(m) // also finishes the bounce
+		return nil
+	}
+	m.Bounce.Tail = true
+	stopPlayback(m)
+	return tea.Tick(bounceTail, func(time.Time) tea.Msg {
+		return BounceTailDoneMsg{}
+	})
+}
+
+// HandleBounceTailDone ends the bounce recording once the tail has been captured, and
+// returns a command measuring the loudness of a loop bounce
+func HandleBounceTailDone(m *model.Model) tea.Cmd {
+	if m.Bounce == nil || !m.Bounce.Tail {
+		return nil
+	}
+	file, printed := m.Bounce.File, m.Bounce.Print
+	if printed {
+		m.AddPrintToSamples(file)
+	}
+	finishLoopBounce(m)
+	if printed {
+		return nil
+	}
+	return measureBounce(m, file)
+}
+
+// measureBounce returns a command that measures a finished bounce off the UI goroutine,
+// normalizes it to the project's loudness target and writes its loudness report
+func measureBounce(m *model.Model, file string) tea.Cmd {
+	target, normalize := m.BounceTarget()
+	return tea.Tick(loudnessDelay, func(time.Time) tea.Msg {
+		report, err := loudness.MeasureFile(file)
+		if err != nil {
+			return LoudnessDoneMsg{File: file, Err: err}
+		}
+		var normalization *loudness.Normalization
+		if normalize {
+			gain, limited := loudness.Gain(report, target.LUFS, model.TruePeakCeiling)
+			normalization = &loudness.Normalization{Target: target.Name, TargetLUFS: target.LUFS, Gain: gain, Limited: limited, Ceiling: model.TruePeakCeiling}
+			if gain != 0 {
+				if err := loudness.NormalizeFile(file, gain); err != nil {
+					return LoudnessDoneMsg{File: file, Err: err}
+				}
+			}
+		}
+		err = loudness.WriteReport(file, report, normalization)
+		return LoudnessDoneMsg{File: file, Report: report, Normalization: normalization, Err: err}
+	})
+}
+
+// HandleLoudnessDone shows the loudness of a finished bounce
+func HandleLoudnessDone(m *model.Model, msg LoudnessDoneMsg) {
+	name := filepath.Base(msg.File)
+	if msg.Err != nil {
+		logging.Storage.Errorf("Error measuring the loudness of %s: %v", msg.File, msg.Err)
+		m.Notice = fmt.Sprintf("Could not measure %s: %v", name, msg.Err)
+		return
+	}
+	integrated, peak := msg.Report.IntegratedLUFS, msg.Report.TruePeakDBTP
+	level := fmt.Sprintf("%s LUFS, %s dBTP", loudness.FormatLevel(integrated), loudness.FormatLevel(peak))
+	if n := msg.Normalization; n != nil {
+		level = fmt.Sprintf("%s LUFS, %s dBTP after %+.1f dB", loudness.FormatLevel(integrated+n.Gain), loudness.FormatLevel(peak+n.Gain), n.Gain)
+		if n.Limited {
+			level += " (peak limited)"
+		}
+	}
+	logging.Storage.Debugf("Loop bounce %s: %s", msg.File, level)
+	m.Notice = fmt.Sprintf("Bounced %s: %s", name, level)
+}
+
+// finishLoopBounce stops the bounce recording (also when playback is stopped by hand)
+func finishLoopBounce(m *model.Model) {
+	if m.Bounce.Print {
+		m.SendOSCPrintMessage(m.Bounce.Track, m.Bounce.File, false)
+	} else {
+		m.SendOSCSessionRecordMessage(m.Bounce.File, false)
+	}
+	logging.Storage.Debugf("Loop bounce finished: %s", m.Bounce.File)
+	m.Bounce = nil
+}
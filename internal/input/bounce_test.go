@@ -0,0 +1,122 @@
+package input
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/schollz/collidertracker/internal/loudness"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+func TestLoopBounce(t *testing.T) {
+	m := createTestModel(t)
+	m.SaveFolder = t.TempDir()
+	m.ViewMode = types.PhraseView
+	m.CurrentTrack = 4
+	m.CurrentPhrase = 2
+	m.BounceRepeats = 2
+	phrasesData := m.GetCurrentPhrasesData()
+	(*phrasesData)[2][0][types.ColNote] = 60
+	(*phrasesData)[2][0][types.ColDeltaTime] = 2
+	(*phrasesData)[2][1][types.ColNote] = 62
+	(*phrasesData)[2][1][types.ColDeltaTime] = 3
+	assert.Equal(t, 5, m.CurrentLoopTicks())
+
+	ToggleLoopBounce(m)
+	assert.NotNil(t, m.Bounce)
+	assert.True(t, m.IsPlaying)
+	assert.Contains(t, m.Bounce.File, "loop-phrase02-")
+	assert.Equal(t, 10, m.Bounce.TicksLeft, "Two repetitions of five ticks")
+
+	for i := 0; i < 9; i++ {
+		assert.Nil(t, ProcessLoopBounce(m))
+	}
+	assert.NotNil(t, ProcessLoopBounce(m), "Last tick schedules the tail")
+	assert.False(t, m.IsPlaying)
+	assert.True(t, m.Bounce.Tail)
+
+	assert.NotNil(t, HandleBounceTailDone(m), "Finished bounces are measured")
+	assert.Nil(t, m.Bounce)
+}
+
+func TestLoudnessDone(t *testing.T) {
+	m := createTestModel(t)
+	file := filepath.Join(t.TempDir(), "loop-phrase02.wav")
+
+	HandleLoudnessDone(m, LoudnessDoneMsg{File: file, Report: loudness.Report{IntegratedLUFS: -17.26, TruePeakDBTP: -3.04}})
+	assert.Equal(t, "Bounced loop-phrase02.wav: -17.3 LUFS, -3.0 dBTP", m.Notice)
+
+	normalization := &loudness.Normalization{Target: "stream", TargetLUFS: -14, Gain: 2.04, Limited: true, Ceiling: -1}
+	HandleLoudnessDone(m, LoudnessDoneMsg{File: file, Report: loudness.Report{IntegratedLUFS: -17.26, TruePeakDBTP: -3.04}, Normalization: normalization})
+	assert.Equal(t, "Bounced loop-phrase02.wav: -15.2 LUFS, -1.0 dBTP after +2.0 dB (peak limited)", m.Notice)
+
+	m.BounceNormalize = 1
+	target, ok := m.BounceTarget()
+	assert.True(t, ok)
+	assert.Equal(t, -14.0, target.LUFS)
+	assert.Equal(t, "-14 LUFS", m.BounceTargetName())
+}
+
+func TestLoopBounceStoppedByHand(t *testing.T) {
+	m := createTestModel(t)
+	m.SaveFolder = t.TempDir()
+	m.ViewMode = types.PhraseView
+	m.CurrentTrack = 4
+	m.CurrentPhrase = 2
+	phrasesData := m.GetCurrentPhrasesData()
+	(*phrasesData)[2][0][types.ColNote] = 60
+	(*phrasesData)[2][0][types.ColDeltaTime] = 4
+
+	ToggleLoopBounce(m)
+	assert.NotNil(t, m.Bounce)
+	ToggleLoopBounce(m)
+	assert.Nil(t, m.Bounce, "Second press cancels the bounce")
+	assert.False(t, m.IsPlaying)
+
+	// Empty loops are not bounced
+	m.CurrentPhrase = 3
+	ToggleLoopBounce(m)
+	assert.Nil(t, m.Bounce)
+}
+
+func TestInstrumentPrint(t *testing.T) {
+	m := createTestModel(t)
+	m.SaveFolder = t.TempDir()
+	m.ViewMode = types.PhraseView
+	m.CurrentTrack = 0
+	m.TrackTypes[0] = false
+	m.CurrentPhrase = 1
+	m.SoundMakerSettings[3].Name = "Infinite Pad"
+	m.InstrumentPhrasesData[1][0][types.ColNote] = 60
+	m.InstrumentPhrasesData[1][0][types.ColDeltaTime] = 4
+	m.InstrumentPhrasesData[1][0][types.ColSoundMaker] = 3
+
+	ToggleInstrumentPrint(m)
+	assert.NotNil(t, m.Bounce)
+	assert.True(t, m.Bounce.Print)
+	assert.Equal(t, 0, m.Bounce.Track)
+	assert.Equal(t, 4, m.Bounce.TicksLeft, "A print plays the phrase once")
+	assert.Contains(t, m.Bounce.File, "print-infinite-pad-phrase01-")
+	file := m.Bounce.File
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, ProcessLoopBounce(m))
+	}
+	assert.NotNil(t, ProcessLoopBounce(m))
+	assert.Nil(t, HandleBounceTailDone(m), "Prints are not measured")
+	assert.Nil(t, m.Bounce)
+	assert.Contains(t, m.SamplerPhrasesFiles, file, "Finished print is added to the sampler files")
+	assert.Equal(t, 1, m.FileMetadata[file].Playthrough, "Prints play as one-shots")
+
+	// A cancelled print is not added, and sampler tracks cannot be printed
+	m.SamplerPhrasesFiles = nil
+	ToggleInstrumentPrint(m)
+	ToggleInstrumentPrint(m)
+	assert.Nil(t, m.Bounce)
+	assert.Empty(t, m.SamplerPhrasesFiles)
+	m.TrackTypes[0] = true
+	ToggleInstrumentPrint(m)
+	assert.Nil(t, m.Bounce)
+}
@@ -0,0 +1,39 @@
+package input
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// openClipboardPicker starts choosing an older clipboard entry to paste
+func openClipboardPicker(m *model.Model) {
+	if len(m.ClipboardHistory) == 0 {
+		return
+	}
+	m.PickingClipboard = true
+	m.ClipboardPick = 0
+}
+
+// handleClipboardPickerKey moves through the clipboard history: enter pastes the chosen
+// entry (which becomes the clipboard), esc closes the picker
+func handleClipboardPickerKey(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		if m.ClipboardPick > 0 {
+			m.ClipboardPick--
+		}
+	case "down", "j":
+		if m.ClipboardPick < len(m.ClipboardHistory)-1 {
+			m.ClipboardPick++
+		}
+	case "enter":
+		m.PickingClipboard = false
+		if m.UseClipboardHistory(m.ClipboardPick) {
+			PasteFromClipboard(m)
+		}
+	case "esc", "V":
+		m.PickingClipboard = false
+	}
+	return nil
+}
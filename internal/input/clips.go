@@ -0,0 +1,62 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// ExportPhraseClip exports the phrase being viewed to the clips folder: an instrument phrase
+// as a MIDI clip, a sampler phrase as an audio loop of one pass recorded from its track alone
+func ExportPhraseClip(m *model.Model) tea.Cmd {
+	if m.ViewMode != types.PhraseView || m.Bounce != nil {
+		return nil
+	}
+	if err := os.MkdirAll(m.ClipsFolder(), 0755); err != nil {
+		logging.Storage.Errorf("Error creating clips folder: %v", err)
+		return nil
+	}
+
+	if m.GetPhraseViewType() == types.InstrumentPhraseView {
+		data, ok, err := m.PhraseMIDIClip(m.CurrentTrack, m.CurrentPhrase)
+		if err != nil {
+			logging.MIDI.Errorf("Error rendering MIDI clip: %v", err)
+			return nil
+		}
+		if !ok {
+			m.Notice = "Nothing to export: the phrase plays no notes"
+			return nil
+		}
+		filename := m.ClipFileName(m.CurrentTrack, m.CurrentPhrase, ".mid")
+		if err := os.WriteFile(filename, data, 0644); err != nil {
+			logging.MIDI.Errorf("Error writing MIDI clip: %v", err)
+			return nil
+		}
+		logging.MIDI.Debugf("Exported MIDI clip %s", filename)
+		m.Notice = "Exported " + filepath.Base(filename)
+		return nil
+	}
+
+	if m.SessionRecording || m.SessionPunchArmed {
+		logging.Storage.Debugf("Clip export unavailable while session recording")
+		return nil
+	}
+	loopTicks := m.CurrentLoopTicks()
+	if loopTicks <= 0 {
+		m.Notice = "Nothing to export: the phrase is empty"
+		return nil
+	}
+	if m.IsPlaying {
+		stopPlayback(m)
+	}
+	filename := m.ClipFileName(m.CurrentTrack, m.CurrentPhrase, ".wav")
+	m.Bounce = &model.LoopBounce{File: filename, TicksLeft: loopTicks, Print: true, Track: m.CurrentTrack, Clip: true}
+	m.SendOSCPrintMessage(m.CurrentTrack, filename, true)
+	logging.Storage.Debugf("Audio clip export started: %s (track %d, %d ticks)", filename, m.CurrentTrack+1, loopTicks)
+	return TogglePlaybackFromTop(m)
+}
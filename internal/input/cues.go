@@ -0,0 +1,38 @@
+package input
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// startCueEdit starts naming the cue on the song row under the cursor
+func startCueEdit(m *model.Model) {
+	if m.ViewMode != types.SongView || m.CurrentRow < 0 || m.CurrentRow >= types.SongRows {
+		return
+	}
+	m.EditingCue = true
+	m.CueBuffer = m.SongCues[m.CurrentRow]
+}
+
+// handleCueKey edits the cue name: enter applies it (an empty name removes the cue), esc cancels
+func handleCueKey(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.EditingCue = false
+		m.SetCue(m.CurrentRow, m.CueBuffer)
+	case tea.KeyEsc:
+		m.EditingCue = false
+	case tea.KeyBackspace:
+		if len(m.CueBuffer) > 0 {
+			runes := []rune(m.CueBuffer)
+			m.CueBuffer = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		if len([]rune(m.CueBuffer))+len(msg.Runes) <= model.MaxCueNameLength {
+			m.CueBuffer += string(msg.Runes)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package input
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// toggleEcoMode turns eco mode on or off, resuming a sample analysis or library scan it paused
+func toggleEcoMode(m *model.Model) tea.Cmd {
+	m.ToggleEcoMode()
+	if m.EcoMode {
+		return nil
+	}
+	var cmds []tea.Cmd
+	if m.Analysis != nil && m.Analysis.Paused {
+		m.Analysis.Paused = false
+		cmds = append(cmds, analyzeNextSample(m))
+	}
+	if m.LibraryScan != nil && m.LibraryScan.Paused {
+		m.LibraryScan.Paused = false
+		cmds = append(cmds, analyzeNextLibraryFile(m))
+	}
+	return tea.Batch(cmds...)
+}
@@ -2,7 +2,9 @@ package input
 
 import (
 	"fmt"
+	"path/filepath"
 
+	"github.com/schollz/collidertracker/internal/logging"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/storage"
 	"github.com/schollz/collidertracker/internal/types"
@@ -67,14 +69,23 @@ func ModifyFileMetadataValue(m *model.Model, delta float32) {
 		)
 		modifyValueWithBounds(modifier, delta)
 
-	case types.FileMetadataRowPlaythrough: // Playthrough (0=Sliced, 1=Oneshot, 2=Slice Bounce, 3=Slice Stop)
+	case types.FileMetadataRowPlaythrough: // Playthrough (0=Sliced, 1=Oneshot, 2=Slice Bounce, 3=Slice Stop, 4=Kit)
 		modifier := createIntModifier(
 			func() int { return metadata.Playthrough },
 			func(v int) {
 				metadata.Playthrough = v
 				m.FileMetadata[m.MetadataEditingFile] = metadata
+				// The first switch to Kit builds the kit from the audio files next to this one
+				if v == types.PlaythroughKit && len(metadata.Kit) == 0 {
+					kitFiles, err := storage.ListAudioFiles(filepath.Dir(m.MetadataEditingFile))
+					if err != nil {
+						logging.Storage.Errorf("Error building kit for %s: %v", m.MetadataEditingFile, err)
+						return
+					}
+					m.SetKit(m.MetadataEditingFile, kitFiles)
+				}
 			},
-			0, 3, fmt.Sprintf("file metadata Playthrough for %s", m.MetadataEditingFile),
+			0, types.PlaythroughKit, fmt.Sprintf("file metadata Playthrough for %s", m.MetadataEditingFile),
 		)
 		modifyValueWithBounds(modifier, delta)
 
@@ -88,7 +99,22 @@ func ModifyFileMetadataValue(m *model.Model, delta float32) {
 			0, 1, fmt.Sprintf("file metadata SyncToBPM for %s", m.MetadataEditingFile),
 		)
 		modifyValueWithBounds(modifier, delta)
+
+	case types.FileMetadataRowOnsetPreset: // Onset Preset (0=Drums, 1=Melodic, 2=Vocal, 3=Ambient)
+		modifier := createIntModifier(
+			func() int { return metadata.OnsetPreset },
+			func(v int) {
+				metadata.OnsetPreset = v
+				m.FileMetadata[m.MetadataEditingFile] = metadata
+				// Slice again with the new preset if in Onsets mode
+				if metadata.SliceType == 1 {
+					m.TriggerOnsetDetection(m.MetadataEditingFile)
+				}
+			},
+			0, len(model.OnsetPresets)-1, fmt.Sprintf("file metadata OnsetPreset for %s", m.MetadataEditingFile),
+		)
+		modifyValueWithBounds(modifier, delta)
 	}
 
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventSettings})
 }
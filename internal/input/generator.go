@@ -0,0 +1,80 @@
+package input
+
+import (
+	"fmt"
+	"math/rand"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// openGenerator opens the phrase generator on the phrase being edited
+func openGenerator(m *model.Model) {
+	if m.ViewMode == types.PhraseView {
+		m.OpenGenerator()
+	}
+}
+
+// handleGeneratorInput handles keys in the phrase generator: the settings, locking rows of the
+// phrase, generating it and going back to the phrase
+func handleGeneratorInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	g := &m.GeneratorState
+	switch msg.String() {
+	case "ctrl+q", "alt+q":
+		return requestQuit(m)
+	case "esc", "q", "F", "shift+left":
+		m.ViewMode = types.PhraseView
+	case "tab":
+		g.OnRows = !g.OnRows
+	case "up", "k":
+		if g.OnRows {
+			g.PhraseRow = max(0, g.PhraseRow-1)
+		} else {
+			g.Row = max(0, g.Row-1)
+		}
+	case "down", "j":
+		if g.OnRows {
+			g.PhraseRow = min(max(0, m.Generator.Length-1), g.PhraseRow+1)
+		} else {
+			g.Row = min(model.GeneratorRowCount-1, g.Row+1)
+		}
+	case "left", "right":
+		if g.OnRows {
+			return nil
+		}
+		delta := 1
+		if msg.String() == "left" {
+			delta = -1
+		}
+		m.ChangeGeneratorSetting(g.Row, delta)
+		g.PhraseRow = min(g.PhraseRow, max(0, m.Generator.Length-1))
+		m.Publish(model.Event{Kind: model.EventSettings})
+	case "x":
+		if g.OnRows {
+			m.ToggleGeneratorLock()
+		}
+	case "enter", "g":
+		generatePhrase(m)
+	case " ":
+		return TogglePlaybackFromTop(m) // Plays the phrase from its first row
+	}
+	return nil
+}
+
+// generatePhrase re-rolls the generator's phrase. The first roll after opening the generator
+// takes a snapshot, so R brings back the phrase as it was.
+func generatePhrase(m *model.Model) {
+	if !m.GeneratorState.Rolled {
+		snapshotBefore(m, "phrase generator")
+		m.GeneratorState.Rolled = true
+	}
+	playing, err := m.GeneratePhrase(rand.Int63())
+	if err != nil {
+		m.Notice = fmt.Sprintf("Cannot generate phrase %02X: %v", m.GeneratorState.Phrase, err)
+		return
+	}
+	m.Notice = fmt.Sprintf("Phrase %02X generated: %d of %d rows play", m.GeneratorState.Phrase, playing, m.Generator.Length)
+	m.Publish(model.Event{Kind: model.EventSettings})
+}
@@ -2,23 +2,23 @@ package input
 
 import (
 	"fmt"
-	"log"
 	"math"
 	"math/rand"
-	"time"
+	"path/filepath"
+	"slices"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/schollz/collidertracker/internal/getbpm"
+	"github.com/schollz/collidertracker/internal/logging"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/modulation"
-	"github.com/schollz/collidertracker/internal/storage"
 	"github.com/schollz/collidertracker/internal/types"
 )
 
 // GetPhrasesDataForTrack returns the appropriate phrases data based on track type
-func GetPhrasesDataForTrack(m *model.Model, track int) *[255][][]int {
-	if track >= 0 && track < 8 && !m.TrackTypes[track] {
+func GetPhrasesDataForTrack(m *model.Model, track int) *[types.NumPhrases][][]int {
+	if track >= 0 && track < types.NumTracks && !m.TrackTypes[track] {
 		// TrackTypes[track] = false means Instrument
 		return &m.InstrumentPhrasesData
 	}
@@ -28,7 +28,7 @@ func GetPhrasesDataForTrack(m *model.Model, track int) *[255][][]int {
 
 // GetChainsDataForTrack returns the appropriate chains data based on track type
 func GetChainsDataForTrack(m *model.Model, track int) *[][]int {
-	if track >= 0 && track < 8 && !m.TrackTypes[track] {
+	if track >= 0 && track < types.NumTracks && !m.TrackTypes[track] {
 		// TrackTypes[track] = false means Instrument
 		return &m.InstrumentChainsData
 	}
@@ -37,8 +37,8 @@ func GetChainsDataForTrack(m *model.Model, track int) *[][]int {
 }
 
 // GetModulateSettingsForTrack returns the appropriate modulate settings based on track type
-func GetModulateSettingsForTrack(m *model.Model, track int) *[255]types.ModulateSettings {
-	if track >= 0 && track < 8 && !m.TrackTypes[track] {
+func GetModulateSettingsForTrack(m *model.Model, track int) *[types.NumSettings]types.ModulateSettings {
+	if track >= 0 && track < types.NumTracks && !m.TrackTypes[track] {
 		// TrackTypes[track] = false means Instrument
 		return &m.InstrumentModulateSettings
 	}
@@ -65,7 +65,7 @@ func modifyValueWithBounds(modifier ValueModifier, delta float32) {
 	// Log the change
 	oldFormatted := modifier.FormatValue(current)
 	newFormatted := modifier.FormatValue(clampedValue)
-	log.Printf("Modified %s: %s -> %s (delta: %.2f)", modifier.LogPrefix, oldFormatted, newFormatted, delta)
+	logging.UI.Debugf("Modified %s: %s -> %s (delta: %.2f)", modifier.LogPrefix, oldFormatted, newFormatted, delta)
 
 	modifier.SetValue(clampedValue)
 }
@@ -136,17 +136,41 @@ func deltaHandler(baseDelta float32, coarseMultiplier float32) float32 {
 }
 
 func ModifyValue(m *model.Model, delta int) {
+	if m.ViewMode == types.ChainView && types.ChainColumn(m.CurrentCol) == types.ChainColTranspose {
+		// Transpose column: coarse steps move an octave rather than 16 semitones
+		if delta == 16 || delta == -16 {
+			delta = delta / 16 * 12
+		}
+		currentValue := m.GetChainTranspose(m.CurrentTrack, m.CurrentChain, m.CurrentRow)
+		m.SetChainTranspose(m.CurrentTrack, m.CurrentChain, m.CurrentRow, currentValue+delta)
+		logging.UI.Debugf("Modified chain %02X row %02X transpose: %d -> %d", m.CurrentChain, m.CurrentRow, currentValue, m.GetChainTranspose(m.CurrentTrack, m.CurrentChain, m.CurrentRow))
+		return
+	}
+	if m.ViewMode == types.ChainView && (types.ChainColumn(m.CurrentCol) == types.ChainColReverb || types.ChainColumn(m.CurrentCol) == types.ChainColLowPass) {
+		// Effect override columns: an unset override starts from no effect (no send, open filter)
+		fx := m.GetChainFX(m.CurrentTrack, m.CurrentChain, m.CurrentRow)
+		value, unset := &fx.Reverb, 0
+		if types.ChainColumn(m.CurrentCol) == types.ChainColLowPass {
+			value, unset = &fx.LowPass, 254
+		}
+		if *value == -1 {
+			*value = unset
+		}
+		*value = max(0, min(254, *value+m.NudgeDelta(delta)))
+		m.SetChainFX(m.CurrentTrack, m.CurrentChain, m.CurrentRow, fx)
+		logging.UI.Debugf("Modified chain %02X row %02X effect overrides: reverb %d, low pass %d", m.CurrentChain, m.CurrentRow, fx.Reverb, fx.LowPass)
+		return
+	}
 	if m.ViewMode == types.ChainView {
-		// Chain view now only has phrase editing
-		chainsData := m.GetCurrentChainsData()
-		currentValue := (*chainsData)[m.CurrentChain][m.CurrentRow]
+		// Phrase column
+		currentValue := m.GetChainCell(m.CurrentTrack, m.CurrentChain, m.CurrentRow)
 
 		var newValue int
 		if currentValue == -1 {
 			// First edit on an empty cell: initialize to 00 and DO NOT apply delta
 			newValue = 0
 		} else {
-			newValue = currentValue + delta
+			newValue = currentValue + m.NudgeDelta(delta)
 		}
 
 		if newValue < 0 {
@@ -154,10 +178,10 @@ func ModifyValue(m *model.Model, delta int) {
 		} else if newValue > 254 {
 			newValue = 254
 		}
-		(*chainsData)[m.CurrentChain][m.CurrentRow] = newValue
+		newValue = m.ClampToBank(m.CurrentTrack, newValue)
+		m.SetChainCell(m.CurrentTrack, m.CurrentChain, m.CurrentRow, newValue)
 
-		log.Printf("Modified chain %02X row %02X phrase: %d -> %d (delta: %d)", m.CurrentChain, m.CurrentRow, currentValue, newValue, delta)
-		storage.AutoSave(m)
+		logging.UI.Debugf("Modified chain %02X row %02X phrase: %d -> %d (delta: %d)", m.CurrentChain, m.CurrentRow, currentValue, newValue, delta)
 		return
 	}
 
@@ -169,21 +193,20 @@ func ModifyValue(m *model.Model, delta int) {
 	}
 
 	colIndex := columnMapping.DataColumnIndex
-	phrasesData := m.GetCurrentPhrasesData()
-	currentValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][colIndex]
+	currentValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(colIndex))
 
 	if colIndex == int(types.ColDeltaTime) {
 		// DT column: clamp 0..254 (hex range)
 		if currentValue == -1 {
 			currentValue = 0
 		}
-		newValue := currentValue + delta
+		newValue := currentValue + m.NudgeDelta(delta)
 		if newValue < 0 {
 			newValue = 0
 		} else if newValue > 254 {
 			newValue = 254
 		}
-		(*phrasesData)[m.CurrentPhrase][m.CurrentRow][colIndex] = newValue
+		m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(colIndex), newValue)
 
 	} else if colIndex == int(types.ColEffectReverse) {
 		// Я column: probability 0..15 (0x0-0xF, where 15 = 100% chance, 0 = 0% chance)
@@ -196,7 +219,7 @@ func ModifyValue(m *model.Model, delta int) {
 		} else if newValue > 15 {
 			newValue = 15 // Clamp to max
 		}
-		(*phrasesData)[m.CurrentPhrase][m.CurrentRow][colIndex] = newValue
+		m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(colIndex), newValue)
 
 	} else {
 		// Handle different behavior for Instrument vs Sampler views
@@ -207,7 +230,7 @@ func ModifyValue(m *model.Model, delta int) {
 			var newValue int
 			if currentValue == -1 {
 				// First edit on an empty cell: initialize to the last note above (or middle C (60) if no notes above)
-				newValue = FindFirstNonEmptyNoteAbove(phrasesData, m.CurrentPhrase, m.CurrentRow)
+				newValue = FindFirstNonEmptyNoteAbove(m, m.CurrentTrack, m.CurrentPhrase, m.CurrentRow)
 			} else {
 				// Apply special increment logic for instrument notes
 				// Coarse (Ctrl+Up/Down) should increment by 12 (octaves)
@@ -228,14 +251,35 @@ func ModifyValue(m *model.Model, delta int) {
 			} else if newValue > 127 {
 				newValue = 127
 			}
-			(*phrasesData)[m.CurrentPhrase][m.CurrentRow][colIndex] = newValue
+			m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(colIndex), newValue)
 
 			// Auto-set DT only when changing from no note (-1) to a note AND DT is currently -1
 			// Use the first non "--" DT value above current row, or default to 1 if none found
-			if currentValue == -1 && newValue != -1 && (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColDeltaTime] == -1 {
-				dtValue := FindFirstNonEmptyDTAbove(phrasesData, m.CurrentPhrase, m.CurrentRow)
-				(*phrasesData)[m.CurrentPhrase][m.CurrentRow][int(types.ColDeltaTime)] = dtValue
+			if currentValue == -1 && newValue != -1 && m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColDeltaTime) == -1 {
+				dtValue := FindFirstNonEmptyDTAbove(m, m.CurrentTrack, m.CurrentPhrase, m.CurrentRow)
+				m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColDeltaTime, dtValue)
+			}
+		} else if phraseViewType == types.InstrumentPhraseView && slices.Contains(types.StackedNoteColumns, types.PhraseColumn(colIndex)) {
+			// Instrument view stacked note columns (N2-N4): MIDI notes like NOT, starting from the row's note
+			var newValue int
+			if currentValue == -1 {
+				newValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColNote)
+				if newValue == -1 {
+					newValue = FindFirstNonEmptyNoteAbove(m, m.CurrentTrack, m.CurrentPhrase, m.CurrentRow)
+				}
+			} else if delta == 16 || delta == -16 {
+				newValue = currentValue + (delta/16)*12 // Coarse: octaves
+			} else {
+				newValue = currentValue + delta // Fine: semitones
 			}
+
+			// Clamp to MIDI range (0-127)
+			if newValue < 0 {
+				newValue = 0
+			} else if newValue > 127 {
+				newValue = 127
+			}
+			m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(colIndex), newValue)
 		} else if phraseViewType == types.InstrumentPhraseView && colIndex == int(types.ColChord) {
 			// Instrument view chord column: Cycle through chord types, stop at ends
 			var newValue int
@@ -258,7 +302,7 @@ func ModifyValue(m *model.Model, delta int) {
 					newValue = 0 // Stop at first valid value
 				}
 			}
-			(*phrasesData)[m.CurrentPhrase][m.CurrentRow][colIndex] = newValue
+			m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(colIndex), newValue)
 		} else if phraseViewType == types.InstrumentPhraseView && colIndex == int(types.ColChordAddition) {
 			// Instrument view chord addition column: Cycle through addition types, stop at ends
 			var newValue int
@@ -281,7 +325,7 @@ func ModifyValue(m *model.Model, delta int) {
 					newValue = 0 // Stop at first valid value
 				}
 			}
-			(*phrasesData)[m.CurrentPhrase][m.CurrentRow][colIndex] = newValue
+			m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(colIndex), newValue)
 		} else if phraseViewType == types.InstrumentPhraseView && colIndex == int(types.ColChordTransposition) {
 			// Instrument view chord transposition column: Cycle through transposition values, stop at ends
 			var newValue int
@@ -304,7 +348,7 @@ func ModifyValue(m *model.Model, delta int) {
 					newValue = 0 // Stop at first valid value
 				}
 			}
-			(*phrasesData)[m.CurrentPhrase][m.CurrentRow][colIndex] = newValue
+			m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(colIndex), newValue)
 		} else if phraseViewType == types.InstrumentPhraseView && colIndex == int(types.ColMidi) {
 			// Instrument view MIDI column: hex values 00-FE
 			var newValue int
@@ -312,7 +356,7 @@ func ModifyValue(m *model.Model, delta int) {
 				// First edit on an empty cell: initialize to 00 and DO NOT apply delta
 				newValue = 0
 			} else {
-				newValue = currentValue + delta
+				newValue = currentValue + m.NudgeDelta(delta)
 			}
 
 			if newValue < 0 {
@@ -320,7 +364,7 @@ func ModifyValue(m *model.Model, delta int) {
 			} else if newValue > 254 {
 				newValue = 254
 			}
-			(*phrasesData)[m.CurrentPhrase][m.CurrentRow][colIndex] = newValue
+			m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(colIndex), newValue)
 		} else if colIndex == int(types.ColVelocity) {
 			// VE (Velocity) column: special handling to limit to 0x7F (127)
 			virtualDefault := types.GetVirtualDefault(types.PhraseColumn(colIndex))
@@ -328,13 +372,13 @@ func ModifyValue(m *model.Model, delta int) {
 			if currentValue == -1 {
 				if virtualDefault != nil {
 					// Virtual default column: start from virtual default value and apply delta
-					newValue = virtualDefault.DefaultValue + delta
+					newValue = virtualDefault.DefaultValue + m.NudgeDelta(delta)
 				} else {
 					// Regular column: initialize to 00 and DO NOT apply delta
 					newValue = 0
 				}
 			} else {
-				newValue = currentValue + delta
+				newValue = currentValue + m.NudgeDelta(delta)
 			}
 
 			if newValue < 0 {
@@ -342,7 +386,7 @@ func ModifyValue(m *model.Model, delta int) {
 			} else if newValue > 127 { // Limit VE to 0x7F (127)
 				newValue = 127
 			}
-			(*phrasesData)[m.CurrentPhrase][m.CurrentRow][colIndex] = newValue
+			m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(colIndex), newValue)
 		} else if colIndex >= int(types.ColMidiCC0) && colIndex <= int(types.ColMidiCC8) {
 			// MIDI CC columns: special handling to limit to 0x7F (127)
 			var newValue int
@@ -350,7 +394,7 @@ func ModifyValue(m *model.Model, delta int) {
 				// First edit on an empty cell: initialize to 00 and DO NOT apply delta
 				newValue = 0
 			} else {
-				newValue = currentValue + delta
+				newValue = currentValue + m.NudgeDelta(delta)
 			}
 
 			if newValue < 0 {
@@ -358,7 +402,7 @@ func ModifyValue(m *model.Model, delta int) {
 			} else if newValue > 127 { // Limit MIDI CC to 0x7F (127)
 				newValue = 127
 			}
-			(*phrasesData)[m.CurrentPhrase][m.CurrentRow][colIndex] = newValue
+			m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(colIndex), newValue)
 		} else {
 			// All other hex-ish columns (NN, DT, GT, RT, TS, CO, FI index) - check for virtual defaults
 			virtualDefault := types.GetVirtualDefault(types.PhraseColumn(colIndex))
@@ -366,13 +410,13 @@ func ModifyValue(m *model.Model, delta int) {
 			if currentValue == -1 {
 				if virtualDefault != nil {
 					// Virtual default column: start from virtual default value and apply delta
-					newValue = virtualDefault.DefaultValue + delta
+					newValue = virtualDefault.DefaultValue + m.NudgeDelta(delta)
 				} else {
 					// Regular column: initialize to 00 and DO NOT apply delta
 					newValue = 0
 				}
 			} else {
-				newValue = currentValue + delta
+				newValue = currentValue + m.NudgeDelta(delta)
 			}
 
 			if newValue < 0 {
@@ -380,40 +424,53 @@ func ModifyValue(m *model.Model, delta int) {
 			} else if newValue > 254 {
 				newValue = 254
 			}
-			(*phrasesData)[m.CurrentPhrase][m.CurrentRow][colIndex] = newValue
+			m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(colIndex), newValue)
 		}
 
 		// Auto-enable playback on first note entry - use DT for both views
 		if colIndex == int(types.ColNote) {
 			// Only auto-set DT when changing from no note (-1) to a note (not -1) AND DT is currently -1
-			if currentValue == -1 && (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColNote] != -1 &&
-				(*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColDeltaTime] == -1 {
+			if currentValue == -1 && m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColNote) != -1 &&
+				m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColDeltaTime) == -1 {
 				// Auto-set DT using the first non "--" DT value above current row, or default to 01 if none found
-				dtValue := FindFirstNonEmptyDTAbove(phrasesData, m.CurrentPhrase, m.CurrentRow)
-				(*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColDeltaTime] = dtValue
-				log.Printf("Auto-set DT=%02X for phrase %d row %d due to note change from -1 to note", dtValue, m.CurrentPhrase, m.CurrentRow)
+				dtValue := FindFirstNonEmptyDTAbove(m, m.CurrentTrack, m.CurrentPhrase, m.CurrentRow)
+				m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColDeltaTime, dtValue)
+				logging.UI.Debugf("Auto-set DT=%02X for phrase %d row %d due to note change from -1 to note", dtValue, m.CurrentPhrase, m.CurrentRow)
 			}
 		}
 	}
 
 	m.LastEditRow = m.CurrentRow
-	log.Printf("Modified phrase %d row %d, col %d: %d -> %d (delta: %d)",
+	logging.UI.Debugf("Modified phrase %d row %d, col %d: %d -> %d (delta: %d)",
 		m.CurrentPhrase, m.CurrentRow, colIndex, currentValue,
-		(*phrasesData)[m.CurrentPhrase][m.CurrentRow][colIndex], delta)
+		m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(colIndex)), delta)
 
 	// If this row is currently playing, send an update OSC message
 	if m.IsRowCurrentlyPlaying(m.CurrentPhrase, m.CurrentRow, m.CurrentTrack) {
-		log.Printf("Row is currently playing, sending update OSC message")
+		logging.OSC.Debugf("Row is currently playing, sending update OSC message")
 		EmitRowDataFor(m, m.CurrentPhrase, m.CurrentRow, m.CurrentTrack, true) // true indicates this is an update
+	} else if shouldAuditionEdit(m, types.PhraseColumn(colIndex)) {
+		logging.UI.Debugf("Auditioning edited row %d", m.CurrentRow)
+		EmitRowDataFor(m, m.CurrentPhrase, m.CurrentRow, m.CurrentTrack)
 	}
+}
 
-	storage.AutoSave(m)
+// shouldAuditionEdit reports whether editing a phrase column should play the row, so a note or
+// velocity can be heard without starting playback
+func shouldAuditionEdit(m *model.Model, col types.PhraseColumn) bool {
+	if !m.AuditionEdits || m.IsPlaying || m.ViewMode != types.PhraseView {
+		return false
+	}
+	if col != types.ColNote && col != types.ColVelocity && !slices.Contains(types.StackedNoteColumns, col) {
+		return false
+	}
+	return m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColNote) != -1
 }
 
 func DebugLogRowEmission(m *model.Model) {
 	// Delegate to the single canonical emitter so "space" playback and "c" manual emit behave identically.
-	if m.PlaybackPhrase < 0 || m.PlaybackPhrase >= 255 || m.PlaybackRow < 0 || m.PlaybackRow >= 255 {
-		log.Printf("ROW_EMIT: Invalid playback position - Phrase: %d, Row: %d", m.PlaybackPhrase, m.PlaybackRow)
+	if m.PlaybackPhrase < 0 || m.PlaybackPhrase >= types.NumPhrases || m.PlaybackRow < 0 || m.PlaybackRow >= types.PhraseRows {
+		logging.Playback.Warnf("ROW_EMIT: Invalid playback position - Phrase: %d, Row: %d", m.PlaybackPhrase, m.PlaybackRow)
 		return
 	}
 	// Use current track context for chain and phrase playback modes
@@ -426,28 +483,26 @@ func FindFirstNonEmptyRowInPhrase(m *model.Model, phraseNum int) int {
 }
 
 func FindFirstNonEmptyRowInPhraseForTrack(m *model.Model, phraseNum int, track int) int {
-	if phraseNum >= 0 && phraseNum < 255 {
-		phrasesData := GetPhrasesDataForTrack(m, track)
-		log.Printf("DEBUG: FindFirstNonEmptyRowInPhraseForTrack - phrase=%d, track=%d", phraseNum, track)
-		for i := 0; i < 255; i++ {
+	if phraseNum >= 0 && phraseNum < types.NumPhrases {
+		logging.UI.Debugf("DEBUG: FindFirstNonEmptyRowInPhraseForTrack - phrase=%d, track=%d", phraseNum, track)
+		for i := 0; i < types.PhraseRows; i++ {
 			// Unified DT-based playback: DT > 0 means playable for both instruments and samplers
-			dtValue := (*phrasesData)[phraseNum][i][types.ColDeltaTime]
+			dtValue := m.GetPhraseCell(track, phraseNum, i, types.ColDeltaTime)
 			if IsRowPlayable(dtValue) {
-				log.Printf("DEBUG: Found playback row %d with DT=%d", i, dtValue)
+				logging.UI.Debugf("DEBUG: Found playback row %d with DT=%d", i, dtValue)
 				return i
 			}
 		}
-		log.Printf("DEBUG: No playback rows found in phrase %d track %d", phraseNum, track)
+		logging.UI.Debugf("DEBUG: No playback rows found in phrase %d track %d", phraseNum, track)
 	}
 	return 0 // Fallback to row 0 if no playback rows found
 }
 
 func FindFirstNonEmptyChain(m *model.Model) int {
-	chainsData := GetChainsDataForTrack(m, m.CurrentTrack)
-	for i := 0; i < 255; i++ {
+	for i := 0; i < types.NumChains; i++ {
 		// Check if any phrase is assigned in this chain
-		for row := 0; row < 16; row++ {
-			if (*chainsData)[i][row] != -1 {
+		for row := 0; row < types.ChainRows; row++ {
+			if m.GetChainCell(m.CurrentTrack, i, row) != -1 {
 				return i
 			}
 		}
@@ -460,32 +515,31 @@ func CopyLastRowWithIncrement(m *model.Model) {
 		return // Only works in phrase view
 	}
 
-	log.Printf("copyLastRowWithIncrement called - currentRow: %d", m.CurrentRow)
+	logging.UI.Debugf("copyLastRowWithIncrement called - currentRow: %d", m.CurrentRow)
 
 	// Check if we're on a header row (invalid row index)
 	if m.CurrentRow < 0 || m.CurrentRow >= 256 {
-		log.Printf("Cannot copy on header row (row %d)", m.CurrentRow)
+		logging.UI.Warnf("Cannot copy on header row (row %d)", m.CurrentRow)
 		return
 	}
 
 	// Get the appropriate phrases data based on view type
-	phrasesData := m.GetCurrentPhrasesData()
 	phraseViewType := m.GetPhraseViewType()
 
 	// Check if current row is empty (note, deltatime, filename are -1, playback is 0)
-	if (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColNote] != -1 ||
-		(*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColDeltaTime] != -1 ||
-		(*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColFilename] != -1 {
-		log.Printf("Current row %d is not empty, skipping copy", m.CurrentRow)
+	if m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColNote) != -1 ||
+		m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColDeltaTime) != -1 ||
+		m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColFilename) != -1 {
+		logging.UI.Debugf("Current row %d is not empty, skipping copy", m.CurrentRow)
 		return
 	}
 
 	// Find the first non-null note above the current row
 	var sourceNote int = -1
 	for r := m.CurrentRow - 1; r >= 0; r-- {
-		if (*phrasesData)[m.CurrentPhrase][r][types.ColNote] != -1 {
-			sourceNote = (*phrasesData)[m.CurrentPhrase][r][types.ColNote]
-			log.Printf("Found non-null note %d at row %d", sourceNote, r)
+		if m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, r, types.ColNote) != -1 {
+			sourceNote = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, r, types.ColNote)
+			logging.UI.Debugf("Found non-null note %d at row %d", sourceNote, r)
 			break
 		}
 	}
@@ -499,11 +553,11 @@ func CopyLastRowWithIncrement(m *model.Model) {
 			// For Sampler view, start with 0
 			sourceNote = -1 // Will be incremented to 0
 		}
-		log.Printf("No non-null note found above current row, using default start")
+		logging.UI.Debugf("No non-null note found above current row, using default start")
 	}
 
 	// Set DT to 1 for both view types
-	(*phrasesData)[m.CurrentPhrase][m.CurrentRow][int(types.ColDeltaTime)] = 1
+	m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColDeltaTime, 1)
 
 	// Increment the note and set it
 	var newNote int
@@ -520,9 +574,9 @@ func CopyLastRowWithIncrement(m *model.Model) {
 			newNote = 0
 		}
 	}
-	(*phrasesData)[m.CurrentPhrase][m.CurrentRow][int(types.ColNote)] = newNote
+	m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColNote, newNote)
 
-	log.Printf("Set note on row %d: %d->%d, P=1", m.CurrentRow, sourceNote, newNote)
+	logging.UI.Debugf("Set note on row %d: %d->%d, P=1", m.CurrentRow, sourceNote, newNote)
 
 	// Update the last edit row to current row
 	m.LastEditRow = m.CurrentRow
@@ -540,8 +594,10 @@ func IsRowEmpty(m *model.Model) bool {
 	}
 
 	// Use track-aware data access
-	phrasesData := m.GetCurrentPhrasesData()
-	rowData := (*phrasesData)[m.CurrentPhrase][m.CurrentRow]
+	rowData := m.GetPhraseRow(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow)
+	if rowData == nil {
+		return true
+	}
 
 	// A row is considered empty if all key data fields are at their default values
 	// For both instrument and sampler tracks, check Note and DeltaTime
@@ -563,10 +619,9 @@ func GetEffectiveValue(m *model.Model, phrase, row, colIndex int) int {
 // GetEffectiveValueForTrack searches backwards from the current row to find the first non-null value for a given column
 func GetEffectiveValueForTrack(m *model.Model, phrase, row, colIndex, trackId int) int {
 	// Use track-specific data pool
-	phrasesData := GetPhrasesDataForTrack(m, trackId)
 	// Search backwards from the given row to find first non-null value
 	for r := row; r >= 0; r-- {
-		value := (*phrasesData)[phrase][r][colIndex]
+		value := m.GetPhraseCell(trackId, phrase, r, types.PhraseColumn(colIndex))
 		if value != -1 {
 			return value
 		}
@@ -595,7 +650,7 @@ func GetEffectiveFilenameForTrack(m *model.Model, phrase, row, trackId int) stri
 
 	// Use track-specific files array based on track type
 	var phrasesFiles *[]string
-	if trackId >= 0 && trackId < 8 && !m.TrackTypes[trackId] {
+	if trackId >= 0 && trackId < types.NumTracks && !m.TrackTypes[trackId] {
 		// TrackTypes[trackId] = false means Instrument - don't use files
 		return "none"
 	} else {
@@ -625,27 +680,26 @@ func EmitLastSelectedPhraseRowData(m *model.Model) {
 // If isUpdate is true, the OSC message will include "update",1 to indicate this is an update to a playing row.
 func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool) {
 	shouldUpdate := len(isUpdate) > 0 && isUpdate[0]
-	log.Printf("DEBUG_EMIT: EmitRowDataFor called with phrase=%d, row=%d, trackId=%d, isUpdate=%v", phrase, row, trackId, shouldUpdate)
+	logging.Playback.Debugf("DEBUG_EMIT: EmitRowDataFor called with phrase=%d, row=%d, trackId=%d, isUpdate=%v", phrase, row, trackId, shouldUpdate)
 
 	// Defensive null check to prevent crashes
 	if m == nil {
-		log.Printf("ERROR: EmitRowDataFor called with nil model")
+		logging.Playback.Errorf("ERROR: EmitRowDataFor called with nil model")
 		return
 	}
 
 	// Validate input parameters
-	if phrase < 0 || phrase >= 255 || row < 0 || row >= 255 || trackId < 0 || trackId >= 8 {
-		log.Printf("ERROR: EmitRowDataFor called with invalid parameters - phrase=%d, row=%d, trackId=%d", phrase, row, trackId)
+	if phrase < 0 || phrase >= types.NumPhrases || row < 0 || row >= types.PhraseRows || trackId < 0 || trackId >= types.NumTracks {
+		logging.Playback.Errorf("ERROR: EmitRowDataFor called with invalid parameters - phrase=%d, row=%d, trackId=%d", phrase, row, trackId)
 		return
 	}
 
 	// Use track-aware data access for correct playback
-	phrasesData := GetPhrasesDataForTrack(m, trackId)
-	if phrasesData == nil {
-		log.Printf("ERROR: GetPhrasesDataForTrack returned nil for trackId=%d", trackId)
+	rowData := m.GetPhraseRow(trackId, phrase, row)
+	if rowData == nil {
+		logging.Playback.Errorf("ERROR: GetPhraseRow returned nil for trackId=%d", trackId)
 		return
 	}
-	rowData := (*phrasesData)[phrase][row]
 
 	// Raw values - DT used for playback control in both views
 	rawNote := rowData[types.ColNote]
@@ -680,6 +734,10 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 	effectiveComb := GetEffectiveValueForTrack(m, phrase, row, int(types.ColEffectComb), trackId)
 	effectiveReverb := GetEffectiveValueForTrack(m, phrase, row, int(types.ColEffectReverb), trackId)
 
+	// Effect overrides of the chain slot the phrase is playing in
+	chainFX := m.PlayingChainFX(trackId)
+	effectiveReverb, effectiveLowPassFilter = model.ApplyChainFX(chainFX, effectiveReverb, effectiveLowPassFilter)
+
 	// Effective/inherited values
 	effectiveNote := GetEffectiveValueForTrack(m, phrase, row, int(types.ColNote), trackId)
 	rawNoteModulated := rawNote
@@ -687,7 +745,7 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 	// For sampler tracks, apply modulation as before (current behavior)
 	if !isInstrumentTrack(m, trackId) {
 		// Apply modulation if there's a modulate setting active on this row
-		if rawModulate != -1 && rawModulate >= 0 && rawModulate < 255 && effectiveNote != -1 {
+		if rawModulate != -1 && rawModulate >= 0 && rawModulate < types.NumSettings && effectiveNote != -1 {
 			modulateSettings := (*GetModulateSettingsForTrack(m, trackId))[rawModulate]
 			originalNote := effectiveNote
 
@@ -697,11 +755,11 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 
 			// Get track-specific RNG for modulation
 			var trackRng *rand.Rand
-			if trackId >= 0 && trackId < 8 {
+			if trackId >= 0 && trackId < types.NumTracks {
 				trackRng = m.ModulateRngs[trackId]
 			} else {
-				// Fallback to creating a temporary RNG for invalid track IDs
-				trackRng = rand.New(rand.NewSource(time.Now().UnixNano()))
+				// Fallback to the effect RNG for invalid track IDs
+				trackRng = m.EffectRng
 			}
 
 			modulatedNote := modulation.ApplyModulation(originalNote, modulation.ModulateSettings{
@@ -729,7 +787,7 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 				Scale:       modulateSettings.Scale,
 				Probability: modulateSettings.Probability,
 			}, trackRng)
-			log.Printf("Applied modulation %02X: NN %02X -> %02X (Seed=%d, IRandom=%d, Sub=%d, Add=%d, Increment=%d, Scale=%s)",
+			logging.Playback.Debugf("Applied modulation %02X: NN %02X -> %02X (Seed=%d, IRandom=%d, Sub=%d, Add=%d, Increment=%d, Scale=%s)",
 				rawModulate, effectiveNote, modulatedNote, modulateSettings.Seed, modulateSettings.IRandom, modulateSettings.Sub, modulateSettings.Add, modulateSettings.Increment, modulateSettings.Scale)
 			effectiveNote = modulatedNote
 		}
@@ -749,16 +807,16 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 	}
 
 	// RICH DEBUG LOG (adapted from previous DebugLogRowEmission)
-	log.Printf("=== ROW EMISSION ===")
+	logging.Playback.Debugf("=== ROW EMISSION ===")
 	if m.IsPlaying {
-		log.Printf("Context: PlaybackMode=%v Chain:%02X Phrase:%02X Row:%02X IsPlaying=%v BPM=%.2f PPQ=%d",
+		logging.Playback.Debugf("Context: PlaybackMode=%v Chain:%02X Phrase:%02X Row:%02X IsPlaying=%v BPM=%.2f PPQ=%d",
 			m.PlaybackMode, m.PlaybackChain, m.PlaybackPhrase, m.PlaybackRow, m.IsPlaying, m.BPM, m.PPQ)
 	} else {
-		log.Printf("Context: Manual emit  Phrase:%02X Row:%02X BPM=%.2f PPQ=%d", phrase, row, m.BPM, m.PPQ)
+		logging.Playback.Debugf("Context: Manual emit  Phrase:%02X Row:%02X BPM=%.2f PPQ=%d", phrase, row, m.BPM, m.PPQ)
 	}
-	log.Printf("DeltaTime (playback control): %d", rawDeltaTime)
+	logging.Playback.Debugf("DeltaTime (playback control): %d", rawDeltaTime)
 	// Show different debug info based on track type
-	if trackId >= 0 && trackId < 8 && !m.TrackTypes[trackId] {
+	if trackId >= 0 && trackId < types.NumTracks && !m.TrackTypes[trackId] {
 		// Instrument track - show all instrument parameters
 		rawChord := rowData[types.ColChord]
 		rawChordAdd := rowData[types.ColChordAddition]
@@ -771,7 +829,7 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 		rawMidi := rowData[types.ColMidi]
 		rawSoundMaker := rowData[types.ColSoundMaker]
 
-		log.Printf("Raw:  NN=%s GT=%s C=%d A=%d T=%d A=%s D=%s S=%s R=%s AR=%s MI=%s SO=%s",
+		logging.Playback.Debugf("Raw:  NN=%s GT=%s C=%d A=%d T=%d A=%s D=%s S=%s R=%s AR=%s MI=%s SO=%s",
 			formatHex(rawNote),
 			formatHex(rawGate),
 			rawChord,
@@ -795,7 +853,7 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 		effMidi := GetEffectiveValueForTrack(m, phrase, row, int(types.ColMidi), trackId)
 		effSoundMaker := GetEffectiveValueForTrack(m, phrase, row, int(types.ColSoundMaker), trackId)
 
-		log.Printf("Eff:  NN=%s A=%s D=%s S=%s R=%s AR=%s MI=%s SO=%s",
+		logging.Playback.Debugf("Eff:  NN=%s A=%s D=%s S=%s R=%s AR=%s MI=%s SO=%s",
 			formatHex(effectiveNote),
 			formatHex(effAttack),
 			formatHex(effDecay),
@@ -807,7 +865,7 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 		)
 	} else {
 		// Sampler track - show traditional sampler information
-		log.Printf("Raw:  NN=%s DT=%s GT=%s RT=%s TS=%s FI=%d Я=%s PA=%s LP=%s HP=%s CO=%s VE=%s",
+		logging.Playback.Debugf("Raw:  NN=%s DT=%s GT=%s RT=%s TS=%s FI=%d Я=%s PA=%s LP=%s HP=%s CO=%s VE=%s",
 			formatHex(rawNote),
 			formatHex(rawDeltaTime),
 			formatHex(rawGate),
@@ -826,7 +884,7 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 			formatHex(rawEffectComb),
 			formatHex(rawEffectReverb),
 		)
-		log.Printf("Eff:  NN=%s DT=%s FI=%d FN=%s PA=%s LP=%s HP=%s CO=%s VE=%s",
+		logging.Playback.Debugf("Eff:  NN=%s DT=%s FI=%d FN=%s PA=%s LP=%s HP=%s CO=%s VE=%s",
 			formatHex(effectiveNote),
 			formatHex(effectiveDeltaTime),
 			effectiveFilenameIndex,
@@ -841,7 +899,7 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 
 	// Only emit if we have playback enabled and a concrete note
 	// For samplers, also check that we have a filename
-	needsFile := trackId >= 0 && trackId < 8 && m.TrackTypes[trackId] // Sampler tracks need files
+	needsFile := trackId >= 0 && trackId < types.NumTracks && m.TrackTypes[trackId] // Sampler tracks need files
 
 	// Check if any CC values are set for instrument tracks
 	hasCCValues := false
@@ -858,27 +916,37 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 	// For instrument tracks, allow emission if CC values are set even without a note
 	if !IsRowPlayable(rawDeltaTime) || (rawNote == -1 && !hasCCValues) || (needsFile && effectiveFilename == "none") {
 		if !IsRowPlayable(rawDeltaTime) {
-			log.Printf("ROW_EMIT: skipped (DT<=0, value=%d)", rawDeltaTime)
+			logging.Playback.Debugf("ROW_EMIT: skipped (DT<=0, value=%d)", rawDeltaTime)
 		} else if rawNote == -1 && !hasCCValues {
-			log.Printf("ROW_EMIT: skipped (NN==null and no CC values)")
+			logging.Playback.Debugf("ROW_EMIT: skipped (NN==null and no CC values)")
 		} else if needsFile && effectiveFilename == "none" {
-			log.Printf("ROW_EMIT: skipped (sampler track needs filename)")
+			logging.Playback.Debugf("ROW_EMIT: skipped (sampler track needs filename)")
 		}
 		return
 	}
 
 	// shouldEmitRow() hook (kept from playback path; e.g., to skip DT==00, etc.)
 	if shouldEmitRowForTrackAtPosition(m, phrase, row, trackId) == false {
-		log.Printf("ROW_EMIT: skipped by shouldEmitRow()")
+		logging.Playback.Debugf("ROW_EMIT: skipped by shouldEmitRow()")
 		return
 	}
 
+	// Flash the track's activity LED
+	if !shouldUpdate {
+		m.MarkTrackTriggered(trackId, m.Now())
+	}
+
+	// Mirror the row trigger as a MIDI sync note during playback
+	if m.IsPlaying && !shouldUpdate {
+		m.MidiSyncTrigger(trackId)
+	}
+
 	// ONLY cancel any existing arpeggio on this track when a new note is actually going to start
 	// This ensures arpeggios are cancelled only when a real note is triggered, not just during row processing
-	if trackId >= 0 && trackId < 8 {
-		log.Printf("DEBUG_EMIT: About to cancel any existing arpeggio for track %d (new note starting)", trackId)
+	if trackId >= 0 && trackId < types.NumTracks {
+		logging.Playback.Debugf("DEBUG_EMIT: About to cancel any existing arpeggio for track %d (new note starting)", trackId)
 		m.CancelArpeggioForTrack(int32(trackId))
-		log.Printf("DEBUG_EMIT: Cancelled any existing arpeggio for track %d (new note starting)", trackId)
+		logging.Playback.Debugf("DEBUG_EMIT: Cancelled any existing arpeggio for track %d (new note starting)", trackId)
 	}
 
 	// Build slice/OSC params
@@ -894,6 +962,14 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 		syncToBPM = fileMetadata.SyncToBPM
 	}
 	sliceNumber := rawNoteModulated % sliceCount
+	// Kit mode: NN picks a whole file from the kit instead of a slice, played as a one-shot at its own speed
+	if kitFile := m.KitFile(effectiveFilename, rawNoteModulated); kitFile != "" {
+		logging.Playback.Debugf("Kit %s NN %02X: %s", filepath.Base(effectiveFilename), rawNoteModulated, filepath.Base(kitFile))
+		effectiveFilename = kitFile
+		sliceCount, sliceNumber = 1, 0
+		playthrough = 1 // Oneshot
+		syncToBPM = 0
+	}
 
 	// Get effective gate value (handles sticky behavior and virtual defaults)
 	effectiveGate := GetEffectiveValueForTrack(m, phrase, row, int(types.ColGate), trackId)
@@ -901,7 +977,7 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 		effectiveGate = 0x80 // Default Gate value (128)
 	}
 
-	baseDuration := 1.0 / float32(m.PPQ)
+	baseDuration := 1.0 / float32(m.PPQ*m.TrackResolution(trackId))
 	gateMultiplier := float32(effectiveGate) / 96.0
 	sliceDuration := baseDuration * gateMultiplier
 
@@ -922,41 +998,46 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 	if velocity > 127 {
 		velocity = 127
 	}
+	// Rows played back vary by the track's humanize range, edits and previews do not
+	humanize := m.IsPlaying && !shouldUpdate
+	if humanize {
+		velocity = m.HumanizedVelocity(trackId, velocity)
+	}
 
 	// Increment step counter for this position (for effect Every functionality)
 	// Add defensive check to ensure model is not nil and arrays are properly initialized
-	if m != nil && trackId >= 0 && trackId < 8 && phrase >= 0 && phrase < 255 && row >= 0 && row < 255 {
+	if m != nil && trackId >= 0 && trackId < types.NumTracks && phrase >= 0 && phrase < types.NumPhrases && row >= 0 && row < types.PhraseRows {
 		m.EffectStepCounter[trackId][phrase][row]++
-		log.Printf("DEBUG_EFFECTS: Incremented step counter for track=%d phrase=%d row=%d, count=%d", trackId, phrase, row, m.EffectStepCounter[trackId][phrase][row])
+		logging.Playback.Debugf("DEBUG_EFFECTS: Incremented step counter for track=%d phrase=%d row=%d, count=%d", trackId, phrase, row, m.EffectStepCounter[trackId][phrase][row])
 
 		// Handle increment counter logic
-		if rawModulate != -1 && rawModulate >= 0 && rawModulate < 255 {
+		if rawModulate != -1 && rawModulate >= 0 && rawModulate < types.NumSettings {
 			modulateSettings := (*GetModulateSettingsForTrack(m, trackId))[rawModulate]
 			if modulateSettings.Increment > 0 {
 				// Add increment value to the counter
 				m.IncrementCounters[trackId][phrase][row] += modulateSettings.Increment
-				log.Printf("DEBUG_INCREMENT: Added increment %d to counter for track=%d phrase=%d row=%d, new counter=%d",
+				logging.Playback.Debugf("DEBUG_INCREMENT: Added increment %d to counter for track=%d phrase=%d row=%d, new counter=%d",
 					modulateSettings.Increment, trackId, phrase, row, m.IncrementCounters[trackId][phrase][row])
 			}
 		}
 	} else {
-		log.Printf("WARNING: Invalid parameters for step counter - model=%v, trackId=%d, phrase=%d, row=%d", m != nil, trackId, phrase, row)
+		logging.Playback.Warnf("WARNING: Invalid parameters for step counter - model=%v, trackId=%d, phrase=%d, row=%d", m != nil, trackId, phrase, row)
 	}
 
 	var oscParams model.SamplerOSCParams
 	// Check if retrigger is set and should be active based on Every setting
 	isRetriggerActive := false
-	if rawRetrigger != -1 && rawRetrigger >= 0 && rawRetrigger < 255 {
+	if rawRetrigger != -1 && rawRetrigger >= 0 && rawRetrigger < types.NumSettings {
 		retriggerSettings := m.RetriggerSettings[rawRetrigger]
 
 		// Validate Every field to prevent division by zero
 		if retriggerSettings.Every <= 0 {
-			log.Printf("WARNING: Invalid retrigger Every value %d for index %d, defaulting to 1", retriggerSettings.Every, rawRetrigger)
+			logging.Playback.Warnf("WARNING: Invalid retrigger Every value %d for index %d, defaulting to 1", retriggerSettings.Every, rawRetrigger)
 			retriggerSettings.Every = 1
 			m.RetriggerSettings[rawRetrigger] = retriggerSettings // Update the model with corrected value
 		}
 
-		if m != nil && trackId >= 0 && trackId < 8 && phrase >= 0 && phrase < 255 && row >= 0 && row < 255 {
+		if m != nil && trackId >= 0 && trackId < types.NumTracks && phrase >= 0 && phrase < types.NumPhrases && row >= 0 && row < types.PhraseRows {
 			stepCount := m.EffectStepCounter[trackId][phrase][row]
 			everyActive := stepCount%retriggerSettings.Every == 0
 
@@ -964,26 +1045,26 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 			if everyActive {
 				// Validate Probability field
 				if retriggerSettings.Probability < 0 || retriggerSettings.Probability > 100 {
-					log.Printf("WARNING: Invalid retrigger Probability value %d for index %d, defaulting to 100", retriggerSettings.Probability, rawRetrigger)
+					logging.Playback.Warnf("WARNING: Invalid retrigger Probability value %d for index %d, defaulting to 100", retriggerSettings.Probability, rawRetrigger)
 					retriggerSettings.Probability = 100
 					m.RetriggerSettings[rawRetrigger] = retriggerSettings
 				}
 
 				// Generate random number 1-100 and check against probability
-				randomValue := (stepCount*31+trackId*17+phrase*13+row*7)%100 + 1
+				randomValue := (stepCount*31+trackId*17+phrase*13+row*7+m.RandomSeed)%100 + 1
 				isRetriggerActive = randomValue <= retriggerSettings.Probability
-				log.Printf("DEBUG_RETRIGGER: track=%d phrase=%d row=%d, stepCount=%d, Every=%d, everyActive=%v, probability=%d%%, random=%d, finalActive=%v",
+				logging.Playback.Debugf("DEBUG_RETRIGGER: track=%d phrase=%d row=%d, stepCount=%d, Every=%d, everyActive=%v, probability=%d%%, random=%d, finalActive=%v",
 					trackId, phrase, row, stepCount, retriggerSettings.Every, everyActive, retriggerSettings.Probability, randomValue, isRetriggerActive)
 			} else {
-				log.Printf("DEBUG_RETRIGGER: track=%d phrase=%d row=%d, stepCount=%d, Every=%d, skipped by Every check", trackId, phrase, row, stepCount, retriggerSettings.Every)
+				logging.Playback.Debugf("DEBUG_RETRIGGER: track=%d phrase=%d row=%d, stepCount=%d, Every=%d, skipped by Every check", trackId, phrase, row, stepCount, retriggerSettings.Every)
 			}
 		} else {
-			log.Printf("WARNING: Invalid parameters for retrigger check - model=%v, trackId=%d, phrase=%d, row=%d", m != nil, trackId, phrase, row)
+			logging.Playback.Warnf("WARNING: Invalid parameters for retrigger check - model=%v, trackId=%d, phrase=%d, row=%d", m != nil, trackId, phrase, row)
 		}
 
 		if isRetriggerActive {
 			oscParams = model.NewSamplerOSCParamsWithRetrigger(
-				effectiveFilename, trackId, sliceCount, sliceNumber, bpmSource, m.BPM, sliceDuration,
+				effectiveFilename, trackId, sliceCount, sliceNumber, bpmSource, m.PlaybackBPM(), sliceDuration,
 				retriggerSettings.Times,
 				float32(retriggerSettings.Beats),
 				retriggerSettings.Start,
@@ -997,10 +1078,10 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 			)
 		} else {
 			// Retrigger is set but not active this time, play normally without retrigger
-			oscParams = model.NewSamplerOSCParams(effectiveFilename, trackId, sliceCount, sliceNumber, bpmSource, m.BPM, sliceDuration, deltaTimeSeconds, velocity)
+			oscParams = model.NewSamplerOSCParams(effectiveFilename, trackId, sliceCount, sliceNumber, bpmSource, m.PlaybackBPM(), sliceDuration, deltaTimeSeconds, velocity)
 		}
 	} else {
-		oscParams = model.NewSamplerOSCParams(effectiveFilename, trackId, sliceCount, sliceNumber, bpmSource, m.BPM, sliceDuration, deltaTimeSeconds, velocity)
+		oscParams = model.NewSamplerOSCParams(effectiveFilename, trackId, sliceCount, sliceNumber, bpmSource, m.PlaybackBPM(), sliceDuration, deltaTimeSeconds, velocity)
 	}
 
 	// Calculate slice start and end positions based on slicing type
@@ -1024,7 +1105,7 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 		}
 		audioLength, _, _, err := getbpm.Length(lengthDetectionFile)
 		if err != nil {
-			log.Printf("WARNING: Failed to get audio length for %s: %v", effectiveFilename, err)
+			logging.Playback.Errorf("WARNING: Failed to get audio length for %s: %v", effectiveFilename, err)
 		} else {
 			// Calculate start position (normalized 0.0-1.0)
 			oscParams.SliceStart = float32(onsetTime / audioLength)
@@ -1037,12 +1118,21 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 				// Last onset - play to end of file
 				oscParams.SliceEnd = 1.0
 			}
+
+			// Warp markers set the slice's own tempo, so syncing to the song BPM locks it to the grid
+			if len(fileMetadata.Warp) > 0 {
+				sliceEndTime := audioLength
+				if onsetIndex < len(fileMetadata.Onsets)-1 {
+					sliceEndTime = fileMetadata.Onsets[onsetIndex+1]
+				}
+				oscParams.BPMSource = model.WarpedBPM(fileMetadata, onsetTime, sliceEndTime)
+			}
 			
 			sliceTypeStr := "Onset"
 			if fileMetadata.SliceType == 0 {
 				sliceTypeStr = "Equal"
 			}
-			log.Printf("%s slicing: file=%s, slice=%d, onsetIndex=%d/%d, start=%.3f, end=%.3f", 
+			logging.Playback.Debugf("%s slicing: file=%s, slice=%d, onsetIndex=%d/%d, start=%.3f, end=%.3f", 
 				sliceTypeStr, effectiveFilename, sliceNumber, onsetIndex, len(fileMetadata.Onsets), oscParams.SliceStart, oscParams.SliceEnd)
 		}
 	} else {
@@ -1057,7 +1147,7 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 		oscParams.SliceStart = float32(normalizedSliceNum) / float32(sliceCount)
 		oscParams.SliceEnd = float32(normalizedSliceNum+1) / float32(sliceCount)
 		
-		log.Printf("Even slicing (fallback): file=%s, slice=%d/%d, start=%.3f, end=%.3f", 
+		logging.Playback.Debugf("Even slicing (fallback): file=%s, slice=%d/%d, start=%.3f, end=%.3f", 
 			effectiveFilename, sliceNumber, sliceCount, oscParams.SliceStart, oscParams.SliceEnd)
 	}
 
@@ -1069,20 +1159,22 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 		// Default pitch is 0.0 when cleared (-1)
 		oscParams.Pitch = 0.0
 	}
+	// Transpose by the chain slot the phrase is playing in
+	oscParams.Pitch += float32(m.PlayingChainTranspose(trackId))
 
 	// Timestretch - check if it should be active based on Every setting
-	if rawTimestretch != -1 && rawTimestretch >= 0 && rawTimestretch < 255 {
+	if rawTimestretch != -1 && rawTimestretch >= 0 && rawTimestretch < types.NumSettings {
 		ts := m.TimestrechSettings[rawTimestretch]
 
 		// Validate Every field to prevent division by zero
 		if ts.Every <= 0 {
-			log.Printf("WARNING: Invalid timestretch Every value %d for index %d, defaulting to 1", ts.Every, rawTimestretch)
+			logging.Playback.Warnf("WARNING: Invalid timestretch Every value %d for index %d, defaulting to 1", ts.Every, rawTimestretch)
 			ts.Every = 1
 			m.TimestrechSettings[rawTimestretch] = ts // Update the model with corrected value
 		}
 
 		isTimestrechActive := false
-		if m != nil && trackId >= 0 && trackId < 8 && phrase >= 0 && phrase < 255 && row >= 0 && row < 255 {
+		if m != nil && trackId >= 0 && trackId < types.NumTracks && phrase >= 0 && phrase < types.NumPhrases && row >= 0 && row < types.PhraseRows {
 			stepCount := m.EffectStepCounter[trackId][phrase][row]
 			everyActive := stepCount%ts.Every == 0
 
@@ -1090,21 +1182,21 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 			if everyActive {
 				// Validate Probability field
 				if ts.Probability < 0 || ts.Probability > 100 {
-					log.Printf("WARNING: Invalid timestretch Probability value %d for index %d, defaulting to 100", ts.Probability, rawTimestretch)
+					logging.Playback.Warnf("WARNING: Invalid timestretch Probability value %d for index %d, defaulting to 100", ts.Probability, rawTimestretch)
 					ts.Probability = 100
 					m.TimestrechSettings[rawTimestretch] = ts
 				}
 
 				// Generate random number 1-100 and check against probability (different seed than retrigger)
-				randomValue := (stepCount*37+trackId*23+phrase*19+row*11)%100 + 1
+				randomValue := (stepCount*37+trackId*23+phrase*19+row*11+m.RandomSeed)%100 + 1
 				isTimestrechActive = randomValue <= ts.Probability
-				log.Printf("DEBUG_TIMESTRETCH: track=%d phrase=%d row=%d, stepCount=%d, Every=%d, everyActive=%v, probability=%d%%, random=%d, finalActive=%v",
+				logging.Playback.Debugf("DEBUG_TIMESTRETCH: track=%d phrase=%d row=%d, stepCount=%d, Every=%d, everyActive=%v, probability=%d%%, random=%d, finalActive=%v",
 					trackId, phrase, row, stepCount, ts.Every, everyActive, ts.Probability, randomValue, isTimestrechActive)
 			} else {
-				log.Printf("DEBUG_TIMESTRETCH: track=%d phrase=%d row=%d, stepCount=%d, Every=%d, skipped by Every check", trackId, phrase, row, stepCount, ts.Every)
+				logging.Playback.Debugf("DEBUG_TIMESTRETCH: track=%d phrase=%d row=%d, stepCount=%d, Every=%d, skipped by Every check", trackId, phrase, row, stepCount, ts.Every)
 			}
 		} else {
-			log.Printf("WARNING: Invalid parameters for timestretch check - model=%v, trackId=%d, phrase=%d, row=%d", m != nil, trackId, phrase, row)
+			logging.Playback.Warnf("WARNING: Invalid parameters for timestretch check - model=%v, trackId=%d, phrase=%d, row=%d", m != nil, trackId, phrase, row)
 		}
 
 		if isTimestrechActive {
@@ -1127,9 +1219,9 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 			oscParams.EffectReverse = 0
 		} else {
 			// Probability-based reverse: 1-15 maps to ~6.67%-100% chance
-			// Use true random for each playback
+			// Rolled from the project seed so renders repeat
 			probability := float64(rawEffectReverse) / 15.0 * 100.0 // Convert to percentage
-			randomValue := float64(rand.Intn(100) + 1)              // 1-100
+			randomValue := float64(m.EffectRng.Intn(100) + 1)       // 1-100
 			if randomValue <= probability {
 				oscParams.EffectReverse = 1
 			} else {
@@ -1199,7 +1291,7 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 		oscParams.Update = 1
 	}
 
-	log.Printf("[EmitRowDataFor] oscParams: %+v", oscParams)
+	logging.Playback.Debugf("[EmitRowDataFor] oscParams: %+v", oscParams)
 
 	// Determine track type and emit appropriate message
 	if isInstrumentTrack(m, trackId) {
@@ -1212,6 +1304,9 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 		if velocity > 127.0 {
 			velocity = 127.0
 		}
+		if humanize {
+			velocity = float32(m.HumanizedVelocity(trackId, int(velocity)))
+		}
 
 		// Extract chord parameters
 		rawChord := rowData[types.ColChord]
@@ -1231,6 +1326,7 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 		rawEffectComb := GetEffectiveValueForTrack(m, phrase, row, int(types.ColEffectComb), trackId)
 		rawEffectReverb := GetEffectiveValueForTrack(m, phrase, row, int(types.ColEffectReverb), trackId)
 		rawEffectDucking := GetEffectiveValueForTrack(m, phrase, row, int(types.ColEffectDucking), trackId)
+		rawEffectReverb, rawLowPassFilter = model.ApplyChainFX(chainFX, rawEffectReverb, rawLowPassFilter)
 
 		// Extract other parameters with effective values (sticky)
 		rawArpeggio := rowData[types.ColArpeggio] // Arpeggio should NOT be sticky - use current row only
@@ -1336,8 +1432,12 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 			rawEffectDucking,
 			midiCC,
 		)
+		instrumentParams.PLocks = m.RowPLocks(phrase, row)
 		// Generate chord notes and apply modulation according to user specification
 		midiNotes := types.GetChordNotes(rowData[types.ColNote], types.ChordType(rawChord), types.ChordAddition(rawChordAdd), types.ChordTransposition(rawChordTrans))
+		if rowData[types.ColNote] != -1 {
+			midiNotes = types.AddStackedNotes(midiNotes, rowData[types.ColNote2], rowData[types.ColNote3], rowData[types.ColNote4])
+		}
 		instrumentParams.Notes = make([]float32, len(midiNotes))
 
 		// Apply modulation to notes according to the new logic for instrument view:
@@ -1347,17 +1447,17 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 
 		hasArpeggio := rawArpeggio != -1
 		hasChord := rawChord != int(types.ChordNone)
-		hasModulation := rawModulate != -1 && rawModulate >= 0 && rawModulate < 255
+		hasModulation := rawModulate != -1 && rawModulate >= 0 && rawModulate < types.NumSettings
 
 		if hasModulation {
 			modulateSettings := (*GetModulateSettingsForTrack(m, trackId))[rawModulate]
 
 			// Get track-specific RNG for modulation
 			var trackRng *rand.Rand
-			if trackId >= 0 && trackId < 8 {
+			if trackId >= 0 && trackId < types.NumTracks {
 				trackRng = m.ModulateRngs[trackId]
 			} else {
-				trackRng = rand.New(rand.NewSource(time.Now().UnixNano()))
+				trackRng = m.EffectRng
 			}
 
 			incrementCounter := m.IncrementCounters[trackId][phrase][row]
@@ -1378,7 +1478,7 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 					Probability: modulateSettings.Probability,
 				}, trackRng)
 				instrumentParams.Notes[i] = float32(modulatedNote)
-				log.Printf("Applied modulation to instrument note %d: %d -> %d (increment=%d, hasArpeggio=%v, hasChord=%v)", i, note, modulatedNote, modulateSettings.Increment, hasArpeggio, hasChord)
+				logging.Playback.Debugf("Applied modulation to instrument note %d: %d -> %d (increment=%d, hasArpeggio=%v, hasChord=%v)", i, note, modulatedNote, modulateSettings.Increment, hasArpeggio, hasChord)
 			}
 		} else {
 			// No modulation - copy notes as-is
@@ -1386,11 +1486,25 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 				instrumentParams.Notes[i] = float32(note)
 			}
 		}
+		// Transpose by the chain slot the phrase is playing in
+		if transpose := m.PlayingChainTranspose(trackId); transpose != 0 {
+			for i, note := range instrumentParams.Notes {
+				if note >= 0 { // -1 is a row without a note
+					instrumentParams.Notes[i] = float32(max(0, min(127, int(note)+transpose)))
+				}
+			}
+		}
 		// Set update flag for instrument params if this is an update
 		if shouldUpdate {
 			instrumentParams.Update = 1
 		}
-		m.SendOSCInstrumentMessageWithArpeggio(instrumentParams)
+		if humanize {
+			m.PlayHumanized(trackId, deltaTimeSeconds, func() { m.SendOSCInstrumentMessageWithArpeggio(instrumentParams) })
+		} else {
+			m.SendOSCInstrumentMessageWithArpeggio(instrumentParams)
+		}
+	} else if humanize {
+		m.PlayHumanized(trackId, deltaTimeSeconds, func() { m.SendOSCSamplerMessage(oscParams) })
 	} else {
 		// For sampler tracks, emit full sampler message
 
@@ -1401,7 +1515,7 @@ func EmitRowDataFor(m *model.Model, phrase, row, trackId int, isUpdate ...bool)
 // isInstrumentTrack determines if the given track should use instrument OSC messages
 // Uses the track type from mixer settings (false = Instrument, true = Sampler)
 func isInstrumentTrack(m *model.Model, trackId int) bool {
-	if trackId >= 0 && trackId < 8 {
+	if trackId >= 0 && trackId < types.NumTracks {
 		return !m.TrackTypes[trackId] // false = Instrument, true = Sampler
 	}
 	return false // Invalid track defaults to Sampler
@@ -1415,13 +1529,15 @@ func isInstrumentTrack(m *model.Model, trackId int) bool {
 // - DT > 0        -> hold for DT number of ticks (baseUs * DT)
 func rowDurationMicroseconds(m *model.Model) float64 {
 	// Guard against invalid BPM/PPQ
-	if m.BPM <= 0 || m.PPQ <= 0 {
+	bpm := m.PlaybackBPM()
+	if bpm <= 0 || m.PPQ <= 0 {
 		// Fallback to a sane default: 120 BPM, PPQ=2  => 250ms (250000us) per row
 		return 250000.0
 	}
 
-	beatsPerSecond := float64(m.BPM) / 60.0
-	ticksPerSecond := beatsPerSecond * float64(m.PPQ)
+	// The playback clock ticks as often as the finest track resolution needs
+	beatsPerSecond := float64(bpm) / 60.0
+	ticksPerSecond := beatsPerSecond * float64(m.PPQ*m.ClockResolution())
 	baseUs := 1000000.0 / ticksPerSecond
 
 	// For Chain/Phrase playback modes with tick-based timing, always return base tick duration
@@ -1433,13 +1549,12 @@ func rowDurationMicroseconds(m *model.Model) float64 {
 	p := m.PlaybackPhrase
 	r := m.PlaybackRow
 	// Bounds checks (255 x 255 grid)
-	if p < 0 || p >= 255 || r < 0 || r >= 255 {
+	if p < 0 || p >= types.NumPhrases || r < 0 || r >= types.PhraseRows {
 		return baseUs
 	}
 
 	// Get the correct phrases data based on current track type
-	phrasesData := GetPhrasesDataForTrack(m, m.CurrentTrack)
-	dtRaw := (*phrasesData)[p][r][types.ColDeltaTime] // row-local DT
+	dtRaw := m.GetPhraseCell(m.CurrentTrack, p, r, types.ColDeltaTime) // row-local DT
 	if dtRaw == -1 {
 		// -- behaves like 01 (1 tick)
 		return baseUs
@@ -1457,24 +1572,24 @@ func rowDurationMicroseconds(m *model.Model) float64 {
 // This is the time per row (based on BPM/PPQ) multiplied by the DT value
 func calculateDeltaTimeSeconds(m *model.Model, phrase, row, trackId int) float32 {
 	// Guard against invalid BPM/PPQ
-	if m.BPM <= 0 || m.PPQ <= 0 {
+	bpm := m.PlaybackBPM()
+	if bpm <= 0 || m.PPQ <= 0 {
 		// Fallback to a sane default: 120 BPM, PPQ=2  => 0.25s per row
 		return 0.25
 	}
 
-	// Calculate base time per row (tick) in seconds
-	beatsPerSecond := float64(m.BPM) / 60.0
-	ticksPerSecond := beatsPerSecond * float64(m.PPQ)
+	// Calculate base time per row (tick) in seconds at the track's resolution
+	beatsPerSecond := float64(bpm) / 60.0
+	ticksPerSecond := beatsPerSecond * float64(m.PPQ*m.TrackResolution(trackId))
 	baseSecondsPerTick := 1.0 / ticksPerSecond
 
 	// Bounds checks (255 x 255 grid)
-	if phrase < 0 || phrase >= 255 || row < 0 || row >= 255 {
+	if phrase < 0 || phrase >= types.NumPhrases || row < 0 || row >= types.PhraseRows {
 		return float32(baseSecondsPerTick)
 	}
 
 	// Get the correct phrases data based on specified track type
-	phrasesData := GetPhrasesDataForTrack(m, trackId)
-	dtRaw := (*phrasesData)[phrase][row][types.ColDeltaTime] // row-local DT
+	dtRaw := m.GetPhraseCell(trackId, phrase, row, types.ColDeltaTime) // row-local DT
 
 	if dtRaw == -1 {
 		// -- behaves like 01 (1 tick)
@@ -1500,13 +1615,12 @@ func shouldEmitRow(m *model.Model) bool {
 func shouldEmitRowForTrack(m *model.Model, trackId int) bool {
 	p := m.PlaybackPhrase
 	r := m.PlaybackRow
-	if p < 0 || p >= 255 || r < 0 || r >= 255 {
+	if p < 0 || p >= types.NumPhrases || r < 0 || r >= types.PhraseRows {
 		return false
 	}
 
-	phrasesData := GetPhrasesDataForTrack(m, trackId)
-	nn := (*phrasesData)[p][r][types.ColNote]         // may still be inherited elsewhere in your code
-	dtRaw := (*phrasesData)[p][r][types.ColDeltaTime] // unified playback control
+	nn := m.GetPhraseCell(trackId, p, r, types.ColNote)         // may still be inherited elsewhere in your code
+	dtRaw := m.GetPhraseCell(trackId, p, r, types.ColDeltaTime) // unified playback control
 
 	// Unified DT-based playback: DT > 0 means play, DT <= 0 means don't play
 	if !IsRowPlayable(dtRaw) {
@@ -1521,13 +1635,12 @@ func shouldEmitRowForTrack(m *model.Model, trackId int) bool {
 }
 
 func shouldEmitRowForTrackAtPosition(m *model.Model, phrase, row, trackId int) bool {
-	if phrase < 0 || phrase >= 255 || row < 0 || row >= 255 {
+	if phrase < 0 || phrase >= types.NumPhrases || row < 0 || row >= types.PhraseRows {
 		return false
 	}
 
-	phrasesData := GetPhrasesDataForTrack(m, trackId)
-	nn := (*phrasesData)[phrase][row][types.ColNote]
-	dtRaw := (*phrasesData)[phrase][row][types.ColDeltaTime]
+	nn := m.GetPhraseCell(trackId, phrase, row, types.ColNote)
+	dtRaw := m.GetPhraseCell(trackId, phrase, row, types.ColDeltaTime)
 
 	// Unified DT-based playback: DT > 0 means play, DT <= 0 means don't play
 	if !IsRowPlayable(dtRaw) {
@@ -1544,7 +1657,7 @@ func shouldEmitRowForTrackAtPosition(m *model.Model, phrase, row, trackId int) b
 			types.ColMidiCC6, types.ColMidiCC7, types.ColMidiCC8,
 		}
 		for _, ccCol := range ccCols {
-			if (*phrasesData)[phrase][row][ccCol] != -1 {
+			if m.GetPhraseCell(trackId, phrase, row, types.PhraseColumn(ccCol)) != -1 {
 				return true // Allow emission if any CC value is set
 			}
 		}
@@ -1557,7 +1670,7 @@ func shouldEmitRowForTrackAtPosition(m *model.Model, phrase, row, trackId int) b
 }
 
 func goToPhrase(m *model.Model, phrase int) {
-	if phrase < 0 || phrase >= 255 {
+	if phrase < 0 || phrase >= types.NumPhrases {
 		return
 	}
 	m.CurrentPhrase = phrase
@@ -1572,7 +1685,7 @@ func goToPhrase(m *model.Model, phrase int) {
 		m.CurrentCol = 1
 		m.ScrollOffset = 0
 	}
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventView})
 }
 
 // ModifySongValue modifies chain ID values in song view
@@ -1581,18 +1694,18 @@ func ModifySongValue(m *model.Model, delta int) {
 	row := m.CurrentRow
 
 	// Bounds check
-	if track < 0 || track >= 8 || row < 0 || row >= 16 {
+	if track < 0 || track >= types.NumTracks || row < 0 || row >= types.SongRows {
 		return
 	}
 
-	currentValue := m.SongData[track][row]
+	currentValue := m.GetSongCell(track, row)
 	var newValue int
 
 	if currentValue == -1 {
 		// First edit on an empty cell: initialize to 00 and DO NOT apply delta
 		newValue = 0
 	} else {
-		newValue = currentValue + delta
+		newValue = currentValue + m.NudgeDelta(delta)
 	}
 
 	// Clamp to valid chain range (0-254, which is 00-FE in hex)
@@ -1601,11 +1714,10 @@ func ModifySongValue(m *model.Model, delta int) {
 	} else if newValue > 254 {
 		newValue = 254
 	}
+	newValue = m.ClampToBank(track, newValue)
 
-	m.SongData[track][row] = newValue
-	log.Printf("Modified song track %d row %d: %d -> %d (delta: %d)", track, row, currentValue, newValue, delta)
-
-	storage.AutoSave(m)
+	m.SetSongCell(track, row, newValue)
+	logging.UI.Debugf("Modified song track %d row %d: %d -> %d (delta: %d)", track, row, currentValue, newValue, delta)
 }
 
 // PlaybackConfig represents the configuration for starting playback
@@ -1615,6 +1727,9 @@ type PlaybackConfig struct {
 	Chain         int  // for chain playback
 	Phrase        int  // for phrase playback
 	Row           int  // starting row
+	// Song row of each track for song playback that does not start all tracks on one row
+	// (-1 leaves the track stopped), nil to start all on Row
+	TrackRows *[types.NumTracks]int
 }
 
 // stopPlayback provides common logic for stopping playback
@@ -1626,15 +1741,36 @@ func stopPlayback(m *model.Model) {
 		stopRecording(m)
 	}
 
+	// A bounce stopped before its last repetition ends right away
+	if m.Bounce != nil && !m.Bounce.Tail {
+		finishLoopBounce(m)
+	}
+
+	// A session take waiting for the next bar will never get there
+	if m.SessionPunchArmed {
+		m.SessionPunchArmed = false
+		logging.Playback.Debugf("Session punch-in cancelled because playback stopped")
+	}
+
 	// Clear file browser playback state when stopping tracker playback
 	if m.CurrentlyPlayingFile != "" {
 		m.SendOSCPlaybackMessage(m.CurrentlyPlayingFile, false)
 		m.CurrentlyPlayingFile = ""
-		log.Printf("Stopped file browser playback when stopping tracker playback")
+		logging.Playback.Debugf("Stopped file browser playback when stopping tracker playback")
 	}
 
 	m.SendStopOSC()
-	log.Printf("Playback stopped")
+	logging.Playback.Debugf("Playback stopped")
+	saveTransport(m)
+}
+
+// StopForExit stops playback and track recording so SuperCollider can finalize the files
+func StopForExit(m *model.Model) {
+	if m.IsPlaying {
+		stopPlayback(m)
+	} else if m.RecordingActive {
+		stopRecording(m)
+	}
 }
 
 // startPlaybackWithConfig provides common logic for starting playback
@@ -1645,16 +1781,21 @@ func startPlaybackWithConfig(m *model.Model, config PlaybackConfig) tea.Cmd {
 	// Initialize timing tracking for drift-free playback
 	// Note: PlaybackStartTime will be set right after the first note is emitted
 	m.PlaybackTickCount = 0
+	m.PlaybackTempo = model.TickTempo{}
+	m.SendStartOSC()
+	m.ResetRandom() // Same seed, same random choices on every play
+	m.MidiSyncBeat(0)
+	m.FlashBeat(0)
 
 	// Initialize increment counters to -1 for all tracks/phrases/rows
-	for track := 0; track < 8; track++ {
-		for phrase := 0; phrase < 255; phrase++ {
-			for row := 0; row < 255; row++ {
+	for track := 0; track < types.NumTracks; track++ {
+		for phrase := 0; phrase < types.NumPhrases; phrase++ {
+			for row := 0; row < types.PhraseRows; row++ {
 				m.IncrementCounters[track][phrase][row] = -1
 			}
 		}
 	}
-	log.Printf("DEBUG_INCREMENT: Initialized all increment counters to -1 for playback start")
+	logging.Playback.Debugf("DEBUG_INCREMENT: Initialized all increment counters to -1 for playback start")
 
 	if config.Mode == types.SongView {
 		// Song playback mode - reset single-track playback variables and initialize all tracks with data
@@ -1664,44 +1805,51 @@ func startPlaybackWithConfig(m *model.Model, config PlaybackConfig) tea.Cmd {
 		m.PlaybackChainRow = -1
 
 		startRow := 0
-		if config.UseCurrentRow && config.Row >= 0 && config.Row < 16 {
+		if config.UseCurrentRow && config.Row >= 0 && config.Row < types.SongRows {
 			startRow = config.Row
 		}
-		log.Printf("Song playback starting from row %02X", startRow)
+		logging.Playback.Debugf("Song playback starting from row %02X", startRow)
 		// Debug: show song data for first few rows
-		for r := 0; r < 4 && r < 16; r++ {
-			log.Printf("Song row %02X data: %v", r, [8]int{
-				m.SongData[0][r], m.SongData[1][r], m.SongData[2][r], m.SongData[3][r],
-				m.SongData[4][r], m.SongData[5][r], m.SongData[6][r], m.SongData[7][r],
+		for r := 0; r < 4 && r < types.SongRows; r++ {
+			logging.Playback.Debugf("Song row %02X data: %v", r, [types.NumTracks]int{
+				m.GetSongCell(0, r), m.GetSongCell(1, r), m.GetSongCell(2, r), m.GetSongCell(3, r),
+				m.GetSongCell(4, r), m.GetSongCell(5, r), m.GetSongCell(6, r), m.GetSongCell(7, r),
 			})
 		}
 
-		for track := 0; track < 8; track++ {
-			chainID := m.SongData[track][startRow]
-			log.Printf("Song track %d at row %02X: chainID = %d", track, startRow, chainID)
+		for track := 0; track < types.NumTracks; track++ {
+			trackRow := startRow
+			if config.TrackRows != nil {
+				trackRow = config.TrackRows[track]
+				if trackRow < 0 || trackRow >= types.SongRows {
+					m.SongPlaybackActive[track] = false
+					continue
+				}
+			}
+			chainID := m.GetSongCell(track, trackRow)
+			logging.Playback.Debugf("Song track %d at row %02X: chainID = %d", track, trackRow, chainID)
 			if chainID == -1 {
 				// No chain at this position
 				m.SongPlaybackActive[track] = false
-				log.Printf("Song track %d: no chain data, skipping", track)
+				logging.Playback.Debugf("Song track %d: no chain data, skipping", track)
 				continue
 			}
 
 			// Check if chain has valid phrase data (find first phrase in chain)
 			firstPhraseID := -1
 			firstChainRow := -1
-			chainsData := m.GetChainsDataForTrack(track)
-			for chainRow := 0; chainRow < 16; chainRow++ {
-				if (*chainsData)[chainID][chainRow] != -1 {
-					firstPhraseID = (*chainsData)[chainID][chainRow]
+			for chainRow := 0; chainRow < types.ChainRows; chainRow++ {
+				if m.GetChainCell(track, chainID, chainRow) != -1 {
+					firstPhraseID = m.GetChainCell(track, chainID, chainRow)
 					firstChainRow = chainRow
-					log.Printf("Song track %d: found phrase %d in chain %d at row %d", track, firstPhraseID, chainID, chainRow)
+					logging.Playback.Debugf("Song track %d: found phrase %d in chain %d at row %d", track, firstPhraseID, chainID, chainRow)
 					break
 				}
 			}
 
 			if firstPhraseID != -1 {
 				m.SongPlaybackActive[track] = true
-				m.SongPlaybackRow[track] = startRow
+				m.SongPlaybackRow[track] = trackRow
 				m.SongPlaybackChain[track] = chainID
 				m.SongPlaybackChainRow[track] = firstChainRow
 				m.SongPlaybackPhrase[track] = firstPhraseID
@@ -1712,11 +1860,11 @@ func startPlaybackWithConfig(m *model.Model, config PlaybackConfig) tea.Cmd {
 
 				// Emit initial row for this track
 				EmitRowDataFor(m, firstPhraseID, m.SongPlaybackRowInPhrase[track], track)
-				log.Printf("Song track %d started at row %02X, chain %02X (chain row %d), phrase %02X with %d ticks", track, startRow, chainID, firstChainRow, firstPhraseID, m.SongPlaybackTicksLeft[track])
+				logging.Playback.Debugf("Song track %d started at row %02X, chain %02X (chain row %d), phrase %02X with %d ticks", track, trackRow, chainID, firstChainRow, firstPhraseID, m.SongPlaybackTicksLeft[track])
 			} else {
 				// Chain exists but has no phrases
 				m.SongPlaybackActive[track] = false
-				log.Printf("Song track %d skipped at row %02X (chain %02X has no phrases)", track, startRow, chainID)
+				logging.Playback.Debugf("Song track %d skipped at row %02X (chain %02X has no phrases)", track, trackRow, chainID)
 			}
 		}
 
@@ -1727,11 +1875,11 @@ func startPlaybackWithConfig(m *model.Model, config PlaybackConfig) tea.Cmd {
 				activeTracks++
 			}
 		}
-		log.Printf("Song playback started from row %02X with %d active tracks", startRow, activeTracks)
+		logging.Playback.Debugf("Song playback started from row %02X with %d active tracks", startRow, activeTracks)
 
 		// If no tracks are active, make track 0 active with chain 0 as fallback
 		if activeTracks == 0 {
-			log.Printf("No active tracks found, activating track 0 with chain 0 as fallback")
+			logging.Playback.Debugf("No active tracks found, activating track 0 with chain 0 as fallback")
 			m.SongPlaybackActive[0] = true
 			m.SongPlaybackRow[0] = startRow
 			m.SongPlaybackChain[0] = 0
@@ -1741,33 +1889,32 @@ func startPlaybackWithConfig(m *model.Model, config PlaybackConfig) tea.Cmd {
 			// Initialize ticks for fallback track 0
 			m.LoadTicksLeftForTrack(0)
 			EmitRowDataFor(m, 0, m.SongPlaybackRowInPhrase[0], 0)
-			log.Printf("Song track 0 fallback started at phrase 0, row %d with %d ticks", m.SongPlaybackRowInPhrase[0], m.SongPlaybackTicksLeft[0])
+			logging.Playback.Debugf("Song track 0 fallback started at phrase 0, row %d with %d ticks", m.SongPlaybackRowInPhrase[0], m.SongPlaybackTicksLeft[0])
 		}
 
 		// Start the playback clock NOW, after all initial notes have been emitted (including fallback)
 		// Set tick count to 1 because the initial emission represents tick 0
-		m.PlaybackStartTime = time.Now()
+		m.PlaybackStartTime = m.Now()
 		m.PlaybackTickCount = 1
-		log.Printf("TIMING: Playback clock started at %v (tick count = 1)", m.PlaybackStartTime)
+		logging.Playback.Debugf("TIMING: Playback clock started at %v (tick count = 1)", m.PlaybackStartTime)
 	} else if config.Mode == types.ChainView {
 		// Chain playback mode - find appropriate starting phrase
 		m.PlaybackChain = config.Chain
 		m.PlaybackPhrase = -1
 
-		chainsData := GetChainsDataForTrack(m, m.CurrentTrack)
-		if config.UseCurrentRow && config.Row >= 0 && config.Row < 16 {
+		if config.UseCurrentRow && config.Row >= 0 && config.Row < types.ChainRows {
 			// Start from specified chain row
-			if (*chainsData)[config.Chain][config.Row] != -1 {
+			if m.GetChainCell(m.CurrentTrack, config.Chain, config.Row) != -1 {
 				m.PlaybackChainRow = config.Row
-				m.PlaybackPhrase = (*chainsData)[config.Chain][config.Row]
+				m.PlaybackPhrase = m.GetChainCell(m.CurrentTrack, config.Chain, config.Row)
 			}
 		}
 
 		// If no phrase found yet, find first non-empty phrase slot in this chain
 		if m.PlaybackPhrase == -1 {
-			for row := 0; row < 16; row++ {
-				if (*chainsData)[config.Chain][row] != -1 {
-					m.PlaybackPhrase = (*chainsData)[config.Chain][row]
+			for row := 0; row < types.ChainRows; row++ {
+				if m.GetChainCell(m.CurrentTrack, config.Chain, row) != -1 {
+					m.PlaybackPhrase = m.GetChainCell(m.CurrentTrack, config.Chain, row)
 					m.PlaybackChainRow = row
 					break
 				}
@@ -1777,13 +1924,13 @@ func startPlaybackWithConfig(m *model.Model, config PlaybackConfig) tea.Cmd {
 		if m.PlaybackPhrase == -1 {
 			// This chain has no phrases, find a different chain
 			m.PlaybackChain = FindFirstNonEmptyChain(m)
-			log.Printf("Chain playback fallback: switching to chain %d", m.PlaybackChain)
+			logging.Playback.Debugf("Chain playback fallback: switching to chain %d", m.PlaybackChain)
 			m.PlaybackChainRow = 0
-			for row := 0; row < 16; row++ {
-				if (*chainsData)[m.PlaybackChain][row] != -1 {
-					m.PlaybackPhrase = (*chainsData)[m.PlaybackChain][row]
+			for row := 0; row < types.ChainRows; row++ {
+				if m.GetChainCell(m.CurrentTrack, m.PlaybackChain, row) != -1 {
+					m.PlaybackPhrase = m.GetChainCell(m.CurrentTrack, m.PlaybackChain, row)
 					m.PlaybackChainRow = row
-					log.Printf("Chain playback fallback: found phrase %d at chain row %d", m.PlaybackPhrase, row)
+					logging.Playback.Debugf("Chain playback fallback: found phrase %d at chain row %d", m.PlaybackPhrase, row)
 					break
 				}
 			}
@@ -1791,9 +1938,9 @@ func startPlaybackWithConfig(m *model.Model, config PlaybackConfig) tea.Cmd {
 
 		// If still no valid phrase found, just start with phrase 0 as fallback
 		if m.PlaybackPhrase == -1 {
-			log.Printf("Chain playback warning - no valid phrases found, using phrase 0 as fallback (Chain: %d, ChainRow: %d)", m.PlaybackChain, m.PlaybackChainRow)
+			logging.Playback.Debugf("Chain playback warning - no valid phrases found, using phrase 0 as fallback (Chain: %d, ChainRow: %d)", m.PlaybackChain, m.PlaybackChainRow)
 			// Let's log the chain data for debugging
-			log.Printf("Chain %d contents: %v", m.PlaybackChain, (*chainsData)[m.PlaybackChain])
+			logging.Playback.Debugf("Chain %d has no phrases", m.PlaybackChain)
 			m.PlaybackPhrase = 0
 			m.PlaybackChainRow = 0
 		}
@@ -1805,59 +1952,61 @@ func startPlaybackWithConfig(m *model.Model, config PlaybackConfig) tea.Cmd {
 		}
 
 		// Initialize ticks for chain playback
-		phrasesData := GetPhrasesDataForTrack(m, m.CurrentTrack)
-		if m.PlaybackPhrase >= 0 && m.PlaybackPhrase < 255 && m.PlaybackRow >= 0 && m.PlaybackRow < 255 {
-			dtValue := (*phrasesData)[m.PlaybackPhrase][m.PlaybackRow][types.ColDeltaTime]
-			m.PlaybackTicksLeft = dtValue
-			log.Printf("DEBUG_CHAIN: Initialized PlaybackTicksLeft=%d for phrase %d row %d", m.PlaybackTicksLeft, m.PlaybackPhrase, m.PlaybackRow)
+		if m.PlaybackPhrase >= 0 && m.PlaybackPhrase < types.NumPhrases && m.PlaybackRow >= 0 && m.PlaybackRow < types.PhraseRows {
+			dtValue := m.GetPhraseCell(m.CurrentTrack, m.PlaybackPhrase, m.PlaybackRow, types.ColDeltaTime)
+			m.PlaybackTicksLeft = m.TrackTicks(m.CurrentTrack, dtValue)
+			logging.Playback.Debugf("DEBUG_CHAIN: Initialized PlaybackTicksLeft=%d for phrase %d row %d", m.PlaybackTicksLeft, m.PlaybackPhrase, m.PlaybackRow)
 		}
 
 		// Emit the initial row
 		DebugLogRowEmission(m)
-		log.Printf("Chain playback started at chain %d, chain row %d, phrase %d, row %d with %d ticks", m.PlaybackChain, m.PlaybackChainRow, m.PlaybackPhrase, m.PlaybackRow, m.PlaybackTicksLeft)
+		logging.Playback.Debugf("Chain playback started at chain %d, chain row %d, phrase %d, row %d with %d ticks", m.PlaybackChain, m.PlaybackChainRow, m.PlaybackPhrase, m.PlaybackRow, m.PlaybackTicksLeft)
 
 		// Start the playback clock NOW, after the initial note has been emitted
 		// Set tick count to 1 because the initial emission represents tick 0
-		m.PlaybackStartTime = time.Now()
+		m.PlaybackStartTime = m.Now()
 		m.PlaybackTickCount = 1
-		log.Printf("TIMING: Playback clock started at %v (tick count = 1)", m.PlaybackStartTime)
+		logging.Playback.Debugf("TIMING: Playback clock started at %v (tick count = 1)", m.PlaybackStartTime)
 	} else {
 		// Phrase playback mode
 		m.PlaybackPhrase = config.Phrase
 		m.PlaybackChain = -1
 
 		trackType := "Sampler"
-		if m.CurrentTrack >= 0 && m.CurrentTrack < 8 && !m.TrackTypes[m.CurrentTrack] {
+		if m.CurrentTrack >= 0 && m.CurrentTrack < types.NumTracks && !m.TrackTypes[m.CurrentTrack] {
 			trackType = "Instrument"
 		}
-		log.Printf("DEBUG: Phrase playback starting - CurrentTrack=%d (%s), Phrase=%d", m.CurrentTrack, trackType, m.PlaybackPhrase)
+		logging.Playback.Debugf("DEBUG: Phrase playback starting - CurrentTrack=%d (%s), Phrase=%d", m.CurrentTrack, trackType, m.PlaybackPhrase)
 
 		if config.UseCurrentRow && config.Row >= 0 {
 			m.PlaybackRow = config.Row
 		} else {
 			m.PlaybackRow = FindFirstNonEmptyRowInPhrase(m, m.PlaybackPhrase)
-			log.Printf("DEBUG: FindFirstNonEmptyRowInPhrase returned row %d for track %d", m.PlaybackRow, m.CurrentTrack)
+			logging.Playback.Debugf("DEBUG: FindFirstNonEmptyRowInPhrase returned row %d for track %d", m.PlaybackRow, m.CurrentTrack)
 		}
 
 		// Initialize ticks for phrase playback
-		phrasesData := GetPhrasesDataForTrack(m, m.CurrentTrack)
-		if m.PlaybackPhrase >= 0 && m.PlaybackPhrase < 255 && m.PlaybackRow >= 0 && m.PlaybackRow < 255 {
-			dtValue := (*phrasesData)[m.PlaybackPhrase][m.PlaybackRow][types.ColDeltaTime]
-			m.PlaybackTicksLeft = dtValue
-			log.Printf("DEBUG_PHRASE: Initialized PlaybackTicksLeft=%d for phrase %d row %d", m.PlaybackTicksLeft, m.PlaybackPhrase, m.PlaybackRow)
+		if m.PlaybackPhrase >= 0 && m.PlaybackPhrase < types.NumPhrases && m.PlaybackRow >= 0 && m.PlaybackRow < types.PhraseRows {
+			dtValue := m.GetPhraseCell(m.CurrentTrack, m.PlaybackPhrase, m.PlaybackRow, types.ColDeltaTime)
+			m.PlaybackTicksLeft = m.TrackTicks(m.CurrentTrack, dtValue)
+			logging.Playback.Debugf("DEBUG_PHRASE: Initialized PlaybackTicksLeft=%d for phrase %d row %d", m.PlaybackTicksLeft, m.PlaybackPhrase, m.PlaybackRow)
 		}
 
 		// Emit the initial row
 		DebugLogRowEmission(m)
-		log.Printf("Phrase playback started at phrase %d, row %d with %d ticks", m.PlaybackPhrase, m.PlaybackRow, m.PlaybackTicksLeft)
+		logging.Playback.Debugf("Phrase playback started at phrase %d, row %d with %d ticks", m.PlaybackPhrase, m.PlaybackRow, m.PlaybackTicksLeft)
 
 		// Start the playback clock NOW, after the initial note has been emitted
 		// Set tick count to 1 because the initial emission represents tick 0
-		m.PlaybackStartTime = time.Now()
+		m.PlaybackStartTime = m.Now()
 		m.PlaybackTickCount = 1
-		log.Printf("TIMING: Playback clock started at %v (tick count = 1)", m.PlaybackStartTime)
+		logging.Playback.Debugf("TIMING: Playback clock started at %v (tick count = 1)", m.PlaybackStartTime)
 	}
 
+	// A session take marks the song section each playback starts in, even the one it stopped in
+	m.SessionSection = -1
+	m.MarkSessionSection()
+
 	// Start recording if enabled
 	if m.RecordingEnabled && !m.RecordingActive {
 		// Determine context based on playback mode
@@ -1866,6 +2015,7 @@ func startPlaybackWithConfig(m *model.Model, config PlaybackConfig) tea.Cmd {
 		startRecordingWithContext(m, fromSongView, fromCtrlSpace)
 	}
 
+	saveTransport(m)
 	return Tick(m)
 }
 
@@ -1874,16 +2024,21 @@ func startPlaybackWithConfigFromCtrlSpace(m *model.Model, config PlaybackConfig)
 	// Initialize timing tracking for drift-free playback
 	// Note: PlaybackStartTime will be set right after the first note is emitted
 	m.PlaybackTickCount = 0
+	m.PlaybackTempo = model.TickTempo{}
+	m.SendStartOSC()
+	m.ResetRandom() // Same seed, same random choices on every play
+	m.MidiSyncBeat(0)
+	m.FlashBeat(0)
 
 	// Initialize increment counters to -1 for all tracks/phrases/rows
-	for track := 0; track < 8; track++ {
-		for phrase := 0; phrase < 255; phrase++ {
-			for row := 0; row < 255; row++ {
+	for track := 0; track < types.NumTracks; track++ {
+		for phrase := 0; phrase < types.NumPhrases; phrase++ {
+			for row := 0; row < types.PhraseRows; row++ {
 				m.IncrementCounters[track][phrase][row] = -1
 			}
 		}
 	}
-	log.Printf("DEBUG_INCREMENT: Initialized all increment counters to -1 for Ctrl+Space playback start")
+	logging.Playback.Debugf("DEBUG_INCREMENT: Initialized all increment counters to -1 for Ctrl+Space playback start")
 	m.IsPlaying = true
 	m.PlaybackMode = config.Mode
 
@@ -1895,30 +2050,29 @@ func startPlaybackWithConfigFromCtrlSpace(m *model.Model, config PlaybackConfig)
 		m.PlaybackChainRow = -1
 
 		startRow := 0
-		if config.UseCurrentRow && config.Row >= 0 && config.Row < 16 {
+		if config.UseCurrentRow && config.Row >= 0 && config.Row < types.SongRows {
 			startRow = config.Row
 		}
-		log.Printf("Song playback starting from row %02X (Ctrl+Space)", startRow)
+		logging.Playback.Debugf("Song playback starting from row %02X (Ctrl+Space)", startRow)
 
-		for track := 0; track < 8; track++ {
-			chainID := m.SongData[track][startRow]
-			log.Printf("Song track %d at row %02X: chainID = %d", track, startRow, chainID)
+		for track := 0; track < types.NumTracks; track++ {
+			chainID := m.GetSongCell(track, startRow)
+			logging.Playback.Debugf("Song track %d at row %02X: chainID = %d", track, startRow, chainID)
 			if chainID == -1 {
 				// No chain at this position
 				m.SongPlaybackActive[track] = false
-				log.Printf("Song track %d: no chain data, skipping", track)
+				logging.Playback.Debugf("Song track %d: no chain data, skipping", track)
 				continue
 			}
 
 			// Check if chain has valid phrase data (find first phrase in chain)
 			firstPhraseID := -1
 			firstChainRow := -1
-			chainsData := m.GetChainsDataForTrack(track)
-			for chainRow := 0; chainRow < 16; chainRow++ {
-				if (*chainsData)[chainID][chainRow] != -1 {
-					firstPhraseID = (*chainsData)[chainID][chainRow]
+			for chainRow := 0; chainRow < types.ChainRows; chainRow++ {
+				if m.GetChainCell(track, chainID, chainRow) != -1 {
+					firstPhraseID = m.GetChainCell(track, chainID, chainRow)
 					firstChainRow = chainRow
-					log.Printf("Song track %d: found phrase %d in chain %d at row %d", track, firstPhraseID, chainID, chainRow)
+					logging.Playback.Debugf("Song track %d: found phrase %d in chain %d at row %d", track, firstPhraseID, chainID, chainRow)
 					break
 				}
 			}
@@ -1934,37 +2088,36 @@ func startPlaybackWithConfigFromCtrlSpace(m *model.Model, config PlaybackConfig)
 
 				// Emit the initial row immediately
 				EmitRowDataFor(m, firstPhraseID, m.SongPlaybackRowInPhrase[track], track)
-				log.Printf("Song track %d initialized: phrase %d, row %d, ticks %d", track, firstPhraseID, m.SongPlaybackRowInPhrase[track], m.SongPlaybackTicksLeft[track])
+				logging.Playback.Debugf("Song track %d initialized: phrase %d, row %d, ticks %d", track, firstPhraseID, m.SongPlaybackRowInPhrase[track], m.SongPlaybackTicksLeft[track])
 			} else {
 				m.SongPlaybackActive[track] = false
-				log.Printf("Song track %d: chain %d has no phrases, skipping", track, chainID)
+				logging.Playback.Debugf("Song track %d: chain %d has no phrases, skipping", track, chainID)
 			}
 		}
 
-		log.Printf("Song playback initialized (Ctrl+Space)")
+		logging.Playback.Debugf("Song playback initialized (Ctrl+Space)")
 
 		// Start the playback clock NOW, after all initial notes have been emitted
 		// Set tick count to 1 because the initial emission represents tick 0
-		m.PlaybackStartTime = time.Now()
+		m.PlaybackStartTime = m.Now()
 		m.PlaybackTickCount = 1
-		log.Printf("TIMING: Playback clock started at %v (Ctrl+Space, tick count = 1)", m.PlaybackStartTime)
+		logging.Playback.Debugf("TIMING: Playback clock started at %v (Ctrl+Space, tick count = 1)", m.PlaybackStartTime)
 	} else {
 		// Chain/Phrase playback modes - same logic as regular playback
 		if config.Mode == types.ChainView {
-			chainsData := m.GetCurrentChainsData()
 			m.PlaybackChain = config.Chain
 			m.PlaybackChainRow = 0
 
 			if config.UseCurrentRow && config.Row >= 0 {
 				m.PlaybackChainRow = config.Row
-				m.PlaybackPhrase = (*chainsData)[config.Chain][config.Row]
+				m.PlaybackPhrase = m.GetChainCell(m.CurrentTrack, config.Chain, config.Row)
 			}
 
 			// If no phrase found yet, find first non-empty phrase slot in this chain
 			if m.PlaybackPhrase == -1 {
-				for row := 0; row < 16; row++ {
-					if (*chainsData)[config.Chain][row] != -1 {
-						m.PlaybackPhrase = (*chainsData)[config.Chain][row]
+				for row := 0; row < types.ChainRows; row++ {
+					if m.GetChainCell(m.CurrentTrack, config.Chain, row) != -1 {
+						m.PlaybackPhrase = m.GetChainCell(m.CurrentTrack, config.Chain, row)
 						m.PlaybackChainRow = row
 						break
 					}
@@ -1978,16 +2131,15 @@ func startPlaybackWithConfigFromCtrlSpace(m *model.Model, config PlaybackConfig)
 			}
 
 			// Initialize ticks for chain playback
-			phrasesData := GetPhrasesDataForTrack(m, m.CurrentTrack)
-			if m.PlaybackPhrase >= 0 && m.PlaybackPhrase < 255 && m.PlaybackRow >= 0 && m.PlaybackRow < 255 {
-				dtValue := (*phrasesData)[m.PlaybackPhrase][m.PlaybackRow][types.ColDeltaTime]
-				m.PlaybackTicksLeft = dtValue
-				log.Printf("DEBUG_CHAIN: Initialized PlaybackTicksLeft=%d for phrase %d row %d (Ctrl+Space)", m.PlaybackTicksLeft, m.PlaybackPhrase, m.PlaybackRow)
+			if m.PlaybackPhrase >= 0 && m.PlaybackPhrase < types.NumPhrases && m.PlaybackRow >= 0 && m.PlaybackRow < types.PhraseRows {
+				dtValue := m.GetPhraseCell(m.CurrentTrack, m.PlaybackPhrase, m.PlaybackRow, types.ColDeltaTime)
+				m.PlaybackTicksLeft = m.TrackTicks(m.CurrentTrack, dtValue)
+				logging.Playback.Debugf("DEBUG_CHAIN: Initialized PlaybackTicksLeft=%d for phrase %d row %d (Ctrl+Space)", m.PlaybackTicksLeft, m.PlaybackPhrase, m.PlaybackRow)
 			}
 
 			// Emit the initial row
 			DebugLogRowEmission(m)
-			log.Printf("Chain playback started (Ctrl+Space): chain %d, phrase %d, row %d with %d ticks", m.PlaybackChain, m.PlaybackPhrase, m.PlaybackRow, m.PlaybackTicksLeft)
+			logging.Playback.Debugf("Chain playback started (Ctrl+Space): chain %d, phrase %d, row %d with %d ticks", m.PlaybackChain, m.PlaybackPhrase, m.PlaybackRow, m.PlaybackTicksLeft)
 		} else {
 			// Phrase playback mode
 			m.PlaybackPhrase = config.Phrase
@@ -2000,25 +2152,28 @@ func startPlaybackWithConfigFromCtrlSpace(m *model.Model, config PlaybackConfig)
 			}
 
 			// Initialize ticks for phrase playback
-			phrasesData := GetPhrasesDataForTrack(m, m.CurrentTrack)
-			if m.PlaybackPhrase >= 0 && m.PlaybackPhrase < 255 && m.PlaybackRow >= 0 && m.PlaybackRow < 255 {
-				dtValue := (*phrasesData)[m.PlaybackPhrase][m.PlaybackRow][types.ColDeltaTime]
-				m.PlaybackTicksLeft = dtValue
-				log.Printf("DEBUG_PHRASE: Initialized PlaybackTicksLeft=%d for phrase %d row %d (Ctrl+Space)", m.PlaybackTicksLeft, m.PlaybackPhrase, m.PlaybackRow)
+			if m.PlaybackPhrase >= 0 && m.PlaybackPhrase < types.NumPhrases && m.PlaybackRow >= 0 && m.PlaybackRow < types.PhraseRows {
+				dtValue := m.GetPhraseCell(m.CurrentTrack, m.PlaybackPhrase, m.PlaybackRow, types.ColDeltaTime)
+				m.PlaybackTicksLeft = m.TrackTicks(m.CurrentTrack, dtValue)
+				logging.Playback.Debugf("DEBUG_PHRASE: Initialized PlaybackTicksLeft=%d for phrase %d row %d (Ctrl+Space)", m.PlaybackTicksLeft, m.PlaybackPhrase, m.PlaybackRow)
 			}
 
 			// Emit the initial row
 			DebugLogRowEmission(m)
-			log.Printf("Phrase playback started (Ctrl+Space): phrase %d, row %d with %d ticks", m.PlaybackPhrase, m.PlaybackRow, m.PlaybackTicksLeft)
+			logging.Playback.Debugf("Phrase playback started (Ctrl+Space): phrase %d, row %d with %d ticks", m.PlaybackPhrase, m.PlaybackRow, m.PlaybackTicksLeft)
 		}
 
 		// Start the playback clock NOW, after the initial note has been emitted
 		// Set tick count to 1 because the initial emission represents tick 0
-		m.PlaybackStartTime = time.Now()
+		m.PlaybackStartTime = m.Now()
 		m.PlaybackTickCount = 1
-		log.Printf("TIMING: Playback clock started at %v (Ctrl+Space, tick count = 1)", m.PlaybackStartTime)
+		logging.Playback.Debugf("TIMING: Playback clock started at %v (Ctrl+Space, tick count = 1)", m.PlaybackStartTime)
 	}
 
+	// A session take marks the song section each playback starts in, even the one it stopped in
+	m.SessionSection = -1
+	m.MarkSessionSection()
+
 	// Start recording if enabled (with Ctrl+Space context)
 	if m.RecordingEnabled && !m.RecordingActive {
 		fromSongView := (config.Mode == types.SongView)
@@ -2070,27 +2225,27 @@ func DeepCopyToClipboard(m *model.Model) {
 	} else if m.ViewMode == types.TimestrechView {
 		DeepCopyTimestrechToClipboard(m)
 	} else {
-		log.Printf("Deep copy not supported in this view")
+		logging.UI.Debugf("Deep copy not supported in this view")
 	}
 }
 
 func DeepCopyChainToClipboard(m *model.Model) {
-	sourceChainID := m.SongData[m.CurrentCol][m.CurrentRow]
+	sourceChainID := m.GetSongCell(m.CurrentCol, m.CurrentRow)
 	if sourceChainID == -1 {
-		log.Printf("Cannot deep copy: no chain at track %d row %02X (cell is empty)", m.CurrentCol, m.CurrentRow)
+		logging.UI.Warnf("Cannot deep copy: no chain at track %d row %02X (cell is empty)", m.CurrentCol, m.CurrentRow)
 		return
 	}
 
 	// Find next unused chain
 	destChainID := FindNextUnusedChain(m, sourceChainID)
 	if destChainID == -1 {
-		log.Printf("Cannot deep copy: no unused chains available")
+		logging.UI.Warnf("Cannot deep copy: no unused chains available")
 		return
 	}
 
 	// Copy all 16 phrase slots from source chain to destination chain
-	for row := 0; row < 16; row++ {
-		m.ChainsData[destChainID][row] = m.ChainsData[sourceChainID][row]
+	for row := 0; row < types.ChainRows; row++ {
+		m.SetChainCell(m.CurrentCol, destChainID, row, m.GetChainCell(m.CurrentCol, sourceChainID, row))
 	}
 
 	// Put the new chain ID in clipboard
@@ -2104,52 +2259,50 @@ func DeepCopyChainToClipboard(m *model.Model) {
 		HighlightPhrase: -1,
 		HighlightView:   types.SongView,
 	}
-	m.Clipboard = clipboard
+	m.SetClipboard(clipboard)
 
-	log.Printf("Deep copied chain %02X to chain %02X", sourceChainID, destChainID)
+	logging.UI.Debugf("Deep copied chain %02X to chain %02X", sourceChainID, destChainID)
 }
 
 func DeepCopyPhraseToClipboard(m *model.Model) {
 	// Bounds check for current row
-	if m.CurrentRow < 0 || m.CurrentRow >= 16 {
-		log.Printf("Cannot deep copy: invalid row %d", m.CurrentRow)
+	if m.CurrentRow < 0 || m.CurrentRow >= types.ChainRows {
+		logging.UI.Warnf("Cannot deep copy: invalid row %d", m.CurrentRow)
 		return
 	}
 
 	// In Chain view, get the phrase from the current row
-	chainsData := m.GetCurrentChainsData()
-	sourcePhraseID := (*chainsData)[m.CurrentChain][m.CurrentRow]
+	sourcePhraseID := m.GetChainCell(m.CurrentTrack, m.CurrentChain, m.CurrentRow)
 	if sourcePhraseID == -1 {
-		log.Printf("Cannot deep copy: no phrase at chain %02X row %02X (cell is empty)", m.CurrentChain, m.CurrentRow)
+		logging.UI.Warnf("Cannot deep copy: no phrase at chain %02X row %02X (cell is empty)", m.CurrentChain, m.CurrentRow)
 		return
 	}
 
 	// Additional bounds check for source phrase ID
-	if sourcePhraseID < 0 || sourcePhraseID >= 255 {
-		log.Printf("Cannot deep copy: invalid source phrase ID %d", sourcePhraseID)
+	if sourcePhraseID < 0 || sourcePhraseID >= types.NumPhrases {
+		logging.UI.Warnf("Cannot deep copy: invalid source phrase ID %d", sourcePhraseID)
 		return
 	}
 
 	// Find next unused phrase in the same pool (Sampler/Instrument)
 	destPhraseID := FindNextUnusedPhrase(m, sourcePhraseID)
 	if destPhraseID == -1 {
-		log.Printf("Cannot deep copy: no unused phrases available")
+		logging.UI.Warnf("Cannot deep copy: no unused phrases available")
 		return
 	}
 
 	// Additional bounds check for destination phrase ID
-	if destPhraseID < 0 || destPhraseID >= 255 {
-		log.Printf("Cannot deep copy: invalid destination phrase ID %d", destPhraseID)
+	if destPhraseID < 0 || destPhraseID >= types.NumPhrases {
+		logging.UI.Warnf("Cannot deep copy: invalid destination phrase ID %d", destPhraseID)
 		return
 	}
 
 	// Get the appropriate phrases data for the current pool
-	phrasesData := m.GetCurrentPhrasesData()
 
 	// Copy all 255 rows of phrase data from source to destination
-	for row := 0; row < 255; row++ {
+	for row := 0; row < types.PhraseRows; row++ {
 		for col := 0; col < int(types.ColCount); col++ {
-			(*phrasesData)[destPhraseID][row][col] = (*phrasesData)[sourcePhraseID][row][col]
+			m.SetPhraseCell(m.CurrentTrack, destPhraseID, row, types.PhraseColumn(col), m.GetPhraseCell(m.CurrentTrack, sourcePhraseID, row, types.PhraseColumn(col)))
 		}
 	}
 
@@ -2158,14 +2311,14 @@ func DeepCopyPhraseToClipboard(m *model.Model) {
 		phrasesFiles := m.GetCurrentPhrasesFiles()
 		if phrasesFiles != nil {
 			// Copy file references by duplicating entries in the files array
-			for row := 0; row < 255; row++ {
-				fileIndex := (*phrasesData)[sourcePhraseID][row][types.ColFilename]
+			for row := 0; row < types.PhraseRows; row++ {
+				fileIndex := m.GetPhraseCell(m.CurrentTrack, sourcePhraseID, row, types.ColFilename)
 				if fileIndex >= 0 && fileIndex < len(*phrasesFiles) && (*phrasesFiles)[fileIndex] != "" {
 					// Add the same file to the files array and update the destination phrase
 					filename := (*phrasesFiles)[fileIndex]
 					newFileIndex := len(*phrasesFiles)
 					*phrasesFiles = append(*phrasesFiles, filename)
-					(*phrasesData)[destPhraseID][row][types.ColFilename] = newFileIndex
+					m.SetPhraseCell(m.CurrentTrack, destPhraseID, row, types.ColFilename, newFileIndex)
 				}
 			}
 		}
@@ -2173,13 +2326,13 @@ func DeepCopyPhraseToClipboard(m *model.Model) {
 
 	// Copy and remap arpeggio settings referenced in the phrase
 	arpeggioMapping := make(map[int]int) // Map from source arpeggio index to destination arpeggio index
-	for row := 0; row < 255; row++ {
-		arpeggioIndex := (*phrasesData)[destPhraseID][row][types.ColArpeggio]
-		if arpeggioIndex >= 0 && arpeggioIndex < 255 {
+	for row := 0; row < types.PhraseRows; row++ {
+		arpeggioIndex := m.GetPhraseCell(m.CurrentTrack, destPhraseID, row, types.ColArpeggio)
+		if arpeggioIndex >= 0 && arpeggioIndex < types.NumSettings {
 			// Check if we already have a mapping for this arpeggio index
 			if newArpeggioIndex, exists := arpeggioMapping[arpeggioIndex]; exists {
 				// Use existing mapping
-				(*phrasesData)[destPhraseID][row][types.ColArpeggio] = newArpeggioIndex
+				m.SetPhraseCell(m.CurrentTrack, destPhraseID, row, types.ColArpeggio, newArpeggioIndex)
 			} else {
 				// Find next unused arpeggio slot and copy the settings
 				newArpeggioIndex := FindNextUnusedArpeggio(m, arpeggioIndex)
@@ -2188,11 +2341,11 @@ func DeepCopyPhraseToClipboard(m *model.Model) {
 					m.ArpeggioSettings[newArpeggioIndex] = m.ArpeggioSettings[arpeggioIndex]
 					// Store mapping and update the phrase data
 					arpeggioMapping[arpeggioIndex] = newArpeggioIndex
-					(*phrasesData)[destPhraseID][row][types.ColArpeggio] = newArpeggioIndex
-					log.Printf("Deep copied arpeggio settings %02X to %02X", arpeggioIndex, newArpeggioIndex)
+					m.SetPhraseCell(m.CurrentTrack, destPhraseID, row, types.ColArpeggio, newArpeggioIndex)
+					logging.UI.Debugf("Deep copied arpeggio settings %02X to %02X", arpeggioIndex, newArpeggioIndex)
 				} else {
 					// No unused arpeggio slots available, keep original reference
-					log.Printf("Warning: No unused arpeggio slots available for copying arpeggio %02X", arpeggioIndex)
+					logging.UI.Warnf("Warning: No unused arpeggio slots available for copying arpeggio %02X", arpeggioIndex)
 				}
 			}
 		}
@@ -2209,32 +2362,31 @@ func DeepCopyPhraseToClipboard(m *model.Model) {
 		HighlightPhrase: -1,
 		HighlightView:   types.ChainView,
 	}
-	m.Clipboard = clipboard
+	m.SetClipboard(clipboard)
 
-	log.Printf("Deep copied phrase %02X to phrase %02X", sourcePhraseID, destPhraseID)
+	logging.UI.Debugf("Deep copied phrase %02X to phrase %02X", sourcePhraseID, destPhraseID)
 }
 
 func DeepCopyCurrentPhraseToClipboard(m *model.Model) {
 	sourcePhraseID := m.CurrentPhrase
-	if sourcePhraseID < 0 || sourcePhraseID >= 255 {
-		log.Printf("Cannot deep copy: invalid current phrase ID %d", sourcePhraseID)
+	if sourcePhraseID < 0 || sourcePhraseID >= types.NumPhrases {
+		logging.UI.Warnf("Cannot deep copy: invalid current phrase ID %d", sourcePhraseID)
 		return
 	}
 
 	// Find next unused phrase in the same pool (Sampler/Instrument)
 	destPhraseID := FindNextUnusedPhrase(m, sourcePhraseID)
 	if destPhraseID == -1 {
-		log.Printf("Cannot deep copy: no unused phrases available")
+		logging.UI.Warnf("Cannot deep copy: no unused phrases available")
 		return
 	}
 
 	// Get the appropriate phrases data for the current pool
-	phrasesData := m.GetCurrentPhrasesData()
 
 	// Copy all 255 rows of phrase data from source to destination
-	for row := 0; row < 255; row++ {
+	for row := 0; row < types.PhraseRows; row++ {
 		for col := 0; col < int(types.ColCount); col++ {
-			(*phrasesData)[destPhraseID][row][col] = (*phrasesData)[sourcePhraseID][row][col]
+			m.SetPhraseCell(m.CurrentTrack, destPhraseID, row, types.PhraseColumn(col), m.GetPhraseCell(m.CurrentTrack, sourcePhraseID, row, types.PhraseColumn(col)))
 		}
 	}
 
@@ -2243,14 +2395,14 @@ func DeepCopyCurrentPhraseToClipboard(m *model.Model) {
 		phrasesFiles := m.GetCurrentPhrasesFiles()
 		if phrasesFiles != nil {
 			// Copy file references by duplicating entries in the files array
-			for row := 0; row < 255; row++ {
-				fileIndex := (*phrasesData)[sourcePhraseID][row][types.ColFilename]
+			for row := 0; row < types.PhraseRows; row++ {
+				fileIndex := m.GetPhraseCell(m.CurrentTrack, sourcePhraseID, row, types.ColFilename)
 				if fileIndex >= 0 && fileIndex < len(*phrasesFiles) && (*phrasesFiles)[fileIndex] != "" {
 					// Add the same file to the files array and update the destination phrase
 					filename := (*phrasesFiles)[fileIndex]
 					newFileIndex := len(*phrasesFiles)
 					*phrasesFiles = append(*phrasesFiles, filename)
-					(*phrasesData)[destPhraseID][row][types.ColFilename] = newFileIndex
+					m.SetPhraseCell(m.CurrentTrack, destPhraseID, row, types.ColFilename, newFileIndex)
 				}
 			}
 		}
@@ -2258,13 +2410,13 @@ func DeepCopyCurrentPhraseToClipboard(m *model.Model) {
 
 	// Copy and remap arpeggio settings referenced in the phrase
 	arpeggioMapping := make(map[int]int) // Map from source arpeggio index to destination arpeggio index
-	for row := 0; row < 255; row++ {
-		arpeggioIndex := (*phrasesData)[destPhraseID][row][types.ColArpeggio]
-		if arpeggioIndex >= 0 && arpeggioIndex < 255 {
+	for row := 0; row < types.PhraseRows; row++ {
+		arpeggioIndex := m.GetPhraseCell(m.CurrentTrack, destPhraseID, row, types.ColArpeggio)
+		if arpeggioIndex >= 0 && arpeggioIndex < types.NumSettings {
 			// Check if we already have a mapping for this arpeggio index
 			if newArpeggioIndex, exists := arpeggioMapping[arpeggioIndex]; exists {
 				// Use existing mapping
-				(*phrasesData)[destPhraseID][row][types.ColArpeggio] = newArpeggioIndex
+				m.SetPhraseCell(m.CurrentTrack, destPhraseID, row, types.ColArpeggio, newArpeggioIndex)
 			} else {
 				// Find next unused arpeggio slot and copy the settings
 				newArpeggioIndex := FindNextUnusedArpeggio(m, arpeggioIndex)
@@ -2273,11 +2425,11 @@ func DeepCopyCurrentPhraseToClipboard(m *model.Model) {
 					m.ArpeggioSettings[newArpeggioIndex] = m.ArpeggioSettings[arpeggioIndex]
 					// Store mapping and update the phrase data
 					arpeggioMapping[arpeggioIndex] = newArpeggioIndex
-					(*phrasesData)[destPhraseID][row][types.ColArpeggio] = newArpeggioIndex
-					log.Printf("Deep copied arpeggio settings %02X to %02X", arpeggioIndex, newArpeggioIndex)
+					m.SetPhraseCell(m.CurrentTrack, destPhraseID, row, types.ColArpeggio, newArpeggioIndex)
+					logging.UI.Debugf("Deep copied arpeggio settings %02X to %02X", arpeggioIndex, newArpeggioIndex)
 				} else {
 					// No unused arpeggio slots available, keep original reference
-					log.Printf("Warning: No unused arpeggio slots available for copying arpeggio %02X", arpeggioIndex)
+					logging.UI.Warnf("Warning: No unused arpeggio slots available for copying arpeggio %02X", arpeggioIndex)
 				}
 			}
 		}
@@ -2294,9 +2446,9 @@ func DeepCopyCurrentPhraseToClipboard(m *model.Model) {
 		HighlightPhrase: destPhraseID,
 		HighlightView:   types.PhraseView,
 	}
-	m.Clipboard = clipboard
+	m.SetClipboard(clipboard)
 
-	log.Printf("Deep copied phrase %02X to phrase %02X", sourcePhraseID, destPhraseID)
+	logging.UI.Debugf("Deep copied phrase %02X to phrase %02X", sourcePhraseID, destPhraseID)
 }
 
 func DeepCopyRetriggerToClipboard(m *model.Model) {
@@ -2307,17 +2459,16 @@ func DeepCopyRetriggerToClipboard(m *model.Model) {
 		sourceRetriggerIndex = m.RetriggerEditingIndex
 	} else {
 		// In PhraseView, get the retrigger index from the current cell
-		phrasesData := m.GetCurrentPhrasesData()
-		sourceRetriggerIndex = (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColRetrigger]
+		sourceRetriggerIndex = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColRetrigger)
 
 		if sourceRetriggerIndex == -1 {
-			log.Printf("Cannot deep copy retrigger: no retrigger set in current cell")
+			logging.UI.Warnf("Cannot deep copy retrigger: no retrigger set in current cell")
 			return
 		}
 	}
 
-	if sourceRetriggerIndex < 0 || sourceRetriggerIndex >= 255 {
-		log.Printf("Cannot deep copy retrigger: invalid retrigger index %d", sourceRetriggerIndex)
+	if sourceRetriggerIndex < 0 || sourceRetriggerIndex >= types.NumSettings {
+		logging.UI.Warnf("Cannot deep copy retrigger: invalid retrigger index %d", sourceRetriggerIndex)
 		return
 	}
 
@@ -2333,9 +2484,9 @@ func DeepCopyRetriggerToClipboard(m *model.Model) {
 		HighlightView:   m.ViewMode,
 		IsFreshDeepCopy: true, // Mark for deep copy on paste
 	}
-	m.Clipboard = clipboard
+	m.SetClipboard(clipboard)
 
-	log.Printf("Marked retrigger %02X for deep copy on paste", sourceRetriggerIndex)
+	logging.UI.Debugf("Marked retrigger %02X for deep copy on paste", sourceRetriggerIndex)
 }
 
 func DeepCopyTimestrechToClipboard(m *model.Model) {
@@ -2346,17 +2497,16 @@ func DeepCopyTimestrechToClipboard(m *model.Model) {
 		sourceTimestrechIndex = m.TimestrechEditingIndex
 	} else {
 		// In PhraseView, get the timestrech index from the current cell
-		phrasesData := m.GetCurrentPhrasesData()
-		sourceTimestrechIndex = (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColTimestretch]
+		sourceTimestrechIndex = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColTimestretch)
 
 		if sourceTimestrechIndex == -1 {
-			log.Printf("Cannot deep copy timestrech: no timestrech set in current cell")
+			logging.UI.Warnf("Cannot deep copy timestrech: no timestrech set in current cell")
 			return
 		}
 	}
 
-	if sourceTimestrechIndex < 0 || sourceTimestrechIndex >= 255 {
-		log.Printf("Cannot deep copy timestrech: invalid timestrech index %d", sourceTimestrechIndex)
+	if sourceTimestrechIndex < 0 || sourceTimestrechIndex >= types.NumSettings {
+		logging.UI.Warnf("Cannot deep copy timestrech: invalid timestrech index %d", sourceTimestrechIndex)
 		return
 	}
 
@@ -2372,23 +2522,22 @@ func DeepCopyTimestrechToClipboard(m *model.Model) {
 		HighlightView:   m.ViewMode,
 		IsFreshDeepCopy: true, // Mark for deep copy on paste
 	}
-	m.Clipboard = clipboard
+	m.SetClipboard(clipboard)
 
-	log.Printf("Marked timestrech %02X for deep copy on paste", sourceTimestrechIndex)
+	logging.UI.Debugf("Marked timestrech %02X for deep copy on paste", sourceTimestrechIndex)
 }
 
 func DeepCopyArpeggioToClipboard(m *model.Model) {
 	// Get the arpeggio index from the current cell
-	phrasesData := m.GetCurrentPhrasesData()
-	sourceArpeggioIndex := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColArpeggio]
+	sourceArpeggioIndex := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColArpeggio)
 
 	if sourceArpeggioIndex == -1 {
-		log.Printf("Cannot deep copy arpeggio: no arpeggio set in current cell")
+		logging.Playback.Warnf("Cannot deep copy arpeggio: no arpeggio set in current cell")
 		return
 	}
 
-	if sourceArpeggioIndex < 0 || sourceArpeggioIndex >= 255 {
-		log.Printf("Cannot deep copy arpeggio: invalid arpeggio index %d", sourceArpeggioIndex)
+	if sourceArpeggioIndex < 0 || sourceArpeggioIndex >= types.NumSettings {
+		logging.Playback.Warnf("Cannot deep copy arpeggio: invalid arpeggio index %d", sourceArpeggioIndex)
 		return
 	}
 
@@ -2404,21 +2553,28 @@ func DeepCopyArpeggioToClipboard(m *model.Model) {
 		HighlightView:   types.PhraseView,
 		IsFreshDeepCopy: true, // Mark for deep copy on paste
 	}
-	m.Clipboard = clipboard
+	m.SetClipboard(clipboard)
 
-	log.Printf("Marked arpeggio %02X for deep copy on paste", sourceArpeggioIndex)
+	logging.Playback.Debugf("Marked arpeggio %02X for deep copy on paste", sourceArpeggioIndex)
 }
 
 func FindNextUnusedChain(m *model.Model, startingFrom int) int {
 	// Bounds check input
-	if startingFrom < 0 || startingFrom >= 255 {
+	if startingFrom < 0 || startingFrom >= types.NumChains {
 		return -1
 	}
 
-	// Search from startingFrom+1 to 254, then wrap to 0 to startingFrom-1
-	for offset := 1; offset < 255; offset++ {
-		chainID := (startingFrom + offset) % 255
-		if chainID >= 0 && chainID < 255 && IsChainUnused(m, chainID) {
+	// Search from startingFrom+1 to the end of the current track's bank, then wrap to its start
+	// (a starting point outside the bank searches the whole bank from its start)
+	first, last := m.BankRange(m.CurrentTrack)
+	size := last - first + 1
+	start, count := startingFrom-first, size-1
+	if start < 0 || start >= size {
+		start, count = size-1, size
+	}
+	for offset := 1; offset <= count; offset++ {
+		chainID := first + (start+offset)%size
+		if chainID >= 0 && chainID < types.NumChains && IsChainUnused(m, chainID) {
 			return chainID
 		}
 	}
@@ -2427,15 +2583,22 @@ func FindNextUnusedChain(m *model.Model, startingFrom int) int {
 
 func FindNextUnusedPhrase(m *model.Model, startingFrom int) int {
 	// Bounds check input
-	if startingFrom < 0 || startingFrom >= 255 {
+	if startingFrom < 0 || startingFrom >= types.NumPhrases {
 		return -1
 	}
 
-	// Search from startingFrom+1 to 254, then wrap to 0 to startingFrom-1
-	for offset := 1; offset < 255; offset++ {
-		phraseID := (startingFrom + offset) % 255
+	// Search from startingFrom+1 to the end of the current track's bank, then wrap to its start
+	// (a starting point outside the bank searches the whole bank from its start)
+	first, last := m.BankRange(m.CurrentTrack)
+	size := last - first + 1
+	start, count := startingFrom-first, size-1
+	if start < 0 || start >= size {
+		start, count = size-1, size
+	}
+	for offset := 1; offset <= count; offset++ {
+		phraseID := first + (start+offset)%size
 
-		if phraseID >= 0 && phraseID < 255 && IsPhraseUnused(m, phraseID) {
+		if phraseID >= 0 && phraseID < types.NumPhrases && IsPhraseUnused(m, phraseID) {
 			return phraseID
 		}
 	}
@@ -2444,22 +2607,22 @@ func FindNextUnusedPhrase(m *model.Model, startingFrom int) int {
 
 func IsChainUnused(m *model.Model, chainID int) bool {
 	// Bounds check first
-	if chainID < 0 || chainID >= 255 {
+	if chainID < 0 || chainID >= types.NumChains {
 		return false
 	}
 
 	// Check if chain is referenced in song data
-	for track := 0; track < 8; track++ {
-		for row := 0; row < 16; row++ {
-			if m.SongData[track][row] == chainID {
+	for track := 0; track < types.NumTracks; track++ {
+		for row := 0; row < types.SongRows; row++ {
+			if m.GetSongCell(track, row) == chainID {
 				return false
 			}
 		}
 	}
 
 	// Check if chain has any phrase data
-	for row := 0; row < 16; row++ {
-		if m.ChainsData[chainID][row] != -1 {
+	for row := 0; row < types.ChainRows; row++ {
+		if m.GetChainCell(m.CurrentTrack, chainID, row) != -1 {
 			return false
 		}
 	}
@@ -2469,24 +2632,23 @@ func IsChainUnused(m *model.Model, chainID int) bool {
 
 func IsPhraseUnused(m *model.Model, phraseID int) bool {
 	// Bounds check first
-	if phraseID < 0 || phraseID >= 255 {
+	if phraseID < 0 || phraseID >= types.NumPhrases {
 		return false
 	}
 
 	// Check if phrase is referenced in any chain
-	for chain := 0; chain < 255; chain++ { // ChainsData has 255 elements (0-254)
-		for row := 0; row < 16; row++ {
-			if m.ChainsData[chain][row] == phraseID {
+	for chain := 0; chain < types.NumChains; chain++ { // ChainsData has 255 elements (0-254)
+		for row := 0; row < types.ChainRows; row++ {
+			if m.GetChainCell(m.CurrentTrack, chain, row) == phraseID {
 				return false
 			}
 		}
 	}
 
 	// Check if phrase has any playback-enabled rows
-	phrasesData := GetPhrasesDataForTrack(m, m.CurrentTrack)
-	for row := 0; row < 255; row++ {
+	for row := 0; row < types.PhraseRows; row++ {
 		// Unified DT-based playback: DT > 0 means playable for both instruments and samplers
-		dtValue := (*phrasesData)[phraseID][row][types.ColDeltaTime]
+		dtValue := m.GetPhraseCell(m.CurrentTrack, phraseID, row, types.ColDeltaTime)
 		if IsRowPlayable(dtValue) {
 			return false
 		}
@@ -2497,14 +2659,14 @@ func IsPhraseUnused(m *model.Model, phraseID int) bool {
 
 func FindNextUnusedArpeggio(m *model.Model, startingFrom int) int {
 	// Bounds check input
-	if startingFrom < 0 || startingFrom >= 255 {
+	if startingFrom < 0 || startingFrom >= types.NumSettings {
 		return -1
 	}
 
 	// Search from startingFrom+1 to 254, then wrap to 0 to startingFrom-1
-	for offset := 1; offset < 255; offset++ {
-		arpeggioID := (startingFrom + offset) % 255
-		if arpeggioID >= 0 && arpeggioID < 255 && IsArpeggioUnused(m, arpeggioID) {
+	for offset := 1; offset < types.NumSettings; offset++ {
+		arpeggioID := (startingFrom + offset) % types.NumSettings
+		if arpeggioID >= 0 && arpeggioID < types.NumSettings && IsArpeggioUnused(m, arpeggioID) {
 			return arpeggioID
 		}
 	}
@@ -2513,13 +2675,13 @@ func FindNextUnusedArpeggio(m *model.Model, startingFrom int) int {
 
 func IsArpeggioUnused(m *model.Model, arpeggioID int) bool {
 	// Bounds check first
-	if arpeggioID < 0 || arpeggioID >= 255 {
+	if arpeggioID < 0 || arpeggioID >= types.NumSettings {
 		return false
 	}
 
 	// Check if arpeggio is referenced in any phrase data
-	for phrase := 0; phrase < 255; phrase++ {
-		for row := 0; row < 255; row++ {
+	for phrase := 0; phrase < types.NumPhrases; phrase++ {
+		for row := 0; row < types.PhraseRows; row++ {
 			// Check both sampler and instrument phrases
 			if m.SamplerPhrasesData[phrase][row][types.ColArpeggio] == arpeggioID ||
 				m.InstrumentPhrasesData[phrase][row][types.ColArpeggio] == arpeggioID {
@@ -2558,42 +2720,95 @@ func ModifyMixerSetLevel(m *model.Model, delta float32) {
 
 	m.TrackSetLevels[m.CurrentMixerTrack] = newValue
 	if m.CurrentMixerTrack == 8 {
-		log.Printf("Modified mixer Input track set level: %.2f -> %.2f (delta: %.2f)", oldValue, newValue, delta)
+		logging.UI.Debugf("Modified mixer Input track set level: %.2f -> %.2f (delta: %.2f)", oldValue, newValue, delta)
 	} else {
-		log.Printf("Modified mixer track %d set level: %.2f -> %.2f (delta: %.2f)", m.CurrentMixerTrack+1, oldValue, newValue, delta)
+		logging.UI.Debugf("Modified mixer track %d set level: %.2f -> %.2f (delta: %.2f)", m.CurrentMixerTrack+1, oldValue, newValue, delta)
 	}
 
 	// Send OSC message for track set level
 	m.SendOSCTrackSetLevelMessage(m.CurrentMixerTrack)
 
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventSettings})
 }
 
-// ToggleTrackType toggles the track type for the specified track (used in Song view)
-func ToggleTrackType(m *model.Model, track int) {
-	// Bounds check
-	if track < 0 || track >= 8 {
+// ModifyMixerResolution steps the resolution of the track selected in mixer view
+func ModifyMixerResolution(m *model.Model, delta int) {
+	if m.CurrentMixerTrack < 0 || m.CurrentMixerTrack >= types.NumTracks {
 		return
 	}
+	m.CycleTrackResolution(m.CurrentMixerTrack, delta)
+	m.Publish(model.Event{Kind: model.EventSettings})
+}
 
-	// Toggle the track type
-	oldType := m.TrackTypes[track]
-	m.TrackTypes[track] = !oldType
+// ModifyMixerRecordQuantize steps the record quantize grid of the track selected in mixer view
+func ModifyMixerRecordQuantize(m *model.Model, delta int) {
+	if m.CurrentMixerTrack < 0 || m.CurrentMixerTrack >= types.NumTracks {
+		return
+	}
+	m.CycleRecordQuantize(m.CurrentMixerTrack, delta)
+	logging.UI.Debugf("Track %d record quantize: %s", m.CurrentMixerTrack+1, types.GetRecordQuantizeName(m.RecordQuantize[m.CurrentMixerTrack]))
+	m.Publish(model.Event{Kind: model.EventSettings})
+}
 
-	var oldTypeStr, newTypeStr string
-	if oldType {
-		oldTypeStr = "SA"
-	} else {
-		oldTypeStr = "IN"
+// ModifyMixerRecordQuantizeStrength changes the record quantize strength of the track selected
+// in mixer view
+func ModifyMixerRecordQuantizeStrength(m *model.Model, delta int) {
+	if m.CurrentMixerTrack < 0 || m.CurrentMixerTrack >= types.NumTracks {
+		return
 	}
-	if m.TrackTypes[track] {
-		newTypeStr = "SA"
-	} else {
-		newTypeStr = "IN"
+	m.AdjustRecordQuantizeStrength(m.CurrentMixerTrack, delta)
+	logging.UI.Debugf("Track %d record quantize strength: %d%%", m.CurrentMixerTrack+1, m.RecordQuantizeStrength[m.CurrentMixerTrack])
+	m.Publish(model.Event{Kind: model.EventSettings})
+}
+
+// ModifyMixerHumanizeTiming changes how far the rows of the track selected in mixer view play
+// early or late
+func ModifyMixerHumanizeTiming(m *model.Model, delta int) {
+	if m.CurrentMixerTrack < 0 || m.CurrentMixerTrack >= types.NumTracks {
+		return
+	}
+	m.AdjustHumanizeTiming(m.CurrentMixerTrack, delta)
+	logging.UI.Debugf("Track %d humanize timing: ±%d/%d beat", m.CurrentMixerTrack+1, m.HumanizeTiming[m.CurrentMixerTrack], model.HumanizeTicksPerBeat)
+	m.Publish(model.Event{Kind: model.EventSettings})
+}
+
+// ModifyMixerHumanizeVelocity changes how far the velocities of the track selected in mixer
+// view move
+func ModifyMixerHumanizeVelocity(m *model.Model, delta int) {
+	if m.CurrentMixerTrack < 0 || m.CurrentMixerTrack >= types.NumTracks {
+		return
 	}
+	m.AdjustHumanizeVelocity(m.CurrentMixerTrack, delta)
+	logging.UI.Debugf("Track %d humanize velocity: ±%d", m.CurrentMixerTrack+1, m.HumanizeVelocity[m.CurrentMixerTrack])
+	m.Publish(model.Event{Kind: model.EventSettings})
+}
+
+// SwitchMixerCompare switches the mixer to the other side of its A/B compare
+func SwitchMixerCompare(m *model.Model) {
+	m.ToggleMixerCompare()
+	m.Publish(model.Event{Kind: model.EventSettings})
+}
+
+// CopyMixerCompare copies the side of the A/B compare playing onto the other side
+func CopyMixerCompare(m *model.Model) {
+	m.CopyMixerToOther()
+	m.Notice = fmt.Sprintf("Mix %s copied to %s", m.MixerSlotName(), m.OtherMixerSlotName())
+	m.Publish(model.Event{Kind: model.EventSettings})
+}
+
+// ToggleTrackType steps the track type of the specified track through Instrument, the configured
+// OSC engines and Sampler (used in Song view)
+func ToggleTrackType(m *model.Model, track int) {
+	// Bounds check
+	if track < 0 || track >= types.NumTracks {
+		return
+	}
+
+	oldType := m.TrackTypeCode(track)
+	m.CycleTrackType(track)
 
-	log.Printf("Toggled track %d type: %s -> %s", track, oldTypeStr, newTypeStr)
-	storage.AutoSave(m)
+	logging.UI.Debugf("Toggled track %d type: %s -> %s", track, oldType, m.TrackTypeCode(track))
+	m.Publish(model.Event{Kind: model.EventSettings})
 }
 
 // FillSequential fills from the last null cell to the current cell in increments of 1
@@ -2617,8 +2832,8 @@ func FillSequentialSong(m *model.Model) {
 	var startRow int = 0
 
 	for row := currentRow - 1; row >= 0; row-- {
-		if m.SongData[track][row] != -1 {
-			startValue = m.SongData[track][row] + 1
+		if m.GetSongCell(track, row) != -1 {
+			startValue = m.GetSongCell(track, row) + 1
 			startRow = row + 1
 			break
 		}
@@ -2630,24 +2845,23 @@ func FillSequentialSong(m *model.Model) {
 		if value > 254 {
 			value = value % 255 // Wrap around (0-254)
 		}
-		m.SongData[track][row] = value
+		m.SetSongCell(track, row, value)
 	}
 
-	log.Printf("Filled song track %d from row %d to %d, starting with %d", track, startRow, currentRow, startValue)
+	logging.UI.Debugf("Filled song track %d from row %d to %d, starting with %d", track, startRow, currentRow, startValue)
 }
 
 // FillSequentialChain fills phrase IDs in chain view
 func FillSequentialChain(m *model.Model) {
 	currentRow := m.CurrentRow
-	chainsData := m.GetCurrentChainsData()
 
 	// Find where to start filling - look for the last non-empty row above current position
 	var startValue int = 0
 	var startRow int = 0
 
 	for row := currentRow - 1; row >= 0; row-- {
-		if (*chainsData)[m.CurrentChain][row] != -1 {
-			startValue = (*chainsData)[m.CurrentChain][row] + 1
+		if m.GetChainCell(m.CurrentTrack, m.CurrentChain, row) != -1 {
+			startValue = m.GetChainCell(m.CurrentTrack, m.CurrentChain, row) + 1
 			startRow = row + 1
 			break
 		}
@@ -2659,10 +2873,10 @@ func FillSequentialChain(m *model.Model) {
 		if value > 254 {
 			value = value % 255 // Wrap around (0-254)
 		}
-		(*chainsData)[m.CurrentChain][row] = value
+		m.SetChainCell(m.CurrentTrack, m.CurrentChain, row, value)
 	}
 
-	log.Printf("Filled chain %02X from row %d to %d, starting with %d", m.CurrentChain, startRow, currentRow, startValue)
+	logging.UI.Debugf("Filled chain %02X from row %d to %d, starting with %d", m.CurrentChain, startRow, currentRow, startValue)
 }
 
 // FillSequentialPhrase fills values in phrase view for the current column
@@ -2682,10 +2896,9 @@ func FillSequentialPhrase(m *model.Model) {
 	var startRow int = 0
 
 	// Use current phrases data based on view type
-	phrasesData := m.GetCurrentPhrasesData()
 
 	for row := currentRow - 1; row >= 0; row-- {
-		cellValue := (*phrasesData)[m.CurrentPhrase][row][colIndex]
+		cellValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex))
 		if cellValue != -1 {
 			startValue = cellValue + 1
 			startRow = row + 1
@@ -2699,7 +2912,7 @@ func FillSequentialPhrase(m *model.Model) {
 		// Find the fill range - start at the first "--" cell at or above current position
 		fillStartRow := currentRow
 		for row := currentRow; row >= 0; row-- {
-			if (*phrasesData)[m.CurrentPhrase][row][types.ColNote] == -1 {
+			if m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.ColNote) == -1 {
 				fillStartRow = row
 			} else {
 				break
@@ -2709,7 +2922,7 @@ func FillSequentialPhrase(m *model.Model) {
 		// Get the last non-null note value before the fill range (this is the starting note)
 		lastNote := -1
 		for row := fillStartRow - 1; row >= 0; row-- {
-			noteValue := (*phrasesData)[m.CurrentPhrase][row][types.ColNote]
+			noteValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.ColNote)
 			if noteValue != -1 {
 				lastNote = noteValue
 				break
@@ -2718,7 +2931,7 @@ func FillSequentialPhrase(m *model.Model) {
 
 		// If no previous note found, we can't fill - need a starting point
 		if lastNote == -1 {
-			log.Printf("No previous note found to use as starting point for NN fill")
+			logging.UI.Debugf("No previous note found to use as starting point for NN fill")
 			return
 		}
 
@@ -2726,20 +2939,20 @@ func FillSequentialPhrase(m *model.Model) {
 		currentNote := lastNote
 		for row := fillStartRow; row <= currentRow; row++ {
 			// Get DT value for this row (use effective/sticky value)
-			dtValue := (*phrasesData)[m.CurrentPhrase][row][types.ColDeltaTime]
+			dtValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.ColDeltaTime)
 
 			// If no DT in current row, search backwards for last non-null DT
 			if dtValue == -1 {
 				dtValue = 1 // Default to 1
 				for searchRow := row - 1; searchRow >= 0; searchRow-- {
-					searchDT := (*phrasesData)[m.CurrentPhrase][searchRow][types.ColDeltaTime]
+					searchDT := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, searchRow, types.ColDeltaTime)
 					if searchDT != -1 {
 						dtValue = searchDT
 						break
 					}
 				}
 				// Fill in the DT value that we're using
-				(*phrasesData)[m.CurrentPhrase][row][types.ColDeltaTime] = dtValue
+				m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.ColDeltaTime, dtValue)
 			}
 
 			// Calculate new note value based on DT
@@ -2753,13 +2966,13 @@ func FillSequentialPhrase(m *model.Model) {
 			}
 
 			// Set the note value
-			(*phrasesData)[m.CurrentPhrase][row][types.ColNote] = currentNote
+			m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.ColNote, currentNote)
 		}
 
-		log.Printf("Filled NN column from row %d to %d, starting note=%d", fillStartRow, currentRow, lastNote)
+		logging.UI.Debugf("Filled NN column from row %d to %d, starting note=%d", fillStartRow, currentRow, lastNote)
 	} else if colIndex == int(types.ColDeltaTime) {
 		// Special Ctrl+F logic for DT column
-		currentValue := (*phrasesData)[m.CurrentPhrase][currentRow][colIndex]
+		currentValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, currentRow, types.PhraseColumn(colIndex))
 
 		if currentValue <= 0 {
 			// Current cell is "--" (value <= 0): Find last non-"--" value and copy it down
@@ -2768,7 +2981,7 @@ func FillSequentialPhrase(m *model.Model) {
 
 			// Find the last non-"--" value going upward
 			for row := currentRow - 1; row >= 0; row-- {
-				cellValue := (*phrasesData)[m.CurrentPhrase][row][colIndex]
+				cellValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex))
 				if cellValue > 0 {
 					lastNonEmptyValue = cellValue
 					fillStartRow = row + 1
@@ -2778,21 +2991,21 @@ func FillSequentialPhrase(m *model.Model) {
 
 			// Fill from fillStartRow to currentRow with lastNonEmptyValue
 			for row := fillStartRow; row <= currentRow; row++ {
-				(*phrasesData)[m.CurrentPhrase][row][colIndex] = lastNonEmptyValue
+				m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex), lastNonEmptyValue)
 			}
 		} else {
 			// Current cell is "XX" (value > 0): Switch XX to "--" on current and all above until first non-XX
 			for row := currentRow; row >= 0; row-- {
-				cellValue := (*phrasesData)[m.CurrentPhrase][row][colIndex]
+				cellValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex))
 				if cellValue != currentValue {
 					break // Stop at first cell that doesn't match current value
 				}
-				(*phrasesData)[m.CurrentPhrase][row][colIndex] = -1 // Set to "--"
+				m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex), -1) // Set to "--"
 			}
 		}
 	} else if colIndex == int(types.ColModulate) {
 		// Special Ctrl+F logic for MO (Modulate) column - similar to DT toggle behavior
-		currentValue := (*phrasesData)[m.CurrentPhrase][currentRow][colIndex]
+		currentValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, currentRow, types.PhraseColumn(colIndex))
 
 		if currentValue == -1 {
 			// Current cell is "--": Find last non-"--" value and copy it down
@@ -2801,7 +3014,7 @@ func FillSequentialPhrase(m *model.Model) {
 
 			// Find the last non-"--" value going upward
 			for row := currentRow - 1; row >= 0; row-- {
-				cellValue := (*phrasesData)[m.CurrentPhrase][row][colIndex]
+				cellValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex))
 				if cellValue != -1 {
 					lastNonEmptyValue = cellValue
 					fillStartRow = row + 1
@@ -2813,22 +3026,22 @@ func FillSequentialPhrase(m *model.Model) {
 			if lastNonEmptyValue != -1 {
 				// Fill from fillStartRow to currentRow with lastNonEmptyValue
 				for row := fillStartRow; row <= currentRow; row++ {
-					(*phrasesData)[m.CurrentPhrase][row][colIndex] = lastNonEmptyValue
+					m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex), lastNonEmptyValue)
 				}
-				log.Printf("Filled MO column from row %d to %d with value %02X", fillStartRow, currentRow, lastNonEmptyValue)
+				logging.UI.Debugf("Filled MO column from row %d to %d with value %02X", fillStartRow, currentRow, lastNonEmptyValue)
 			}
 		} else {
 			// Current cell is not "--": Clear current and all consecutive cells above with the same value
 			clearCount := 0
 			for row := currentRow; row >= 0; row-- {
-				cellValue := (*phrasesData)[m.CurrentPhrase][row][colIndex]
+				cellValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex))
 				if cellValue != currentValue {
 					break // Stop at first cell that doesn't match current value
 				}
-				(*phrasesData)[m.CurrentPhrase][row][colIndex] = -1 // Set to "--"
+				m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex), -1) // Set to "--"
 				clearCount++
 			}
-			log.Printf("Cleared %d MO cells with value %02X", clearCount, currentValue)
+			logging.UI.Debugf("Cleared %d MO cells with value %02X", clearCount, currentValue)
 		}
 	} else if colIndex == int(types.ColRetrigger) || colIndex == int(types.ColTimestretch) {
 		// RT and TS columns should keep the reference value constant
@@ -2836,7 +3049,7 @@ func FillSequentialPhrase(m *model.Model) {
 		referenceValue := 0
 		fillStartRow := 0
 		for row := currentRow - 1; row >= 0; row-- {
-			cellValue := (*phrasesData)[m.CurrentPhrase][row][colIndex]
+			cellValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex))
 			if cellValue != -1 {
 				referenceValue = cellValue
 				fillStartRow = row + 1
@@ -2845,11 +3058,11 @@ func FillSequentialPhrase(m *model.Model) {
 		}
 		// Fill from the found starting row to current row with the same reference value
 		for row := fillStartRow; row <= currentRow; row++ {
-			(*phrasesData)[m.CurrentPhrase][row][colIndex] = referenceValue
+			m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex), referenceValue)
 		}
 	} else if colIndex == int(types.ColEffectReverse) {
 		// Special Ctrl+F logic for Reverse column - similar to DT toggle behavior
-		currentValue := (*phrasesData)[m.CurrentPhrase][currentRow][colIndex]
+		currentValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, currentRow, types.PhraseColumn(colIndex))
 
 		if currentValue == -1 {
 			// Current cell is empty: Find last non-empty value and repeat it, or fill incrementally
@@ -2858,7 +3071,7 @@ func FillSequentialPhrase(m *model.Model) {
 
 			// Find the last non-empty value going upward
 			for row := currentRow - 1; row >= 0; row-- {
-				cellValue := (*phrasesData)[m.CurrentPhrase][row][colIndex]
+				cellValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex))
 				if cellValue != -1 {
 					lastNonEmptyValue = cellValue
 					fillStartRow = row + 1
@@ -2869,7 +3082,7 @@ func FillSequentialPhrase(m *model.Model) {
 			if lastNonEmptyValue != -1 {
 				// Found a reference value - repeat it
 				for row := fillStartRow; row <= currentRow; row++ {
-					(*phrasesData)[m.CurrentPhrase][row][colIndex] = lastNonEmptyValue
+					m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex), lastNonEmptyValue)
 				}
 			} else {
 				// No reference value found - fill incrementally (0-15, wrapping)
@@ -2878,17 +3091,17 @@ func FillSequentialPhrase(m *model.Model) {
 					if value > 15 {
 						value = value % 16 // Wrap: 0-15, 0-15, ...
 					}
-					(*phrasesData)[m.CurrentPhrase][row][colIndex] = value
+					m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex), value)
 				}
 			}
 		} else {
 			// Current cell has value (0-15): Toggle/erase this value and matching values above
 			for row := currentRow; row >= 0; row-- {
-				cellValue := (*phrasesData)[m.CurrentPhrase][row][colIndex]
+				cellValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex))
 				if cellValue != currentValue {
 					break // Stop at first cell that doesn't match current value
 				}
-				(*phrasesData)[m.CurrentPhrase][row][colIndex] = -1 // Set to empty
+				m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex), -1) // Set to empty
 			}
 		}
 	} else if colIndex == int(types.ColPitch) {
@@ -2904,7 +3117,7 @@ func FillSequentialPhrase(m *model.Model) {
 			if value > maxValue {
 				value = value % (maxValue + 1) // Wrap: 0-254, 0-254, ...
 			}
-			(*phrasesData)[m.CurrentPhrase][row][colIndex] = value
+			m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex), value)
 		}
 	} else {
 		// Hex columns (0-254)
@@ -2914,7 +3127,7 @@ func FillSequentialPhrase(m *model.Model) {
 			if value > maxValue {
 				value = value % (maxValue + 1) // Wrap: 0-254, 0-254, ...
 			}
-			(*phrasesData)[m.CurrentPhrase][row][colIndex] = value
+			m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.PhraseColumn(colIndex), value)
 		}
 	}
 
@@ -2922,8 +3135,8 @@ func FillSequentialPhrase(m *model.Model) {
 	if colIndex == int(types.ColNote) {
 		for row := startRow; row <= currentRow; row++ {
 			// Use DT column for both views (only if not already set)
-			if (*phrasesData)[m.CurrentPhrase][row][types.ColDeltaTime] == -1 {
-				(*phrasesData)[m.CurrentPhrase][row][types.ColDeltaTime] = 1
+			if m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.ColDeltaTime) == -1 {
+				m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.ColDeltaTime, 1)
 			}
 		}
 	}
@@ -2931,7 +3144,7 @@ func FillSequentialPhrase(m *model.Model) {
 	// Update last edit row
 	m.LastEditRow = currentRow
 
-	log.Printf("Filled phrase %02X column %d from row %d to %d, starting with %d", m.CurrentPhrase, colIndex, startRow, currentRow, startValue)
+	logging.UI.Debugf("Filled phrase %02X column %d from row %d to %d, starting with %d", m.CurrentPhrase, colIndex, startRow, currentRow, startValue)
 }
 
 // Shared DT (Delta Time) utility functions for both Sampler and Instrument views
@@ -2951,16 +3164,16 @@ func GetEffectiveDTValue(dtValue int) string {
 }
 
 // SetDTForPlayback sets DT to 01 (default playback value) for a row
-func SetDTForPlayback(phrasesData *[255][][]int, phrase, row int) {
-	(*phrasesData)[phrase][row][types.ColDeltaTime] = 1
+func SetDTForPlayback(m *model.Model, track, phrase, row int) {
+	m.SetPhraseCell(track, phrase, row, types.ColDeltaTime, 1)
 }
 
 // FindFirstNonEmptyDTAbove finds the first non "--" DT value above the current row
 // Returns the DT value if found, or 1 (default) if none found
-func FindFirstNonEmptyDTAbove(phrasesData *[255][][]int, phrase, currentRow int) int {
+func FindFirstNonEmptyDTAbove(m *model.Model, track, phrase, currentRow int) int {
 	// Search upward from currentRow-1 to row 0
 	for row := currentRow - 1; row >= 0; row-- {
-		dtValue := (*phrasesData)[phrase][row][types.ColDeltaTime]
+		dtValue := m.GetPhraseCell(track, phrase, row, types.ColDeltaTime)
 		if dtValue != -1 {
 			// Found a non "--" DT value
 			return dtValue
@@ -2972,10 +3185,10 @@ func FindFirstNonEmptyDTAbove(phrasesData *[255][][]int, phrase, currentRow int)
 
 // FindFirstNonEmptyNoteAbove finds the first non "--" note value above the current row
 // Returns the note value if found, or 60 (middle C / C-4) if none found
-func FindFirstNonEmptyNoteAbove(phrasesData *[255][][]int, phrase, currentRow int) int {
+func FindFirstNonEmptyNoteAbove(m *model.Model, track, phrase, currentRow int) int {
 	// Search upward from currentRow-1 to row 0
 	for row := currentRow - 1; row >= 0; row-- {
-		noteValue := (*phrasesData)[phrase][row][types.ColNote]
+		noteValue := m.GetPhraseCell(track, phrase, row, types.ColNote)
 		if noteValue != -1 {
 			// Found a non "--" note value
 			return noteValue
@@ -2999,14 +3212,14 @@ func GetDTStatusMessage(dtValue int) string {
 // FindNextUnusedRetrigger finds the next unused retrigger slot starting from a given index
 func FindNextUnusedRetrigger(m *model.Model, startingFrom int) int {
 	// Bounds check input
-	if startingFrom < 0 || startingFrom >= 255 {
+	if startingFrom < 0 || startingFrom >= types.NumSettings {
 		return -1
 	}
 
 	// Search from startingFrom+1 to 254, then wrap to 0 to startingFrom-1
-	for offset := 1; offset < 255; offset++ {
-		retriggerID := (startingFrom + offset) % 255
-		if retriggerID >= 0 && retriggerID < 255 && IsRetriggerUnused(m, retriggerID) {
+	for offset := 1; offset < types.NumSettings; offset++ {
+		retriggerID := (startingFrom + offset) % types.NumSettings
+		if retriggerID >= 0 && retriggerID < types.NumSettings && IsRetriggerUnused(m, retriggerID) {
 			return retriggerID
 		}
 	}
@@ -3016,7 +3229,7 @@ func FindNextUnusedRetrigger(m *model.Model, startingFrom int) int {
 // IsRetriggerUnused checks if a retrigger slot is unused (Times == 0)
 func IsRetriggerUnused(m *model.Model, retriggerID int) bool {
 	// Bounds check first
-	if retriggerID < 0 || retriggerID >= 255 {
+	if retriggerID < 0 || retriggerID >= types.NumSettings {
 		return false
 	}
 
@@ -3027,14 +3240,14 @@ func IsRetriggerUnused(m *model.Model, retriggerID int) bool {
 // FindNextUnusedTimestrech finds the next unused timestrech slot starting from a given index
 func FindNextUnusedTimestrech(m *model.Model, startingFrom int) int {
 	// Bounds check input
-	if startingFrom < 0 || startingFrom >= 255 {
+	if startingFrom < 0 || startingFrom >= types.NumSettings {
 		return -1
 	}
 
 	// Search from startingFrom+1 to 254, then wrap to 0 to startingFrom-1
-	for offset := 1; offset < 255; offset++ {
-		timestrechID := (startingFrom + offset) % 255
-		if timestrechID >= 0 && timestrechID < 255 && IsTimestrechUnused(m, timestrechID) {
+	for offset := 1; offset < types.NumSettings; offset++ {
+		timestrechID := (startingFrom + offset) % types.NumSettings
+		if timestrechID >= 0 && timestrechID < types.NumSettings && IsTimestrechUnused(m, timestrechID) {
 			return timestrechID
 		}
 	}
@@ -3044,7 +3257,7 @@ func FindNextUnusedTimestrech(m *model.Model, startingFrom int) int {
 // IsTimestrechUnused checks if a timestrech slot is unused (Start == 0, End == 0, and Beats == 0)
 func IsTimestrechUnused(m *model.Model, timestrechID int) bool {
 	// Bounds check first
-	if timestrechID < 0 || timestrechID >= 255 {
+	if timestrechID < 0 || timestrechID >= types.NumSettings {
 		return false
 	}
 
@@ -3060,7 +3273,7 @@ func ResolveDuckingIndex(m *model.Model, phrase, row, trackId int) int {
 
 // ApplyDuckingToSamplerParams clamps and assigns the ducking index to the outgoing params
 func ApplyDuckingToSamplerParams(m *model.Model, params *model.SamplerOSCParams, duckIdx int) {
-	if duckIdx >= 0 && duckIdx < 255 {
+	if duckIdx >= 0 && duckIdx < types.NumSettings {
 		params.DuckingIndex = duckIdx
 	} else {
 		params.DuckingIndex = -1
@@ -0,0 +1,45 @@
+package input
+
+import (
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// handleHistoryInput scrolls the edit history and exports it
+func handleHistoryInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+q", "alt+q":
+		return requestQuit(m)
+	case "esc", "q", "G":
+		toggleAuxView(m, types.HistoryView)
+	case "up", "k":
+		m.ScrollAudit(-1)
+	case "down", "j":
+		m.ScrollAudit(1)
+	case "pgup":
+		m.ScrollAudit(-16)
+	case "pgdown":
+		m.ScrollAudit(16)
+	case "e":
+		exportHistory(m)
+	}
+	return nil
+}
+
+// exportHistory writes the edit history to the project folder and says where
+func exportHistory(m *model.Model) {
+	path, err := m.ExportAuditLog()
+	if err != nil {
+		logging.UI.Errorf("Error exporting the edit history: %v", err)
+		m.Notice = fmt.Sprintf("Could not export the history: %v", err)
+		return
+	}
+	logging.UI.Debugf("Exported the edit history to %s", path)
+	m.Notice = "Exported " + filepath.Base(path)
+}
@@ -1,7 +1,7 @@
 package input
 
 import (
-	"log"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -11,6 +11,7 @@ import (
 
 	"github.com/schollz/collidertracker/internal/audio"
 	"github.com/schollz/collidertracker/internal/hacks"
+	"github.com/schollz/collidertracker/internal/logging"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/storage"
 	"github.com/schollz/collidertracker/internal/types"
@@ -30,7 +31,7 @@ func switchToView(m *model.Model, config ViewSwitchConfig) {
 	m.CurrentRow = config.Row
 	m.CurrentCol = config.Col
 	m.ScrollOffset = config.ScrollOffset
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventView})
 }
 
 // switchToViewWithVisibilityCheck ensures the cursor row is visible after switching
@@ -73,7 +74,7 @@ func chainViewConfig(row int) ViewSwitchConfig {
 	return ViewSwitchConfig{
 		ViewMode:     types.ChainView,
 		Row:          row,
-		Col:          1,
+		Col:          0, // Phrase column
 		ScrollOffset: 0,
 	}
 }
@@ -159,6 +160,53 @@ func soundMakerViewConfig() ViewSwitchConfig {
 	}
 }
 
+// toggleAuxView opens a read-only view on top of the current one, or closes it again.
+// The cursor is left untouched so the previous view comes back exactly as it was.
+func toggleAuxView(m *model.Model, view types.ViewMode) {
+	if m.ViewMode == view {
+		m.ViewMode = m.AuxPreviousView
+		return
+	}
+	m.AuxPreviousView = m.ViewMode
+	m.ViewMode = view
+}
+
+// handleAuxViewInput handles keys in read-only views: quit, or go back to the previous view
+func handleAuxViewInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+q", "alt+q":
+		return requestQuit(m)
+	case "esc", "q":
+		m.ViewMode = m.AuxPreviousView
+	case "tab":
+		// The project stats and the diagnostics are two pages of one view
+		if m.ViewMode == types.StatsView {
+			m.ViewMode = types.DiagnosticsView
+		} else if m.ViewMode == types.DiagnosticsView {
+			m.ViewMode = types.StatsView
+		}
+	case "ctrl+t", "alt+t":
+		if m.ViewMode == types.DiagnosticsView {
+			m.ViewMode = m.AuxPreviousView
+		} else {
+			toggleAuxView(m, types.StatsView)
+		}
+	case "m":
+		if m.ViewMode == types.DiagnosticsView {
+			return startLatencyTest(m)
+		}
+	case "ctrl+g", "alt+g":
+		toggleAuxView(m, types.VisualizerView)
+	case "ctrl+e", "alt+e":
+		m.ViewMode = m.AuxPreviousView
+		toggleRecordingsView(m)
+	case "ctrl+a", "alt+a":
+		m.ViewMode = m.AuxPreviousView
+		toggleTimelineView(m)
+	}
+	return nil
+}
+
 type TickMsg time.Time
 
 // GetModifierKey returns "Alt" on macOS, "Ctrl" on other platforms
@@ -170,16 +218,135 @@ func GetModifierKey() string {
 }
 
 func HandleKeyInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
-	log.Printf("key: %s, %+v", msg.String(), msg)
+	msg = normalizeKey(msg, time.Now())
+	logging.UI.Debugf("key: %s, %+v", msg.String(), msg)
+
+	// Startup notices are dismissed by any key press
+	m.Notice = ""
+
+	// A pending confirmation swallows the next key press. Quitting and copying cancel it and go
+	// ahead, except on the quit prompt itself, which needs an answer.
+	if m.PendingConfirm != nil {
+		switch msg.String() {
+		case "ctrl+q", "alt+q", "ctrl+c", "alt+c":
+			if m.PendingConfirm.Quit {
+				return handleConfirmKey(m, msg)
+			}
+			logging.UI.Debugf("Cancelled: %s", m.PendingConfirm.Message)
+			m.PendingConfirm = nil
+		default:
+			return handleConfirmKey(m, msg)
+		}
+	}
+
+	// Naming a song cue takes the keys until it is applied or cancelled
+	if m.EditingCue {
+		return handleCueKey(m, msg)
+	}
+
+	// So does typing the tempo of a song row
+	if m.EditingRowTempo {
+		return handleRowTempoKey(m, msg)
+	}
+
+	// Naming a chain or phrase, or searching the names, takes the keys until it is done
+	if m.NameEntry.Active {
+		return handleNameKey(m, msg)
+	}
+
+	// A project password takes the keys until it is set or cancelled
+	if m.PasswordEntry.Active {
+		return handlePasswordKey(m, msg)
+	}
+
+	// A tempo/key preview takes the arrows, enter and esc; other keys (e.g. space) still work
+	if m.Preview.Active {
+		if cmd, handled := handlePreviewKey(m, msg); handled {
+			return cmd
+		}
+	}
+
+	// The live keyboard plays the note keys until it is left
+	if m.LiveKeys.Active {
+		if cmd, handled := handleLiveKeyboardKey(m, msg); handled {
+			return cmd
+		}
+	}
+
+	// The clipboard picker takes the keys until an entry is pasted or it is closed
+	if m.PickingClipboard {
+		return handleClipboardPickerKey(m, msg)
+	}
+
+	// The track kit picker takes the keys until a kit is imported or it is closed
+	if m.PickingKit {
+		return handleKitPickerKey(m, msg)
+	}
+
+	// A typed value takes digits, enter, esc and backspace; other keys cancel it
+	if m.NumberEntry.Active {
+		if cmd, handled := handleNumberEntryKey(m, msg); handled {
+			return cmd
+		}
+	}
 	
 	// Handle waveform view input separately
 	if m.ViewMode == types.WaveformView {
 		return HandleWaveformInput(m, msg)
 	}
+
+	// Read-only views only react to leaving them
+	if m.ViewMode == types.StatsView || m.ViewMode == types.VisualizerView || m.ViewMode == types.DiagnosticsView {
+		return handleAuxViewInput(m, msg)
+	}
+
+	if m.ViewMode == types.RecordingsView {
+		return handleRecordingsInput(m, msg)
+	}
+
+	if m.ViewMode == types.MasterChainView {
+		return handleMasterChainInput(m, msg)
+	}
+
+	if m.ViewMode == types.TimelineView {
+		return handleTimelineInput(m, msg)
+	}
+
+	if m.ViewMode == types.UsageView {
+		return handleUsageInput(m, msg)
+	}
+
+	if m.ViewMode == types.InputView {
+		return handleInputMeterInput(m, msg)
+	}
+
+	if m.ViewMode == types.TunerView {
+		return handleTunerInput(m, msg)
+	}
+
+	if m.ViewMode == types.GeneratorView {
+		return handleGeneratorInput(m, msg)
+	}
+
+	if m.ViewMode == types.NotesView {
+		return handleNotesInput(m, msg)
+	}
+
+	if m.ViewMode == types.ChangelogView {
+		return handleChangelogInput(m, msg)
+	}
+
+	if m.ViewMode == types.HistoryView {
+		return handleHistoryInput(m, msg)
+	}
+
+	if m.ViewMode == types.LibraryView {
+		return handleLibraryInput(m, msg)
+	}
 	
 	switch msg.String() {
 	case "ctrl+q", "alt+q":
-		return tea.Quit
+		return requestQuit(m)
 
 	case "esc":
 		ClearClipboardHighlight(m)
@@ -265,6 +432,149 @@ func HandleKeyInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
 	case "ctrl+r", "alt+r":
 		return handleCtrlR(m)
 
+	case "ctrl+z", "alt+z":
+		RestoreFromTrash(m)
+
+	case "ctrl+t", "alt+t":
+		toggleAuxView(m, types.StatsView)
+
+	case "ctrl+g", "alt+g":
+		toggleAuxView(m, types.VisualizerView)
+
+	case "ctrl+e", "alt+e":
+		toggleRecordingsView(m)
+
+	case "ctrl+a", "alt+a":
+		toggleTimelineView(m)
+
+	case "ctrl+p", "alt+p":
+		m.TogglePitchTracking()
+
+	case "ctrl+n", "alt+n":
+		if m.ViewMode == types.SongView {
+			startCueEdit(m)
+		} else {
+			startNameEdit(m)
+		}
+
+	case "/":
+		if m.ViewMode == types.FileView {
+			return openLibrary(m)
+		}
+		startNameSearch(m)
+
+	case "+":
+		if m.ViewMode == types.FileView {
+			toggleSampleFolder(m)
+		}
+
+	case "ctrl+u", "alt+u":
+		m.StartPreview()
+
+	case "ctrl+w", "alt+w":
+		return ToggleSessionRecording(m)
+
+	case "ctrl+b", "alt+b":
+		return ToggleLoopBounce(m)
+
+	case "ctrl+y", "alt+y":
+		return ToggleInstrumentPrint(m)
+
+	case "X":
+		return ExportPhraseClip(m)
+
+	case "t":
+		if m.ViewMode == types.SoundMakerView {
+			ToggleSoundMakerPLock(m)
+		}
+
+	case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		slot := int(msg.Runes[0] - '0')
+		if m.ViewMode == types.FileView {
+			bindQuickSlot(m, slot)
+		} else if !assignQuickSlot(m, slot) {
+			startNumberEntry(m, msg.String())
+		}
+
+	case "V":
+		openClipboardPicker(m)
+
+	case "D":
+		if m.ViewMode == types.SongView {
+			DuplicateTrack(m, m.CurrentCol)
+		}
+
+	case "W":
+		if m.ViewMode == types.SongView {
+			trackKitKey(m)
+		}
+
+	case "M":
+		if m.ViewMode == types.ChainView {
+			MorphPhrases(m)
+		}
+
+	case "F":
+		openGenerator(m)
+
+	case "U":
+		toggleUsageView(m)
+
+	case "I":
+		toggleAuxView(m, types.InputView)
+
+	case "N":
+		toggleAuxView(m, types.NotesView)
+
+	case "C":
+		return toggleChangelogView(m)
+
+	case "G":
+		m.AuditScroll = 0
+		toggleAuxView(m, types.HistoryView)
+
+	case "R":
+		RevertLastOperation(m)
+
+	case "A":
+		return ToggleSampleAnalysis(m)
+
+	case "E":
+		return toggleEcoMode(m)
+
+	case "T":
+		return toggleTimedStart(m)
+
+	case "Z":
+		m.ToggleLiveKeyboard()
+
+	case "S":
+		toggleTheoryHelper(m)
+
+	case "B":
+		return WriteBugReport(m)
+
+	case "[", "]":
+		// Weight of the song cell for generative song mode
+		if m.ViewMode == types.SongView && m.CurrentRow >= 0 {
+			delta := 1
+			if msg.String() == "[" {
+				delta = -1
+			}
+			m.SetSongWeight(m.CurrentCol, m.CurrentRow, m.SongWeights[m.CurrentCol][m.CurrentRow]+delta)
+		}
+
+	case "=":
+		startRowTempoEdit(m)
+
+	case "<", ">":
+		// Groove of the song row
+		delta := 1
+		if msg.String() == "<" {
+			delta = -1
+		}
+		stepRowGroove(m, delta)
+
 	case "ctrl+f", "alt+f":
 		return handleCtrlF(m)
 
@@ -274,6 +584,16 @@ func HandleKeyInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
 	case "m":
 		return handleM(m)
 
+	case "a":
+		if m.ViewMode == types.MixerView {
+			SwitchMixerCompare(m)
+		}
+
+	case "b":
+		if m.ViewMode == types.MixerView {
+			CopyMixerCompare(m)
+		}
+
 	case "pgdown":
 		return handlePgDown(m)
 
@@ -361,17 +681,21 @@ func HandleKeyInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
 }
 
 func handleShiftRight(m *model.Model) tea.Cmd {
-	if m.ViewMode == types.SongView {
+	if m.ViewMode == types.SettingsView {
+		// The master chain page sits to the right of the settings
+		m.ViewMode = types.MasterChainView
+		return nil
+	} else if m.ViewMode == types.SongView {
 		// Don't navigate when on track type row (row -1)
 		if m.CurrentRow == -1 {
-			log.Printf("Cannot navigate from track type row (Sampler/Instrument toggle)")
+			logging.UI.Warnf("Cannot navigate from track type row (Sampler/Instrument toggle)")
 			return nil
 		}
 
 		// Navigate to chain view for the selected song cell's chain
 		track := m.CurrentCol
 		row := m.CurrentRow
-		chainID := m.SongData[track][row]
+		chainID := m.GetSongCell(track, row)
 
 		if chainID != -1 {
 			// Remember current song position
@@ -383,20 +707,19 @@ func handleShiftRight(m *model.Model) tea.Cmd {
 			m.CurrentChain = chainID // Set which chain we're viewing
 			m.CurrentTrack = track   // Set track context for playback markers
 			m.CurrentRow = 0         // Start at first row of the chain
-			m.CurrentCol = 0         // Only one column in chain view (phrase column)
+			m.CurrentCol = 0         // Start on the phrase column
 			m.ScrollOffset = 0
 
-			log.Printf("Navigated from Song (T%d R%02X) to Chain %02X (Track context: %d)", track, row, chainID, track)
-			storage.AutoSave(m)
+			logging.UI.Debugf("Navigated from Song (T%d R%02X) to Chain %02X (Track context: %d)", track, row, chainID, track)
+			m.Publish(model.Event{Kind: model.EventView})
 		}
 	} else if m.ViewMode == types.ChainView {
 		// Navigate to phrase view for the selected chain row's phrase
-		chainsData := m.GetCurrentChainsData()
-		phraseNum := (*chainsData)[m.CurrentChain][m.CurrentRow]
+		phraseNum := m.GetChainCell(m.CurrentTrack, m.CurrentChain, m.CurrentRow)
 		if phraseNum != -1 {
 			// Remember current chain and row within chain
 			m.LastChainRow = m.CurrentRow
-			log.Printf("Saved LastChainRow = %d (Chain %02X Row %02X)", m.LastChainRow, m.CurrentChain, m.CurrentRow)
+			logging.Storage.Debugf("Saved LastChainRow = %d (Chain %02X Row %02X)", m.LastChainRow, m.CurrentChain, m.CurrentRow)
 			prevPhrase := m.CurrentPhrase
 
 			// Switch to phrase view for the selected phrase
@@ -426,15 +749,14 @@ func handleShiftRight(m *model.Model) tea.Cmd {
 				m.ScrollOffset = m.CurrentRow - visibleRows + 1
 			}
 
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 		}
 	} else if m.ViewMode == types.PhraseView {
 		// Use centralized column mapping to check if we're on RT or TS columns
 		columnMapping := m.GetColumnMapping(m.CurrentCol)
 		if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColRetrigger) {
 			// Navigate to retrigger view only if a retrigger is selected (not -1)
-			phrasesData := m.GetCurrentPhrasesData()
-			retriggerIndex := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColRetrigger]
+			retriggerIndex := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColRetrigger)
 			if retriggerIndex == -1 {
 				return nil // Don't navigate if no retrigger is selected
 			}
@@ -446,13 +768,12 @@ func handleShiftRight(m *model.Model) tea.Cmd {
 			m.CurrentRow = 0 // Start at first setting
 			m.CurrentCol = 0
 			m.ScrollOffset = 0
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 			return nil
 		} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColTimestretch) {
 			// Check if we're on the TS column
 			// Navigate to timestretch view only if a timestretch is selected (not -1)
-			phrasesData := m.GetCurrentPhrasesData()
-			timestrechIndex := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColTimestretch]
+			timestrechIndex := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColTimestretch)
 			if timestrechIndex == -1 {
 				return nil // Don't navigate if no timestretch is selected
 			}
@@ -464,12 +785,11 @@ func handleShiftRight(m *model.Model) tea.Cmd {
 			m.CurrentRow = 0 // Start at first setting
 			m.CurrentCol = 0
 			m.ScrollOffset = 0
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 			return nil
 		} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColModulate) {
 			// Navigate to modulate view - if no modulate is selected, use index 00
-			phrasesData := m.GetCurrentPhrasesData()
-			modulateIndex := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColModulate]
+			modulateIndex := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColModulate)
 			if modulateIndex == -1 {
 				// If no modulate is selected, default to index 00 for settings
 				modulateIndex = 0
@@ -482,12 +802,11 @@ func handleShiftRight(m *model.Model) tea.Cmd {
 			m.CurrentRow = 0 // Start at first setting
 			m.CurrentCol = 0
 			m.ScrollOffset = 0
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 			return nil
 		} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColArpeggio) {
 			// Navigate to arpeggio view only if an arpeggio is selected (not -1)
-			phrasesData := m.GetCurrentPhrasesData()
-			arpeggioIndex := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColArpeggio]
+			arpeggioIndex := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColArpeggio)
 			if arpeggioIndex == -1 {
 				return nil // Don't navigate if no arpeggio is selected
 			}
@@ -498,17 +817,16 @@ func handleShiftRight(m *model.Model) tea.Cmd {
 			m.CurrentRow = 0 // Start at first row
 			m.CurrentCol = 0 // Start at DI column
 			m.ScrollOffset = 0
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 			return nil
 		} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColMidi) {
 			// Navigate to MIDI view - if no MIDI is selected, use 00
-			phrasesData := m.GetCurrentPhrasesData()
-			midiIndex := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColMidi]
+			midiIndex := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColMidi)
 			if midiIndex == -1 {
 				// If no MIDI is selected, default to index 00 for settings
 				midiIndex = 0
 				// Also set the value in the cell to 00 so it shows when we return
-				(*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColMidi] = 0
+				m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColMidi, 0)
 			}
 			// Save current phrase view position
 			m.LastPhraseRow = m.CurrentRow
@@ -518,17 +836,16 @@ func handleShiftRight(m *model.Model) tea.Cmd {
 			m.CurrentRow = 0 // Start at first setting
 			m.CurrentCol = 0 // Start at Device column
 			m.ScrollOffset = 0
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 			return nil
 		} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColSoundMaker) {
 			// Navigate to SoundMaker view - if no SoundMaker is selected, use 00
-			phrasesData := m.GetCurrentPhrasesData()
-			soundMakerIndex := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColSoundMaker]
+			soundMakerIndex := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColSoundMaker)
 			if soundMakerIndex == -1 {
 				// If no SoundMaker is selected, default to index 00 for settings
 				soundMakerIndex = 0
 				// Also set the value in the cell to 00 so it shows when we return
-				(*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColSoundMaker] = 0
+				m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColSoundMaker, 0)
 			}
 			// Save current phrase view position
 			m.LastPhraseRow = m.CurrentRow
@@ -538,17 +855,16 @@ func handleShiftRight(m *model.Model) tea.Cmd {
 			m.CurrentRow = 0 // Start at first setting
 			m.CurrentCol = 0 // Start at Name column
 			m.ScrollOffset = 0
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 			return nil
 		} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColEffectDucking) {
 			// Navigate to ducking view - if no ducking is selected, use 00
-			phrasesData := m.GetCurrentPhrasesData()
-			duckingIndex := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColEffectDucking]
+			duckingIndex := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColEffectDucking)
 			if duckingIndex == -1 {
 				// If no ducking is selected, default to index 00 for settings
 				duckingIndex = 0
 				// Also set the value in the cell to 00 so it shows when we return
-				(*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColEffectDucking] = 0
+				m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColEffectDucking, 0)
 			}
 			// Save current phrase view position
 			m.LastPhraseRow = m.CurrentRow
@@ -558,7 +874,7 @@ func handleShiftRight(m *model.Model) tea.Cmd {
 			m.CurrentRow = 0 // Start at first setting
 			m.CurrentCol = 0
 			m.ScrollOffset = 0
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 			return nil
 		}
 
@@ -567,11 +883,9 @@ func handleShiftRight(m *model.Model) tea.Cmd {
 			// For columns that don't have their own Shift+Right navigation (all except SO/MI and DU),
 			// check if SO/MI column has effective (sticky) values and navigate to the appropriate view
 			if m.CurrentCol != int(types.InstrumentColSOMI) && m.CurrentCol != int(types.InstrumentColDU) {
-				phrasesData := m.GetCurrentPhrasesData()
-
 				// Find effective (sticky) MI and SO values by looking backwards from current row
-				effectiveSoundMakerIndex := getEffectiveValue(phrasesData, m.CurrentPhrase, m.CurrentRow, types.ColSoundMaker)
-				effectiveMidiIndex := getEffectiveValue(phrasesData, m.CurrentPhrase, m.CurrentRow, types.ColMidi)
+				effectiveSoundMakerIndex := GetEffectiveValue(m, m.CurrentPhrase, m.CurrentRow, int(types.ColSoundMaker))
+				effectiveMidiIndex := GetEffectiveValue(m, m.CurrentPhrase, m.CurrentRow, int(types.ColMidi))
 
 				// If both are not null, prefer SoundMaker view
 				if effectiveSoundMakerIndex != -1 {
@@ -583,7 +897,7 @@ func handleShiftRight(m *model.Model) tea.Cmd {
 					m.CurrentRow = 0 // Start at first setting
 					m.CurrentCol = 0 // Start at Name column
 					m.ScrollOffset = 0
-					storage.AutoSave(m)
+					m.Publish(model.Event{Kind: model.EventView})
 					return nil
 				} else if effectiveMidiIndex != -1 {
 					// Navigate to MIDI view
@@ -594,7 +908,7 @@ func handleShiftRight(m *model.Model) tea.Cmd {
 					m.CurrentRow = 0 // Start at first setting
 					m.CurrentCol = 0 // Start at Device column
 					m.ScrollOffset = 0
-					storage.AutoSave(m)
+					m.Publish(model.Event{Kind: model.EventView})
 					return nil
 				}
 			}
@@ -607,8 +921,7 @@ func handleShiftRight(m *model.Model) tea.Cmd {
 
 		// Try to navigate to the folder containing the current row's file
 		selectedFilename := ""
-		phrasesData := m.GetCurrentPhrasesData()
-		fileIndex := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColFilename]
+		fileIndex := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColFilename)
 		phrasesFiles := m.GetCurrentPhrasesFiles()
 		if phrasesFiles != nil && fileIndex >= 0 && fileIndex < len(*phrasesFiles) && (*phrasesFiles)[fileIndex] != "" {
 			// Get the directory of the current file
@@ -619,9 +932,9 @@ func handleShiftRight(m *model.Model) tea.Cmd {
 				// Change to the file's directory
 				m.CurrentDir = fileDir
 			}
-			log.Printf("Navigating to file browser for file: %s in directory: %s", selectedFilename, m.CurrentDir)
+			logging.UI.Debugf("Navigating to file browser for file: %s in directory: %s", selectedFilename, m.CurrentDir)
 		} else {
-			log.Printf("No file for current row, using current directory: %s", m.CurrentDir)
+			logging.UI.Debugf("No file for current row, using current directory: %s", m.CurrentDir)
 		}
 
 		m.ViewMode = types.FileView
@@ -642,13 +955,13 @@ func handleShiftRight(m *model.Model) tea.Cmd {
 					} else if m.CurrentRow < m.ScrollOffset {
 						m.ScrollOffset = m.CurrentRow
 					}
-					log.Printf("Positioned cursor on selected file '%s' at row %d", selectedFilename, i)
+					logging.UI.Debugf("Positioned cursor on selected file '%s' at row %d", selectedFilename, i)
 					break
 				}
 			}
 		}
 
-		storage.AutoSave(m)
+		m.Publish(model.Event{Kind: model.EventView})
 	}
 	return nil
 }
@@ -693,7 +1006,7 @@ func handleShiftUp(m *model.Model) tea.Cmd {
 				fullPath := filepath.Join(m.CurrentDir, selectedFile)
 				m.MetadataEditingFile = fullPath
 				switchToView(m, fileMetadataViewConfig())
-				log.Printf("Opening metadata editor for file: %s", fullPath)
+				logging.UI.Debugf("Opening metadata editor for file: %s", fullPath)
 			}
 		}
 	}
@@ -727,7 +1040,6 @@ func handleShiftDown(m *model.Model) tea.Cmd {
 		case types.ChainView:
 			// Keep CurrentChain as-is, just restore row/col
 			cfg := chainViewConfig(m.LastChainRow)
-			// chainViewConfig sets Col=1; if you prefer 0, change there
 			switchToViewWithVisibilityCheck(m, cfg)
 		case types.PhraseView:
 			// Go back to the last phrase row; keep whatever column policy you want
@@ -768,8 +1080,8 @@ func handleShiftLeft(m *model.Model) tea.Cmd {
 			m.CurrentCol = m.LastSongTrack
 			m.ScrollOffset = 0
 
-			log.Printf("Navigated back from Chain to Song (T%d R%02X)", m.CurrentCol, m.CurrentRow)
-			storage.AutoSave(m)
+			logging.UI.Debugf("Navigated back from Chain to Song (T%d R%02X)", m.CurrentCol, m.CurrentRow)
+			m.Publish(model.Event{Kind: model.EventView})
 			return nil
 		}
 		// Otherwise, stay in chain view (no song context)
@@ -777,13 +1089,13 @@ func handleShiftLeft(m *model.Model) tea.Cmd {
 		// Navigate back to chain view
 		// Remember current phrase row
 		m.LastPhraseRow = m.CurrentRow
-		log.Printf("Returning to Chain view, Chain = %d, LastChainRow = %d", m.CurrentChain, m.LastChainRow)
+		logging.UI.Debugf("Returning to Chain view, Chain = %d, LastChainRow = %d", m.CurrentChain, m.LastChainRow)
 		// Return to the same position within the chain
 		m.ViewMode = types.ChainView
 		m.CurrentRow = m.LastChainRow
 		m.CurrentCol = 0
 		m.ScrollOffset = 0
-		storage.AutoSave(m)
+		m.Publish(model.Event{Kind: model.EventView})
 	} else if m.ViewMode == types.FileView {
 		// Navigate back to phrase view - return to the column we came from
 		switchToView(m, phraseViewConfig(m.FileSelectRow, m.FileSelectCol)) // Go back to original column
@@ -970,7 +1282,7 @@ func handleUp(m *model.Model) tea.Cmd {
 			m.LastChainRow = m.CurrentRow
 		}
 	}
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventView})
 	return nil
 }
 
@@ -987,18 +1299,13 @@ func handleDown(m *model.Model) tea.Cmd {
 			m.CurrentRow = m.CurrentRow + 1
 		}
 	} else if m.ViewMode == types.SettingsView {
-		// Column 0 (Global): BPM to Shimmer, Column 1 (Input): InputLevelDB to ReverbSendPercent
-		var maxRow int
-		if m.CurrentCol == 0 {
-			maxRow = int(types.GlobalSettingsRowShimmerPercent) // Global column: BPM(0) to Shimmer(8)
-		} else {
-			maxRow = int(types.InputSettingsRowReverbSendPercent) // Input column: InputLevelDB(0) to ReverbSendPercent(1)
-		}
+		// Each settings column has its own number of rows
+		maxRow := settingsColumnMaxRow(m.CurrentCol)
 		if m.CurrentRow < maxRow {
 			m.CurrentRow = m.CurrentRow + 1
 		}
 	} else if m.ViewMode == types.FileMetadataView {
-		if m.CurrentRow < int(types.FileMetadataRowSyncToBPM) { // BPM(0) to SyncToBPM(3)
+		if m.CurrentRow < int(types.FileMetadataRowOnsetPreset) { // BPM(0) to OnsetPreset(5)
 			m.CurrentRow = m.CurrentRow + 1
 		}
 	} else if m.ViewMode == types.RetriggerView {
@@ -1066,8 +1373,10 @@ func handleDown(m *model.Model) tea.Cmd {
 			m.CurrentRow = m.CurrentRow + 1
 		}
 	} else if m.ViewMode == types.MixerView {
-		// Only row 0 (set level) exists now, no navigation needed
-		// Keep CurrentMixerRow at 0
+		// Rows 1-5 (resolution, record quantize, strength and humanize) exist for tracks 1-8 but not for Input
+		if m.CurrentMixerRow < 5 && m.CurrentMixerTrack < types.NumTracks {
+			m.CurrentMixerRow = m.CurrentMixerRow + 1
+		}
 	} else if m.ViewMode == types.FileView {
 		// Ensure we don't go beyond the last file
 		if len(m.Files) > 0 && m.CurrentRow < len(m.Files)-1 {
@@ -1084,7 +1393,7 @@ func handleDown(m *model.Model) tea.Cmd {
 			if m.CurrentRow == -1 {
 				m.CurrentRow = 0
 				m.LastPhraseRow = 0
-			} else if m.CurrentRow < 254 { // Standard navigation for data rows
+			} else if m.CurrentRow < types.PhraseRows-1 { // Standard navigation for data rows
 				m.CurrentRow = m.CurrentRow + 1
 				visibleRows := m.GetVisibleRows()
 				if m.CurrentRow >= m.ScrollOffset+visibleRows {
@@ -1094,7 +1403,7 @@ func handleDown(m *model.Model) tea.Cmd {
 			}
 		} else {
 			// Standard navigation for other columns
-			if m.CurrentRow < 254 {
+			if m.CurrentRow < types.PhraseRows-1 {
 				m.CurrentRow = m.CurrentRow + 1
 				visibleRows := m.GetVisibleRows()
 				if m.CurrentRow >= m.ScrollOffset+visibleRows {
@@ -1114,7 +1423,7 @@ func handleDown(m *model.Model) tea.Cmd {
 			m.LastChainRow = m.CurrentRow
 		}
 	}
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventView})
 	return nil
 }
 
@@ -1123,12 +1432,14 @@ func handleLeft(m *model.Model) tea.Cmd {
 		if m.CurrentCol > 0 { // 8 tracks: 0-7
 			m.CurrentCol = m.CurrentCol - 1
 			m.LastSongTrack = m.CurrentCol
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 		}
 	} else if m.ViewMode == types.ChainView {
-		if m.CurrentChain > 0 { // Switch to previous chain
+		if m.CurrentCol > int(types.ChainColPhrase) { // Move left through the chain columns
+			m.CurrentCol = m.CurrentCol - 1
+		} else if m.CurrentChain > 0 { // Switch to previous chain
 			m.CurrentChain = m.CurrentChain - 1
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 		}
 	} else if m.ViewMode == types.PhraseView {
 		// Handle different column limits based on view type
@@ -1142,12 +1453,12 @@ func handleLeft(m *model.Model) tea.Cmd {
 
 		if m.CurrentCol > minCol {
 			m.CurrentCol = m.CurrentCol - 1
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 		}
 	} else if m.ViewMode == types.ArpeggioView {
 		if m.CurrentCol > int(types.ArpeggioColDI) { // 3 columns: DI, CO, Divisor
 			m.CurrentCol = m.CurrentCol - 1
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 		}
 	} else if m.ViewMode == types.FileView {
 		// Left arrow = go up one folder (same as pressing space on "..")
@@ -1165,7 +1476,7 @@ func handleLeft(m *model.Model) tea.Cmd {
 			storage.LoadFiles(m)
 			m.CurrentRow = 0
 			m.ScrollOffset = 0
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 		}
 	} else if m.ViewMode == types.MidiView {
 		// No horizontal navigation in MIDI view - use up/down for settings
@@ -1182,21 +1493,21 @@ func handleLeft(m *model.Model) tea.Cmd {
 					m.CurrentRow = len(col0)
 				}
 			}
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 		}
 	} else if m.ViewMode == types.SettingsView {
-		if m.CurrentCol > 0 { // Switch between Global (0) and Input (1) columns
+		if m.CurrentCol > 0 { // Switch between Global (0), Input (1), Reverb (2) and App (3) columns
 			m.CurrentCol = m.CurrentCol - 1
 			// Adjust row if it's beyond the bounds of the new column
-			if m.CurrentCol == 0 && m.CurrentRow > int(types.GlobalSettingsRowShimmerPercent) {
-				m.CurrentRow = int(types.GlobalSettingsRowShimmerPercent) // Global column max is 8
+			if m.CurrentRow > settingsColumnMaxRow(m.CurrentCol) {
+				m.CurrentRow = settingsColumnMaxRow(m.CurrentCol)
 			}
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 		}
 	} else if m.ViewMode == types.MixerView {
 		if m.CurrentMixerTrack > 0 { // Select previous track (0-7)
 			m.CurrentMixerTrack = m.CurrentMixerTrack - 1
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 		}
 	} else { // FileView
 		// No horizontal navigation in file view
@@ -1209,31 +1520,33 @@ func handleRight(m *model.Model) tea.Cmd {
 		if m.CurrentCol < 7 { // 8 tracks: 0-7
 			m.CurrentCol = m.CurrentCol + 1
 			m.LastSongTrack = m.CurrentCol
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 		}
 	} else if m.ViewMode == types.ChainView {
-		if m.CurrentChain < 254 { // Switch to next chain (0-254)
+		if m.CurrentCol < int(types.ChainColLowPass) { // Move right through the chain columns
+			m.CurrentCol = m.CurrentCol + 1
+		} else if m.CurrentChain < types.NumChains-1 { // Switch to next chain (0-254)
 			m.CurrentChain = m.CurrentChain + 1
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 		}
 	} else if m.ViewMode == types.PhraseView {
 		// Handle different column limits based on view type
 		phraseViewType := m.GetPhraseViewType()
 		var maxValidCol int
 		if phraseViewType == types.InstrumentPhraseView {
-			maxValidCol = int(types.InstrumentColDU) // Instrument: last valid column is DU (Ducking)
+			maxValidCol = int(types.InstrumentColN4) // Instrument: last valid column is N4 (stacked note 4)
 		} else {
 			maxValidCol = int(types.SamplerColFI) // Sampler: last valid column is FI (Filename)
 		}
 
 		if m.CurrentCol < maxValidCol {
 			m.CurrentCol = m.CurrentCol + 1
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 		}
 	} else if m.ViewMode == types.ArpeggioView {
 		if m.CurrentCol < int(types.ArpeggioColDIV) { // 3 columns: DI, CO, Divisor
 			m.CurrentCol = m.CurrentCol + 1
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 		}
 	} else if m.ViewMode == types.FileView {
 		// Right arrow = enter current folder/file (same as pressing space)
@@ -1253,7 +1566,7 @@ func handleRight(m *model.Model) tea.Cmd {
 				storage.LoadFiles(m)
 				m.CurrentRow = 0
 				m.ScrollOffset = 0
-				storage.AutoSave(m)
+				m.Publish(model.Event{Kind: model.EventView})
 			}
 		}
 	} else if m.ViewMode == types.MidiView {
@@ -1270,22 +1583,25 @@ func handleRight(m *model.Model) tea.Cmd {
 				if m.CurrentRow > len(col1) {
 					m.CurrentRow = len(col1)
 				}
-				storage.AutoSave(m)
+				m.Publish(model.Event{Kind: model.EventView})
 			}
 		}
 	} else if m.ViewMode == types.SettingsView {
-		if m.CurrentCol < 1 { // Switch between Global (0) and Input (1) columns
+		if m.CurrentCol < settingsColumnCount-1 { // Switch between Global (0), Input (1), Reverb (2) and App (3) columns
 			m.CurrentCol = m.CurrentCol + 1
 			// Adjust row if it's beyond the bounds of the new column
-			if m.CurrentCol == 1 && m.CurrentRow > 1 {
-				m.CurrentRow = 1 // Input column max is 1
+			if m.CurrentRow > settingsColumnMaxRow(m.CurrentCol) {
+				m.CurrentRow = settingsColumnMaxRow(m.CurrentCol)
 			}
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventView})
 		}
 	} else if m.ViewMode == types.MixerView {
-		if m.CurrentMixerTrack < 8 { // Select next track (0-8, including Input track)
+		if m.CurrentMixerTrack < types.NumTracks { // Select next track (0-8, including Input track)
 			m.CurrentMixerTrack = m.CurrentMixerTrack + 1
-			storage.AutoSave(m)
+			if m.CurrentMixerTrack == 8 {
+				m.CurrentMixerRow = 0 // Input has only the level row
+			}
+			m.Publish(model.Event{Kind: model.EventView})
 		}
 	} else { // FileView
 		// No horizontal navigation in file view
@@ -1294,7 +1610,30 @@ func handleRight(m *model.Model) tea.Cmd {
 }
 
 func handleCtrlS(m *model.Model) tea.Cmd {
-	storage.DoSave(m)
+	// Save now instead of waiting for the autosave
+	storage.Flush(m)
+	// Deleted items are only restorable until an explicit save
+	m.ClearTrash()
+	m.Notice = "Saved"
+	return nil
+}
+
+// requestQuit quits, first asking whether to save if there are unsaved changes
+func requestQuit(m *model.Model) tea.Cmd {
+	if !m.IsDirty() {
+		return tea.Quit
+	}
+	m.PendingConfirm = &model.ConfirmPrompt{
+		Message: "Unsaved changes. Save before quitting?",
+		OnConfirm: func() {
+			storage.Flush(m)
+		},
+		OnDecline: func() {
+			storage.CancelAutoSave()
+			logging.UI.Debugf("Quitting without saving")
+		},
+		Quit: true,
+	}
 	return nil
 }
 
@@ -1313,13 +1652,13 @@ func handleCtrlUp(m *model.Model) tea.Cmd {
 			if m.CurrentCol == int(types.InstrumentColSOMI) {
 				// Switch to MI mode
 				m.SOColumnMode = types.SOModeMIDI
-				storage.AutoSave(m)
+				m.Publish(model.Event{Kind: model.EventSettings})
 			} else if m.SOColumnMode == types.SOModeMIDI {
 				// Modify CC number for CC columns
 				ccIndex := getCCColumnIndex(m.CurrentCol)
 				if ccIndex != -1 {
 					m.MidiCCNumbers[ccIndex] = clampInt(m.MidiCCNumbers[ccIndex]+16, 0, 127)
-					storage.AutoSave(m)
+					m.Publish(model.Event{Kind: model.EventSettings})
 				}
 			}
 		} else {
@@ -1345,8 +1684,19 @@ func handleCtrlUp(m *model.Model) tea.Cmd {
 	} else if m.ViewMode == types.DuckingView {
 		ModifyDuckingValue(m, 1.0)
 	} else if m.ViewMode == types.MixerView {
-		if m.CurrentMixerRow == 0 {
+		switch m.CurrentMixerRow {
+		case 0:
 			ModifyMixerSetLevel(m, 1.0) // Coarse increment for set level
+		case 1:
+			ModifyMixerResolution(m, 1)
+		case 2:
+			ModifyMixerRecordQuantize(m, 1)
+		case 3:
+			ModifyMixerRecordQuantizeStrength(m, 10)
+		case 4:
+			ModifyMixerHumanizeTiming(m, 4)
+		default:
+			ModifyMixerHumanizeVelocity(m, 16)
 		}
 	} else if m.ViewMode != types.FileView {
 		ModifyValue(m, 16)
@@ -1369,13 +1719,13 @@ func handleCtrlDown(m *model.Model) tea.Cmd {
 			if m.CurrentCol == int(types.InstrumentColSOMI) {
 				// Switch to SO mode
 				m.SOColumnMode = types.SOModeSound
-				storage.AutoSave(m)
+				m.Publish(model.Event{Kind: model.EventSettings})
 			} else if m.SOColumnMode == types.SOModeMIDI {
 				// Modify CC number for CC columns
 				ccIndex := getCCColumnIndex(m.CurrentCol)
 				if ccIndex != -1 {
 					m.MidiCCNumbers[ccIndex] = clampInt(m.MidiCCNumbers[ccIndex]-16, 0, 127)
-					storage.AutoSave(m)
+					m.Publish(model.Event{Kind: model.EventSettings})
 				}
 			}
 		} else {
@@ -1401,8 +1751,19 @@ func handleCtrlDown(m *model.Model) tea.Cmd {
 	} else if m.ViewMode == types.DuckingView {
 		ModifyDuckingValue(m, -1.0)
 	} else if m.ViewMode == types.MixerView {
-		if m.CurrentMixerRow == 0 {
+		switch m.CurrentMixerRow {
+		case 0:
 			ModifyMixerSetLevel(m, -1.0) // Coarse decrement for set level
+		case 1:
+			ModifyMixerResolution(m, -1)
+		case 2:
+			ModifyMixerRecordQuantize(m, -1)
+		case 3:
+			ModifyMixerRecordQuantizeStrength(m, -10)
+		case 4:
+			ModifyMixerHumanizeTiming(m, -4)
+		default:
+			ModifyMixerHumanizeVelocity(m, -16)
 		}
 	} else if m.ViewMode != types.FileView {
 		ModifyValue(m, -16)
@@ -1424,13 +1785,13 @@ func handleCtrlLeft(m *model.Model) tea.Cmd {
 			if m.CurrentCol == int(types.InstrumentColSOMI) {
 				// Switch to SO mode
 				m.SOColumnMode = types.SOModeSound
-				storage.AutoSave(m)
+				m.Publish(model.Event{Kind: model.EventSettings})
 			} else if m.SOColumnMode == types.SOModeMIDI {
 				// Modify CC number for CC columns
 				ccIndex := getCCColumnIndex(m.CurrentCol)
 				if ccIndex != -1 {
 					m.MidiCCNumbers[ccIndex] = clampInt(m.MidiCCNumbers[ccIndex]-1, 0, 127)
-					storage.AutoSave(m)
+					m.Publish(model.Event{Kind: model.EventSettings})
 				}
 			}
 		} else {
@@ -1456,8 +1817,19 @@ func handleCtrlLeft(m *model.Model) tea.Cmd {
 	} else if m.ViewMode == types.DuckingView {
 		ModifyDuckingValue(m, -0.05)
 	} else if m.ViewMode == types.MixerView {
-		if m.CurrentMixerRow == 0 {
+		switch m.CurrentMixerRow {
+		case 0:
 			ModifyMixerSetLevel(m, -0.05) // Fine decrement for set level
+		case 1:
+			ModifyMixerResolution(m, -1)
+		case 2:
+			ModifyMixerRecordQuantize(m, -1)
+		case 3:
+			ModifyMixerRecordQuantizeStrength(m, -1)
+		case 4:
+			ModifyMixerHumanizeTiming(m, -1)
+		default:
+			ModifyMixerHumanizeVelocity(m, -1)
 		}
 	} else if m.ViewMode != types.FileView {
 		ModifyValue(m, -1)
@@ -1479,13 +1851,13 @@ func handleCtrlRight(m *model.Model) tea.Cmd {
 			if m.CurrentCol == int(types.InstrumentColSOMI) {
 				// Switch to MI mode
 				m.SOColumnMode = types.SOModeMIDI
-				storage.AutoSave(m)
+				m.Publish(model.Event{Kind: model.EventSettings})
 			} else if m.SOColumnMode == types.SOModeMIDI {
 				// Modify CC number for CC columns
 				ccIndex := getCCColumnIndex(m.CurrentCol)
 				if ccIndex != -1 {
 					m.MidiCCNumbers[ccIndex] = clampInt(m.MidiCCNumbers[ccIndex]+1, 0, 127)
-					storage.AutoSave(m)
+					m.Publish(model.Event{Kind: model.EventSettings})
 				}
 			}
 		} else {
@@ -1513,8 +1885,19 @@ func handleCtrlRight(m *model.Model) tea.Cmd {
 	} else if m.ViewMode == types.DuckingView {
 		ModifyDuckingValue(m, 0.05)
 	} else if m.ViewMode == types.MixerView {
-		if m.CurrentMixerRow == 0 {
+		switch m.CurrentMixerRow {
+		case 0:
 			ModifyMixerSetLevel(m, 0.05) // Fine increment for set level
+		case 1:
+			ModifyMixerResolution(m, 1)
+		case 2:
+			ModifyMixerRecordQuantize(m, 1)
+		case 3:
+			ModifyMixerRecordQuantizeStrength(m, 1)
+		case 4:
+			ModifyMixerHumanizeTiming(m, 1)
+		default:
+			ModifyMixerHumanizeVelocity(m, 1)
 		}
 	} else {
 		ModifyValue(m, 1)
@@ -1525,7 +1908,7 @@ func handleCtrlRight(m *model.Model) tea.Cmd {
 func handleS(m *model.Model) tea.Cmd {
 	if m.ViewMode != types.FileView && m.ViewMode != types.SettingsView && m.ViewMode != types.FileMetadataView {
 		PasteLastEditedRow(m)
-		storage.AutoSave(m)
+		m.Publish(model.Event{Kind: model.EventSettings})
 	}
 	return nil
 }
@@ -1546,13 +1929,13 @@ func handleC(m *model.Model) tea.Cmd {
 		if m.CurrentlyPlayingFile != "" {
 			m.SendOSCPlaybackMessage(m.CurrentlyPlayingFile, false)
 			m.CurrentlyPlayingFile = ""
-			log.Printf("Stopped file browser playback when stopping via 'C'")
+			logging.UI.Debugf("Stopped file browser playback when stopping via 'C'")
 		}
 
 		// Send OSC "/stop" with no params (tiny helper on the model)
 		m.SendStopOSC()
 
-		log.Printf("Playback stopped via 'C'")
+		logging.Playback.Debugf("Playback stopped via 'C'")
 		return nil
 	}
 
@@ -1561,35 +1944,33 @@ func handleC(m *model.Model) tea.Cmd {
 		if IsRowEmpty(m) {
 			// If row is empty, do the copy operation
 			CopyLastRowWithIncrement(m)
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventSettings})
 		} else {
 			// If row is not empty, emit the row data
 			EmitRowData(m)
 		}
 	} else if m.ViewMode == types.ChainView {
-		chainsData := m.GetCurrentChainsData()
-		if (*chainsData)[m.CurrentChain][m.CurrentRow] == -1 {
+		if m.GetChainCell(m.CurrentTrack, m.CurrentChain, m.CurrentRow) == -1 {
 			// If chain slot is empty, fill it with next unused phrase
 			seed := 254 // 254 => first check will be 0 (wrap-around)
-			if m.CurrentRow > 0 && (*chainsData)[m.CurrentChain][m.CurrentRow-1] != -1 {
-				seed = (*chainsData)[m.CurrentChain][m.CurrentRow-1]
+			if m.CurrentRow > 0 && m.GetChainCell(m.CurrentTrack, m.CurrentChain, m.CurrentRow-1) != -1 {
+				seed = m.GetChainCell(m.CurrentTrack, m.CurrentChain, m.CurrentRow-1)
 			}
 
 			next := FindNextUnusedPhrase(m, seed)
 			if next == -1 {
-				log.Printf("No unused phrases available")
+				logging.UI.Debugf("No unused phrases available")
 				return nil
 			}
 
-			(*chainsData)[m.CurrentChain][m.CurrentRow] = next
-			log.Printf("Filled Chain %02X Row %02X with next empty phrase %02X",
+			m.SetChainCell(m.CurrentTrack, m.CurrentChain, m.CurrentRow, next)
+			logging.UI.Debugf("Filled Chain %02X Row %02X with next empty phrase %02X",
 				m.CurrentChain, m.CurrentRow, next)
-			storage.AutoSave(m)
 		} else {
 			// If chain slot is not empty, emit the phrase data for that slot
-			phraseNumber := (*chainsData)[m.CurrentChain][m.CurrentRow]
+			phraseNumber := m.GetChainCell(m.CurrentTrack, m.CurrentChain, m.CurrentRow)
 			EmitRowDataFor(m, phraseNumber, 0, m.CurrentTrack) // Emit first row of the phrase
-			log.Printf("Emitting data for Chain %02X Row %02X -> Phrase %02X",
+			logging.Playback.Debugf("Emitting data for Chain %02X Row %02X -> Phrase %02X",
 				m.CurrentChain, m.CurrentRow, phraseNumber)
 		}
 		return nil
@@ -1597,41 +1978,40 @@ func handleC(m *model.Model) tea.Cmd {
 		track := m.CurrentCol
 		row := m.CurrentRow
 
-		if m.SongData[track][row] == -1 {
+		if m.GetSongCell(track, row) == -1 {
 			// If song slot is empty, fill it with next unused chain
 			seed := 254 // 254 => first check will be 0 (wrap-around)
-			if row > 0 && m.SongData[track][row-1] != -1 {
-				seed = m.SongData[track][row-1]
+			if row > 0 && m.GetSongCell(track, row-1) != -1 {
+				seed = m.GetSongCell(track, row-1)
 			}
 
+			m.CurrentTrack = track // The unused search follows the current track's pool and bank
 			next := FindNextUnusedChain(m, seed)
 			if next == -1 {
-				log.Printf("No unused chains available")
+				logging.UI.Debugf("No unused chains available")
 				return nil
 			}
 
-			m.SongData[track][row] = next
-			log.Printf("Filled Song T%d R%02X with next empty chain %02X", track, row, next)
-			storage.AutoSave(m)
+			m.SetSongCell(track, row, next)
+			logging.UI.Debugf("Filled Song T%d R%02X with next empty chain %02X", track, row, next)
 		} else {
 			// If song slot is not empty, emit the chain data for that slot
-			chainNumber := m.SongData[track][row]
+			chainNumber := m.GetSongCell(track, row)
 			// Find the first non-empty phrase in this chain
-			chainsData := m.GetChainsDataForTrack(track)
 			firstPhraseNumber := -1
-			for chainRow := 0; chainRow < 16; chainRow++ {
-				if (*chainsData)[chainNumber][chainRow] != -1 {
-					firstPhraseNumber = (*chainsData)[chainNumber][chainRow]
+			for chainRow := 0; chainRow < types.ChainRows; chainRow++ {
+				if m.GetChainCell(track, chainNumber, chainRow) != -1 {
+					firstPhraseNumber = m.GetChainCell(track, chainNumber, chainRow)
 					break
 				}
 			}
 
 			if firstPhraseNumber != -1 {
 				EmitRowDataFor(m, firstPhraseNumber, 0, track) // Emit first row of the first phrase
-				log.Printf("Emitting data for Song T%d R%02X -> Chain %02X -> Phrase %02X",
+				logging.Playback.Debugf("Emitting data for Song T%d R%02X -> Chain %02X -> Phrase %02X",
 					track, row, chainNumber, firstPhraseNumber)
 			} else {
-				log.Printf("Chain %02X is empty, cannot emit data", chainNumber)
+				logging.UI.Debugf("Chain %02X is empty, cannot emit data", chainNumber)
 			}
 		}
 		return nil
@@ -1662,19 +2042,19 @@ func handleCtrlC(m *model.Model) tea.Cmd {
 
 func handleCtrlX(m *model.Model) tea.Cmd {
 	CutRowToClipboard(m)
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventSettings})
 	return nil
 }
 
 func handleCtrlV(m *model.Model) tea.Cmd {
 	PasteFromClipboard(m)
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventSettings})
 	return nil
 }
 
 func handleCtrlD(m *model.Model) tea.Cmd {
 	DeepCopyToClipboard(m)
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventSettings})
 	return nil
 }
 
@@ -1688,8 +2068,8 @@ func handleSpace(m *model.Model) tea.Cmd {
 			deviceIndex := m.CurrentRow - 2 + m.ScrollOffset
 			selectedDevice := m.AvailableMidiDevices[deviceIndex]
 			m.MidiSettings[m.MidiEditingIndex].Device = selectedDevice
-			log.Printf("Selected MIDI device: %s for MIDI %02X", selectedDevice, m.MidiEditingIndex)
-			storage.AutoSave(m)
+			logging.MIDI.Debugf("Selected MIDI device: %s for MIDI %02X", selectedDevice, m.MidiEditingIndex)
+			m.Publish(model.Event{Kind: model.EventSettings})
 		}
 		return nil
 	} else if m.ViewMode == types.SoundMakerView {
@@ -1699,8 +2079,8 @@ func handleSpace(m *model.Model) tea.Cmd {
 			soundMakerIndex := m.CurrentRow - 5 + m.ScrollOffset
 			selectedSoundMaker := availableSoundMakers[soundMakerIndex]
 			m.SoundMakerSettings[m.SoundMakerEditingIndex].Name = selectedSoundMaker
-			log.Printf("Selected SoundMaker: %s for SoundMaker %02X", selectedSoundMaker, m.SoundMakerEditingIndex)
-			storage.AutoSave(m)
+			logging.UI.Debugf("Selected SoundMaker: %s for SoundMaker %02X", selectedSoundMaker, m.SoundMakerEditingIndex)
+			m.Publish(model.Event{Kind: model.EventSettings})
 		}
 		return nil
 	} else if m.ViewMode == types.SongView {
@@ -1720,18 +2100,29 @@ func handleCtrlSpace(m *model.Model) tea.Cmd {
 func handleBackspace(m *model.Model) tea.Cmd {
 	if m.ViewMode == types.SongView {
 		// Clear chain ID in song view
-		m.SongData[m.CurrentCol][m.CurrentRow] = -1
-		log.Printf("Cleared song track %d row %02X chain", m.CurrentCol, m.CurrentRow)
-		storage.AutoSave(m)
+		if m.CurrentRow >= 0 {
+			DeleteSongCell(m, m.CurrentCol, m.CurrentRow)
+		}
 	} else if m.ViewMode == types.ChainView {
-		// Clear phrase number in chain view (works from any column)
-		chainsData := m.GetCurrentChainsData()
-		(*chainsData)[m.CurrentChain][m.CurrentRow] = -1
-		log.Printf("Cleared chain %d phrase", m.CurrentRow)
-		storage.AutoSave(m)
+		switch types.ChainColumn(m.CurrentCol) {
+		case types.ChainColTranspose:
+			// Reset the slot's transpose
+			m.SetChainTranspose(m.CurrentTrack, m.CurrentChain, m.CurrentRow, 0)
+		case types.ChainColReverb, types.ChainColLowPass:
+			// Clear the slot's effect override
+			fx := m.GetChainFX(m.CurrentTrack, m.CurrentChain, m.CurrentRow)
+			if types.ChainColumn(m.CurrentCol) == types.ChainColReverb {
+				fx.Reverb = -1
+			} else {
+				fx.LowPass = -1
+			}
+			m.SetChainFX(m.CurrentTrack, m.CurrentChain, m.CurrentRow, fx)
+		default:
+			// Clear phrase number in chain view
+			DeleteChainRow(m)
+		}
 	} else if m.ViewMode == types.PhraseView {
 		// Clear the current cell in phrase view
-		phrasesData := m.GetCurrentPhrasesData()
 
 		// Use centralized column mapping system
 		columnMapping := m.GetColumnMapping(m.CurrentCol)
@@ -1741,16 +2132,19 @@ func handleBackspace(m *model.Model) tea.Cmd {
 
 		colIndex := columnMapping.DataColumnIndex
 
-		if colIndex >= 0 && colIndex < int(types.ColCount) {
+		if colIndex == int(types.ColFilename) {
+			// Removing a sample goes through confirmation and trash
+			DeletePhraseSample(m)
+		} else if colIndex >= 0 && colIndex < int(types.ColCount) {
 			if colIndex == int(types.ColDeltaTime) {
 				// Reset DT to -1 (means skip/not played)
-				(*phrasesData)[m.CurrentPhrase][m.CurrentRow][colIndex] = -1
+				m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(colIndex), -1)
 			} else {
 				// Clear all other columns to -1 (including GT, PI, RT, etc.)
-				(*phrasesData)[m.CurrentPhrase][m.CurrentRow][colIndex] = -1
+				m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(colIndex), -1)
 			}
-			log.Printf("Cleared phrase %d row %d col %d", m.CurrentPhrase, m.CurrentRow, colIndex)
-			storage.AutoSave(m)
+			logging.UI.Debugf("Cleared phrase %d row %d col %d", m.CurrentPhrase, m.CurrentRow, colIndex)
+			m.Publish(model.Event{Kind: model.EventSettings})
 		}
 	} else if m.ViewMode == types.ArpeggioView {
 		// Clear the current cell in arpeggio view
@@ -1760,15 +2154,15 @@ func handleBackspace(m *model.Model) tea.Cmd {
 		switch m.CurrentCol {
 		case 0: // Direction column
 			currentRow.Direction = int(types.ArpeggioDirectionNone) // Clear to "--"
-			log.Printf("Cleared arpeggio %02X row %02X Direction", m.ArpeggioEditingIndex, m.CurrentRow)
+			logging.UI.Debugf("Cleared arpeggio %02X row %02X Direction", m.ArpeggioEditingIndex, m.CurrentRow)
 		case 1: // Count column
 			currentRow.Count = -1 // Clear to "--"
-			log.Printf("Cleared arpeggio %02X row %02X Count", m.ArpeggioEditingIndex, m.CurrentRow)
+			logging.UI.Debugf("Cleared arpeggio %02X row %02X Count", m.ArpeggioEditingIndex, m.CurrentRow)
 		case 2: // Divisor column
 			currentRow.Divisor = -1 // Clear to "--"
-			log.Printf("Cleared arpeggio %02X row %02X Divisor", m.ArpeggioEditingIndex, m.CurrentRow)
+			logging.UI.Debugf("Cleared arpeggio %02X row %02X Divisor", m.ArpeggioEditingIndex, m.CurrentRow)
 		}
-		storage.AutoSave(m)
+		m.Publish(model.Event{Kind: model.EventSettings})
 	}
 	return nil
 }
@@ -1776,25 +2170,10 @@ func handleBackspace(m *model.Model) tea.Cmd {
 func handleCtrlH(m *model.Model) tea.Cmd {
 	if m.ViewMode == types.ChainView {
 		// Delete entire chain row (clear phrase, keep chain number)
-		chainsData := m.GetCurrentChainsData()
-		(*chainsData)[m.CurrentChain][m.CurrentRow] = -1
-		log.Printf("Deleted chain %d row (cleared phrase)", m.CurrentRow)
-		storage.AutoSave(m)
+		DeleteChainRow(m)
 	} else if m.ViewMode == types.PhraseView {
 		// Delete entire phrase row (clear all columns)
-		phrasesData := m.GetCurrentPhrasesData()
-		phraseViewType := m.GetPhraseViewType()
-		if phraseViewType == types.InstrumentPhraseView {
-		}
-		(*phrasesData)[m.CurrentPhrase][m.CurrentRow][int(types.ColNote)] = -1          // Clear note
-		(*phrasesData)[m.CurrentPhrase][m.CurrentRow][int(types.ColPitch)] = -1         // Clear pitch (displays "--", behaves as 80)
-		(*phrasesData)[m.CurrentPhrase][m.CurrentRow][int(types.ColDeltaTime)] = -1     // Clear deltatime (for samplers this also clears playback)
-		(*phrasesData)[m.CurrentPhrase][m.CurrentRow][int(types.ColGate)] = -1          // Clear gate (displays "--", behaves as 80)
-		(*phrasesData)[m.CurrentPhrase][m.CurrentRow][int(types.ColRetrigger)] = -1     // Clear retrigger
-		(*phrasesData)[m.CurrentPhrase][m.CurrentRow][int(types.ColEffectDucking)] = -1 // Clear ducking
-		(*phrasesData)[m.CurrentPhrase][m.CurrentRow][int(types.ColFilename)] = -1      // Clear filename
-		log.Printf("Deleted phrase %d row %d (cleared all columns)", m.CurrentPhrase, m.CurrentRow)
-		storage.AutoSave(m)
+		DeletePhraseRow(m)
 	}
 	return nil
 }
@@ -1804,20 +2183,20 @@ func handleCtrlR(m *model.Model) tea.Cmd {
 	m.RecordingEnabled = !m.RecordingEnabled
 
 	if m.RecordingEnabled {
-		log.Printf("Recording enabled (queued)")
+		logging.UI.Debugf("Recording enabled (queued)")
 		// If playback is already active, start recording immediately
 		if m.IsPlaying {
 			startRecording(m)
 		}
 	} else {
-		log.Printf("Recording disabled")
+		logging.UI.Debugf("Recording disabled")
 		// If recording is currently active, stop it
 		if m.RecordingActive {
 			stopRecording(m)
 		}
 	}
 
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventView})
 	return nil
 }
 
@@ -1830,7 +2209,10 @@ func startRecordingWithContext(m *model.Model, fromSongView bool, fromCtrlSpace
 		return
 	}
 
-	// Generate timestamped filename
+	// Generate timestamped filename in the project's recordings folder
+	if err := os.MkdirAll(m.RecordingsFolder(), 0755); err != nil {
+		logging.UI.Errorf("Error creating recordings folder: %v", err)
+	}
 	filename := m.GenerateRecordingFilename()
 	m.CurrentRecordingFile = filename
 	m.RecordingActive = true
@@ -1840,7 +2222,7 @@ func startRecordingWithContext(m *model.Model, fromSongView bool, fromCtrlSpace
 
 	// Send OSC message to start recording with track mask
 	m.SendOSCRecordMessage(filename, true, trackMask)
-	log.Printf("Recording started: %s (tracks: 0x%04X)", filename, trackMask)
+	logging.UI.Debugf("Recording started: %s (tracks: 0x%04X)", filename, trackMask)
 }
 
 func stopRecording(m *model.Model) {
@@ -1850,7 +2232,7 @@ func stopRecording(m *model.Model) {
 
 	// Send OSC message to stop recording with the same filename (track mask 0 for stop)
 	m.SendOSCRecordMessage(m.CurrentRecordingFile, false, 0)
-	log.Printf("Recording stopped: %s", m.CurrentRecordingFile)
+	logging.UI.Debugf("Recording stopped: %s", m.CurrentRecordingFile)
 
 	// Reset recording state but keep enabled flag
 	m.RecordingActive = false
@@ -1859,7 +2241,7 @@ func stopRecording(m *model.Model) {
 
 func handleCtrlF(m *model.Model) tea.Cmd {
 	FillSequential(m)
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventSettings})
 	return nil
 }
 
@@ -1892,8 +2274,8 @@ func handlePgDown(m *model.Model) tea.Cmd {
 	if m.ViewMode == types.SongView {
 		// Calculate next 16-aligned row for Song view (0-15)
 		newRow := ((m.CurrentRow + 16) / 16) * 16
-		if newRow > 15 {
-			newRow = 15 // Cap at maximum song row
+		if newRow > types.SongRows-1 {
+			newRow = types.SongRows - 1 // Cap at maximum song row
 		}
 		if newRow != m.CurrentRow {
 			m.CurrentRow = newRow
@@ -1904,8 +2286,8 @@ func handlePgDown(m *model.Model) tea.Cmd {
 	} else if m.ViewMode == types.ChainView {
 		// Calculate next 16-aligned row for Chain view (0-15)
 		newRow := ((m.CurrentRow + 16) / 16) * 16
-		if newRow > 15 {
-			newRow = 15 // Cap at maximum chain row
+		if newRow > types.ChainRows-1 {
+			newRow = types.ChainRows - 1 // Cap at maximum chain row
 		}
 		if newRow != m.CurrentRow {
 			m.CurrentRow = newRow
@@ -1913,8 +2295,8 @@ func handlePgDown(m *model.Model) tea.Cmd {
 	} else if m.ViewMode == types.PhraseView {
 		// Calculate next 16-aligned row for Phrase view (0-254)
 		newRow := ((m.CurrentRow + 16) / 16) * 16
-		if newRow > 254 {
-			newRow = 254 // Cap at maximum phrase row
+		if newRow > types.PhraseRows-1 {
+			newRow = types.PhraseRows - 1 // Cap at maximum phrase row
 		}
 		if newRow != m.CurrentRow {
 			m.CurrentRow = newRow
@@ -1979,9 +2361,9 @@ func handlePgDown(m *model.Model) tea.Cmd {
 		case types.ModulateView:
 			maxRow = int(types.ModulateSettingsRowProbability) // Seed(0) to Probability(6)
 		case types.FileMetadataView:
-			maxRow = int(types.FileMetadataRowSyncToBPM) // BPM(0) to SyncToBPM(3)
+			maxRow = int(types.FileMetadataRowOnsetPreset) // BPM(0) to OnsetPreset(5)
 		default:
-			maxRow = 254 // Default maximum
+			maxRow = types.PhraseRows - 1 // Default maximum
 		}
 
 		if newRow > maxRow {
@@ -1998,7 +2380,7 @@ func handlePgDown(m *model.Model) tea.Cmd {
 			}
 		}
 	}
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventView})
 	return nil
 }
 
@@ -2077,28 +2459,15 @@ func handlePgUp(m *model.Model) tea.Cmd {
 			}
 		}
 	}
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventView})
 	return nil
 }
 
-// getEffectiveValue finds the effective (sticky) value for a given column by looking backwards from the current row.
-// This implements sticky behavior where values persist until explicitly changed.
-func getEffectiveValue(phrasesData *[255][][]int, phrase int, currentRow int, column types.PhraseColumn) int {
-	// Start from current row and work backwards
-	for row := currentRow; row >= 0; row-- {
-		value := (*phrasesData)[phrase][row][column]
-		if value != -1 {
-			return value // Found a non-null value
-		}
-	}
-	return -1 // No effective value found
-}
-
 func handleCtrlO(m *model.Model) tea.Cmd {
 	// Set a flag to indicate we want to return to project selection
 	m.ReturnToProjectSelector = true
 	// Save current state before exiting
-	storage.AutoSave(m)
+	storage.Flush(m)
 	return tea.Quit
 }
 
@@ -0,0 +1,59 @@
+package input
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// modifyInputLevel changes the input gain by delta dB and sends it to SuperCollider
+func modifyInputLevel(m *model.Model, delta float32) {
+	modifier := createFloatModifier(
+		func() float32 { return m.InputLevelDB },
+		func(v float32) {
+			m.InputLevelDB = v
+			m.SendOSCInputLevelMessage() // Send OSC message for input level change
+		},
+		-48, 24, "InputLevelDB",
+	)
+	modifyValueWithBounds(modifier, delta)
+}
+
+// handleInputMeterInput handles keys in the input view: gain, arming, monitoring, the clip count
+// and the tuner
+func handleInputMeterInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+q", "alt+q":
+		return requestQuit(m)
+	case "esc", "q", "I":
+		toggleAuxView(m, types.InputView)
+		return nil
+	case "ctrl+r", "alt+r":
+		return handleCtrlR(m)
+	case "up":
+		modifyInputLevel(m, 1)
+	case "down":
+		modifyInputLevel(m, -1)
+	case "right":
+		modifyInputLevel(m, 0.1)
+	case "left":
+		modifyInputLevel(m, -0.1)
+	case "a":
+		m.ToggleInputArmed()
+		return nil
+	case "m":
+		m.InputMonitor = !m.InputMonitor
+		m.SendOSCInputMonitorMessage()
+	case "r":
+		m.ResetInputClips()
+		return nil
+	case "t":
+		openTuner(m)
+		return nil
+	default:
+		return nil
+	}
+	m.Publish(model.Event{Kind: model.EventSettings})
+	return nil
+}
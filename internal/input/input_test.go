@@ -2,17 +2,24 @@ package input
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/storage"
 	"github.com/schollz/collidertracker/internal/types"
+	"github.com/schollz/collidertracker/internal/update"
 )
 
-func createTestModel() *model.Model {
-	return model.NewModel(0, "test.json", false) // Port 0 to disable OSC for testing
+// createTestModel returns a model that saves under the test's temporary directory
+func createTestModel(t testing.TB) *model.Model {
+	return model.NewModel(0, t.TempDir(), false) // Port 0 to disable OSC for testing
 }
 
 func TestHandlePgDown(t *testing.T) {
@@ -110,7 +117,7 @@ func TestHandlePgDown(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m := createTestModel()
+			m := createTestModel(t)
 			m.ViewMode = tt.viewMode
 			m.CurrentRow = tt.initialRow
 			m.CurrentCol = tt.initialCol
@@ -238,7 +245,7 @@ func TestHandlePgUp(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m := createTestModel()
+			m := createTestModel(t)
 			m.ViewMode = tt.viewMode
 			m.CurrentRow = tt.initialRow
 			m.CurrentCol = tt.initialCol
@@ -303,7 +310,7 @@ func TestPgUpPgDown16Alignment(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m := createTestModel()
+			m := createTestModel(t)
 			m.ViewMode = tt.viewMode
 			m.CurrentRow = tt.startRow
 			m.CurrentCol = tt.col
@@ -353,7 +360,7 @@ func TestHandleKeyInputPgUpPgDown(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m := createTestModel()
+			m := createTestModel(t)
 			m.ViewMode = tt.viewMode
 			m.CurrentRow = tt.initialRow
 			m.CurrentCol = tt.initialCol
@@ -408,7 +415,7 @@ func TestViewSwitchConfig(t *testing.T) {
 			expected: ViewSwitchConfig{
 				ViewMode:     types.ChainView,
 				Row:          10,
-				Col:          1,
+				Col:          0,
 				ScrollOffset: 0,
 			},
 		},
@@ -455,7 +462,7 @@ func TestViewSwitchConfig(t *testing.T) {
 }
 
 func TestSwitchToView(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Set initial state
 	m.CurrentRow = 10
@@ -480,7 +487,7 @@ func TestSwitchToView(t *testing.T) {
 }
 
 func TestSwitchToViewWithVisibilityCheck(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 	m.TermHeight = 20
 
 	// Test with row that needs scrolling
@@ -504,7 +511,7 @@ func TestSwitchToViewWithVisibilityCheck(t *testing.T) {
 }
 
 func TestHandleKeyInput(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Test various key inputs don't crash
 	testKeys := []tea.KeyMsg{
@@ -539,7 +546,7 @@ func TestHandleKeyInput(t *testing.T) {
 }
 
 func TestTickFunction(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 	m.BPM = 120
 
 	// Test tick command generation
@@ -548,7 +555,7 @@ func TestTickFunction(t *testing.T) {
 }
 
 func TestAdvancePlayback(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Set up playback state
 	m.IsPlaying = true
@@ -565,7 +572,7 @@ func TestAdvancePlayback(t *testing.T) {
 }
 
 func TestPlaybackAdvancementEdgeCases(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Test advancement when not playing
 	m.IsPlaying = false
@@ -596,7 +603,7 @@ func TestInputTickMessage(t *testing.T) {
 }
 
 func TestViewSwitchingLogic(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Test switching between different views
 	initialView := m.ViewMode
@@ -627,8 +634,54 @@ func TestViewSwitchingLogic(t *testing.T) {
 	assert.Equal(t, initialView, m.ViewMode)
 }
 
+func TestTunerView(t *testing.T) {
+	m := createTestModel(t)
+	m.ViewMode = types.SongView
+
+	// The tuner opens from the input view and goes back to it
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'I'}})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	assert.Equal(t, types.TunerView, m.ViewMode)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyUp})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyUp})
+	assert.Equal(t, model.TunerDefaultReference+2, m.TunerReference)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, types.InputView, m.ViewMode)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, types.SongView, m.ViewMode)
+}
+
+func TestGeneratorView(t *testing.T) {
+	m := createTestModel(t)
+	m.SaveFolder = t.TempDir()
+	m.TrackTypes[0] = false
+	m.ViewMode = types.PhraseView
+	m.CurrentTrack, m.CurrentPhrase = 0, 0x02
+
+	// F opens the generator on the phrase; left/right change the setting under the cursor
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	assert.Equal(t, types.GeneratorView, m.ViewMode)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyDown})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRight})
+	assert.Equal(t, 55, m.Generator.Density)
+
+	// Enter generates, then x on the rows locks one so the next roll keeps it
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Contains(t, m.Notice, "Phrase 02 generated")
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyTab})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyDown})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	assert.True(t, m.GeneratorState.Locked[1])
+	m.InstrumentPhrasesData[0x02][1][types.ColNote] = 40
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	assert.Equal(t, 40, m.InstrumentPhrasesData[0x02][1][types.ColNote])
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, types.PhraseView, m.ViewMode)
+}
+
 func TestInputHelpers(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Test various input scenarios that should not crash
 
@@ -655,7 +708,7 @@ func TestInputHelpers(t *testing.T) {
 }
 
 func TestScrollAndVisibility(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 	m.TermHeight = 20
 
 	// Test visibility checking with different configurations
@@ -693,7 +746,7 @@ func TestScrollAndVisibility(t *testing.T) {
 }
 
 func BenchmarkHandleKeyInput(b *testing.B) {
-	m := createTestModel()
+	m := createTestModel(b)
 	keyMsg := tea.KeyMsg{Type: tea.KeyDown}
 
 	b.ResetTimer()
@@ -703,7 +756,7 @@ func BenchmarkHandleKeyInput(b *testing.B) {
 }
 
 func BenchmarkAdvancePlayback(b *testing.B) {
-	m := createTestModel()
+	m := createTestModel(b)
 	m.IsPlaying = true
 
 	b.ResetTimer()
@@ -713,7 +766,7 @@ func BenchmarkAdvancePlayback(b *testing.B) {
 }
 
 func TestDeepCopyWithArpeggio(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Set up source phrase with arpeggio data
 	sourcePhraseID := 1
@@ -766,7 +819,7 @@ func TestDeepCopyWithArpeggio(t *testing.T) {
 }
 
 func TestArpeggioCellCopyPaste(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Set up arpeggio view
 	m.ViewMode = types.ArpeggioView
@@ -827,7 +880,7 @@ func TestArpeggioCellCopyPaste(t *testing.T) {
 }
 
 func TestRetriggerDeepCopy(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Set up phrase view
 	m.ViewMode = types.PhraseView
@@ -871,7 +924,7 @@ func TestRetriggerDeepCopy(t *testing.T) {
 }
 
 func TestRetriggerDeepCopyNoUnusedSlots(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Set up phrase view
 	m.ViewMode = types.PhraseView
@@ -905,7 +958,7 @@ func TestRetriggerDeepCopyNoUnusedSlots(t *testing.T) {
 }
 
 func TestRetriggerCtrlDDeepCopy(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Set up phrase view with cursor in retrigger column
 	m.ViewMode = types.PhraseView
@@ -939,7 +992,7 @@ func TestRetriggerCtrlDDeepCopy(t *testing.T) {
 }
 
 func TestRetriggerCtrlDDeepCopyEmptyCell(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Set up phrase view with cursor in retrigger column
 	m.ViewMode = types.PhraseView
@@ -958,7 +1011,7 @@ func TestRetriggerCtrlDDeepCopyEmptyCell(t *testing.T) {
 }
 
 func TestCtrlDInNonRetriggerColumn(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Set up phrase view with cursor in non-retrigger column (note column)
 	m.ViewMode = types.PhraseView
@@ -986,7 +1039,7 @@ func TestCtrlDInNonRetriggerColumn(t *testing.T) {
 }
 
 func TestArpeggioDeepCopy(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Set up phrase view with cursor in arpeggio column
 	m.ViewMode = types.PhraseView
@@ -1029,7 +1082,7 @@ func TestArpeggioDeepCopy(t *testing.T) {
 }
 
 func TestArpeggioCtrlDDeepCopy(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Set up phrase view with cursor in arpeggio column
 	m.ViewMode = types.PhraseView
@@ -1061,7 +1114,7 @@ func TestArpeggioCtrlDDeepCopy(t *testing.T) {
 }
 
 func TestArpeggioCtrlDDeepCopyEmptyCell(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Set up phrase view with cursor in arpeggio column
 	m.ViewMode = types.PhraseView
@@ -1082,7 +1135,7 @@ func TestArpeggioCtrlDDeepCopyEmptyCell(t *testing.T) {
 }
 
 func TestRetriggerCtrlDPasteOptimal(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Set up phrase view
 	m.ViewMode = types.PhraseView
@@ -1139,7 +1192,7 @@ func TestRetriggerCtrlDPasteOptimal(t *testing.T) {
 }
 
 func TestArpeggioCtrlDPasteOptimal(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Set up phrase view with instrument track
 	m.ViewMode = types.PhraseView
@@ -1204,7 +1257,7 @@ func TestArpeggioCtrlDPasteOptimal(t *testing.T) {
 }
 
 func TestRegularCopyPasteStillWorks(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Set up phrase view
 	m.ViewMode = types.PhraseView
@@ -1239,7 +1292,7 @@ func TestRegularCopyPasteStillWorks(t *testing.T) {
 
 // TestDuckingStickyBehavior tests that DU column has sticky behavior
 func TestDuckingStickyBehavior(t *testing.T) {
-	m := createTestModel()
+	m := createTestModel(t)
 
 	// Test data: Set up a phrase with ducking values
 	// Row 0: DU = 05
@@ -1293,3 +1346,811 @@ func TestDuckingStickyBehavior(t *testing.T) {
 	result := GetEffectiveValueForTrack(m, 1, 2, int(types.ColEffectDucking), trackId)
 	assert.Equal(t, -1, result, "Should return -1 when no non-null values found")
 }
+
+func TestStackedNoteColumns(t *testing.T) {
+	m := createTestModel(t)
+	m.TrackTypes[0] = false // Instrument track
+	m.CurrentTrack = 0
+	m.ViewMode = types.PhraseView
+	m.CurrentPhrase = 0
+	m.CurrentRow = 0
+	m.SetPhraseCell(0, 0, 0, types.ColNote, 60)
+
+	// First edit starts from the row's note, then moves in semitones and octaves
+	m.CurrentCol = int(types.InstrumentColN2)
+	ModifyValue(m, 1)
+	assert.Equal(t, 60, m.GetPhraseCell(0, 0, 0, types.ColNote2))
+	ModifyValue(m, 1)
+	ModifyValue(m, 16)
+	assert.Equal(t, 73, m.GetPhraseCell(0, 0, 0, types.ColNote2))
+	ModifyValue(m, 16)
+	ModifyValue(m, 16)
+	ModifyValue(m, 16)
+	assert.Equal(t, 109, m.GetPhraseCell(0, 0, 0, types.ColNote2))
+	ModifyValue(m, 16)
+	assert.Equal(t, 121, m.GetPhraseCell(0, 0, 0, types.ColNote2))
+	ModifyValue(m, 16)
+	assert.Equal(t, 127, m.GetPhraseCell(0, 0, 0, types.ColNote2), "Should clamp to the MIDI range")
+
+	// The last stacked column is reachable with Right
+	m.CurrentCol = int(types.InstrumentColDU)
+	for i := 0; i < 5; i++ {
+		handleRight(m)
+	}
+	assert.Equal(t, int(types.InstrumentColN4), m.CurrentCol)
+
+	// Deleting the row clears the stacked notes
+	m.ConfirmDeletes = false
+	DeletePhraseRow(m)
+	assert.Equal(t, -1, m.GetPhraseCell(0, 0, 0, types.ColNote2))
+}
+
+func TestTimelineNavigation(t *testing.T) {
+	m := createTestModel(t)
+	m.BPM = 120
+	m.PPQ = 2
+	for _, track := range []int{0, 2} {
+		m.TrackTypes[track] = false
+		m.InstrumentChainsData[track][0] = track
+		m.InstrumentPhrasesData[track][0][types.ColDeltaTime] = 4 * (track + 1)
+	}
+	m.SongData[0][0], m.SongData[0][1] = 0, 0 // 1s blocks at 0s and 1s
+	m.SongData[2][0] = 2                      // One 3s block
+
+	// Opening from the song view selects the cell under the cursor
+	m.ViewMode = types.SongView
+	m.CurrentCol, m.CurrentRow = 0, 1
+	toggleTimelineView(m)
+	assert.Equal(t, types.TimelineView, m.ViewMode)
+	assert.Equal(t, 1, m.TimelineRow)
+
+	handleTimelineInput(m, tea.KeyMsg{Type: tea.KeyLeft})
+	assert.Equal(t, 0, m.TimelineRow)
+	handleTimelineInput(m, tea.KeyMsg{Type: tea.KeyRight})
+
+	// Down skips the empty lane and picks the block playing at the same time
+	handleTimelineInput(m, tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 2, m.TimelineTrack)
+	assert.Equal(t, 0, m.TimelineRow)
+
+	// Enter opens the block in the song view
+	handleTimelineInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, types.SongView, m.ViewMode)
+	assert.Equal(t, 2, m.CurrentCol)
+	assert.Equal(t, 0, m.CurrentRow)
+}
+
+func TestCueEditing(t *testing.T) {
+	m := createTestModel(t)
+	m.ViewMode = types.SongView
+	m.CurrentRow = 3
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyCtrlN})
+	assert.True(t, m.EditingCue)
+	for _, r := range "DROPS" {
+		HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.EditingCue)
+	assert.Equal(t, "DROP", m.SongCues[3])
+
+	// Esc leaves the cue as it was
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyCtrlN})
+	assert.Equal(t, "DROP", m.CueBuffer)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, "DROP", m.SongCues[3])
+}
+
+func TestRowTempoEditing(t *testing.T) {
+	m := createTestModel(t)
+	m.ViewMode = types.SongView
+	m.CurrentRow = 2
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'='}})
+	assert.True(t, m.EditingRowTempo)
+	for _, r := range "87.5.x" {
+		HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.EditingRowTempo)
+	assert.Equal(t, float32(87.5), m.SongRowTempos[2].BPM)
+
+	// The groove steps through the song's groove and the templates
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	assert.Equal(t, 2, m.SongRowTempos[2].Groove)
+	for range model.GrooveTemplates {
+		HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'<'}})
+	}
+	assert.Equal(t, 0, m.SongRowTempos[2].Groove)
+
+	// Clearing the tempo returns the row to the song tempo
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'='}})
+	assert.Equal(t, "87.5", m.RowTempoBuffer)
+	for range "87.5" {
+		HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.HasSongRowTempos())
+}
+
+func TestChainTransposeColumn(t *testing.T) {
+	m := createTestModel(t)
+	m.ViewMode = types.ChainView
+	m.CurrentChain, m.CurrentRow, m.CurrentCol = 2, 4, 0
+
+	// Right moves to the transpose column before switching chains
+	handleRight(m)
+	assert.Equal(t, 1, m.CurrentCol)
+	assert.Equal(t, 2, m.CurrentChain)
+
+	ModifyValue(m, 1)
+	ModifyValue(m, 16)
+	assert.Equal(t, 13, m.GetChainTranspose(m.CurrentTrack, 2, 4), "Coarse steps move an octave")
+	assert.Equal(t, -1, m.GetChainCell(m.CurrentTrack, 2, 4), "The phrase slot is untouched")
+
+	// The effect override columns start from no effect
+	handleRight(m)
+	ModifyValue(m, 16)
+	handleRight(m)
+	ModifyValue(m, -16)
+	assert.Equal(t, types.ChainFX{Reverb: 0x10, LowPass: 0xEE}, m.GetChainFX(m.CurrentTrack, 2, 4))
+	handleBackspace(m)
+	assert.Equal(t, -1, m.GetChainFX(m.CurrentTrack, 2, 4).LowPass)
+
+	handleRight(m)
+	assert.Equal(t, 3, m.CurrentChain)
+	assert.Equal(t, int(types.ChainColLowPass), m.CurrentCol)
+	for range 3 {
+		handleLeft(m)
+	}
+	assert.Equal(t, 0, m.CurrentCol)
+	assert.Equal(t, 3, m.CurrentChain)
+	handleLeft(m)
+	assert.Equal(t, 2, m.CurrentChain)
+}
+
+func TestTempoKeyPreviewKeys(t *testing.T) {
+	m := createTestModel(t)
+	m.BPM = 120
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyCtrlU})
+	assert.True(t, m.Preview.Active)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyUp})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRight})
+	assert.Equal(t, float32(121), m.BPM)
+	assert.Equal(t, 1, m.Preview.Transpose)
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, m.Preview.Active)
+	assert.Equal(t, float32(120), m.BPM)
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyCtrlU})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyDown})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.Preview.Active)
+	assert.Equal(t, float32(119), m.BPM)
+}
+
+func TestSoundMakerPLock(t *testing.T) {
+	m := createTestModel(t)
+	m.TrackTypes[0] = false
+	m.CurrentTrack = 0
+	m.CurrentPhrase = 1
+	m.LastPhraseRow = 3
+	m.ViewMode = types.SoundMakerView
+	m.SoundMakerEditingIndex = 0
+	m.SoundMakerSettings[0] = types.SoundMakerSettings{Name: "PolyPerc"}
+	m.SoundMakerSettings[0].InitializeParameters()
+	m.SoundMakerSettings[0].SetParameterValue("resonance", 1.5)
+	m.CurrentRow = 1 // Resonance
+	m.CurrentCol = 0
+
+	ToggleSoundMakerPLock(m)
+	value, ok := m.PLock(1, 3, "resonance")
+	assert.True(t, ok, "t locks the parameter on the phrase row")
+	assert.Equal(t, float32(1.5), value)
+
+	// Adjusting a locked parameter edits the lock, not the SoundMaker
+	ModifySoundMakerValue(m, 1)
+	value, _ = m.PLock(1, 3, "resonance")
+	assert.InDelta(t, 1.6, value, 0.001)
+	assert.Equal(t, float32(1.5), m.SoundMakerSettings[0].GetParameterValue("resonance"))
+
+	ToggleSoundMakerPLock(m)
+	_, ok = m.PLock(1, 3, "resonance")
+	assert.False(t, ok, "t again removes the lock")
+	ModifySoundMakerValue(m, 1)
+	assert.InDelta(t, 1.6, m.SoundMakerSettings[0].GetParameterValue("resonance"), 0.001)
+}
+
+func TestNumberEntry(t *testing.T) {
+	m := createTestModel(t)
+	typeKeys := func(keys string) {
+		for _, r := range keys {
+			HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+
+	// Hex cells: a digit starts the entry, then hex digits follow
+	m.ViewMode = types.PhraseView
+	m.TrackTypes[0] = false
+	m.CurrentTrack = 0
+	m.CurrentPhrase = 2
+	m.CurrentRow = 4
+	m.CurrentCol = int(types.InstrumentColDT)
+	typeKeys("1f")
+	assert.True(t, m.NumberEntry.Active)
+	assert.True(t, m.NumberEntry.Hex)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.NumberEntry.Active)
+	assert.Equal(t, 0x1F, m.InstrumentPhrasesData[2][4][types.ColDeltaTime])
+
+	// Values past the column's range are clamped, esc cancels
+	typeKeys("3ff")
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, 254, m.InstrumentPhrasesData[2][4][types.ColDeltaTime])
+	typeKeys("12")
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, 254, m.InstrumentPhrasesData[2][4][types.ColDeltaTime])
+
+	// Other keys cancel the entry and keep their action
+	typeKeys("1")
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyDown})
+	assert.False(t, m.NumberEntry.Active)
+	assert.Equal(t, 5, m.CurrentRow)
+
+	// BPM is typed in decimal
+	m.ViewMode = types.SettingsView
+	m.CurrentCol = 0
+	m.CurrentRow = int(types.GlobalSettingsRowBPM)
+	typeKeys("174.5")
+	assert.False(t, m.NumberEntry.Hex)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, float32(174.5), m.BPM)
+}
+
+func TestNudgeSteps(t *testing.T) {
+	m := createTestModel(t)
+	m.ViewMode = types.ChainView
+	m.CurrentCol = int(types.ChainColPhrase)
+	m.SetChainCell(m.CurrentTrack, m.CurrentChain, 0, 0x10)
+	m.NudgeFine, m.NudgeCoarse = 2, 8
+
+	ModifyValue(m, 1)
+	assert.Equal(t, 0x12, m.GetChainCell(m.CurrentTrack, m.CurrentChain, 0))
+	ModifyValue(m, -16)
+	assert.Equal(t, 0x0A, m.GetChainCell(m.CurrentTrack, m.CurrentChain, 0))
+
+	// The Nudge setting changes the fine step with fine keys and the coarse step with coarse keys
+	m.ViewMode = types.SettingsView
+	m.CurrentCol = 3
+	m.CurrentRow = int(types.AppSettingsRowNudge)
+	ModifySettingsValue(m, 0.05)
+	ModifySettingsValue(m, -1)
+	assert.Equal(t, 3, m.NudgeFine)
+	assert.Equal(t, 7, m.NudgeCoarse)
+}
+
+func TestClipboardHistory(t *testing.T) {
+	m := createTestModel(t)
+	m.ViewMode = types.SongView
+	m.CurrentCol = 0
+	for row, chain := range []int{0x0A, 0x0B, 0x0C} {
+		m.SetSongCell(0, row, chain)
+		m.CurrentRow = row
+		CopyCellToClipboard(m)
+	}
+	assert.Len(t, m.ClipboardHistory, 3)
+	assert.Equal(t, 0x0C, m.Clipboard.Value)
+	assert.Equal(t, "song cell 0C (row 02)", model.ClipboardSummary(m.ClipboardHistory[0]))
+
+	// Esc clears the clipboard, the picker still has the older entries
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, m.Clipboard.HasData)
+	m.CurrentRow = 5
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	assert.True(t, m.PickingClipboard)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyDown})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyDown})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.PickingClipboard)
+	assert.Equal(t, 0x0A, m.GetSongCell(0, 5), "Oldest entry pasted")
+	assert.Equal(t, 0x0A, m.Clipboard.Value, "Picked entry becomes the clipboard")
+	assert.Equal(t, 0x0A, m.ClipboardHistory[0].Value)
+	assert.Len(t, m.ClipboardHistory, 3)
+}
+
+func TestDuplicateTrack(t *testing.T) {
+	m := createTestModel(t)
+	m.TrackTypes[1] = false
+	m.TrackSetLevels[1] = -12
+	m.SetSongCell(1, 0, 0x02)
+	m.SetSongCell(1, 1, 0x02)
+	m.SetChainCell(1, 0x02, 0, 0x05)
+	m.SetChainCell(1, 0x02, 1, 0x05)
+	m.SetChainTranspose(1, 0x02, 1, 7)
+	m.InstrumentPhrasesData[0x05][0][types.ColNote] = 60
+	m.InstrumentPhrasesData[0x05][0][types.ColDeltaTime] = 1
+	m.SetPLock(0x05, 0, "resonance", 2)
+	m.SetSongCell(2, 0, 0x09) // Track 3 is in use
+
+	assert.Equal(t, 3, DuplicateTrack(m, 1), "First empty track after the source")
+	assert.False(t, m.TrackTypes[3])
+	assert.Equal(t, float32(-12), m.TrackSetLevels[3])
+
+	chain := m.GetSongCell(3, 0)
+	assert.NotEqual(t, 0x02, chain, "Copy gets its own chain")
+	assert.Equal(t, chain, m.GetSongCell(3, 1), "Repeated chains stay shared within the copy")
+	assert.Equal(t, 7, m.GetChainTranspose(3, chain, 1))
+	phrase := m.GetChainCell(3, chain, 0)
+	assert.NotEqual(t, 0x05, phrase, "Copy gets its own phrase")
+	assert.Equal(t, phrase, m.GetChainCell(3, chain, 1))
+	assert.Equal(t, 60, m.InstrumentPhrasesData[phrase][0][types.ColNote])
+	value, ok := m.PLock(phrase, 0, "resonance")
+	assert.True(t, ok)
+	assert.Equal(t, float32(2), value)
+
+	// Editing the copy leaves the original alone
+	m.InstrumentPhrasesData[phrase][0][types.ColNote] = 62
+	assert.Equal(t, 60, m.InstrumentPhrasesData[0x05][0][types.ColNote])
+}
+
+func TestTrackBanks(t *testing.T) {
+	m := createTestModel(t)
+	m.TrackTypes[0] = false
+	m.TrackTypes[1] = false
+	// Tracks 1 and 2 share chain 03 and its phrase 04 in the shared pool
+	m.SetSongCell(0, 0, 0x03)
+	m.SetSongCell(1, 0, 0x03)
+	m.SetChainCell(0, 0x03, 0, 0x04)
+	m.SetChainTranspose(0, 0x03, 0, 5)
+	m.InstrumentPhrasesData[0x04][0][types.ColNote] = 60
+	m.InstrumentPhrasesData[0x04][0][types.ColDeltaTime] = 1
+
+	assert.True(t, SetTrackBanks(m, true))
+	assert.True(t, m.PerTrackBanks)
+	assert.Equal(t, 0x03, m.GetSongCell(0, 0), "Chains already in the track's bank stay put")
+	chain := m.GetSongCell(1, 0)
+	assert.True(t, m.InBank(1, chain), "Track 2 gets its own copy in its bank")
+	assert.Equal(t, 5, m.GetChainTranspose(1, chain, 0))
+	phrase := m.GetChainCell(1, chain, 0)
+	assert.True(t, m.InBank(1, phrase))
+	assert.Equal(t, 60, m.InstrumentPhrasesData[phrase][0][types.ColNote])
+	assert.Equal(t, 0x04, m.GetChainCell(0, 0x03, 0))
+
+	// New chains, edits and typed values stay in the track's bank
+	m.ViewMode = types.SongView
+	m.CurrentCol = 1
+	m.CurrentRow = 0
+	ModifySongValue(m, -16)
+	first, last := m.BankRange(1)
+	assert.Equal(t, first, m.GetSongCell(1, 0))
+	assert.Equal(t, 0x3F, last)
+	m.CurrentTrack = 1
+	assert.Equal(t, first+1, FindNextUnusedChain(m, 0xF0), "Searches outside the bank start at its first free chain")
+	m.SetSongCell(1, 1, first+1)
+	assert.Equal(t, first+2, FindNextUnusedChain(m, first+1))
+
+	// A bank too small for a track's phrases keeps the shared pool
+	assert.True(t, SetTrackBanks(m, false))
+	for row := 0; row < types.SongRows; row++ {
+		m.SetSongCell(2, row, 0x80+row)
+		for slot := 0; slot < 3; slot++ {
+			m.SetChainCell(2, 0x80+row, slot, 0x80+row*3+slot)
+		}
+	}
+	assert.False(t, SetTrackBanks(m, true))
+	assert.False(t, m.PerTrackBanks)
+	assert.Equal(t, 0x80, m.GetSongCell(2, 0))
+}
+
+func TestCompactPools(t *testing.T) {
+	m := createTestModel(t)
+	m.TrackTypes[0] = false
+	m.TrackTypes[1] = false
+	m.SetSongCell(0, 0, 0x40)
+	m.SetSongCell(0, 1, 0x10)
+	m.SetSongCell(1, 0, 0x40)
+	m.SetChainCell(0, 0x40, 0, 0x90)
+	m.SetChainCell(0, 0x10, 0, 0x30)
+	m.SetChainTranspose(0, 0x10, 0, 3)
+	m.InstrumentPhrasesData[0x30][0][types.ColNote] = 60
+	m.InstrumentPhrasesData[0x30][0][types.ColDeltaTime] = 1
+	m.SetPLock(0x30, 0, "cutoff", 5)
+
+	assert.True(t, CompactPools(m))
+	assert.Equal(t, 0x00, m.GetSongCell(0, 0), "Chains follow song order")
+	assert.Equal(t, 0x01, m.GetSongCell(0, 1))
+	assert.Equal(t, 0x00, m.GetSongCell(1, 0), "Shared chains stay shared")
+	assert.Equal(t, 0x00, m.GetChainCell(0, 0x00, 0), "Phrases follow chain order")
+	assert.Equal(t, 0x01, m.GetChainCell(0, 0x01, 0))
+	assert.Equal(t, 3, m.GetChainTranspose(0, 0x01, 0))
+	assert.Equal(t, 60, m.InstrumentPhrasesData[0x01][0][types.ColNote])
+	value, ok := m.PLock(0x01, 0, "cutoff")
+	assert.True(t, ok)
+	assert.Equal(t, float32(5), value)
+
+	// The renumbering can be restored from the trash
+	RestoreFromTrash(m)
+	assert.Equal(t, 0x40, m.GetSongCell(0, 0))
+	assert.Equal(t, 0x30, m.GetChainCell(0, 0x10, 0))
+	assert.Equal(t, 60, m.InstrumentPhrasesData[0x30][0][types.ColNote])
+
+	m.IsPlaying = true
+	assert.False(t, CompactPools(m))
+}
+
+func TestRevertLastOperation(t *testing.T) {
+	m := createTestModel(t)
+	m.SaveFolder = t.TempDir()
+	m.ConfirmDeletes = false
+	RevertLastOperation(m)
+	assert.Equal(t, "No operation to revert", m.Notice)
+
+	m.TrackTypes[0] = false
+	m.SetSongCell(0, 0, 0x40)
+	assert.True(t, CompactPools(m))
+	assert.Equal(t, 0x00, m.GetSongCell(0, 0))
+	m.SetSongCell(0, 1, 0x07)
+
+	RevertLastOperation(m)
+	assert.Equal(t, "Reverted renumbering", m.Notice)
+	assert.Equal(t, 0x40, m.GetSongCell(0, 0))
+	assert.Equal(t, -1, m.GetSongCell(0, 1), "Edits after the operation are reverted too")
+	assert.Empty(t, m.Trash)
+}
+
+func TestSampleAnalysis(t *testing.T) {
+	m := createTestModel(t)
+	m.SaveFolder = t.TempDir()
+	assert.Nil(t, ToggleSampleAnalysis(m))
+	assert.Equal(t, "No project samples to analyze", m.Notice)
+
+	file, err := filepath.Abs("../getbpm/Break120.wav")
+	assert.NoError(t, err)
+	m.SamplerPhrasesFiles = []string{file, "", file, filepath.Join(m.SaveFolder, "missing.wav")}
+	warp := []types.WarpMarker{{Time: 0.1, Beat: 0}}
+	m.FileMetadata[file] = types.FileMetadata{BPM: 90, Slices: 8, SliceType: 0, SyncToBPM: 1, Warp: warp}
+
+	cmd := ToggleSampleAnalysis(m)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []string{file}, m.Analysis.Files, "Each existing sample once")
+	_, ok := storage.LatestSnapshot(m)
+	assert.True(t, ok, "The project is snapshotted before the analysis")
+
+	// A result from a cancelled batch is dropped
+	msg := cmd().(AnalysisDoneMsg)
+	assert.NoError(t, msg.Err)
+	assert.Nil(t, ToggleSampleAnalysis(m))
+	assert.Nil(t, HandleAnalysisDone(m, msg))
+	assert.Equal(t, float32(90), m.FileMetadata[file].BPM)
+
+	cmd = ToggleSampleAnalysis(m)
+	assert.Nil(t, HandleAnalysisDone(m, cmd().(AnalysisDoneMsg)))
+	assert.Nil(t, m.Analysis)
+	assert.Equal(t, "Analyzed 1 samples", m.Notice)
+	metadata := m.FileMetadata[file]
+	assert.NotEqual(t, float32(90), metadata.BPM, "The BPM is detected again")
+	assert.Len(t, metadata.Onsets, 8, "The slice count chosen for the file is kept")
+	assert.Equal(t, warp, metadata.Warp)
+	assert.NotEmpty(t, metadata.WaveformFile)
+}
+
+func TestEcoMode(t *testing.T) {
+	m := createTestModel(t)
+	m.SaveFolder = t.TempDir()
+	file, err := filepath.Abs("../getbpm/Break120.wav")
+	assert.NoError(t, err)
+	m.SamplerPhrasesFiles = []string{file}
+
+	// A sample analysis started in eco mode waits for it to end
+	assert.Nil(t, toggleEcoMode(m))
+	assert.True(t, m.EcoMode)
+	assert.Nil(t, ToggleSampleAnalysis(m))
+	assert.True(t, m.Analysis.Paused)
+
+	assert.NotNil(t, toggleEcoMode(m))
+	assert.False(t, m.EcoMode)
+	assert.False(t, m.Analysis.Paused)
+}
+
+func TestTimedStart(t *testing.T) {
+	m := createTestModel(t)
+	m.StartCountdown = 5
+	m.PreRoll = 2
+	assert.NotNil(t, toggleTimedStart(m))
+	assert.True(t, m.TimedStartArmed())
+	armed := m.TimedStart
+
+	// Count-in beats click and schedule the next; messages of another arming are ignored
+	assert.Nil(t, HandleTimedStart(m, TimedStartMsg{At: armed.Add(time.Second), Beat: 0}))
+	assert.False(t, m.IsPlaying)
+	assert.NotNil(t, HandleTimedStart(m, TimedStartMsg{At: armed, Beat: 2}))
+	assert.True(t, m.TimedStartArmed())
+
+	// The start plays the song from the top
+	HandleTimedStart(m, TimedStartMsg{At: armed, Beat: 0})
+	assert.False(t, m.TimedStartArmed())
+	assert.True(t, m.IsPlaying)
+	assert.Equal(t, types.SongView, m.PlaybackMode)
+
+	// Arming needs a stopped transport, and T again cancels
+	assert.Nil(t, toggleTimedStart(m))
+	assert.False(t, m.TimedStartArmed())
+	m.IsPlaying = false
+	toggleTimedStart(m)
+	assert.Nil(t, toggleTimedStart(m))
+	assert.False(t, m.TimedStartArmed())
+}
+
+func TestGenerativeSong(t *testing.T) {
+	m := createTestModel(t)
+	m.TrackTypes[0] = false
+	for _, row := range []int{0, 1, 5} {
+		m.SongData[0][row] = 1
+	}
+	m.InstrumentChainsData[1][0] = 1
+	m.InstrumentPhrasesData[1][0][types.ColDeltaTime] = 1
+	m.SongPlaybackRow[0], m.SongPlaybackChain[0], m.SongPlaybackPhrase[0] = 0, 1, 1
+
+	// In order, the chain is followed by the next song row
+	ok, looped := advanceToNextPlayableRowForTrack(m, 0)
+	assert.True(t, ok)
+	assert.True(t, looped)
+	assert.Equal(t, 1, m.SongPlaybackRow[0])
+
+	// Generative mode only chooses rows with weight
+	m.ToggleGenerativeSong()
+	m.SetSongWeight(0, 0, 0)
+	m.SetSongWeight(0, 1, 0)
+	for i := 0; i < 5; i++ {
+		ok, _ = advanceToNextPlayableRowForTrack(m, 0)
+		assert.True(t, ok)
+		assert.Equal(t, 5, m.SongPlaybackRow[0])
+	}
+
+	// Without weight anywhere, rows play in order again
+	m.SetSongWeight(0, 5, 0)
+	advanceToNextPlayableRowForTrack(m, 0)
+	assert.Equal(t, 0, m.SongPlaybackRow[0])
+}
+
+func TestNotesView(t *testing.T) {
+	m := createTestModel(t)
+	m.ViewMode = types.SongView
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("N")})
+	assert.Equal(t, types.NotesView, m.ViewMode)
+
+	// Keys that are commands elsewhere type in the notes
+	for _, r := range "play x" {
+		HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("N")})
+	assert.Equal(t, "play x\nN", m.NotesText())
+	assert.Equal(t, types.NotesView, m.ViewMode)
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, types.SongView, m.ViewMode)
+}
+
+func TestHistoryView(t *testing.T) {
+	m := model.NewModel(0, t.TempDir(), false)
+	m.ViewMode = types.SongView
+	for row := 0; row < 3; row++ {
+		m.SetSongCell(0, row, row+1)
+	}
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	assert.Equal(t, types.HistoryView, m.ViewMode)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyDown})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyPgDown})
+	assert.Equal(t, 2, m.AuditScroll)
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	assert.Equal(t, "Exported history.txt", m.Notice)
+	exported, err := os.ReadFile(filepath.Join(m.SaveFolder, "history.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(exported), "\n"))
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, types.SongView, m.ViewMode)
+}
+
+func TestChangelogView(t *testing.T) {
+	m := createTestModel(t)
+	m.ViewMode = types.SongView
+	m.Version = "v1.4.0"
+
+	HandleUpdateCheck(m, UpdateCheckMsg{Releases: []update.Release{{Tag: "v1.5.0", Notes: "- Faster\r\n- Smaller"}, {Tag: "v1.4.0"}}})
+	assert.Contains(t, m.Notice, "v1.5.0")
+	release, ok := m.AvailableUpdate()
+	assert.True(t, ok)
+	assert.Equal(t, "v1.5.0", release.Tag)
+	assert.Equal(t, []string{"v1.5.0", "  - Faster", "  - Smaller", "", "v1.4.0"}, m.ChangelogLines())
+
+	// Releases were found, so opening the changelog does not check again
+	assert.Nil(t, HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")}))
+	assert.Equal(t, types.ChangelogView, m.ViewMode)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyDown})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyPgDown})
+	assert.Equal(t, 4, m.ChangelogScroll)
+
+	// Installing asks first
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	assert.NotNil(t, m.PendingConfirm)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	assert.Nil(t, m.PendingConfirm)
+	assert.False(t, m.UpdateBusy)
+
+	HandleUpdateInstall(m, UpdateInstallMsg{Tag: "v1.5.0"})
+	assert.Equal(t, "v1.5.0", m.UpdateInstalled)
+	_, ok = m.AvailableUpdate()
+	assert.False(t, ok, "An installed update is not offered again")
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, types.SongView, m.ViewMode)
+}
+
+func TestAuditionEdit(t *testing.T) {
+	m := model.NewModel(0, t.TempDir(), false)
+	m.ViewMode = types.PhraseView
+	m.CurrentTrack, m.CurrentPhrase, m.CurrentRow = 0, 0, 0
+
+	// An empty row has nothing to play
+	assert.False(t, shouldAuditionEdit(m, types.ColNote))
+
+	m.SetPhraseCell(0, 0, 0, types.ColNote, 60)
+	assert.True(t, shouldAuditionEdit(m, types.ColNote))
+	assert.True(t, shouldAuditionEdit(m, types.ColVelocity))
+	assert.False(t, shouldAuditionEdit(m, types.ColDeltaTime), "Only notes and velocities are auditioned")
+
+	// Playing rows are updated in place instead
+	m.IsPlaying = true
+	assert.False(t, shouldAuditionEdit(m, types.ColNote))
+	m.IsPlaying = false
+
+	// The App setting turns it off
+	m.ViewMode = types.SettingsView
+	m.CurrentRow = int(types.AppSettingsRowAudition)
+	m.CurrentCol = 3
+	ModifySettingsValue(m, 1)
+	assert.False(t, m.AuditionEdits)
+	m.ViewMode, m.CurrentRow = types.PhraseView, 0
+	assert.False(t, shouldAuditionEdit(m, types.ColNote))
+}
+
+func TestNameAndSearchChains(t *testing.T) {
+	m := createTestModel(t)
+	m.TrackTypes[0] = false
+	m.CurrentTrack = 0
+	m.ViewMode = types.ChainView
+	m.CurrentChain = 7
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyCtrlN})
+	for _, r := range "chorus x" {
+		HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, "chorus", m.ChainName(0, 7))
+	assert.False(t, m.NameEntry.Active)
+
+	// Searching from the song view opens the chain
+	m.ViewMode = types.SongView
+	m.CurrentChain = 0
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	for _, r := range "cho" {
+		HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, types.ChainView, m.ViewMode)
+	assert.Equal(t, 7, m.CurrentChain)
+}
+
+func TestExportPhraseClip(t *testing.T) {
+	m := model.NewModel(0, t.TempDir(), false)
+	m.TrackTypes[0] = false
+	m.CurrentTrack = 0
+	m.CurrentPhrase = 3
+	m.ViewMode = types.PhraseView
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	assert.Contains(t, m.Notice, "Nothing to export")
+
+	m.SetPhraseName(0, 3, "Hook")
+	m.InstrumentPhrasesData[3][0][types.ColNote] = 60
+	m.InstrumentPhrasesData[3][0][types.ColDeltaTime] = 1
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	files, err := filepath.Glob(filepath.Join(m.ClipsFolder(), "phrase03-hook-*.mid"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Nil(t, m.Bounce, "MIDI clips are written without playing")
+}
+
+func TestProjectPasswordEntry(t *testing.T) {
+	m := model.NewModel(0, t.TempDir(), false)
+	m.ViewMode = types.SettingsView
+	m.CurrentCol = 0
+	m.CurrentRow = int(types.GlobalSettingsRowLock)
+	typeText := func(text string) {
+		for _, r := range text {
+			HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	}
+
+	// Short passwords are refused and typos end the entry
+	ModifySettingsValue(m, 1)
+	assert.True(t, m.PasswordEntry.Active)
+	typeText("short")
+	assert.Contains(t, m.Notice, "at least")
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, m.PasswordEntry.Active)
+
+	ModifySettingsValue(m, 1)
+	typeText("long enough")
+	typeText("long enuogh")
+	assert.False(t, m.PasswordEntry.Active)
+	assert.False(t, m.IsLocked())
+
+	ModifySettingsValue(m, 1)
+	typeText("long enough")
+	typeText("long enough")
+	assert.True(t, m.IsLocked())
+	assert.True(t, storage.IsProjectLocked(m.SaveFolder))
+
+	// Removing the password is confirmed first
+	ModifySettingsValue(m, -1)
+	assert.NotNil(t, m.PendingConfirm)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	assert.False(t, m.IsLocked())
+	assert.False(t, storage.IsProjectLocked(m.SaveFolder))
+}
+
+func TestMorphPhrases(t *testing.T) {
+	m := createTestModel(t)
+	m.SaveFolder = t.TempDir()
+	m.TrackTypes[1] = false
+	m.ViewMode = types.ChainView
+	m.CurrentTrack, m.CurrentChain = 1, 0x02
+	m.SetChainCell(1, 0x02, 0, 0x05)
+	m.SetChainCell(1, 0x02, 4, 0x06)
+	for phrase, values := range map[int][3]int{0x05: {60, 40, 0}, 0x06: {72, 120, 1}} {
+		m.InstrumentPhrasesData[phrase][0][types.ColNote] = values[0]
+		m.InstrumentPhrasesData[phrase][0][types.ColDeltaTime] = 1
+		m.InstrumentPhrasesData[phrase][0][types.ColVelocity] = values[1]
+		m.InstrumentPhrasesData[phrase][0][types.ColSoundMaker] = values[2]
+	}
+	m.InstrumentPhrasesData[0x06][2][types.ColNote] = 67 // Only plays in the target
+	m.InstrumentPhrasesData[0x06][2][types.ColDeltaTime] = 1
+	m.SetPLock(0x06, 0, "resonance", 2)
+
+	// A phrase with no empty rows below it has nothing to morph through
+	m.CurrentRow = 4
+	assert.Equal(t, 0, MorphPhrases(m))
+	assert.Contains(t, m.Notice, "empty chain rows")
+
+	m.CurrentRow = 0
+	assert.Equal(t, 3, MorphPhrases(m))
+	var notes, velocities, soundMakers, row2 []int
+	for row := 1; row <= 3; row++ {
+		phrase := m.GetChainCell(1, 0x02, row)
+		assert.NotContains(t, []int{-1, 0x05, 0x06}, phrase, "Each step gets a free phrase")
+		notes = append(notes, m.InstrumentPhrasesData[phrase][0][types.ColNote])
+		velocities = append(velocities, m.InstrumentPhrasesData[phrase][0][types.ColVelocity])
+		soundMakers = append(soundMakers, m.InstrumentPhrasesData[phrase][0][types.ColSoundMaker])
+		row2 = append(row2, m.InstrumentPhrasesData[phrase][2][types.ColDeltaTime])
+		_, locked := m.PLock(phrase, 0, "resonance")
+		assert.Equal(t, soundMakers[row-1] == 1, locked, "Parameter locks switch with the row")
+	}
+	assert.Equal(t, []int{63, 66, 69}, notes)
+	assert.Equal(t, []int{60, 80, 100}, velocities)
+	// The two changing rows switch at a quarter and three quarters of the way
+	assert.Equal(t, []int{1, 1, 1}, soundMakers)
+	assert.Equal(t, []int{-1, -1, 1}, row2)
+}
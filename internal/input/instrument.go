@@ -1,19 +1,17 @@
 package input
 
 import (
-	"log"
-
+	"github.com/schollz/collidertracker/internal/logging"
 	"github.com/schollz/collidertracker/internal/model"
-	"github.com/schollz/collidertracker/internal/storage"
 	"github.com/schollz/collidertracker/internal/supercollider"
 	"github.com/schollz/collidertracker/internal/types"
 )
 
 func ModifyArpeggioValue(m *model.Model, baseDelta float32) {
-	if m.ArpeggioEditingIndex < 0 || m.ArpeggioEditingIndex >= 255 {
+	if m.ArpeggioEditingIndex < 0 || m.ArpeggioEditingIndex >= types.NumSettings {
 		return
 	}
-	if m.CurrentRow < 0 || m.CurrentRow >= 16 {
+	if m.CurrentRow < 0 || m.CurrentRow >= types.ArpeggioRows {
 		return
 	}
 
@@ -37,7 +35,7 @@ func ModifyArpeggioValue(m *model.Model, baseDelta float32) {
 			newDirection = int(types.ArpeggioDirectionDown) // Stay at "d-"
 		}
 		currentRow.Direction = newDirection
-		log.Printf("Modified arpeggio %02X row %02X Direction: %d -> %d", m.ArpeggioEditingIndex, m.CurrentRow, currentRow.Direction-delta, currentRow.Direction)
+		logging.Playback.Debugf("Modified arpeggio %02X row %02X Direction: %d -> %d", m.ArpeggioEditingIndex, m.CurrentRow, currentRow.Direction-delta, currentRow.Direction)
 	} else if m.CurrentCol == int(types.ArpeggioColCO) { // CO (Count) column
 		// Count: -1="--", 0-254 for hex values 00-FE
 		var delta int
@@ -56,7 +54,7 @@ func ModifyArpeggioValue(m *model.Model, baseDelta float32) {
 			newCount = 254 // Cap at FE
 		}
 		currentRow.Count = newCount
-		log.Printf("Modified arpeggio %02X row %02X Count: %d -> %d (delta: %d)", m.ArpeggioEditingIndex, m.CurrentRow, currentRow.Count-delta, currentRow.Count, delta)
+		logging.Playback.Debugf("Modified arpeggio %02X row %02X Count: %d -> %d (delta: %d)", m.ArpeggioEditingIndex, m.CurrentRow, currentRow.Count-delta, currentRow.Count, delta)
 	} else if m.CurrentCol == int(types.ArpeggioColDIV) { // Divisor (/) column
 		// Divisor: -1="--", 1-254 for hex values 01-FE (never allow 00)
 		var delta int
@@ -80,16 +78,16 @@ func ModifyArpeggioValue(m *model.Model, baseDelta float32) {
 			newDivisor = 254
 		}
 		currentRow.Divisor = newDivisor
-		log.Printf("Modified arpeggio %02X row %02X Divisor: %d -> %d (delta: %d)", m.ArpeggioEditingIndex, m.CurrentRow, currentRow.Divisor-delta, currentRow.Divisor, delta)
+		logging.Playback.Debugf("Modified arpeggio %02X row %02X Divisor: %d -> %d (delta: %d)", m.ArpeggioEditingIndex, m.CurrentRow, currentRow.Divisor-delta, currentRow.Divisor, delta)
 	}
 
 	// Store back the modified settings
 	m.ArpeggioSettings[m.ArpeggioEditingIndex] = settings
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventSettings})
 }
 
 func ModifyMidiValue(m *model.Model, baseDelta float32) {
-	if m.MidiEditingIndex < 0 || m.MidiEditingIndex >= 255 {
+	if m.MidiEditingIndex < 0 || m.MidiEditingIndex >= types.NumSettings {
 		return
 	}
 
@@ -133,7 +131,7 @@ func ModifyMidiValue(m *model.Model, baseDelta float32) {
 
 		oldDevice := settings.Device
 		settings.Device = devices[newIndex]
-		log.Printf("Modified MIDI %02X Device: %s -> %s", m.MidiEditingIndex, oldDevice, settings.Device)
+		logging.MIDI.Debugf("Modified MIDI %02X Device: %s -> %s", m.MidiEditingIndex, oldDevice, settings.Device)
 	} else if m.CurrentRow == 1 { // Channel row
 		// Channel cycles through: "1"-"16" and "all"
 		var delta int
@@ -169,14 +167,14 @@ func ModifyMidiValue(m *model.Model, baseDelta float32) {
 
 		oldChannel := settings.Channel
 		settings.Channel = channels[newIndex]
-		log.Printf("Modified MIDI %02X Channel: %s -> %s", m.MidiEditingIndex, oldChannel, settings.Channel)
+		logging.MIDI.Debugf("Modified MIDI %02X Channel: %s -> %s", m.MidiEditingIndex, oldChannel, settings.Channel)
 	}
 
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventSettings})
 }
 
 func ModifySoundMakerValue(m *model.Model, baseDelta float32) {
-	if m.SoundMakerEditingIndex < 0 || m.SoundMakerEditingIndex >= 255 {
+	if m.SoundMakerEditingIndex < 0 || m.SoundMakerEditingIndex >= types.NumSettings {
 		return
 	}
 
@@ -223,7 +221,7 @@ func ModifySoundMakerValue(m *model.Model, baseDelta float32) {
 		// Initialize parameters for the new instrument
 		settings.InitializeParameters()
 
-		log.Printf("Modified SoundMaker %02X Name: %s -> %s", m.SoundMakerEditingIndex, oldName, settings.Name)
+		logging.UI.Debugf("Modified SoundMaker %02X Name: %s -> %s", m.SoundMakerEditingIndex, oldName, settings.Name)
 	} else {
 		// Handle parameter modification using the instrument framework
 		if def, exists := types.GetInstrumentDefinition(settings.Name); exists {
@@ -242,6 +240,15 @@ func ModifySoundMakerValue(m *model.Model, baseDelta float32) {
 				param := params[paramIndex]
 				oldValue := settings.GetParameterValue(param.Key)
 
+				// A parameter locked on the phrase row the view was opened from edits the lock
+				lockPhrase, lockRow, canLock := m.PLockTarget()
+				locked := false
+				if canLock {
+					if value, ok := m.PLock(lockPhrase, lockRow, param.Key); ok {
+						oldValue, locked = value, true
+					}
+				}
+
 				// Calculate delta based on parameter type and input
 				var delta float32
 
@@ -305,10 +312,14 @@ func ModifySoundMakerValue(m *model.Model, baseDelta float32) {
 				}
 
 				// Set the new value
-				settings.SetParameterValue(param.Key, newValue)
+				if locked {
+					m.SetPLock(lockPhrase, lockRow, param.Key, newValue)
+				} else {
+					settings.SetParameterValue(param.Key, newValue)
+				}
 
 				// Special handling for DX7 patch name updates
-				if param.Key == "preset" && settings.Name == "DX7" {
+				if !locked && param.Key == "preset" && settings.Name == "DX7" {
 					if newValue >= 0 {
 						if patchName, err := supercollider.GetDX7PatchName(int(newValue)); err == nil {
 							settings.PatchName = patchName
@@ -321,22 +332,65 @@ func ModifySoundMakerValue(m *model.Model, baseDelta float32) {
 				// Log the change
 				if newValue == -1 {
 					if oldValue == -1 {
-						log.Printf("Modified SoundMaker %02X %s: -- -> -- (delta: %f)", m.SoundMakerEditingIndex, param.DisplayName, delta)
+						logging.UI.Debugf("Modified SoundMaker %02X %s: -- -> -- (delta: %f)", m.SoundMakerEditingIndex, param.DisplayName, delta)
 					} else {
-						log.Printf("Modified SoundMaker %02X %s: %f -> -- (delta: %f)", m.SoundMakerEditingIndex, param.DisplayName, oldValue, delta)
+						logging.UI.Debugf("Modified SoundMaker %02X %s: %f -> -- (delta: %f)", m.SoundMakerEditingIndex, param.DisplayName, oldValue, delta)
 					}
 				} else {
 					if oldValue == -1 {
-						log.Printf("Modified SoundMaker %02X %s: -- -> %f (delta: %f)", m.SoundMakerEditingIndex, param.DisplayName, newValue, delta)
+						logging.UI.Debugf("Modified SoundMaker %02X %s: -- -> %f (delta: %f)", m.SoundMakerEditingIndex, param.DisplayName, newValue, delta)
 					} else {
-						log.Printf("Modified SoundMaker %02X %s: %f -> %f (delta: %f)", m.SoundMakerEditingIndex, param.DisplayName, oldValue, newValue, delta)
+						logging.UI.Debugf("Modified SoundMaker %02X %s: %f -> %f (delta: %f)", m.SoundMakerEditingIndex, param.DisplayName, oldValue, newValue, delta)
 					}
 				}
 			}
 		}
 	}
 
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventSettings})
+}
+
+// selectedSoundMakerParam returns the SoundMaker parameter under the cursor
+func selectedSoundMakerParam(m *model.Model) (types.InstrumentParameterDef, bool) {
+	if m.SoundMakerEditingIndex < 0 || m.SoundMakerEditingIndex >= types.NumSettings || m.CurrentRow == 0 {
+		return types.InstrumentParameterDef{}, false
+	}
+	def, exists := types.GetInstrumentDefinition(m.SoundMakerSettings[m.SoundMakerEditingIndex].Name)
+	if !exists {
+		return types.InstrumentParameterDef{}, false
+	}
+	params, col1 := def.GetParametersSortedByColumn()
+	if m.CurrentCol == 1 {
+		params = col1
+	}
+	paramIndex := m.CurrentRow - 1
+	if paramIndex < 0 || paramIndex >= len(params) {
+		return types.InstrumentParameterDef{}, false
+	}
+	return params[paramIndex], true
+}
+
+// ToggleSoundMakerPLock locks the parameter under the cursor to its current value on the
+// phrase row the SoundMaker view was opened from, or removes the lock
+func ToggleSoundMakerPLock(m *model.Model) {
+	phrase, row, ok := m.PLockTarget()
+	if !ok {
+		return
+	}
+	param, ok := selectedSoundMakerParam(m)
+	if !ok {
+		return
+	}
+	if _, locked := m.PLock(phrase, row, param.Key); locked {
+		m.ClearPLock(phrase, row, param.Key)
+	} else {
+		value := m.SoundMakerSettings[m.SoundMakerEditingIndex].GetParameterValue(param.Key)
+		if value == -1 {
+			value = param.MinValue
+		}
+		m.SetPLock(phrase, row, param.Key, value)
+	}
+	m.Publish(model.Event{Kind: model.EventSettings})
 }
 
 // ClearArpeggioCell clears the current cell in Arpeggio Settings view
@@ -345,10 +399,10 @@ func ClearArpeggioCell(m *model.Model) {
 		return
 	}
 
-	if m.ArpeggioEditingIndex < 0 || m.ArpeggioEditingIndex >= 255 {
+	if m.ArpeggioEditingIndex < 0 || m.ArpeggioEditingIndex >= types.NumSettings {
 		return
 	}
-	if m.CurrentRow < 0 || m.CurrentRow >= 16 {
+	if m.CurrentRow < 0 || m.CurrentRow >= types.ArpeggioRows {
 		return
 	}
 
@@ -358,13 +412,13 @@ func ClearArpeggioCell(m *model.Model) {
 	switch m.CurrentCol {
 	case 0: // DI (Direction) column
 		currentRow.Direction = int(types.ArpeggioDirectionNone) // Clear to "--"
-		log.Printf("Cleared arpeggio %02X row %02X Direction", m.ArpeggioEditingIndex, m.CurrentRow)
+		logging.Playback.Debugf("Cleared arpeggio %02X row %02X Direction", m.ArpeggioEditingIndex, m.CurrentRow)
 	case 1: // CO (Count) column
 		currentRow.Count = -1 // Clear to "--"
-		log.Printf("Cleared arpeggio %02X row %02X Count", m.ArpeggioEditingIndex, m.CurrentRow)
+		logging.Playback.Debugf("Cleared arpeggio %02X row %02X Count", m.ArpeggioEditingIndex, m.CurrentRow)
 	case 2: // Divisor (/) column
 		currentRow.Divisor = -1 // Clear to "--"
-		log.Printf("Cleared arpeggio %02X row %02X Divisor", m.ArpeggioEditingIndex, m.CurrentRow)
+		logging.Playback.Debugf("Cleared arpeggio %02X row %02X Divisor", m.ArpeggioEditingIndex, m.CurrentRow)
 	}
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventSettings})
 }
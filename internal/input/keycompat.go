@@ -0,0 +1,52 @@
+package input
+
+import (
+	"runtime"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyCompatEnabled turns on fallback bindings for terminals that cannot report some
+// modifier combinations. It is on by default on Windows (Windows Terminal / ConPTY).
+var keyCompatEnabled = runtime.GOOS == "windows"
+
+// escAltWindow is how soon a key must follow a lone Esc to be read as an Alt combo
+const escAltWindow = 50 * time.Millisecond
+
+// keyFallbacks maps alternative key presses to the bindings HandleKeyInput expects
+var keyFallbacks = map[string]tea.KeyMsg{
+	// ConPTY delivers Ctrl+Space as a plain space, so Alt+Space stands in for it
+	"alt+ ": {Type: tea.KeyCtrlAt},
+	// Shift+arrows are taken for selection by some Windows terminals
+	"ctrl+shift+up":    {Type: tea.KeyShiftUp},
+	"ctrl+shift+down":  {Type: tea.KeyShiftDown},
+	"ctrl+shift+left":  {Type: tea.KeyShiftLeft},
+	"ctrl+shift+right": {Type: tea.KeyShiftRight},
+}
+
+// lastEscTime is when a lone Esc was last seen (for Esc-prefixed Alt combos)
+var lastEscTime time.Time
+
+// normalizeKey rewrites terminal-specific key presses to the canonical bindings
+func normalizeKey(msg tea.KeyMsg, now time.Time) tea.KeyMsg {
+	if !keyCompatEnabled {
+		return msg
+	}
+
+	// Some terminals split Alt+key into Esc followed by the key
+	if msg.Type == tea.KeyEscape {
+		lastEscTime = now
+		return msg
+	}
+	escPrefixed := !lastEscTime.IsZero() && now.Sub(lastEscTime) < escAltWindow
+	lastEscTime = time.Time{}
+	if escPrefixed && !msg.Alt && (msg.Type != tea.KeyRunes || len(msg.Runes) == 1) {
+		msg.Alt = true
+	}
+
+	if fallback, ok := keyFallbacks[msg.String()]; ok {
+		return fallback
+	}
+	return msg
+}
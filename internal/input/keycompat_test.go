@@ -0,0 +1,44 @@
+package input
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeKey(t *testing.T) {
+	defer func(enabled bool) { keyCompatEnabled = enabled }(keyCompatEnabled)
+	now := time.Now()
+
+	t.Run("disabled leaves keys alone", func(t *testing.T) {
+		keyCompatEnabled = false
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}, Alt: true}
+		assert.Equal(t, "alt+ ", normalizeKey(msg, now).String())
+	})
+
+	keyCompatEnabled = true
+
+	t.Run("fallback bindings", func(t *testing.T) {
+		assert.Equal(t, "ctrl+@", normalizeKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}, Alt: true}, now).String())
+		assert.Equal(t, "shift+up", normalizeKey(tea.KeyMsg{Type: tea.KeyCtrlShiftUp}, now).String())
+		assert.Equal(t, "shift+left", normalizeKey(tea.KeyMsg{Type: tea.KeyCtrlShiftLeft}, now).String())
+		assert.Equal(t, "up", normalizeKey(tea.KeyMsg{Type: tea.KeyUp}, now).String())
+	})
+
+	t.Run("esc prefix becomes alt", func(t *testing.T) {
+		normalizeKey(tea.KeyMsg{Type: tea.KeyEscape}, now)
+		msg := normalizeKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}}, now.Add(10*time.Millisecond))
+		assert.Equal(t, "alt+s", msg.String())
+
+		// The prefix is consumed by one key only
+		msg = normalizeKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}}, now.Add(20*time.Millisecond))
+		assert.Equal(t, "s", msg.String())
+
+		// A slow key after Esc is a separate key press
+		normalizeKey(tea.KeyMsg{Type: tea.KeyEscape}, now)
+		msg = normalizeKey(tea.KeyMsg{Type: tea.KeyUp}, now.Add(time.Second))
+		assert.Equal(t, "up", msg.String())
+	})
+}
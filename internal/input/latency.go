@@ -0,0 +1,36 @@
+package input
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// LatencyProbeMsg sends the next test trigger of a latency measurement
+type LatencyProbeMsg struct{}
+
+// LatencyDoneMsg ends a latency measurement once the last replies had time to arrive
+type LatencyDoneMsg struct{}
+
+// startLatencyTest starts a latency measurement from the diagnostics view
+func startLatencyTest(m *model.Model) tea.Cmd {
+	if !m.StartLatencyTest() {
+		return nil
+	}
+	return HandleLatencyProbe(m)
+}
+
+// HandleLatencyProbe sends a test trigger and schedules the next one, or the end of the
+// measurement after the last
+func HandleLatencyProbe(m *model.Model) tea.Cmd {
+	if m.SendLatencyProbe(time.Now()) {
+		return tea.Tick(model.LatencyProbeInterval, func(time.Time) tea.Msg {
+			return LatencyProbeMsg{}
+		})
+	}
+	return tea.Tick(model.LatencyTimeout, func(time.Time) tea.Msg {
+		return LatencyDoneMsg{}
+	})
+}
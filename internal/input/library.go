@@ -0,0 +1,205 @@
+package input
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/audio"
+	"github.com/schollz/collidertracker/internal/library"
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/storage"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// LibraryListedMsg carries the audio files found in the sample folders back to the UI goroutine
+type LibraryListedMsg struct {
+	Job   *model.LibraryScan // Scan the files were listed for
+	Files []library.File     // Audio files of the sample folders
+}
+
+// LibraryAnalyzedMsg carries the analysis of one file of the sample library back to the UI goroutine
+type LibraryAnalyzedMsg struct {
+	Job   *model.LibraryScan // Scan the file belongs to
+	Entry library.Entry      // What the analysis found
+	Err   error              // Why the analysis failed (nil if it worked)
+}
+
+// openLibrary switches from the file browser to the sample library, loading its index the first
+// time, and looks for new and changed files in the sample folders in the background
+func openLibrary(m *model.Model) tea.Cmd {
+	if m.Library == nil {
+		index, err := library.Load(library.Path())
+		if err != nil {
+			logging.Storage.Errorf("Error loading the sample library: %v", err)
+			index = &library.Index{}
+		}
+		m.Library = index
+	}
+	m.ViewMode = types.LibraryView
+	m.CurrentRow = 0
+	m.ScrollOffset = 0
+	return scanLibrary(m)
+}
+
+// scanLibrary lists the files of the sample folders off the UI goroutine, unless a scan is
+// already running
+func scanLibrary(m *model.Model) tea.Cmd {
+	if m.LibraryScan != nil || len(m.Config.SampleDirs) == 0 {
+		return nil
+	}
+	job := &model.LibraryScan{Listing: true}
+	m.LibraryScan = job
+	dirs := slices.Clone(m.Config.SampleDirs)
+	return func() tea.Msg {
+		return LibraryListedMsg{Job: job, Files: library.List(dirs, storage.IsAudioFile)}
+	}
+}
+
+// HandleLibraryListed drops the samples that are gone from the index and starts analyzing the
+// new and changed files one at a time, unless eco mode pauses the scan
+func HandleLibraryListed(m *model.Model, msg LibraryListedMsg) tea.Cmd {
+	job := m.LibraryScan
+	if job == nil || msg.Job != job {
+		return nil
+	}
+	before := len(m.Library.Entries)
+	job.Listing = false
+	job.Files = m.Library.Update(msg.Files)
+	logging.Storage.Debugf("Sample library: %d files, %d to analyze", len(msg.Files), len(job.Files))
+	if len(job.Files) == 0 {
+		m.LibraryScan = nil
+		if len(m.Library.Entries) != before {
+			saveLibrary(m)
+		}
+		return nil
+	}
+	if m.EcoMode {
+		job.Paused = true
+		return nil
+	}
+	return analyzeNextLibraryFile(m)
+}
+
+// analyzeNextLibraryFile returns a command that analyzes the next file of the scan off the UI goroutine
+func analyzeNextLibraryFile(m *model.Model) tea.Cmd {
+	job := m.LibraryScan
+	file := job.Files[job.Done]
+	return func() tea.Msg {
+		entry, err := library.Analyze(file)
+		return LibraryAnalyzedMsg{Job: job, Entry: entry, Err: err}
+	}
+}
+
+// HandleLibraryAnalyzed adds a file to the index and starts on the next one. The index is saved
+// every 50 files, so a long first scan is not lost on quitting, and when the scan is complete.
+func HandleLibraryAnalyzed(m *model.Model, msg LibraryAnalyzedMsg) tea.Cmd {
+	job := m.LibraryScan
+	if job == nil || msg.Job != job {
+		return nil
+	}
+	if msg.Err != nil {
+		job.Failed++
+		logging.Storage.Warnf("Sample library analysis failed for %s: %v", job.Files[job.Done].Path, msg.Err)
+	} else {
+		m.Library.Put(msg.Entry)
+	}
+	job.Done++
+	if job.Done < len(job.Files) {
+		if job.Done%50 == 0 {
+			saveLibrary(m)
+		}
+		if m.EcoMode {
+			job.Paused = true
+			return nil
+		}
+		return analyzeNextLibraryFile(m)
+	}
+
+	m.LibraryScan = nil
+	m.Notice = fmt.Sprintf("Indexed %d samples", job.Done-job.Failed)
+	if job.Failed > 0 {
+		m.Notice += fmt.Sprintf(", %d failed", job.Failed)
+	}
+	logging.Storage.Debugf("Sample library scan finished: %d files, %d failed", job.Done, job.Failed)
+	saveLibrary(m)
+	return nil
+}
+
+// saveLibrary writes the index to the config folder
+func saveLibrary(m *model.Model) {
+	path := library.Path()
+	if path == "" {
+		return
+	}
+	if err := m.Library.Save(path); err != nil {
+		logging.Storage.Errorf("Error saving the sample library: %v", err)
+	}
+}
+
+// toggleSampleFolder adds the folder being browsed to the folders the sample library indexes,
+// or removes it if it is one of them
+func toggleSampleFolder(m *model.Model) {
+	dir := m.CurrentDir
+	if i := slices.Index(m.Config.SampleDirs, dir); i >= 0 {
+		m.Config.SampleDirs = slices.Delete(slices.Clone(m.Config.SampleDirs), i, i+1)
+		m.Notice = "Removed " + filepath.Base(dir) + " from the sample library"
+	} else {
+		m.Config.SampleDirs = append(slices.Clone(m.Config.SampleDirs), dir)
+		m.Notice = "Added " + filepath.Base(dir) + " to the sample library"
+	}
+	logging.Storage.Debugf("Sample library folders: %v", m.Config.SampleDirs)
+	m.Publish(model.Event{Kind: model.EventSettings})
+}
+
+// handleLibraryInput edits the search and picks a sample: typing searches, enter uses the
+// selected sample on the phrase row the file browser was opened from, esc returns to the browser
+func handleLibraryInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	matches := m.LibraryMatches()
+	switch msg.String() {
+	case "ctrl+q", "alt+q":
+		return requestQuit(m)
+	case "esc":
+		m.ViewMode = types.FileView
+		m.CurrentRow = 0
+		m.ScrollOffset = 0
+	case "up":
+		m.CurrentRow = max(0, m.CurrentRow-1)
+	case "down":
+		m.CurrentRow = max(0, min(len(matches)-1, m.CurrentRow+1))
+	case "pgup":
+		m.CurrentRow = max(0, m.CurrentRow-16)
+	case "pgdown":
+		m.CurrentRow = max(0, min(len(matches)-1, m.CurrentRow+16))
+	case "ctrl+right", "alt+right":
+		if m.CurrentRow < len(matches) {
+			audio.PlayPath(m, matches[m.CurrentRow].Path)
+		}
+	case "enter":
+		if m.CurrentRow < len(matches) {
+			audio.AssignFile(m, matches[m.CurrentRow].Path)
+			switchToView(m, phraseViewConfig(m.FileSelectRow, m.FileSelectCol))
+		}
+	case "backspace":
+		if query := []rune(m.LibraryQuery); len(query) > 0 {
+			m.LibraryQuery = string(query[:len(query)-1])
+			m.CurrentRow = 0
+		}
+	case "ctrl+u", "alt+u":
+		m.LibraryQuery = ""
+		m.CurrentRow = 0
+	default:
+		switch msg.Type {
+		case tea.KeyRunes:
+			m.LibraryQuery += string(msg.Runes)
+			m.CurrentRow = 0
+		case tea.KeySpace:
+			m.LibraryQuery += " "
+			m.CurrentRow = 0
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/schollz/collidertracker/internal/library"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+func TestLibraryView(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // Keep the index out of the real config folder
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	samples := t.TempDir()
+	data, err := os.ReadFile("../getbpm/Break120.wav")
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(samples, "Break120.wav"), data, 0644))
+
+	m := createTestModel(t)
+	m.SaveFolder = t.TempDir()
+	m.ViewMode = types.FileView
+	m.CurrentDir = samples
+	m.FileSelectRow = 3
+	m.FileSelectCol = int(types.ColFilename)
+
+	// + indexes the folder browsed, / opens the library and scans it
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	assert.Equal(t, []string{samples}, m.Config.SampleDirs)
+	cmd := HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	assert.Equal(t, types.LibraryView, m.ViewMode)
+	assert.NotNil(t, cmd)
+	cmd = HandleLibraryListed(m, cmd().(LibraryListedMsg))
+	assert.Equal(t, 1, len(m.LibraryScan.Files))
+	assert.Nil(t, HandleLibraryAnalyzed(m, cmd().(LibraryAnalyzedMsg)))
+	assert.Nil(t, m.LibraryScan)
+	assert.Equal(t, "Indexed 1 samples", m.Notice)
+	saved, err := library.Load(library.Path())
+	assert.NoError(t, err)
+	assert.Len(t, saved.Entries, 1)
+
+	// Typing searches
+	for _, r := range "drum" {
+		HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	assert.Empty(t, m.LibraryMatches())
+	for range "drum" {
+		HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	for _, r := range "loop" {
+		HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	assert.Len(t, m.LibraryMatches(), 1)
+
+	// Enter uses the sample on the phrase row the browser was opened for
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, types.PhraseView, m.ViewMode)
+	fileIndex := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, 3, types.ColFilename)
+	assert.Equal(t, filepath.Join(samples, "Break120.wav"), (*m.GetCurrentPhrasesFiles())[fileIndex])
+
+	// A second scan finds nothing new
+	m.ViewMode = types.FileView
+	cmd = HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	assert.Nil(t, HandleLibraryListed(m, cmd().(LibraryListedMsg)))
+	assert.Nil(t, m.LibraryScan)
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, types.FileView, m.ViewMode)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	assert.Empty(t, m.Config.SampleDirs, "+ again removes the folder")
+}
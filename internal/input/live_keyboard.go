@@ -0,0 +1,54 @@
+package input
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// handleLiveKeyboardKey plays the live keyboard: note keys play the current track, - and =
+// change the octave, and esc or Z leave it. Other letters are swallowed so nothing is edited
+// by accident; handled is false for the keys left to the current view (arrows, space, Ctrl).
+func handleLiveKeyboardKey(m *model.Model, msg tea.KeyMsg) (cmd tea.Cmd, handled bool) {
+	switch key := msg.String(); key {
+	case "esc", "Z":
+		m.ToggleLiveKeyboard()
+	case "-":
+		m.ShiftLiveOctave(-1)
+	case "=", "+":
+		m.ShiftLiveOctave(1)
+	default:
+		if value, ok := m.LiveKeyValue(key); ok {
+			playLiveKey(m, value)
+		} else if msg.Type != tea.KeyRunes {
+			return nil, false
+		}
+	}
+	return nil, true
+}
+
+// playLiveKey plays a note (instrument) or slice (sampler) through the phrase row under the
+// cursor, or the last phrase row selected when another view is shown
+func playLiveKey(m *model.Model, value int) {
+	row := m.LastPhraseRow
+	if m.ViewMode == types.PhraseView {
+		row = m.CurrentRow
+	}
+	if row < 0 || row >= types.PhraseRows {
+		row = 0
+	}
+	if m.CurrentTrack < 0 || m.CurrentTrack >= types.NumTracks {
+		return
+	}
+	if m.TrackTypes[m.CurrentTrack] && GetEffectiveFilenameForTrack(m, m.CurrentPhrase, row, m.CurrentTrack) == "none" {
+		m.Notice = "No sample to play on this row"
+		return
+	}
+	logging.UI.Debugf("Live keyboard plays %02X on track %d", value, m.CurrentTrack)
+	m.WithLiveNote(m.CurrentTrack, m.CurrentPhrase, row, value, func() {
+		EmitRowDataFor(m, m.CurrentPhrase, row, m.CurrentTrack)
+	})
+	m.LiveKeys.Last = value
+}
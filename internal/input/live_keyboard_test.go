@@ -0,0 +1,52 @@
+package input
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+func TestLiveKeyboard(t *testing.T) {
+	m := createTestModel(t)
+	m.TrackTypes[0] = false
+	m.CurrentTrack = 0
+	m.CurrentPhrase = 3
+	m.ViewMode = types.PhraseView
+	m.CurrentRow = 2
+	before := m.GetPhraseRow(0, 3, 2)
+	key := func(s string) { HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}) }
+
+	key("Z")
+	assert.True(t, m.LiveKeys.Active)
+
+	// q is C5 on the default octave, one octave above z
+	key("q")
+	assert.Equal(t, 72, m.LiveKeys.Last)
+	assert.True(t, m.TrackTriggered(0, time.Now()), "The key plays the current track")
+	assert.Equal(t, before, m.GetPhraseRow(0, 3, 2), "Playing does not edit the row")
+	assert.False(t, m.IsDirty())
+
+	key("-")
+	key("z")
+	assert.Equal(t, 48, m.LiveKeys.Last)
+
+	// Other letters do nothing instead of editing
+	key("p")
+	assert.Equal(t, types.PhraseView, m.ViewMode)
+	assert.Equal(t, 48, m.LiveKeys.Last)
+
+	// Sampler tracks play slices of the row's sample
+	m.TrackTypes[0] = true
+	v, ok := m.LiveKeyValue("w")
+	assert.True(t, ok)
+	assert.Equal(t, 14, v)
+	key("w")
+	assert.Equal(t, "No sample to play on this row", m.Notice)
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, m.LiveKeys.Active)
+}
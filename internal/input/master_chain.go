@@ -0,0 +1,51 @@
+package input
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// handleMasterChainInput handles keys in the master chain view: select a stage, move it, or go back to Settings
+func handleMasterChainInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+q", "alt+q":
+		return requestQuit(m)
+	case "esc", "q", "p", "shift+left":
+		m.ViewMode = types.SettingsView
+	case "up", "k":
+		if m.MasterChainRow > 0 {
+			m.MasterChainRow--
+		}
+	case "down", "j":
+		if m.MasterChainRow < len(m.MasterChain)-1 {
+			m.MasterChainRow++
+		}
+	case "ctrl+up", "alt+up", "ctrl+left", "alt+left":
+		moveMasterStage(m, -1)
+	case "ctrl+down", "alt+down", "ctrl+right", "alt+right":
+		moveMasterStage(m, 1)
+	case "r":
+		if !m.IsDefaultMasterChain() {
+			m.MasterChain = model.DefaultMasterChain()
+			applyMasterChain(m)
+		}
+	}
+	return nil
+}
+
+// moveMasterStage moves the selected stage earlier or later in the master chain
+func moveMasterStage(m *model.Model, delta int) {
+	if m.MoveMasterStage(delta) {
+		applyMasterChain(m)
+	}
+}
+
+// applyMasterChain rebuilds the master output in SuperCollider and saves the new order
+func applyMasterChain(m *model.Model) {
+	logging.UI.Debugf("Master chain: %v", m.MasterChain)
+	m.SendOSCMasterChainMessage()
+	m.Publish(model.Event{Kind: model.EventSettings})
+}
@@ -0,0 +1,81 @@
+package input
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// startNameEdit starts naming the chain or phrase being viewed
+func startNameEdit(m *model.Model) {
+	switch m.ViewMode {
+	case types.ChainView:
+		m.NameEntry = model.NameEntry{Active: true, Buffer: m.ChainName(m.CurrentTrack, m.CurrentChain)}
+	case types.PhraseView:
+		m.NameEntry = model.NameEntry{Active: true, Buffer: m.PhraseName(m.CurrentTrack, m.CurrentPhrase)}
+	}
+}
+
+// startNameSearch starts searching the names of the current track's chains and phrases
+func startNameSearch(m *model.Model) {
+	switch m.ViewMode {
+	case types.SongView, types.ChainView, types.PhraseView:
+		m.NameEntry = model.NameEntry{Active: true, Search: true}
+	}
+}
+
+// handleNameKey edits a name or search: enter applies the name (an empty name removes it) or
+// opens the chosen match, tab and up/down choose between matches, esc cancels
+func handleNameKey(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		entry := m.NameEntry
+		m.NameEntry = model.NameEntry{}
+		switch {
+		case entry.Search:
+			if matches := m.SearchNames(m.CurrentTrack, entry.Buffer); len(matches) > 0 {
+				openNameMatch(m, matches[entry.Match%len(matches)])
+			}
+		case m.ViewMode == types.ChainView:
+			m.SetChainName(m.CurrentTrack, m.CurrentChain, entry.Buffer)
+		case m.ViewMode == types.PhraseView:
+			m.SetPhraseName(m.CurrentTrack, m.CurrentPhrase, entry.Buffer)
+		}
+	case tea.KeyEsc:
+		m.NameEntry = model.NameEntry{}
+	case tea.KeyTab, tea.KeyDown:
+		m.NameEntry.Match++
+	case tea.KeyShiftTab, tea.KeyUp:
+		m.NameEntry.Match = max(0, m.NameEntry.Match-1)
+	case tea.KeyBackspace:
+		if len(m.NameEntry.Buffer) > 0 {
+			runes := []rune(m.NameEntry.Buffer)
+			m.NameEntry.Buffer = string(runes[:len(runes)-1])
+			m.NameEntry.Match = 0
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		if len([]rune(m.NameEntry.Buffer))+len(msg.Runes) <= model.MaxNameLength {
+			m.NameEntry.Buffer += string(msg.Runes)
+			m.NameEntry.Match = 0
+		}
+	}
+	return nil
+}
+
+// openNameMatch opens a chain or phrase found by a name search
+func openNameMatch(m *model.Model, match model.NameMatch) {
+	if match.Kind == model.DataChain {
+		m.CurrentChain = match.ID
+		switchToViewWithVisibilityCheck(m, chainViewConfig(0))
+	} else {
+		col := int(types.SamplerColNN)
+		if m.GetPhraseViewType() == types.InstrumentPhraseView {
+			col = int(types.InstrumentColNOT)
+		}
+		goToPhrase(m, match.ID)
+		switchToViewWithVisibilityCheck(m, phraseViewConfig(0, col))
+	}
+	logging.UI.Debugf("Opened %s from a name search", match)
+}
@@ -0,0 +1,49 @@
+package input
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// handleNotesInput edits the project notes: every printable key types, so only esc leaves
+func handleNotesInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+q", "alt+q":
+		return requestQuit(m)
+	case "esc":
+		toggleAuxView(m, types.NotesView)
+	case "ctrl+s", "alt+s":
+		return handleCtrlS(m)
+	case "up":
+		m.MoveNotesCursor(-1, 0)
+	case "down":
+		m.MoveNotesCursor(1, 0)
+	case "left":
+		m.MoveNotesCursor(0, -1)
+	case "right":
+		m.MoveNotesCursor(0, 1)
+	case "pgup":
+		m.MoveNotesCursor(-16, 0)
+	case "pgdown":
+		m.MoveNotesCursor(16, 0)
+	case "home", "ctrl+a":
+		m.NotesCol = 0
+	case "end", "ctrl+e":
+		m.MoveNotesToLineEnd()
+	case "enter":
+		m.NotesNewline()
+	case "backspace":
+		m.NotesBackspace()
+	case "delete":
+		m.NotesDelete()
+	case "tab":
+		m.TypeNotes("\t")
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.TypeNotes(string(msg.Runes))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package input
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// numberEntryTarget reports whether the cell under the cursor takes a typed value, whether
+// it is hexadecimal and the largest value it holds
+func numberEntryTarget(m *model.Model) (hex bool, maxValue int, ok bool) {
+	switch m.ViewMode {
+	case types.SongView:
+		return true, 254, m.CurrentCol >= 0 && m.CurrentCol < types.NumTracks
+	case types.ChainView:
+		switch types.ChainColumn(m.CurrentCol) {
+		case types.ChainColPhrase, types.ChainColReverb, types.ChainColLowPass:
+			return true, 254, true
+		}
+	case types.PhraseView:
+		columnMapping := m.GetColumnMapping(m.CurrentCol)
+		if columnMapping == nil || !columnMapping.IsEditable || m.CurrentRow < 0 {
+			return false, 0, false
+		}
+		col := types.PhraseColumn(columnMapping.DataColumnIndex)
+		if m.GetPhraseViewType() == types.InstrumentPhraseView {
+			// Notes are shown as note names and chords as symbols, so they are not typed
+			if col == types.ColNote || col == types.ColChord || col == types.ColChordAddition ||
+				col == types.ColChordTransposition || slices.Contains(types.StackedNoteColumns, col) {
+				return false, 0, false
+			}
+		}
+		switch {
+		case col == types.ColEffectReverse:
+			return true, 15, true
+		case col == types.ColVelocity, col >= types.ColMidiCC0 && col <= types.ColMidiCC8:
+			return true, 127, true
+		}
+		return true, 254, true
+	case types.SettingsView:
+		return false, 999, m.CurrentCol == 0 && types.GlobalSettingsRow(m.CurrentRow) == types.GlobalSettingsRowBPM
+	}
+	return false, 0, false
+}
+
+// startNumberEntry starts typing a value into the cell under the cursor with its first digit
+func startNumberEntry(m *model.Model, digit string) {
+	hex, _, ok := numberEntryTarget(m)
+	if !ok {
+		return
+	}
+	m.NumberEntry = model.NumberEntry{Active: true, Hex: hex, Buffer: digit}
+}
+
+// handleNumberEntryKey takes the digits of a typed value: enter sets it, esc and backspace
+// past the first digit cancel. Other keys cancel the entry and keep their usual action.
+func handleNumberEntryKey(m *model.Model, msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		applyNumberEntry(m)
+		m.NumberEntry = model.NumberEntry{}
+		return nil, true
+	case tea.KeyEsc:
+		m.NumberEntry = model.NumberEntry{}
+		return nil, true
+	case tea.KeyBackspace:
+		m.NumberEntry.Buffer = m.NumberEntry.Buffer[:len(m.NumberEntry.Buffer)-1]
+		if m.NumberEntry.Buffer == "" {
+			m.NumberEntry = model.NumberEntry{}
+		}
+		return nil, true
+	case tea.KeyRunes:
+		if digits := string(msg.Runes); validNumberEntry(m.NumberEntry, digits) {
+			if len(m.NumberEntry.Buffer)+len(digits) <= model.MaxNumberEntryLength {
+				m.NumberEntry.Buffer += digits
+			}
+			return nil, true
+		}
+	}
+	m.NumberEntry = model.NumberEntry{}
+	return nil, false
+}
+
+// validNumberEntry reports whether typed characters belong in an entry
+func validNumberEntry(entry model.NumberEntry, typed string) bool {
+	for _, r := range typed {
+		switch {
+		case r >= '0' && r <= '9':
+		case entry.Hex && (r >= 'a' && r <= 'f' || r >= 'A' && r <= 'F'):
+		case !entry.Hex && r == '.' && !strings.Contains(entry.Buffer, "."):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// applyNumberEntry sets the cell under the cursor to the typed value, clamped to its range
+func applyNumberEntry(m *model.Model) {
+	hex, maxValue, ok := numberEntryTarget(m)
+	if !ok {
+		return
+	}
+	if !hex {
+		bpm, err := strconv.ParseFloat(m.NumberEntry.Buffer, 32)
+		if err != nil {
+			return
+		}
+		m.BPM = float32(max(1, min(float64(maxValue), bpm)))
+		logging.UI.Debugf("Typed BPM: %.2f", m.BPM)
+		m.Publish(model.Event{Kind: model.EventSettings})
+		return
+	}
+	parsed, err := strconv.ParseInt(m.NumberEntry.Buffer, 16, 32)
+	if err != nil {
+		return
+	}
+	value := max(0, min(maxValue, int(parsed)))
+
+	switch m.ViewMode {
+	case types.SongView:
+		m.SetSongCell(m.CurrentCol, m.CurrentRow, m.ClampToBank(m.CurrentCol, value))
+	case types.ChainView:
+		switch types.ChainColumn(m.CurrentCol) {
+		case types.ChainColPhrase:
+			m.SetChainCell(m.CurrentTrack, m.CurrentChain, m.CurrentRow, m.ClampToBank(m.CurrentTrack, value))
+		case types.ChainColReverb, types.ChainColLowPass:
+			fx := m.GetChainFX(m.CurrentTrack, m.CurrentChain, m.CurrentRow)
+			if types.ChainColumn(m.CurrentCol) == types.ChainColReverb {
+				fx.Reverb = value
+			} else {
+				fx.LowPass = value
+			}
+			m.SetChainFX(m.CurrentTrack, m.CurrentChain, m.CurrentRow, fx)
+		}
+	case types.PhraseView:
+		col := types.PhraseColumn(m.GetColumnMapping(m.CurrentCol).DataColumnIndex)
+		oldValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, col)
+		m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, col, value)
+		// A note typed on an empty row gets a DT like one entered with the arrows
+		if col == types.ColNote && oldValue == -1 &&
+			m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColDeltaTime) == -1 {
+			dtValue := FindFirstNonEmptyDTAbove(m, m.CurrentTrack, m.CurrentPhrase, m.CurrentRow)
+			m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColDeltaTime, dtValue)
+		}
+		m.LastEditRow = m.CurrentRow
+		if m.IsRowCurrentlyPlaying(m.CurrentPhrase, m.CurrentRow, m.CurrentTrack) {
+			EmitRowDataFor(m, m.CurrentPhrase, m.CurrentRow, m.CurrentTrack, true)
+		}
+	}
+	logging.UI.Debugf("Typed value %02X", value)
+	m.Publish(model.Event{Kind: model.EventSettings})
+}
@@ -0,0 +1,71 @@
+package input
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/storage"
+)
+
+// startPasswordEntry starts typing a password to protect the project with
+func startPasswordEntry(m *model.Model) {
+	m.PasswordEntry = model.PasswordEntry{Active: true}
+}
+
+// confirmRemovePassword asks before the project is saved unencrypted again
+func confirmRemovePassword(m *model.Model) {
+	m.PendingConfirm = &model.ConfirmPrompt{
+		Message: "Remove the project password and save it unencrypted?",
+		OnConfirm: func() {
+			setProjectPassword(m, "")
+		},
+	}
+}
+
+// handlePasswordKey types a project password twice: enter moves on to repeating it and then
+// protects the project, esc cancels
+func handlePasswordKey(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	entry := &m.PasswordEntry
+	switch msg.Type {
+	case tea.KeyEnter:
+		switch {
+		case entry.First == "" && len([]rune(entry.Buffer)) < model.MinPasswordLength:
+			m.Notice = fmt.Sprintf("Passwords need at least %d characters", model.MinPasswordLength)
+		case entry.First == "":
+			entry.First, entry.Buffer = entry.Buffer, ""
+		case entry.Buffer != entry.First:
+			m.PasswordEntry = model.PasswordEntry{}
+			m.Notice = "Passwords don't match, the project is not protected"
+		default:
+			password := entry.Buffer
+			m.PasswordEntry = model.PasswordEntry{}
+			setProjectPassword(m, password)
+		}
+	case tea.KeyEsc:
+		m.PasswordEntry = model.PasswordEntry{}
+	case tea.KeyBackspace:
+		if runes := []rune(entry.Buffer); len(runes) > 0 {
+			entry.Buffer = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		entry.Buffer += string(msg.Runes)
+	}
+	return nil
+}
+
+// setProjectPassword protects the project with a password, or removes it for "", and says so
+func setProjectPassword(m *model.Model, password string) {
+	if err := storage.SetProjectPassword(m, password); err != nil {
+		logging.UI.Errorf("Error setting the project password: %v", err)
+		m.Notice = fmt.Sprintf("Could not change the project password: %v", err)
+		return
+	}
+	if password == "" {
+		m.Notice = "Project password removed"
+	} else {
+		m.Notice = "Project is password protected"
+	}
+}
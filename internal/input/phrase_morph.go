@@ -0,0 +1,132 @@
+package input
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// morphSwitchedColumns hold settings indexes and choices rather than amounts, so a morph
+// switches them from one phrase to the other instead of interpolating them
+var morphSwitchedColumns = []types.PhraseColumn{
+	types.ColRetrigger, types.ColTimestretch, types.ColModulate, types.ColFilename,
+	types.ColChord, types.ColChordAddition, types.ColChordTransposition,
+	types.ColArpeggio, types.ColMidi, types.ColSoundMaker,
+}
+
+// MorphPhrases fills the empty chain rows between the phrase under the cursor and the next
+// phrase below it with new phrases that morph from one to the other, one step per empty row.
+// Amounts (notes, pitch, velocity, filters, envelopes...) move in even steps; rows that play
+// in only one phrase, settings indexes and parameter locks switch over row by row, in a
+// scattered order, so each step changes a little more of the pattern. The new phrases come
+// from free slots. It returns how many phrases were made.
+func MorphPhrases(m *model.Model) int {
+	if m.ViewMode != types.ChainView || m.CurrentRow < 0 || m.CurrentRow >= types.ChainRows {
+		return 0
+	}
+	track, chain := m.CurrentTrack, m.CurrentChain
+	from := m.GetChainCell(track, chain, m.CurrentRow)
+	if from < 0 {
+		m.Notice = "Morph starts from a phrase in the chain"
+		return 0
+	}
+	toRow := -1
+	for row := m.CurrentRow + 1; row < types.ChainRows && toRow == -1; row++ {
+		if m.GetChainCell(track, chain, row) >= 0 {
+			toRow = row
+		}
+	}
+	steps := toRow - m.CurrentRow - 1
+	if toRow == -1 || steps < 1 {
+		m.Notice = "Leave empty chain rows before the next phrase to morph through"
+		return 0
+	}
+	to := m.GetChainCell(track, chain, toRow)
+	if countUnused(IsPhraseUnused, m) < steps {
+		m.Notice = fmt.Sprintf("Not enough free phrases to morph over %d steps", steps)
+		return 0
+	}
+	snapshotBefore(m, "phrase morph")
+
+	switchAt := morphSwitchPoints(m, track, from, to)
+	for step := 1; step <= steps; step++ {
+		phrase := FindNextUnusedPhrase(m, from)
+		m.SetChainCell(track, chain, m.CurrentRow+step, phrase) // Claims the phrase before the next search
+		morphPhrase(m, track, from, to, phrase, float64(step)/float64(steps+1), switchAt)
+	}
+
+	logging.UI.Debugf("Morphed phrase %02X into %02X over %d phrases in chain %02X", from, to, steps, chain)
+	m.Notice = fmt.Sprintf("Phrase %02X morphed into %02X over %d phrases", from, to, steps)
+	m.Publish(model.Event{Kind: model.EventSettings})
+	return steps
+}
+
+// morphPhrase writes one step of a morph into dest, at amount (0 for the source phrase, 1 for
+// the target)
+func morphPhrase(m *model.Model, track, from, to, dest int, amount float64, switchAt []float64) {
+	for row := 0; row < types.PhraseRows; row++ {
+		switched := amount >= switchAt[row]
+		source := from
+		if switched {
+			source = to
+		}
+		wholeRow := IsRowPlayable(m.GetPhraseCell(track, from, row, types.ColDeltaTime)) !=
+			IsRowPlayable(m.GetPhraseCell(track, to, row, types.ColDeltaTime))
+		for col := types.PhraseColumn(0); col < types.ColCount; col++ {
+			a, b := m.GetPhraseCell(track, from, row, col), m.GetPhraseCell(track, to, row, col)
+			value := m.GetPhraseCell(track, source, row, col)
+			if !wholeRow && a != -1 && b != -1 && !morphSwitched(m, track, col) {
+				value = int(math.Round(float64(a) + float64(b-a)*amount))
+			}
+			m.SetPhraseCell(track, dest, row, col, value)
+		}
+		if !m.TrackTypes[track] {
+			for key := range m.RowPLocks(dest, row) {
+				m.ClearPLock(dest, row, key)
+			}
+			for key, value := range m.RowPLocks(source, row) {
+				m.SetPLock(dest, row, key, value)
+			}
+		}
+	}
+}
+
+// morphSwitched reports whether a morph switches a column rather than interpolating it. The
+// note column of sampler phrases picks a slice.
+func morphSwitched(m *model.Model, track int, col types.PhraseColumn) bool {
+	return slices.Contains(morphSwitchedColumns, col) || (col == types.ColNote && m.TrackTypes[track])
+}
+
+// morphSwitchPoints returns the amount at which each row of a morph switches from the source
+// phrase to the target. Rows that differ in more than amounts are spread evenly over the
+// morph in a scattered order (by the golden ratio), so the pattern doesn't change front to
+// back; other rows switch halfway.
+func morphSwitchPoints(m *model.Model, track, from, to int) []float64 {
+	switchAt := make([]float64, types.PhraseRows)
+	var changing []int
+	for row := range switchAt {
+		switchAt[row] = 0.5
+		for col := types.PhraseColumn(0); col < types.ColCount; col++ {
+			a, b := m.GetPhraseCell(track, from, row, col), m.GetPhraseCell(track, to, row, col)
+			if a != b && (a == -1 || b == -1 || morphSwitched(m, track, col) ||
+				(col == types.ColDeltaTime && IsRowPlayable(a) != IsRowPlayable(b))) {
+				changing = append(changing, row)
+				break
+			}
+		}
+	}
+	scatter := func(row int) float64 {
+		_, frac := math.Modf(float64(row+1) * 0.6180339887)
+		return frac
+	}
+	sort.SliceStable(changing, func(i, j int) bool { return scatter(changing[i]) < scatter(changing[j]) })
+	for i, row := range changing {
+		switchAt[row] = (float64(i) + 0.5) / float64(len(changing))
+	}
+	return switchAt
+}
@@ -1,10 +1,10 @@
 package input
 
 import (
-	"log"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/schollz/collidertracker/internal/logging"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/types"
 )
@@ -33,7 +33,7 @@ func TogglePlayback(m *model.Model) tea.Cmd {
 		}
 
 		if shouldStop {
-			log.Printf("Stopping playback before starting new playback in different context")
+			logging.Playback.Debugf("Stopping playback before starting new playback in different context")
 			m.IsPlaying = false
 			if m.RecordingActive {
 				stopRecording(m)
@@ -164,7 +164,7 @@ func TogglePlaybackFromTopGlobal(m *model.Model) tea.Cmd {
 func TogglePlaybackFromLastSongRow(m *model.Model) tea.Cmd {
 	// If playback was started from Chain or Phrase view, stop it first
 	if m.IsPlaying && (m.PlaybackMode == types.ChainView || m.PlaybackMode == types.PhraseView) {
-		log.Printf("Ctrl+Space: Stopping playback that was started from %v view", m.PlaybackMode)
+		logging.Playback.Debugf("Ctrl+Space: Stopping playback that was started from %v view", m.PlaybackMode)
 		m.IsPlaying = false
 		if m.RecordingActive {
 			stopRecording(m)
@@ -202,13 +202,13 @@ func CancelQueuedAction(m *model.Model) {
 	}
 
 	track := m.CurrentCol
-	if track < 0 || track >= 8 {
+	if track < 0 || track >= types.NumTracks {
 		return
 	}
 
 	// Check if there's a queued action for this track
 	if m.SongPlaybackQueued[track] != 0 {
-		log.Printf("ESC: Cancelling queued action for track %d (queued=%d, queuedRow=%d)",
+		logging.Playback.Debugf("ESC: Cancelling queued action for track %d (queued=%d, queuedRow=%d)",
 			track, m.SongPlaybackQueued[track], m.SongPlaybackQueuedRow[track])
 		m.SongPlaybackQueued[track] = 0
 		m.SongPlaybackQueuedRow[track] = -1
@@ -223,14 +223,14 @@ func ToggleSingleTrackPlayback(m *model.Model) tea.Cmd {
 	}
 
 	track := m.CurrentCol
-	if track < 0 || track >= 8 {
-		log.Printf("Invalid track %d for single track playback", track)
+	if track < 0 || track >= types.NumTracks {
+		logging.Playback.Warnf("Invalid track %d for single track playback", track)
 		return nil
 	}
 
 	// If playback was started from Chain or Phrase view, stop it first
 	if m.IsPlaying && (m.PlaybackMode == types.ChainView || m.PlaybackMode == types.PhraseView) {
-		log.Printf("Stopping playback that was started from %v view", m.PlaybackMode)
+		logging.Playback.Debugf("Stopping playback that was started from %v view", m.PlaybackMode)
 		m.IsPlaying = false
 		if m.RecordingActive {
 			stopRecording(m)
@@ -264,8 +264,8 @@ func ToggleSingleTrackPlayback(m *model.Model) tea.Cmd {
 	}
 
 	songRow := m.CurrentRow
-	if songRow < 0 || songRow >= 16 {
-		log.Printf("Invalid song row %d for single track playback", songRow)
+	if songRow < 0 || songRow >= types.SongRows {
+		logging.Playback.Warnf("Invalid song row %d for single track playback", songRow)
 		return nil
 	}
 
@@ -277,36 +277,35 @@ func ToggleSingleTrackPlayback(m *model.Model) tea.Cmd {
 		if !isOnPlayingCell {
 			// Track is playing but cursor is on a different cell - JUMP functionality
 			// Queue stop for current cell and start for selected cell
-			chainID := m.SongData[track][songRow]
+			chainID := m.GetSongCell(track, songRow)
 			if chainID == -1 {
-				log.Printf("Cannot jump: no chain at track %d, row %02X", track, songRow)
+				logging.Playback.Warnf("Cannot jump: no chain at track %d, row %02X", track, songRow)
 				return nil
 			}
 
 			// Verify target chain has phrases
-			chainsData := m.GetChainsDataForTrack(track)
 			hasValidPhrase := false
-			for chainRow := 0; chainRow < 16; chainRow++ {
-				if (*chainsData)[chainID][chainRow] != -1 {
+			for chainRow := 0; chainRow < types.ChainRows; chainRow++ {
+				if m.GetChainCell(track, chainID, chainRow) != -1 {
 					hasValidPhrase = true
 					break
 				}
 			}
 
 			if !hasValidPhrase {
-				log.Printf("Cannot jump: chain %02X has no phrases for track %d", chainID, track)
+				logging.Playback.Warnf("Cannot jump: chain %02X has no phrases for track %d", chainID, track)
 				return nil
 			}
 
 			// Queue stop at current cell boundary and jump to target row
 			m.SongPlaybackQueued[track] = -1
 			m.SongPlaybackQueuedRow[track] = songRow // Store jump target
-			log.Printf("JUMP: Queued track %d to jump from row %02X to row %02X at cell boundary", track, m.SongPlaybackRow[track], songRow)
+			logging.Playback.Debugf("JUMP: Queued track %d to jump from row %02X to row %02X at cell boundary", track, m.SongPlaybackRow[track], songRow)
 		} else if hasOtherTracksPlaying {
 			// On currently playing cell with other tracks playing - queue stop at end of current cell
 			m.SongPlaybackQueued[track] = -1
 			m.SongPlaybackQueuedRow[track] = -1 // Clear jump target (normal stop)
-			log.Printf("Queued track %d to stop at cell boundary", track)
+			logging.Playback.Debugf("Queued track %d to stop at cell boundary", track)
 		} else {
 			// On currently playing cell with no other tracks playing - stop immediately
 			m.SongPlaybackActive[track] = false
@@ -322,30 +321,29 @@ func ToggleSingleTrackPlayback(m *model.Model) tea.Cmd {
 				m.CurrentlyPlayingFile = ""
 			}
 			m.SendStopOSC()
-			log.Printf("Stopped track %d immediately (no other tracks playing)", track)
+			logging.Playback.Debugf("Stopped track %d immediately (no other tracks playing)", track)
 		}
 	} else {
 		// Current track is not playing
-		chainID := m.SongData[track][songRow]
+		chainID := m.GetSongCell(track, songRow)
 		if chainID == -1 {
-			log.Printf("No chain at track %d, row %d", track, songRow)
+			logging.Playback.Debugf("No chain at track %d, row %d", track, songRow)
 			return nil
 		}
 
 		// Check if chain has valid phrase data
-		chainsData := m.GetChainsDataForTrack(track)
 		firstPhraseID := -1
 		firstChainRow := -1
-		for chainRow := 0; chainRow < 16; chainRow++ {
-			if (*chainsData)[chainID][chainRow] != -1 {
-				firstPhraseID = (*chainsData)[chainID][chainRow]
+		for chainRow := 0; chainRow < types.ChainRows; chainRow++ {
+			if m.GetChainCell(track, chainID, chainRow) != -1 {
+				firstPhraseID = m.GetChainCell(track, chainID, chainRow)
 				firstChainRow = chainRow
 				break
 			}
 		}
 
 		if firstPhraseID == -1 {
-			log.Printf("Chain %d has no phrases for track %d", chainID, track)
+			logging.Playback.Debugf("Chain %d has no phrases for track %d", chainID, track)
 			return nil
 		}
 
@@ -353,7 +351,7 @@ func ToggleSingleTrackPlayback(m *model.Model) tea.Cmd {
 			// Other tracks are playing - queue start at next cell boundary
 			m.SongPlaybackQueued[track] = 1
 			m.SongPlaybackQueuedRow[track] = songRow // Store the row to start from
-			log.Printf("QUEUE_DEBUG: Queued track %d to start at next cell boundary from row %02X (other tracks playing)", track, songRow)
+			logging.Playback.Debugf("QUEUE_DEBUG: Queued track %d to start at next cell boundary from row %02X (other tracks playing)", track, songRow)
 		} else {
 			// No other tracks playing - start immediately
 			// Initialize playback if not already running
@@ -366,8 +364,8 @@ func ToggleSingleTrackPlayback(m *model.Model) tea.Cmd {
 				m.PlaybackChainRow = -1
 
 				// Initialize increment counters for this track
-				for phrase := 0; phrase < 255; phrase++ {
-					for row := 0; row < 255; row++ {
+				for phrase := 0; phrase < types.NumPhrases; phrase++ {
+					for row := 0; row < types.PhraseRows; row++ {
 						m.IncrementCounters[track][phrase][row] = -1
 					}
 				}
@@ -386,7 +384,7 @@ func ToggleSingleTrackPlayback(m *model.Model) tea.Cmd {
 
 			// Emit initial row for this track
 			EmitRowDataFor(m, firstPhraseID, m.SongPlaybackRowInPhrase[track], track)
-			log.Printf("Started track %d immediately at row %02X, chain %02X, phrase %02X with %d ticks",
+			logging.Playback.Debugf("Started track %d immediately at row %02X, chain %02X, phrase %02X with %d ticks",
 				track, songRow, chainID, firstPhraseID, m.SongPlaybackTicksLeft[track])
 
 			return Tick(m)
@@ -396,22 +394,42 @@ func ToggleSingleTrackPlayback(m *model.Model) tea.Cmd {
 	return nil
 }
 
-func Tick(m *model.Model) tea.Cmd {
-	us := rowDurationMicroseconds(m)
-
+// NextTickAt returns when the next tick of the playback clock is due
+func NextTickAt(m *model.Model) time.Time {
 	// If PlaybackStartTime is not set (zero time), initialize it now
 	// This can happen in tests or edge cases where playback was started differently
 	if m.PlaybackStartTime.IsZero() {
-		m.PlaybackStartTime = time.Now()
+		m.PlaybackStartTime = m.Now()
 		m.PlaybackTickCount = 0
-		log.Printf("TIMING: Initialized PlaybackStartTime to %v", m.PlaybackStartTime)
+		m.PlaybackTempo = model.TickTempo{}
+		logging.Playback.Debugf("TIMING: Initialized PlaybackStartTime to %v", m.PlaybackStartTime)
 	}
 
 	// Calculate the absolute time when the next tick should occur based on CURRENT tick count
-	// This prevents drift accumulation by always scheduling relative to start time
+	// This prevents drift accumulation by always scheduling relative to start time (or to the
+	// tick the tempo or groove last changed on)
 	// We calculate for the current tick BEFORE incrementing to avoid off-by-one error
-	nextTickTime := m.PlaybackStartTime.Add(time.Duration(float64(m.PlaybackTickCount) * us * nanosecondsPerMicrosecond))
-	now := time.Now()
+	return m.TickTime(m.PlaybackTickCount)
+}
+
+// StepPlayback runs one tick of the playback clock: queued session punch-ins, the MIDI sync
+// metronome, the beat flash and the rows due. It returns the command that finishes a loop bounce, if one ends.
+func StepPlayback(m *model.Model) tea.Cmd {
+	// Note: We start with count=1 after emitting the initial row (which represents tick 0)
+	ProcessSessionPunchIn(m)
+	m.MidiSyncBeat(m.PlaybackTickCount)
+	m.FlashBeat(m.PlaybackTickCount)
+	AdvancePlayback(m)
+	m.MarkSessionSection()
+	// Increment tick count AFTER processing the current tick
+	m.PlaybackTickCount++
+	return ProcessLoopBounce(m)
+}
+
+func Tick(m *model.Model) tea.Cmd {
+	us := rowDurationMicroseconds(m)
+	nextTickTime := NextTickAt(m)
+	now := m.Now()
 
 	// Calculate how long to wait until the next tick
 	waitDuration := nextTickTime.Sub(now)
@@ -421,7 +439,7 @@ func Tick(m *model.Model) tea.Cmd {
 	if waitDuration < 0 {
 		drift := -waitDuration
 		if drift > 100*time.Millisecond {
-			log.Printf("TIMING_WARNING: Running behind schedule by %v (tick %d)", drift, m.PlaybackTickCount)
+			logging.Playback.Warnf("TIMING_WARNING: Running behind schedule by %v (tick %d)", drift, m.PlaybackTickCount)
 		}
 		waitDuration = 0
 	}
@@ -433,7 +451,7 @@ func Tick(m *model.Model) tea.Cmd {
 		// E.g., when count=60, we've processed ticks 0-59, expected time is 59*interval
 		expectedElapsed := time.Duration(float64(m.PlaybackTickCount-1) * us * nanosecondsPerMicrosecond)
 		drift := elapsed - expectedElapsed
-		log.Printf("TIMING: Tick %d - Elapsed: %v, Expected: %v, Drift: %v",
+		logging.Playback.Debugf("TIMING: Tick %d - Elapsed: %v, Expected: %v, Drift: %v",
 			m.PlaybackTickCount, elapsed, expectedElapsed, drift)
 	}
 
@@ -448,26 +466,24 @@ func Tick(m *model.Model) tea.Cmd {
 func AdvancePlayback(m *model.Model) {
 	oldRow := m.PlaybackRow
 
-	// Increment tick counter for blinking indicators
-	m.TickCount++
-
 	if m.PlaybackMode == types.SongView {
 		// Song playback mode with per-track tick counting
-		log.Printf("Song playback advancing - checking %d tracks", 8)
+		logging.Playback.Debugf("Song playback advancing - checking %d tracks", types.NumTracks)
 		activeTrackCount := 0
-		anyTrackAtCellBoundary := false // Track if any track reached a cell boundary this tick
+		anyTrackAtCellBoundary := false  // Track if any track reached a cell boundary this tick
+		var jumped [types.NumTracks]bool // Tracks whose queued jump executed this tick
 
-		for track := 0; track < 8; track++ {
+		for track := 0; track < types.NumTracks; track++ {
 			if !m.SongPlaybackActive[track] {
 				continue
 			}
 			activeTrackCount++
-			log.Printf("DEBUG_SONG: Processing active track %d, ticksLeft=%d", track, m.SongPlaybackTicksLeft[track])
+			logging.Playback.Debugf("DEBUG_SONG: Processing active track %d, ticksLeft=%d", track, m.SongPlaybackTicksLeft[track])
 
 			// Decrement ticks for this track if > 0
 			if m.SongPlaybackTicksLeft[track] > 0 {
 				m.SongPlaybackTicksLeft[track]--
-				log.Printf("Song track %d: %d ticks remaining", track, m.SongPlaybackTicksLeft[track])
+				logging.Playback.Debugf("Song track %d: %d ticks remaining", track, m.SongPlaybackTicksLeft[track])
 			}
 
 			// Only advance when ticks reach 0
@@ -476,7 +492,7 @@ func AdvancePlayback(m *model.Model) {
 			}
 
 			// Mark that at least one track reached a cell boundary
-			log.Printf("CELL_BOUNDARY: Song track %d: ticks exhausted, advancing (checking if song row changes)", track)
+			logging.Playback.Debugf("CELL_BOUNDARY: Song track %d: ticks exhausted, advancing (checking if song row changes)", track)
 
 			// Remember the song row before advancing
 			oldSongRow := m.SongPlaybackRow[track]
@@ -487,7 +503,7 @@ func AdvancePlayback(m *model.Model) {
 				// Track finished, deactivate
 				m.SongPlaybackActive[track] = false
 				m.SongPlaybackQueued[track] = 0 // Clear any queued action
-				log.Printf("Song track %d deactivated (end of sequence)", track)
+				logging.Playback.Debugf("Song track %d deactivated (end of sequence)", track)
 				continue
 			}
 
@@ -499,32 +515,33 @@ func AdvancePlayback(m *model.Model) {
 				// Track advanced to a new song row OR chain looped back - this is a song-level cell boundary
 				anyTrackAtCellBoundary = true
 				if newSongRow != oldSongRow {
-					log.Printf("SONG_CELL_BOUNDARY: Song track %d advanced from song row %02X to %02X (anyTrackAtCellBoundary=true)", track, oldSongRow, newSongRow)
+					logging.Playback.Debugf("SONG_CELL_BOUNDARY: Song track %d advanced from song row %02X to %02X (anyTrackAtCellBoundary=true)", track, oldSongRow, newSongRow)
 				} else {
-					log.Printf("SONG_CELL_BOUNDARY: Song track %d chain looped back to beginning at song row %02X (anyTrackAtCellBoundary=true)", track, oldSongRow)
+					logging.Playback.Debugf("SONG_CELL_BOUNDARY: Song track %d chain looped back to beginning at song row %02X (anyTrackAtCellBoundary=true)", track, oldSongRow)
 				}
 
 				// Check for queued stop action at SONG cell boundary (after finishing current chain)
 				if m.SongPlaybackQueued[track] == -1 {
 					jumpTargetRow := m.SongPlaybackQueuedRow[track]
 					// Check if this is a jump (target row is set and different from current)
-					if jumpTargetRow >= 0 && jumpTargetRow < 16 && jumpTargetRow != newSongRow {
+					if jumpTargetRow >= 0 && jumpTargetRow < types.SongRows && jumpTargetRow != newSongRow {
 						// This is a jump - queue start at target row instead of stopping
 						m.SongPlaybackActive[track] = false
 						m.SongPlaybackQueued[track] = 1 // Queue start
+						jumped[track] = true
 						// jumpTargetRow is already set in SongPlaybackQueuedRow
-						log.Printf("JUMP_EXEC: Song track %d stopped at row %02X, queued to jump to row %02X at next cell boundary", track, newSongRow, jumpTargetRow)
+						logging.Playback.Debugf("JUMP_EXEC: Song track %d stopped at row %02X, queued to jump to row %02X at next cell boundary", track, newSongRow, jumpTargetRow)
 					} else {
 						// Regular queued stop - deactivate track after finishing the chain
 						m.SongPlaybackActive[track] = false
 						m.SongPlaybackQueued[track] = 0
 						m.SongPlaybackQueuedRow[track] = -1
-						log.Printf("Song track %d stopped (queued stop executed after chain finished)", track)
+						logging.Playback.Debugf("Song track %d stopped (queued stop executed after chain finished)", track)
 					}
 					continue
 				}
 			} else {
-				log.Printf("Song track %d advanced within chain (song row %02X unchanged)", track, oldSongRow)
+				logging.Playback.Debugf("Song track %d advanced within chain (song row %02X unchanged)", track, oldSongRow)
 			}
 
 			// Load new ticks for the advanced row
@@ -533,42 +550,41 @@ func AdvancePlayback(m *model.Model) {
 			// Emit the newly advanced row immediately (at start of its DT period)
 			phraseNum := m.SongPlaybackPhrase[track]
 			currentRow := m.SongPlaybackRowInPhrase[track]
-			if phraseNum >= 0 && phraseNum < 255 && currentRow >= 0 && currentRow < 255 {
+			if phraseNum >= 0 && phraseNum < types.NumPhrases && currentRow >= 0 && currentRow < types.PhraseRows {
 				EmitRowDataFor(m, phraseNum, currentRow, track)
-				log.Printf("Song track %d emitted phrase %02X row %d with %d ticks", track, phraseNum, currentRow, m.SongPlaybackTicksLeft[track])
+				logging.Playback.Debugf("Song track %d emitted phrase %02X row %d with %d ticks", track, phraseNum, currentRow, m.SongPlaybackTicksLeft[track])
 			}
 		}
-		log.Printf("Song playback: processed %d active tracks", activeTrackCount)
+		logging.Playback.Debugf("Song playback: processed %d active tracks", activeTrackCount)
 
 		// Process queued start actions ONLY at cell boundaries (when at least one track advanced)
-		log.Printf("QUEUE_CHECK: anyTrackAtCellBoundary=%v, checking queued starts", anyTrackAtCellBoundary)
+		logging.Playback.Debugf("QUEUE_CHECK: anyTrackAtCellBoundary=%v, checking queued starts", anyTrackAtCellBoundary)
 		if anyTrackAtCellBoundary {
-			for track := 0; track < 8; track++ {
+			for track := 0; track < types.NumTracks; track++ {
 				if m.SongPlaybackQueued[track] == 1 && !m.SongPlaybackActive[track] {
 					// Queued to start - activate track
 					songRow := m.SongPlaybackQueuedRow[track]
 					// Validate song row bounds (0-15). This should not occur in normal operation
 					// as the row is set from CurrentRow when queuing, but we check defensively.
-					if songRow < 0 || songRow >= 16 {
-						log.Printf("ERROR: Invalid queued song row %d for track %d (valid range: 0-15) - clearing queue", songRow, track)
+					if songRow < 0 || songRow >= types.SongRows {
+						logging.Playback.Errorf("ERROR: Invalid queued song row %d for track %d (valid range: 0-15) - clearing queue", songRow, track)
 						m.SongPlaybackQueued[track] = 0
 						continue
 					}
 
-					chainID := m.SongData[track][songRow]
+					chainID := m.GetSongCell(track, songRow)
 					if chainID == -1 {
 						m.SongPlaybackQueued[track] = 0
-						log.Printf("Cannot start track %d: no chain at row %02X (empty cell)", track, songRow)
+						logging.Playback.Warnf("Cannot start track %d: no chain at row %02X (empty cell)", track, songRow)
 						continue
 					}
 
 					// Find first phrase in chain
-					chainsData := m.GetChainsDataForTrack(track)
 					firstPhraseID := -1
 					firstChainRow := -1
-					for chainRow := 0; chainRow < 16; chainRow++ {
-						if (*chainsData)[chainID][chainRow] != -1 {
-							firstPhraseID = (*chainsData)[chainID][chainRow]
+					for chainRow := 0; chainRow < types.ChainRows; chainRow++ {
+						if m.GetChainCell(track, chainID, chainRow) != -1 {
+							firstPhraseID = m.GetChainCell(track, chainID, chainRow)
 							firstChainRow = chainRow
 							break
 						}
@@ -576,7 +592,7 @@ func AdvancePlayback(m *model.Model) {
 
 					if firstPhraseID == -1 {
 						m.SongPlaybackQueued[track] = 0
-						log.Printf("Cannot start track %d: chain %d has no phrases", track, chainID)
+						logging.Playback.Warnf("Cannot start track %d: chain %d has no phrases", track, chainID)
 						continue
 					}
 
@@ -592,9 +608,12 @@ func AdvancePlayback(m *model.Model) {
 					// Initialize ticks for this track
 					m.LoadTicksLeftForTrack(track)
 
-					// Emit initial row for this track
+					// Emit initial row for this track, crossfading from the chain it jumped from
+					if jumped[track] {
+						m.SendOSCCrossfadeMessage(track)
+					}
 					EmitRowDataFor(m, firstPhraseID, m.SongPlaybackRowInPhrase[track], track)
-					log.Printf("QUEUE_EXEC: Song track %d started (queued start executed) at row %02X, chain %02X, phrase %02X with %d ticks",
+					logging.Playback.Debugf("QUEUE_EXEC: Song track %d started (queued start executed) at row %02X, chain %02X, phrase %02X with %d ticks",
 						track, songRow, chainID, firstPhraseID, m.SongPlaybackTicksLeft[track])
 				}
 			}
@@ -602,7 +621,7 @@ func AdvancePlayback(m *model.Model) {
 
 		// Check if all tracks are now inactive - stop playback entirely
 		allTracksInactive := true
-		for track := 0; track < 8; track++ {
+		for track := 0; track < types.NumTracks; track++ {
 			if m.SongPlaybackActive[track] {
 				allTracksInactive = false
 				break
@@ -618,16 +637,16 @@ func AdvancePlayback(m *model.Model) {
 				m.CurrentlyPlayingFile = ""
 			}
 			m.SendStopOSC()
-			log.Printf("All tracks inactive - stopped playback")
+			logging.Playback.Debugf("All tracks inactive - stopped playback")
 		}
 	} else if m.PlaybackMode == types.ChainView {
 		// Chain playback mode - advance through phrases in sequence with tick-based timing
-		log.Printf("DEBUG_CHAIN: Chain playback advancing - ticksLeft=%d", m.PlaybackTicksLeft)
+		logging.Playback.Debugf("DEBUG_CHAIN: Chain playback advancing - ticksLeft=%d", m.PlaybackTicksLeft)
 
 		// Decrement ticks if > 0
 		if m.PlaybackTicksLeft > 0 {
 			m.PlaybackTicksLeft--
-			log.Printf("Chain playback: %d ticks remaining", m.PlaybackTicksLeft)
+			logging.Playback.Debugf("Chain playback: %d ticks remaining", m.PlaybackTicksLeft)
 		}
 
 		// Only advance when ticks reach 0
@@ -635,42 +654,40 @@ func AdvancePlayback(m *model.Model) {
 			return
 		}
 
-		log.Printf("Chain playback: ticks exhausted, advancing to next row")
+		logging.Playback.Debugf("Chain playback: ticks exhausted, advancing to next row")
 
 		// Find next row with playback enabled (unified DT-based playback)
-		phrasesData := GetPhrasesDataForTrack(m, m.CurrentTrack)
 
 		// Validate PlaybackPhrase is within bounds before accessing array
-		if m.PlaybackPhrase >= 0 && m.PlaybackPhrase < 255 {
-			for i := m.PlaybackRow + 1; i < 255; i++ {
+		if m.PlaybackPhrase >= 0 && m.PlaybackPhrase < types.NumPhrases {
+			for i := m.PlaybackRow + 1; i < types.PhraseRows; i++ {
 				// Unified DT-based playback: DT > 0 means playable for both instruments and samplers
-				dtValue := (*phrasesData)[m.PlaybackPhrase][i][types.ColDeltaTime]
+				dtValue := m.GetPhraseCell(m.CurrentTrack, m.PlaybackPhrase, i, types.ColDeltaTime)
 				if IsRowPlayable(dtValue) {
 					m.PlaybackRow = i
 					// Load ticks for the new row
-					m.PlaybackTicksLeft = dtValue
+					m.PlaybackTicksLeft = m.TrackTicks(m.CurrentTrack, dtValue)
 					DebugLogRowEmission(m)
-					log.Printf("Chain playback advanced from row %d to %d with %d ticks", oldRow, m.PlaybackRow, m.PlaybackTicksLeft)
+					logging.Playback.Debugf("Chain playback advanced from row %d to %d with %d ticks", oldRow, m.PlaybackRow, m.PlaybackTicksLeft)
 					return
 				}
 			}
 		}
 
 		// End of phrase reached, move to next phrase slot in the same chain
-		chainsData := GetChainsDataForTrack(m, m.CurrentTrack)
-		for i := m.PlaybackChainRow + 1; i < 16; i++ {
-			phraseID := (*chainsData)[m.PlaybackChain][i]
-			if phraseID != -1 && phraseID >= 0 && phraseID < 255 {
+		for i := m.PlaybackChainRow + 1; i < types.ChainRows; i++ {
+			phraseID := m.GetChainCell(m.CurrentTrack, m.PlaybackChain, i)
+			if phraseID != -1 && phraseID >= 0 && phraseID < types.NumPhrases {
 				m.PlaybackChainRow = i
 				m.PlaybackPhrase = phraseID
 				m.PlaybackRow = FindFirstNonEmptyRowInPhrase(m, m.PlaybackPhrase)
 
 				// Load ticks for the new row
-				if m.PlaybackPhrase >= 0 && m.PlaybackPhrase < 255 && m.PlaybackRow >= 0 && m.PlaybackRow < 255 {
-					dtValue := (*phrasesData)[m.PlaybackPhrase][m.PlaybackRow][types.ColDeltaTime]
-					m.PlaybackTicksLeft = dtValue
+				if m.PlaybackPhrase >= 0 && m.PlaybackPhrase < types.NumPhrases && m.PlaybackRow >= 0 && m.PlaybackRow < types.PhraseRows {
+					dtValue := m.GetPhraseCell(m.CurrentTrack, m.PlaybackPhrase, m.PlaybackRow, types.ColDeltaTime)
+					m.PlaybackTicksLeft = m.TrackTicks(m.CurrentTrack, dtValue)
 					DebugLogRowEmission(m)
-					log.Printf("Chain playback moved to chain row %d, phrase %d, row %d with %d ticks", m.PlaybackChainRow, m.PlaybackPhrase, m.PlaybackRow, m.PlaybackTicksLeft)
+					logging.Playback.Debugf("Chain playback moved to chain row %d, phrase %d, row %d with %d ticks", m.PlaybackChainRow, m.PlaybackPhrase, m.PlaybackRow, m.PlaybackTicksLeft)
 				}
 				// Reset inheritance values when changing phrases would be handled in main
 
@@ -679,19 +696,19 @@ func AdvancePlayback(m *model.Model) {
 		}
 
 		// End of chain reached, loop back to first phrase slot in the same chain
-		for i := 0; i < 16; i++ {
-			phraseID := (*chainsData)[m.PlaybackChain][i]
-			if phraseID != -1 && phraseID >= 0 && phraseID < 255 {
+		for i := 0; i < types.ChainRows; i++ {
+			phraseID := m.GetChainCell(m.CurrentTrack, m.PlaybackChain, i)
+			if phraseID != -1 && phraseID >= 0 && phraseID < types.NumPhrases {
 				m.PlaybackChainRow = i
 				m.PlaybackPhrase = phraseID
 				m.PlaybackRow = FindFirstNonEmptyRowInPhrase(m, m.PlaybackPhrase)
 
 				// Load ticks for the new row
-				if m.PlaybackPhrase >= 0 && m.PlaybackPhrase < 255 && m.PlaybackRow >= 0 && m.PlaybackRow < 255 {
-					dtValue := (*phrasesData)[m.PlaybackPhrase][m.PlaybackRow][types.ColDeltaTime]
-					m.PlaybackTicksLeft = dtValue
+				if m.PlaybackPhrase >= 0 && m.PlaybackPhrase < types.NumPhrases && m.PlaybackRow >= 0 && m.PlaybackRow < types.PhraseRows {
+					dtValue := m.GetPhraseCell(m.CurrentTrack, m.PlaybackPhrase, m.PlaybackRow, types.ColDeltaTime)
+					m.PlaybackTicksLeft = m.TrackTicks(m.CurrentTrack, dtValue)
 					DebugLogRowEmission(m)
-					log.Printf("Chain playback looped back to chain row %d, phrase %d, row %d with %d ticks", m.PlaybackChainRow, m.PlaybackPhrase, m.PlaybackRow, m.PlaybackTicksLeft)
+					logging.Playback.Debugf("Chain playback looped back to chain row %d, phrase %d, row %d with %d ticks", m.PlaybackChainRow, m.PlaybackPhrase, m.PlaybackRow, m.PlaybackTicksLeft)
 				}
 				// Reset inheritance values when changing phrases would be handled in main
 
@@ -700,16 +717,16 @@ func AdvancePlayback(m *model.Model) {
 		}
 
 		// No valid phrases found in this chain - stop playback
-		log.Printf("Chain playback stopped - no valid phrases found in chain %d", m.PlaybackChain)
+		logging.Playback.Debugf("Chain playback stopped - no valid phrases found in chain %d", m.PlaybackChain)
 		return
 	} else {
 		// Phrase-only playback mode with tick-based timing
-		log.Printf("DEBUG_PHRASE: Phrase playback advancing - ticksLeft=%d", m.PlaybackTicksLeft)
+		logging.Playback.Debugf("DEBUG_PHRASE: Phrase playback advancing - ticksLeft=%d", m.PlaybackTicksLeft)
 
 		// Decrement ticks if > 0
 		if m.PlaybackTicksLeft > 0 {
 			m.PlaybackTicksLeft--
-			log.Printf("Phrase playback: %d ticks remaining", m.PlaybackTicksLeft)
+			logging.Playback.Debugf("Phrase playback: %d ticks remaining", m.PlaybackTicksLeft)
 		}
 
 		// Only advance when ticks reach 0
@@ -717,19 +734,18 @@ func AdvancePlayback(m *model.Model) {
 			return
 		}
 
-		log.Printf("Phrase playback: ticks exhausted, advancing to next row")
+		logging.Playback.Debugf("Phrase playback: ticks exhausted, advancing to next row")
 
 		// Find next row with playback enabled (unified DT-based playback)
-		phrasesData := GetPhrasesDataForTrack(m, m.CurrentTrack)
-		for i := m.PlaybackRow + 1; i < 255; i++ {
+		for i := m.PlaybackRow + 1; i < types.PhraseRows; i++ {
 			// Unified DT-based playback: DT > 0 means playable for both instruments and samplers
-			dtValue := (*phrasesData)[m.PlaybackPhrase][i][types.ColDeltaTime]
+			dtValue := m.GetPhraseCell(m.CurrentTrack, m.PlaybackPhrase, i, types.ColDeltaTime)
 			if IsRowPlayable(dtValue) {
 				m.PlaybackRow = i
 				// Load ticks for the new row
-				m.PlaybackTicksLeft = dtValue
+				m.PlaybackTicksLeft = m.TrackTicks(m.CurrentTrack, dtValue)
 				DebugLogRowEmission(m)
-				log.Printf("Phrase playback advanced from row %d to %d with %d ticks", oldRow, m.PlaybackRow, m.PlaybackTicksLeft)
+				logging.Playback.Debugf("Phrase playback advanced from row %d to %d with %d ticks", oldRow, m.PlaybackRow, m.PlaybackTicksLeft)
 				return
 			}
 		}
@@ -737,11 +753,11 @@ func AdvancePlayback(m *model.Model) {
 		// Loop back to beginning of phrase
 		m.PlaybackRow = FindFirstNonEmptyRowInPhrase(m, m.PlaybackPhrase)
 		// Load ticks for the looped row
-		if m.PlaybackPhrase >= 0 && m.PlaybackPhrase < 255 && m.PlaybackRow >= 0 && m.PlaybackRow < 255 {
-			dtValue := (*phrasesData)[m.PlaybackPhrase][m.PlaybackRow][types.ColDeltaTime]
-			m.PlaybackTicksLeft = dtValue
+		if m.PlaybackPhrase >= 0 && m.PlaybackPhrase < types.NumPhrases && m.PlaybackRow >= 0 && m.PlaybackRow < types.PhraseRows {
+			dtValue := m.GetPhraseCell(m.CurrentTrack, m.PlaybackPhrase, m.PlaybackRow, types.ColDeltaTime)
+			m.PlaybackTicksLeft = m.TrackTicks(m.CurrentTrack, dtValue)
 			DebugLogRowEmission(m)
-			log.Printf("Phrase playback looped from row %d back to %d with %d ticks", oldRow, m.PlaybackRow, m.PlaybackTicksLeft)
+			logging.Playback.Debugf("Phrase playback looped from row %d back to %d with %d ticks", oldRow, m.PlaybackRow, m.PlaybackTicksLeft)
 		}
 	}
 }
@@ -751,19 +767,18 @@ func AdvancePlayback(m *model.Model) {
 // - success: true if track advanced to a valid row, false if track should stop
 // - chainLooped: true if chain completed and looped back to beginning (even on same song row)
 func advanceToNextPlayableRowForTrack(m *model.Model, track int) (bool, bool) {
-	if track < 0 || track >= 8 {
+	if track < 0 || track >= types.NumTracks {
 		return false, false
 	}
 
 	// Try to advance within current phrase first
 	phraseNum := m.SongPlaybackPhrase[track]
-	if phraseNum >= 0 && phraseNum < 255 {
-		phrasesData := GetPhrasesDataForTrack(m, track)
-		for i := m.SongPlaybackRowInPhrase[track] + 1; i < 255; i++ {
-			dtValue := (*phrasesData)[phraseNum][i][types.ColDeltaTime]
+	if phraseNum >= 0 && phraseNum < types.NumPhrases {
+		for i := m.SongPlaybackRowInPhrase[track] + 1; i < types.PhraseRows; i++ {
+			dtValue := m.GetPhraseCell(track, phraseNum, i, types.ColDeltaTime)
 			if dtValue >= 1 {
 				m.SongPlaybackRowInPhrase[track] = i
-				log.Printf("Song track %d advanced within phrase to row %d", track, i)
+				logging.Playback.Debugf("Song track %d advanced within phrase to row %d", track, i)
 				return true, false
 			}
 		}
@@ -771,15 +786,14 @@ func advanceToNextPlayableRowForTrack(m *model.Model, track int) (bool, bool) {
 
 	// End of phrase reached, try to advance within current chain
 	currentChain := m.SongPlaybackChain[track]
-	chainsData := m.GetChainsDataForTrack(track)
-	for chainRow := m.SongPlaybackChainRow[track] + 1; chainRow < 16; chainRow++ {
-		phraseID := (*chainsData)[currentChain][chainRow]
+	for chainRow := m.SongPlaybackChainRow[track] + 1; chainRow < types.ChainRows; chainRow++ {
+		phraseID := m.GetChainCell(track, currentChain, chainRow)
 		if phraseID != -1 {
 			// Found next phrase in chain, find its first playable row
 			m.SongPlaybackChainRow[track] = chainRow
 			m.SongPlaybackPhrase[track] = phraseID
 			if findFirstPlayableRowInPhraseForTrack(m, phraseID, track) {
-				log.Printf("Song track %d advanced to chain row %d, phrase %02X", track, chainRow, phraseID)
+				logging.Playback.Debugf("Song track %d advanced to chain row %d, phrase %02X", track, chainRow, phraseID)
 				return true, false
 			}
 		}
@@ -788,14 +802,20 @@ func advanceToNextPlayableRowForTrack(m *model.Model, track int) (bool, bool) {
 	// End of chain reached, find next valid song row
 	// This means the chain has completed - we'll mark this as a loop-back
 	startSearchRow := m.SongPlaybackRow[track] + 1
-	for searchOffset := 0; searchOffset < 16; searchOffset++ {
-		searchRow := (startSearchRow + searchOffset) % 16
-		chainID := m.SongData[track][searchRow]
+	if m.GenerativeSong {
+		// Start the search at a weighted random choice, which is playable
+		if row := m.PickSongRow(track, func(row int) bool { return songRowPlayable(m, track, row) }); row >= 0 {
+			startSearchRow = row
+		}
+	}
+	for searchOffset := 0; searchOffset < types.SongRows; searchOffset++ {
+		searchRow := (startSearchRow + searchOffset) % types.SongRows
+		chainID := m.GetSongCell(track, searchRow)
 
 		if chainID != -1 {
 			// Check if this chain has any phrases with playable rows
-			for chainRow := 0; chainRow < 16; chainRow++ {
-				phraseID := (*chainsData)[chainID][chainRow]
+			for chainRow := 0; chainRow < types.ChainRows; chainRow++ {
+				phraseID := m.GetChainCell(track, chainID, chainRow)
 				if phraseID != -1 {
 					// Found a phrase, check if it has playable rows
 					if findFirstPlayableRowInPhraseForTrack(m, phraseID, track) {
@@ -804,7 +824,8 @@ func advanceToNextPlayableRowForTrack(m *model.Model, track int) (bool, bool) {
 						m.SongPlaybackChain[track] = chainID
 						m.SongPlaybackChainRow[track] = chainRow
 						m.SongPlaybackPhrase[track] = phraseID
-						log.Printf("Song track %d advanced to song row %02X, chain %02X", track, searchRow, chainID)
+						logging.Playback.Debugf("Song track %d advanced to song row %02X, chain %02X", track, searchRow, chainID)
+						saveTransport(m)
 						// Return chainLooped=true since we completed the previous chain
 						return true, true
 					}
@@ -817,16 +838,35 @@ func advanceToNextPlayableRowForTrack(m *model.Model, track int) (bool, bool) {
 	return false, false
 }
 
+// songRowPlayable reports whether a song row of a track holds a chain with a playable row
+func songRowPlayable(m *model.Model, track, songRow int) bool {
+	chainID := m.GetSongCell(track, songRow)
+	if chainID == -1 {
+		return false
+	}
+	for chainRow := 0; chainRow < types.ChainRows; chainRow++ {
+		phraseID := m.GetChainCell(track, chainID, chainRow)
+		if phraseID == -1 {
+			continue
+		}
+		for row := 0; row < types.PhraseRows; row++ {
+			if m.GetPhraseCell(track, phraseID, row, types.ColDeltaTime) >= 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // findFirstPlayableRowInPhraseForTrack finds the first playable row in a phrase for a track
 // Sets the track's SongPlaybackRowInPhrase and returns true if found
 func findFirstPlayableRowInPhraseForTrack(m *model.Model, phraseNum, track int) bool {
-	if phraseNum < 0 || phraseNum >= 255 || track < 0 || track >= 8 {
+	if phraseNum < 0 || phraseNum >= types.NumPhrases || track < 0 || track >= types.NumTracks {
 		return false
 	}
 
-	phrasesData := GetPhrasesDataForTrack(m, track)
-	for row := 0; row < 255; row++ {
-		dtValue := (*phrasesData)[phraseNum][row][types.ColDeltaTime]
+	for row := 0; row < types.PhraseRows; row++ {
+		dtValue := m.GetPhraseCell(track, phraseNum, row, types.ColDeltaTime)
 		if dtValue >= 1 {
 			m.SongPlaybackRowInPhrase[track] = row
 			return true
@@ -2,6 +2,7 @@ package input
 
 import (
 	"testing"
+	"time"
 
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/types"
@@ -12,7 +13,7 @@ func TestToggleSingleTrackPlayback_JumpCells(t *testing.T) {
 	// Test the "jump" functionality: pressing Space on a playing track but different cell.
 
 	t.Run("Jump from one cell to another when track is playing", func(t *testing.T) {
-		m := model.NewModel(0, "test.json", false)
+		m := model.NewModel(0, t.TempDir(), false)
 		m.ViewMode = types.SongView
 
 		// Set up song data with chains at rows 2 and 5
@@ -55,7 +56,7 @@ func TestToggleSingleTrackPlayback_JumpCells(t *testing.T) {
 	})
 
 	t.Run("Normal stop when pressing Space on currently playing cell", func(t *testing.T) {
-		m := model.NewModel(0, "test.json", false)
+		m := model.NewModel(0, t.TempDir(), false)
 		m.ViewMode = types.SongView
 
 		// Set up song data
@@ -80,7 +81,7 @@ func TestToggleSingleTrackPlayback_JumpCells(t *testing.T) {
 	})
 
 	t.Run("Queue stop when pressing Space on currently playing cell with other tracks", func(t *testing.T) {
-		m := model.NewModel(0, "test.json", false)
+		m := model.NewModel(0, t.TempDir(), false)
 		m.ViewMode = types.SongView
 
 		// Set up song data for two tracks
@@ -112,7 +113,7 @@ func TestToggleSingleTrackPlayback_JumpCells(t *testing.T) {
 	})
 
 	t.Run("Cannot jump to empty cell", func(t *testing.T) {
-		m := model.NewModel(0, "test.json", false)
+		m := model.NewModel(0, t.TempDir(), false)
 		m.ViewMode = types.SongView
 
 		// Set up song data with chain at row 2 only
@@ -143,7 +144,7 @@ func TestCancelQueuedAction(t *testing.T) {
 	// Test cancelling queued actions with ESC key
 
 	t.Run("Cancel queued start action", func(t *testing.T) {
-		m := model.NewModel(0, "test.json", false)
+		m := model.NewModel(0, t.TempDir(), false)
 		m.ViewMode = types.SongView
 
 		// Set up song data with chains at rows 2 and 5
@@ -180,7 +181,7 @@ func TestCancelQueuedAction(t *testing.T) {
 	})
 
 	t.Run("Cancel queued stop action", func(t *testing.T) {
-		m := model.NewModel(0, "test.json", false)
+		m := model.NewModel(0, t.TempDir(), false)
 		m.ViewMode = types.SongView
 
 		// Set up song data
@@ -225,7 +226,7 @@ func TestCancelQueuedAction(t *testing.T) {
 	})
 
 	t.Run("Cancel queued jump action", func(t *testing.T) {
-		m := model.NewModel(0, "test.json", false)
+		m := model.NewModel(0, t.TempDir(), false)
 		m.ViewMode = types.SongView
 
 		// Set up song data with chains at rows 2 and 5
@@ -263,7 +264,7 @@ func TestCancelQueuedAction(t *testing.T) {
 	})
 
 	t.Run("ESC does nothing when no queued action", func(t *testing.T) {
-		m := model.NewModel(0, "test.json", false)
+		m := model.NewModel(0, t.TempDir(), false)
 		m.ViewMode = types.SongView
 
 		// Set up song data
@@ -287,7 +288,7 @@ func TestCancelQueuedAction(t *testing.T) {
 	})
 
 	t.Run("ESC only affects current track", func(t *testing.T) {
-		m := model.NewModel(0, "test.json", false)
+		m := model.NewModel(0, t.TempDir(), false)
 		m.ViewMode = types.SongView
 
 		// Set up song data for two tracks
@@ -333,7 +334,7 @@ func TestCancelQueuedAction(t *testing.T) {
 	})
 
 	t.Run("ESC does nothing in non-Song view", func(t *testing.T) {
-		m := model.NewModel(0, "test.json", false)
+		m := model.NewModel(0, t.TempDir(), false)
 		m.ViewMode = types.PhraseView
 
 		// Artificially set a queued action (shouldn't happen in phrase view, but test defensive code)
@@ -358,7 +359,7 @@ func TestQueuedActionWithSingleRowSongTrack(t *testing.T) {
 	const maxIterations = 50
 
 	t.Run("Queued start action triggered when chain loops back to beginning", func(t *testing.T) {
-		m := model.NewModel(0, "test.json", false)
+		m := model.NewModel(0, t.TempDir(), false)
 		m.ViewMode = types.SongView
 
 		// Set up song data with only ONE row for track 0
@@ -422,7 +423,7 @@ func TestQueuedActionWithSingleRowSongTrack(t *testing.T) {
 	})
 
 	t.Run("Queued stop action triggered when single-row track loops", func(t *testing.T) {
-		m := model.NewModel(0, "test.json", false)
+		m := model.NewModel(0, t.TempDir(), false)
 		m.ViewMode = types.SongView
 
 		// Set up song data with only ONE row for track 0
@@ -484,3 +485,112 @@ func TestQueuedActionWithSingleRowSongTrack(t *testing.T) {
 		t.Fatal("Queued stop action was never processed after track 0 looped multiple times")
 	})
 }
+
+func TestTrackResolutionPlayback(t *testing.T) {
+	m := model.NewModel(0, t.TempDir(), false)
+	m.ViewMode = types.SongView
+	m.SongData[0][0] = 0
+	m.SongData[1][0] = 0
+	m.SamplerChainsData[0][0] = 0
+	for row := 0; row < 8; row++ {
+		m.SamplerPhrasesData[0][row][types.ColDeltaTime] = 1
+	}
+	m.SetTrackResolution(1, 4)
+
+	m.IsPlaying = true
+	m.PlaybackMode = types.SongView
+	for track := 0; track < 2; track++ {
+		m.SongPlaybackActive[track] = true
+		m.SongPlaybackChain[track] = 0
+		m.SongPlaybackPhrase[track] = 0
+		m.LoadTicksLeftForTrack(track)
+	}
+	assert.Equal(t, 4, m.SongPlaybackTicksLeft[0], "a x1 row lasts 4 ticks of a x4 clock")
+	assert.Equal(t, 1, m.SongPlaybackTicksLeft[1])
+
+	for i := 0; i < 4; i++ {
+		AdvancePlayback(m)
+	}
+	assert.Equal(t, 1, m.SongPlaybackRowInPhrase[0])
+	assert.Equal(t, 4, m.SongPlaybackRowInPhrase[1], "the x4 track plays four rows per PPQ tick")
+
+	// The clock runs four times faster, and note lengths follow each track's resolution
+	m.BPM, m.PPQ = 120, 2
+	assert.InDelta(t, 62500.0, rowDurationMicroseconds(m), 0.001)
+	assert.InDelta(t, 0.25, calculateDeltaTimeSeconds(m, 0, 0, 0), 0.0001)
+	assert.InDelta(t, 0.0625, calculateDeltaTimeSeconds(m, 0, 0, 1), 0.0001)
+}
+
+func TestResumePlayback(t *testing.T) {
+	m := model.NewModel(0, t.TempDir(), false)
+	m.ViewMode = types.SongView
+	for track := 0; track < 3; track++ {
+		m.SongData[track][0] = 0
+		m.SongData[track][2] = 1
+	}
+	m.SamplerChainsData[0][0] = 0
+	m.SamplerChainsData[1][0] = 1
+	m.SamplerPhrasesData[0][0][types.ColDeltaTime] = 1
+	m.SamplerPhrasesData[1][0][types.ColDeltaTime] = 1
+
+	// Nothing to resume
+	assert.Nil(t, ResumePlayback(m))
+	assert.False(t, m.IsPlaying)
+
+	// Each track comes back on its own song row, stopped tracks stay stopped
+	rows := [types.NumTracks]int{0, 2, -1, -1, -1, -1, -1, -1}
+	m.PendingResume = &types.TransportState{Mode: types.SongView, SongRows: rows}
+	assert.NotNil(t, ResumePlayback(m))
+	assert.Nil(t, m.PendingResume)
+	assert.True(t, m.IsPlaying)
+	assert.Equal(t, types.SongView, m.PlaybackMode)
+	assert.Equal(t, rows, m.TransportState().SongRows)
+	assert.Equal(t, 1, m.SongPlaybackPhrase[1])
+	stopPlayback(m)
+	assert.Nil(t, m.TransportState())
+
+	// Phrase playback comes back on its track and row
+	m.PendingResume = &types.TransportState{Mode: types.PhraseView, Track: 1, Phrase: 1, Row: 0}
+	assert.NotNil(t, ResumePlayback(m))
+	assert.Equal(t, types.PhraseView, m.PlaybackMode)
+	assert.Equal(t, 1, m.CurrentTrack)
+	assert.Equal(t, 1, m.PlaybackPhrase)
+	stopPlayback(m)
+
+	m.PendingResume = &types.TransportState{Mode: types.PhraseView, Track: 9, Phrase: 1}
+	assert.Nil(t, ResumePlayback(m), "A transport that does not fit the project is ignored")
+}
+
+func TestHandOver(t *testing.T) {
+	newSong := func() *model.Model {
+		m := model.NewModel(0, "", false)
+		m.BPM = 600
+		m.SongData[0][0] = 0
+		m.SamplerChainsData[0][0] = 0
+		for row := 0; row < 4; row++ {
+			m.SamplerPhrasesData[0][row][types.ColDeltaTime] = 1
+		}
+		return m
+	}
+
+	// A closed project plays on by itself until the next one takes over
+	previous := newSong()
+	startPlaybackWithConfig(previous, PlaybackConfig{Mode: types.SongView, Chain: -1, Phrase: -1, Row: 0})
+	stop := PlayDetached(previous)
+	time.Sleep(100 * time.Millisecond)
+	stop()
+	assert.True(t, previous.IsPlaying)
+	assert.Greater(t, previous.PlaybackTickCount, 1, "Rows went on playing without the interface")
+
+	m := newSong()
+	assert.NotNil(t, HandOver(m, previous, func() {}))
+	assert.False(t, previous.IsPlaying, "The previous project stops")
+	assert.True(t, m.IsPlaying, "The new project takes over")
+	assert.Equal(t, types.SongView, m.PlaybackMode)
+
+	// A stopped project leaves the next one stopped
+	stopped := newSong()
+	next := newSong()
+	HandOver(next, stopped, PlayDetached(stopped))
+	assert.False(t, next.IsPlaying)
+}
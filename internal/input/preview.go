@@ -0,0 +1,34 @@
+package input
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// handlePreviewKey adjusts a tempo/key preview: up/down change the tempo (ctrl for 0.1 BPM),
+// left/right the key, enter keeps the changes and esc reverts them. handled is false for keys
+// the preview leaves to the current view.
+func handlePreviewKey(m *model.Model, msg tea.KeyMsg) (cmd tea.Cmd, handled bool) {
+	switch msg.String() {
+	case "up":
+		m.PreviewTempo(1)
+	case "down":
+		m.PreviewTempo(-1)
+	case "ctrl+up", "alt+up":
+		m.PreviewTempo(0.1)
+	case "ctrl+down", "alt+down":
+		m.PreviewTempo(-0.1)
+	case "right":
+		m.PreviewKey(1)
+	case "left":
+		m.PreviewKey(-1)
+	case "enter":
+		m.AcceptPreview()
+	case "esc":
+		m.RevertPreview()
+	default:
+		return nil, false
+	}
+	return nil, true
+}
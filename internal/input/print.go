@@ -0,0 +1,50 @@
+package input
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// ToggleInstrumentPrint plays the instrument phrase being viewed once and records only its
+// track to a WAV that is added to the sampler files, or cancels a print or bounce in progress
+func ToggleInstrumentPrint(m *model.Model) tea.Cmd {
+	if m.Bounce != nil {
+		return ToggleLoopBounce(m)
+	}
+	if m.ViewMode != types.PhraseView || m.GetPhraseViewType() != types.InstrumentPhraseView {
+		return nil
+	}
+	if m.SessionRecording || m.SessionPunchArmed {
+		logging.Storage.Debugf("Print unavailable while session recording")
+		return nil
+	}
+	loopTicks := m.CurrentLoopTicks()
+	if loopTicks <= 0 {
+		logging.Storage.Debugf("Nothing to print: phrase is empty")
+		return nil
+	}
+
+	if m.IsPlaying {
+		stopPlayback(m)
+	}
+
+	label := model.PrintFileLabel(m.PrintSoundMakerName(m.CurrentPhrase, m.CurrentRow))
+	if err := os.MkdirAll(m.RecordingsFolder(), 0755); err != nil {
+		logging.Storage.Errorf("Error creating recordings folder: %v", err)
+	}
+	filename := filepath.Join(m.RecordingsFolder(),
+		fmt.Sprintf("print-%s-phrase%02X-%s.wav", label, m.CurrentPhrase, time.Now().Format("2006-01-02-15-04-05")))
+
+	m.Bounce = &model.LoopBounce{File: filename, TicksLeft: loopTicks, Print: true, Track: m.CurrentTrack}
+	m.SendOSCPrintMessage(m.CurrentTrack, filename, true)
+	logging.Storage.Debugf("Print started: %s (track %d, %d ticks)", filename, m.CurrentTrack+1, loopTicks)
+	return TogglePlaybackFromTop(m)
+}
@@ -0,0 +1,52 @@
+package input
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// PlayDetached keeps a project playing after its interface closed, while the project selector
+// runs and the next project loads. The returned function stops driving playback and waits
+// until no row is being played; the project keeps IsPlaying for HandOver.
+func PlayDetached(m *model.Model) (stop func()) {
+	quit, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		for m.IsPlaying {
+			timer := time.NewTimer(max(0, NextTickAt(m).Sub(m.Now())))
+			select {
+			case <-quit:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			StepPlayback(m) // A finished loop bounce stops playback, which ends the loop
+		}
+	}()
+	return func() {
+		close(quit)
+		<-done
+	}
+}
+
+// HandOver switches playback from the previous project, kept playing with PlayDetached, to
+// this one once its samples are loaded. The previous project's voices release over the fade
+// time as this project starts, at the top of its song unless it resumes playback where it was
+// saved. When the previous project had stopped, this one opens as it would on its own.
+func HandOver(m, previous *model.Model, stop func()) tea.Cmd {
+	stop()
+	playing := previous.IsPlaying
+	StopForExit(previous)
+	m.ReleaseServer(previous)
+	if playing && !m.IsPlaying && m.PendingResume == nil {
+		logging.Playback.Debugf("Handing playback over to the song of the new project")
+		m.Notice = "Playback handed over to this project"
+		return startPlaybackWithConfig(m, PlaybackConfig{Mode: types.SongView, Chain: -1, Phrase: -1, Row: 0})
+	}
+	return ResumePlayback(m)
+}
@@ -0,0 +1,56 @@
+package input
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/schollz/collidertracker/internal/audio"
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// bindQuickSlot binds the file selected in the file browser to a quick-slot, or unbinds it
+// when it is already bound there
+func bindQuickSlot(m *model.Model, slot int) {
+	if m.CurrentRow >= len(m.Files) {
+		return
+	}
+	selected := m.Files[m.CurrentRow]
+	if strings.HasSuffix(selected, "/") || selected == ".." {
+		return
+	}
+	if m.BindQuickSlot(slot, filepath.Join(m.CurrentDir, selected)) {
+		m.Notice = fmt.Sprintf("Quick-slot %d: %s", slot, selected)
+	} else {
+		m.Notice = fmt.Sprintf("Quick-slot %d cleared", slot)
+	}
+}
+
+// assignQuickSlot sets the sample bound to a quick-slot on the phrase row under the cursor
+// when it is in the filename column of a sampler phrase, and reports whether it did. Digits
+// of slots without a sample are left to typing a file number.
+func assignQuickSlot(m *model.Model, slot int) bool {
+	if m.ViewMode != types.PhraseView || m.GetPhraseViewType() != types.SamplerPhraseView {
+		return false
+	}
+	columnMapping := m.GetColumnMapping(m.CurrentCol)
+	if columnMapping == nil || types.PhraseColumn(columnMapping.DataColumnIndex) != types.ColFilename || m.CurrentRow < 0 {
+		return false
+	}
+	file := m.QuickSlots[slot]
+	if file == "" {
+		return false
+	}
+	if _, err := os.Stat(file); err != nil {
+		logging.Storage.Warnf("Quick-slot %d: %v", slot, err)
+		m.Notice = fmt.Sprintf("Quick-slot %d: %s is missing", slot, filepath.Base(file))
+		return true
+	}
+	m.FileSelectRow = m.CurrentRow
+	audio.AssignFile(m, file)
+	m.Notice = fmt.Sprintf("Quick-slot %d: %s", slot, filepath.Base(file))
+	return true
+}
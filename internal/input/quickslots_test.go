@@ -0,0 +1,50 @@
+package input
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+func TestQuickSlots(t *testing.T) {
+	m := createTestModel(t)
+	m.SaveFolder = t.TempDir()
+	dir, err := filepath.Abs("../getbpm")
+	assert.NoError(t, err)
+	digit := func(r rune) { HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}) }
+
+	// A digit in the file browser binds the selected file, the same digit again unbinds it
+	m.ViewMode = types.FileView
+	m.CurrentDir = dir
+	m.Files = []string{"..", "Break120.wav"}
+	m.CurrentRow = 1
+	digit('3')
+	assert.Equal(t, filepath.Join(dir, "Break120.wav"), m.QuickSlots[3])
+	assert.Equal(t, 3, m.QuickSlotOf(filepath.Join(dir, "Break120.wav")))
+	digit('3')
+	assert.Empty(t, m.QuickSlots[3])
+	digit('3')
+	m.CurrentRow = 0
+	digit('4')
+	assert.Empty(t, m.QuickSlots[4], "Folders are not bound")
+
+	// In the filename column of a sampler phrase the digit assigns the sample to the row
+	m.ViewMode = types.PhraseView
+	m.CurrentTrack = 0
+	m.CurrentPhrase = 1
+	m.CurrentRow = 5
+	m.CurrentCol = int(types.SamplerColFI)
+	digit('3')
+	assert.False(t, m.NumberEntry.Active)
+	fileIndex := m.GetPhraseCell(0, 1, 5, types.ColFilename)
+	assert.Equal(t, filepath.Join(dir, "Break120.wav"), (*m.GetCurrentPhrasesFiles())[fileIndex])
+	assert.Equal(t, types.PhraseView, m.ViewMode)
+
+	// Digits of empty slots still type a file number
+	digit('4')
+	assert.True(t, m.NumberEntry.Active)
+}
@@ -0,0 +1,157 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/audio"
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/loudness"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// toggleRecordingsView opens the recordings view with a fresh listing, or closes it
+func toggleRecordingsView(m *model.Model) {
+	if m.ViewMode != types.RecordingsView {
+		m.RefreshRecordings()
+	} else {
+		stopRecordingPreview(m)
+	}
+	toggleAuxView(m, types.RecordingsView)
+}
+
+// handleRecordingsInput handles keys in the recordings view
+func handleRecordingsInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	if m.RenamingRecording {
+		handleRecordingRenameKey(m, msg)
+		return nil
+	}
+
+	switch msg.String() {
+	case "ctrl+q", "alt+q":
+		return requestQuit(m)
+	case "esc", "q", "ctrl+e", "alt+e":
+		toggleRecordingsView(m)
+	case "up", "k":
+		if m.RecordingsRow > 0 {
+			m.RecordingsRow--
+		}
+	case "down", "j":
+		if m.RecordingsRow < len(m.Recordings)-1 {
+			m.RecordingsRow++
+		}
+	case " ":
+		toggleRecordingPreview(m)
+	case "r":
+		if rec, ok := m.SelectedRecording(); ok {
+			m.RenamingRecording = true
+			m.RenameBuffer = strings.TrimSuffix(rec.Name, filepath.Ext(rec.Name))
+		}
+	case "d", "backspace":
+		deleteSelectedRecording(m)
+	case "i":
+		importSelectedRecording(m)
+	}
+	return nil
+}
+
+// handleRecordingRenameKey edits the new name: enter applies it, esc cancels
+func handleRecordingRenameKey(m *model.Model, msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.RenamingRecording = false
+		rec, ok := m.SelectedRecording()
+		if !ok {
+			return
+		}
+		if m.CurrentlyPlayingFile == rec.Path {
+			stopRecordingPreview(m)
+		}
+		newPath, err := m.RenameRecording(rec.Path, m.RenameBuffer)
+		if err != nil {
+			logging.Storage.Errorf("Error renaming recording: %v", err)
+			return
+		}
+		logging.Storage.Debugf("Renamed recording %s to %s", rec.Path, newPath)
+		if err := audio.MoveTracklist(rec.Path, newPath); err != nil {
+			logging.Storage.Errorf("Error moving the tracklist of %s: %v", newPath, err)
+		}
+		m.RefreshRecordings()
+	case tea.KeyEsc:
+		m.RenamingRecording = false
+	case tea.KeyBackspace:
+		if len(m.RenameBuffer) > 0 {
+			runes := []rune(m.RenameBuffer)
+			m.RenameBuffer = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.RenameBuffer += string(msg.Runes)
+	}
+}
+
+// toggleRecordingPreview plays the selected recording, or stops it if it is already playing
+func toggleRecordingPreview(m *model.Model) {
+	rec, ok := m.SelectedRecording()
+	if !ok {
+		return
+	}
+	if m.CurrentlyPlayingFile == rec.Path {
+		stopRecordingPreview(m)
+		return
+	}
+	stopRecordingPreview(m)
+	m.CurrentlyPlayingFile = rec.Path
+	m.SendOSCPlaybackMessage(rec.Path, true)
+	logging.Storage.Debugf("Previewing recording: %s", rec.Name)
+}
+
+// stopRecordingPreview stops a recording preview if one is playing
+func stopRecordingPreview(m *model.Model) {
+	if m.CurrentlyPlayingFile == "" {
+		return
+	}
+	m.SendOSCPlaybackMessage(m.CurrentlyPlayingFile, false)
+	m.CurrentlyPlayingFile = ""
+}
+
+// deleteSelectedRecording removes the selected recording from disk after confirmation
+func deleteSelectedRecording(m *model.Model) {
+	rec, ok := m.SelectedRecording()
+	if !ok {
+		return
+	}
+	confirmDestructive(m, "Delete recording "+rec.Name+" from disk?", func() {
+		if m.CurrentlyPlayingFile == rec.Path {
+			stopRecordingPreview(m)
+		}
+		if err := os.Remove(rec.Path); err != nil {
+			logging.Storage.Errorf("Error deleting recording: %v", err)
+		} else {
+			logging.Storage.Debugf("Deleted recording %s", rec.Path)
+			os.Remove(loudness.ReportPath(rec.Path))
+			audio.RemoveTracklist(rec.Path)
+		}
+		m.RefreshRecordings()
+	})
+}
+
+// importSelectedRecording uses the selected recording as the sample of the phrase row
+// the view was opened from (sampler tracks only), then returns to that phrase
+func importSelectedRecording(m *model.Model) {
+	rec, ok := m.SelectedRecording()
+	if !ok {
+		return
+	}
+	if m.AuxPreviousView != types.PhraseView || m.GetPhraseViewType() != types.SamplerPhraseView {
+		logging.Storage.Debugf("Open the recordings view from a sampler phrase to import a recording")
+		return
+	}
+	stopRecordingPreview(m)
+	m.FileSelectRow = m.CurrentRow
+	audio.AssignFile(m, rec.Path)
+	m.ViewMode = types.PhraseView
+}
@@ -0,0 +1,48 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/schollz/collidertracker/internal/audio"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+func TestRecordingsView(t *testing.T) {
+	m := createTestModel(t)
+	m.SaveFolder = t.TempDir()
+	m.ConfirmDeletes = false
+	m.ViewMode = types.SongView
+	assert.NoError(t, os.MkdirAll(m.RecordingsFolder(), 0755))
+	take := filepath.Join(m.RecordingsFolder(), "take.wav")
+	assert.NoError(t, os.WriteFile(take, []byte("RIFF"), 0644))
+	assert.NoError(t, audio.WriteTracklist(take, "song", []audio.Marker{{Name: "Start"}}))
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyCtrlE})
+	assert.Equal(t, types.RecordingsView, m.ViewMode)
+	assert.Len(t, m.Recordings, 1)
+
+	// Rename: r, edit the name, enter
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	assert.True(t, m.RenamingRecording)
+	assert.Equal(t, "take", m.RenameBuffer)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.RenamingRecording)
+	assert.Equal(t, "take2.wav", m.Recordings[0].Name)
+	assert.FileExists(t, audio.CueSheetPath(m.Recordings[0].Path), "The tracklist follows the recording")
+
+	// Delete without confirmation removes the file
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	assert.Empty(t, m.Recordings)
+	_, err := os.Stat(filepath.Join(m.RecordingsFolder(), "take2.wav"))
+	assert.True(t, os.IsNotExist(err))
+	assert.NoFileExists(t, audio.ChaptersPath(filepath.Join(m.RecordingsFolder(), "take2.wav")))
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, types.SongView, m.ViewMode)
+}
@@ -0,0 +1,41 @@
+package input
+
+import (
+	"time"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// uiCrashSnapshotInterval is the least time between two emergency snapshots, so a view that
+// crashes on every frame does not push every other snapshot out
+const uiCrashSnapshotInterval = time.Minute
+
+// RecoverUI puts the interface back together after a panic in it, leaving playback and the
+// project as they are. The panic is logged with where it happened and its stack, the
+// project is snapshotted so R can go back to it, and the tracker returns to the Song view
+// with any prompt or picker closed.
+func RecoverUI(m *model.Model, where string, value any, stack []byte) {
+	logging.UI.Errorf("Recovered from a panic in %s: %v\n%s", where, value, stack)
+	snapshot := ""
+	if now := time.Now(); now.Sub(m.LastUICrash) >= uiCrashSnapshotInterval {
+		m.LastUICrash = now
+		snapshotBefore(m, "crash recovery")
+		snapshot = ", the project was snapshotted"
+	}
+
+	m.PendingConfirm = nil
+	m.NumberEntry = model.NumberEntry{}
+	m.NameEntry = model.NameEntry{}
+	m.PickingClipboard = false
+	m.PickingKit = false
+	m.EditingRowTempo = false
+	wasTuning := m.ViewMode == types.TunerView
+	m.ViewMode = types.SongView
+	if wasTuning {
+		m.SendOSCPitchTrackMessage() // The tuner's pitch readings are not needed anymore
+	}
+	m.CurrentRow, m.CurrentCol, m.ScrollOffset = 0, 0, 0
+	m.Notice = "The interface recovered from a crash" + snapshot
+}
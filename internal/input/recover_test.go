@@ -0,0 +1,38 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/storage"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+func TestRecoverUI(t *testing.T) {
+	m := createTestModel(t)
+	m.SaveFolder = t.TempDir()
+	m.ViewMode = types.PhraseView
+	m.CurrentRow, m.CurrentCol = 5, 3
+	m.NumberEntry = model.NumberEntry{Active: true, Buffer: "1"}
+	m.PickingKit = true
+	m.IsPlaying = true
+
+	RecoverUI(m, "view 2", "index out of range", nil)
+	assert.Equal(t, types.SongView, m.ViewMode)
+	assert.Equal(t, 0, m.CurrentRow)
+	assert.False(t, m.NumberEntry.Active)
+	assert.False(t, m.PickingKit)
+	assert.True(t, m.IsPlaying, "Playback goes on")
+	assert.Equal(t, "The interface recovered from a crash, the project was snapshotted", m.Notice)
+	snapshot, ok := storage.LatestSnapshot(m)
+	assert.True(t, ok)
+	assert.Equal(t, "crash recovery", storage.SnapshotOperation(snapshot))
+
+	// A crash soon after is not snapshotted again
+	RecoverUI(m, "view 2", "index out of range", nil)
+	assert.Equal(t, "The interface recovered from a crash", m.Notice)
+	again, _ := storage.LatestSnapshot(m)
+	assert.Equal(t, snapshot, again)
+}
@@ -0,0 +1,52 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/report"
+)
+
+// ReportDoneMsg carries the result of writing a bug report back to the UI goroutine
+type ReportDoneMsg struct {
+	File string // Report written
+	Err  error  // Why it could not be written (nil if it was)
+}
+
+// WriteBugReport writes a bug report to the reports folder in the background: the latest log
+// lines, a summary of the project and details of the system and SuperCollider, redacted
+func WriteBugReport(m *model.Model) tea.Cmd {
+	folder := report.Folder()
+	if folder == "" {
+		m.Notice = "No config folder to write the bug report to"
+		return nil
+	}
+	file := filepath.Join(folder, report.FileName(time.Now()))
+	project := report.Summarize(m)
+	logging.UI.Info("Bug report requested", "file", file)
+	m.Notice = "Writing bug report..."
+	return func() tea.Msg {
+		return ReportDoneMsg{File: file, Err: report.Write(file, project, report.CollectSystem())}
+	}
+}
+
+// HandleReportDone tells where the bug report was written
+func HandleReportDone(m *model.Model, msg ReportDoneMsg) {
+	if msg.Err != nil {
+		logging.UI.Error("Bug report failed", "error", msg.Err)
+		m.Notice = "Bug report failed: " + msg.Err.Error()
+		return
+	}
+	logging.UI.Info("Bug report written", "file", msg.File)
+	file := msg.File
+	if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(file, home) {
+		file = "~" + strings.TrimPrefix(file, home)
+	}
+	m.Notice = "Bug report written to " + file
+}
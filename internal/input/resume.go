@@ -0,0 +1,66 @@
+package input
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// ResumePlayback starts playback where it was when the project was saved, if the project
+// resumes playback and was playing then
+func ResumePlayback(m *model.Model) tea.Cmd {
+	state := m.PendingResume
+	m.PendingResume = nil
+	if state == nil || m.IsPlaying {
+		return nil
+	}
+
+	config := PlaybackConfig{Mode: state.Mode, UseCurrentRow: true, Chain: -1, Phrase: -1}
+	switch state.Mode {
+	case types.SongView:
+		rows := state.SongRows
+		config.TrackRows = &rows
+	case types.ChainView:
+		if state.Track < 0 || state.Track >= types.NumTracks || state.Chain < 0 || state.Chain >= types.NumChains {
+			return nil
+		}
+		m.CurrentTrack = state.Track
+		config.Chain, config.Row = state.Chain, state.ChainRow
+	case types.PhraseView:
+		if state.Track < 0 || state.Track >= types.NumTracks || state.Phrase < 0 || state.Phrase >= types.NumPhrases {
+			return nil
+		}
+		m.CurrentTrack = state.Track
+		config.Phrase, config.Row = state.Phrase, state.Row
+	default:
+		return nil
+	}
+	logging.Playback.Debugf("Resuming %v playback", state.Mode)
+	m.Notice = "Playback resumed where the project was saved"
+	return startPlaybackWithConfig(m, config)
+}
+
+// StartDemo plays the song from the top, in place of resuming, when the project was opened
+// as a demo
+func StartDemo(m *model.Model) tea.Cmd {
+	if !m.DemoStart {
+		return nil
+	}
+	m.DemoStart, m.PendingResume = false, nil
+	if m.IsPlaying {
+		return nil
+	}
+	logging.Storage.Debugf("Starting the demo")
+	m.Notice = "Playing the demo, changes are not kept"
+	return startPlaybackWithConfig(m, PlaybackConfig{Mode: types.SongView, Chain: -1, Phrase: -1, Row: 0})
+}
+
+// saveTransport schedules an autosave of where playback is, so a project that resumes playback
+// reopens close to where it stopped
+func saveTransport(m *model.Model) {
+	if m.ResumePlayback {
+		m.Publish(model.Event{Kind: model.EventView})
+	}
+}
@@ -0,0 +1,62 @@
+package input
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// startRowTempoEdit starts typing the tempo of the song row under the cursor
+func startRowTempoEdit(m *model.Model) {
+	if m.ViewMode != types.SongView || m.CurrentRow < 0 || m.CurrentRow >= types.SongRows {
+		return
+	}
+	m.EditingRowTempo = true
+	m.RowTempoBuffer = ""
+	if bpm := m.SongRowTempos[m.CurrentRow].BPM; bpm > 0 {
+		m.RowTempoBuffer = strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", bpm), "0"), ".")
+	}
+}
+
+// handleRowTempoKey edits the row's tempo: enter applies it (empty or 0 returns the row to the
+// song tempo), esc cancels
+func handleRowTempoKey(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.EditingRowTempo = false
+		bpm, err := strconv.ParseFloat(m.RowTempoBuffer, 32)
+		if err != nil {
+			bpm = 0
+		}
+		m.SetSongRowBPM(m.CurrentRow, float32(bpm))
+	case tea.KeyEsc:
+		m.EditingRowTempo = false
+	case tea.KeyBackspace:
+		if len(m.RowTempoBuffer) > 0 {
+			m.RowTempoBuffer = m.RowTempoBuffer[:len(m.RowTempoBuffer)-1]
+		}
+	case tea.KeyRunes:
+		for _, r := range msg.Runes {
+			digit := r >= '0' && r <= '9'
+			point := r == '.' && !strings.Contains(m.RowTempoBuffer, ".")
+			if (digit || point) && len(m.RowTempoBuffer) < model.MaxNumberEntryLength {
+				m.RowTempoBuffer += string(r)
+			}
+		}
+	}
+	return nil
+}
+
+// stepRowGroove moves the groove of the song row under the cursor through the song's groove
+// and the groove templates
+func stepRowGroove(m *model.Model, delta int) {
+	if m.ViewMode != types.SongView || m.CurrentRow < 0 || m.CurrentRow >= types.SongRows {
+		return
+	}
+	m.SetSongRowGroove(m.CurrentRow, m.SongRowTempos[m.CurrentRow].Groove+delta)
+}
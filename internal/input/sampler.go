@@ -2,14 +2,14 @@ package input
 
 import (
 	"fmt"
-	"log"
 
+	"github.com/schollz/collidertracker/internal/logging"
 	"github.com/schollz/collidertracker/internal/model"
-	"github.com/schollz/collidertracker/internal/storage"
+	"github.com/schollz/collidertracker/internal/types"
 )
 
 func ModifyRetriggerValue(m *model.Model, baseDelta float32) {
-	if m.RetriggerEditingIndex < 0 || m.RetriggerEditingIndex >= 255 {
+	if m.RetriggerEditingIndex < 0 || m.RetriggerEditingIndex >= types.NumSettings {
 		return
 	}
 
@@ -34,7 +34,7 @@ func ModifyRetriggerValue(m *model.Model, baseDelta float32) {
 			newTimes = 256
 		}
 		settings.Times = newTimes
-		log.Printf("Modified retrigger %02X Times: %d -> %d (delta: %d)", m.RetriggerEditingIndex, settings.Times-delta, settings.Times, delta)
+		logging.UI.Debugf("Modified retrigger %02X Times: %d -> %d (delta: %d)", m.RetriggerEditingIndex, settings.Times-delta, settings.Times, delta)
 	} else if m.CurrentRow == 1 { // Starting Rate
 		// Use different increments: 0.05 for fine, 1.0 for coarse (based on Ctrl+Up/Down vs Ctrl+Left/Right)
 		var delta float32
@@ -51,7 +51,7 @@ func ModifyRetriggerValue(m *model.Model, baseDelta float32) {
 			newStart = 256
 		}
 		settings.Start = newStart
-		log.Printf("Modified retrigger %02X Starting Rate: %.2f -> %.2f (delta: %.2f)", m.RetriggerEditingIndex, settings.Start-delta, settings.Start, delta)
+		logging.UI.Debugf("Modified retrigger %02X Starting Rate: %.2f -> %.2f (delta: %.2f)", m.RetriggerEditingIndex, settings.Start-delta, settings.Start, delta)
 	} else if m.CurrentRow == 2 { // Final Rate
 		// Use different increments: 0.05 for fine, 1.0 for coarse (based on Ctrl+Up/Down vs Ctrl+Left/Right)
 		var delta float32
@@ -68,7 +68,7 @@ func ModifyRetriggerValue(m *model.Model, baseDelta float32) {
 			newEnd = 256
 		}
 		settings.End = newEnd
-		log.Printf("Modified retrigger %02X Final Rate: %.2f -> %.2f (delta: %.2f)", m.RetriggerEditingIndex, settings.End-delta, settings.End, delta)
+		logging.UI.Debugf("Modified retrigger %02X Final Rate: %.2f -> %.2f (delta: %.2f)", m.RetriggerEditingIndex, settings.End-delta, settings.End, delta)
 	} else if m.CurrentRow == 3 { // Beats
 		// Use different increments: 4 for coarse, 1 for fine (based on Ctrl+Up/Down vs Ctrl+Left/Right)
 		var delta int
@@ -87,7 +87,7 @@ func ModifyRetriggerValue(m *model.Model, baseDelta float32) {
 			newBeats = 256
 		}
 		settings.Beats = newBeats
-		log.Printf("Modified retrigger %02X Beats: %d -> %d (delta: %d)", m.RetriggerEditingIndex, settings.Beats-delta, settings.Beats, delta)
+		logging.UI.Debugf("Modified retrigger %02X Beats: %d -> %d (delta: %d)", m.RetriggerEditingIndex, settings.Beats-delta, settings.Beats, delta)
 	} else if m.CurrentRow == 4 { // Volume dB
 		newVolumeDB := settings.VolumeDB + baseDelta
 		if newVolumeDB < -16.0 {
@@ -96,7 +96,7 @@ func ModifyRetriggerValue(m *model.Model, baseDelta float32) {
 			newVolumeDB = 16.0
 		}
 		settings.VolumeDB = newVolumeDB
-		log.Printf("Modified retrigger %02X VolumeDB: %.1f -> %.1f (delta: %.1f)", m.RetriggerEditingIndex, settings.VolumeDB-baseDelta, settings.VolumeDB, baseDelta)
+		logging.UI.Debugf("Modified retrigger %02X VolumeDB: %.1f -> %.1f (delta: %.1f)", m.RetriggerEditingIndex, settings.VolumeDB-baseDelta, settings.VolumeDB, baseDelta)
 	} else if m.CurrentRow == 5 { // Pitch change
 		newPitchChange := settings.PitchChange + baseDelta
 		if newPitchChange < -24.0 {
@@ -105,7 +105,7 @@ func ModifyRetriggerValue(m *model.Model, baseDelta float32) {
 			newPitchChange = 24.0
 		}
 		settings.PitchChange = newPitchChange
-		log.Printf("Modified retrigger %02X PitchChange: %.1f -> %.1f (delta: %.1f)", m.RetriggerEditingIndex, settings.PitchChange-baseDelta, settings.PitchChange, baseDelta)
+		logging.UI.Debugf("Modified retrigger %02X PitchChange: %.1f -> %.1f (delta: %.1f)", m.RetriggerEditingIndex, settings.PitchChange-baseDelta, settings.PitchChange, baseDelta)
 	} else if m.CurrentRow == 6 { // Final pitch to start
 		// Toggle between 0 (No) and 1 (Yes)
 		if baseDelta > 0 {
@@ -117,7 +117,7 @@ func ModifyRetriggerValue(m *model.Model, baseDelta float32) {
 		if settings.FinalPitchToStart == 1 {
 			finalPitchValue = "Yes"
 		}
-		log.Printf("Modified retrigger %02X FinalPitchToStart: %s", m.RetriggerEditingIndex, finalPitchValue)
+		logging.UI.Debugf("Modified retrigger %02X FinalPitchToStart: %s", m.RetriggerEditingIndex, finalPitchValue)
 	} else if m.CurrentRow == 7 { // Final volume to start
 		// Toggle between 0 (No) and 1 (Yes)
 		if baseDelta > 0 {
@@ -129,7 +129,7 @@ func ModifyRetriggerValue(m *model.Model, baseDelta float32) {
 		if settings.FinalVolumeToStart == 1 {
 			finalVolumeValue = "Yes"
 		}
-		log.Printf("Modified retrigger %02X FinalVolumeToStart: %s", m.RetriggerEditingIndex, finalVolumeValue)
+		logging.UI.Debugf("Modified retrigger %02X FinalVolumeToStart: %s", m.RetriggerEditingIndex, finalVolumeValue)
 	} else if m.CurrentRow == 8 { // Every
 		// Use different increments: 4 for coarse, 1 for fine (based on Ctrl+Up/Down vs Ctrl+Left/Right)
 		var delta int
@@ -148,7 +148,7 @@ func ModifyRetriggerValue(m *model.Model, baseDelta float32) {
 			newEvery = 64
 		}
 		settings.Every = newEvery
-		log.Printf("Modified retrigger %02X Every: %d -> %d (delta: %d)", m.RetriggerEditingIndex, settings.Every-delta, settings.Every, delta)
+		logging.UI.Debugf("Modified retrigger %02X Every: %d -> %d (delta: %d)", m.RetriggerEditingIndex, settings.Every-delta, settings.Every, delta)
 	} else if m.CurrentRow == 9 { // Probability
 		// Use different increments: 10 for coarse, 1 for fine (based on Ctrl+Up/Down vs Ctrl+Left/Right)
 		var delta int
@@ -167,16 +167,16 @@ func ModifyRetriggerValue(m *model.Model, baseDelta float32) {
 			newProbability = 100
 		}
 		settings.Probability = newProbability
-		log.Printf("Modified retrigger %02X Probability: %d -> %d (delta: %d)", m.RetriggerEditingIndex, settings.Probability-delta, settings.Probability, delta)
+		logging.UI.Debugf("Modified retrigger %02X Probability: %d -> %d (delta: %d)", m.RetriggerEditingIndex, settings.Probability-delta, settings.Probability, delta)
 	}
 
 	// Store back the modified settings
 	m.RetriggerSettings[m.RetriggerEditingIndex] = settings
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventSettings})
 }
 
 func ModifyTimestrechValue(m *model.Model, baseDelta float32) {
-	if m.TimestrechEditingIndex < 0 || m.TimestrechEditingIndex >= 255 {
+	if m.TimestrechEditingIndex < 0 || m.TimestrechEditingIndex >= types.NumSettings {
 		return
 	}
 
@@ -199,7 +199,7 @@ func ModifyTimestrechValue(m *model.Model, baseDelta float32) {
 			newStart = 256
 		}
 		settings.Start = newStart
-		log.Printf("Modified timestretch %02X Start: %.2f -> %.2f (delta: %.2f)", m.TimestrechEditingIndex, settings.Start-delta, settings.Start, delta)
+		logging.UI.Debugf("Modified timestretch %02X Start: %.2f -> %.2f (delta: %.2f)", m.TimestrechEditingIndex, settings.Start-delta, settings.Start, delta)
 	} else if m.CurrentRow == 1 { // End
 		// Use different increments: 0.05 for fine, 1.0 for coarse (based on Ctrl+Up/Down vs Ctrl+Left/Right)
 		var delta float32
@@ -216,7 +216,7 @@ func ModifyTimestrechValue(m *model.Model, baseDelta float32) {
 			newEnd = 256
 		}
 		settings.End = newEnd
-		log.Printf("Modified timestretch %02X End: %.2f -> %.2f (delta: %.2f)", m.TimestrechEditingIndex, settings.End-delta, settings.End, delta)
+		logging.UI.Debugf("Modified timestretch %02X End: %.2f -> %.2f (delta: %.2f)", m.TimestrechEditingIndex, settings.End-delta, settings.End, delta)
 	} else if m.CurrentRow == 2 { // Beats
 		newBeats := settings.Beats + int(baseDelta)
 		if newBeats < 0 {
@@ -225,7 +225,7 @@ func ModifyTimestrechValue(m *model.Model, baseDelta float32) {
 			newBeats = 256
 		}
 		settings.Beats = newBeats
-		log.Printf("Modified timestretch %02X Beats: %d -> %d (delta: %.2f)", m.TimestrechEditingIndex, settings.Beats-int(baseDelta), settings.Beats, baseDelta)
+		logging.UI.Debugf("Modified timestretch %02X Beats: %d -> %d (delta: %.2f)", m.TimestrechEditingIndex, settings.Beats-int(baseDelta), settings.Beats, baseDelta)
 	} else if m.CurrentRow == 3 { // Every
 		// Use different increments: 4 for coarse, 1 for fine (based on Ctrl+Up/Down vs Ctrl+Left/Right)
 		var delta int
@@ -244,7 +244,7 @@ func ModifyTimestrechValue(m *model.Model, baseDelta float32) {
 			newEvery = 64
 		}
 		settings.Every = newEvery
-		log.Printf("Modified timestretch %02X Every: %d -> %d (delta: %d)", m.TimestrechEditingIndex, settings.Every-delta, settings.Every, delta)
+		logging.UI.Debugf("Modified timestretch %02X Every: %d -> %d (delta: %d)", m.TimestrechEditingIndex, settings.Every-delta, settings.Every, delta)
 	} else if m.CurrentRow == 4 { // Probability
 		// Use different increments: 10 for coarse, 1 for fine (based on Ctrl+Up/Down vs Ctrl+Left/Right)
 		var delta int
@@ -263,16 +263,16 @@ func ModifyTimestrechValue(m *model.Model, baseDelta float32) {
 			newProbability = 100
 		}
 		settings.Probability = newProbability
-		log.Printf("Modified timestretch %02X Probability: %d -> %d (delta: %d)", m.TimestrechEditingIndex, settings.Probability-delta, settings.Probability, delta)
+		logging.UI.Debugf("Modified timestretch %02X Probability: %d -> %d (delta: %d)", m.TimestrechEditingIndex, settings.Probability-delta, settings.Probability, delta)
 	}
 
 	// Store back the modified settings
 	m.TimestrechSettings[m.TimestrechEditingIndex] = settings
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventSettings})
 }
 
 func ModifyModulateValue(m *model.Model, baseDelta float32) {
-	if m.ModulateEditingIndex < 0 || m.ModulateEditingIndex >= 255 {
+	if m.ModulateEditingIndex < 0 || m.ModulateEditingIndex >= types.NumSettings {
 		return
 	}
 
@@ -309,7 +309,7 @@ func ModifyModulateValue(m *model.Model, baseDelta float32) {
 		} else if settings.Seed > 0 {
 			newSeedValue = fmt.Sprintf("%d", settings.Seed)
 		}
-		log.Printf("Modified modulate %02X Seed: %s -> %s", m.ModulateEditingIndex, oldSeedValue, newSeedValue)
+		logging.UI.Debugf("Modified modulate %02X Seed: %s -> %s", m.ModulateEditingIndex, oldSeedValue, newSeedValue)
 	} else if m.CurrentRow == 1 { // IRandom
 		// Use different increments: 5 for coarse, 1 for fine
 		var delta int
@@ -328,7 +328,7 @@ func ModifyModulateValue(m *model.Model, baseDelta float32) {
 			newIRandom = 128
 		}
 		settings.IRandom = newIRandom
-		log.Printf("Modified modulate %02X IRandom: %d -> %d (delta: %d)", m.ModulateEditingIndex, settings.IRandom-delta, settings.IRandom, delta)
+		logging.UI.Debugf("Modified modulate %02X IRandom: %d -> %d (delta: %d)", m.ModulateEditingIndex, settings.IRandom-delta, settings.IRandom, delta)
 	} else if m.CurrentRow == 2 { // Sub
 		// Use different increments: 5 for coarse, 1 for fine
 		var delta int
@@ -347,7 +347,7 @@ func ModifyModulateValue(m *model.Model, baseDelta float32) {
 			newSub = 120
 		}
 		settings.Sub = newSub
-		log.Printf("Modified modulate %02X Sub: %d -> %d (delta: %d)", m.ModulateEditingIndex, settings.Sub-delta, settings.Sub, delta)
+		logging.UI.Debugf("Modified modulate %02X Sub: %d -> %d (delta: %d)", m.ModulateEditingIndex, settings.Sub-delta, settings.Sub, delta)
 	} else if m.CurrentRow == 3 { // Add
 		// Use different increments: 5 for coarse, 1 for fine
 		var delta int
@@ -366,7 +366,7 @@ func ModifyModulateValue(m *model.Model, baseDelta float32) {
 			newAdd = 120
 		}
 		settings.Add = newAdd
-		log.Printf("Modified modulate %02X Add: %d -> %d (delta: %d)", m.ModulateEditingIndex, settings.Add-delta, settings.Add, delta)
+		logging.UI.Debugf("Modified modulate %02X Add: %d -> %d (delta: %d)", m.ModulateEditingIndex, settings.Add-delta, settings.Add, delta)
 	} else if m.CurrentRow == 4 { // Increment
 		// Use different increments: 5 for coarse, 1 for fine
 		var delta int
@@ -385,7 +385,7 @@ func ModifyModulateValue(m *model.Model, baseDelta float32) {
 			newIncrement = 128
 		}
 		settings.Increment = newIncrement
-		log.Printf("Modified modulate %02X Increment: %d -> %d (delta: %d)", m.ModulateEditingIndex, settings.Increment-delta, settings.Increment, delta)
+		logging.UI.Debugf("Modified modulate %02X Increment: %d -> %d (delta: %d)", m.ModulateEditingIndex, settings.Increment-delta, settings.Increment, delta)
 	} else if m.CurrentRow == 5 { // Wrap
 		// Use different increments: 5 for coarse, 1 for fine
 		var delta int
@@ -404,7 +404,7 @@ func ModifyModulateValue(m *model.Model, baseDelta float32) {
 			newWrap = 128
 		}
 		settings.Wrap = newWrap
-		log.Printf("Modified modulate %02X Wrap: %d -> %d (delta: %d)", m.ModulateEditingIndex, settings.Wrap-delta, settings.Wrap, delta)
+		logging.UI.Debugf("Modified modulate %02X Wrap: %d -> %d (delta: %d)", m.ModulateEditingIndex, settings.Wrap-delta, settings.Wrap, delta)
 	} else if m.CurrentRow == 6 { // ScaleRoot
 		// Cycle through note names (C, C#, D, D#, E, F, F#, G, G#, A, A#, B)
 		noteNames := []string{"C", "C#", "D", "D#", "E", "F", "F#", "G", "G#", "A", "A#", "B"}
@@ -425,7 +425,7 @@ func ModifyModulateValue(m *model.Model, baseDelta float32) {
 
 		oldNote := noteNames[currentIndex]
 		settings.ScaleRoot = newIndex
-		log.Printf("Modified modulate %02X ScaleRoot: %s -> %s", m.ModulateEditingIndex, oldNote, noteNames[newIndex])
+		logging.UI.Debugf("Modified modulate %02X ScaleRoot: %s -> %s", m.ModulateEditingIndex, oldNote, noteNames[newIndex])
 	} else if m.CurrentRow == 7 { // Scale
 		// Cycle through available scales
 		availableScales := []string{"all", "major", "minor", "dorian", "mixolydian", "pentatonic", "blues", "chromatic"}
@@ -454,7 +454,7 @@ func ModifyModulateValue(m *model.Model, baseDelta float32) {
 
 		oldScale := settings.Scale
 		settings.Scale = availableScales[newIndex]
-		log.Printf("Modified modulate %02X Scale: %s -> %s", m.ModulateEditingIndex, oldScale, settings.Scale)
+		logging.UI.Debugf("Modified modulate %02X Scale: %s -> %s", m.ModulateEditingIndex, oldScale, settings.Scale)
 	} else if m.CurrentRow == 8 { // Probability
 		// Use different increments: 10 for coarse, 1 for fine (based on Ctrl+Up/Down vs Ctrl+Left/Right)
 		var delta int
@@ -473,16 +473,16 @@ func ModifyModulateValue(m *model.Model, baseDelta float32) {
 			newProbability = 100
 		}
 		settings.Probability = newProbability
-		log.Printf("Modified modulate %02X Probability: %d -> %d (delta: %d)", m.ModulateEditingIndex, settings.Probability-delta, settings.Probability, delta)
+		logging.UI.Debugf("Modified modulate %02X Probability: %d -> %d (delta: %d)", m.ModulateEditingIndex, settings.Probability-delta, settings.Probability, delta)
 	}
 
 	// Save the modified settings back to the model
 	(*m.GetCurrentModulateSettings())[m.ModulateEditingIndex] = settings
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventSettings})
 }
 
 func ModifyDuckingValue(m *model.Model, baseDelta float32) {
-	if m.DuckingEditingIndex < 0 || m.DuckingEditingIndex >= 255 {
+	if m.DuckingEditingIndex < 0 || m.DuckingEditingIndex >= types.NumSettings {
 		return
 	}
 
@@ -509,7 +509,7 @@ func ModifyDuckingValue(m *model.Model, baseDelta float32) {
 
 		oldTypeName := typeNames[currentType]
 		settings.Type = newType
-		log.Printf("Modified ducking %02X Type: %s -> %s", m.DuckingEditingIndex, oldTypeName, typeNames[newType])
+		logging.UI.Debugf("Modified ducking %02X Type: %s -> %s", m.DuckingEditingIndex, oldTypeName, typeNames[newType])
 
 		// If we changed away from "ducked" type (2) and we're on a row that shouldn't be visible, move to a valid row
 		if newType != 2 && m.CurrentRow > 2 {
@@ -533,7 +533,7 @@ func ModifyDuckingValue(m *model.Model, baseDelta float32) {
 			newBus = 7
 		}
 		settings.Bus = newBus
-		log.Printf("Modified ducking %02X Bus: %d -> %d (delta: %d)", m.DuckingEditingIndex, settings.Bus-delta, settings.Bus, delta)
+		logging.UI.Debugf("Modified ducking %02X Bus: %d -> %d (delta: %d)", m.DuckingEditingIndex, settings.Bus-delta, settings.Bus, delta)
 	} else if m.CurrentRow == 2 { // Depth
 		// Use different increments: 0.1 for coarse, 0.01 for fine (based on Ctrl+Up/Down vs Ctrl+Left/Right)
 		var delta float32
@@ -552,7 +552,7 @@ func ModifyDuckingValue(m *model.Model, baseDelta float32) {
 			newDepth = 1.0
 		}
 		settings.Depth = newDepth
-		log.Printf("Modified ducking %02X Depth: %.2f -> %.2f (delta: %.2f)", m.DuckingEditingIndex, settings.Depth-delta, settings.Depth, delta)
+		logging.UI.Debugf("Modified ducking %02X Depth: %.2f -> %.2f (delta: %.2f)", m.DuckingEditingIndex, settings.Depth-delta, settings.Depth, delta)
 	} else if settings.Type == 2 && m.CurrentRow == 3 { // Attack (only when type is ducked)
 		// Use different increments: 0.1 for coarse, 0.01 for fine (based on Ctrl+Up/Down vs Ctrl+Left/Right)
 		var delta float32
@@ -571,7 +571,7 @@ func ModifyDuckingValue(m *model.Model, baseDelta float32) {
 			newAttack = 2.0
 		}
 		settings.Attack = newAttack
-		log.Printf("Modified ducking %02X Attack: %.2f -> %.2f (delta: %.2f)", m.DuckingEditingIndex, settings.Attack-delta, settings.Attack, delta)
+		logging.UI.Debugf("Modified ducking %02X Attack: %.2f -> %.2f (delta: %.2f)", m.DuckingEditingIndex, settings.Attack-delta, settings.Attack, delta)
 	} else if settings.Type == 2 && m.CurrentRow == 4 { // Release (only when type is ducked)
 		// Use different increments: 0.1 for coarse, 0.01 for fine (based on Ctrl+Up/Down vs Ctrl+Left/Right)
 		var delta float32
@@ -590,7 +590,7 @@ func ModifyDuckingValue(m *model.Model, baseDelta float32) {
 			newRelease = 2.0
 		}
 		settings.Release = newRelease
-		log.Printf("Modified ducking %02X Release: %.2f -> %.2f (delta: %.2f)", m.DuckingEditingIndex, settings.Release-delta, settings.Release, delta)
+		logging.UI.Debugf("Modified ducking %02X Release: %.2f -> %.2f (delta: %.2f)", m.DuckingEditingIndex, settings.Release-delta, settings.Release, delta)
 	} else if settings.Type == 2 && m.CurrentRow == 5 { // Thresh (only when type is ducked)
 		// Use different increments: 0.1 for coarse, 0.01 for fine (based on Ctrl+Up/Down vs Ctrl+Left/Right)
 		var delta float32
@@ -609,12 +609,12 @@ func ModifyDuckingValue(m *model.Model, baseDelta float32) {
 			newThresh = 1.0
 		}
 		settings.Thresh = newThresh
-		log.Printf("Modified ducking %02X Thresh: %.2f -> %.2f (delta: %.2f)", m.DuckingEditingIndex, settings.Thresh-delta, settings.Thresh, delta)
+		logging.UI.Debugf("Modified ducking %02X Thresh: %.2f -> %.2f (delta: %.2f)", m.DuckingEditingIndex, settings.Thresh-delta, settings.Thresh, delta)
 	}
 
 	// Store back the modified settings
 	m.DuckingSettings[m.DuckingEditingIndex] = settings
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventSettings})
 
 	// Send ducking parameters to track 8 (external input) if in MI mode and ducking is active
 	m.SendDuckingToExternalInput(m.DuckingEditingIndex)
@@ -0,0 +1,148 @@
+package input
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/audio"
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+const (
+	beatsPerBar  = 4           // Bar length used to align session punch-in
+	takeDoneWait = time.Second // Gives SuperCollider time to close a stopped take
+)
+
+// SessionTakeDoneMsg reports that a stopped session take was finished: the pre-roll put before
+// it and its song sections marked, in the file and in a tracklist next to it
+type SessionTakeDoneMsg struct {
+	File string
+	Err  error
+}
+
+// ToggleSessionRecording stops the current session take, or starts a new one.
+// While playing, the take is armed and starts on the next bar (punch-in);
+// pressing again while armed cancels it. A take starts with the first row played
+// after it, and stopping it returns the command that puts the pre-roll before it
+// and writes the markers and tracklist of the song sections it went through.
+func ToggleSessionRecording(m *model.Model) tea.Cmd {
+	if m.Bounce != nil {
+		logging.Storage.Debugf("Session recording unavailable while bouncing a loop")
+		return nil
+	}
+	switch {
+	case m.SessionRecording:
+		file, title, preRoll := m.SessionRecordingFile, takeTitle(m), m.PreRollSeconds()
+		markers := takeMarkers(m, preRoll)
+		m.SendOSCSessionRecordMessage(file, false)
+		logging.Storage.Debugf("Session recording stopped: %s", file)
+		m.SessionRecording = false
+		m.SessionRecordingFile = ""
+		if (preRoll > 0 || len(markers) > 0) && file != "" {
+			return tea.Tick(takeDoneWait, func(time.Time) tea.Msg {
+				return SessionTakeDoneMsg{File: file, Err: finishTake(file, title, preRoll, markers)}
+			})
+		}
+	case m.SessionPunchArmed:
+		m.SessionPunchArmed = false
+		logging.Storage.Debugf("Session punch-in cancelled")
+	case m.IsPlaying:
+		m.SessionPunchArmed = true
+		logging.Storage.Debugf("Session recording armed, starting on the next bar")
+	default:
+		startSessionRecording(m)
+	}
+	return nil
+}
+
+// HandleSessionTakeDone reports a take whose pre-roll or markers could not be written. A take
+// that never played a row has no file and is left alone.
+func HandleSessionTakeDone(m *model.Model, msg SessionTakeDoneMsg) {
+	if msg.Err == nil {
+		logging.Storage.Debugf("Session take finished: %s", msg.File)
+		return
+	}
+	if os.IsNotExist(msg.Err) {
+		return
+	}
+	logging.Storage.Errorf("Error finishing the session take %s: %v", msg.File, msg.Err)
+	m.Notice = fmt.Sprintf("Could not add the pre-roll, markers and tracklist to %s: %v", filepath.Base(msg.File), msg.Err)
+}
+
+// FinishSessionTake puts the pre-roll before the session take still recording when the
+// tracker closed, and writes its markers and tracklist. Call once SuperCollider has stopped.
+func FinishSessionTake(m *model.Model) {
+	if !m.SessionRecording || m.SessionRecordingFile == "" {
+		return
+	}
+	preRoll := m.PreRollSeconds()
+	markers := takeMarkers(m, preRoll)
+	if preRoll <= 0 && len(markers) == 0 {
+		return
+	}
+	HandleSessionTakeDone(m, SessionTakeDoneMsg{File: m.SessionRecordingFile, Err: finishTake(m.SessionRecordingFile, takeTitle(m), preRoll, markers)})
+	m.SessionRecording = false
+}
+
+// finishTake puts the pre-roll before a stopped take, then writes its markers into it and its
+// tracklist next to it
+func finishTake(file, title string, preRoll float64, markers []audio.Marker) error {
+	if err := audio.PadStart(file, preRoll); err != nil {
+		return err
+	}
+	if err := audio.AddMarkers(file, markers); err != nil {
+		return err
+	}
+	return audio.WriteTracklist(file, title, markers)
+}
+
+// takeTitle returns the title of a session take's tracklist: the project's name
+func takeTitle(m *model.Model) string {
+	return filepath.Base(m.SaveFolder)
+}
+
+// takeMarkers returns the song sections the session take went through as markers on its
+// file, after the pre-roll. The file starts with the take's first sound.
+func takeMarkers(m *model.Model, preRoll float64) []audio.Marker {
+	if m.SessionTakeStart.IsZero() {
+		return nil
+	}
+	markers := make([]audio.Marker, 0, len(m.SessionMarkers))
+	for _, marker := range m.SessionMarkers {
+		at := max(0, marker.At.Sub(m.SessionTakeStart).Seconds())
+		markers = append(markers, audio.Marker{Name: marker.Name, Seconds: preRoll + at})
+	}
+	return markers
+}
+
+// ProcessSessionPunchIn starts an armed session take when the tick about to be played
+// is the first of a bar. Call before AdvancePlayback for each playback tick.
+func ProcessSessionPunchIn(m *model.Model) {
+	if !m.SessionPunchArmed || m.PPQ <= 0 {
+		return
+	}
+	if m.PlaybackTickCount%(beatsPerBar*m.PPQ*m.ClockResolution()) == 0 {
+		startSessionRecording(m)
+	}
+}
+
+// startSessionRecording starts recording the master output to a new file in the recordings folder
+func startSessionRecording(m *model.Model) {
+	m.SessionPunchArmed = false
+	if err := os.MkdirAll(m.RecordingsFolder(), 0755); err != nil {
+		logging.Storage.Errorf("Error creating recordings folder: %v", err)
+	}
+	filename := filepath.Join(m.RecordingsFolder(), fmt.Sprintf("session-%s.wav", time.Now().Format("2006-01-02-15-04-05")))
+	m.SessionRecording = true
+	m.SessionRecordingFile = filename
+	m.SessionTakeStart = time.Time{}
+	m.SessionSection = -1
+	m.SessionMarkers = nil
+	m.SendOSCSessionRecordMessage(filename, true)
+	logging.Storage.Debugf("Session recording started: %s", filename)
+}
@@ -0,0 +1,85 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+func TestSessionRecordingPunchIn(t *testing.T) {
+	m := createTestModel(t)
+	m.SaveFolder = t.TempDir()
+	m.PPQ = 2
+
+	// Stopped: the take is armed in SuperCollider right away
+	ToggleSessionRecording(m)
+	assert.True(t, m.SessionRecording)
+	assert.Contains(t, m.SessionRecordingFile, "session-")
+	assert.Nil(t, ToggleSessionRecording(m), "Without pre-roll a stopped take is left as recorded")
+	assert.False(t, m.SessionRecording)
+	assert.Empty(t, m.SessionRecordingFile)
+
+	// Playing: armed until the next bar
+	m.IsPlaying = true
+	ToggleSessionRecording(m)
+	assert.True(t, m.SessionPunchArmed)
+	assert.False(t, m.SessionRecording)
+
+	m.PlaybackTickCount = 5
+	ProcessSessionPunchIn(m)
+	assert.False(t, m.SessionRecording, "Mid-bar ticks do not start the take")
+
+	m.PlaybackTickCount = 8 // 4 beats * PPQ 2
+	ProcessSessionPunchIn(m)
+	assert.True(t, m.SessionRecording)
+	assert.False(t, m.SessionPunchArmed)
+
+	ToggleSessionRecording(m)
+	assert.False(t, m.SessionRecording)
+
+	// Pressing again while armed cancels
+	ToggleSessionRecording(m)
+	ToggleSessionRecording(m)
+	assert.False(t, m.SessionPunchArmed)
+	assert.False(t, m.SessionRecording)
+}
+
+func TestSessionPreRoll(t *testing.T) {
+	m := createTestModel(t)
+	m.SaveFolder = t.TempDir()
+	m.BPM = 120
+
+	// The App setting steps through the choices
+	m.ViewMode = types.SettingsView
+	m.CurrentRow = int(types.AppSettingsRowPreRoll)
+	m.CurrentCol = 3
+	assert.Equal(t, "off", m.PreRollName())
+	ModifySettingsValue(m, 1)
+	ModifySettingsValue(m, 1)
+	assert.Equal(t, "2 beats", m.PreRollName())
+	assert.Equal(t, 1.0, m.PreRollSeconds())
+
+	// Stopping a take returns the command that pads it
+	ToggleSessionRecording(m)
+	file := m.SessionRecordingFile
+	assert.NotNil(t, ToggleSessionRecording(m))
+
+	// A take that never played a row has no file, which is not an error
+	HandleSessionTakeDone(m, SessionTakeDoneMsg{File: file, Err: &os.PathError{Op: "open", Path: file, Err: os.ErrNotExist}})
+	assert.Empty(t, m.Notice)
+	HandleSessionTakeDone(m, SessionTakeDoneMsg{File: file, Err: assert.AnError})
+	assert.Contains(t, m.Notice, filepath.Base(file))
+
+	// A take still recording on exit is padded then
+	m.SessionRecording = true
+	m.SessionRecordingFile = filepath.Join(m.SaveFolder, "take.wav")
+	assert.NoError(t, os.WriteFile(m.SessionRecordingFile, []byte("RIFF\x04\x00\x00\x00WAVE"), 0644))
+	m.Notice = ""
+	FinishSessionTake(m)
+	assert.False(t, m.SessionRecording)
+	assert.Contains(t, m.Notice, "take.wav", "A take without audio cannot be padded")
+}
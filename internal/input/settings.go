@@ -1,11 +1,32 @@
 package input
 
 import (
+	"slices"
+
+	"github.com/schollz/collidertracker/internal/i18n"
+	"github.com/schollz/collidertracker/internal/logging"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/storage"
 	"github.com/schollz/collidertracker/internal/types"
 )
 
+// settingsColumnCount is the number of columns in the settings view (Global, Input, Reverb, App)
+const settingsColumnCount = 4
+
+// settingsColumnMaxRow returns the last row index of a settings view column
+func settingsColumnMaxRow(col int) int {
+	switch col {
+	case 0:
+		return int(types.GlobalSettingsRowLock) // Global column: BPM(0) to lock(17)
+	case 1:
+		return int(types.InputSettingsRowBeatPulse) // Input column: InputLevelDB(0) to beat pulse(8)
+	case 2:
+		return int(types.ReverbSettingsRowImpulse) // Reverb column: Algorithm(0) to Impulse(5)
+	default:
+		return int(types.AppSettingsRowLog) // App column: Confirm(0) to log(23)
+	}
+}
+
 func ModifySettingsValue(m *model.Model, delta float32) {
 	if m.CurrentCol == 0 {
 		// Global column settings
@@ -102,20 +123,72 @@ func ModifySettingsValue(m *model.Model, delta float32) {
 				0, 300, "ShimmerPercent",
 			)
 			modifyValueWithBounds(modifier, delta)
+
+		case types.GlobalSettingsRowFadeMS: // FadeMS
+			modifier := createIntModifier(
+				func() int { return m.FadeMS },
+				func(v int) {
+					m.FadeMS = v
+					m.SendOSCFadeMessage() // Send OSC message for fade time change
+				},
+				1, model.MaxFadeMS, "FadeMS",
+			)
+			modifyValueWithBounds(modifier, delta)
+
+		case types.GlobalSettingsRowSeed: // Seed
+			// Fine steps walk through seeds; coarse steps re-roll a new one
+			if delta >= 1 || delta <= -1 {
+				m.RerollRandomSeed()
+			} else if delta > 0 {
+				m.SetRandomSeed(m.RandomSeed + 1)
+			} else if delta < 0 {
+				m.SetRandomSeed(m.RandomSeed - 1)
+			}
+
+		case types.GlobalSettingsRowJumpCrossfade: // JumpCrossfade
+			modifier := createIntModifier(
+				func() int { return m.JumpCrossfade },
+				func(v int) { m.JumpCrossfade = v },
+				0, model.MaxJumpCrossfade, "JumpCrossfade",
+			)
+			modifyValueWithBounds(modifier, delta)
+
+		case types.GlobalSettingsRowBanks: // Banks
+			SetTrackBanks(m, delta > 0)
+
+		case types.GlobalSettingsRowCountdown: // StartCountdown
+			m.StartCountdown = stepChoice(types.StartCountdowns, m.StartCountdown, delta)
+			logging.UI.Debugf("Timed start countdown: %d s", m.StartCountdown)
+
+		case types.GlobalSettingsRowPreRoll: // PreRoll
+			m.PreRoll = stepChoice(types.PreRolls, m.PreRoll, delta)
+			logging.UI.Debugf("Timed start pre-roll: %d beats", m.PreRoll)
+
+		case types.GlobalSettingsRowSongMode: // GenerativeSong
+			if m.GenerativeSong != (delta > 0) {
+				m.ToggleGenerativeSong()
+			}
+
+		case types.GlobalSettingsRowGroove: // Groove
+			if delta > 0 && m.Groove < len(model.GrooveTemplates)-1 {
+				m.Groove++
+			} else if delta < 0 && m.Groove > 0 {
+				m.Groove--
+			}
+			logging.Playback.Debugf("Groove: %s", model.GrooveName(m.Groove))
+
+		case types.GlobalSettingsRowLock: // Project password
+			if delta > 0 && !m.IsLocked() {
+				startPasswordEntry(m)
+			} else if delta < 0 && m.IsLocked() {
+				confirmRemovePassword(m)
+			}
 		}
 	} else if m.CurrentCol == 1 {
 		// Input column settings
 		switch types.InputSettingsRow(m.CurrentRow) {
 		case types.InputSettingsRowInputLevelDB: // InputLevelDB
-			modifier := createFloatModifier(
-				func() float32 { return m.InputLevelDB },
-				func(v float32) {
-					m.InputLevelDB = v
-					m.SendOSCInputLevelMessage() // Send OSC message for input level change
-				},
-				-48, 24, "InputLevelDB",
-			)
-			modifyValueWithBounds(modifier, delta)
+			modifyInputLevel(m, delta)
 
 		case types.InputSettingsRowReverbSendPercent: // ReverbSendPercent
 			modifier := createFloatModifier(
@@ -127,7 +200,301 @@ func ModifySettingsValue(m *model.Model, delta float32) {
 				0, 100, "ReverbSendPercent",
 			)
 			modifyValueWithBounds(modifier, delta)
+
+		case types.InputSettingsRowMonitor: // Monitor
+			m.InputMonitor = !m.InputMonitor
+			m.SendOSCInputMonitorMessage()
+			logging.UI.Debugf("Input monitor: %v", m.InputMonitor)
+
+		case types.InputSettingsRowInsert: // Insert
+			if delta > 0 && m.InputInsert < len(types.InputInsertNames)-1 {
+				m.InputInsert++
+			} else if delta < 0 && m.InputInsert > 0 {
+				m.InputInsert--
+			}
+			m.SendOSCInputInsertMessage()
+			logging.UI.Debugf("Input insert: %s", types.GetInputInsertName(m.InputInsert))
+
+		case types.InputSettingsRowMidiSync: // MIDI sync mode
+			if delta > 0 && m.MidiSync.Mode < len(types.MidiSyncModeNames)-1 {
+				m.MidiSync.Mode++
+			} else if delta < 0 && m.MidiSync.Mode > 0 {
+				m.MidiSync.Mode--
+			}
+			logging.MIDI.Debugf("MIDI sync: %s", types.GetMidiSyncModeName(m.MidiSync.Mode))
+
+		case types.InputSettingsRowMidiSyncDevice: // MIDI sync device
+			// Steps through "None" and the available MIDI devices, without wrapping
+			devices := append([]string{"None"}, m.AvailableMidiDevices...)
+			current := 0
+			for i, device := range devices {
+				if device == m.MidiSync.Device {
+					current = i
+				}
+			}
+			if delta > 0 && current < len(devices)-1 {
+				current++
+			} else if delta < 0 && current > 0 {
+				current--
+			}
+			m.MidiSync.Device = devices[current]
+			logging.MIDI.Debugf("MIDI sync device: %s", m.MidiSync.Device)
+
+		case types.InputSettingsRowMidiSyncChannel: // MIDI sync channel
+			modifier := createIntModifier(
+				func() int { return m.MidiSync.Channel },
+				func(v int) { m.MidiSync.Channel = v },
+				1, 16, "MidiSyncChannel",
+			)
+			modifyValueWithBounds(modifier, delta)
+
+		case types.InputSettingsRowBeatFlash: // Beat flash
+			if delta > 0 && m.BeatFlash < len(types.BeatFlashNames)-1 {
+				m.BeatFlash++
+			} else if delta < 0 && m.BeatFlash > 0 {
+				m.BeatFlash--
+			}
+			logging.UI.Debugf("Beat flash: %s", types.GetBeatFlashName(m.BeatFlash))
+
+		case types.InputSettingsRowBeatPulse: // OSC engine pulsed with the beat flash
+			// Steps through none and the configured OSC engines, without wrapping
+			engines := append([]string{""}, m.EngineNames()...)
+			current := 0
+			for i, engine := range engines {
+				if engine == m.BeatPulse {
+					current = i
+				}
+			}
+			if delta > 0 && current < len(engines)-1 {
+				current++
+			} else if delta < 0 && current > 0 {
+				current--
+			}
+			m.BeatPulse = engines[current]
+			logging.OSC.Debugf("Beat pulse: %q", m.BeatPulse)
 		}
+	} else if m.CurrentCol == 2 {
+		// Reverb column settings
+		switch types.ReverbSettingsRow(m.CurrentRow) {
+		case types.ReverbSettingsRowAlgorithm: // Algorithm
+			if delta > 0 && m.Reverb.Algorithm < len(types.ReverbAlgorithmNames)-1 {
+				m.Reverb.Algorithm++
+			} else if delta < 0 && m.Reverb.Algorithm > 0 {
+				m.Reverb.Algorithm--
+			}
+			m.SendOSCReverbSettingsMessage()
+			logging.UI.Debugf("Reverb algorithm: %s", types.GetReverbAlgorithmName(m.Reverb.Algorithm))
+
+		case types.ReverbSettingsRowSize: // Size
+			modifier := createIntModifier(
+				func() int { return m.Reverb.Size },
+				func(v int) {
+					m.Reverb.Size = v
+					m.SendOSCReverbSettingsMessage()
+				},
+				0, 100, "ReverbSize",
+			)
+			modifyValueWithBounds(modifier, delta)
+
+		case types.ReverbSettingsRowDamp: // Damp
+			modifier := createIntModifier(
+				func() int { return m.Reverb.Damp },
+				func(v int) {
+					m.Reverb.Damp = v
+					m.SendOSCReverbSettingsMessage()
+				},
+				0, 100, "ReverbDamp",
+			)
+			modifyValueWithBounds(modifier, delta)
+
+		case types.ReverbSettingsRowPreDelay: // PreDelay
+			modifier := createIntModifier(
+				func() int { return m.Reverb.PreDelayMS },
+				func(v int) {
+					m.Reverb.PreDelayMS = v
+					m.SendOSCReverbSettingsMessage()
+				},
+				0, 300, "ReverbPreDelayMS",
+			)
+			modifyValueWithBounds(modifier, delta)
+
+		case types.ReverbSettingsRowShimmerVoicing: // ShimmerVoicing
+			if delta > 0 && m.Reverb.ShimmerVoicing < len(types.ShimmerVoicingNames)-1 {
+				m.Reverb.ShimmerVoicing++
+			} else if delta < 0 && m.Reverb.ShimmerVoicing > 0 {
+				m.Reverb.ShimmerVoicing--
+			}
+			m.SendOSCReverbSettingsMessage()
+			logging.UI.Debugf("Shimmer voicing: %s", types.GetShimmerVoicingName(m.Reverb.ShimmerVoicing))
+
+		case types.ReverbSettingsRowImpulse: // Impulse
+			m.CycleReverbImpulse(delta)
+			m.SendOSCReverbImpulseMessage()
+			logging.UI.Debugf("Reverb impulse: %s", model.ReverbImpulseName(m.ReverbImpulse))
+		}
+	} else if m.CurrentCol == 3 {
+		// App column settings
+		switch types.AppSettingsRow(m.CurrentRow) {
+		case types.AppSettingsRowConfirmDeletes: // ConfirmDeletes
+			m.ConfirmDeletes = !m.ConfirmDeletes
+			logging.UI.Debugf("Confirm deletes: %v", m.ConfirmDeletes)
+		case types.AppSettingsRowBounceRepeats: // BounceRepeats
+			if delta > 0 && m.BounceRepeats < model.MaxBounceRepeats {
+				m.BounceRepeats++
+			} else if delta < 0 && m.BounceRepeats > 1 {
+				m.BounceRepeats--
+			}
+			logging.UI.Debugf("Bounce repeats: %d", m.BounceRepeats)
+		case types.AppSettingsRowSplash: // Splash
+			if delta > 0 && m.SplashMode < len(types.SplashModeNames)-1 {
+				m.SplashMode++
+			} else if delta < 0 && m.SplashMode > 0 {
+				m.SplashMode--
+			}
+			logging.UI.Debugf("Splash screen: %s", types.GetSplashModeName(m.SplashMode))
+		case types.AppSettingsRowAutosave: // Autosave
+			m.Autosave = !m.Autosave
+			if !m.Autosave {
+				storage.CancelAutoSave()
+			}
+			logging.UI.Debugf("Saving: %s", m.AutosaveModeName())
+		case types.AppSettingsRowAutosaveDelay: // AutosaveDelayMS
+			step := 250 // Fine steps a quarter second, coarse steps a second
+			if delta >= 1 || delta <= -1 {
+				step = 1000
+			}
+			if delta < 0 {
+				step = -step
+			}
+			m.AutosaveDelayMS = clampInt(m.AutosaveDelayMS+step, model.MinAutosaveDelayMS, model.MaxAutosaveDelayMS)
+			logging.UI.Debugf("Autosave delay: %d ms", m.AutosaveDelayMS)
+		case types.AppSettingsRowAutosaveInterval: // AutosaveIntervalS
+			step := 5 // Fine steps 5 seconds, coarse steps a minute
+			if delta >= 1 || delta <= -1 {
+				step = 60
+			}
+			if delta < 0 {
+				step = -step
+			}
+			m.AutosaveIntervalS = clampInt(m.AutosaveIntervalS+step, 0, model.MaxAutosaveIntervalS)
+			logging.UI.Debugf("Autosave interval: %s", m.AutosaveIntervalName())
+		case types.AppSettingsRowPort: // Config.Port
+			step := 1 // Fine steps one port, coarse steps 100
+			if delta >= 1 || delta <= -1 {
+				step = 100
+			}
+			if delta < 0 {
+				step = -step
+			}
+			m.Config.Port = clampInt(m.Config.Port+step, model.MinOSCPort, model.MaxOSCPort)
+			logging.OSC.Debugf("OSC port: %d", m.Config.Port)
+		case types.AppSettingsRowRecord: // Config.Record
+			m.Config.Record = !m.Config.Record
+			logging.UI.Debugf("Record session on launch: %v", m.Config.Record)
+		case types.AppSettingsRowVim: // VimMode
+			m.ToggleVim()
+			logging.UI.Debugf("Vim mode: %v", m.VimMode)
+		case types.AppSettingsRowDump: // Config.Dump
+			m.ToggleDump()
+			logging.UI.Debugf("Terminal dump: %s", m.DumpName())
+		case types.AppSettingsRowSkipSC: // Config.SkipSC
+			m.Config.SkipSC = !m.Config.SkipSC
+			logging.OSC.Debugf("SuperCollider: %s", m.SCModeName())
+		case types.AppSettingsRowNudge: // NudgeFine with fine steps, NudgeCoarse with coarse steps
+			step := 1
+			if delta < 0 {
+				step = -1
+			}
+			if delta >= 1 || delta <= -1 {
+				m.NudgeCoarse = clampInt(m.NudgeCoarse+step, 2, model.MaxNudgeCoarse)
+			} else {
+				m.NudgeFine = clampInt(m.NudgeFine+step, 1, model.MaxNudgeFine)
+			}
+			logging.UI.Debugf("Nudge steps: fine %d, coarse %d", m.NudgeFine, m.NudgeCoarse)
+		case types.AppSettingsRowImport: // ImportMode
+			if delta > 0 && m.ImportMode < len(types.ImportModeNames)-1 {
+				m.ImportMode++
+			} else if delta < 0 && m.ImportMode > 0 {
+				m.ImportMode--
+			}
+			logging.UI.Debugf("Sample import: %s", types.GetImportModeName(m.ImportMode))
+		case types.AppSettingsRowFrameRate: // UIFrameRate, faster to the left as in types.FrameRates
+			i := slices.Index(types.FrameRates, m.UIFrameRate)
+			if delta > 0 && i > 0 {
+				i--
+			} else if delta < 0 && i < len(types.FrameRates)-1 {
+				i++
+			}
+			m.UIFrameRate = types.FrameRates[max(i, 0)]
+			logging.UI.Debugf("UI frame rate: %d fps", m.UIFrameRate)
+		case types.AppSettingsRowWaveform: // WaveformDetail
+			if delta > 0 && m.WaveformDetail < len(types.WaveformDetailNames)-1 {
+				m.WaveformDetail++
+			} else if delta < 0 && m.WaveformDetail > 0 {
+				m.WaveformDetail--
+			}
+			logging.UI.Debugf("Header waveform: %s", types.GetWaveformDetailName(m.WaveformDetail))
+		case types.AppSettingsRowAnimations: // Animations
+			m.Animations = !m.Animations
+			logging.UI.Debugf("Animations: %v", m.Animations)
+		case types.AppSettingsRowPalette: // Palette
+			if delta > 0 && m.Palette < len(types.PaletteNames)-1 {
+				m.Palette++
+			} else if delta < 0 && m.Palette > 0 {
+				m.Palette--
+			}
+			logging.UI.Debugf("Palette: %s", types.GetPaletteName(m.Palette))
+		case types.AppSettingsRowLocale: // Config.Locale, "" first to follow the environment
+			locales := append([]string{""}, i18n.Locales()...)
+			i := slices.Index(locales, m.Config.Locale)
+			if delta > 0 && i < len(locales)-1 {
+				i++
+			} else if delta < 0 && i > 0 {
+				i--
+			}
+			m.Config.Locale = locales[max(i, 0)]
+			logging.UI.Debugf("Locale: %q", m.Config.Locale)
+		case types.AppSettingsRowUpdates: // Config.CheckUpdates
+			m.Config.CheckUpdates = !m.Config.CheckUpdates
+			logging.UI.Debugf("Check for updates at launch: %v", m.Config.CheckUpdates)
+		case types.AppSettingsRowResume: // ResumePlayback
+			m.ResumePlayback = !m.ResumePlayback
+			logging.UI.Debugf("Resume playback: %v", m.ResumePlayback)
+		case types.AppSettingsRowAudition: // AuditionEdits
+			m.AuditionEdits = !m.AuditionEdits
+			logging.UI.Debugf("Audition edits: %v", m.AuditionEdits)
+		case types.AppSettingsRowNormalize: // BounceNormalize
+			if delta > 0 && m.BounceNormalize < len(model.LoudnessTargets)-1 {
+				m.BounceNormalize++
+			} else if delta < 0 && m.BounceNormalize > 0 {
+				m.BounceNormalize--
+			}
+			logging.UI.Debugf("Bounce loudness target: %s", m.BounceTargetName())
+		case types.AppSettingsRowPreRoll: // SessionPreRoll
+			m.SessionPreRoll = stepChoice(model.PreRollChoices, m.SessionPreRoll, delta)
+			logging.UI.Debugf("Session pre-roll: %s", m.PreRollName())
+		case types.AppSettingsRowLog: // Config.LogLevel, the level of the subsystems it does not name
+			i := slices.Index(logging.LevelNames, logging.BaseLevel(m.Config.LogLevel))
+			if delta < 0 && i > 0 {
+				i--
+			} else if delta > 0 && i < len(logging.LevelNames)-1 {
+				i++
+			}
+			m.Config.LogLevel = logging.WithBaseLevel(m.Config.LogLevel, logging.LevelNames[max(i, 0)])
+			logging.UI.Debugf("Log levels: %s", m.Config.LogLevel)
+		}
+	}
+	m.Publish(model.Event{Kind: model.EventSettings})
+}
+
+// stepChoice moves a setting to the next (delta > 0) or previous choice, staying at the ends.
+// A value that is not a choice moves to the first.
+func stepChoice(choices []int, value int, delta float32) int {
+	i := slices.Index(choices, value)
+	if i >= 0 && delta > 0 && i < len(choices)-1 {
+		i++
+	} else if i > 0 && delta < 0 {
+		i--
 	}
-	storage.AutoSave(m)
+	return choices[max(i, 0)]
 }
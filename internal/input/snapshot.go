@@ -0,0 +1,44 @@
+package input
+
+import (
+	"fmt"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/storage"
+)
+
+// snapshotBefore writes a project snapshot before a bulk operation, so it can be reverted
+func snapshotBefore(m *model.Model, operation string) {
+	if _, err := storage.Snapshot(m, operation); err != nil {
+		logging.Storage.Errorf("Error writing snapshot before %s: %v", operation, err)
+	}
+}
+
+// RevertLastOperation asks to restore the project from the snapshot taken before the last
+// bulk operation
+func RevertLastOperation(m *model.Model) {
+	path, ok := storage.LatestSnapshot(m)
+	if !ok {
+		m.Notice = "No operation to revert"
+		return
+	}
+	if m.IsPlaying {
+		m.Notice = "Stop playback to revert"
+		return
+	}
+	if m.Analysis != nil {
+		m.Notice = "Cancel the sample analysis (A) to revert"
+		return
+	}
+	operation := storage.SnapshotOperation(path)
+	confirmDestructive(m, fmt.Sprintf("Revert the project to before %s?", operation), func() {
+		if _, err := storage.RevertSnapshot(m); err != nil {
+			logging.Storage.Errorf("Error reverting %s: %v", operation, err)
+			m.Notice = "Could not revert " + operation
+			return
+		}
+		m.ClearTrash() // Trashed items refer to the project as it was before the revert
+		m.Notice = "Reverted " + operation
+	})
+}
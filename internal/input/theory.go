@@ -0,0 +1,14 @@
+package input
+
+import (
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// toggleTheoryHelper shows or hides the chord and scale helper, scrolling the phrase so the
+// cursor row stays on screen above it
+func toggleTheoryHelper(m *model.Model) {
+	m.ToggleTheoryHelper()
+	if visibleRows := m.GetVisibleRows(); m.CurrentRow >= m.ScrollOffset+visibleRows {
+		m.ScrollOffset = m.CurrentRow - visibleRows + 1
+	}
+}
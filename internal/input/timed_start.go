@@ -0,0 +1,83 @@
+package input
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// TimedStartMsg falls on a count-in beat (Beat counting down to 1) or on the start (Beat 0) of
+// the timed start armed for At
+type TimedStartMsg struct {
+	At   time.Time
+	Beat int
+}
+
+// toggleTimedStart arms playback to start from the top of the song after the countdown, or
+// cancels the armed start
+func toggleTimedStart(m *model.Model) tea.Cmd {
+	if m.TimedStartArmed() {
+		m.DisarmTimedStart()
+		m.Notice = "Timed start cancelled"
+		return nil
+	}
+	if m.IsPlaying {
+		m.Notice = "Stop playback to arm a timed start"
+		return nil
+	}
+	m.ArmTimedStart(time.Now().Add(time.Duration(m.StartCountdown) * time.Second))
+	return ScheduleTimedStart(m, time.Now())
+}
+
+// ScheduleTimedStart schedules the first count-in beat of an armed timed start, or the start
+// itself without a count-in. Beats already past are skipped.
+func ScheduleTimedStart(m *model.Model, now time.Time) tea.Cmd {
+	if !m.TimedStartArmed() {
+		return nil
+	}
+	return scheduleTimedStartFrom(m, now, m.PreRoll)
+}
+
+// scheduleTimedStartFrom schedules the next count-in beat from beat down, or the start
+func scheduleTimedStartFrom(m *model.Model, now time.Time, beat int) tea.Cmd {
+	armed := m.TimedStart
+	for ; beat >= 1; beat-- {
+		if at := m.PreRollBeatAt(beat); !at.Before(now) {
+			break
+		}
+	}
+	at := m.PreRollBeatAt(beat)
+	return tea.Tick(max(0, at.Sub(now)), func(time.Time) tea.Msg {
+		return TimedStartMsg{At: armed, Beat: beat}
+	})
+}
+
+// HandleTimedStart clicks a count-in beat and schedules the next, or starts the song. Messages
+// of a cancelled or re-armed start are ignored.
+func HandleTimedStart(m *model.Model, msg TimedStartMsg) tea.Cmd {
+	if !m.TimedStartArmed() || !msg.At.Equal(m.TimedStart) {
+		return nil
+	}
+	if msg.Beat > 0 {
+		m.TimedStartClick(msg.Beat)
+		return scheduleTimedStartFrom(m, time.Now(), msg.Beat-1)
+	}
+	m.DisarmTimedStart()
+	if m.IsPlaying {
+		logging.Playback.Debugf("Timed start skipped: already playing")
+		return nil
+	}
+	logging.Playback.Debugf("Timed start: %s late", time.Since(msg.At))
+	m.Notice = fmt.Sprintf("Timed start at %s", msg.At.Format("15:04:05"))
+	return startPlaybackWithConfig(m, PlaybackConfig{
+		Mode:   types.SongView,
+		Chain:  -1,
+		Phrase: -1,
+		Row:    0,
+	})
+}
@@ -0,0 +1,78 @@
+package input
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// toggleTimelineView opens the timeline, selecting the song cell under the cursor when
+// opened from the song view, or closes it
+func toggleTimelineView(m *model.Model) {
+	if m.ViewMode == types.SongView && m.CurrentRow >= 0 && m.CurrentCol >= 0 && m.CurrentCol < types.NumTracks {
+		m.TimelineTrack, m.TimelineRow = m.CurrentCol, m.CurrentRow
+	}
+	toggleAuxView(m, types.TimelineView)
+}
+
+// handleTimelineInput handles keys in the timeline view
+func handleTimelineInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+q", "alt+q":
+		return requestQuit(m)
+	case "esc", "q", "ctrl+a", "alt+a":
+		toggleTimelineView(m)
+	case "left", "h":
+		moveTimelineBlock(m, -1)
+	case "right", "l":
+		moveTimelineBlock(m, 1)
+	case "up", "k":
+		moveTimelineLane(m, -1)
+	case "down", "j":
+		moveTimelineLane(m, 1)
+	case "enter":
+		// Jump to the selected song cell
+		if m.TimelineTrack >= 0 && m.TimelineTrack < types.NumTracks && m.GetSongCell(m.TimelineTrack, m.TimelineRow) != -1 {
+			switchToViewWithVisibilityCheck(m, songViewConfig(m.TimelineRow, m.TimelineTrack))
+		}
+	}
+	return nil
+}
+
+// moveTimelineBlock selects the previous (delta < 0) or next block in the selected lane
+func moveTimelineBlock(m *model.Model, delta int) {
+	if m.TimelineTrack < 0 || m.TimelineTrack >= types.NumTracks {
+		m.TimelineTrack = 0
+	}
+	lane := m.Timeline()[m.TimelineTrack]
+	if len(lane) == 0 {
+		return
+	}
+	index := model.TimelineBlockIndex(lane, m.TimelineRow)
+	if index == -1 {
+		index = 0
+	} else {
+		index = max(0, min(len(lane)-1, index+delta))
+	}
+	m.TimelineRow = lane[index].Row
+}
+
+// moveTimelineLane moves to the next lane with blocks above (delta < 0) or below, selecting
+// the block playing when the selected block starts
+func moveTimelineLane(m *model.Model, delta int) {
+	lanes := m.Timeline()
+	start := 0.0
+	if m.TimelineTrack >= 0 && m.TimelineTrack < types.NumTracks {
+		if index := model.TimelineBlockIndex(lanes[m.TimelineTrack], m.TimelineRow); index != -1 {
+			start = lanes[m.TimelineTrack][index].Start
+		}
+	}
+	for track := m.TimelineTrack + delta; track >= 0 && track < types.NumTracks; track += delta {
+		if index := model.TimelineBlockAt(lanes[track], start); index != -1 {
+			m.TimelineTrack = track
+			m.TimelineRow = lanes[track][index].Row
+			return
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package input
+
+import (
+	"fmt"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// DuplicateTrack copies a track into the next track with an empty song column. The copy gets
+// its own chains and phrases (so it can be edited as a variation without touching the
+// original), the same track type, set level and resolution. It returns the new track, or -1
+// when no track is empty or the chain and phrase pool has too few free slots.
+func DuplicateTrack(m *model.Model, track int) int {
+	if track < 0 || track >= types.NumTracks {
+		return -1
+	}
+	dest := -1
+	for offset := 1; offset < types.NumTracks && dest == -1; offset++ {
+		if candidate := (track + offset) % types.NumTracks; songColumnEmpty(m, candidate) {
+			dest = candidate
+		}
+	}
+	if dest == -1 {
+		m.Notice = "No empty track to duplicate into"
+		return -1
+	}
+
+	if !duplicateFits(m, track, dest) {
+		m.Notice = fmt.Sprintf("Not enough free chains or phrases to duplicate track %d", track+1)
+		return -1
+	}
+	snapshotBefore(m, "track duplicate")
+
+	// The unused searches follow the current track's pool and bank, so the copy's new chains
+	// and phrases come from the destination's bank
+	currentTrack := m.CurrentTrack
+	m.CurrentTrack = dest
+	defer func() { m.CurrentTrack = currentTrack }()
+
+	m.TrackTypes[dest] = m.TrackTypes[track]
+	m.TrackEngines[dest] = m.TrackEngines[track]
+	m.TrackSetLevels[dest] = m.TrackSetLevels[track]
+	m.TrackResolutions[dest] = m.TrackResolutions[track]
+	m.HumanizeTiming[dest] = m.HumanizeTiming[track]
+	m.HumanizeVelocity[dest] = m.HumanizeVelocity[track]
+	m.SendOSCTrackSetLevelMessage(dest)
+
+	chainMap := make(map[int]int)
+	phraseMap := make(map[int]int)
+	for row := 0; row < types.SongRows; row++ {
+		chain := m.GetSongCell(track, row)
+		if chain < 0 {
+			continue
+		}
+		if newChain, ok := chainMap[chain]; ok {
+			m.SetSongCell(dest, row, newChain)
+			continue
+		}
+		newChain := FindNextUnusedChain(m, chain)
+		chainMap[chain] = newChain
+		m.SetSongCell(dest, row, newChain) // Claims the chain before the next search
+		for slot := 0; slot < types.ChainRows; slot++ {
+			m.SetChainTranspose(track, newChain, slot, m.GetChainTranspose(track, chain, slot))
+			m.SetChainFX(track, newChain, slot, m.GetChainFX(track, chain, slot))
+			phrase := m.GetChainCell(track, chain, slot)
+			if phrase < 0 {
+				continue
+			}
+			newPhrase, copied := phraseMap[phrase]
+			if !copied {
+				newPhrase = FindNextUnusedPhrase(m, phrase)
+				phraseMap[phrase] = newPhrase
+			}
+			m.SetChainCell(track, newChain, slot, newPhrase) // Claims the phrase before the next search
+			if !copied {
+				copyPhrase(m, track, phrase, newPhrase)
+			}
+		}
+	}
+
+	logging.UI.Debugf("Duplicated track %d to track %d (%d chains, %d phrases)", track+1, dest+1, len(chainMap), len(phraseMap))
+	m.Notice = fmt.Sprintf("Track %d duplicated to track %d", track+1, dest+1)
+	m.Publish(model.Event{Kind: model.EventSettings})
+	return dest
+}
+
+// duplicateFits reports whether dest's bank has enough free chains and phrases for a copy of track
+func duplicateFits(m *model.Model, track, dest int) bool {
+	currentTrack, destType := m.CurrentTrack, m.TrackTypes[dest]
+	m.CurrentTrack, m.TrackTypes[dest] = dest, m.TrackTypes[track]
+	defer func() { m.CurrentTrack, m.TrackTypes[dest] = currentTrack, destType }()
+
+	chains, phrases := trackChainsAndPhrases(m, track)
+	return countUnused(IsChainUnused, m) >= len(chains) && countUnused(IsPhraseUnused, m) >= len(phrases)
+}
+
+// songColumnEmpty reports whether a track has no chains in the song
+func songColumnEmpty(m *model.Model, track int) bool {
+	for row := 0; row < types.SongRows; row++ {
+		if m.GetSongCell(track, row) != -1 {
+			return false
+		}
+	}
+	return true
+}
+
+// trackChainsAndPhrases returns the chains a track's song column uses and the phrases in them
+func trackChainsAndPhrases(m *model.Model, track int) (map[int]bool, map[int]bool) {
+	chains := make(map[int]bool)
+	phrases := make(map[int]bool)
+	for row := 0; row < types.SongRows; row++ {
+		chain := m.GetSongCell(track, row)
+		if chain < 0 || chains[chain] {
+			continue
+		}
+		chains[chain] = true
+		for slot := 0; slot < types.ChainRows; slot++ {
+			if phrase := m.GetChainCell(track, chain, slot); phrase >= 0 {
+				phrases[phrase] = true
+			}
+		}
+	}
+	return chains, phrases
+}
+
+// countUnused counts the chains or phrases of the current track's bank an unused check accepts
+func countUnused(isUnused func(*model.Model, int) bool, m *model.Model) int {
+	count := 0
+	first, last := m.BankRange(m.CurrentTrack)
+	for id := first; id <= last; id++ {
+		if isUnused(m, id) {
+			count++
+		}
+	}
+	return count
+}
+
+// copyPhrase copies every row of a phrase (and the parameter locks of instrument phrases)
+func copyPhrase(m *model.Model, track, source, dest int) {
+	for row := 0; row < types.PhraseRows; row++ {
+		for col := 0; col < int(types.ColCount); col++ {
+			m.SetPhraseCell(track, dest, row, types.PhraseColumn(col), m.GetPhraseCell(track, source, row, types.PhraseColumn(col)))
+		}
+		if !m.TrackTypes[track] {
+			for key, value := range m.RowPLocks(source, row) {
+				m.SetPLock(dest, row, key, value)
+			}
+		}
+	}
+}
@@ -0,0 +1,520 @@
+package input
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/storage"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// kitPool is a settings pool the phrases of a track kit point into through a column
+type kitPool interface {
+	column() types.PhraseColumn
+	collect(slots []int)                         // Copies slots of the project's pool into the kit
+	plan(taken map[int]bool) (map[int]int, bool) // Picks a project slot for each slot of the kit
+	apply(slots map[int]int)                     // Writes the kit's settings into their project slots
+}
+
+// settingsPool is a kitPool of one type of settings
+type settingsPool[S any] struct {
+	col  types.PhraseColumn
+	pool *[types.NumSettings]S
+	kit  *map[int]S
+}
+
+func (p settingsPool[S]) column() types.PhraseColumn { return p.col }
+
+func (p settingsPool[S]) collect(slots []int) {
+	*p.kit = nil
+	for _, slot := range slots {
+		if *p.kit == nil {
+			*p.kit = make(map[int]S)
+		}
+		(*p.kit)[slot] = p.pool[slot]
+	}
+}
+
+// plan keeps a kit slot where the project has the same settings, else puts it in the same
+// slot or the first one no phrase of the project points to. taken holds the slots phrases
+// point to and gets the slots the plan uses.
+func (p settingsPool[S]) plan(taken map[int]bool) (map[int]int, bool) {
+	slots := make(map[int]int)
+	written := make(map[int]bool)
+	for _, slot := range sortedSlots(*p.kit) {
+		settings := (*p.kit)[slot]
+		target := -1
+		if reflect.DeepEqual(p.pool[slot], settings) && !written[slot] {
+			target = slot
+		}
+		for i := 0; i < len(p.pool) && target == -1; i++ {
+			if !written[i] && reflect.DeepEqual(p.pool[i], settings) {
+				target = i
+			}
+		}
+		if target == -1 {
+			if !taken[slot] {
+				target = slot
+			}
+			for i := 0; i < len(p.pool) && target == -1; i++ {
+				if !taken[i] {
+					target = i
+				}
+			}
+			if target == -1 {
+				return nil, false
+			}
+			written[target] = true
+		}
+		taken[target] = true
+		slots[slot] = target
+	}
+	return slots, true
+}
+
+func (p settingsPool[S]) apply(slots map[int]int) {
+	for slot, target := range slots {
+		p.pool[target] = (*p.kit)[slot]
+	}
+}
+
+// sortedSlots returns the slots of a kit's settings in order
+func sortedSlots[S any](settings map[int]S) []int {
+	slots := make([]int, 0, len(settings))
+	for slot := range settings {
+		slots = append(slots, slot)
+	}
+	slices.Sort(slots)
+	return slots
+}
+
+// kitPools returns the settings pools a kit of a sampler or instrument track points into
+func kitPools(m *model.Model, kit *types.TrackKit) []kitPool {
+	modulate := &m.InstrumentModulateSettings
+	if kit.Sampler {
+		modulate = &m.SamplerModulateSettings
+	}
+	return []kitPool{
+		settingsPool[types.RetriggerSettings]{types.ColRetrigger, &m.RetriggerSettings, &kit.Retriggers},
+		settingsPool[types.TimestrechSettings]{types.ColTimestretch, &m.TimestrechSettings, &kit.Timestretches},
+		settingsPool[types.ModulateSettings]{types.ColModulate, modulate, &kit.Modulates},
+		settingsPool[types.ArpeggioSettings]{types.ColArpeggio, &m.ArpeggioSettings, &kit.Arpeggios},
+		settingsPool[types.MidiSettings]{types.ColMidi, &m.MidiSettings, &kit.Midis},
+		settingsPool[types.SoundMakerSettings]{types.ColSoundMaker, &m.SoundMakerSettings, &kit.SoundMakers},
+		settingsPool[types.DuckingSettings]{types.ColEffectDucking, &m.DuckingSettings, &kit.Duckings},
+	}
+}
+
+// kitSlots returns the slots the phrases of a kit point to through a column, in order
+func kitSlots(kit *types.TrackKit, col types.PhraseColumn) []int {
+	var slots []int
+	for _, phrase := range kit.Phrases {
+		for _, row := range phrase.Rows {
+			if int(col) < len(row) && row[col] >= 0 && row[col] < types.NumSettings && !slices.Contains(slots, row[col]) {
+				slots = append(slots, row[col])
+			}
+		}
+	}
+	slices.Sort(slots)
+	return slots
+}
+
+// usedSlots returns the slots the phrases of the project point to through a column. The
+// modulate pools are separate for sampler and instrument tracks; the other pools are shared.
+func usedSlots(m *model.Model, col types.PhraseColumn, sampler bool) map[int]bool {
+	pools := []*[types.NumPhrases][][]int{&m.SamplerPhrasesData, &m.InstrumentPhrasesData}
+	if col == types.ColModulate && sampler {
+		pools = pools[:1]
+	} else if col == types.ColModulate {
+		pools = pools[1:]
+	}
+	used := make(map[int]bool)
+	for _, pool := range pools {
+		for _, rows := range pool {
+			for _, row := range rows {
+				if int(col) < len(row) && row[col] >= 0 {
+					used[row[col]] = true
+				}
+			}
+		}
+	}
+	return used
+}
+
+// trackKit packs a track into a kit and returns it with the sample files it bundles, by name
+func trackKit(m *model.Model, track int) (types.TrackKit, map[string]string) {
+	kit := types.TrackKit{
+		Name:             fmt.Sprintf("%s track %d", filepath.Base(m.SaveFolder), track+1),
+		Sampler:          m.TrackTypes[track],
+		Engine:           m.TrackEngines[track],
+		SetLevel:         m.TrackSetLevels[track],
+		Resolution:       m.TrackResolutions[track],
+		HumanizeTiming:   m.HumanizeTiming[track],
+		HumanizeVelocity: m.HumanizeVelocity[track],
+	}
+	chains := make(map[int]int)
+	phrases := make(map[int]int)
+	for row := range kit.Song {
+		kit.Song[row] = -1
+		chain := m.GetSongCell(track, row)
+		if chain < 0 {
+			continue
+		}
+		if id, ok := chains[chain]; ok {
+			kit.Song[row] = id
+			continue
+		}
+		kitChain := types.KitChain{Name: m.ChainName(track, chain)}
+		for slot := 0; slot < types.ChainRows; slot++ {
+			kitChain.Phrases[slot] = -1
+			kitChain.Transposes[slot] = m.GetChainTranspose(track, chain, slot)
+			kitChain.FX[slot] = m.GetChainFX(track, chain, slot)
+			phrase := m.GetChainCell(track, chain, slot)
+			if phrase < 0 {
+				continue
+			}
+			id, ok := phrases[phrase]
+			if !ok {
+				id = len(kit.Phrases)
+				phrases[phrase] = id
+				kit.Phrases = append(kit.Phrases, kitPhrase(m, track, phrase))
+			}
+			kitChain.Phrases[slot] = id
+		}
+		chains[chain] = len(kit.Chains)
+		kit.Song[row] = len(kit.Chains)
+		kit.Chains = append(kit.Chains, kitChain)
+	}
+
+	for _, pool := range kitPools(m, &kit) {
+		pool.collect(kitSlots(&kit, pool.column()))
+	}
+	return kit, kitSamples(m, &kit)
+}
+
+// kitPhrase copies a phrase of a track into a kit
+func kitPhrase(m *model.Model, track, phrase int) types.KitPhrase {
+	kp := types.KitPhrase{Name: m.PhraseName(track, phrase)}
+	for row := 0; row < types.PhraseRows; row++ {
+		kp.Rows = append(kp.Rows, m.GetPhraseRow(track, phrase, row))
+		if m.TrackTypes[track] {
+			continue
+		}
+		if plocks := m.RowPLocks(phrase, row); len(plocks) > 0 {
+			if kp.PLocks == nil {
+				kp.PLocks = make(map[int]map[string]float32)
+			}
+			kp.PLocks[row] = make(map[string]float32, len(plocks))
+			for key, value := range plocks {
+				kp.PLocks[row][key] = value
+			}
+		}
+	}
+	return kp
+}
+
+// kitSamples adds the sample files a sampler kit's phrases play to the kit, with the files
+// their kits play, and returns the files to bundle by name. Missing files are left out.
+func kitSamples(m *model.Model, kit *types.TrackKit) map[string]string {
+	if !kit.Sampler {
+		return nil
+	}
+	slots := kitSlots(kit, types.ColFilename)
+	for i := 0; i < len(slots); i++ {
+		if slots[i] >= len(m.SamplerPhrasesFiles) {
+			continue
+		}
+		for _, slot := range m.FileMetadata[m.SamplerPhrasesFiles[slots[i]]].Kit {
+			if slot >= 0 && !slices.Contains(slots, slot) {
+				slots = append(slots, slot)
+			}
+		}
+	}
+
+	samples := make(map[string]string)
+	for _, slot := range slots {
+		if slot >= len(m.SamplerPhrasesFiles) || m.SamplerPhrasesFiles[slot] == "" {
+			continue
+		}
+		path := m.SamplerPhrasesFiles[slot]
+		if _, err := os.Stat(path); err != nil {
+			logging.Storage.Debugf("Track kit: leaving out missing sample %s", path)
+			continue
+		}
+		name := filepath.Base(path)
+		if other, ok := samples[name]; ok && other != path {
+			name = fmt.Sprintf("%02X-%s", slot, name)
+		}
+		if kit.Files == nil {
+			kit.Files = make(map[int]string)
+			kit.FileMetadata = make(map[string]types.FileMetadata)
+		}
+		kit.Files[slot] = name
+		samples[name] = path
+		if metadata, ok := m.FileMetadata[path]; ok {
+			metadata.WaveformFile = "" // Made again for the copy
+			kit.FileMetadata[name] = metadata
+		}
+	}
+	return samples
+}
+
+// kitFileName returns the file a track is written to in the kit library
+func kitFileName(m *model.Model, track int) string {
+	project := model.PrintFileLabel(filepath.Base(m.SaveFolder))
+	return fmt.Sprintf("%s-track%d-%s%s", project, track+1, time.Now().Format("2006-01-02-15-04-05"), storage.KitExtension)
+}
+
+// ExportTrackKit writes a track, with the settings and samples its phrases use, to the kit
+// library and returns the kit's path ("" when nothing was written)
+func ExportTrackKit(m *model.Model, track int) string {
+	if track < 0 || track >= types.NumTracks {
+		return ""
+	}
+	if songColumnEmpty(m, track) {
+		m.Notice = fmt.Sprintf("Track %d has no chains to save as a kit", track+1)
+		return ""
+	}
+	folder := storage.KitsFolder()
+	if folder == "" {
+		m.Notice = "No config folder to keep kits in"
+		return ""
+	}
+	kit, samples := trackKit(m, track)
+	path := filepath.Join(folder, kitFileName(m, track))
+	if err := storage.WriteTrackKit(path, kit, samples); err != nil {
+		logging.Storage.Errorf("Error writing track kit %s: %v", path, err)
+		m.Notice = "Could not write the track kit"
+		return ""
+	}
+	logging.Storage.Debugf("Track %d saved as kit %s (%d chains, %d phrases, %d samples)", track+1, path, len(kit.Chains), len(kit.Phrases), len(samples))
+	m.Notice = fmt.Sprintf("Track %d saved as kit %s", track+1, strings.TrimSuffix(filepath.Base(path), storage.KitExtension))
+	return path
+}
+
+// kitFits reports whether track's bank has enough free chains and phrases for a kit, once the
+// track has the kit's type
+func kitFits(m *model.Model, kit *types.TrackKit, track int) bool {
+	currentTrack, trackType := m.CurrentTrack, m.TrackTypes[track]
+	m.CurrentTrack, m.TrackTypes[track] = track, kit.Sampler
+	defer func() { m.CurrentTrack, m.TrackTypes[track] = currentTrack, trackType }()
+	return countUnused(IsChainUnused, m) >= len(kit.Chains) && countUnused(IsPhraseUnused, m) >= len(kit.Phrases)
+}
+
+// ImportTrackKit puts the kit at path into an empty track. The kit's chains and phrases get
+// free slots of the track's bank, its settings are kept in slots holding the same settings or
+// put in free ones, and its samples are copied into the project. It reports whether the kit
+// was imported.
+func ImportTrackKit(m *model.Model, path string, track int) bool {
+	if track < 0 || track >= types.NumTracks {
+		return false
+	}
+	kit, err := storage.ReadTrackKit(path)
+	if err != nil {
+		logging.Storage.Errorf("Error reading track kit: %v", err)
+		m.Notice = "Could not read the track kit"
+		return false
+	}
+	if !songColumnEmpty(m, track) {
+		m.Notice = fmt.Sprintf("Track %d is not empty", track+1)
+		return false
+	}
+	if !kitFits(m, &kit, track) {
+		m.Notice = fmt.Sprintf("Not enough free chains or phrases for the kit on track %d", track+1)
+		return false
+	}
+	pools := kitPools(m, &kit)
+	plans := make([]map[int]int, len(pools))
+	for i, pool := range pools {
+		var ok bool
+		if plans[i], ok = pool.plan(usedSlots(m, pool.column(), kit.Sampler)); !ok {
+			m.Notice = "Not enough free settings for the kit"
+			return false
+		}
+	}
+	var samples map[string]string
+	if len(kit.Files) > 0 {
+		if samples, err = storage.UnpackKitSamples(path, m.ProjectFolder()); err != nil {
+			logging.Storage.Errorf("Error copying the samples of track kit %s: %v", path, err)
+			m.Notice = "Could not copy the kit's samples"
+			return false
+		}
+	}
+	snapshotBefore(m, "kit import")
+
+	for i, pool := range pools {
+		pool.apply(plans[i])
+	}
+	files := kitFiles(m, &kit, samples)
+	remap := map[types.PhraseColumn]map[int]int{types.ColFilename: files}
+	for i, pool := range pools {
+		remap[pool.column()] = plans[i]
+	}
+
+	// The unused searches follow the current track's pool and bank
+	currentTrack := m.CurrentTrack
+	m.CurrentTrack = track
+	defer func() { m.CurrentTrack = currentTrack }()
+
+	m.TrackTypes[track] = kit.Sampler
+	m.TrackEngines[track] = kit.Engine
+	m.TrackSetLevels[track] = kit.SetLevel
+	if model.ValidTrackResolution(kit.Resolution) {
+		m.SetTrackResolution(track, kit.Resolution)
+	}
+	m.HumanizeTiming[track] = max(0, min(model.MaxHumanizeTiming, kit.HumanizeTiming))
+	m.HumanizeVelocity[track] = max(0, min(model.MaxHumanizeVelocity, kit.HumanizeVelocity))
+	m.SendOSCTrackSetLevelMessage(track)
+
+	first, last := m.BankRange(track)
+	chains := make(map[int]int)
+	phrases := make(map[int]int)
+	nextChain, nextPhrase := last, last
+	for row, id := range kit.Song {
+		if id < 0 || id >= len(kit.Chains) {
+			continue
+		}
+		if chain, ok := chains[id]; ok {
+			m.SetSongCell(track, row, chain)
+			continue
+		}
+		chain := FindNextUnusedChain(m, nextChain)
+		chains[id], nextChain = chain, chain
+		m.SetSongCell(track, row, chain) // Claims the chain before the next search
+		kitChain := kit.Chains[id]
+		m.SetChainName(track, chain, kitChain.Name)
+		for slot := 0; slot < types.ChainRows; slot++ {
+			m.SetChainTranspose(track, chain, slot, kitChain.Transposes[slot])
+			m.SetChainFX(track, chain, slot, kitChain.FX[slot])
+			pid := kitChain.Phrases[slot]
+			if pid < 0 || pid >= len(kit.Phrases) {
+				continue
+			}
+			phrase, copied := phrases[pid]
+			if !copied {
+				phrase = FindNextUnusedPhrase(m, nextPhrase)
+				phrases[pid], nextPhrase = phrase, phrase
+			}
+			m.SetChainCell(track, chain, slot, phrase) // Claims the phrase before the next search
+			if !copied {
+				importKitPhrase(m, track, phrase, kit.Phrases[pid], remap)
+			}
+		}
+	}
+
+	logging.Storage.Debugf("Imported track kit %s into track %d (bank %02X-%02X, %d chains, %d phrases, %d samples)",
+		path, track+1, first, last, len(chains), len(phrases), len(files))
+	m.Notice = fmt.Sprintf("Kit %s imported into track %d", strings.TrimSuffix(filepath.Base(path), storage.KitExtension), track+1)
+	m.Publish(model.Event{Kind: model.EventSettings})
+	return true
+}
+
+// kitFiles gives each sample of a kit a sampler file slot of the project, with the kit's
+// metadata, and returns the slot of each of the kit's slots
+func kitFiles(m *model.Model, kit *types.TrackKit, samples map[string]string) map[int]int {
+	slots := make(map[int]int)
+	for _, slot := range sortedSlots(kit.Files) {
+		if path, ok := samples[kit.Files[slot]]; ok {
+			slots[slot] = m.SamplerFileSlot(path)
+		}
+	}
+	for _, slot := range sortedSlots(kit.Files) {
+		path, ok := samples[kit.Files[slot]]
+		metadata, known := kit.FileMetadata[kit.Files[slot]]
+		if !ok || !known {
+			continue
+		}
+		if _, exists := m.FileMetadata[path]; exists {
+			continue // A sample the project already had keeps its own settings
+		}
+		kitSlots := make([]int, 0, len(metadata.Kit))
+		for _, kitSlot := range metadata.Kit {
+			if target, ok := slots[kitSlot]; ok {
+				kitSlots = append(kitSlots, target)
+			}
+		}
+		metadata.Kit = kitSlots
+		m.FileMetadata[path] = metadata
+	}
+	return slots
+}
+
+// importKitPhrase writes a kit phrase into a phrase of track, pointing its settings and file
+// columns to where the kit's settings and samples went
+func importKitPhrase(m *model.Model, track, phrase int, kp types.KitPhrase, remap map[types.PhraseColumn]map[int]int) {
+	m.SetPhraseName(track, phrase, kp.Name)
+	for row := 0; row < types.PhraseRows; row++ {
+		for col := types.PhraseColumn(0); col < types.ColCount; col++ {
+			value := -1
+			if row < len(kp.Rows) && int(col) < len(kp.Rows[row]) {
+				value = kp.Rows[row][col]
+			}
+			if slots, ok := remap[col]; ok && value >= 0 {
+				if target, ok := slots[value]; ok {
+					value = target
+				} else if col == types.ColFilename {
+					value = -1 // The sample was not in the kit
+				}
+			}
+			m.SetPhraseCell(track, phrase, row, col, value)
+		}
+		if m.TrackTypes[track] {
+			continue
+		}
+		for key, value := range kp.PLocks[row] {
+			m.SetPLock(phrase, row, key, value)
+		}
+	}
+}
+
+// trackKitKey saves the track under the cursor in the Song view as a kit, or opens the kit
+// picker on an empty track to import a kit into it
+func trackKitKey(m *model.Model) {
+	track := m.CurrentCol
+	if track < 0 || track >= types.NumTracks {
+		return
+	}
+	if !songColumnEmpty(m, track) {
+		ExportTrackKit(m, track)
+		return
+	}
+	m.KitChoices = storage.ListTrackKits(storage.KitsFolder())
+	if len(m.KitChoices) == 0 {
+		m.Notice = "No track kits saved yet: W on a track saves one"
+		return
+	}
+	m.PickingKit = true
+	m.KitPick = 0
+}
+
+// handleKitPickerKey moves through the saved track kits: enter imports the chosen kit into
+// the track under the cursor, esc closes the picker
+func handleKitPickerKey(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		if m.KitPick > 0 {
+			m.KitPick--
+		}
+	case "down", "j":
+		if m.KitPick < len(m.KitChoices)-1 {
+			m.KitPick++
+		}
+	case "enter":
+		m.PickingKit = false
+		if m.KitPick < len(m.KitChoices) {
+			ImportTrackKit(m, m.KitChoices[m.KitPick], m.CurrentCol)
+		}
+	case "esc", "W":
+		m.PickingKit = false
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+func TestTrackKit(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	// A sampler track playing a sample with a retrigger
+	m := createTestModel(t)
+	m.SaveFolder = t.TempDir()
+	sample := filepath.Join(m.SaveFolder, "kick.wav")
+	assert.NoError(t, os.WriteFile(sample, []byte("kick"), 0644))
+	m.SamplerPhrasesFiles = []string{sample}
+	m.FileMetadata[sample] = types.FileMetadata{BPM: 90, Slices: 4}
+	m.RetriggerSettings[3] = types.RetriggerSettings{Times: 4, Start: 2}
+	m.TrackTypes[1] = true
+	m.TrackSetLevels[1] = -9
+	m.SetSongCell(1, 0, 0x02)
+	m.SetSongCell(1, 2, 0x02)
+	m.SetChainCell(1, 0x02, 0, 0x05)
+	m.SetChainTranspose(1, 0x02, 0, 3)
+	m.SetPhraseName(1, 0x05, "groove")
+	m.SamplerPhrasesData[0x05][0][types.ColNote] = 1
+	m.SamplerPhrasesData[0x05][0][types.ColDeltaTime] = 1
+	m.SamplerPhrasesData[0x05][0][types.ColFilename] = 0
+	m.SamplerPhrasesData[0x05][0][types.ColRetrigger] = 3
+
+	m.ViewMode = types.SongView
+	m.CurrentCol = 1
+	key := func(s string) { HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}) }
+	key("W")
+	assert.Contains(t, m.Notice, "Track 2 saved as kit")
+
+	// Another project where retrigger 03 is taken by different settings
+	m = createTestModel(t)
+	m.SaveFolder = t.TempDir()
+	m.RetriggerSettings[3] = types.RetriggerSettings{Times: 8}
+	m.SamplerPhrasesData[0x40][0][types.ColRetrigger] = 3
+	m.SetSongCell(0, 0, 0x01)
+	m.TrackTypes[4] = false
+	m.ViewMode = types.SongView
+	m.CurrentCol = 4
+	key = func(s string) { HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}) }
+	key("W")
+	assert.True(t, m.PickingKit, "W on an empty track opens the kit picker")
+	assert.Len(t, m.KitChoices, 1)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.PickingKit)
+	assert.Contains(t, m.Notice, "imported into track 5")
+
+	assert.True(t, m.TrackTypes[4], "The track takes the kit's type")
+	assert.Equal(t, float32(-9), m.TrackSetLevels[4])
+	chain := m.GetSongCell(4, 0)
+	assert.NotEqual(t, -1, chain)
+	assert.NotEqual(t, 0x01, chain, "The kit's chain gets a free slot")
+	assert.Equal(t, -1, m.GetSongCell(4, 1))
+	assert.Equal(t, chain, m.GetSongCell(4, 2), "Repeated chains stay shared")
+	assert.Equal(t, 3, m.GetChainTranspose(4, chain, 0))
+	phrase := m.GetChainCell(4, chain, 0)
+	assert.Equal(t, "groove", m.PhraseName(4, phrase))
+
+	row := m.SamplerPhrasesData[phrase][0]
+	retrigger := row[types.ColRetrigger]
+	assert.NotEqual(t, 3, retrigger, "Taken settings are not overwritten")
+	assert.Equal(t, types.RetriggerSettings{Times: 8}, m.RetriggerSettings[3])
+	assert.Equal(t, types.RetriggerSettings{Times: 4, Start: 2}, m.RetriggerSettings[retrigger])
+
+	file := m.SamplerPhrasesFiles[row[types.ColFilename]]
+	assert.Equal(t, filepath.Join(m.SaveFolder, "kick.wav"), file, "The sample is copied into the project")
+	assert.Equal(t, float32(90), m.FileMetadata[file].BPM)
+
+	// Only empty tracks take a kit
+	m.CurrentCol = 0
+	assert.False(t, ImportTrackKit(m, m.KitChoices[0], 0))
+	assert.Equal(t, "Track 1 is not empty", m.Notice)
+}
@@ -0,0 +1,183 @@
+package input
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// phraseRowDeleteColumns are the columns cleared when deleting a whole phrase row
+var phraseRowDeleteColumns = []types.PhraseColumn{
+	types.ColNote,          // Note
+	types.ColPitch,         // Pitch (displays "--", behaves as 80)
+	types.ColDeltaTime,     // Deltatime (for samplers this also clears playback)
+	types.ColGate,          // Gate (displays "--", behaves as 80)
+	types.ColRetrigger,     // Retrigger
+	types.ColEffectDucking, // Ducking
+	types.ColFilename,      // Filename
+	types.ColNote2,         // Stacked note 2
+	types.ColNote3,         // Stacked note 3
+	types.ColNote4,         // Stacked note 4
+}
+
+// confirmDestructive runs action immediately when confirmations are disabled,
+// otherwise it is parked behind a y/n prompt
+func confirmDestructive(m *model.Model, message string, action func()) {
+	if !m.ConfirmDeletes {
+		action()
+		m.Publish(model.Event{Kind: model.EventSettings})
+		return
+	}
+	m.PendingConfirm = &model.ConfirmPrompt{Message: message, OnConfirm: action}
+	logging.Storage.Debugf("Awaiting confirmation: %s", message)
+}
+
+// handleConfirmKey answers a pending confirmation, only y/enter confirms
+func handleConfirmKey(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	prompt := m.PendingConfirm
+	m.PendingConfirm = nil
+
+	switch msg.String() {
+	case "y", "Y", "enter":
+		prompt.OnConfirm()
+		if !prompt.Quit {
+			m.Publish(model.Event{Kind: model.EventSettings})
+		}
+		if prompt.Background != nil {
+			return func() tea.Msg { return prompt.Background() }
+		}
+	case "n", "N":
+		if prompt.OnDecline == nil {
+			logging.Storage.Debugf("Cancelled: %s", prompt.Message)
+			return nil
+		}
+		prompt.OnDecline()
+	default:
+		logging.Storage.Debugf("Cancelled: %s", prompt.Message)
+		return nil
+	}
+	if prompt.Quit {
+		return tea.Quit
+	}
+	return nil
+}
+
+// RestoreFromTrash restores the most recently deleted item
+func RestoreFromTrash(m *model.Model) {
+	if _, ok := m.RestoreLastTrash(); ok {
+		m.Publish(model.Event{Kind: model.EventSettings})
+	} else {
+		logging.Storage.Debugf("Trash is empty")
+	}
+}
+
+// DeleteSongCell clears a chain from the song, keeping it in the trash
+func DeleteSongCell(m *model.Model, track, row int) {
+	chainID := m.GetSongCell(track, row)
+	if chainID == -1 {
+		return
+	}
+	confirmDestructive(m, fmt.Sprintf("Delete chain %02X from T%d row %02X?", chainID, track+1, row), func() {
+		m.SetSongCell(track, row, -1)
+		m.PushTrash(fmt.Sprintf("chain %02X at T%d row %02X", chainID, track+1, row), func() {
+			m.SetSongCell(track, row, chainID)
+		})
+		logging.Storage.Debugf("Cleared song track %d row %02X chain", track, row)
+	})
+}
+
+// DeleteChainRow clears a phrase from the current chain, keeping it in the trash
+func DeleteChainRow(m *model.Model) {
+	track, chain, row := m.CurrentTrack, m.CurrentChain, m.CurrentRow
+	phraseID := m.GetChainCell(track, chain, row)
+	if phraseID == -1 {
+		return
+	}
+	confirmDestructive(m, fmt.Sprintf("Delete phrase %02X from chain %02X row %02X?", phraseID, chain, row), func() {
+		m.SetChainCell(track, chain, row, -1)
+		m.PushTrash(fmt.Sprintf("phrase %02X in chain %02X row %02X", phraseID, chain, row), func() {
+			m.SetChainCell(track, chain, row, phraseID)
+		})
+		logging.Storage.Debugf("Cleared chain %02X row %02X phrase", chain, row)
+	})
+}
+
+// DeletePhraseRow clears every column of the current phrase row, keeping it in the trash
+func DeletePhraseRow(m *model.Model) {
+	track, phrase, row := m.CurrentTrack, m.CurrentPhrase, m.CurrentRow
+	empty := true
+	for _, col := range phraseRowDeleteColumns {
+		if m.GetPhraseCell(track, phrase, row, col) != -1 {
+			empty = false
+			break
+		}
+	}
+	if empty {
+		return
+	}
+	confirmDestructive(m, fmt.Sprintf("Delete phrase %02X row %02X?", phrase, row), func() {
+		saved := m.GetPhraseRow(track, phrase, row)
+		for _, col := range phraseRowDeleteColumns {
+			m.SetPhraseCell(track, phrase, row, col, -1)
+		}
+		m.PushTrash(fmt.Sprintf("phrase %02X row %02X", phrase, row), func() {
+			m.SetPhraseRow(track, phrase, row, saved)
+		})
+		logging.Storage.Debugf("Deleted phrase %d row %d (cleared all columns)", phrase, row)
+	})
+}
+
+// DeletePhraseSample removes the sample assignment from the current phrase row
+func DeletePhraseSample(m *model.Model) {
+	track, phrase, row := m.CurrentTrack, m.CurrentPhrase, m.CurrentRow
+	fileIndex := m.GetPhraseCell(track, phrase, row, types.ColFilename)
+	if fileIndex == -1 {
+		return
+	}
+	confirmDestructive(m, fmt.Sprintf("Remove sample from phrase %02X row %02X?", phrase, row), func() {
+		m.SetPhraseCell(track, phrase, row, types.ColFilename, -1)
+		m.PushTrash(fmt.Sprintf("sample in phrase %02X row %02X", phrase, row), func() {
+			m.SetPhraseCell(track, phrase, row, types.ColFilename, fileIndex)
+		})
+		logging.Storage.Debugf("Cleared phrase %d row %d sample", phrase, row)
+	})
+}
+
+// DeleteWaveformMarker removes the selected slice marker, keeping it in the trash
+func DeleteWaveformMarker(m *model.Model) {
+	file := m.WaveformFile
+	metadata, exists := m.FileMetadata[file]
+	if !exists || m.WaveformSelectedSlice < 0 || m.WaveformSelectedSlice >= len(metadata.Onsets) {
+		return
+	}
+	marker := metadata.Onsets[m.WaveformSelectedSlice]
+	confirmDestructive(m, fmt.Sprintf("Delete marker at %.3fs?", marker), func() {
+		saved := m.FileMetadata[file]
+		saved.Onsets = append([]float64(nil), saved.Onsets...)
+		m.DeleteSelectedWaveformMarker()
+		m.PushTrash(fmt.Sprintf("marker at %.3fs", marker), func() {
+			m.FileMetadata[file] = saved
+		})
+	})
+}
+
+// DeleteWarpMarker deletes the selected warp marker of the waveform view, moving it to the trash
+func DeleteWarpMarker(m *model.Model) {
+	file := m.WaveformFile
+	metadata, exists := m.FileMetadata[file]
+	if !exists || m.WaveformSelectedWarp < 0 || m.WaveformSelectedWarp >= len(metadata.Warp) {
+		return
+	}
+	marker := metadata.Warp[m.WaveformSelectedWarp]
+	confirmDestructive(m, fmt.Sprintf("Delete warp marker at %.3fs (beat %g)?", marker.Time, marker.Beat), func() {
+		saved := m.FileMetadata[file]
+		m.DeleteSelectedWarpMarker()
+		m.PushTrash(fmt.Sprintf("warp marker at %.3fs", marker.Time), func() {
+			m.FileMetadata[file] = saved
+		})
+	})
+}
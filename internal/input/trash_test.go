@@ -0,0 +1,162 @@
+package input
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+func TestDeleteSongCellRequiresConfirmation(t *testing.T) {
+	m := createTestModel(t)
+	m.ViewMode = types.SongView
+	m.SongData[2][3] = 0x10
+	m.CurrentCol = 2
+	m.CurrentRow = 3
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.NotNil(t, m.PendingConfirm, "Backspace should ask for confirmation")
+	assert.Equal(t, 0x10, m.SongData[2][3], "Chain should stay until confirmed")
+
+	// Any key other than y cancels
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	assert.Nil(t, m.PendingConfirm)
+	assert.Equal(t, 0x10, m.SongData[2][3], "Cancelled delete should keep the chain")
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	assert.Equal(t, -1, m.SongData[2][3], "Confirmed delete should clear the chain")
+	assert.Len(t, m.Trash, 1)
+
+	RestoreFromTrash(m)
+	assert.Equal(t, 0x10, m.SongData[2][3], "Restore should bring the chain back")
+	assert.Empty(t, m.Trash)
+}
+
+func TestDeleteWithoutConfirmation(t *testing.T) {
+	m := createTestModel(t)
+	m.ConfirmDeletes = false
+	m.ViewMode = types.ChainView
+	m.CurrentTrack = 4
+	m.CurrentChain = 1
+	m.CurrentRow = 5
+	chainsData := m.GetCurrentChainsData()
+	(*chainsData)[1][5] = 0x22
+
+	DeleteChainRow(m)
+	assert.Nil(t, m.PendingConfirm, "No prompt when confirmations are disabled")
+	assert.Equal(t, -1, (*chainsData)[1][5])
+
+	RestoreFromTrash(m)
+	assert.Equal(t, 0x22, (*chainsData)[1][5])
+}
+
+func TestDeletePhraseRowRestore(t *testing.T) {
+	m := createTestModel(t)
+	m.ConfirmDeletes = false
+	m.ViewMode = types.PhraseView
+	m.CurrentTrack = 4
+	m.CurrentPhrase = 3
+	m.CurrentRow = 7
+	phrasesData := m.GetCurrentPhrasesData()
+	(*phrasesData)[3][7][types.ColNote] = 60
+	(*phrasesData)[3][7][types.ColDeltaTime] = 1
+	(*phrasesData)[3][7][types.ColFilename] = 0
+
+	DeletePhraseRow(m)
+	assert.Equal(t, -1, (*phrasesData)[3][7][types.ColNote])
+	assert.Equal(t, -1, (*phrasesData)[3][7][types.ColFilename])
+
+	RestoreFromTrash(m)
+	assert.Equal(t, 60, (*phrasesData)[3][7][types.ColNote])
+	assert.Equal(t, 1, (*phrasesData)[3][7][types.ColDeltaTime])
+	assert.Equal(t, 0, (*phrasesData)[3][7][types.ColFilename])
+}
+
+func TestDeleteEmptyCellDoesNotPrompt(t *testing.T) {
+	m := createTestModel(t)
+	m.ViewMode = types.SongView
+	m.CurrentCol = 0
+	m.CurrentRow = 0
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.Nil(t, m.PendingConfirm, "Nothing to delete, nothing to confirm")
+	assert.Empty(t, m.Trash)
+}
+
+func TestQuitPrompt(t *testing.T) {
+	m := model.NewModel(0, t.TempDir(), false)
+	ctrlQ := tea.KeyMsg{Type: tea.KeyCtrlQ}
+
+	// Nothing unsaved, quit straight away
+	assert.NotNil(t, HandleKeyInput(m, ctrlQ))
+	assert.Nil(t, m.PendingConfirm)
+
+	m.SetSongCell(0, 0, 1)
+	assert.Nil(t, HandleKeyInput(m, ctrlQ))
+	assert.NotNil(t, m.PendingConfirm, "Unsaved changes should ask before quitting")
+
+	// Esc stays in the tracker
+	assert.Nil(t, HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyEsc}))
+	assert.True(t, m.IsDirty())
+
+	// n quits without saving
+	HandleKeyInput(m, ctrlQ)
+	assert.NotNil(t, HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}}))
+	assert.True(t, m.IsDirty())
+
+	// y saves, then quits
+	HandleKeyInput(m, ctrlQ)
+	assert.NotNil(t, HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}}))
+	assert.False(t, m.IsDirty())
+}
+
+func TestQuitDuringConfirmation(t *testing.T) {
+	m := createTestModel(t)
+	m.ViewMode = types.SongView
+	m.SongData[2][3] = 0x10
+	m.CurrentCol = 2
+	m.CurrentRow = 3
+
+	// Ctrl+C cancels the delete and copies
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.NotNil(t, m.PendingConfirm)
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyCtrlC})
+	assert.Nil(t, m.PendingConfirm)
+	assert.Equal(t, 0x10, m.SongData[2][3])
+
+	// Ctrl+Q cancels the delete and quits
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.NotNil(t, m.PendingConfirm)
+	assert.NotNil(t, HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyCtrlQ}))
+	assert.Nil(t, m.PendingConfirm)
+	assert.Equal(t, 0x10, m.SongData[2][3])
+}
+
+func TestQuitPromptAfterMarkerEdit(t *testing.T) {
+	m := model.NewModel(0, t.TempDir(), false)
+	m.ViewMode = types.WaveformView
+	m.WaveformFile = "kick.wav"
+	m.WaveformEnd = 1
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	assert.Len(t, m.FileMetadata["kick.wav"].Onsets, 1)
+	assert.True(t, m.IsDirty(), "Marker edits should count as unsaved changes")
+
+	assert.Nil(t, HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyCtrlQ}))
+	if assert.NotNil(t, m.PendingConfirm) {
+		assert.True(t, m.PendingConfirm.Quit)
+	}
+}
+
+func TestCtrlSSaves(t *testing.T) {
+	m := model.NewModel(0, t.TempDir(), false)
+	m.SetSongCell(0, 0, 1)
+
+	HandleKeyInput(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	assert.False(t, m.IsDirty(), "Ctrl+S should save without waiting for the autosave")
+	assert.Equal(t, "Saved", m.Notice)
+}
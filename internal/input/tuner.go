@@ -0,0 +1,39 @@
+package input
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// openTuner switches from the input view to the tuner and asks SuperCollider for pitch
+// readings of the input
+func openTuner(m *model.Model) {
+	m.ViewMode = types.TunerView
+	m.SendOSCPitchTrackMessage()
+}
+
+// closeTuner returns to the input view, stopping the pitch readings unless pitch tracking
+// still needs them
+func closeTuner(m *model.Model) {
+	m.ViewMode = types.InputView
+	m.SendOSCPitchTrackMessage()
+}
+
+// handleTunerInput handles keys in the tuner: the reference pitch, and going back to the input view
+func handleTunerInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+q", "alt+q":
+		return requestQuit(m)
+	case "esc", "q", "t":
+		closeTuner(m)
+	case "up":
+		m.SetTunerReference(m.TunerReference + 1)
+	case "down":
+		m.SetTunerReference(m.TunerReference - 1)
+	case "ctrl+r", "alt+r":
+		return handleCtrlR(m)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package input
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+	"github.com/schollz/collidertracker/internal/update"
+)
+
+// UpdateCheckMsg carries the releases found by an update check back to the UI goroutine
+type UpdateCheckMsg struct {
+	Releases []update.Release // Published releases, newest first
+	Err      error            // Why the check failed (nil if it worked)
+}
+
+// UpdateInstallMsg reports the end of an update download
+type UpdateInstallMsg struct {
+	Tag string // Version installed
+	Err error  // Why the install failed (nil if it worked)
+}
+
+// CheckForUpdates asks GitHub for the releases off the UI goroutine
+func CheckForUpdates(m *model.Model) tea.Cmd {
+	if m.UpdateBusy {
+		return nil
+	}
+	m.UpdateBusy = true
+	m.UpdateError = ""
+	return func() tea.Msg {
+		releases, err := update.FetchReleases()
+		return UpdateCheckMsg{Releases: releases, Err: err}
+	}
+}
+
+// HandleUpdateCheck stores the releases found and points out a newer one
+func HandleUpdateCheck(m *model.Model, msg UpdateCheckMsg) {
+	m.UpdateBusy = false
+	if msg.Err != nil {
+		logging.UI.Warnf("Update check failed: %v", msg.Err)
+		m.UpdateError = msg.Err.Error()
+		return
+	}
+	m.Releases = msg.Releases
+	m.ChangelogScroll = 0
+	if release, ok := m.AvailableUpdate(); ok {
+		logging.UI.Debugf("Update available: %s (running %s)", release.Tag, m.Version)
+		m.Notice = fmt.Sprintf("Version %s is available, press C for the changelog", release.Tag)
+	} else {
+		logging.UI.Debugf("No update available (running %s)", m.Version)
+	}
+}
+
+// installUpdate asks to download the newest release over the running binary
+func installUpdate(m *model.Model) {
+	release, ok := m.AvailableUpdate()
+	if !ok || m.UpdateBusy {
+		return
+	}
+	m.PendingConfirm = &model.ConfirmPrompt{
+		Message:   fmt.Sprintf("Download and install %s?", release.Tag),
+		OnConfirm: func() { m.UpdateBusy = true; m.UpdateError = "" },
+		Background: func() any {
+			return UpdateInstallMsg{Tag: release.Tag, Err: update.Install(release)}
+		},
+	}
+	logging.UI.Debugf("Awaiting confirmation: install %s", release.Tag)
+}
+
+// HandleUpdateInstall reports how the download of an update went
+func HandleUpdateInstall(m *model.Model, msg UpdateInstallMsg) {
+	m.UpdateBusy = false
+	if msg.Err != nil {
+		logging.UI.Warnf("Update to %s failed: %v", msg.Tag, msg.Err)
+		m.UpdateError = msg.Err.Error()
+		m.Notice = "Update failed: " + msg.Err.Error()
+		return
+	}
+	logging.UI.Debugf("Installed %s", msg.Tag)
+	m.UpdateInstalled = msg.Tag
+	m.Notice = fmt.Sprintf("Installed %s, restart ColliderTracker to use it", msg.Tag)
+}
+
+// toggleChangelogView opens the changelog of the releases, checking for them the first time,
+// or closes it
+func toggleChangelogView(m *model.Model) tea.Cmd {
+	toggleAuxView(m, types.ChangelogView)
+	if m.ViewMode == types.ChangelogView && m.Releases == nil {
+		return CheckForUpdates(m)
+	}
+	return nil
+}
+
+// handleChangelogInput handles keys in the changelog view
+func handleChangelogInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+q", "alt+q":
+		return requestQuit(m)
+	case "esc", "q", "C":
+		return toggleChangelogView(m)
+	case "up", "k":
+		m.ScrollChangelog(-1)
+	case "down", "j":
+		m.ScrollChangelog(1)
+	case "pgup":
+		m.ScrollChangelog(-16)
+	case "pgdown":
+		m.ScrollChangelog(16)
+	case "r":
+		return CheckForUpdates(m)
+	case "u":
+		installUpdate(m)
+	}
+	return nil
+}
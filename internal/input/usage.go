@@ -0,0 +1,58 @@
+package input
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// toggleUsageView opens the chain and phrase usage map on the pool of the current track, or closes it
+func toggleUsageView(m *model.Model) {
+	if m.ViewMode != types.UsageView && m.CurrentTrack >= 0 && m.CurrentTrack < types.NumTracks {
+		m.UsageSampler = m.TrackTypes[m.CurrentTrack]
+	}
+	toggleAuxView(m, types.UsageView)
+}
+
+// handleUsageInput handles keys in the usage view
+func handleUsageInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+q", "alt+q":
+		return requestQuit(m)
+	case "esc", "q", "U":
+		toggleUsageView(m)
+	case "tab", "left", "right", "h", "l":
+		m.UsageSampler = !m.UsageSampler
+	case "c":
+		confirmDestructive(m, "Renumber all chains and phrases contiguously?", func() { CompactPools(m) })
+	}
+	return nil
+}
+
+// CompactPools renumbers the chains and phrases of both pools so the used ones are contiguous
+// from the start of each bank, in song order. The renumbering goes into the trash, so it can
+// be undone like a deletion. It does nothing during playback.
+func CompactPools(m *model.Model) bool {
+	if m.IsPlaying {
+		m.Notice = "Stop playback to renumber chains and phrases"
+		return false
+	}
+	snapshotBefore(m, "renumbering")
+	var undo []func()
+	for _, sampler := range []bool{false, true} {
+		chainMap, phraseMap := m.CompactionMaps(sampler)
+		m.RenumberPool(sampler, chainMap, phraseMap)
+		undo = append(undo, func() { m.RenumberPool(sampler, model.InvertMap(chainMap), model.InvertMap(phraseMap)) })
+	}
+	m.PushTrash("chain and phrase numbering", func() {
+		for i := len(undo) - 1; i >= 0; i-- {
+			undo[i]()
+		}
+	})
+	logging.UI.Debugf("Renumbered chains and phrases")
+	m.Notice = "Chains and phrases renumbered"
+	m.Publish(model.Event{Kind: model.EventSettings})
+	return true
+}
@@ -1,7 +1,6 @@
 package input
 
 import (
-	"log"
 	"os"
 	"path/filepath"
 
@@ -9,8 +8,8 @@ import (
 
 	"github.com/schollz/collidertracker/internal/audio"
 	"github.com/schollz/collidertracker/internal/getbpm"
+	"github.com/schollz/collidertracker/internal/logging"
 	"github.com/schollz/collidertracker/internal/model"
-	"github.com/schollz/collidertracker/internal/storage"
 	"github.com/schollz/collidertracker/internal/types"
 )
 
@@ -19,31 +18,31 @@ func handleW(m *model.Model) tea.Cmd {
 	// If already in waveform view, return to previous view
 	if m.ViewMode == types.WaveformView {
 		m.ViewMode = m.WaveformPreviousView
-		storage.AutoSave(m)
+		m.Publish(model.Event{Kind: model.EventView})
 		return nil
 	}
 	
 	// Only allow waveform view for sampler tracks
 	if m.GetPhraseViewType() == types.InstrumentPhraseView {
-		log.Printf("Waveform view only available for Sampler tracks")
+		logging.UI.Debugf("Waveform view only available for Sampler tracks")
 		return nil
 	}
 	
 	// Get the current file for this track
 	file := m.GetCurrentTrackFile()
 	if file == "" {
-		log.Printf("No audio file for current track")
+		logging.UI.Debugf("No audio file for current track")
 		return nil
 	}
 
 	// Make sure the file is absolute path and exists
 	if !filepath.IsAbs(file) {
 		// Try to resolve relative to save folder
-		candidatePath := filepath.Join(m.SaveFolder, file)
+		candidatePath := filepath.Join(m.ProjectFolder(), file)
 		if _, err := os.Stat(candidatePath); err == nil {
 			file = candidatePath
 		} else {
-			log.Printf("Warning: File not found at relative path %s or absolute path %s", candidatePath, file)
+			logging.UI.Warnf("Warning: File not found at relative path %s or absolute path %s", candidatePath, file)
 		}
 	}
 	
@@ -52,9 +51,9 @@ func handleW(m *model.Model) tea.Cmd {
 	metadata, hasMetadata := m.FileMetadata[file]
 	if !hasMetadata || metadata.WaveformFile == "" {
 		// Need to generate waveform file
-		waveformFile, err := audio.ConvertToWaveformFile(file, m.SaveFolder)
+		waveformFile, err := audio.ConvertToWaveformFile(file, m.ProjectFolder())
 		if err != nil {
-			log.Printf("Warning: Failed to create waveform file: %v", err)
+			logging.UI.Warnf("Warning: Failed to create waveform file: %v", err)
 			// Continue anyway - will use original file
 		} else {
 			// Update metadata with waveform file
@@ -72,7 +71,7 @@ func handleW(m *model.Model) tea.Cmd {
 					WaveformFile: waveformFile,
 				}
 			}
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventSettings})
 		}
 	}
 	
@@ -89,7 +88,7 @@ func handleW(m *model.Model) tea.Cmd {
 
 	duration, _, _, err := getbpm.Length(waveformFile)
 	if err != nil {
-		log.Printf("Error getting audio duration: %v", err)
+		logging.UI.Errorf("Error getting audio duration: %v", err)
 		return nil
 	}
 	
@@ -100,10 +99,11 @@ func handleW(m *model.Model) tea.Cmd {
 	m.WaveformEnd = duration
 	m.WaveformDuration = duration // Cache duration
 	m.WaveformSelectedSlice = -1
+	m.WaveformSelectedWarp = -1
 	
 	// Switch to waveform view
 	m.ViewMode = types.WaveformView
-	storage.AutoSave(m)
+	m.Publish(model.Event{Kind: model.EventView})
 	
 	return nil
 }
@@ -113,12 +113,12 @@ func HandleWaveformInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
 	switch msg.String() {
 	case "ctrl+q", "alt+q":
 		// Quit the program
-		return tea.Quit
+		return requestQuit(m)
 
 	case "w", "q":
 		// Exit waveform view
 		m.ViewMode = m.WaveformPreviousView
-		storage.AutoSave(m)
+		m.Publish(model.Event{Kind: model.EventView})
 		return nil
 
 	case " ":
@@ -142,7 +142,7 @@ func HandleWaveformInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
 			}
 
 			m.SendStopOSC()
-			log.Printf("Playback stopped via 'C' in waveform view")
+			logging.Playback.Debugf("Playback stopped via 'C' in waveform view")
 			return nil
 		}
 
@@ -153,30 +153,67 @@ func HandleWaveformInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
 	case "m":
 		// Add marker at midpoint
 		m.AddWaveformMarker()
-		storage.AutoSave(m)
+		m.WaveformSelectedWarp = -1
+		m.Publish(model.Event{Kind: model.EventSettings})
 		return nil
 		
 	case "tab":
 		// Select next marker
 		m.SelectNextWaveformMarker()
+		m.WaveformSelectedWarp = -1
+		return nil
+
+	case "b":
+		// Add warp marker at the selected slice or midpoint, pinned to the nearest beat
+		m.AddWarpMarker()
+		m.Publish(model.Event{Kind: model.EventSettings})
+		return nil
+
+	case "n":
+		// Select next warp marker
+		m.SelectNextWarpMarker()
+		return nil
+
+	case "[", "]", "{", "}":
+		// Move the selected warp marker's beat by a quarter beat or a whole beat
+		delta := map[string]float64{"[": -0.25, "]": 0.25, "{": -1, "}": 1}[msg.String()]
+		m.NudgeWarpBeat(delta)
+		m.Publish(model.Event{Kind: model.EventSettings})
+		return nil
+
+	case "g":
+		// Replace the slices with one per beat of the warped grid
+		SliceToWarpGrid(m)
 		return nil
 
 	case "esc":
 		// Unselect marker
 		m.WaveformSelectedSlice = -1
+		m.WaveformSelectedWarp = -1
+		return nil
+
+	case "ctrl+z", "alt+z":
+		// Restore last deleted item
+		RestoreFromTrash(m)
 		return nil
 
 	case "d", "backspace":
-		// Delete selected marker
-		m.DeleteSelectedWaveformMarker()
-		storage.AutoSave(m)
+		// Delete selected warp or slice marker
+		if m.WaveformSelectedWarp >= 0 {
+			DeleteWarpMarker(m)
+		} else {
+			DeleteWaveformMarker(m)
+		}
 		return nil
 		
 	case "left":
 		// Jog marker or view left
-		if m.WaveformSelectedSlice >= 0 {
+		if m.WaveformSelectedWarp >= 0 {
+			m.JogWarpMarker(-1, false)
+			m.Publish(model.Event{Kind: model.EventSettings})
+		} else if m.WaveformSelectedSlice >= 0 {
 			m.JogWaveformMarker(-1, false)
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventSettings})
 		} else {
 			m.JogWaveformView(-1, false)
 		}
@@ -184,9 +221,12 @@ func HandleWaveformInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
 		
 	case "right":
 		// Jog marker or view right
-		if m.WaveformSelectedSlice >= 0 {
+		if m.WaveformSelectedWarp >= 0 {
+			m.JogWarpMarker(1, false)
+			m.Publish(model.Event{Kind: model.EventSettings})
+		} else if m.WaveformSelectedSlice >= 0 {
 			m.JogWaveformMarker(1, false)
-			storage.AutoSave(m)
+			m.Publish(model.Event{Kind: model.EventSettings})
 		} else {
 			m.JogWaveformView(1, false)
 		}
@@ -215,3 +255,24 @@ func HandleWaveformInput(m *model.Model, msg tea.KeyMsg) tea.Cmd {
 	
 	return nil
 }
+
+// SliceToWarpGrid replaces the slice markers of the waveform file with one per beat of its
+// warp grid. The previous slices go into the trash.
+func SliceToWarpGrid(m *model.Model) {
+	file := m.WaveformFile
+	saved, exists := m.FileMetadata[file]
+	if !exists || len(saved.Warp) == 0 {
+		m.Notice = "Add warp markers (b) before slicing to the grid"
+		return
+	}
+	confirmDestructive(m, "Replace the slices with one per warped beat?", func() {
+		saved.Onsets = append([]float64(nil), saved.Onsets...)
+		if !m.SliceToWarpGrid() {
+			return
+		}
+		m.PushTrash("slices before warp grid", func() {
+			m.FileMetadata[file] = saved
+		})
+		logging.UI.Debugf("Sliced %s to its warp grid: %d slices", filepath.Base(file), m.FileMetadata[file].Slices)
+	})
+}
@@ -0,0 +1,397 @@
+// Package library indexes the sample folders set in the config file, so samples can be found by
+// name, type, tempo and key instead of by browsing folders. Each file is analyzed once: its
+// length, what kind of sound it is (kick, snare, loop...), the BPM of loops and the key of
+// tonal material. Only files that are new or changed are analyzed again.
+package library
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/schollz/audiomorph"
+
+	"github.com/schollz/collidertracker/internal/getbpm"
+	"github.com/schollz/collidertracker/internal/music"
+)
+
+// Types of sound a sample can be detected as
+const (
+	TypeKick    = "kick"
+	TypeSnare   = "snare"
+	TypeHat     = "hat"
+	TypeClap    = "clap"
+	TypePerc    = "perc"
+	TypeLoop    = "loop"
+	TypeVocal   = "vocal"
+	TypePad     = "pad"     // Long sound without a beat
+	TypeOneshot = "oneshot" // Short sound that is none of the drums
+)
+
+// Entry is an analyzed sample file
+type Entry struct {
+	Path     string    `json:"path"`
+	Size     int64     `json:"size"`    // Size when analyzed, to tell when the file changed
+	ModTime  time.Time `json:"modTime"` // Modification time when analyzed
+	Duration float64   `json:"duration"`
+	Type     string    `json:"type"`
+	BPM      float64   `json:"bpm,omitempty"` // Tempo of loops (0 for other types)
+	Key      string    `json:"key,omitempty"` // Key of tonal material, e.g. "A minor" ("" when no chord is heard)
+}
+
+// File is an audio file found in a sample folder
+type File struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// Index holds the entries of the sample folders, sorted by path
+type Index struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Path returns where the index is kept, next to config.json ("" when there is no config
+// directory)
+func Path() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "collidertracker", "library.json")
+}
+
+// Load reads the index at path. A missing index is empty.
+func Load(path string) (*Index, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Index{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	sort.Slice(index.Entries, func(i, j int) bool { return index.Entries[i].Path < index.Entries[j].Path })
+	return &index, nil
+}
+
+// Save writes the index to path, replacing the previous one only once it is complete
+func (ix *Index) Save(path string) error {
+	data, err := json.Marshal(ix)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// List returns the audio files in the folders and their subfolders, skipping hidden ones.
+// Folders that cannot be read are left out.
+func List(dirs []string, isAudio func(name string) bool) []File {
+	seen := make(map[string]bool)
+	var files []File
+	for _, dir := range dirs {
+		filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if strings.HasPrefix(d.Name(), ".") && path != dir {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() || !isAudio(d.Name()) || seen[path] {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			seen[path] = true
+			files = append(files, File{Path: path, Size: info.Size(), ModTime: info.ModTime()})
+			return nil
+		})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+// Update drops the entries of files that are no longer listed and returns the files that are
+// new or changed since they were analyzed
+func (ix *Index) Update(files []File) []File {
+	listed := make(map[string]File, len(files))
+	for _, file := range files {
+		listed[file.Path] = file
+	}
+	kept := ix.Entries[:0]
+	analyzed := make(map[string]bool, len(ix.Entries))
+	for _, entry := range ix.Entries {
+		file, ok := listed[entry.Path]
+		if !ok {
+			continue
+		}
+		kept = append(kept, entry)
+		analyzed[entry.Path] = file.Size == entry.Size && file.ModTime.Equal(entry.ModTime)
+	}
+	ix.Entries = kept
+
+	var stale []File
+	for _, file := range files {
+		if !analyzed[file.Path] {
+			stale = append(stale, file)
+		}
+	}
+	return stale
+}
+
+// Put adds an entry, or replaces the one of the same file
+func (ix *Index) Put(entry Entry) {
+	i := sort.Search(len(ix.Entries), func(i int) bool { return ix.Entries[i].Path >= entry.Path })
+	if i < len(ix.Entries) && ix.Entries[i].Path == entry.Path {
+		ix.Entries[i] = entry
+		return
+	}
+	ix.Entries = append(ix.Entries, Entry{})
+	copy(ix.Entries[i+1:], ix.Entries[i:])
+	ix.Entries[i] = entry
+}
+
+// Search returns the entries matching every word of the query. A word matches the type, a BPM
+// within 2 of a number, a key written like "am", "c#" or "minor", or else any part of the path.
+// An empty query matches everything.
+func (ix *Index) Search(query string) []Entry {
+	words := strings.Fields(strings.ToLower(query))
+	var matches []Entry
+	for _, entry := range ix.Entries {
+		if matchesAll(entry, words) {
+			matches = append(matches, entry)
+		}
+	}
+	return matches
+}
+
+func matchesAll(entry Entry, words []string) bool {
+	path := strings.ToLower(entry.Path)
+	for _, word := range words {
+		if word == entry.Type || strings.Contains(path, word) {
+			continue
+		}
+		if bpm, err := strconv.ParseFloat(strings.TrimSuffix(word, "bpm"), 64); err == nil && entry.BPM > 0 && math.Abs(entry.BPM-bpm) <= 2 {
+			continue
+		}
+		if entry.Key != "" && slices.Contains(keyWords(entry.Key), word) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// keyWords returns the ways a key can be searched for: "A minor" is "am", "a" and "minor"
+func keyWords(key string) []string {
+	root, mode, _ := strings.Cut(strings.ToLower(key), " ")
+	short := root
+	if mode == "minor" {
+		short += "m"
+	}
+	return []string{short, root, mode}
+}
+
+// Analysis windows: loudness is measured in frames of frameSeconds, and the key is heard at
+// about keyRate samples a second, over at most maxKeySeconds
+const (
+	frameSeconds  = 0.01
+	keyRate       = 8000
+	maxKeySeconds = 120
+)
+
+// Analyze decodes a file and detects its length, type, BPM and key
+func Analyze(file File) (Entry, error) {
+	decoded, err := audiomorph.DecodeFile(file.Path)
+	if err != nil {
+		return Entry{}, err
+	}
+	if decoded.NumChannels <= 0 || decoded.SampleRate <= 0 || len(decoded.Data) == 0 {
+		return Entry{}, fmt.Errorf("no audio in %s", file.Path)
+	}
+	scale := math.Pow(2, float64(max(1, decoded.BitDepth)-1))
+	samples := make([]float64, len(decoded.Data[0]))
+	for i, v := range decoded.Data[0] {
+		samples[i] = float64(v) / scale
+	}
+	rate := decoded.SampleRate
+
+	entry := Entry{
+		Path:     file.Path,
+		Size:     file.Size,
+		ModTime:  file.ModTime,
+		Duration: float64(len(samples)) / float64(rate),
+	}
+	entry.Type = classify(file.Path, samples, rate)
+	if entry.Type == TypeLoop {
+		_, entry.BPM = getbpm.GetBPMOfLength(file.Path, entry.Duration)
+	}
+	if tonal(entry.Type) {
+		bar := 2.0 // Pads have no bars: listen in stretches of two seconds
+		if entry.BPM > 0 {
+			bar = 4 * 60 / entry.BPM
+		}
+		low, lowRate := downsample(samples, rate, keyRate, maxKeySeconds)
+		_, entry.Key = music.ChordsAndKey(low, lowRate, bar)
+	}
+	return entry, nil
+}
+
+// tonal reports whether sounds of a type can be in a key
+func tonal(typ string) bool {
+	switch typ {
+	case TypeLoop, TypeVocal, TypePad, TypeOneshot:
+		return true
+	}
+	return false
+}
+
+// downsample averages groups of samples down to about rate, keeping at most seconds
+func downsample(samples []float64, from, to int, seconds float64) ([]float64, int) {
+	factor := max(1, from/to)
+	n := min(len(samples), int(seconds*float64(from)))
+	out := make([]float64, 0, n/factor)
+	for start := 0; start+factor <= n; start += factor {
+		sum := 0.0
+		for _, v := range samples[start : start+factor] {
+			sum += v
+		}
+		out = append(out, sum/float64(factor))
+	}
+	return out, from / factor
+}
+
+// nameHints are words in file names that tell the type of a sample. Short words must be a
+// whole word of the name, longer ones may be part of one ("hihat", "breakbeat").
+var nameHints = []struct {
+	typ   string
+	words []string
+}{
+	{TypeKick, []string{"kick", "kck", "bd", "bassdrum"}},
+	{TypeSnare, []string{"snare", "snr", "sd", "rim"}},
+	{TypeHat, []string{"hat", "hihat", "hh", "oh", "ch", "cymbal", "ride", "crash"}},
+	{TypeClap, []string{"clap", "clp"}},
+	{TypePerc, []string{"perc", "tom", "shaker", "conga", "bongo", "cowbell"}},
+	{TypeLoop, []string{"loop", "break", "beat", "groove"}},
+	{TypeVocal, []string{"vocal", "vox", "voice", "acapella"}},
+}
+
+// classify tells the type of a sample from its name, or else from how it sounds: loops and pads
+// by length and beat, drums by how bright they are and how quickly they fade
+func classify(path string, samples []float64, rate int) string {
+	name := strings.ToLower(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	words := strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) })
+	for _, hint := range nameHints {
+		for _, hintWord := range hint.words {
+			for _, word := range words {
+				if word == hintWord || len(hintWord) >= 4 && strings.Contains(word, hintWord) {
+					return hint.typ
+				}
+			}
+		}
+	}
+
+	frames := loudness(samples, rate)
+	if len(frames) == 0 {
+		return TypeOneshot
+	}
+	duration := float64(len(samples)) / float64(rate)
+	if duration >= 1.5 {
+		if hits(frames) >= 4 {
+			return TypeLoop
+		}
+		return TypePad
+	}
+
+	// Brightness from how often the signal crosses zero just after its peak
+	peak := 0
+	for i, v := range frames {
+		if v > frames[peak] {
+			peak = i
+		}
+	}
+	start := peak * int(frameSeconds*float64(rate))
+	end := min(len(samples), start+rate/10)
+	crossings := 0
+	for i := start + 1; i < end; i++ {
+		if (samples[i-1] < 0) != (samples[i] < 0) {
+			crossings++
+		}
+	}
+	perSecond := float64(crossings) * float64(rate) / float64(max(1, end-start))
+
+	// A drum has faded by 20 dB within 400 ms of its peak
+	fades := true
+	if after := peak + int(0.4/frameSeconds); after < len(frames) {
+		fades = frames[after] < frames[peak]/10
+	}
+	switch {
+	case perSecond > 10000:
+		return TypeHat
+	case perSecond < 800 && fades:
+		return TypeKick
+	case fades:
+		return TypeSnare
+	}
+	return TypeOneshot
+}
+
+// loudness returns the RMS level of each frame of the samples
+func loudness(samples []float64, rate int) []float64 {
+	size := max(1, int(frameSeconds*float64(rate)))
+	var frames []float64
+	for start := 0; start+size <= len(samples); start += size {
+		sum := 0.0
+		for _, v := range samples[start : start+size] {
+			sum += v * v
+		}
+		frames = append(frames, math.Sqrt(sum/float64(size)))
+	}
+	return frames
+}
+
+// hits counts the frames where the level jumps well above the frames just before it, at least
+// 50 ms apart and no quieter than a tenth of the loudest frame
+func hits(frames []float64) int {
+	loudest := 0.0
+	for _, v := range frames {
+		loudest = max(loudest, v)
+	}
+	const history, gap = 5, 5
+	count, last := 0, -gap
+	for i := history; i < len(frames); i++ {
+		before := 0.0
+		for _, v := range frames[i-history : i] {
+			before += v
+		}
+		before /= history
+		if frames[i] > 1.8*before && frames[i] > loudest/10 && i-last >= gap {
+			count++
+			last = i
+		}
+	}
+	return count
+}
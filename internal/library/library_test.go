@@ -0,0 +1,155 @@
+package library
+
+import (
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	goaudio "github.com/go-audio/audio"
+	"github.com/go-audio/wav"
+	"github.com/stretchr/testify/assert"
+)
+
+const rate = 44100
+
+// writeWAV writes mono samples in [-1,1] to a 16-bit WAV file in dir
+func writeWAV(t *testing.T, dir, name string, samples []float64) string {
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	data := make([]int, len(samples))
+	for i, v := range samples {
+		data[i] = int(v * 32767)
+	}
+	encoder := wav.NewEncoder(f, rate, 16, 1, 1)
+	assert.NoError(t, encoder.Write(&goaudio.IntBuffer{Format: &goaudio.Format{NumChannels: 1, SampleRate: rate}, Data: data, SourceBitDepth: 16}))
+	assert.NoError(t, encoder.Close())
+	assert.NoError(t, f.Close())
+	return path
+}
+
+// sound returns seconds of a signal whose level fades by decay per second (0 holds it)
+func sound(seconds, decay float64, signal func(i int) float64) []float64 {
+	out := make([]float64, int(seconds*rate))
+	for i := range out {
+		out[i] = 0.8 * math.Exp(-decay*float64(i)/rate) * signal(i)
+	}
+	return out
+}
+
+func TestAnalyze(t *testing.T) {
+	dir := t.TempDir()
+	noise := rand.New(rand.NewSource(1))
+	kick := sound(0.5, 12, func(i int) float64 { return math.Sin(2 * math.Pi * 55 * float64(i) / rate) })
+	hat := sound(0.2, 30, func(i int) float64 {
+		if i%2 == 0 {
+			return 1
+		}
+		return -1 // Alternating samples are as bright as it gets
+	})
+	filtered := 0.0
+	snare := sound(0.3, 15, func(i int) float64 {
+		filtered = 0.8*filtered + 0.2*(2*noise.Float64()-1) // Noise without the highest frequencies
+		return 0.4*math.Sin(2*math.Pi*200*float64(i)/rate) + 2*filtered
+	})
+	chord := func(i int) float64 {
+		v := 0.0
+		for _, freq := range []float64{220.00, 261.63, 329.63} { // A minor
+			v += math.Sin(2*math.Pi*freq*float64(i)/rate) / 3
+		}
+		return v
+	}
+	pad := sound(4, 0, chord)
+	var loop []float64
+	for beat := 0; beat < 8; beat++ {
+		loop = append(loop, kick...)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		samples []float64
+		typ     string
+		key     string
+	}{
+		{"one.wav", kick, TypeKick, ""},
+		{"two.wav", hat, TypeHat, ""},
+		{"three.wav", snare, TypeSnare, ""},
+		{"four.wav", pad, TypePad, "A minor"},
+		{"five.wav", loop, TypeLoop, ""},
+		{"Clap 03.wav", kick, TypeClap, ""}, // The name wins over the sound
+		{"hihat_open.wav", kick, TypeHat, ""},
+	} {
+		path := writeWAV(t, dir, tt.name, tt.samples)
+		entry, err := Analyze(File{Path: path})
+		assert.NoError(t, err)
+		assert.Equal(t, tt.typ, entry.Type, tt.name)
+		assert.Equal(t, tt.key, entry.Key, tt.name)
+		assert.InDelta(t, float64(len(tt.samples))/rate, entry.Duration, 0.001, tt.name)
+		if tt.typ == TypeLoop {
+			assert.Greater(t, entry.BPM, 0.0, "Loops get a BPM")
+		} else {
+			assert.Zero(t, entry.BPM, tt.name)
+		}
+	}
+
+	_, err := Analyze(File{Path: filepath.Join(dir, "missing.wav")})
+	assert.Error(t, err)
+}
+
+func TestIndex(t *testing.T) {
+	dir := t.TempDir()
+	kick := sound(0.5, 12, func(i int) float64 { return math.Sin(2 * math.Pi * 55 * float64(i) / rate) })
+	writeWAV(t, dir, "kick.wav", kick)
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "loops", ".hidden"), 0755))
+	writeWAV(t, filepath.Join(dir, "loops"), "funky_120.wav", kick)
+	writeWAV(t, filepath.Join(dir, "loops", ".hidden"), "skipped.wav", kick)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0644))
+	isAudio := func(name string) bool { return strings.HasSuffix(name, ".wav") }
+
+	files := List([]string{dir, filepath.Join(dir, "loops")}, isAudio)
+	assert.Len(t, files, 2, "Hidden folders, other files and files listed twice are left out")
+
+	index := &Index{}
+	stale := index.Update(files)
+	assert.Len(t, stale, 2)
+	for _, file := range stale {
+		entry, err := Analyze(file)
+		assert.NoError(t, err)
+		index.Put(entry)
+	}
+	index.Put(Entry{Path: files[1].Path, Size: files[1].Size, ModTime: files[1].ModTime, Type: TypeLoop, BPM: 120, Key: "C# minor"})
+
+	path := filepath.Join(t.TempDir(), "library.json")
+	assert.NoError(t, index.Save(path))
+	loaded, err := Load(path)
+	assert.NoError(t, err)
+	assert.Empty(t, loaded.Update(files), "Nothing changed")
+
+	// A changed file is analyzed again, a removed one leaves the index
+	files[0].ModTime = files[0].ModTime.Add(time.Second)
+	assert.Equal(t, []File{files[0]}, loaded.Update(files))
+	assert.Equal(t, files[:1], loaded.Update(files[:1]), "Until it is analyzed")
+	assert.Len(t, loaded.Entries, 1)
+
+	search := func(query string) []string {
+		var names []string
+		for _, entry := range index.Search(query) {
+			names = append(names, filepath.Base(entry.Path))
+		}
+		return names
+	}
+	assert.Equal(t, []string{"kick.wav", "funky_120.wav"}, search(""))
+	assert.Equal(t, []string{"kick.wav"}, search("KICK"))
+	assert.Equal(t, []string{"funky_120.wav"}, search("loop 119"))
+	assert.Equal(t, []string{"funky_120.wav"}, search("c#m 120bpm"))
+	assert.Equal(t, []string{"funky_120.wav"}, search("LOOPS/"))
+	assert.Empty(t, search("kick minor"))
+
+	empty, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.NoError(t, err)
+	assert.Empty(t, empty.Entries)
+}
@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// A log file is rotated when a record would take it past maxFileSize: path becomes path.1,
+// path.1 becomes path.2 and so on, keeping maxBackups old files
+const (
+	maxFileSize = 10 << 20
+	maxBackups  = 3
+)
+
+// Open starts writing every subsystem's records to the log file at path, appending to it,
+// in place of any log file open before
+func Open(path string) error {
+	file, err := openRotatingFile(path)
+	if err != nil {
+		return err
+	}
+	return output.set(file)
+}
+
+// Close closes the log file. Records are still kept for Recent.
+func Close() error {
+	return output.set(nil)
+}
+
+// rotatingFile is a log file that moves aside when it grows too big
+type rotatingFile struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	size int64
+}
+
+// openRotatingFile opens the log file at path for appending
+func openRotatingFile(path string) (*rotatingFile, error) {
+	r := &rotatingFile{path: path}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// open opens the file at r.path for appending and notes its size
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	r.file, r.size = file, info.Size()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.size > 0 && r.size+int64(len(p)) > maxFileSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotate moves the log file and its backups one number up, dropping the oldest, and starts
+// a new file
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	for i := maxBackups - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+	}
+	os.Rename(r.path, r.path+".1") // A file that cannot be moved aside is written on
+	return r.open()
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
@@ -0,0 +1,108 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// DefaultLevel is the level of the subsystems a level spec does not set
+const DefaultLevel = slog.LevelInfo
+
+// levelOff is above every level, for a subsystem that writes nothing
+const levelOff = slog.LevelError + 4
+
+// LevelNames are the levels a spec can use, from the most verbose
+var LevelNames = []string{"debug", "info", "warn", "error", "off"}
+
+// levels maps LevelNames to their levels
+var levels = map[string]slog.Level{
+	"debug": slog.LevelDebug,
+	"info":  slog.LevelInfo,
+	"warn":  slog.LevelWarn,
+	"error": slog.LevelError,
+	"off":   levelOff,
+}
+
+// SetLevels sets the level of every subsystem from a spec: a level for all of them, then
+// levels for single subsystems, such as "warn,playback=debug". Subsystems the spec does not set
+// get DefaultLevel, and an empty spec sets them all to it. Levels apply at once, also while
+// logging.
+func SetLevels(spec string) error {
+	set := make(map[*Logger]slog.Level)
+	base := DefaultLevel
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(strings.ToLower(part))
+		if part == "" {
+			continue
+		}
+		name, levelName, ok := strings.Cut(part, "=")
+		if !ok {
+			name, levelName = "", name
+		}
+		level, ok := levels[levelName]
+		if !ok {
+			return fmt.Errorf("unknown log level %q (use %s)", levelName, strings.Join(LevelNames, ", "))
+		}
+		if name == "" {
+			base = level
+			continue
+		}
+		logger := Subsystem(name)
+		if logger == nil {
+			return fmt.Errorf("unknown log subsystem %q (use %s)", name, strings.Join(SubsystemNames(), ", "))
+		}
+		set[logger] = level
+	}
+	for _, logger := range loggers {
+		level, ok := set[logger]
+		if !ok {
+			level = base
+		}
+		logger.level.Set(level)
+	}
+	return nil
+}
+
+// BaseLevel returns the level a spec gives every subsystem it does not name
+func BaseLevel(spec string) string {
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(strings.ToLower(part))
+		if _, ok := levels[part]; ok {
+			return part
+		}
+	}
+	return strings.ToLower(DefaultLevel.String())
+}
+
+// WithBaseLevel returns a spec with its level for all subsystems changed to level, keeping
+// the levels of single subsystems
+func WithBaseLevel(spec, level string) string {
+	parts := []string{level}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(strings.ToLower(part))
+		if strings.Contains(part, "=") {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// Subsystem returns the logger of a subsystem by name, or nil
+func Subsystem(name string) *Logger {
+	for _, logger := range loggers {
+		if logger.name == name {
+			return logger
+		}
+	}
+	return nil
+}
+
+// SubsystemNames returns the names of the subsystems
+func SubsystemNames() []string {
+	names := make([]string, len(loggers))
+	for i, logger := range loggers {
+		names[i] = logger.name
+	}
+	return names
+}
@@ -0,0 +1,218 @@
+// Package logging writes the --log file as JSON lines. Each line comes from one of the
+// tracker's subsystems, and each subsystem has its own level, which can change while running.
+// The latest lines are also kept in memory, with or without a log file, for bug reports.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Logger logs for one subsystem. Its f methods format the message only when the level is
+// on, so lines written on every playback tick cost nothing while they are filtered out.
+type Logger struct {
+	*slog.Logger
+	name  string
+	level *slog.LevelVar
+}
+
+// The subsystems of the tracker
+var (
+	OSC      = newLogger("osc")      // Messages to and from SuperCollider and OSC engines
+	MIDI     = newLogger("midi")     // MIDI devices, notes and sync
+	Playback = newLogger("playback") // The sequencer: rows, ticks and timing
+	Storage  = newLogger("storage")  // Projects, samples and config files
+	UI       = newLogger("ui")       // The interface and everything else
+)
+
+// loggers are the subsystems in the order SetLevels names them
+var loggers = []*Logger{OSC, MIDI, Playback, Storage, UI}
+
+// newLogger returns the logger of a subsystem, at DefaultLevel
+func newLogger(name string) *Logger {
+	level := new(slog.LevelVar)
+	level.Set(DefaultLevel)
+	base := slog.NewJSONHandler(output, &slog.HandlerOptions{AddSource: true, Level: slog.LevelDebug})
+	h := &handler{Handler: base.WithAttrs([]slog.Attr{slog.String("subsystem", name)}), level: level}
+	return &Logger{Logger: slog.New(h), name: name, level: level}
+}
+
+// Debugf logs a formatted message at debug level
+func (l *Logger) Debugf(format string, args ...any) { l.logf(slog.LevelDebug, format, args) }
+
+// Infof logs a formatted message at info level
+func (l *Logger) Infof(format string, args ...any) { l.logf(slog.LevelInfo, format, args) }
+
+// Warnf logs a formatted message at warn level
+func (l *Logger) Warnf(format string, args ...any) { l.logf(slog.LevelWarn, format, args) }
+
+// Errorf logs a formatted message at error level
+func (l *Logger) Errorf(format string, args ...any) { l.logf(slog.LevelError, format, args) }
+
+// logf formats and writes a message when its level is on, with the source of the f method's caller
+func (l *Logger) logf(level slog.Level, format string, args []any) {
+	ctx := context.Background()
+	if !l.Enabled(ctx, level) {
+		return
+	}
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // Skip runtime.Callers, logf and the f method
+	record := slog.NewRecord(time.Now(), level, fmt.Sprintf(format, args...), pcs[0])
+	_ = l.Handler().Handle(ctx, record)
+}
+
+// handler writes the records of one subsystem at or above its level
+type handler struct {
+	slog.Handler
+	level *slog.LevelVar
+}
+
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &handler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
+
+// StandardWriter is the output for the standard log package, which the tracker only uses
+// for the output of SuperCollider and of libraries. Each line becomes a record of the
+// subsystem of the code that wrote it, at debug level, or at warn or error level when it
+// reads like a warning or an error. Set the standard log's flags to 0: the records carry
+// their own time and source.
+func StandardWriter() io.Writer {
+	return standardWriter{}
+}
+
+type standardWriter struct{}
+
+func (standardWriter) Write(p []byte) (int, error) {
+	message := strings.TrimSuffix(string(p), "\n")
+	level := levelOf(message)
+	ctx := context.Background()
+	if !anyEnabled(ctx, level) {
+		return len(p), nil // Skips walking the stack for lines no subsystem writes
+	}
+	pc, function := standardCaller()
+	if logger := subsystemOf(function); logger.Enabled(ctx, level) {
+		_ = logger.Handler().Handle(ctx, slog.NewRecord(time.Now(), level, message, pc))
+	}
+	return len(p), nil
+}
+
+// anyEnabled reports whether any subsystem writes records at level
+func anyEnabled(ctx context.Context, level slog.Level) bool {
+	for _, logger := range loggers {
+		if logger.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// standardCaller returns the frame of the code that called the standard log package
+func standardCaller() (uintptr, string) {
+	var pcs [16]uintptr
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "log.") && !strings.Contains(frame.Function, "/internal/logging.") {
+			return frame.PC, frame.Function
+		}
+		if !more {
+			return 0, ""
+		}
+	}
+}
+
+// subsystemOf returns the subsystem of a function, by its package or, for code that serves
+// several subsystems, its name
+func subsystemOf(function string) *Logger {
+	switch {
+	case strings.Contains(function, "/internal/storage."):
+		return Storage
+	case strings.Contains(function, "/internal/midiplayer."), strings.Contains(function, "/internal/midiconnector."),
+		strings.Contains(function, "Midi"), strings.Contains(function, "MIDI"):
+		return MIDI
+	case strings.Contains(function, "/internal/supercollider."), strings.Contains(function, "OSC"):
+		return OSC
+	case strings.Contains(function, "Playback"), strings.Contains(function, "Emit"), strings.Contains(function, "Arpeggio"):
+		return Playback
+	}
+	return UI
+}
+
+// levelOf guesses the level of a line written with the standard log package
+func levelOf(message string) slog.Level {
+	lower := strings.ToLower(message)
+	for _, prefix := range []string{"error", "fatal", "failed"} {
+		if strings.HasPrefix(lower, prefix) {
+			return slog.LevelError
+		}
+	}
+	for _, prefix := range []string{"warning", "could not", "cannot"} {
+		if strings.HasPrefix(lower, prefix) {
+			return slog.LevelWarn
+		}
+	}
+	return slog.LevelDebug
+}
+
+// recentLines is how many of the latest records Recent returns
+const recentLines = 1000
+
+// output is where all subsystems write: the open log file, if any, and the recent records
+var output = &switchWriter{}
+
+// switchWriter keeps the recent records and passes them on to a writer that can be replaced
+type switchWriter struct {
+	mu     sync.Mutex
+	w      io.WriteCloser
+	recent [recentLines][]byte // Ring of the latest records, next being the oldest once full
+	next   int
+}
+
+func (s *switchWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recent[s.next] = append(s.recent[s.next][:0], p...) // Handlers reuse their buffers
+	s.next = (s.next + 1) % recentLines
+	if s.w == nil {
+		return len(p), nil
+	}
+	return s.w.Write(p)
+}
+
+// Recent returns the latest records of all subsystems as JSON lines, oldest first
+func Recent() []byte {
+	output.mu.Lock()
+	defer output.mu.Unlock()
+	var out []byte
+	for i := range recentLines {
+		out = append(out, output.recent[(output.next+i)%recentLines]...)
+	}
+	return out
+}
+
+// set replaces the writer, closing the one it replaces
+func (s *switchWriter) set(w io.WriteCloser) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var err error
+	if s.w != nil {
+		err = s.w.Close()
+	}
+	s.w = w
+	return err
+}
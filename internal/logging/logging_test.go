@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// records reads the JSON lines of a log file
+func records(t *testing.T, path string) []map[string]any {
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var out []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		out = append(out, record)
+	}
+	return out
+}
+
+func TestLogging(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tracker.log")
+	assert.NoError(t, SetLevels(""))
+	Playback.Infof("kept in memory, no file open")
+	assert.NoError(t, Open(path))
+	defer Close()
+
+	assert.NoError(t, SetLevels("warn,playback=debug"))
+	Playback.Debugf("row %02X", 10)
+	OSC.Infof("filtered out")
+	OSC.Error("send failed", "address", "/sampler")
+
+	// Lines of the standard log package go to their subsystem at a guessed level
+	std := log.New(StandardWriter(), "", 0)
+	std.Printf("Error reading %s", "config.json")
+	std.Printf("just chatter")
+	assert.NoError(t, Close())
+
+	got := records(t, path)
+	assert.Len(t, got, 3)
+	assert.Equal(t, "playback", got[0]["subsystem"])
+	assert.Equal(t, "DEBUG", got[0]["level"])
+	assert.Equal(t, "row 0A", got[0]["msg"])
+	assert.Contains(t, got[0]["source"].(map[string]any)["file"], "logging_test.go")
+	assert.Equal(t, "/sampler", got[1]["address"])
+	assert.Equal(t, "ERROR", got[2]["level"])
+	assert.Equal(t, "Error reading config.json", got[2]["msg"])
+
+	assert.Error(t, SetLevels("loud"))
+	assert.Error(t, SetLevels("gui=debug"))
+	assert.Equal(t, "warn", BaseLevel("warn,playback=debug"))
+	assert.Equal(t, "info", BaseLevel("playback=debug"))
+	assert.Equal(t, "error,playback=debug", WithBaseLevel("warn,playback=debug", "error"))
+	assert.NoError(t, SetLevels(""))
+
+	recent := string(Recent())
+	assert.Contains(t, recent, "kept in memory, no file open")
+	assert.Contains(t, recent, "Error reading config.json")
+	assert.NotContains(t, recent, "filtered out")
+
+	// Lines no subsystem writes are dropped before their caller is looked up
+	assert.NoError(t, SetLevels("off"))
+	std.Printf("Error while off")
+	assert.NotContains(t, string(Recent()), "Error while off")
+	assert.NoError(t, SetLevels(""))
+}
+
+func TestRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tracker.log")
+	file, err := openRotatingFile(path)
+	assert.NoError(t, err)
+	line := []byte(strings.Repeat("x", 1<<20) + "\n")
+	for i := 0; i < 12*(maxBackups+1); i++ {
+		_, err := file.Write(line)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, file.Close())
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, info.Size(), int64(maxFileSize))
+	for i := 1; i <= maxBackups; i++ {
+		assert.FileExists(t, fmt.Sprintf("%s.%d", path, i))
+	}
+	assert.NoFileExists(t, fmt.Sprintf("%s.%d", path, maxBackups+1), "The oldest backup is dropped")
+}
@@ -0,0 +1,322 @@
+// Package loudness measures the integrated loudness (ITU-R BS.1770-4) and true peak of
+// rendered audio, and normalizes it to a loudness target.
+package loudness
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-audio/audio"
+	"github.com/go-audio/wav"
+)
+
+const (
+	blockSeconds = 0.4   // Gating block length
+	stepSeconds  = 0.1   // Gating blocks overlap by 75%
+	absoluteGate = -70.0 // LUFS
+	relativeGate = -10.0 // LU below the loudness of the blocks above the absolute gate
+	oversampling = 4     // True peak is measured at four times the sample rate
+	tapsPerPhase = 16    // Length of each phase of the interpolation filter
+	reportSuffix = ".loudness.txt"
+	silenceFloor = -200.0 // Levels below are shown as -inf
+)
+
+// Report is what a render measured
+type Report struct {
+	IntegratedLUFS float64 // Gated integrated loudness (-Inf for silence)
+	TruePeakDBTP   float64 // Highest peak of the 4x oversampled signal (-Inf for silence)
+	SampleRate     int
+	Seconds        float64
+}
+
+// Measure measures interleaved samples in -1..1
+func Measure(samples []float64, channels, sampleRate int) Report {
+	report := Report{IntegratedLUFS: math.Inf(-1), TruePeakDBTP: math.Inf(-1), SampleRate: sampleRate}
+	if channels <= 0 || sampleRate <= 0 {
+		return report
+	}
+	frames := len(samples) / channels
+	report.Seconds = float64(frames) / float64(sampleRate)
+
+	// Mean square of the K-weighted signal per 100 ms step, summed over the channels
+	stepFrames := int(stepSeconds * float64(sampleRate))
+	steps := make([]float64, frames/stepFrames)
+	peak := 0.0
+	for ch := 0; ch < channels; ch++ {
+		filter := newKWeighting(sampleRate)
+		interp := newInterpolator()
+		for i := 0; i < frames; i++ {
+			x := samples[i*channels+ch]
+			peak = math.Max(peak, interp.peak(x))
+			if step := i / stepFrames; step < len(steps) {
+				y := filter.process(x)
+				steps[step] += y * y
+			}
+		}
+	}
+	if peak > 0 {
+		report.TruePeakDBTP = 20 * math.Log10(peak)
+	}
+
+	// 400 ms blocks are four consecutive steps
+	stepsPerBlock := int(math.Round(blockSeconds / stepSeconds))
+	var blocks []float64
+	for i := 0; i+stepsPerBlock <= len(steps); i++ {
+		sum := 0.0
+		for _, power := range steps[i : i+stepsPerBlock] {
+			sum += power
+		}
+		blocks = append(blocks, sum/float64(stepsPerBlock*stepFrames))
+	}
+	report.IntegratedLUFS = gatedLoudness(blocks)
+	return report
+}
+
+// gatedLoudness returns the loudness of the blocks that pass the absolute and relative gates
+func gatedLoudness(blocks []float64) float64 {
+	mean := func(threshold float64) float64 {
+		sum, n := 0.0, 0
+		for _, power := range blocks {
+			if blockLoudness(power) > threshold {
+				sum += power
+				n++
+			}
+		}
+		if n == 0 {
+			return math.Inf(-1)
+		}
+		return blockLoudness(sum / float64(n))
+	}
+	ungated := mean(absoluteGate)
+	if math.IsInf(ungated, -1) {
+		return ungated
+	}
+	return mean(math.Max(absoluteGate, ungated+relativeGate))
+}
+
+// blockLoudness converts the summed mean square of a block to LUFS
+func blockLoudness(power float64) float64 {
+	if power <= 0 {
+		return math.Inf(-1)
+	}
+	return -0.691 + 10*math.Log10(power)
+}
+
+// biquad is a second-order IIR filter
+type biquad struct {
+	b0, b1, b2, a1, a2 float64
+	x1, x2, y1, y2     float64
+}
+
+func (f *biquad) process(x float64) float64 {
+	y := f.b0*x + f.b1*f.x1 + f.b2*f.x2 - f.a1*f.y1 - f.a2*f.y2
+	f.x2, f.x1 = f.x1, x
+	f.y2, f.y1 = f.y1, y
+	return y
+}
+
+// kWeighting is the BS.1770 pre-filter: a high shelf for the head, then a high pass
+type kWeighting struct {
+	shelf, highPass biquad
+}
+
+// newKWeighting returns the K-weighting filter for a sample rate. The coefficients are
+// derived so that they match the ones the standard gives for 48 kHz.
+func newKWeighting(sampleRate int) *kWeighting {
+	fs := float64(sampleRate)
+
+	f0, gain, q := 1681.974450955533, 3.999843853973347, 0.7071752369554196
+	k := math.Tan(math.Pi * f0 / fs)
+	vh := math.Pow(10, gain/20)
+	vb := math.Pow(vh, 0.4996667741545416)
+	a0 := 1 + k/q + k*k
+	shelf := biquad{
+		b0: (vh + vb*k/q + k*k) / a0,
+		b1: 2 * (k*k - vh) / a0,
+		b2: (vh - vb*k/q + k*k) / a0,
+		a1: 2 * (k*k - 1) / a0,
+		a2: (1 - k/q + k*k) / a0,
+	}
+
+	f0, q = 38.13547087602444, 0.5003270373238773
+	k = math.Tan(math.Pi * f0 / fs)
+	a0 = 1 + k/q + k*k
+	highPass := biquad{
+		b0: 1, b1: -2, b2: 1,
+		a1: 2 * (k*k - 1) / a0,
+		a2: (1 - k/q + k*k) / a0,
+	}
+	return &kWeighting{shelf: shelf, highPass: highPass}
+}
+
+func (k *kWeighting) process(x float64) float64 {
+	return k.highPass.process(k.shelf.process(x))
+}
+
+// interpolator finds the peaks between samples with a windowed-sinc polyphase filter
+type interpolator struct {
+	phases  [oversampling][tapsPerPhase]float64
+	history [tapsPerPhase]float64
+	next    int
+}
+
+func newInterpolator() *interpolator {
+	in := &interpolator{}
+	taps := oversampling * tapsPerPhase
+	for p := 0; p < oversampling; p++ {
+		for k := 0; k < tapsPerPhase; k++ {
+			// Position of the tap in input samples, relative to the interpolated point
+			n := k*oversampling + p
+			t := float64(n-taps/2) / oversampling
+			window := 0.42 - 0.5*math.Cos(2*math.Pi*float64(n)/float64(taps)) + 0.08*math.Cos(4*math.Pi*float64(n)/float64(taps))
+			sinc := 1.0
+			if t != 0 {
+				sinc = math.Sin(math.Pi*t) / (math.Pi * t)
+			}
+			in.phases[p][k] = sinc * window
+		}
+	}
+	return in
+}
+
+// peak adds a sample and returns the largest magnitude of the points interpolated up to it
+func (in *interpolator) peak(x float64) float64 {
+	in.history[in.next] = x
+	in.next = (in.next + 1) % tapsPerPhase
+	peak := 0.0
+	for p := range in.phases {
+		sum := 0.0
+		for k, coefficient := range in.phases[p] {
+			// Newest sample first
+			sum += coefficient * in.history[(in.next-1-k+2*tapsPerPhase)%tapsPerPhase]
+		}
+		peak = math.Max(peak, math.Abs(sum))
+	}
+	return math.Max(peak, math.Abs(x))
+}
+
+// Gain returns the gain in dB that brings a report to the target loudness without the true
+// peak going over ceiling, and whether the ceiling limited it
+func Gain(report Report, targetLUFS, ceilingDBTP float64) (gain float64, limited bool) {
+	if math.IsInf(report.IntegratedLUFS, -1) {
+		return 0, false
+	}
+	gain = targetLUFS - report.IntegratedLUFS
+	if headroom := ceilingDBTP - report.TruePeakDBTP; gain > headroom {
+		return headroom, true
+	}
+	return gain, false
+}
+
+// readWAV returns the samples of a WAV in -1..1, interleaved, with the decoded buffer
+func readWAV(path string) ([]float64, *audio.IntBuffer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	decoder := wav.NewDecoder(f)
+	if !decoder.IsValidFile() {
+		return nil, nil, fmt.Errorf("%s is not a WAV file", filepath.Base(path))
+	}
+	buf, err := decoder.FullPCMBuffer()
+	if err != nil {
+		return nil, nil, err
+	}
+	if buf.Format == nil || buf.Format.NumChannels <= 0 || buf.SourceBitDepth <= 0 {
+		return nil, nil, fmt.Errorf("%s has no audio format", filepath.Base(path))
+	}
+	scale := math.Pow(2, float64(buf.SourceBitDepth-1))
+	samples := make([]float64, len(buf.Data))
+	for i, v := range buf.Data {
+		samples[i] = float64(v) / scale
+	}
+	return samples, buf, nil
+}
+
+// MeasureFile measures a PCM WAV file
+func MeasureFile(path string) (Report, error) {
+	samples, buf, err := readWAV(path)
+	if err != nil {
+		return Report{}, err
+	}
+	return Measure(samples, buf.Format.NumChannels, buf.Format.SampleRate), nil
+}
+
+// NormalizeFile applies a gain in dB to a PCM WAV file in place
+func NormalizeFile(path string, gainDB float64) error {
+	_, buf, err := readWAV(path)
+	if err != nil {
+		return err
+	}
+	factor := math.Pow(10, gainDB/20)
+	maxValue := math.Pow(2, float64(buf.SourceBitDepth-1)) - 1
+	for i, v := range buf.Data {
+		buf.Data[i] = int(math.Max(-maxValue-1, math.Min(maxValue, math.Round(float64(v)*factor))))
+	}
+
+	tmp := path + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	encoder := wav.NewEncoder(out, buf.Format.SampleRate, buf.SourceBitDepth, buf.Format.NumChannels, 1)
+	if err = encoder.Write(buf); err == nil {
+		err = encoder.Close()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Normalization is the gain a render was normalized with
+type Normalization struct {
+	Target     string  // Name of the target, e.g. "stream"
+	TargetLUFS float64 // Loudness aimed for
+	Gain       float64 // Gain applied in dB
+	Limited    bool    // Whether the true peak ceiling kept the gain below the target
+	Ceiling    float64 // True peak ceiling in dBTP
+}
+
+// ReportPath returns where the loudness report of a WAV file is written
+func ReportPath(wavPath string) string {
+	return strings.TrimSuffix(wavPath, filepath.Ext(wavPath)) + reportSuffix
+}
+
+// FormatLevel formats a loudness or peak, with "-inf" for silence
+func FormatLevel(value float64) string {
+	if math.IsInf(value, -1) || value < silenceFloor {
+		return "-inf"
+	}
+	return fmt.Sprintf("%.1f", value)
+}
+
+// WriteReport writes the loudness report of a WAV file next to it. normalization is nil when
+// the file was left as rendered.
+func WriteReport(wavPath string, report Report, normalization *Normalization) error {
+	var text strings.Builder
+	fmt.Fprintf(&text, "File:                %s\n", filepath.Base(wavPath))
+	fmt.Fprintf(&text, "Length:              %.2f s at %d Hz\n", report.Seconds, report.SampleRate)
+	fmt.Fprintf(&text, "Integrated loudness: %s LUFS\n", FormatLevel(report.IntegratedLUFS))
+	fmt.Fprintf(&text, "True peak:           %s dBTP\n", FormatLevel(report.TruePeakDBTP))
+	if normalization == nil {
+		text.WriteString("Normalization:       off\n")
+	} else {
+		fmt.Fprintf(&text, "Normalization:       %s (%.0f LUFS, ceiling %.1f dBTP), gain %+.1f dB\n",
+			normalization.Target, normalization.TargetLUFS, normalization.Ceiling, normalization.Gain)
+		if normalization.Limited {
+			text.WriteString("                     limited by the true peak ceiling\n")
+		}
+		fmt.Fprintf(&text, "Normalized loudness: %s LUFS\n", FormatLevel(report.IntegratedLUFS+normalization.Gain))
+		fmt.Fprintf(&text, "Normalized peak:     %s dBTP\n", FormatLevel(report.TruePeakDBTP+normalization.Gain))
+	}
+	return os.WriteFile(ReportPath(wavPath), []byte(text.String()), 0644)
+}
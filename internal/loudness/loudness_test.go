@@ -0,0 +1,92 @@
+package loudness
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-audio/audio"
+	"github.com/go-audio/wav"
+	"github.com/stretchr/testify/assert"
+)
+
+// sine returns seconds of a stereo sine in both channels
+func sine(freq, amplitude, phase float64, sampleRate int, seconds float64) []float64 {
+	frames := int(seconds * float64(sampleRate))
+	samples := make([]float64, 0, frames*2)
+	for i := 0; i < frames; i++ {
+		x := amplitude * math.Sin(2*math.Pi*freq*float64(i)/float64(sampleRate)+phase)
+		samples = append(samples, x, x)
+	}
+	return samples
+}
+
+func TestMeasure(t *testing.T) {
+	// A 1 kHz sine at -6 dBFS in both channels reads -6 LUFS
+	report := Measure(sine(1000, 0.5, 0, 48000, 5), 2, 48000)
+	assert.InDelta(t, -6.02, report.IntegratedLUFS, 0.1)
+	assert.InDelta(t, -6.02, report.TruePeakDBTP, 0.1)
+	assert.InDelta(t, 5.0, report.Seconds, 0.001)
+
+	// The same at 44.1 kHz
+	report = Measure(sine(1000, 0.5, 0, 44100, 5), 2, 44100)
+	assert.InDelta(t, -6.02, report.IntegratedLUFS, 0.1)
+
+	// Samples of a sine at a quarter of the sample rate, 45° off, miss its peaks by 3 dB
+	report = Measure(sine(12000, 1, math.Pi/4, 48000, 1), 2, 48000)
+	assert.InDelta(t, 0, report.TruePeakDBTP, 0.3)
+
+	// Quiet passages below the gates don't count (only the blocks across the drop do)
+	quiet := append(sine(1000, 0.5, 0, 48000, 5), sine(1000, 0.001, 0, 48000, 5)...)
+	assert.InDelta(t, -6.02, Measure(quiet, 2, 48000).IntegratedLUFS, 0.2)
+
+	silence := Measure(make([]float64, 96000), 2, 48000)
+	assert.True(t, math.IsInf(silence.IntegratedLUFS, -1))
+	assert.Equal(t, "-inf", FormatLevel(silence.TruePeakDBTP))
+}
+
+func TestGain(t *testing.T) {
+	gain, limited := Gain(Report{IntegratedLUFS: -20, TruePeakDBTP: -10}, -14, -1)
+	assert.InDelta(t, 6, gain, 1e-9)
+	assert.False(t, limited)
+
+	gain, limited = Gain(Report{IntegratedLUFS: -20, TruePeakDBTP: -3}, -14, -1)
+	assert.InDelta(t, 2, gain, 1e-9)
+	assert.True(t, limited)
+
+	gain, _ = Gain(Report{IntegratedLUFS: math.Inf(-1), TruePeakDBTP: math.Inf(-1)}, -14, -1)
+	assert.Equal(t, 0.0, gain)
+}
+
+func TestNormalizeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "loop.wav")
+	samples := sine(1000, 0.25, 0, 48000, 3)
+	data := make([]int, len(samples))
+	for i, x := range samples {
+		data[i] = int(math.Round(x * 32767))
+	}
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	encoder := wav.NewEncoder(f, 48000, 16, 2, 1)
+	assert.NoError(t, encoder.Write(&audio.IntBuffer{Format: &audio.Format{NumChannels: 2, SampleRate: 48000}, Data: data, SourceBitDepth: 16}))
+	assert.NoError(t, encoder.Close())
+	assert.NoError(t, f.Close())
+
+	report, err := MeasureFile(path)
+	assert.NoError(t, err)
+	assert.InDelta(t, -12.04, report.IntegratedLUFS, 0.1)
+
+	gain, _ := Gain(report, -9, -1)
+	assert.NoError(t, NormalizeFile(path, gain))
+	report, err = MeasureFile(path)
+	assert.NoError(t, err)
+	assert.InDelta(t, -9, report.IntegratedLUFS, 0.1)
+
+	assert.NoError(t, WriteReport(path, report, &Normalization{Target: "club", TargetLUFS: -9, Gain: gain, Ceiling: -1}))
+	text, err := os.ReadFile(ReportPath(path))
+	assert.NoError(t, err)
+	assert.Contains(t, string(text), "Integrated loudness: -9.0 LUFS")
+	assert.Contains(t, string(text), "club (-9 LUFS, ceiling -1.0 dBTP)")
+	assert.Equal(t, filepath.Join(filepath.Dir(path), "loop.loudness.txt"), ReportPath(path))
+}
@@ -4,13 +4,14 @@ package midiconnector
 
 import (
 	"fmt"
-	"log"
 	"strings"
 	"sync"
 
 	"gitlab.com/gomidi/midi/v2"
 	"gitlab.com/gomidi/midi/v2/drivers"
 	_ "gitlab.com/gomidi/midi/v2/drivers/rtmididrv"
+
+	"github.com/schollz/collidertracker/internal/logging"
 )
 
 var mutex sync.Mutex
@@ -124,7 +125,7 @@ func (d *Device) ControlChange(channel, controller, value uint8) (err error) {
 		err = out.Send([]byte{0xB0 | channel, controller, value})
 		if err != nil {
 			// Log MIDI errors instead of letting them print to stderr
-			log.Printf("MIDI ControlChange error for device %s: %v", d.name, err)
+			logging.MIDI.Warnf("MIDI ControlChange error for device %s: %v", d.name, err)
 		}
 	}
 	return
@@ -137,7 +138,7 @@ func (d *Device) NoteOn(channel, note, velocity uint8) (err error) {
 		err = out.Send([]byte{0x90 | channel, note, velocity})
 		if err != nil {
 			// Log MIDI errors instead of letting them print to stderr
-			log.Printf("MIDI NoteOn error for device %s: %v", d.name, err)
+			logging.MIDI.Warnf("MIDI NoteOn error for device %s: %v", d.name, err)
 		} else {
 			d.notesOn[note] = channel
 		}
@@ -152,7 +153,7 @@ func (d *Device) NoteOff(channel, note uint8) (err error) {
 		err = out.Send([]byte{0x80 | channel, note, 0})
 		if err != nil {
 			// Log MIDI errors instead of letting them print to stderr
-			log.Printf("MIDI NoteOff error for device %s: %v", d.name, err)
+			logging.MIDI.Warnf("MIDI NoteOff error for device %s: %v", d.name, err)
 		} else {
 			delete(d.notesOn, note)
 		}
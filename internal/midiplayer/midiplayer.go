@@ -3,12 +3,12 @@ package midiplayer
 import (
 	"context"
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/schollz/collidertracker/internal/logging"
 	"github.com/schollz/collidertracker/internal/midiconnector"
 )
 
@@ -109,7 +109,7 @@ func (m *Player) NoteOn(note int, velocity int) (err error) {
 }
 
 func (m *Player) ControlChange(controller int, value int) (err error) {
-	log.Printf("[MIDIPLAYER] ControlChange called on %s: controller=%d, value=%d", m.Name, controller, value)
+	logging.MIDI.Debugf("ControlChange called on %s: controller=%d, value=%d", m.Name, controller, value)
 	if m.opened {
 		err = m.Device.ControlChange(m.channel, uint8(controller), uint8(value))
 	}
@@ -130,7 +130,7 @@ func (m *Player) NoteOff(note int) (err error) {
 			}
 		}
 		if tempErr != nil || err != nil {
-			log.Printf("[MIDIPLAYER] Error sending note-off to closed device: %v", err)
+			logging.MIDI.Errorf("Error sending note-off to closed device: %v", err)
 		}
 	}
 	return
@@ -142,7 +142,7 @@ func getGlobalState() *GlobalMidiState {
 		globalState = &GlobalMidiState{
 			instruments: make(map[string]*InstrumentState),
 		}
-		log.Printf("[MIDIPLAYER] Global MIDI state initialized")
+		logging.MIDI.Debugf("Global MIDI state initialized")
 	})
 	return globalState
 }
@@ -192,7 +192,7 @@ func (gms *GlobalMidiState) getOrCreateInstrument(midiinstrument string, channel
 
 	// Check if we already have this instrument:channel combination
 	if inst, exists := gms.instruments[instrumentKey]; exists {
-		log.Printf("[MIDIPLAYER] Found existing instrument: %s (channel %d)", midiinstrument, channel)
+		logging.MIDI.Debugf("Found existing instrument: %s (channel %d)", midiinstrument, channel)
 		return inst, nil
 	}
 
@@ -202,7 +202,7 @@ func (gms *GlobalMidiState) getOrCreateInstrument(midiinstrument string, channel
 		return nil, fmt.Errorf("no MIDI instrument found containing '%s'", midiinstrument)
 	}
 
-	log.Printf("[MIDIPLAYER] Found device '%s' for search string '%s' on channel %d", actualDeviceName, midiinstrument, channel)
+	logging.MIDI.Debugf("Found device '%s' for search string '%s' on channel %d", actualDeviceName, midiinstrument, channel)
 
 	// Create new player with the specified channel
 	player, err := New(actualDeviceName, channel)
@@ -217,7 +217,7 @@ func (gms *GlobalMidiState) getOrCreateInstrument(midiinstrument string, channel
 	}
 
 	gms.instruments[instrumentKey] = instrumentState
-	log.Printf("[MIDIPLAYER] Created new instrument: %s -> %s (channel %d)", midiinstrument, actualDeviceName, channel)
+	logging.MIDI.Debugf("Created new instrument: %s -> %s (channel %d)", midiinstrument, actualDeviceName, channel)
 
 	return instrumentState, nil
 }
@@ -234,7 +234,7 @@ func NoteOn(midiinstrument string, note float64, velocity float64, duration floa
 	noteInt := int(note)
 	velocityInt := int(velocity)
 
-	log.Printf("[MIDIPLAYER] NoteOn called: instrument=%s, note=%d, velocity=%d, duration=%.3fs, channel=%d",
+	logging.MIDI.Debugf("NoteOn called: instrument=%s, note=%d, velocity=%d, duration=%.3fs, channel=%d",
 		midiinstrument, noteInt, velocityInt, duration, channel)
 
 	// Get or create instrument
@@ -248,7 +248,7 @@ func NoteOn(midiinstrument string, note float64, velocity float64, duration floa
 
 	// Check if this note is already playing
 	if existingNote, exists := instrument.Notes[noteInt]; exists {
-		log.Printf("[MIDIPLAYER] Note %d already playing on %s, cancelling previous and sending note-off",
+		logging.MIDI.Debugf("Note %d already playing on %s, cancelling previous and sending note-off",
 			noteInt, midiinstrument)
 
 		// Cancel the existing note-off goroutine
@@ -257,7 +257,7 @@ func NoteOn(midiinstrument string, note float64, velocity float64, duration floa
 		// Send immediate note-off
 		err := instrument.Player.NoteOff(noteInt)
 		if err != nil {
-			log.Printf("[MIDIPLAYER] Error sending immediate note-off for note %d: %v", noteInt, err)
+			logging.MIDI.Errorf("Error sending immediate note-off for note %d: %v", noteInt, err)
 		}
 	}
 
@@ -267,7 +267,7 @@ func NoteOn(midiinstrument string, note float64, velocity float64, duration floa
 		return fmt.Errorf("failed to send note-on for note %d: %v", noteInt, err)
 	}
 
-	log.Printf("[MIDIPLAYER] Note-on sent: instrument=%s, note=%d, velocity=%d",
+	logging.MIDI.Debugf("Note-on sent: instrument=%s, note=%d, velocity=%d",
 		midiinstrument, noteInt, velocityInt)
 
 	// Create cancellable context for note-off
@@ -288,12 +288,12 @@ func NoteOn(midiinstrument string, note float64, velocity float64, duration floa
 		select {
 		case <-timer.C:
 			// Duration elapsed, send note-off
-			log.Printf("[MIDIPLAYER] Duration elapsed, sending note-off: instrument=%s, note=%d",
+			logging.MIDI.Debugf("Duration elapsed, sending note-off: instrument=%s, note=%d",
 				midiinstrument, noteInt)
 
 			err := instrument.Player.NoteOff(noteInt)
 			if err != nil {
-				log.Printf("[MIDIPLAYER] Error sending note-off for note %d: %v", noteInt, err)
+				logging.MIDI.Errorf("Error sending note-off for note %d: %v", noteInt, err)
 			}
 
 			// Remove from active notes
@@ -301,13 +301,13 @@ func NoteOn(midiinstrument string, note float64, velocity float64, duration floa
 			instrumentKey := fmt.Sprintf("%s:%d", midiinstrument, channel)
 			if inst, exists := gms.instruments[instrumentKey]; exists {
 				delete(inst.Notes, noteInt)
-				log.Printf("[MIDIPLAYER] Note %d removed from active notes for %s (channel %d)", noteInt, midiinstrument, channel)
+				logging.MIDI.Debugf("Note %d removed from active notes for %s (channel %d)", noteInt, midiinstrument, channel)
 			}
 			gms.mu.Unlock()
 
 		case <-ctx.Done():
 			// Context was cancelled (overlapping note)
-			log.Printf("[MIDIPLAYER] Note-off cancelled for note %d on %s (channel %d, overlapping note)",
+			logging.MIDI.Debugf("Note-off cancelled for note %d on %s (channel %d, overlapping note)",
 				noteInt, midiinstrument, channel)
 		}
 	}()
@@ -324,7 +324,7 @@ func ControlChange(midiinstrument string, controller int, value int, channel int
 
 	gms := getGlobalState()
 
-	log.Printf("[MIDIPLAYER] ControlChange called: instrument=%s, controller=%d, value=%d, channel=%d",
+	logging.MIDI.Debugf("ControlChange called: instrument=%s, controller=%d, value=%d, channel=%d",
 		midiinstrument, controller, value, channel)
 
 	// Get or create instrument
@@ -342,7 +342,7 @@ func ControlChange(midiinstrument string, controller int, value int, channel int
 		return fmt.Errorf("failed to send control change for controller %d: %v", controller, err)
 	}
 
-	log.Printf("[MIDIPLAYER] Control change sent: instrument=%s, controller=%d, value=%d",
+	logging.MIDI.Debugf("Control change sent: instrument=%s, controller=%d, value=%d",
 		midiinstrument, controller, value)
 
 	return nil
@@ -355,25 +355,25 @@ func StopAll(midiinstrument string, channel int) {
 	defer gms.mu.Unlock()
 
 	instrumentKey := fmt.Sprintf("%s:%d", midiinstrument, channel)
-	log.Printf("[MIDIPLAYER] StopAll called for instrument: %s (channel %d)", midiinstrument, channel)
+	logging.MIDI.Debugf("StopAll called for instrument: %s (channel %d)", midiinstrument, channel)
 
 	instrument, exists := gms.instruments[instrumentKey]
 	if !exists {
-		log.Printf("[MIDIPLAYER] Instrument %s (channel %d) not found, nothing to stop", midiinstrument, channel)
+		logging.MIDI.Debugf("Instrument %s (channel %d) not found, nothing to stop", midiinstrument, channel)
 		return
 	}
 
 	if len(instrument.Notes) == 0 {
-		log.Printf("[MIDIPLAYER] No active notes for instrument %s (channel %d)", midiinstrument, channel)
+		logging.MIDI.Debugf("No active notes for instrument %s (channel %d)", midiinstrument, channel)
 		return
 	}
 
-	log.Printf("[MIDIPLAYER] Stopping %d active notes for instrument %s (channel %d)",
+	logging.MIDI.Debugf("Stopping %d active notes for instrument %s (channel %d)",
 		len(instrument.Notes), midiinstrument, channel)
 
 	// Cancel all note-off goroutines and send immediate note-offs
 	for noteInt, noteState := range instrument.Notes {
-		log.Printf("[MIDIPLAYER] Stopping note %d on %s (channel %d)", noteInt, midiinstrument, channel)
+		logging.MIDI.Debugf("Stopping note %d on %s (channel %d)", noteInt, midiinstrument, channel)
 
 		// Cancel the note-off goroutine
 		noteState.Cancel()
@@ -381,11 +381,33 @@ func StopAll(midiinstrument string, channel int) {
 		// Send immediate note-off
 		err := instrument.Player.NoteOff(noteInt)
 		if err != nil {
-			log.Printf("[MIDIPLAYER] Error sending note-off for note %d: %v", noteInt, err)
+			logging.MIDI.Errorf("Error sending note-off for note %d: %v", noteInt, err)
 		}
 	}
 
 	// Clear all notes
 	instrument.Notes = make(map[int]*NoteState)
-	log.Printf("[MIDIPLAYER] All notes stopped for instrument %s (channel %d)", midiinstrument, channel)
+	logging.MIDI.Debugf("All notes stopped for instrument %s (channel %d)", midiinstrument, channel)
+}
+
+// AllNotesOff stops every tracked note and sends All Notes Off (CC 123) to every opened instrument
+func AllNotesOff() {
+	gms := getGlobalState()
+	gms.mu.Lock()
+	defer gms.mu.Unlock()
+
+	for instrumentKey, instrument := range gms.instruments {
+		for noteInt, noteState := range instrument.Notes {
+			noteState.Cancel()
+			if err := instrument.Player.NoteOff(noteInt); err != nil {
+				logging.MIDI.Errorf("Error sending note-off for note %d: %v", noteInt, err)
+			}
+		}
+		instrument.Notes = make(map[int]*NoteState)
+
+		if err := instrument.Player.ControlChange(123, 0); err != nil {
+			logging.MIDI.Errorf("Error sending all notes off to %s: %v", instrumentKey, err)
+		}
+	}
+	logging.MIDI.Debugf("All notes off sent to %d instruments", len(gms.instruments))
 }
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// ActivityFlash is how long a track's activity LED stays lit after one of its rows triggers
+const ActivityFlash = 120 * time.Millisecond
+
+// MarkTrackTriggered records that a row of a track was just sent to SuperCollider
+func (m *Model) MarkTrackTriggered(track int, now time.Time) {
+	if track >= 0 && track < types.NumTracks {
+		m.trackTriggeredAt[track] = now
+	}
+}
+
+// TrackTriggered reports whether a track triggered a row in the last ActivityFlash
+func (m *Model) TrackTriggered(track int, now time.Time) bool {
+	if track < 0 || track >= types.NumTracks || m.trackTriggeredAt[track].IsZero() {
+		return false
+	}
+	return now.Sub(m.trackTriggeredAt[track]) < ActivityFlash
+}
+
+// TrackSilenced reports whether a track's mixer level is all the way down, so it is not heard
+func (m *Model) TrackSilenced(track int) bool {
+	return track >= 0 && track < types.NumTracks && m.TrackSetLevels[track] <= -96
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	onset "github.com/schollz/onsets"
+)
+
+// SampleAnalysis is a batch re-analysis of sample files running in the background
+type SampleAnalysis struct {
+	Files  []string // Sample files to analyze, in order
+	Done   int      // Files analyzed so far
+	Failed int      // Files whose analysis failed
+	Paused bool     // Waiting for eco mode to end before analyzing the next file
+}
+
+// OnsetPreset tunes onset detection to a kind of material
+type OnsetPreset struct {
+	Name      string
+	Method    string  // Detection function of the onsets package
+	WindowMs  float64 // Onsets are moved within this window onto where the sound starts
+	SpacingMs float64 // Onsets closer than this to the one before are dropped (0 keeps them all)
+}
+
+// OnsetPresets are the choices of FileMetadata.OnsetPreset. Drums comes first as it is the
+// setting files were sliced with before there were presets.
+var OnsetPresets = []OnsetPreset{
+	{Name: "Drums", Method: "hfc", WindowMs: 15},                      // Sharp hits, however close: high frequency content
+	{Name: "Melodic", Method: "complex", WindowMs: 30, SpacingMs: 90}, // Note changes without a hard attack: phase and magnitude
+	{Name: "Vocal", Method: "specflux", WindowMs: 40, SpacingMs: 150}, // Soft, breathy starts a syllable apart: spectral flux
+	{Name: "Ambient", Method: "kl", WindowMs: 80, SpacingMs: 400},     // Slow swells: relative change of the spectrum
+}
+
+// OnsetOptions returns the settings onset detection slices a file with, tuned by its preset
+func OnsetOptions(slices, preset int) onset.SliceAnalyzerOptions {
+	if preset < 0 || preset >= len(OnsetPresets) {
+		preset = 0
+	}
+	p := OnsetPresets[preset]
+	return onset.SliceAnalyzerOptions{
+		NumSlices:         slices,
+		Method:            p.Method,
+		Optimize:          true,
+		OptimizeWindowMs:  p.WindowMs,
+		UseMinimumSpacing: p.SpacingMs > 0,
+		MinimumSpacing:    p.SpacingMs,
+	}
+}
+
+// EqualSliceOnsets returns the start times of slices of equal length in a file
+func EqualSliceOnsets(audioLength float64, slices int) []float64 {
+	onsets := make([]float64, slices)
+	for i := range onsets {
+		onsets[i] = float64(i) * audioLength / float64(slices)
+	}
+	return onsets
+}
+
+// ProjectSampleFiles returns the sample files of the project that exist on disk, sorted and
+// each listed once, limited to those inside folder unless it is ""
+func (m *Model) ProjectSampleFiles(folder string) []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, file := range m.SamplerPhrasesFiles {
+		if file == "" || seen[file] {
+			continue
+		}
+		seen[file] = true
+		if folder != "" {
+			if rel, err := filepath.Rel(folder, file); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				continue
+			}
+		}
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
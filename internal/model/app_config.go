@@ -0,0 +1,49 @@
+package model
+
+import (
+	"path/filepath"
+)
+
+// OSC port limits for the Port setting (ColliderTracker also listens on port+1)
+const (
+	MinOSCPort = 1024
+	MaxOSCPort = 65534
+)
+
+// DefaultDumpPath is where Dump writes when it is turned on without a --dump file
+const DefaultDumpPath = "collidertracker-dump.txt"
+
+// ToggleVim switches vim-style cursor movement, which applies immediately
+func (m *Model) ToggleVim() {
+	m.VimMode = !m.VimMode
+	m.Config.Vim = m.VimMode
+}
+
+// ToggleDump turns terminal dumps off, or back on to the last dump file used
+func (m *Model) ToggleDump() {
+	if m.Config.Dump != "" {
+		m.dumpPath = m.Config.Dump
+		m.Config.Dump = ""
+		return
+	}
+	m.Config.Dump = m.dumpPath
+	if m.Config.Dump == "" {
+		m.Config.Dump = DefaultDumpPath
+	}
+}
+
+// DumpName returns the dump file name for the settings view ("off" when disabled)
+func (m *Model) DumpName() string {
+	if m.Config.Dump == "" {
+		return "off"
+	}
+	return filepath.Base(m.Config.Dump)
+}
+
+// SCModeName returns "auto" when ColliderTracker manages SuperCollider, "skip" otherwise
+func (m *Model) SCModeName() string {
+	if m.Config.SkipSC {
+		return "skip"
+	}
+	return "auto"
+}
@@ -0,0 +1,170 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/schollz/collidertracker/internal/music"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+const (
+	// MaxAuditEntries is how many edits the history keeps; older ones are dropped
+	MaxAuditEntries = 1000
+	// auditMergeWindow is how long repeated edits of one cell are merged into one entry, so
+	// holding Ctrl+Up on a value records where it started and ended
+	auditMergeWindow = 30 * time.Second
+	// AuditTimeFormat is how entry times are shown and exported
+	AuditTimeFormat = "2006-01-02 15:04:05"
+)
+
+// auditColumnNames names the phrase columns in the history
+var auditColumnNames = map[types.PhraseColumn]string{
+	types.ColNote:               "note",
+	types.ColPitch:              "pitch",
+	types.ColDeltaTime:          "delta time",
+	types.ColGate:               "gate",
+	types.ColRetrigger:          "retrigger",
+	types.ColTimestretch:        "timestretch",
+	types.ColModulate:           "modulate",
+	types.ColEffectReverse:      "reverse",
+	types.ColPan:                "pan",
+	types.ColLowPassFilter:      "low pass",
+	types.ColHighPassFilter:     "high pass",
+	types.ColEffectComb:         "comb",
+	types.ColEffectReverb:       "reverb",
+	types.ColEffectDucking:      "ducking",
+	types.ColFilename:           "file",
+	types.ColChord:              "chord",
+	types.ColChordAddition:      "chord addition",
+	types.ColChordTransposition: "chord transpose",
+	types.ColArpeggio:           "arpeggio",
+	types.ColMidi:               "midi",
+	types.ColSoundMaker:         "soundmaker",
+	types.ColAttack:             "attack",
+	types.ColDecay:              "decay",
+	types.ColSustain:            "sustain",
+	types.ColRelease:            "release",
+	types.ColVelocity:           "velocity",
+	types.ColNote2:              "note 2",
+	types.ColNote3:              "note 3",
+	types.ColNote4:              "note 4",
+}
+
+// recordAudit adds edits of song, chain and phrase cells to the history
+func (m *Model) recordAudit(e Event) {
+	if e.Kind != EventData {
+		return
+	}
+	change := e.Data
+	now := time.Now()
+
+	m.auditMutex.Lock()
+	defer m.auditMutex.Unlock()
+	last := len(m.auditLog) - 1
+	if last >= 0 && m.auditLast.sameCell(change) && now.Sub(m.auditLog[last].Time) < auditMergeWindow {
+		change.Old = m.auditLast.Old
+		if change.Old == change.New && change.Kind != DataChainFX {
+			// Back where it started: the edit is undone
+			m.auditLog = m.auditLog[:last]
+			m.auditLast = DataChange{Kind: -1}
+			return
+		}
+		m.auditLog[last] = types.AuditEntry{Time: now, Text: m.AuditText(change)}
+		m.auditLast = change
+		return
+	}
+	m.auditLog = append(m.auditLog, types.AuditEntry{Time: now, Text: m.AuditText(change)})
+	if len(m.auditLog) > MaxAuditEntries {
+		m.auditLog = m.auditLog[len(m.auditLog)-MaxAuditEntries:]
+	}
+	m.auditLast = change
+}
+
+// sameCell reports whether two changes wrote the same cell
+func (c DataChange) sameCell(other DataChange) bool {
+	return c.Kind == other.Kind && c.Track == other.Track && c.ID == other.ID && c.Row == other.Row && c.Col == other.Col
+}
+
+// AuditText describes a change for the history, e.g. "phrase 0A row 04: note C-4 → D-4".
+// Chains and phrases of the sampler pool are marked "sampler".
+func (m *Model) AuditText(change DataChange) string {
+	sampler := change.Track >= 0 && change.Track < types.NumTracks && m.TrackTypes[change.Track]
+	pool := ""
+	if sampler {
+		pool = "sampler "
+	}
+	hex := func(value int) string {
+		if value < 0 {
+			return "--"
+		}
+		return fmt.Sprintf("%02X", value)
+	}
+
+	switch change.Kind {
+	case DataSong:
+		return fmt.Sprintf("song T%d row %02X: chain %s → %s", change.Track+1, change.Row, hex(change.Old), hex(change.New))
+	case DataChain:
+		return fmt.Sprintf("%schain %02X row %02X: phrase %s → %s", pool, change.ID, change.Row, hex(change.Old), hex(change.New))
+	case DataChainTranspose:
+		return fmt.Sprintf("%schain %02X row %02X: transpose %+d → %+d", pool, change.ID, change.Row, change.Old, change.New)
+	case DataChainFX:
+		return fmt.Sprintf("%schain %02X row %02X: effects changed", pool, change.ID, change.Row)
+	}
+
+	name, ok := auditColumnNames[change.Col]
+	if !ok && change.Col >= types.ColMidiCC0 && change.Col <= types.ColMidiCC8 {
+		name, ok = fmt.Sprintf("cc %d", change.Col-types.ColMidiCC0), true
+	}
+	if !ok {
+		name = fmt.Sprintf("column %d", change.Col)
+	}
+	value := hex
+	if !sampler && (change.Col == types.ColNote || change.Col >= types.ColNote2 && change.Col <= types.ColNote4) {
+		value = func(note int) string {
+			return strings.ToUpper(music.MidiToNoteName(note))
+		}
+	}
+	return fmt.Sprintf("%sphrase %02X row %02X: %s %s → %s", pool, change.ID, change.Row, name, value(change.Old), value(change.New))
+}
+
+// AuditEntries returns a copy of the history, oldest first
+func (m *Model) AuditEntries() []types.AuditEntry {
+	m.auditMutex.Lock()
+	defer m.auditMutex.Unlock()
+	return append([]types.AuditEntry(nil), m.auditLog...)
+}
+
+// LoadAuditLog replaces the history with a saved one (nil for none)
+func (m *Model) LoadAuditLog(saved []types.AuditEntry) {
+	m.auditMutex.Lock()
+	defer m.auditMutex.Unlock()
+	if len(saved) > MaxAuditEntries {
+		saved = saved[len(saved)-MaxAuditEntries:]
+	}
+	m.auditLog = append([]types.AuditEntry(nil), saved...)
+	m.auditLast = DataChange{Kind: -1}
+	m.AuditScroll = 0
+}
+
+// ScrollAudit moves the history view by a number of entries, staying within the history
+func (m *Model) ScrollAudit(entries int) {
+	m.AuditScroll = max(0, min(m.AuditScroll+entries, len(m.AuditEntries())-1))
+}
+
+// ExportAuditLog writes the history to history.txt in the project folder, one edit per line,
+// oldest first, and returns the file's path
+func (m *Model) ExportAuditLog() (string, error) {
+	var text strings.Builder
+	for _, entry := range m.AuditEntries() {
+		text.WriteString(entry.Time.Format(AuditTimeFormat) + "  " + entry.Text + "\n")
+	}
+	if err := os.MkdirAll(m.ProjectFolder(), 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(m.ProjectFolder(), "history.txt")
+	return path, os.WriteFile(path, []byte(text.String()), 0644)
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Autosave limits
+const (
+	DefaultAutosaveDelayMS = 1000 // Wait after the last change before autosaving
+	MinAutosaveDelayMS     = 250
+	MaxAutosaveDelayMS     = 30000
+	MaxAutosaveIntervalS   = 600 // Longest enforced gap between two autosaves
+)
+
+// AutosaveDelay is how long autosave waits after the last change
+func (m *Model) AutosaveDelay() time.Duration {
+	return time.Duration(m.AutosaveDelayMS) * time.Millisecond
+}
+
+// AutosaveInterval is the shortest time between two autosaves (0 for no limit)
+func (m *Model) AutosaveInterval() time.Duration {
+	return time.Duration(m.AutosaveIntervalS) * time.Second
+}
+
+// AutosaveModeName returns "auto" or "manual" for the settings view
+func (m *Model) AutosaveModeName() string {
+	if m.Autosave {
+		return "auto"
+	}
+	return "manual"
+}
+
+// AutosaveIntervalName returns the autosave interval for the settings view
+func (m *Model) AutosaveIntervalName() string {
+	if m.AutosaveIntervalS == 0 {
+		return "any"
+	}
+	return fmt.Sprintf("%ds", m.AutosaveIntervalS)
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// TrackBankSize is how many chain and phrase IDs each track owns when tracks have their own banks
+const TrackBankSize = 0x20
+
+// BankRange returns the first and last chain and phrase ID a track uses: the whole pool when
+// tracks share it, or the track's own bank (track 1 owns 00-1F, track 2 owns 20-3F, ...)
+func (m *Model) BankRange(track int) (int, int) {
+	if !m.PerTrackBanks || track < 0 || track >= types.NumTracks {
+		return 0, 254
+	}
+	first := track * TrackBankSize
+	return first, min(first+TrackBankSize-1, 254)
+}
+
+// InBank reports whether a chain or phrase ID lies in a track's bank
+func (m *Model) InBank(track, id int) bool {
+	first, last := m.BankRange(track)
+	return id >= first && id <= last
+}
+
+// ClampToBank clamps a chain or phrase ID into a track's bank, keeping -1 (empty) as is
+func (m *Model) ClampToBank(track, id int) int {
+	if id < 0 {
+		return id
+	}
+	first, last := m.BankRange(track)
+	return max(first, min(last, id))
+}
+
+// BankModeName returns the settings label of a bank mode
+func BankModeName(perTrack bool) string {
+	if perTrack {
+		return "track"
+	}
+	return "shared"
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hypebeast/go-osc/osc"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// BeatFlashDuration is how long the header stays lit after a beat
+const BeatFlashDuration = 120 * time.Millisecond
+
+// FlashBeat flashes the header, and pulses the beat pulse engine, when a playback clock tick
+// starts a beat
+func (m *Model) FlashBeat(tick int) {
+	ticksPerBeat := m.PPQ * m.ClockResolution()
+	if m.BeatFlash == types.BeatFlashOff || ticksPerBeat <= 0 || tick < 0 || tick%ticksPerBeat != 0 {
+		return
+	}
+	m.flashBeat(tick/ticksPerBeat%midiSyncBeatsPerBar + 1)
+}
+
+// flashBeat flashes beat 1-4 of a bar: every beat, or only the first in bar mode. The engine
+// gets <address>/beat with the beat number.
+func (m *Model) flashBeat(beat int) {
+	if m.BeatFlash == types.BeatFlashOff || (m.BeatFlash == types.BeatFlashBar && beat != 1) {
+		return
+	}
+	m.beatFlashAt = m.Now()
+	m.beatFlashBar = beat == 1
+	engine, ok := m.Engine(m.BeatPulse)
+	if !ok {
+		return
+	}
+	var client oscSender = m.engineClient(engine)
+	if m.output != nil {
+		client = outputSender{to: "engine:" + engine.Name, output: m.output}
+	}
+	if err := client.Send(osc.NewMessage(engineAddress(engine)+"/beat", int32(beat))); err != nil {
+		logging.OSC.Errorf("Error sending beat pulse to OSC engine %s: %v", engine.Name, err)
+	}
+}
+
+// BeatFlashing reports whether the header is lit by a beat at a moment, and whether that beat
+// starts a bar
+func (m *Model) BeatFlashing(now time.Time) (lit, bar bool) {
+	if m.BeatFlash == types.BeatFlashOff || m.beatFlashAt.IsZero() {
+		return false, false
+	}
+	since := now.Sub(m.beatFlashAt)
+	return since >= 0 && since < BeatFlashDuration, m.beatFlashBar
+}
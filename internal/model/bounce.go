@@ -0,0 +1,89 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// Loop bounce limits
+const (
+	DefaultBounceRepeats = 4
+	MaxBounceRepeats     = 64
+)
+
+// LoudnessTarget is a loudness loop bounces can be normalized to
+type LoudnessTarget struct {
+	Name string  // "off", "stream" or "club"
+	LUFS float64 // Integrated loudness aimed for
+}
+
+// LoudnessTargets are the choices of Norm in the Settings view; the first leaves bounces as rendered
+var LoudnessTargets = []LoudnessTarget{{Name: "off"}, {Name: "stream", LUFS: -14}, {Name: "club", LUFS: -9}}
+
+// TruePeakCeiling is the highest true peak in dBTP a normalized bounce may reach
+const TruePeakCeiling = -1.0
+
+// BounceTarget returns the loudness target of loop bounces, and false when they are not normalized
+func (m *Model) BounceTarget() (LoudnessTarget, bool) {
+	if m.BounceNormalize <= 0 || m.BounceNormalize >= len(LoudnessTargets) {
+		return LoudnessTargets[0], false
+	}
+	return LoudnessTargets[m.BounceNormalize], true
+}
+
+// BounceTargetName returns the loudness target of loop bounces for the Settings view
+func (m *Model) BounceTargetName() string {
+	target, ok := m.BounceTarget()
+	if !ok {
+		return "off"
+	}
+	return fmt.Sprintf("%.0f LUFS", target.LUFS)
+}
+
+// LoopBounce is a loop being played into a WAV file
+type LoopBounce struct {
+	File      string // WAV file the loop is recorded to
+	TicksLeft int    // Playback ticks until all repetitions have been played
+	Tail      bool   // Whether playback has stopped and only the tail is being recorded
+	Print     bool   // Whether only Track's output is recorded and added to the sampler files
+	Track     int    // Track printed when Print is set
+	Clip      bool   // Whether the print is a phrase clip: it stops with the loop, without a tail, and is not added to the sampler files
+}
+
+// PhraseLoopTicks returns the length of one pass through a phrase in ticks
+func PhraseLoopTicks(phrasesData *[types.NumPhrases][][]int, phrase int) int {
+	if phrase < 0 || phrase >= 255 {
+		return 0
+	}
+	ticks := 0
+	for _, rowData := range (*phrasesData)[phrase] {
+		if dt := rowData[types.ColDeltaTime]; dt > 0 {
+			ticks += dt
+		}
+	}
+	return ticks
+}
+
+// ChainLoopTicks returns the length of one pass through a chain in ticks
+func ChainLoopTicks(chainsData *[][]int, phrasesData *[types.NumPhrases][][]int, chain int) int {
+	if chain < 0 || chain >= len(*chainsData) {
+		return 0
+	}
+	ticks := 0
+	for _, phrase := range (*chainsData)[chain] {
+		ticks += PhraseLoopTicks(phrasesData, phrase)
+	}
+	return ticks
+}
+
+// CurrentLoopTicks returns the length of the phrase or chain being viewed in playback clock ticks
+func (m *Model) CurrentLoopTicks() int {
+	switch m.ViewMode {
+	case types.PhraseView:
+		return m.TrackTicks(m.CurrentTrack, PhraseLoopTicks(m.GetCurrentPhrasesData(), m.CurrentPhrase))
+	case types.ChainView:
+		return m.TrackTicks(m.CurrentTrack, ChainLoopTicks(m.GetCurrentChainsData(), m.GetCurrentPhrasesData(), m.CurrentChain))
+	}
+	return 0
+}
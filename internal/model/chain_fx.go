@@ -0,0 +1,87 @@
+package model
+
+import (
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// NewChainFX returns a set of chain slot effect overrides for one data pool, with no overrides
+func NewChainFX() [][]types.ChainFX {
+	fx := make([][]types.ChainFX, 255)
+	for i := range fx {
+		fx[i] = make([]types.ChainFX, types.ChainRows)
+		for j := range fx[i] {
+			fx[i][j] = types.NoChainFX
+		}
+	}
+	return fx
+}
+
+// chainFXForTrack returns the chain slot effect overrides of track's pool
+func (m *Model) chainFXForTrack(track int) *[][]types.ChainFX {
+	if track >= 0 && track < len(m.TrackTypes) && !m.TrackTypes[track] {
+		return &m.InstrumentChainFX
+	}
+	return &m.SamplerChainFX
+}
+
+// GetChainFX returns the effect overrides of a chain slot from track's pool
+func (m *Model) GetChainFX(track, chain, row int) types.ChainFX {
+	fx := *m.chainFXForTrack(track)
+	if chain < 0 || chain >= len(fx) || row < 0 || row >= len(fx[chain]) {
+		return types.NoChainFX
+	}
+	return fx[chain][row]
+}
+
+// SetChainFX sets the effect overrides of a chain slot in track's pool, clamping each value
+// to 00-FE or -1, and reports whether the slot exists
+func (m *Model) SetChainFX(track, chain, row int, value types.ChainFX) bool {
+	fx := *m.chainFXForTrack(track)
+	if chain < 0 || chain >= len(fx) || row < 0 || row >= len(fx[chain]) {
+		return false
+	}
+	value.Reverb = max(-1, min(254, value.Reverb))
+	value.LowPass = max(-1, min(254, value.LowPass))
+	old := fx[chain][row]
+	fx[chain][row] = value
+	if old != value {
+		m.publishDataChange(DataChange{Kind: DataChainFX, Track: track, ID: chain, Row: row})
+	}
+	return true
+}
+
+// HasChainFX reports whether any chain slot in either pool has effect overrides
+func (m *Model) HasChainFX() bool {
+	for _, pool := range [][][]types.ChainFX{m.InstrumentChainFX, m.SamplerChainFX} {
+		for _, chain := range pool {
+			for _, fx := range chain {
+				if fx != types.NoChainFX {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// PlayingChainFX returns the effect overrides of the chain slot a track is playing
+// (none outside song and chain playback)
+func (m *Model) PlayingChainFX(track int) types.ChainFX {
+	if chain, row, ok := m.playingChainSlot(track); ok {
+		return m.GetChainFX(track, chain, row)
+	}
+	return types.NoChainFX
+}
+
+// ApplyChainFX applies chain slot overrides to a row's reverb send and low pass values
+// (00-FE, -1 when the row sets none): the reverb is raised to the slot's and the cutoff
+// lowered to it
+func ApplyChainFX(fx types.ChainFX, reverb, lowPass int) (int, int) {
+	if fx.Reverb != -1 {
+		reverb = max(reverb, fx.Reverb)
+	}
+	if fx.LowPass != -1 && (lowPass == -1 || fx.LowPass < lowPass) {
+		lowPass = fx.LowPass
+	}
+	return reverb, lowPass
+}
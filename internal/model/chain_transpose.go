@@ -0,0 +1,92 @@
+package model
+
+import (
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// MaxChainTranspose is the largest chain slot transpose, in semitones either way
+const MaxChainTranspose = 48
+
+// NewChainTransposes returns an untransposed set of chain slot transposes for one data pool
+func NewChainTransposes() [][]int {
+	transposes := make([][]int, 255)
+	for i := range transposes {
+		transposes[i] = make([]int, types.ChainRows)
+	}
+	return transposes
+}
+
+// chainTransposesForTrack returns the chain slot transposes of track's pool
+func (m *Model) chainTransposesForTrack(track int) *[][]int {
+	if track >= 0 && track < len(m.TrackTypes) && !m.TrackTypes[track] {
+		return &m.InstrumentChainTransposes
+	}
+	return &m.SamplerChainTransposes
+}
+
+// GetChainTranspose returns the transpose of a chain slot from track's pool (0 when out of range)
+func (m *Model) GetChainTranspose(track, chain, row int) int {
+	transposes := *m.chainTransposesForTrack(track)
+	if chain < 0 || chain >= len(transposes) || row < 0 || row >= len(transposes[chain]) {
+		return 0
+	}
+	return transposes[chain][row]
+}
+
+// SetChainTranspose sets the transpose of a chain slot in track's pool, clamped to
+// ±MaxChainTranspose, reporting whether the slot exists
+func (m *Model) SetChainTranspose(track, chain, row, semitones int) bool {
+	transposes := *m.chainTransposesForTrack(track)
+	if chain < 0 || chain >= len(transposes) || row < 0 || row >= len(transposes[chain]) {
+		return false
+	}
+	semitones = max(-MaxChainTranspose, min(MaxChainTranspose, semitones))
+	old := transposes[chain][row]
+	transposes[chain][row] = semitones
+	if old != semitones {
+		m.publishDataChange(DataChange{Kind: DataChainTranspose, Track: track, ID: chain, Row: row, Old: old, New: semitones})
+	}
+	return true
+}
+
+// HasChainTransposes reports whether any chain slot in either pool is transposed
+func (m *Model) HasChainTransposes() bool {
+	for _, transposes := range [][][]int{m.InstrumentChainTransposes, m.SamplerChainTransposes} {
+		for _, chain := range transposes {
+			for _, semitones := range chain {
+				if semitones != 0 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// PlayingChainTranspose returns the transpose of the chain slot a track is playing, plus any
+// key change being previewed, so rows are transposed as they are emitted (0 outside song and
+// chain playback)
+func (m *Model) PlayingChainTranspose(track int) int {
+	if chain, row, ok := m.playingChainSlot(track); ok {
+		return m.GetChainTranspose(track, chain, row) + m.previewTranspose()
+	}
+	return 0
+}
+
+// playingChainSlot returns the chain and chain row a track is playing in song or chain playback
+func (m *Model) playingChainSlot(track int) (chain, row int, ok bool) {
+	if !m.IsPlaying || track < 0 || track >= types.NumTracks {
+		return 0, 0, false
+	}
+	switch m.PlaybackMode {
+	case types.SongView:
+		if m.SongPlaybackActive[track] {
+			return m.SongPlaybackChain[track], m.SongPlaybackChainRow[track], true
+		}
+	case types.ChainView:
+		if track == m.CurrentTrack {
+			return m.PlaybackChain, m.PlaybackChainRow, true
+		}
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// MaxClipboardHistory is how many copied cells and rows are kept to paste again
+const MaxClipboardHistory = 16
+
+// SetClipboard makes data the clipboard and puts it at the front of the clipboard history
+func (m *Model) SetClipboard(data types.ClipboardData) {
+	m.Clipboard = data
+	m.ClipboardHistory = append([]types.ClipboardData{data}, m.ClipboardHistory...)
+	if len(m.ClipboardHistory) > MaxClipboardHistory {
+		m.ClipboardHistory = m.ClipboardHistory[:MaxClipboardHistory]
+	}
+}
+
+// UseClipboardHistory makes an older history entry the clipboard again and moves it to the front
+func (m *Model) UseClipboardHistory(index int) bool {
+	if index < 0 || index >= len(m.ClipboardHistory) {
+		return false
+	}
+	data := m.ClipboardHistory[index]
+	data.HasData = true
+	m.ClipboardHistory = append(m.ClipboardHistory[:index], m.ClipboardHistory[index+1:]...)
+	m.SetClipboard(data)
+	return true
+}
+
+// ClipboardSummary describes a clipboard entry for the clipboard picker
+func ClipboardSummary(data types.ClipboardData) string {
+	source := clipboardViewName(data.HighlightView)
+	if data.HighlightView == types.PhraseView && data.HighlightPhrase >= 0 {
+		source = fmt.Sprintf("phrase %02X", data.HighlightPhrase)
+	}
+	if data.Mode == types.RowMode {
+		return fmt.Sprintf("%s row %02X", source, data.HighlightRow)
+	}
+	value := "--"
+	if data.Value >= 0 {
+		value = fmt.Sprintf("%02X", data.Value)
+	}
+	kind := "cell"
+	if data.CellType == types.FilenameCell {
+		kind = "file"
+	}
+	return fmt.Sprintf("%s %s %s (row %02X)", source, kind, value, data.HighlightRow)
+}
+
+// clipboardViewName names the view a clipboard entry was copied from
+func clipboardViewName(view types.ViewMode) string {
+	switch view {
+	case types.SongView:
+		return "song"
+	case types.ChainView:
+		return "chain"
+	case types.PhraseView:
+		return "phrase"
+	case types.ArpeggioView:
+		return "arpeggio"
+	case types.RetriggerView:
+		return "retrigger"
+	case types.TimestrechView:
+		return "timestretch"
+	}
+	return "view"
+}
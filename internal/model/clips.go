@@ -0,0 +1,139 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gitlab.com/gomidi/midi/v2"
+	"gitlab.com/gomidi/midi/v2/smf"
+
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// ClipsFolderName is the project subfolder holding exported phrase clips
+const ClipsFolderName = "clips"
+
+// clipTicksPerQuarter is the resolution of exported MIDI clips
+const clipTicksPerQuarter = 960
+
+// ClipsFolder returns where phrase clips of this project are exported to
+func (m *Model) ClipsFolder() string {
+	return filepath.Join(m.ProjectFolder(), ClipsFolderName)
+}
+
+// ClipFileName returns the file a phrase of track is exported to, labelled with the phrase's
+// name when it has one
+func (m *Model) ClipFileName(track, phrase int, ext string) string {
+	label := fmt.Sprintf("phrase%02X", phrase)
+	if name, _ := m.DisplayPhraseName(track, phrase); name != "" {
+		label += "-" + PrintFileLabel(name)
+	}
+	return filepath.Join(m.ClipsFolder(), fmt.Sprintf("%s-%s%s", label, time.Now().Format("2006-01-02-15-04-05"), ext))
+}
+
+// clipEvent is a MIDI message at an absolute tick of a clip
+type clipEvent struct {
+	tick int
+	msg  []byte
+	off  bool // Note-offs go before note-ons on the same tick
+}
+
+// PhraseMIDIClip renders an instrument phrase of track as a standard MIDI file of one pass
+// through the phrase at the project tempo. Each playable row plays its note with its chord and
+// stacked notes at its velocity, held for its gate, and sends its CC columns; arpeggios and
+// modulation are left out, as they are played live. ok is false when the phrase plays no notes.
+func (m *Model) PhraseMIDIClip(track, phrase int) (data []byte, ok bool, err error) {
+	if m.isSamplerTrack(track) || phrase < 0 || phrase >= types.NumPhrases {
+		return nil, false, nil
+	}
+	rows := m.InstrumentPhrasesData[phrase]
+	ticksPerRow := clipTicksPerQuarter / max(1, m.PPQ*m.TrackResolution(track))
+
+	// The last value set at or above a row, for the sticky columns
+	sticky := func(row int, col types.PhraseColumn) int {
+		for r := row; r >= 0; r-- {
+			if int(col) < len(rows[r]) && rows[r][col] != -1 {
+				return rows[r][col]
+			}
+		}
+		return -1
+	}
+
+	var events []clipEvent
+	tick, notes := 0, 0
+	for row, cells := range rows {
+		if len(cells) < int(types.ColCount) {
+			continue
+		}
+		dt := cells[types.ColDeltaTime]
+		if dt <= 0 {
+			continue // Rows without a DT are skipped in playback
+		}
+		length := dt * ticksPerRow
+		for i := 0; i < 9; i++ {
+			if value := cells[types.ColMidiCC0+types.PhraseColumn(i)]; value >= 0 && value <= 127 {
+				events = append(events, clipEvent{tick: tick, msg: midi.ControlChange(0, uint8(m.MidiCCNumbers[i]), uint8(value))})
+			}
+		}
+		if root := cells[types.ColNote]; root != -1 {
+			velocity := sticky(row, types.ColVelocity)
+			if velocity == -1 {
+				velocity = 64
+			}
+			gate := sticky(row, types.ColGate)
+			if gate == -1 {
+				gate = 0x80
+			}
+			held := max(1, length*gate/128)
+			chord := types.GetChordNotes(root, types.ChordType(cells[types.ColChord]), types.ChordAddition(cells[types.ColChordAddition]), types.ChordTransposition(cells[types.ColChordTransposition]))
+			for _, note := range types.AddStackedNotes(chord, cells[types.ColNote2], cells[types.ColNote3], cells[types.ColNote4]) {
+				if note < 0 || note > 127 {
+					continue
+				}
+				events = append(events,
+					clipEvent{tick: tick, msg: midi.NoteOn(0, uint8(note), uint8(min(127, velocity)))},
+					clipEvent{tick: tick + held, msg: midi.NoteOff(0, uint8(note)), off: true})
+				notes++
+			}
+		}
+		tick += length
+	}
+	if notes == 0 {
+		return nil, false, nil
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].tick != events[j].tick {
+			return events[i].tick < events[j].tick
+		}
+		return events[i].off && !events[j].off
+	})
+
+	var clip smf.Track
+	name := fmt.Sprintf("Phrase %02X", phrase)
+	if phraseName, _ := m.DisplayPhraseName(track, phrase); phraseName != "" {
+		name = phraseName
+	}
+	clip.Add(0, smf.MetaTrackSequenceName(name))
+	clip.Add(0, smf.MetaMeter(4, 4))
+	clip.Add(0, smf.MetaTempo(float64(m.BPM)))
+	last := 0
+	for _, event := range events {
+		clip.Add(uint32(event.tick-last), event.msg)
+		last = event.tick
+	}
+	clip.Close(uint32(max(0, tick-last))) // The clip lasts the whole phrase, so it loops in time
+
+	file := smf.New()
+	file.TimeFormat = smf.MetricTicks(clipTicksPerQuarter)
+	if err := file.Add(clip); err != nil {
+		return nil, false, err
+	}
+	var buf bytes.Buffer
+	if _, err := file.WriteTo(&buf); err != nil {
+		return nil, false, err
+	}
+	return buf.Bytes(), true, nil
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hypebeast/go-osc/osc"
+
+	"github.com/schollz/collidertracker/internal/midiplayer"
+)
+
+// Clock is the time playback runs on. Tests and simulations replace the wall clock with one
+// they advance themselves, so rows and the notes played after them come out the same every run.
+type Clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) // Calls f once d has passed
+}
+
+// wallClock is the real time
+type wallClock struct{}
+
+func (wallClock) Now() time.Time { return time.Now() }
+
+func (wallClock) AfterFunc(d time.Duration, f func()) { time.AfterFunc(d, f) }
+
+// Sent is a message playback sent out, handed to the output of a simulation instead
+type Sent struct {
+	To      string // "sc" for SuperCollider, "engine:<name>" for an OSC engine, "midi:<device>" for MIDI
+	Address string // OSC address, or "note" and "cc" for MIDI
+	Args    []any  // OSC arguments; channel, note, velocity and seconds of a MIDI note; channel, number and value of a CC
+}
+
+// Simulate runs playback on clock and hands every message it sends to output instead of
+// SuperCollider, the OSC engines and the MIDI devices
+func (m *Model) Simulate(clock Clock, output func(Sent)) {
+	m.clock = clock
+	m.output = output
+	m.oscClient = outputSender{to: "sc", output: output}
+}
+
+// Now returns the time on the model's clock
+func (m *Model) Now() time.Time {
+	if m.clock == nil {
+		return time.Now()
+	}
+	return m.clock.Now()
+}
+
+// afterFunc calls f once d has passed on the model's clock
+func (m *Model) afterFunc(d time.Duration, f func()) {
+	if m.clock == nil {
+		time.AfterFunc(d, f)
+		return
+	}
+	m.clock.AfterFunc(d, f)
+}
+
+// midiNoteOn plays a MIDI note, or hands it to the output of a simulation
+func (m *Model) midiNoteOn(device string, note, velocity, seconds float64, channel int) error {
+	if m.output != nil {
+		m.output(Sent{To: "midi:" + device, Address: "note", Args: []any{channel, note, velocity, seconds}})
+		return nil
+	}
+	return midiplayer.NoteOn(device, note, velocity, seconds, channel)
+}
+
+// midiControlChange sends a MIDI CC, or hands it to the output of a simulation
+func (m *Model) midiControlChange(device string, number, value, channel int) error {
+	if m.output != nil {
+		m.output(Sent{To: "midi:" + device, Address: "cc", Args: []any{channel, number, value}})
+		return nil
+	}
+	return midiplayer.ControlChange(device, number, value, channel)
+}
+
+// oscSender sends OSC messages: an osc.Client, or the output of a simulation
+type oscSender interface {
+	Send(packet osc.Packet) error
+}
+
+// outputSender hands OSC messages to the output of a simulation
+type outputSender struct {
+	to     string
+	output func(Sent)
+}
+
+func (s outputSender) Send(packet osc.Packet) error {
+	switch p := packet.(type) {
+	case *osc.Message:
+		s.output(Sent{To: s.to, Address: p.Address, Args: append([]any(nil), p.Arguments...)})
+	case *osc.Bundle:
+		for _, msg := range p.Messages {
+			s.Send(msg)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package model
+
+import (
+	"syscall"
+	"time"
+)
+
+// processCPUTime returns the CPU time the tracker process has used so far
+func processCPUTime() time.Duration {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0
+	}
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano())
+}
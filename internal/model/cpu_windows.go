@@ -0,0 +1,23 @@
+//go:build windows
+
+package model
+
+import (
+	"syscall"
+	"time"
+)
+
+// processCPUTime returns the CPU time the tracker process has used so far
+func processCPUTime() time.Duration {
+	handle, err := syscall.GetCurrentProcess()
+	if err != nil {
+		return 0
+	}
+	var creation, exit, kernel, user syscall.Filetime
+	if err := syscall.GetProcessTimes(handle, &creation, &exit, &kernel, &user); err != nil {
+		return 0
+	}
+	// Kernel and user times count 100 ns intervals
+	ticks := func(ft syscall.Filetime) int64 { return int64(ft.HighDateTime)<<32 | int64(ft.LowDateTime) }
+	return time.Duration((ticks(kernel) + ticks(user)) * 100)
+}
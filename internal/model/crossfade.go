@@ -0,0 +1,34 @@
+package model
+
+import (
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// MaxJumpCrossfade is the longest song jump crossfade, in ticks
+const MaxJumpCrossfade = 64
+
+// JumpCrossfadeSeconds returns how long a jump on a track crossfades, at the track's resolution
+// and the current tempo (0 when jumps hard-switch)
+func (m *Model) JumpCrossfadeSeconds(track int) float64 {
+	bpm := m.PlaybackBPM()
+	if m.JumpCrossfade <= 0 || bpm <= 0 || m.PPQ <= 0 {
+		return 0
+	}
+	return float64(m.JumpCrossfade) * 60.0 / (float64(bpm) * float64(m.PPQ) * float64(m.TrackResolution(track)))
+}
+
+// SendOSCCrossfadeMessage makes the next note on a track crossfade: the track's playing voices
+// fade out over the crossfade while the new ones fade in, instead of being cut at the jump
+func (m *Model) SendOSCCrossfadeMessage(track int) {
+	seconds := float32(m.JumpCrossfadeSeconds(track))
+	if seconds <= 0 || track < 0 || track >= types.NumTracks {
+		return
+	}
+	config := OSCMessageConfig{
+		Address:    "/crossfade",
+		Parameters: []interface{}{int32(track), seconds},
+		LogFormat:  "OSC crossfade message sent: /crossfade %d %.3f",
+		LogArgs:    []interface{}{track, seconds},
+	}
+	m.sendOSCMessage(config)
+}
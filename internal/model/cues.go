@@ -0,0 +1,81 @@
+package model
+
+import (
+	"math"
+	"strings"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// MaxCueNameLength is the longest cue name, so cues fit beside the song grid and in the header
+const MaxCueNameLength = 8
+
+// SetCue names the cue marker on a song row; an empty name removes it
+func (m *Model) SetCue(row int, name string) {
+	if row < 0 || row >= types.SongRows {
+		return
+	}
+	name = strings.TrimSpace(name)
+	if runes := []rune(name); len(runes) > MaxCueNameLength {
+		name = string(runes[:MaxCueNameLength])
+	}
+	if m.SongCues[row] == name {
+		return
+	}
+	m.SongCues[row] = name
+	if name == "" {
+		logging.Playback.Debugf("Removed cue on song row %02X", row)
+	} else {
+		logging.Playback.Debugf("Cue %q on song row %02X", name, row)
+	}
+	m.Publish(Event{Kind: EventSettings})
+}
+
+// HasCues reports whether any song row has a cue marker
+func (m *Model) HasCues() bool {
+	for _, name := range m.SongCues {
+		if name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// NextCue returns the next cue ahead of song playback and how many bars away it is, counting
+// the bar it is in (1 during the last bar before the cue). Cues are timed on the track the song
+// position follows, at the first of its chains on or after the cue's row; after the last cue the
+// countdown runs to the first one as the song loops. ok is false when there is no cue to count to.
+func (m *Model) NextCue() (name string, bars int, ok bool) {
+	position, length, playing := m.SongPosition()
+	if !playing || length <= 0 || !m.HasCues() {
+		return "", 0, false
+	}
+	lanes := m.Timeline()
+	lane := lanes[m.songPositionTrack(lanes)]
+
+	next := math.Inf(1)
+	for row, cue := range m.SongCues {
+		if cue == "" {
+			continue
+		}
+		for _, block := range lane {
+			if block.Row < row {
+				continue
+			}
+			at := block.Start
+			if at <= position {
+				at += length // Comes around again on the next pass
+			}
+			if at < next {
+				next, name = at, cue
+			}
+			break
+		}
+	}
+	if math.IsInf(next, 1) {
+		return "", 0, false
+	}
+	secondsPerBar := 60.0 / float64(m.BPM) * TimelineBeatsPerBar
+	return name, int(math.Ceil((next-position)/secondsPerBar - 1e-9)), true
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// DataKind is which part of the song a DataChange touched
+type DataKind int
+
+const (
+	DataSong           DataKind = iota // A song cell (chain ID)
+	DataChain                          // A chain row (phrase ID)
+	DataPhrase                         // A phrase cell
+	DataChainTranspose                 // A chain row's transpose (semitones)
+	DataChainFX                        // A chain row's effect overrides
+)
+
+// DataChange describes one cell written through the data-access methods (published as EventData)
+type DataChange struct {
+	Kind  DataKind
+	Track int                // Track whose pool was written (instrument and sampler tracks have separate chains and phrases)
+	ID    int                // Chain or phrase ID (-1 for song cells)
+	Row   int                // Song, chain or phrase row
+	Col   types.PhraseColumn // Phrase column (0 for song and chain cells)
+	Old   int
+	New   int
+}
+
+// GetSongCell returns the chain ID in a song cell (-1 when empty or out of range)
+func (m *Model) GetSongCell(track, row int) int {
+	if track < 0 || track >= types.NumTracks || row < 0 || row >= types.SongRows {
+		return -1
+	}
+	return m.SongData[track][row]
+}
+
+// SetSongCell writes a chain ID into a song cell, reporting whether the cell exists
+func (m *Model) SetSongCell(track, row, value int) bool {
+	if track < 0 || track >= types.NumTracks || row < 0 || row >= types.SongRows {
+		return false
+	}
+	old := m.SongData[track][row]
+	m.SongData[track][row] = value
+	if old != value {
+		m.publishDataChange(DataChange{Kind: DataSong, Track: track, ID: -1, Row: row, Old: old, New: value})
+	}
+	return true
+}
+
+// GetChainCell returns the phrase ID in a row of a chain from track's pool (-1 when empty or out of range)
+func (m *Model) GetChainCell(track, chain, row int) int {
+	chainsData := *m.GetChainsDataForTrack(track)
+	if chain < 0 || chain >= len(chainsData) || row < 0 || row >= len(chainsData[chain]) {
+		return -1
+	}
+	return chainsData[chain][row]
+}
+
+// SetChainCell writes a phrase ID into a row of a chain from track's pool, reporting whether the row exists
+func (m *Model) SetChainCell(track, chain, row, value int) bool {
+	chainsData := *m.GetChainsDataForTrack(track)
+	if chain < 0 || chain >= len(chainsData) || row < 0 || row >= len(chainsData[chain]) {
+		return false
+	}
+	old := chainsData[chain][row]
+	chainsData[chain][row] = value
+	if old != value {
+		m.publishDataChange(DataChange{Kind: DataChain, Track: track, ID: chain, Row: row, Old: old, New: value})
+	}
+	return true
+}
+
+// phraseRow returns a row of a phrase from track's pool, or nil when out of range
+func (m *Model) phraseRow(track, phrase, row int) []int {
+	if phrase < 0 || phrase >= types.NumPhrases {
+		return nil
+	}
+	rows := m.GetPhrasesDataForTrack(track)[phrase]
+	if row < 0 || row >= len(rows) {
+		return nil
+	}
+	return rows[row]
+}
+
+// GetPhraseCell returns a cell of a phrase from track's pool (-1 when out of range)
+func (m *Model) GetPhraseCell(track, phrase, row int, col types.PhraseColumn) int {
+	cells := m.phraseRow(track, phrase, row)
+	if col < 0 || int(col) >= len(cells) {
+		return -1
+	}
+	return cells[col]
+}
+
+// SetPhraseCell writes a cell of a phrase from track's pool, reporting whether the cell exists
+func (m *Model) SetPhraseCell(track, phrase, row int, col types.PhraseColumn, value int) bool {
+	cells := m.phraseRow(track, phrase, row)
+	if col < 0 || int(col) >= len(cells) {
+		return false
+	}
+	old := cells[col]
+	cells[col] = value
+	if old != value {
+		m.publishDataChange(DataChange{Kind: DataPhrase, Track: track, ID: phrase, Row: row, Col: col, Old: old, New: value})
+	}
+	return true
+}
+
+// GetPhraseRow returns a copy of every column of a phrase row from track's pool (nil when out of range)
+func (m *Model) GetPhraseRow(track, phrase, row int) []int {
+	cells := m.phraseRow(track, phrase, row)
+	if cells == nil {
+		return nil
+	}
+	return append([]int(nil), cells...)
+}
+
+// SetPhraseRow writes values into a phrase row from track's pool, one column per value
+func (m *Model) SetPhraseRow(track, phrase, row int, values []int) bool {
+	if m.phraseRow(track, phrase, row) == nil {
+		return false
+	}
+	for col, value := range values {
+		m.SetPhraseCell(track, phrase, row, types.PhraseColumn(col), value)
+	}
+	return true
+}
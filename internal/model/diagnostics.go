@@ -0,0 +1,95 @@
+package model
+
+import (
+	"math"
+	"runtime"
+	"runtime/metrics"
+	"sync/atomic"
+	"time"
+)
+
+// DiagnosticsHistory is how many one-second samples the diagnostics view keeps
+const DiagnosticsHistory = 60
+
+// diagnosticsInterval is how often the load of the tracker and SuperCollider is sampled
+const diagnosticsInterval = time.Second
+
+// DiagnosticsSample is the load of the tracker and SuperCollider over one sampling interval
+type DiagnosticsSample struct {
+	CPU        float64 // CPU used by the tracker process, in percent of one core
+	HeapBytes  uint64  // Bytes of live and not yet collected heap objects
+	Goroutines int     // Goroutines running
+	OSCIn      float64 // OSC messages received from SuperCollider per second
+	OSCOut     float64 // OSC messages sent to SuperCollider per second
+	SCAvgCPU   float32 // Average CPU load SuperCollider reports, in percent
+	SCPeakCPU  float32 // Peak CPU load SuperCollider reports, in percent
+}
+
+// diagnostics keeps the recent load history. The OSC counters are updated from any goroutine,
+// the rest only from the UI goroutine.
+type diagnostics struct {
+	oscIn, oscOut   atomic.Int64
+	scPeakCPU       atomic.Uint32 // math.Float32bits of the last peak CPU reported
+	samples         []DiagnosticsSample
+	lastAt          time.Time
+	lastCPU         time.Duration
+	lastIn, lastOut int64
+}
+
+// CountOSCReceived counts a message received from SuperCollider
+func (m *Model) CountOSCReceived() {
+	m.diagnostics.oscIn.Add(1)
+}
+
+// countOSCSent counts a message sent to SuperCollider
+func (m *Model) countOSCSent() {
+	m.diagnostics.oscOut.Add(1)
+}
+
+// HandleCPUPeak records the peak CPU load SuperCollider reports with its average
+func (m *Model) HandleCPUPeak(peak float32) {
+	m.diagnostics.scPeakCPU.Store(math.Float32bits(peak))
+}
+
+// SampleDiagnostics adds a sample to the load history once per sampling interval. It is cheap
+// to call more often.
+func (m *Model) SampleDiagnostics(now time.Time) {
+	d := &m.diagnostics
+	elapsed := now.Sub(d.lastAt)
+	if elapsed < diagnosticsInterval {
+		return
+	}
+	cpu := processCPUTime()
+	in, out := d.oscIn.Load(), d.oscOut.Load()
+	if d.lastAt.IsZero() {
+		d.lastAt, d.lastCPU, d.lastIn, d.lastOut = now, cpu, in, out
+		return
+	}
+
+	heap := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	metrics.Read(heap)
+	m.oscHealthMutex.Lock()
+	scAvg := m.CPUUsage
+	m.oscHealthMutex.Unlock()
+	sample := DiagnosticsSample{
+		CPU:        100 * float64(cpu-d.lastCPU) / float64(elapsed),
+		Goroutines: runtime.NumGoroutine(),
+		OSCIn:      float64(in-d.lastIn) / elapsed.Seconds(),
+		OSCOut:     float64(out-d.lastOut) / elapsed.Seconds(),
+		SCAvgCPU:   scAvg,
+		SCPeakCPU:  math.Float32frombits(d.scPeakCPU.Load()),
+	}
+	if heap[0].Value.Kind() == metrics.KindUint64 {
+		sample.HeapBytes = heap[0].Value.Uint64()
+	}
+	d.samples = append(d.samples, sample)
+	if len(d.samples) > DiagnosticsHistory {
+		d.samples = d.samples[len(d.samples)-DiagnosticsHistory:]
+	}
+	d.lastAt, d.lastCPU, d.lastIn, d.lastOut = now, cpu, in, out
+}
+
+// Diagnostics returns the load history, oldest sample first
+func (m *Model) Diagnostics() []DiagnosticsSample {
+	return m.diagnostics.samples
+}
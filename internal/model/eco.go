@@ -0,0 +1,44 @@
+package model
+
+import "github.com/schollz/collidertracker/internal/logging"
+
+// Eco mode limits, for quiet laptops on stage
+const (
+	EcoFrameRate     = 10 // Highest UI refresh rate in eco mode (frames per second)
+	EcoTelemetryRate = 10 // Level and waveform reports per second from SuperCollider in eco mode
+	telemetryRate    = 30 // Level and waveform reports per second otherwise
+)
+
+// ToggleEcoMode turns eco mode on or off. Eco mode lowers the UI refresh rate and the rate of
+// SuperCollider's level and waveform reports, pauses background sample analysis and holds
+// autosaves back until playback stops.
+func (m *Model) ToggleEcoMode() {
+	m.EcoMode = !m.EcoMode
+	m.SendOSCTelemetryRateMessage()
+	if m.EcoMode {
+		m.Notice = "Eco mode on"
+	} else {
+		m.Notice = "Eco mode off"
+	}
+	logging.UI.Debugf("Eco mode: %v", m.EcoMode)
+}
+
+// SendOSCTelemetryRateMessage sets how often SuperCollider reports levels and waveforms
+func (m *Model) SendOSCTelemetryRateMessage() {
+	rate := float32(telemetryRate)
+	if m.EcoMode {
+		rate = EcoTelemetryRate
+	}
+	m.sendOSCMessage(OSCMessageConfig{
+		Address:    "/set",
+		Parameters: []interface{}{"telemetryRate", rate},
+		LogFormat:  "OSC telemetry rate message sent: /set 'telemetryRate' %.0f",
+		LogArgs:    []interface{}{rate},
+	})
+	m.sendOSCMessage(OSCMessageConfig{
+		Address:    "/set_track",
+		Parameters: []interface{}{int32(8), "telemetryRate", rate},
+		LogFormat:  "OSC telemetry rate message sent: /set_track 8 'telemetryRate' %.0f",
+		LogArgs:    []interface{}{rate},
+	})
+}
@@ -0,0 +1,55 @@
+package model
+
+// EventKind is what changed in the model
+type EventKind int
+
+const (
+	EventData     EventKind = iota // A song, chain or phrase cell changed (Event.Data says which)
+	EventSettings                  // A setting or other project data outside the song, chain and phrase cells changed
+	EventSaved                     // The project was written to disk
+	EventLoaded                    // A project was read from disk
+	EventView                      // The view, cursor or transport position changed (saved, but not an edit)
+)
+
+// Event is published on the model's event bus
+type Event struct {
+	Kind EventKind
+	Data DataChange // Set for EventData
+}
+
+// Subscribe registers fn to be called for every event published on the model
+func (m *Model) Subscribe(fn func(Event)) {
+	m.eventsMutex.Lock()
+	defer m.eventsMutex.Unlock()
+	m.subscribers = append(m.subscribers, fn)
+}
+
+// Publish sends e to the subscribers. Data and settings changes mark the project dirty
+// until the next save or load; view changes do not.
+func (m *Model) Publish(e Event) {
+	m.eventsMutex.Lock()
+	switch e.Kind {
+	case EventData, EventSettings:
+		m.dirty = true
+	case EventSaved, EventLoaded:
+		m.dirty = false
+	}
+	subscribers := m.subscribers
+	m.eventsMutex.Unlock()
+
+	for _, fn := range subscribers {
+		fn(e)
+	}
+}
+
+// IsDirty reports whether the project has changes that are not saved yet
+func (m *Model) IsDirty() bool {
+	m.eventsMutex.Lock()
+	defer m.eventsMutex.Unlock()
+	return m.dirty
+}
+
+// publishDataChange publishes a cell written through the data-access methods
+func (m *Model) publishDataChange(change DataChange) {
+	m.Publish(Event{Kind: EventData, Data: change})
+}
@@ -0,0 +1,31 @@
+package model
+
+import "github.com/schollz/collidertracker/internal/types"
+
+// IdleFrameRate is the UI refresh rate (frames per second) when nothing on screen moves by itself
+const IdleFrameRate = 4
+
+// FrameRate returns the UI refresh rate for the current view, at most EcoFrameRate in eco mode. Views that show live levels, and
+// pitch tracking, which enters notes on refresh, keep the chosen rate. Other views only move with
+// the header waveform, so without it they drop to IdleFrameRate; playback and key presses still
+// redraw them at once. The beat flash keeps the chosen rate while it plays.
+func (m *Model) FrameRate() int {
+	fps := m.UIFrameRate
+	if fps <= 0 {
+		fps = types.FrameRates[0]
+	}
+	if m.EcoMode {
+		fps = min(fps, EcoFrameRate)
+	}
+	if m.WaveformDetail != types.WaveformDetailOff || m.PitchTracking {
+		return fps
+	}
+	if m.BeatFlash != types.BeatFlashOff && (m.IsPlaying || m.TimedStartArmed()) {
+		return fps // The flash goes out between frames
+	}
+	switch m.ViewMode {
+	case types.MixerView, types.VisualizerView, types.InputView, types.TunerView, types.WaveformView:
+		return fps
+	}
+	return min(fps, IdleFrameRate)
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// Song row weights for generative song mode
+const (
+	DefaultSongWeight = 1   // Weight of every song row until it is changed
+	MaxSongWeight     = 0xF // Largest weight, shown as one hex digit
+)
+
+// ToggleGenerativeSong switches song playback between playing rows in order and choosing them
+func (m *Model) ToggleGenerativeSong() {
+	m.GenerativeSong = !m.GenerativeSong
+	logging.Playback.Debugf("Generative song: %v", m.GenerativeSong)
+	m.Publish(Event{Kind: EventSettings})
+}
+
+// SetSongWeight sets how likely a song row of a track is chosen next in generative mode,
+// clamped to 0-MaxSongWeight
+func (m *Model) SetSongWeight(track, row, weight int) {
+	if track < 0 || track >= types.NumTracks || row < 0 || row >= types.SongRows {
+		return
+	}
+	weight = max(0, min(MaxSongWeight, weight))
+	if m.SongWeights[track][row] == weight {
+		return
+	}
+	m.SongWeights[track][row] = weight
+	logging.Playback.Debugf("Song weight of track %d row %02X: %X", track+1, row, weight)
+	m.Publish(Event{Kind: EventSettings})
+}
+
+// HasSongWeights reports whether any song row weight differs from the default
+func (m *Model) HasSongWeights() bool {
+	for _, weights := range m.SongWeights {
+		for _, weight := range weights {
+			if weight != DefaultSongWeight {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PickSongRow chooses the next song row of a track in generative mode: a weighted random
+// choice among the rows playable reports, from the song RNG so the same seed makes the same
+// song. It returns -1 when no playable row has any weight.
+func (m *Model) PickSongRow(track int, playable func(row int) bool) int {
+	if track < 0 || track >= types.NumTracks {
+		return -1
+	}
+	total := 0
+	var weights [types.SongRows]int
+	for row, weight := range m.SongWeights[track] {
+		if weight > 0 && playable(row) {
+			weights[row] = weight
+			total += weight
+		}
+	}
+	if total == 0 {
+		return -1
+	}
+	if m.SongRng == nil {
+		m.ResetRandom()
+	}
+	pick := m.SongRng.Intn(total)
+	for row, weight := range weights {
+		if pick < weight {
+			return row
+		}
+		pick -= weight
+	}
+	return -1
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"time"
+
+	"github.com/schollz/collidertracker/internal/logging"
+)
+
+// Humanize ranges: timing is in ticks of 1/96 beat, velocity in steps of the 00-7F velocity
+const (
+	HumanizeTicksPerBeat = 96
+	MaxHumanizeTiming    = 12 // A 32nd note either way
+	MaxHumanizeVelocity  = 64
+)
+
+// AdjustHumanizeTiming changes how far a track's rows may play early or late, in 1/96 beat
+func (m *Model) AdjustHumanizeTiming(track int, delta int) {
+	if track < 0 || track >= len(m.HumanizeTiming) {
+		return
+	}
+	m.HumanizeTiming[track] = max(0, min(MaxHumanizeTiming, m.HumanizeTiming[track]+delta))
+}
+
+// AdjustHumanizeVelocity changes how far a track's velocities may be moved up or down
+func (m *Model) AdjustHumanizeVelocity(track int, delta int) {
+	if track < 0 || track >= len(m.HumanizeVelocity) {
+		return
+	}
+	m.HumanizeVelocity[track] = max(0, min(MaxHumanizeVelocity, m.HumanizeVelocity[track]+delta))
+}
+
+// HumanizedVelocity moves a velocity by a random amount within the track's range, keeping it
+// between 1 and 127 so a humanized note is never silenced
+func (m *Model) HumanizedVelocity(track, velocity int) int {
+	if track < 0 || track >= len(m.HumanizeVelocity) || m.HumanizeVelocity[track] == 0 || velocity <= 0 {
+		return velocity
+	}
+	spread := m.HumanizeVelocity[track]
+	return max(1, min(127, velocity+m.HumanizeRng.Intn(2*spread+1)-spread))
+}
+
+// humanizeLookahead is the delay every track plays with while any track humanizes its timing,
+// so rows can land early as well as late: the widest timing range of the tracks, in 1/96 beat
+func (m *Model) humanizeLookahead() int {
+	lookahead := 0
+	for _, timing := range m.HumanizeTiming {
+		lookahead = max(lookahead, timing)
+	}
+	return lookahead
+}
+
+// PlayHumanized plays a row of a track now, or when any track humanizes its timing, after the
+// lookahead moved by a random amount within the track's range. The shift stays under half the
+// row's length so rows keep their order. Delayed rows are sent from their own goroutine, like
+// arpeggio notes.
+func (m *Model) PlayHumanized(track int, rowSeconds float32, play func()) {
+	lookahead := m.humanizeLookahead()
+	bpm := m.PlaybackBPM()
+	if lookahead == 0 || bpm <= 0 {
+		play()
+		return
+	}
+	tick := 60 / float64(bpm) / HumanizeTicksPerBeat
+	shift := 0.0
+	if track >= 0 && track < len(m.HumanizeTiming) && m.HumanizeTiming[track] > 0 {
+		spread := m.HumanizeTiming[track]
+		shift = float64(m.HumanizeRng.Intn(2*spread+1)-spread) * tick
+		if limit := float64(rowSeconds) / 2; rowSeconds > 0 {
+			shift = max(-limit, min(limit, shift))
+		}
+	}
+	delay := time.Duration((float64(lookahead)*tick + shift) * float64(time.Second))
+	if delay <= 0 {
+		play()
+		return
+	}
+	logging.Playback.Debugf("Humanize: track %d plays %v late", track, delay)
+	m.afterFunc(delay, play)
+}
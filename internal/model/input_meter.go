@@ -0,0 +1,96 @@
+package model
+
+import (
+	"sync"
+	"time"
+
+	"github.com/schollz/collidertracker/internal/logging"
+)
+
+// Input meter levels, in dBFS, for the readings SuperCollider sends 30 times a second
+const (
+	InputMeterFloorDB = -60.0 // Level the meters start at; quieter readings show as empty
+	InputClipDB       = -0.1  // Peak at which the input counts as clipping
+)
+
+// inputHoldTime is how long the meters hold a peak and the clip light stays on
+const inputHoldTime = 2 * time.Second
+
+// inputMeter keeps the levels of the audio input
+type inputMeter struct {
+	mu      sync.Mutex
+	peak    [2]float32   // Latest peak of the left and right input
+	hold    [2]float32   // Highest recent peak
+	holdAt  [2]time.Time // When the held peak was reached
+	clipAt  time.Time    // When the input last clipped
+	clips   int          // Readings that clipped since the count was reset
+	reading bool         // Whether SuperCollider has reported a level yet
+}
+
+// InputLevels is what the input meters show
+type InputLevels struct {
+	Peak     [2]float32 // Latest peak of the left and right input in dBFS
+	Hold     [2]float32 // Highest peak of the last couple of seconds in dBFS
+	Clipping bool       // Whether the input clipped in the last couple of seconds
+	Clips    int        // Readings that clipped since the count was reset
+}
+
+// HandleInputLevel takes a peak reading (dBFS) of the left and right audio input, after the
+// input gain, as it goes to the recorders
+func (m *Model) HandleInputLevel(left, right float32, now time.Time) {
+	meter := &m.inputMeter
+	meter.mu.Lock()
+	defer meter.mu.Unlock()
+	meter.reading = true
+	clipped := false
+	for ch, level := range [2]float32{left, right} {
+		level = max(level, InputMeterFloorDB)
+		meter.peak[ch] = level
+		if level >= meter.hold[ch] || now.Sub(meter.holdAt[ch]) > inputHoldTime {
+			meter.hold[ch], meter.holdAt[ch] = level, now
+		}
+		clipped = clipped || level >= InputClipDB
+	}
+	if clipped {
+		meter.clipAt = now
+		meter.clips++
+	}
+}
+
+// InputLevels returns the input meter levels at a moment, all at the floor until SuperCollider reports
+func (m *Model) InputLevels(now time.Time) InputLevels {
+	meter := &m.inputMeter
+	meter.mu.Lock()
+	defer meter.mu.Unlock()
+	levels := InputLevels{
+		Peak:  [2]float32{InputMeterFloorDB, InputMeterFloorDB},
+		Hold:  [2]float32{InputMeterFloorDB, InputMeterFloorDB},
+		Clips: meter.clips,
+	}
+	if !meter.reading {
+		return levels
+	}
+	levels.Peak = meter.peak
+	for ch := range levels.Hold {
+		levels.Hold[ch] = meter.peak[ch]
+		if now.Sub(meter.holdAt[ch]) <= inputHoldTime {
+			levels.Hold[ch] = meter.hold[ch]
+		}
+	}
+	levels.Clipping = meter.clips > 0 && now.Sub(meter.clipAt) <= inputHoldTime
+	return levels
+}
+
+// ResetInputClips clears the count of clipped readings
+func (m *Model) ResetInputClips() {
+	m.inputMeter.mu.Lock()
+	m.inputMeter.clips = 0
+	m.inputMeter.mu.Unlock()
+}
+
+// ToggleInputArmed arms or disarms the audio input for Ctrl+R recordings
+func (m *Model) ToggleInputArmed() {
+	m.InputArmed = !m.InputArmed
+	logging.UI.Debugf("Input armed: %v", m.InputArmed)
+	m.Publish(Event{Kind: EventSettings})
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// SetKit makes file a kit of kitFiles: NN 00, 01, ... play them in order. Each kit file gets
+// a sampler file slot (reusing its slot when it has one) so it is bundled with the project.
+func (m *Model) SetKit(file string, kitFiles []string) {
+	metadata, exists := m.FileMetadata[file]
+	if !exists {
+		metadata = types.FileMetadata{BPM: 120.0, Slices: 16, SliceType: 0, Playthrough: types.PlaythroughKit, SyncToBPM: 1}
+	}
+	metadata.Kit = make([]int, 0, len(kitFiles))
+	for _, kitFile := range kitFiles {
+		metadata.Kit = append(metadata.Kit, m.SamplerFileSlot(kitFile))
+	}
+	m.FileMetadata[file] = metadata
+	logging.Storage.Debugf("Kit %s: %d files", filepath.Base(file), len(metadata.Kit))
+}
+
+// SamplerFileSlot returns the sampler file slot holding file, adding one when there is none
+func (m *Model) SamplerFileSlot(file string) int {
+	if slot := slices.Index(m.SamplerPhrasesFiles, file); slot >= 0 {
+		return slot
+	}
+	m.SamplerPhrasesFiles = append(m.SamplerPhrasesFiles, file)
+	return len(m.SamplerPhrasesFiles) - 1
+}
+
+// KitFile returns the file a note plays from file's kit, wrapping around the kit
+// ("" when file is not in Kit mode or the note is empty)
+func (m *Model) KitFile(file string, note int) string {
+	metadata, exists := m.FileMetadata[file]
+	if !exists || metadata.Playthrough != types.PlaythroughKit || len(metadata.Kit) == 0 || note < 0 {
+		return ""
+	}
+	slot := metadata.Kit[note%len(metadata.Kit)]
+	if slot < 0 || slot >= len(m.SamplerPhrasesFiles) {
+		return ""
+	}
+	return m.SamplerPhrasesFiles[slot]
+}
@@ -0,0 +1,145 @@
+package model
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/schollz/collidertracker/internal/logging"
+)
+
+// Latency measurement: test triggers sent to SuperCollider at a steady rate, each answered
+// by the language and by a synth started on the server
+const (
+	LatencyProbes        = 100                    // Test triggers sent per measurement
+	LatencyProbeInterval = 20 * time.Millisecond  // Time between test triggers
+	LatencyTimeout       = 500 * time.Millisecond // How long replies are awaited after the last trigger
+)
+
+// Paths a latency probe reply takes back to the tracker
+const (
+	LatencyPathLanguage = 0 // Answered by sclang as the trigger arrives
+	LatencyPathServer   = 1 // Answered by a synth in its first control block, through the audio callback
+)
+
+// LatencyStats summarizes the round trips of one reply path
+type LatencyStats struct {
+	Received int           // Replies received
+	Min      time.Duration // Shortest round trip
+	Mean     time.Duration // Average round trip
+	Max      time.Duration // Longest round trip
+	Jitter   time.Duration // Standard deviation of the round trips
+}
+
+// LatencyReport is the state of the latest latency measurement
+type LatencyReport struct {
+	Running bool            // Whether test triggers are still being sent or awaited
+	Done    bool            // Whether a measurement has finished
+	Sent    int             // Test triggers sent
+	Paths   [2]LatencyStats // Round trips by LatencyPathLanguage and LatencyPathServer
+}
+
+// latencyTest keeps the latest latency measurement. Replies arrive on the OSC server goroutine.
+type latencyTest struct {
+	mu      sync.Mutex
+	run     int                // Measurement number, so late replies of an earlier one are ignored
+	running bool               // Whether the measurement is in progress
+	done    bool               // Whether a measurement has finished
+	sentAt  []time.Time        // When each test trigger was sent
+	trips   [2][]time.Duration // Round trips received by path
+}
+
+// StartLatencyTest starts a new latency measurement, reporting false when one is in progress
+func (m *Model) StartLatencyTest() bool {
+	test := &m.latencyTest
+	test.mu.Lock()
+	defer test.mu.Unlock()
+	if test.running {
+		return false
+	}
+	test.run++
+	test.running, test.done = true, false
+	test.sentAt = test.sentAt[:0]
+	test.trips = [2][]time.Duration{}
+	logging.OSC.Debugf("Latency measurement %d started", test.run)
+	return true
+}
+
+// SendLatencyProbe sends the next test trigger of the measurement in progress and reports
+// whether more remain to be sent
+func (m *Model) SendLatencyProbe(now time.Time) bool {
+	test := &m.latencyTest
+	test.mu.Lock()
+	if !test.running || len(test.sentAt) >= LatencyProbes {
+		test.mu.Unlock()
+		return false
+	}
+	run, probe := test.run, len(test.sentAt)
+	test.sentAt = append(test.sentAt, now)
+	test.mu.Unlock()
+
+	m.sendOSCMessage(OSCMessageConfig{
+		Address:    "/latency_probe",
+		Parameters: []interface{}{int32(run), int32(probe)},
+	})
+	return probe+1 < LatencyProbes
+}
+
+// HandleLatencyReply records the round trip of a test trigger answered by SuperCollider
+func (m *Model) HandleLatencyReply(run, probe, path int, now time.Time) {
+	test := &m.latencyTest
+	test.mu.Lock()
+	defer test.mu.Unlock()
+	if !test.running || run != test.run || probe < 0 || probe >= len(test.sentAt) || path < 0 || path >= len(test.trips) {
+		return
+	}
+	test.trips[path] = append(test.trips[path], now.Sub(test.sentAt[probe]))
+}
+
+// FinishLatencyTest ends the measurement in progress; replies that have not arrived count as lost
+func (m *Model) FinishLatencyTest() {
+	test := &m.latencyTest
+	test.mu.Lock()
+	defer test.mu.Unlock()
+	if !test.running {
+		return
+	}
+	test.running, test.done = false, true
+	language, server := latencyStats(test.trips[LatencyPathLanguage]), latencyStats(test.trips[LatencyPathServer])
+	logging.OSC.Debugf("Latency measurement %d: %d sent, language %d replies mean %v jitter %v, server %d replies mean %v jitter %v",
+		test.run, len(test.sentAt), language.Received, language.Mean, language.Jitter, server.Received, server.Mean, server.Jitter)
+}
+
+// LatencyReport returns the state of the latest latency measurement
+func (m *Model) LatencyReport() LatencyReport {
+	test := &m.latencyTest
+	test.mu.Lock()
+	defer test.mu.Unlock()
+	report := LatencyReport{Running: test.running, Done: test.done, Sent: len(test.sentAt)}
+	for path, trips := range test.trips {
+		report.Paths[path] = latencyStats(trips)
+	}
+	return report
+}
+
+// latencyStats summarizes round trips
+func latencyStats(trips []time.Duration) LatencyStats {
+	stats := LatencyStats{Received: len(trips)}
+	if len(trips) == 0 {
+		return stats
+	}
+	stats.Min, stats.Max = trips[0], trips[0]
+	var sum float64
+	for _, trip := range trips {
+		stats.Min, stats.Max = min(stats.Min, trip), max(stats.Max, trip)
+		sum += float64(trip)
+	}
+	mean := sum / float64(len(trips))
+	var variance float64
+	for _, trip := range trips {
+		variance += (float64(trip) - mean) * (float64(trip) - mean)
+	}
+	stats.Mean = time.Duration(mean)
+	stats.Jitter = time.Duration(math.Sqrt(variance / float64(len(trips))))
+	return stats
+}
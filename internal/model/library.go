@@ -0,0 +1,20 @@
+package model
+
+import "github.com/schollz/collidertracker/internal/library"
+
+// LibraryScan is an update of the sample library index running in the background
+type LibraryScan struct {
+	Listing bool           // Still looking through the sample folders for files
+	Files   []library.File // New and changed files to analyze, in order
+	Done    int            // Files analyzed so far
+	Failed  int            // Files whose analysis failed
+	Paused  bool           // Waiting for eco mode to end before analyzing the next file
+}
+
+// LibraryMatches returns the samples of the library matching the search, in path order
+func (m *Model) LibraryMatches() []library.Entry {
+	if m.Library == nil {
+		return nil
+	}
+	return m.Library.Search(m.LibraryQuery)
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// Live keyboard octaves: the lower row starts on C of the octave (C4 is MIDI note 60)
+const (
+	DefaultLiveOctave = 4
+	MaxLiveOctave     = 7
+)
+
+// LiveKeyboardNotes maps keys to semitones above the lowest note of the live keyboard. The
+// bottom letter row plays the lower octave (white keys z to m, black keys on s d g h j) and
+// the top row the upper one (q to u, black keys on 2 3 5 6 7).
+var LiveKeyboardNotes = map[string]int{
+	"z": 0, "s": 1, "x": 2, "d": 3, "c": 4, "v": 5, "g": 6, "b": 7, "h": 8, "n": 9, "j": 10, "m": 11,
+	"q": 12, "2": 13, "w": 14, "3": 15, "e": 16, "r": 17, "5": 18, "t": 19, "6": 20, "y": 21, "7": 22, "u": 23,
+}
+
+// LiveKeyboard is the computer keyboard played as an instrument. Keys play the current
+// track without touching its phrases.
+type LiveKeyboard struct {
+	Active bool
+	Octave int // Octave of the bottom row on instrument tracks
+	Last   int // Last note (instrument) or slice (sampler) played, -1 for none
+}
+
+// ToggleLiveKeyboard turns the live keyboard on or off
+func (m *Model) ToggleLiveKeyboard() {
+	m.LiveKeys.Active = !m.LiveKeys.Active
+	m.LiveKeys.Last = -1
+	logging.UI.Debugf("Live keyboard: %v", m.LiveKeys.Active)
+}
+
+// ShiftLiveOctave moves the live keyboard by delta octaves
+func (m *Model) ShiftLiveOctave(delta int) {
+	m.LiveKeys.Octave = max(0, min(MaxLiveOctave, m.LiveKeys.Octave+delta))
+}
+
+// LiveKeyValue returns what a key plays on the current track: a MIDI note on instrument
+// tracks, or a slice (00 to 17) of the sample on sampler tracks. ok is false for other keys.
+func (m *Model) LiveKeyValue(key string) (value int, ok bool) {
+	semitone, ok := LiveKeyboardNotes[key]
+	if !ok {
+		return -1, false
+	}
+	if m.GetPhraseViewType() == types.SamplerPhraseView {
+		return semitone, true
+	}
+	note := (m.LiveKeys.Octave+1)*12 + semitone
+	return note, note <= 127
+}
+
+// WithLiveNote plays a phrase row with another note (or slice) for the length of play, so
+// the row's instrument or sample and effects sound without editing it. The row gets DT 01 if
+// it has none and no modulation; it is put back as it was afterwards, without an edit.
+func (m *Model) WithLiveNote(track, phrase, row, note int, play func()) {
+	cells := m.phraseRow(track, phrase, row)
+	if cells == nil {
+		return
+	}
+	saved := append([]int(nil), cells...)
+	defer copy(cells, saved)
+	cells[types.ColNote] = note
+	if cells[types.ColDeltaTime] <= 0 {
+		cells[types.ColDeltaTime] = 1
+	}
+	cells[types.ColModulate] = -1
+	play()
+}
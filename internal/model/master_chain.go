@@ -0,0 +1,76 @@
+package model
+
+// MasterStages are the reorderable stages of the master chain, in their default order
+var MasterStages = []string{"comb", "reverb", "filter", "tape", "limiter"}
+
+// MasterStageDescriptions explains each master chain stage in the master chain view
+var MasterStageDescriptions = map[string]string{
+	"comb":    "comb send return",
+	"reverb":  "reverb send return (algorithmic or convolution)",
+	"filter":  "60 Hz high-pass filter",
+	"tape":    "pre gain and tape saturation",
+	"limiter": "limiter with a -0.3 dB ceiling",
+}
+
+// DefaultMasterChain returns a copy of the default master chain order
+func DefaultMasterChain() []string {
+	return append([]string(nil), MasterStages...)
+}
+
+// ValidMasterChain reports whether chain holds every master stage exactly once
+func ValidMasterChain(chain []string) bool {
+	if len(chain) != len(MasterStages) {
+		return false
+	}
+	seen := make(map[string]bool, len(chain))
+	for _, stage := range chain {
+		if _, ok := MasterStageDescriptions[stage]; !ok || seen[stage] {
+			return false
+		}
+		seen[stage] = true
+	}
+	return true
+}
+
+// IsDefaultMasterChain reports whether the master chain is in its default order
+func (m *Model) IsDefaultMasterChain() bool {
+	for i, stage := range m.MasterChain {
+		if stage != MasterStages[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// MoveMasterStage moves the selected stage one place earlier (delta < 0) or later (delta > 0)
+// in the chain, keeping it selected. It reports whether the order changed.
+func (m *Model) MoveMasterStage(delta int) bool {
+	from := m.MasterChainRow
+	to := from
+	if delta > 0 {
+		to++
+	} else if delta < 0 {
+		to--
+	}
+	if to == from || from < 0 || to < 0 || to >= len(m.MasterChain) {
+		return false
+	}
+	m.MasterChain[from], m.MasterChain[to] = m.MasterChain[to], m.MasterChain[from]
+	m.MasterChainRow = to
+	return true
+}
+
+// SendOSCMasterChainMessage makes SuperCollider rebuild the master output with the current stage order
+func (m *Model) SendOSCMasterChainMessage() {
+	params := make([]interface{}, len(m.MasterChain))
+	for i, stage := range m.MasterChain {
+		params[i] = stage
+	}
+	config := OSCMessageConfig{
+		Address:    "/master_chain",
+		Parameters: params,
+		LogFormat:  "OSC master chain message sent: /master_chain %v",
+		LogArgs:    []interface{}{m.MasterChain},
+	}
+	m.sendOSCMessage(config)
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// MIDI sync notes. The metronome uses the General MIDI metronome sounds; track triggers
+// start at C1 where drum machines usually map their first pad.
+const (
+	MidiSyncClickNote = 33 // Metronome click on each beat
+	MidiSyncBellNote  = 34 // Metronome bell on the first beat of a bar
+	MidiSyncTrackNote = 36 // Track 1's trigger note, track 2 is one semitone up, and so on
+)
+
+const (
+	midiSyncBeatsPerBar = 4
+	midiSyncVelocity    = 100
+	midiSyncDuration    = 0.05 // Note length in seconds, short enough for every tempo
+)
+
+// midiSyncSends reports whether MIDI sync sends track triggers (tracks) or metronome notes
+func (m *Model) midiSyncSends(tracks bool) bool {
+	if m.MidiSync.Device == "None" || m.MidiSync.Device == "" {
+		return false
+	}
+	if tracks {
+		return m.MidiSync.Mode == types.MidiSyncTracks || m.MidiSync.Mode == types.MidiSyncBoth
+	}
+	return m.MidiSync.Mode == types.MidiSyncClick || m.MidiSync.Mode == types.MidiSyncBoth
+}
+
+// MidiSyncBeatNote returns the metronome note for a playback clock tick: the bell on the
+// first beat of a bar, the click on other beats and -1 between beats
+func (m *Model) MidiSyncBeatNote(tick int) int {
+	ticksPerBeat := m.PPQ * m.ClockResolution()
+	if ticksPerBeat <= 0 || tick < 0 || tick%ticksPerBeat != 0 {
+		return -1
+	}
+	if tick%(ticksPerBeat*midiSyncBeatsPerBar) == 0 {
+		return MidiSyncBellNote
+	}
+	return MidiSyncClickNote
+}
+
+// MidiSyncBeat sends the metronome note when a playback clock tick starts a beat
+func (m *Model) MidiSyncBeat(tick int) {
+	if !m.midiSyncSends(false) {
+		return
+	}
+	if note := m.MidiSyncBeatNote(tick); note != -1 {
+		m.sendMidiSyncNote(note)
+	}
+}
+
+// MidiSyncTrigger sends a track's trigger note when one of its rows plays
+func (m *Model) MidiSyncTrigger(track int) {
+	if track < 0 || track >= types.NumTracks || !m.midiSyncSends(true) {
+		return
+	}
+	m.sendMidiSyncNote(MidiSyncTrackNote + track)
+}
+
+// sendMidiSyncNote plays a short MIDI sync note on the sync device and channel
+func (m *Model) sendMidiSyncNote(note int) {
+	channel := m.MidiSync.Channel
+	if channel < 1 || channel > 16 {
+		logging.MIDI.Errorf("ERROR: Invalid MIDI sync channel %d, must be 1-16", channel)
+		return
+	}
+	if err := m.midiNoteOn(m.MidiSync.Device, float64(note), midiSyncVelocity, midiSyncDuration, channel-1); err != nil {
+		logging.MIDI.Errorf("ERROR: Failed to send MIDI sync note %d: %v", note, err)
+	}
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// MixerSlotNames name the sides of the mixer's A/B compare
+var MixerSlotNames = []string{"A", "B"}
+
+// MixerState returns what the Mixer view currently sets, as one side of the A/B compare
+func (m *Model) MixerState() types.MixerState {
+	return types.MixerState{Levels: m.TrackSetLevels, HumanizeTiming: m.HumanizeTiming, HumanizeVelocity: m.HumanizeVelocity}
+}
+
+// applyMixerState sets the mixer to a stored side of the A/B compare and sends its levels
+func (m *Model) applyMixerState(state types.MixerState) {
+	m.TrackSetLevels = state.Levels
+	m.HumanizeTiming = state.HumanizeTiming
+	m.HumanizeVelocity = state.HumanizeVelocity
+	for track := range m.TrackSetLevels {
+		m.SendOSCTrackSetLevelMessage(track)
+	}
+}
+
+// ToggleMixerCompare switches the mixer between its A and B sides. The side left is kept as it
+// was, so switching back and forth compares two mixes.
+func (m *Model) ToggleMixerCompare() {
+	other := m.MixerOther
+	m.MixerOther = m.MixerState()
+	m.applyMixerState(other)
+	m.MixerSlot = 1 - m.MixerSlot
+	logging.UI.Debugf("Mixer compare: playing %s", m.MixerSlotName())
+}
+
+// CopyMixerToOther copies the side playing onto the other side of the A/B compare (A to B
+// while A plays)
+func (m *Model) CopyMixerToOther() {
+	m.MixerOther = m.MixerState()
+	logging.UI.Debugf("Mixer compare: copied %s to %s", m.MixerSlotName(), m.OtherMixerSlotName())
+}
+
+// MixerSlotName returns the side of the A/B compare playing
+func (m *Model) MixerSlotName() string {
+	return MixerSlotNames[m.MixerSlot&1]
+}
+
+// OtherMixerSlotName returns the side of the A/B compare not playing
+func (m *Model) OtherMixerSlotName() string {
+	return MixerSlotNames[1-m.MixerSlot&1]
+}
+
+// MixerSidesDiffer reports whether the two sides of the A/B compare hold different mixes
+func (m *Model) MixerSidesDiffer() bool {
+	return m.MixerState() != m.MixerOther
+}
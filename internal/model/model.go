@@ -3,7 +3,6 @@ package model
 import (
 	"context"
 	"fmt"
-	"log"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -15,8 +14,10 @@ import (
 	onset "github.com/schollz/onsets"
 
 	"github.com/schollz/collidertracker/internal/getbpm"
-	"github.com/schollz/collidertracker/internal/midiplayer"
+	"github.com/schollz/collidertracker/internal/library"
+	"github.com/schollz/collidertracker/internal/logging"
 	"github.com/schollz/collidertracker/internal/types"
+	"github.com/schollz/collidertracker/internal/update"
 )
 
 // OSCMessageConfig represents configuration for sending OSC messages
@@ -33,39 +34,55 @@ type Model struct {
 	ScrollOffset int
 	ViewMode     types.ViewMode
 	// Legacy shared data structures (will be phased out)
-	PhrasesData  [255][][]int // [phrase][row][col] where col uses PhraseColumn enum
-	ChainsData   [][]int      // [chain][row] where each chain has 16 rows, each row contains a phrase_number
-	PhrasesFiles []string     // [phrase] filename for each phrase row
+	PhrasesData  [types.NumPhrases][][]int // [phrase][row][col] where col uses PhraseColumn enum
+	ChainsData   [][]int                   // [chain][row] where each chain has 16 rows, each row contains a phrase_number
+	PhrasesFiles []string                  // [phrase] filename for each phrase row
 	// Separate data pools for Instruments (tracks 0-3) and Samplers (tracks 4-7)
-	InstrumentPhrasesData [255][][]int        // [phrase][row][col] for instrument tracks - simplified data
-	InstrumentChainsData  [][]int             // [chain][row] for instrument tracks
-	SamplerPhrasesData    [255][][]int        // [phrase][row][col] for sampler tracks - full complexity
-	SamplerChainsData     [][]int             // [chain][row] for sampler tracks
-	SamplerPhrasesFiles   []string            // [phrase] filename for sampler phrases only
-	CurrentPhrase         int                 // Which phrase we're viewing/editing
-	CurrentChain          int                 // Which chain we're viewing/editing
-	CurrentTrack          int                 // Which track context we're viewing (0-7)
-	FileSelectRow         int                 // Which phrase row we're selecting a file for
-	FileSelectCol         int                 // Which phrase column we were on when navigating to file browser
-	Clipboard             types.ClipboardData // Cell clipboard
-	CurrentDir            string              // Current directory for file browser
-	Files                 []string            // Files in current directory
-	TermHeight            int
-	TermWidth             int
-	IsPlaying             bool
-	PlaybackRow           int            // Current row within phrase
-	PlaybackChain         int            // Current chain being played
-	PlaybackChainRow      int            // Current row within chain during playback
-	PlaybackPhrase        int            // Current phrase being played
-	PlaybackMode          types.ViewMode // Whether playback started from Chain or Phrase view
-	ticker                *time.Ticker
-	TickCount             int            // Counter for tick-based animations (blinking indicators)
-	LastEditRow           int            // Track the last row that was edited
-	BPM                   float32        // Beats per minute
-	PPQ                   int            // Pulses per quarter note
+	InstrumentPhrasesData [types.NumPhrases][][]int // [phrase][row][col] for instrument tracks - simplified data
+	InstrumentChainsData  [][]int                   // [chain][row] for instrument tracks
+	SamplerPhrasesData    [types.NumPhrases][][]int // [phrase][row][col] for sampler tracks - full complexity
+	SamplerChainsData     [][]int                   // [chain][row] for sampler tracks
+	// Semitone transpose of each chain slot, applied as the slot's phrase plays
+	InstrumentChainTransposes [][]int // [chain][row] for instrument tracks
+	SamplerChainTransposes    [][]int // [chain][row] for sampler tracks
+	// Effect overrides of each chain slot, applied as the slot's phrase plays
+	InstrumentChainFX   [][]types.ChainFX               // [chain][row] for instrument tracks
+	SamplerChainFX      [][]types.ChainFX               // [chain][row] for sampler tracks
+	PLocks              map[PLockRow]map[string]float32 // SoundMaker parameters locked on instrument phrase rows
+	InstrumentNames     types.PoolNames                 // Names of instrument chains and phrases
+	SamplerNames        types.PoolNames                 // Names of sampler chains and phrases
+	NameEntry           NameEntry                       // Chain or phrase name, or name search, being typed
+	SamplerPhrasesFiles []string                        // [phrase] filename for sampler phrases only
+	CurrentPhrase       int                             // Which phrase we're viewing/editing
+	CurrentChain        int                             // Which chain we're viewing/editing
+	CurrentTrack        int                             // Which track context we're viewing (0-7)
+	FileSelectRow       int                             // Which phrase row we're selecting a file for
+	FileSelectCol       int                             // Which phrase column we were on when navigating to file browser
+	Clipboard           types.ClipboardData             // Cell clipboard
+	ClipboardHistory    []types.ClipboardData           // Recently copied cells and rows, newest first
+	PickingClipboard    bool                            // Whether the clipboard picker is open
+	ClipboardPick       int                             // History entry chosen in the clipboard picker
+	PickingKit          bool                            // Whether the track kit picker is open
+	KitPick             int                             // Kit chosen in the track kit picker
+	KitChoices          []string                        // Track kits the picker chooses from
+	CurrentDir          string                          // Current directory for file browser
+	Files               []string                        // Files in current directory
+	TermHeight          int
+	TermWidth           int
+	IsPlaying           bool
+	PlaybackRow         int            // Current row within phrase
+	PlaybackChain       int            // Current chain being played
+	PlaybackChainRow    int            // Current row within chain during playback
+	PlaybackPhrase      int            // Current phrase being played
+	PlaybackMode        types.ViewMode // Whether playback started from Chain or Phrase view
+	ticker              *time.Ticker
+	LastEditRow         int     // Track the last row that was edited
+	BPM                 float32 // Beats per minute
+	PPQ                 int     // Pulses per quarter note
 	// Timing tracking for drift-free playback
 	PlaybackStartTime     time.Time      // Absolute time when playback started
 	PlaybackTickCount     int            // Number of ticks since playback started
+	PlaybackTempo         TickTempo      // Tempo and groove the clock runs at since one of its ticks (reset when playback starts)
 	PregainDB             float32        // Pre-gain in decibels (-96.0 to +32.0, default 0.0)
 	PostgainDB            float32        // Post-gain in decibels (-96.0 to +32.0, default 0.0)
 	BiasDB                float32        // Bias in decibels (-96.0 to +32.0, default -6.0)
@@ -73,8 +90,17 @@ type Model struct {
 	DriveDB               float32        // Drive in decibels (-96.0 to +32.0, default -6.0)
 	InputLevelDB          float32        // Input level in decibels (-48.0 to +24.0, default 0.0)
 	ReverbSendPercent     float32        // Reverb send percentage (0.0 to 100.0, default 0.0)
+	InputMonitor          bool           // Pass the input through the insert and master effects
+	InputArmed            bool           // Record the audio input (track 9) with Ctrl+R recordings
+	InputInsert           int            // Insert effect on the monitored input (index into types.InputInsertNames)
+	ReverbImpulse         string         // Convolution reverb impulse response ("" for the algorithmic reverb, see ReverbImpulseChoices)
 	TapePercent           float32        // Tape percentage (0.0 to 100.0, default 0.0)
 	ShimmerPercent        float32        // Shimmer percentage (0.0 to 300.0, default 0.0)
+	FadeMS                int            // Click-free ramp time in milliseconds for transport start/stop (1 to 500, default 10)
+	JumpCrossfade         int            // Ticks a queued song jump crossfades over (0 = hard switch, default)
+	PerTrackBanks         bool           // Each track uses its own bank of chain and phrase IDs instead of the shared pool
+	StartCountdown        int            // Seconds T counts down before a timed start (one of types.StartCountdowns, default 10)
+	PreRoll               int            // Count-in beats at the song tempo ending on a timed start (one of types.PreRolls, default 0)
 	PreviousView          types.ViewMode // Track the view we came from when entering Settings
 	// Playback state for inheriting values from previous rows
 	lastPlaybackNote     int    // Last non-null note value during playback
@@ -82,7 +108,7 @@ type Model struct {
 	lastPlaybackFileIdx  int    // Last non-null filename index during playback
 	lastPlaybackFilename string // Last non-null filename during playback
 	// OSC client configuration
-	oscClient        *osc.Client
+	oscClient        oscSender
 	oscPort          int
 	LastWaveform     float64      // Last waveform value received from OSC
 	WaveformBuf      []float64    // Buffer for waveform data
@@ -122,7 +148,7 @@ type Model struct {
 	MidiCCNumbers [9]int             // MIDI CC numbers for the 9 CC columns (default 0-8, range 0-127)
 
 	// Song data structure (8 tracks × 16 rows)
-	SongData [8][16]int // [track][row] = chain ID (00-FE, -1 for empty)
+	SongData [types.NumTracks][types.SongRows]int // [track][row] = chain ID (00-FE, -1 for empty)
 
 	// Song playback state
 	SongPlaybackRow         [8]int  // Current row for each track during playback
@@ -140,29 +166,82 @@ type Model struct {
 	// Increment counter tracking - tracks increment counter values per track/phrase/row
 	IncrementCounters [8][255][255]int // [track][phrase][row] = increment counter (-1 means uninitialized/unused)
 	// Save folder configuration
-	SaveFolder string // Path to the save folder
+	SaveFolder    string        // Path to the save folder
+	ProjectKey    *ProjectKey   // Key the project is saved with (nil saves it unencrypted)
+	WorkFolder    string        // Decrypted copies of a password-protected project's files ("" when unprotected)
+	PasswordEntry PasswordEntry // Project password being typed
 	// Recording state
 	RecordingEnabled     bool   // Whether recording is queued/enabled
 	RecordingActive      bool   // Whether recording is currently active
 	CurrentRecordingFile string // Current recording filename
+	// Session (master) recording state
+	SessionRecording     bool            // Whether the master output is being recorded
+	SessionRecordingFile string          // File the current session take is written to
+	SessionPunchArmed    bool            // Whether a session take starts on the next bar
+	SessionTakeStart     time.Time       // When the take's first sound went out, which starts its file (zero until then)
+	SessionSection       int             // First song row of the section last marked on the take (-1 for none)
+	SessionMarkers       []SessionMarker // Song sections the take went through
+	SessionPreRoll       int             // Beats of silence put before beat 1 of session takes (one of PreRollChoices)
+	// Recordings view state
+	Recordings        []RecordingInfo // Recordings listed in the recordings view
+	RecordingsRow     int             // Selected row in the recordings view
+	RenamingRecording bool            // Whether the selected recording's name is being edited
+	RenameBuffer      string          // Name being typed while renaming a recording
+	// Timeline view state
+	TimelineTrack int  // Lane of the selected block in the timeline view
+	TimelineRow   int  // Song row of the selected block in the timeline view
+	UsageSampler  bool // The usage view shows the sampler pool instead of the instrument pool
 	// Project selection state
 	ReturnToProjectSelector bool // Flag to indicate we should return to project selection
 	// Mixer state
 	TrackVolumes      [9]float32 // Current volume levels received from SuperCollider (-96 to +12 dB)
 	TrackSetLevels    [9]float32 // User-controllable set levels for each track (-96 to +32 dB, default -6.0)
 	TrackTypes        [9]bool    // Track type: false = Instrument (IN), true = Sampler (SA), default SA
+	TrackResolutions  [8]int     // Ticks per PPQ tick for each track's rows (1, 2, 4 or 8; default 1)
+	TrackEngines      [8]string  // OSC engine (from Config.OSCEngines) each instrument track plays instead of SuperCollider ("" for none)
 	CurrentMixerTrack int        // Currently selected track in mixer view (0-7)
-	CurrentMixerRow   int        // Current row in mixer: 0 = level (track type now in Song view)
+	CurrentMixerRow   int        // Current row in mixer: 0 = level, 1 = resolution, 2 = record quantize, 3 = strength, 4-5 = humanize
+	// Record quantize of notes entered live into a playing phrase
+	RecordQuantize         [types.NumTracks]int // Grid per track (index into types.RecordQuantizeNames, 0 = off)
+	RecordQuantizeStrength [types.NumTracks]int // Percent of half the grid within which notes snap to it (default 50)
+	// Humanize of rows played back, leaving the phrases as written
+	HumanizeTiming   [8]int // Most a track's rows play early or late, in 1/96 beat (0 = off)
+	HumanizeVelocity [8]int // Most a track's velocities move up or down (0 = off)
+	// A/B compare of the mixer
+	MixerSlot  int              // Side of the A/B compare playing (0 A, 1 B)
+	MixerOther types.MixerState // Mixer stored on the side not playing
 	// MIDI functionality
 	AvailableMidiDevices []string
 	// Arpeggio cancellation tracking
 	arpeggioContexts     map[int32]context.CancelFunc // Per-track cancellation functions
 	arpeggioCurrentNotes map[int32][]float32          // Currently playing arpeggio notes for each track
 	arpeggioMutex        sync.Mutex                   // Mutex for safe access to arpeggio tracking
+	// Clients of the OSC engines tracks play, by host and port
+	engineClients map[string]*osc.Client
+	engineMutex   sync.Mutex // Arpeggios send from their own goroutines
 	// Per-track random number generators for modulation
 	ModulateRngs [8]*rand.Rand // Per-track RNG for modulation (one per track)
+	EffectRng    *rand.Rand    // RNG for the reverse probability effect
+	SongRng      *rand.Rand    // RNG for generative song row choices
+	HumanizeRng  *rand.Rand    // RNG for humanized timing and velocities
+	RandomSeed   int           // Project seed the RNGs start from at playback start (1-FFFF)
+	// Event bus for model changes
+	subscribers []func(Event) // Called for every published event
+	dirty       bool          // Whether there are changes since the project was last saved or loaded
+	eventsMutex sync.Mutex    // Mutex for the subscribers and dirty flag (saves publish from the autosave goroutine)
+	// Edit history
+	auditLog    []types.AuditEntry // Readable edits, oldest first (see audit.go)
+	auditLast   DataChange         // Last edit recorded, Old being the cell's value before the edits merged into it
+	auditMutex  sync.Mutex         // Mutex for the edit history (edits can come from playback goroutines)
+	AuditScroll int                // Entries scrolled back from the newest in the history view
 	// Vim mode configuration
 	VimMode bool // Enable vim-style cursor movement (h/j/k/l)
+	// Startup options, edited in the App column of the Settings view and kept in the config file
+	Config   types.AppConfig // Options as last edited (Config.Vim follows VimMode)
+	dumpPath string          // Dump file to turn back on after Dump is switched off
+	// Project statistics
+	sampleStats      map[string]sampleStat // Sample files read for the statistics, until the next load or edit (see stats.go)
+	sampleStatsMutex sync.Mutex            // Mutex for the sample cache (events can come from playback goroutines)
 	// Onset detection state
 	onsetDetectionPending map[string]*time.Timer // Map of file path to debounce timer
 	onsetDetectionMutex   sync.Mutex             // Mutex for safe access to onset detection state
@@ -172,7 +251,9 @@ type Model struct {
 	WaveformEnd           float64 // End time in seconds for waveform view
 	WaveformDuration      float64 // Total duration of the waveform file (cached)
 	WaveformSelectedSlice int     // Index of selected slice/marker (-1 if none)
+	WaveformSelectedWarp  int            // Index of selected warp marker (-1 if none)
 	WaveformPreviousView  types.ViewMode // View to return to when exiting waveform view
+	AuxPreviousView       types.ViewMode // View to return to when leaving a read-only view (stats)
 	// Playhead tracking for waveform view
 	PlayheadTrackID    int       // Track ID of current playhead
 	PlayheadGate       int       // Gate status (0 = off, 1 = on)
@@ -180,6 +261,115 @@ type Model struct {
 	PlayheadSliceStart float64   // Current slice start position (0.0 to 1.0)
 	PlayheadSliceEnd   float64   // Current slice end position (0.0 to 1.0)
 	PlayheadLastUpdate time.Time // Timestamp of last playhead update
+	// OSC link health (driven by /cpuusage telemetry)
+	CPUUsage         float32    // Last CPU usage reported by SuperCollider
+	LastCPUUsageTime time.Time  // When /cpuusage was last received (zero until first message)
+	OSCLinkLost      bool       // Whether /cpuusage stopped arriving
+	oscHealthMutex   sync.Mutex // Mutex for OSC health state (updated from the OSC server goroutine)
+	ServerProgram    string     // Server program reported by SuperCollider (scsynth or supernova)
+	ServerSampleRate int        // Sample rate reported by SuperCollider (0 until /server_info arrives)
+	ServerBlockSize  int        // Block size reported by SuperCollider (0 until /server_info arrives)
+	Notice           string     // One-off notice shown in the footer (e.g. negotiated OSC ports), cleared on the next key press
+	// Destructive operation safety
+	ConfirmDeletes bool           // Ask before deleting phrases, chains, markers and samples
+	PendingConfirm *ConfirmPrompt // Confirmation waiting for a y/n answer (nil if none)
+	AuditionEdits  bool           // Play the row when its note or velocity is edited while stopped
+	Trash          []TrashEntry   // Deleted items, restorable until the next save
+	// Loop bounce
+	BounceRepeats   int         // How many times the loop is played into a bounce
+	BounceNormalize int         // Index into LoudnessTargets of the loudness bounces are normalized to (0 leaves them as rendered)
+	NudgeFine       int         // Fine step of hex cells (Ctrl+Left/Right)
+	NudgeCoarse     int         // Coarse step of hex cells (Ctrl+Up/Down)
+	NumberEntry     NumberEntry // Value being typed into the cell under the cursor
+	Bounce          *LoopBounce // Bounce in progress (nil if none)
+	// Sample analysis
+	Analysis *SampleAnalysis // Batch re-analysis of sample files in progress (nil if none)
+	// Sample library
+	Library      *library.Index // Index of the sample folders (nil until the library view first opens)
+	LibraryScan  *LibraryScan   // Update of the index in progress (nil if none)
+	LibraryQuery string         // Words the library view searches for
+	// Sample quick-slots
+	QuickSlots [QuickSlotCount]string // Sample file bound to each digit key ("" for none)
+	// Reverb send
+	Reverb types.ReverbSettings // Algorithmic reverb and shimmer voicing
+	// Startup
+	SplashMode int // Splash screen at startup (types.SplashModeFull, Short or Off)
+	// Sample import
+	ImportMode int // Conversion of assigned samples (types.ImportModeOff, Rate, Mono or Both)
+	// UI performance
+	UIFrameRate    int  // UI refresh rate in frames per second (one of types.FrameRates)
+	WaveformDetail int  // Header waveform (types.WaveformDetailFull, Low or Off)
+	Animations     bool // Animate the splash screen
+	Palette        int  // Color palette (types.PaletteDefault, Deutan or Protan)
+	// Autosave
+	Autosave          bool // Save automatically after changes (off: only Ctrl+S and the quit prompt save)
+	AutosaveDelayMS   int  // Wait after the last change before autosaving
+	AutosaveIntervalS int  // Shortest time between two autosaves in seconds (0 for no limit)
+	// Playback resume
+	ResumePlayback bool                  // Save where playback is, to start from there when the project is opened
+	PendingResume  *types.TransportState // Playback to resume once SuperCollider is ready (nil for none)
+	DemoStart      bool                  // Play the song from the top once SuperCollider is ready (the project is a demo)
+	// UI recovery
+	LastUICrash time.Time // When the interface last recovered from a panic and snapshotted the project
+	// Master chain
+	MasterChain    []string // Order of the master chain stages (see MasterStages)
+	MasterChainRow int      // Selected stage in the master chain view
+	// Song cues
+	SongCues   [types.SongRows]string // Cue marker names on song rows ("" for none)
+	EditingCue bool                   // Whether the cue on the song row under the cursor is being named
+	CueBuffer  string                 // Cue name being typed
+	// Tempo and groove
+	Groove          int                                // Groove template of the song (index into GrooveTemplates)
+	SongRowTempos   [types.SongRows]types.SongRowTempo // Tempo and groove overrides of song rows
+	EditingRowTempo bool                               // Whether the tempo of the song row under the cursor is being typed
+	RowTempoBuffer  string                             // Tempo being typed
+	// Project notes
+	Notes    []string // Lines of the project's free-text notes (lyrics, arrangement TODOs, gear checklists)
+	NotesRow int      // Line of the notes cursor
+	NotesCol int      // Character of the notes cursor in its line
+	// Updates
+	Version         string           // Version of the running binary ("dev" for builds without one)
+	Releases        []update.Release // Releases found by the last update check, newest first
+	UpdateBusy      bool             // Whether an update check or download is in progress
+	UpdateError     string           // Why the last update check or install failed
+	UpdateInstalled string           // Version installed over the running binary, used from the next launch
+	ChangelogScroll int              // First line shown in the changelog view
+	// Generative song
+	GenerativeSong bool                                 // Choose each track's next song row by weighted random instead of in order
+	SongWeights    [types.NumTracks][types.SongRows]int // Chance of each song row being chosen next in generative mode (0-F, 0 never)
+	// Tempo and key change preview
+	Preview TempoKeyPreview // Tempo and key change being previewed before it is kept or reverted
+	// Live keyboard
+	LiveKeys LiveKeyboard // Computer keyboard played as an instrument on the current track
+	// Chord and scale helper
+	TheoryHelper bool // Show the chord and scale helper under instrument phrases
+	// MIDI sync out
+	MidiSync types.MidiSyncSettings // Metronome and track triggers mirrored as MIDI notes
+	// Eco mode
+	EcoMode bool // Lower UI and telemetry rates, pause sample analysis and hold autosaves during playback
+	// Tuner
+	TunerReference int // A4 in Hz the tuner measures against
+	// Phrase generator
+	Generator      types.GeneratorSettings // Constraints the phrase generator fills phrases within
+	GeneratorState PhraseGenerator         // Cursor and locked rows of the generator view
+	// Beat flash
+	BeatFlash    int       // Flash the header on beats or bars (BeatFlashOff, BeatFlashBeat or BeatFlashBar)
+	BeatPulse    string    // OSC engine pulsed with each flash, "" for none
+	beatFlashAt  time.Time // When the header last flashed
+	beatFlashBar bool      // The last flash was the first beat of a bar
+	// Pitch tracking
+	PitchTracking bool         // Enter notes detected in the audio input into the current phrase
+	pitchTracker  pitchTracker // Note onsets detected in the input (updated from the OSC server goroutine)
+	diagnostics   diagnostics  // Recent load of the tracker and SuperCollider
+	inputMeter    inputMeter   // Levels of the audio input (updated from the OSC server goroutine)
+	latencyTest   latencyTest  // Latest latency measurement (replies arrive on the OSC server goroutine)
+	// Timed start
+	TimedStart time.Time // When armed playback starts from the top of the song (zero when not armed)
+	// Activity LEDs
+	trackTriggeredAt [types.NumTracks]time.Time // When each track last triggered a row
+	// Simulation
+	clock  Clock      // Time playback runs on (nil for the wall clock)
+	output func(Sent) // Where a simulation's messages go instead of out (nil when not simulating)
 }
 
 // Methods for modifying data structures
@@ -213,7 +403,7 @@ func (m *Model) GetCurrentModulateSettings() *[255]types.ModulateSettings {
 }
 
 // GetCurrentPhrasesData returns the appropriate phrases data based on current track
-func (m *Model) GetCurrentPhrasesData() *[255][][]int {
+func (m *Model) GetCurrentPhrasesData() *[types.NumPhrases][][]int {
 	if m.GetPhraseViewType() == types.InstrumentPhraseView {
 		return &m.InstrumentPhrasesData
 	}
@@ -557,6 +747,33 @@ func (m *Model) GetColumnMapping(uiColumn int) *ColumnMapping {
 				IsDeletable:     true,
 				DisplayName:     "DU",
 			}
+		case int(types.InstrumentColN2): // N2 - stacked note 2
+			return &ColumnMapping{
+				DataColumnIndex: int(types.ColNote2),
+				IsEditable:      true,
+				IsCopyable:      true,
+				IsPasteable:     true,
+				IsDeletable:     true,
+				DisplayName:     "N2",
+			}
+		case int(types.InstrumentColN3): // N3 - stacked note 3
+			return &ColumnMapping{
+				DataColumnIndex: int(types.ColNote3),
+				IsEditable:      true,
+				IsCopyable:      true,
+				IsPasteable:     true,
+				IsDeletable:     true,
+				DisplayName:     "N3",
+			}
+		case int(types.InstrumentColN4): // N4 - stacked note 4
+			return &ColumnMapping{
+				DataColumnIndex: int(types.ColNote4),
+				IsEditable:      true,
+				IsCopyable:      true,
+				IsPasteable:     true,
+				IsDeletable:     true,
+				DisplayName:     "N4",
+			}
 		default:
 			return nil // Invalid column
 		}
@@ -729,7 +946,8 @@ func NewModel(oscPort int, saveFolder string, vimMode bool) *Model {
 		CurrentCol:        0,              // Start at first track in song view
 		ViewMode:          types.SongView, // Start with song view
 		CurrentPhrase:     0,
-		LastEditRow:       -1,    // No row edited yet
+		LastEditRow:       -1, // No row edited yet
+		pitchTracker:      pitchTracker{candidate: -1, held: -1},
 		BPM:               120.0, // Default BPM
 		PPQ:               2,     // Default PPQ
 		PregainDB:         0.0,   // Default pregain (0 dB)
@@ -738,9 +956,19 @@ func NewModel(oscPort int, saveFolder string, vimMode bool) *Model {
 		SaturationDB:      -6.0,  // Default saturation (-6 dB)
 		DriveDB:           -6.0,  // Default drive (-6 dB)
 		InputLevelDB:      0.0,   // Default input level (0 dB)
+		InputArmed:        true,  // Record the input by default
+		UIFrameRate:       30,    // Default UI refresh rate (30 fps)
+		Animations:        true,  // Animate by default
+		StartCountdown:    10,    // Default timed start countdown (10 s)
 		ReverbSendPercent: 0.0,   // Default reverb send (0%)
 		TapePercent:       0.0,   // Default tape (0%)
 		ShimmerPercent:    0.0,   // Default shimmer (0%)
+		FadeMS:            DefaultFadeMS,
+		Reverb:            types.DefaultReverbSettings(),
+		MidiSync:          types.DefaultMidiSyncSettings(),
+		MasterChain:       DefaultMasterChain(),
+		TunerReference:    TunerDefaultReference,
+		Generator:         types.DefaultGeneratorSettings(),
 		// Initialize playback inheritance values
 		lastPlaybackNote:     -1,
 		lastPlaybackDT:       -1,
@@ -784,6 +1012,7 @@ func NewModel(oscPort int, saveFolder string, vimMode bool) *Model {
 		RecordingEnabled:     false,
 		RecordingActive:      false,
 		CurrentRecordingFile: "",
+		SessionSection:       -1,
 		// Initialize vim mode
 		VimMode: vimMode,
 		// Initialize onset detection state
@@ -794,7 +1023,17 @@ func NewModel(oscPort int, saveFolder string, vimMode bool) *Model {
 		WaveformEnd:           0.0,
 		WaveformDuration:      0.0,
 		WaveformSelectedSlice: -1,
+		WaveformSelectedWarp:  -1,
 		WaveformPreviousView:  types.SongView,
+		// Confirm destructive operations by default
+		ConfirmDeletes:  true,
+		AuditionEdits:   true,
+		BounceRepeats:   DefaultBounceRepeats,
+		NudgeFine:       DefaultNudgeFine,
+		NudgeCoarse:     DefaultNudgeCoarse,
+		Autosave:        true,
+		AutosaveDelayMS: DefaultAutosaveDelayMS,
+		LiveKeys:        LiveKeyboard{Octave: DefaultLiveOctave, Last: -1},
 	}
 
 	// Initialize mixer state with defaults
@@ -802,22 +1041,32 @@ func NewModel(oscPort int, saveFolder string, vimMode bool) *Model {
 		m.TrackVolumes[i] = -96.0  // Start with silence (-96 dB)
 		m.TrackSetLevels[i] = -6.0 // Default set level (-6 dB)
 		m.TrackTypes[i] = true     // Default to Sampler (SA)
-		// Initialize per-track RNG for modulation
-		m.ModulateRngs[i] = rand.New(rand.NewSource(time.Now().UnixNano() + int64(i)))
+		m.TrackResolutions[i] = 1  // Default to the global PPQ
+		m.RecordQuantizeStrength[i] = DefaultRecordQuantizeStrength
 		// Initialize queued row to -1 (no target)
 		m.SongPlaybackQueuedRow[i] = -1
+		for row := range m.SongWeights[i] {
+			m.SongWeights[i][row] = DefaultSongWeight
+		}
 	}
 	m.CurrentMixerRow = 0   // Start on level row
 	m.CurrentMixerTrack = 0 // Default to track 0
+	m.MixerOther = m.MixerState() // Both sides of the A/B compare start the same
+
+	// Seed the random choices made during playback
+	m.RandomSeed = NewRandomSeed()
+	m.ResetRandom()
 
 	// Initialize OSC client if port is provided
 	if oscPort > 0 {
 		m.oscClient = osc.NewClient("localhost", oscPort)
-		log.Printf("OSC client initialized for localhost:%d", oscPort)
+		logging.OSC.Debugf("OSC client initialized for localhost:%d", oscPort)
 	}
 
 	// Initialize default data
 	m.initializeDefaultData()
+	m.Subscribe(m.recordAudit)
+	m.Subscribe(m.forgetSampleStats)
 	return m
 }
 
@@ -825,10 +1074,10 @@ func NewModel(oscPort int, saveFolder string, vimMode bool) *Model {
 // This is useful when SuperCollider starts on a different port than expected
 func (m *Model) UpdateOSCPort(newPort int) {
 	if newPort > 0 && newPort != m.oscPort {
-		log.Printf("Updating OSC client from port %d to %d", m.oscPort, newPort)
+		logging.OSC.Debugf("Updating OSC client from port %d to %d", m.oscPort, newPort)
 		m.oscPort = newPort
 		m.oscClient = osc.NewClient("localhost", newPort)
-		log.Printf("OSC client updated to localhost:%d", newPort)
+		logging.OSC.Debugf("OSC client updated to localhost:%d", newPort)
 	}
 }
 
@@ -910,6 +1159,10 @@ func (m *Model) initializeDefaultData() {
 			m.InstrumentPhrasesData[p][i][types.ColMidiCC6] = -1 // MIDI CC 6 (-1 displays "--", no emission)
 			m.InstrumentPhrasesData[p][i][types.ColMidiCC7] = -1 // MIDI CC 7 (-1 displays "--", no emission)
 			m.InstrumentPhrasesData[p][i][types.ColMidiCC8] = -1 // MIDI CC 8 (-1 displays "--", no emission)
+			// Initialize stacked note columns (no extra notes by default)
+			m.InstrumentPhrasesData[p][i][types.ColNote2] = -1 // Note 2 (-1 displays "---")
+			m.InstrumentPhrasesData[p][i][types.ColNote3] = -1 // Note 3 (-1 displays "---")
+			m.InstrumentPhrasesData[p][i][types.ColNote4] = -1 // Note 4 (-1 displays "---")
 			// Other columns can stay -1 (unused for instruments)
 		}
 	}
@@ -955,6 +1208,12 @@ func (m *Model) initializeDefaultData() {
 		}
 	}
 
+	m.InstrumentChainTransposes = NewChainTransposes()
+	m.SamplerChainTransposes = NewChainTransposes()
+	m.InstrumentChainFX = NewChainFX()
+	m.SamplerChainFX = NewChainFX()
+	m.PLocks = make(map[PLockRow]map[string]float32)
+
 	// Initialize sampler phrases files array
 	m.SamplerPhrasesFiles = make([]string, 0)
 
@@ -1081,10 +1340,14 @@ func (m *Model) initializeDefaultData() {
 
 func (m *Model) GetVisibleRows() int {
 	cellsHigh := (types.WaveformHeight + 1) / 2
-	if m.TermHeight == 0 {
-		return 20 - cellsHigh
+	rows := 20 - cellsHigh
+	if m.TermHeight != 0 {
+		rows = m.TermHeight - 5 - cellsHigh
+	}
+	if m.TheoryHelperShown() {
+		rows -= TheoryHelperLines
 	}
-	return m.TermHeight - 5 - cellsHigh
+	return rows
 }
 
 // SamplerOSCParams holds parameters for OSC sampler messages
@@ -1129,28 +1392,29 @@ type SamplerOSCParams struct {
 type InstrumentOSCParams struct {
 	TrackId            int32 // Track ID
 	NoteOn             int32
-	Notes              []float32 // Note number (MIDI values, but can be fractional)
-	Velocity           float32   // Note velocity (0.0-1.0)
-	ChordType          int       // Chord type (C parameter)
-	ChordAddition      int       // Chord addition (A parameter)
-	ChordTransposition int       // Chord transposition (T parameter)
-	Gate               int       // Gate value (GT parameter, raw value)
-	DeltaTime          float32   // Delta time in seconds (DT parameter, time per row * DT)
-	Attack             float32   // Attack time in seconds (A parameter)
-	Decay              float32   // Decay time in seconds (D parameter)
-	Sustain            float32   // Sustain level (S parameter)
-	Release            float32   // Release time in seconds (R parameter)
-	Pan                float32   // -1.0 to 1.0 (pan position)
-	LowPassFilter      float32   // Frequency in Hz (20Hz to 20kHz) or -1 for no filter
-	HighPassFilter     float32   // Frequency in Hz (20Hz to 20kHz) or -1 for no filter
-	EffectComb         float32   // 0.0 .. 1.0
-	EffectReverb       float32   // 0.0 .. 1.0
-	ArpeggioIndex      int       // Arpeggio settings index (AR parameter)
-	MidiSettingsIndex  int       // MIDI settings index (MI parameter)
-	SoundMakerIndex    int       // SoundMaker settings index (SO parameter)
-	DuckingIndex       int       // Ducking settings index (DU parameter)
-	MidiCC             [9]int    // MIDI CC values 0-8 (-1 = not set)
-	Update             int       // 1 if this is an update to a playing row, 0 otherwise
+	Notes              []float32          // Note number (MIDI values, but can be fractional)
+	Velocity           float32            // Note velocity (0.0-1.0)
+	ChordType          int                // Chord type (C parameter)
+	ChordAddition      int                // Chord addition (A parameter)
+	ChordTransposition int                // Chord transposition (T parameter)
+	Gate               int                // Gate value (GT parameter, raw value)
+	DeltaTime          float32            // Delta time in seconds (DT parameter, time per row * DT)
+	Attack             float32            // Attack time in seconds (A parameter)
+	Decay              float32            // Decay time in seconds (D parameter)
+	Sustain            float32            // Sustain level (S parameter)
+	Release            float32            // Release time in seconds (R parameter)
+	Pan                float32            // -1.0 to 1.0 (pan position)
+	LowPassFilter      float32            // Frequency in Hz (20Hz to 20kHz) or -1 for no filter
+	HighPassFilter     float32            // Frequency in Hz (20Hz to 20kHz) or -1 for no filter
+	EffectComb         float32            // 0.0 .. 1.0
+	EffectReverb       float32            // 0.0 .. 1.0
+	ArpeggioIndex      int                // Arpeggio settings index (AR parameter)
+	MidiSettingsIndex  int                // MIDI settings index (MI parameter)
+	SoundMakerIndex    int                // SoundMaker settings index (SO parameter)
+	PLocks             map[string]float32 // SoundMaker parameters locked on the row, by key
+	DuckingIndex       int                // Ducking settings index (DU parameter)
+	MidiCC             [9]int             // MIDI CC values 0-8 (-1 = not set)
+	Update             int                // 1 if this is an update to a playing row, 0 otherwise
 }
 
 // NewSamplerOSCParams creates sampler parameters with custom slice duration
@@ -1264,13 +1528,13 @@ func NewInstrumentOSCParams(trackId int32, velocity float32, chordType, chordAdd
 
 // CancelArpeggioForTrack cancels any existing arpeggio on the given track and sends note-off for currently playing notes
 func (m *Model) CancelArpeggioForTrack(trackId int32) {
-	log.Printf("DEBUG: CancelArpeggioForTrack called for track %d", trackId)
+	logging.Playback.Debugf("DEBUG: CancelArpeggioForTrack called for track %d", trackId)
 	m.arpeggioMutex.Lock()
 	defer m.arpeggioMutex.Unlock()
 
 	// Cancel any existing arpeggio context
 	if cancelFunc, exists := m.arpeggioContexts[trackId]; exists {
-		log.Printf("DEBUG: cancelArpeggioForTrack - FOUND existing arpeggio context for track %d, cancelling", trackId)
+		logging.Playback.Debugf("DEBUG: cancelArpeggioForTrack - FOUND existing arpeggio context for track %d, cancelling", trackId)
 		delete(m.arpeggioContexts, trackId) // Remove before calling cancel
 		m.arpeggioMutex.Unlock()            // Unlock before calling cancel to prevent deadlock
 		cancelFunc()                        // Now safe to call
@@ -1278,12 +1542,12 @@ func (m *Model) CancelArpeggioForTrack(trackId int32) {
 		time.Sleep(2 * time.Millisecond)
 		m.arpeggioMutex.Lock() // Re-lock for the rest of the function
 	} else {
-		log.Printf("DEBUG: cancelArpeggioForTrack - NO existing arpeggio context for track %d", trackId)
+		logging.Playback.Debugf("DEBUG: cancelArpeggioForTrack - NO existing arpeggio context for track %d", trackId)
 	}
 
 	// Send note-off for any currently playing arpeggio notes
 	if currentNotes, exists := m.arpeggioCurrentNotes[trackId]; exists && len(currentNotes) > 0 {
-		log.Printf("DEBUG: cancelArpeggioForTrack - sending note-off for %d notes on track %d", len(currentNotes), trackId)
+		logging.Playback.Debugf("DEBUG: cancelArpeggioForTrack - sending note-off for %d notes on track %d", len(currentNotes), trackId)
 
 		// Create note-off parameters based on the current notes
 		noteOffParams := InstrumentOSCParams{
@@ -1302,7 +1566,7 @@ func (m *Model) CancelArpeggioForTrack(trackId int32) {
 
 // SendOSCInstrumentMessageWithArpeggio is the high-level function that handles arpeggio logic
 func (m *Model) SendOSCInstrumentMessageWithArpeggio(params InstrumentOSCParams) {
-	log.Printf("DEBUG: SendOSCInstrumentMessageWithArpeggio called for track %d with notes %v, ArpeggioIndex=%d", params.TrackId, params.Notes, params.ArpeggioIndex)
+	logging.OSC.Debugf("DEBUG: SendOSCInstrumentMessageWithArpeggio called for track %d with notes %v, ArpeggioIndex=%d", params.TrackId, params.Notes, params.ArpeggioIndex)
 
 	// ALWAYS cancel any existing arpeggio on this track (whether new note has arpeggio or not)
 	m.CancelArpeggioForTrack(params.TrackId)
@@ -1312,7 +1576,7 @@ func (m *Model) SendOSCInstrumentMessageWithArpeggio(params InstrumentOSCParams)
 
 	if len(arpeggioNotes) > 0 {
 		// Arpeggio is active - send only the root note initially
-		log.Printf("DEBUG: Arpeggio active - sending only root note for track %d: %v", params.TrackId, params.Notes[0:1])
+		logging.OSC.Debugf("DEBUG: Arpeggio active - sending only root note for track %d: %v", params.TrackId, params.Notes[0:1])
 		rootOnlyParams := params
 		rootOnlyParams.Notes = []float32{params.Notes[0]} // Only send the root note
 		m.sendOSCInstrumentMessage(rootOnlyParams)
@@ -1321,29 +1585,29 @@ func (m *Model) SendOSCInstrumentMessageWithArpeggio(params InstrumentOSCParams)
 		m.PlayArpeggio(params, arpeggioNotes, arpeggioDivisions)
 	} else {
 		// No arpeggio - send the full chord/note as normal
-		log.Printf("DEBUG: No arpeggio - sending full chord/note for track %d: %v", params.TrackId, params.Notes)
+		logging.OSC.Debugf("DEBUG: No arpeggio - sending full chord/note for track %d: %v", params.TrackId, params.Notes)
 		m.sendOSCInstrumentMessage(params)
 	}
 }
 
 func (m *Model) ProcessArpeggio(params InstrumentOSCParams) (arpeggioNotes []float32, arpeggioDivisions []float32) {
-	log.Printf("DEBUG: ProcessArpeggio called with ArpeggioIndex=%d, input notes=%v", params.ArpeggioIndex, params.Notes)
+	logging.Playback.Debugf("DEBUG: ProcessArpeggio called with ArpeggioIndex=%d, input notes=%v", params.ArpeggioIndex, params.Notes)
 
 	// Check if we have a valid arpeggio index
 	if params.ArpeggioIndex < 0 || params.ArpeggioIndex >= 255 {
-		log.Printf("DEBUG: ProcessArpeggio - invalid arpeggio index %d, returning empty", params.ArpeggioIndex)
+		logging.Playback.Debugf("DEBUG: ProcessArpeggio - invalid arpeggio index %d, returning empty", params.ArpeggioIndex)
 		return nil, nil
 	}
 
 	arpeggioSettings := m.ArpeggioSettings[params.ArpeggioIndex]
-	log.Printf("DEBUG: ProcessArpeggio - got arpeggio settings for index %d", params.ArpeggioIndex)
+	logging.Playback.Debugf("DEBUG: ProcessArpeggio - got arpeggio settings for index %d", params.ArpeggioIndex)
 
 	// Use the chord notes that are already calculated and transposed in params.Notes
 	// This avoids double-transposition since helpers.go already called GetChordNotes with transposition
 	baseChord := make([]float32, len(params.Notes))
 	copy(baseChord, params.Notes)
 
-	log.Printf("DEBUG: ProcessArpeggio - using pre-calculated transposed chord notes: %v", baseChord)
+	logging.Playback.Debugf("DEBUG: ProcessArpeggio - using pre-calculated transposed chord notes: %v", baseChord)
 
 	var resultNotes []float32
 	var resultDivisors []float32
@@ -1352,7 +1616,7 @@ func (m *Model) ProcessArpeggio(params InstrumentOSCParams) (arpeggioNotes []flo
 	currentNote := baseChord[0]
 	isChord := len(baseChord) > 1
 
-	log.Printf("DEBUG: ProcessArpeggio - isChord: %v, baseChord: %v", isChord, baseChord)
+	logging.Playback.Debugf("DEBUG: ProcessArpeggio - isChord: %v, baseChord: %v", isChord, baseChord)
 
 	// Process each arpeggio row
 	for rowIdx, row := range arpeggioSettings.Rows {
@@ -1360,7 +1624,7 @@ func (m *Model) ProcessArpeggio(params InstrumentOSCParams) (arpeggioNotes []flo
 			continue // Skip empty rows ("--" values)
 		}
 
-		log.Printf("DEBUG: ProcessArpeggio - processing row %d: direction=%d, count=%d, divisor=%d",
+		logging.Playback.Debugf("DEBUG: ProcessArpeggio - processing row %d: direction=%d, count=%d, divisor=%d",
 			rowIdx, row.Direction, row.Count, row.Divisor)
 
 		isUp := row.Direction == 1 // 1="u-", 2="d-"
@@ -1382,11 +1646,11 @@ func (m *Model) ProcessArpeggio(params InstrumentOSCParams) (arpeggioNotes []flo
 			resultNotes = append(resultNotes, currentNote)
 			resultDivisors = append(resultDivisors, float32(row.Divisor))
 
-			log.Printf("DEBUG: ProcessArpeggio - added note: %f, divisor: %d", currentNote, row.Divisor)
+			logging.Playback.Debugf("DEBUG: ProcessArpeggio - added note: %f, divisor: %d", currentNote, row.Divisor)
 		}
 	}
 
-	log.Printf("DEBUG: ProcessArpeggio - final result: notes=%v, divisors=%v",
+	logging.Playback.Debugf("DEBUG: ProcessArpeggio - final result: notes=%v, divisors=%v",
 		resultNotes, resultDivisors)
 
 	return resultNotes, resultDivisors
@@ -1461,17 +1725,22 @@ func (m *Model) getNextChordNote(currentNote float32, baseChord []float32, isUp
 
 // sendOSCInstrumentMessage is the low-level function that sends a single OSC message
 func (m *Model) sendOSCInstrumentMessage(params InstrumentOSCParams) {
-	log.Printf("DEBUG: sendOSCInstrumentMessage called for track %d with notes %v", params.TrackId, params.Notes)
+	logging.OSC.Debugf("DEBUG: sendOSCInstrumentMessage called for track %d with notes %v", params.TrackId, params.Notes)
+
+	if engine, ok := m.TrackEngine(int(params.TrackId)); ok {
+		m.sendEngineMessages(engine, params)
+		return // OSC engine tracks don't play SuperCollider or MIDI
+	}
 
 	if m.oscClient == nil {
-		log.Printf("DEBUG: sendOSCInstrumentMessage - OSC client is nil, not sending")
+		logging.OSC.Debugf("DEBUG: sendOSCInstrumentMessage - OSC client is nil, not sending")
 		return // OSC not configured
 	}
 
 	// Check which mode the user has selected in the instrument view
 	// Only use MI if the user is in MI mode, otherwise use SO
 	if m.SOColumnMode == types.SOModeMIDI && params.MidiSettingsIndex != -1 {
-		log.Printf("DEBUG: sendOSCInstrumentMessage - User is in MI mode, using MIDI")
+		logging.OSC.Debugf("DEBUG: sendOSCInstrumentMessage - User is in MI mode, using MIDI")
 		m.sendMIDIInstrumentMessage(params)
 		return
 	}
@@ -1532,6 +1801,12 @@ func (m *Model) sendOSCInstrumentMessage(params InstrumentOSCParams) {
 		}
 		msg.Append("velocity")
 		msg.Append(int32(params.Velocity))
+		if params.NoteOn > 0 {
+			// A new row only releases voices of its own SoundMaker, so rows can switch
+			// SoundMakers like a drum kit (note-offs release every voice on the track)
+			msg.Append("voiceGroup")
+			msg.Append(int32(params.SoundMakerIndex))
+		}
 
 		// Add SoundMaker information using the new parameter framework
 		if params.SoundMakerIndex == -1 {
@@ -1549,6 +1824,9 @@ func (m *Model) sendOSCInstrumentMessage(params InstrumentOSCParams) {
 			if def, exists := types.GetInstrumentDefinition(soundMakerSettings.Name); exists {
 				for _, param := range def.Parameters {
 					value := soundMakerSettings.GetParameterValue(param.Key)
+					if locked, ok := params.PLocks[param.Key]; ok {
+						value = locked
+					}
 
 					// Append parameter key
 					msg.Append(param.Key)
@@ -1580,7 +1858,7 @@ func (m *Model) sendOSCInstrumentMessage(params InstrumentOSCParams) {
 				}
 			} else {
 				// Fallback if instrument definition not found
-				log.Printf("WARNING: No instrument definition found for %s", soundMakerSettings.Name)
+				logging.OSC.Warnf("WARNING: No instrument definition found for %s", soundMakerSettings.Name)
 			}
 		}
 
@@ -1590,13 +1868,15 @@ func (m *Model) sendOSCInstrumentMessage(params InstrumentOSCParams) {
 			msg.Append(int32(1))
 		}
 
-		log.Printf("DEBUG: Sending OSC to /instrument for %s:%d", m.oscClient.IP(), m.oscClient.Port())
+		logging.OSC.Debugf("DEBUG: Sending OSC to /instrument on port %d", m.oscPort)
+		m.countOSCSent()
+		m.sessionTakeSound()
 		err := m.oscClient.Send(msg)
 		if err != nil {
-			log.Printf("Error sending OSC instrument message: %v", err)
+			logging.OSC.Errorf("Error sending OSC instrument message: %v", err)
 		} else {
-			log.Printf("DEBUG: OSC instrument message sent successfully for track %d with notes %v", params.TrackId, params.Notes)
-			log.Printf("%s", msg)
+			logging.OSC.Debugf("DEBUG: OSC instrument message sent successfully for track %d with notes %v", params.TrackId, params.Notes)
+			logging.OSC.Debugf("%s", msg)
 		}
 	}
 }
@@ -1613,19 +1893,19 @@ func (m *Model) sendMIDIInstrumentMessage(params InstrumentOSCParams) {
 
 	// Check if device is not "None" (empty or default)
 	if midiSettings.Device == "None" || midiSettings.Device == "" {
-		log.Printf("DEBUG: MIDI device is None or empty, not sending MIDI messages")
+		logging.MIDI.Debugf("DEBUG: MIDI device is None or empty, not sending MIDI messages")
 		return
 	}
 
 	// Parse channel (convert from string to int, 1-indexed to 0-indexed)
 	channel, err := strconv.Atoi(midiSettings.Channel)
 	if err != nil {
-		log.Printf("ERROR: Failed to parse MIDI channel '%s': %v", midiSettings.Channel, err)
+		logging.MIDI.Errorf("ERROR: Failed to parse MIDI channel '%s': %v", midiSettings.Channel, err)
 		return
 	}
 	// Convert from 1-indexed to 0-indexed
 	if channel < 1 || channel > 16 {
-		log.Printf("ERROR: Invalid MIDI channel %d, must be 1-16", channel)
+		logging.MIDI.Errorf("ERROR: Invalid MIDI channel %d, must be 1-16", channel)
 		return
 	}
 	channel = channel - 1
@@ -1636,7 +1916,7 @@ func (m *Model) sendMIDIInstrumentMessage(params InstrumentOSCParams) {
 	// Use velocity from instrument parameters
 	velocity := float64(params.Velocity)
 
-	log.Printf("DEBUG: Sending MIDI messages for device=%s, channel=%d, notes=%v, velocity=%.0f, duration=%.3f",
+	logging.MIDI.Debugf("DEBUG: Sending MIDI messages for device=%s, channel=%d, notes=%v, velocity=%.0f, duration=%.3f",
 		midiSettings.Device, channel, params.Notes, velocity, duration)
 
 	// Send MIDI CC messages for each CC value that is not "--" (i.e., not -1)
@@ -1645,11 +1925,11 @@ func (m *Model) sendMIDIInstrumentMessage(params InstrumentOSCParams) {
 		if params.MidiCC[i] != -1 {
 			ccNumber := m.MidiCCNumbers[i]
 			ccValue := params.MidiCC[i]
-			err := midiplayer.ControlChange(midiSettings.Device, int(ccNumber), ccValue, channel)
+			err := m.midiControlChange(midiSettings.Device, int(ccNumber), ccValue, channel)
 			if err != nil {
-				log.Printf("ERROR: Failed to send MIDI CC %d with value %d: %v", ccNumber, ccValue, err)
+				logging.MIDI.Errorf("ERROR: Failed to send MIDI CC %d with value %d: %v", ccNumber, ccValue, err)
 			} else {
-				log.Printf("DEBUG: MIDI CC sent: device=%s, cc=%d, value=%d, channel=%d",
+				logging.MIDI.Debugf("DEBUG: MIDI CC sent: device=%s, cc=%d, value=%d, channel=%d",
 					midiSettings.Device, ccNumber, ccValue, channel)
 			}
 		}
@@ -1659,14 +1939,14 @@ func (m *Model) sendMIDIInstrumentMessage(params InstrumentOSCParams) {
 	for _, note := range params.Notes {
 		// Skip invalid notes (e.g., when NOT column is not defined)
 		if note < 0 || note > 127 {
-			log.Printf("DEBUG: Skipping invalid MIDI note: %.1f", note)
+			logging.MIDI.Debugf("DEBUG: Skipping invalid MIDI note: %.1f", note)
 			continue
 		}
-		err := midiplayer.NoteOn(midiSettings.Device, float64(note), velocity, duration, channel)
+		err := m.midiNoteOn(midiSettings.Device, float64(note), velocity, duration, channel)
 		if err != nil {
-			log.Printf("ERROR: Failed to send MIDI note-on for note %.1f: %v", note, err)
+			logging.MIDI.Errorf("ERROR: Failed to send MIDI note-on for note %.1f: %v", note, err)
 		} else {
-			log.Printf("DEBUG: MIDI note-on sent: device=%s, note=%.1f, velocity=%.0f, duration=%.3f, channel=%d",
+			logging.MIDI.Debugf("DEBUG: MIDI note-on sent: device=%s, note=%.1f, velocity=%.0f, duration=%.3f, channel=%d",
 				midiSettings.Device, note, velocity, duration, channel)
 		}
 	}
@@ -1674,16 +1954,16 @@ func (m *Model) sendMIDIInstrumentMessage(params InstrumentOSCParams) {
 
 // PlayArpeggio plays an arpeggio sequence for the given track with cancellation support
 func (m *Model) PlayArpeggio(params InstrumentOSCParams, notes []float32, divisions []float32) {
-	log.Printf("DEBUG: PlayArpeggio called with trackId=%d, notes=%v, divisions=%v, deltaTime=%f",
+	logging.Playback.Debugf("DEBUG: PlayArpeggio called with trackId=%d, notes=%v, divisions=%v, deltaTime=%f",
 		params.TrackId, notes, divisions, params.DeltaTime)
 
 	if len(notes) == 0 {
-		log.Printf("DEBUG: PlayArpeggio - no notes provided, returning")
+		logging.Playback.Debugf("DEBUG: PlayArpeggio - no notes provided, returning")
 		return
 	}
 
 	if len(divisions) == 0 {
-		log.Printf("DEBUG: PlayArpeggio - no divisions provided, returning")
+		logging.Playback.Debugf("DEBUG: PlayArpeggio - no divisions provided, returning")
 		return
 	}
 
@@ -1692,7 +1972,7 @@ func (m *Model) PlayArpeggio(params InstrumentOSCParams, notes []float32, divisi
 	m.arpeggioMutex.Lock()
 	// Ensure no old context exists before storing new one
 	if oldCancel, exists := m.arpeggioContexts[params.TrackId]; exists {
-		log.Printf("DEBUG: PlayArpeggio - WARNING: Found existing context for track %d, this shouldn't happen after cancellation!", params.TrackId)
+		logging.Playback.Debugf("DEBUG: PlayArpeggio - WARNING: Found existing context for track %d, this shouldn't happen after cancellation!", params.TrackId)
 		oldCancel() // Cancel the old one just in case
 	}
 	m.arpeggioContexts[params.TrackId] = cancel
@@ -1700,77 +1980,59 @@ func (m *Model) PlayArpeggio(params InstrumentOSCParams, notes []float32, divisi
 	m.arpeggioCurrentNotes[params.TrackId] = []float32{params.Notes[0]}
 	m.arpeggioMutex.Unlock()
 
-	log.Printf("DEBUG: PlayArpeggio - starting goroutine for track %d", params.TrackId)
+	logging.Playback.Debugf("DEBUG: PlayArpeggio - scheduling notes for track %d", params.TrackId)
 
-	// Start arpeggio in goroutine
-	go func() {
-		defer func() {
-			// Clean up context when done
-			log.Printf("DEBUG: PlayArpeggio - cleaning up context for track %d", params.TrackId)
-			m.arpeggioMutex.Lock()
-			delete(m.arpeggioContexts, params.TrackId)
-			m.arpeggioMutex.Unlock()
-		}()
-
-		log.Printf("DEBUG: PlayArpeggio - calculating wait time for first note. DeltaTime=%f, divisions[0]=%f",
-			params.DeltaTime, divisions[0])
-
-		if len(notes) > 0 && len(divisions) > 0 {
-			waitTime := time.Duration(float64(params.DeltaTime) / float64(divisions[0]) * float64(time.Second))
-			log.Printf("DEBUG: PlayArpeggio - waiting %v before first arpeggio note", waitTime)
-			select {
-			case <-time.After(waitTime):
-				log.Printf("DEBUG: PlayArpeggio - wait completed for first note")
-			case <-ctx.Done():
-				log.Printf("DEBUG: PlayArpeggio - cancelled during first wait")
-				return
-			}
+	// done forgets the arpeggio once it ends or is cancelled
+	done := func() {
+		logging.Playback.Debugf("DEBUG: PlayArpeggio - cleaning up context for track %d", params.TrackId)
+		m.arpeggioMutex.Lock()
+		delete(m.arpeggioContexts, params.TrackId)
+		m.arpeggioMutex.Unlock()
+	}
+
+	// play sends note i and schedules the next one on the model's clock, so a simulated clock
+	// plays arpeggios the same on every run
+	var play func(i int)
+	play = func(i int) {
+		if ctx.Err() != nil {
+			logging.Playback.Debugf("DEBUG: PlayArpeggio - cancelled before note %d", i)
+			return // CancelArpeggioForTrack forgets it
+		}
+		if i >= len(notes) || i >= len(divisions) {
+			logging.Playback.Debugf("DEBUG: PlayArpeggio - arpeggio sequence completed for track %d", params.TrackId)
+			done()
+			return
 		}
 
-		// Play remaining notes in the arpeggio
-		log.Printf("DEBUG: PlayArpeggio - starting loop for notes 1 to %d (total notes: %d, total divisions: %d)",
-			len(notes)-1, len(notes), len(divisions))
+		logging.Playback.Debugf("DEBUG: PlayArpeggio - playing note %d: %f", i, notes[i])
 
-		for i := 1; i < len(notes) && i < len(divisions); i++ {
-			select {
-			case <-ctx.Done():
-				log.Printf("DEBUG: PlayArpeggio - cancelled during note %d", i)
-				return
-			default:
-			}
+		// Create new params with the arpeggio note
+		arpeggioParams := params
+		arpeggioParams.Notes = []float32{notes[i]}
 
-			log.Printf("DEBUG: PlayArpeggio - playing note %d: %f", i, notes[i])
-
-			// Create new params with the arpeggio note
-			arpeggioParams := params
-			arpeggioParams.Notes = []float32{notes[i]}
-
-			// Send OSC message for this arpeggio note
-			m.sendOSCInstrumentMessage(arpeggioParams)
-
-			// Update currently playing note tracking
-			m.arpeggioMutex.Lock()
-			m.arpeggioCurrentNotes[params.TrackId] = []float32{notes[i]}
-			m.arpeggioMutex.Unlock()
-
-			// Wait for next note based on division
-			if i < len(divisions)-1 {
-				waitTime := time.Duration(float64(params.DeltaTime) / float64(divisions[i]) * float64(time.Second))
-				log.Printf("DEBUG: PlayArpeggio - waiting %v before note %d (division=%f)", waitTime, i+1, divisions[i])
-				select {
-				case <-time.After(waitTime):
-					log.Printf("DEBUG: PlayArpeggio - wait completed for note %d", i+1)
-				case <-ctx.Done():
-					log.Printf("DEBUG: PlayArpeggio - cancelled during wait for note %d", i+1)
-					return
-				}
-			} else {
-				log.Printf("DEBUG: PlayArpeggio - no more divisions, finishing after note %d", i)
-			}
+		// Send OSC message for this arpeggio note
+		m.sendOSCInstrumentMessage(arpeggioParams)
+
+		// Update currently playing note tracking
+		m.arpeggioMutex.Lock()
+		m.arpeggioCurrentNotes[params.TrackId] = []float32{notes[i]}
+		m.arpeggioMutex.Unlock()
+
+		// Wait for next note based on division
+		if i >= len(divisions)-1 {
+			logging.Playback.Debugf("DEBUG: PlayArpeggio - no more divisions, finishing after note %d", i)
+			done()
+			return
 		}
+		waitTime := time.Duration(float64(params.DeltaTime) / float64(divisions[i]) * float64(time.Second))
+		logging.Playback.Debugf("DEBUG: PlayArpeggio - waiting %v before note %d (division=%f)", waitTime, i+1, divisions[i])
+		m.afterFunc(waitTime, func() { play(i + 1) })
+	}
 
-		log.Printf("DEBUG: PlayArpeggio - arpeggio sequence completed for track %d", params.TrackId)
-	}()
+	// The root note is already sent, the rest follow after the first division
+	waitTime := time.Duration(float64(params.DeltaTime) / float64(divisions[0]) * float64(time.Second))
+	logging.Playback.Debugf("DEBUG: PlayArpeggio - waiting %v before first arpeggio note", waitTime)
+	m.afterFunc(waitTime, func() { play(1) })
 }
 
 func (m *Model) SendOSCSamplerMessage(params SamplerOSCParams) {
@@ -1781,7 +2043,7 @@ func (m *Model) SendOSCSamplerMessage(params SamplerOSCParams) {
 	// Convert filename to absolute path for SuperCollider
 	absolutePath, err := filepath.Abs(params.Filename)
 	if err != nil {
-		log.Printf("Error converting filename to absolute path: %v", err)
+		logging.OSC.Errorf("Error converting filename to absolute path: %v", err)
 		absolutePath = params.Filename // fallback to original filename
 	}
 
@@ -1880,11 +2142,13 @@ func (m *Model) SendOSCSamplerMessage(params SamplerOSCParams) {
 		msg.Append(int32(1))
 	}
 
+	m.countOSCSent()
+	m.sessionTakeSound()
 	err = m.oscClient.Send(msg)
 	if err != nil {
-		log.Printf("Error sending OSC sampler message: %v", err)
+		logging.OSC.Errorf("Error sending OSC sampler message: %v", err)
 	} else {
-		log.Printf("OSC sampler message sent: /sampler '%s' slices=%d slice=%d duration=%.2f bpmSource=%.2f bpmTarget=%.2f",
+		logging.OSC.Debugf("OSC sampler message sent: /sampler '%s' slices=%d slice=%d duration=%.2f bpmSource=%.2f bpmTarget=%.2f",
 			absolutePath, params.SliceCount, params.SliceNumber, params.SliceDuration, params.BPMSource, params.BPMTarget)
 	}
 }
@@ -1898,7 +2162,7 @@ func (m *Model) SendOSCPlaybackMessage(filename string, playing bool) {
 	// Convert filename to absolute path for SuperCollider
 	absolutePath, err := filepath.Abs(filename)
 	if err != nil {
-		log.Printf("Error converting filename to absolute path: %v", err)
+		logging.OSC.Errorf("Error converting filename to absolute path: %v", err)
 		absolutePath = filename // fallback to original filename
 	}
 
@@ -1978,6 +2242,32 @@ func (m *Model) SendOSCInputLevelMessage() {
 	m.sendOSCMessage(config)
 }
 
+// SendOSCInputMonitorMessage turns input monitoring on or off
+func (m *Model) SendOSCInputMonitorMessage() {
+	monitor := float32(0)
+	if m.InputMonitor {
+		monitor = 1
+	}
+	config := OSCMessageConfig{
+		Address:    "/set_track",
+		Parameters: []interface{}{int32(8), "monitor", monitor},
+		LogFormat:  "OSC input monitor message sent: /set_track 8 'monitor' %.0f",
+		LogArgs:    []interface{}{monitor},
+	}
+	m.sendOSCMessage(config)
+}
+
+// SendOSCInputInsertMessage selects the insert effect on the monitored input
+func (m *Model) SendOSCInputInsertMessage() {
+	config := OSCMessageConfig{
+		Address:    "/set_track",
+		Parameters: []interface{}{int32(8), "insert", float32(m.InputInsert)},
+		LogFormat:  "OSC input insert message sent: /set_track 8 'insert' %d (%s)",
+		LogArgs:    []interface{}{m.InputInsert, types.GetInputInsertName(m.InputInsert)},
+	}
+	m.sendOSCMessage(config)
+}
+
 func (m *Model) SendOSCReverbSendMessage() {
 	// Normalize percentage (0-100) to 0.0-1.0 for SuperCollider
 	normalizedValue := m.ReverbSendPercent / 100.0
@@ -2116,6 +2406,17 @@ func (m *Model) SendDuckingToExternalInput(duckingIndex int) {
 }
 
 func (m *Model) SendOSCTrackSetLevelMessage(trackNum int) {
+	if trackNum == 8 {
+		// The input track's mixer level is its monitor level
+		config := OSCMessageConfig{
+			Address:    "/set_track",
+			Parameters: []interface{}{int32(8), "monitorLevel", m.TrackSetLevels[8]},
+			LogFormat:  "OSC input monitor level message sent: /set_track 8 'monitorLevel' %.1f",
+			LogArgs:    []interface{}{m.TrackSetLevels[8]},
+		}
+		m.sendOSCMessage(config)
+		return
+	}
 	if trackNum < 0 || trackNum >= 8 {
 		return
 	}
@@ -2142,7 +2443,7 @@ func (m *Model) SendOSCRecordMessage(filename string, recording bool, trackMask
 	// Convert filename to absolute path for SuperCollider
 	absolutePath, err := filepath.Abs(filename)
 	if err != nil {
-		log.Printf("Error converting filename to absolute path: %v", err)
+		logging.OSC.Errorf("Error converting filename to absolute path: %v", err)
 		absolutePath = filename // fallback to original filename
 	}
 
@@ -2156,11 +2457,48 @@ func (m *Model) SendOSCRecordMessage(filename string, recording bool, trackMask
 	m.sendOSCMessage(config)
 }
 
+// SendOSCShutdownMessage asks SuperCollider to finalize recordings and free sample buffers before exit
+func (m *Model) SendOSCShutdownMessage() {
+	config := OSCMessageConfig{
+		Address:   "/shutdown",
+		LogFormat: "OSC shutdown message sent: /shutdown",
+	}
+
+	m.sendOSCMessage(config)
+}
+
+// SendOSCSessionRecordMessage starts or stops recording the master output to filename.
+// SuperCollider arms a take and starts it in the same bundle as the next row played, so
+// the file begins exactly on that row.
+func (m *Model) SendOSCSessionRecordMessage(filename string, recording bool) {
+	recordingInt := int32(0)
+	if recording {
+		recordingInt = 1
+	}
+
+	// Convert filename to absolute path for SuperCollider
+	absolutePath, err := filepath.Abs(filename)
+	if err != nil {
+		logging.OSC.Errorf("Error converting filename to absolute path: %v", err)
+		absolutePath = filename // fallback to original filename
+	}
+
+	config := OSCMessageConfig{
+		Address:    "/session_record",
+		Parameters: []interface{}{absolutePath, recordingInt},
+		LogFormat:  "OSC session record message sent: /session_record '%s' %d",
+		LogArgs:    []interface{}{absolutePath, int(recordingInt)},
+	}
+
+	m.sendOSCMessage(config)
+}
+
+// GenerateRecordingFilename returns a timestamped WAV path in the project's recordings folder
 func (m *Model) GenerateRecordingFilename() string {
 	now := time.Now()
-	return fmt.Sprintf("%04d-%02d-%02d-%02d-%02d-%02d.wav",
+	return filepath.Join(m.RecordingsFolder(), fmt.Sprintf("%04d-%02d-%02d-%02d-%02d-%02d.wav",
 		now.Year(), now.Month(), now.Day(),
-		now.Hour(), now.Minute(), now.Second())
+		now.Hour(), now.Minute(), now.Second()))
 }
 
 func (m *Model) PushTrackWaveformSample(track int, v float64, maxCols int) {
@@ -2187,19 +2525,49 @@ func (m *Model) PushWaveformSample(v float64, maxCols int) {
 }
 
 func (m *Model) SendStopOSC() {
+	m.sendEngineStop()
 	if m.oscClient == nil {
 		return
 	}
 	msg := osc.NewMessage("/stop")
+	m.countOSCSent()
 	_ = m.oscClient.Send(msg) // ignore error or log if you prefer
 }
 
+// SendStartOSC tells SuperCollider the transport is starting so it can ramp the output in
+func (m *Model) SendStartOSC() {
+	if m.oscClient == nil {
+		return
+	}
+	msg := osc.NewMessage("/start")
+	m.countOSCSent()
+	_ = m.oscClient.Send(msg)
+}
+
+// Fade time limits in milliseconds
+const (
+	DefaultFadeMS = 10
+	MaxFadeMS     = 500
+)
+
+// SendOSCFadeMessage sends the click-free ramp time used on transport start/stop and input level changes
+func (m *Model) SendOSCFadeMessage() {
+	seconds := float32(m.FadeMS) / 1000.0
+	config := OSCMessageConfig{
+		Address:    "/fade",
+		Parameters: []interface{}{seconds},
+		LogFormat:  "OSC fade message sent: /fade %.3f",
+		LogArgs:    []interface{}{seconds},
+	}
+	m.sendOSCMessage(config)
+}
+
 // SetAvailableMidiDevices updates the list of available MIDI devices
 // This function should be called from main.go when MIDI functionality is added
 func SetAvailableMidiDevices(devices []string) {
 	// For now this is just a placeholder to store the devices globally
 	// In the future, this would update the device list that can be selected in MIDI view
-	log.Printf("Available MIDI devices updated: %v", devices)
+	logging.MIDI.Debugf("Available MIDI devices updated: %v", devices)
 	// TODO: Store devices list and update MIDI settings accordingly
 }
 
@@ -2246,7 +2614,6 @@ func (m *Model) GetCurrentTrackFile() string {
 	return ""
 }
 
-
 // sendOSCMessage provides common logic for sending OSC messages
 func (m *Model) sendOSCMessage(config OSCMessageConfig) {
 	if m.oscClient == nil {
@@ -2258,12 +2625,13 @@ func (m *Model) sendOSCMessage(config OSCMessageConfig) {
 		msg.Append(param)
 	}
 
+	m.countOSCSent()
 	err := m.oscClient.Send(msg)
 	if err != nil {
-		log.Printf("Error sending OSC message to %s: %v", config.Address, err)
+		logging.OSC.Errorf("Error sending OSC message to %s: %v", config.Address, err)
 	} else {
 		if config.LogFormat != "" {
-			log.Printf(config.LogFormat, config.LogArgs...)
+			logging.OSC.Debugf(config.LogFormat, config.LogArgs...)
 		}
 	}
 }
@@ -2304,14 +2672,14 @@ func (m *Model) LoadTicksLeftForTrack(track int) {
 	if dtValue <= 0 {
 		m.SongPlaybackTicksLeft[track] = 0
 	} else {
-		// Set to dtValue so the row plays for exactly DT ticks
+		// Set to dtValue so the row plays for exactly DT ticks (in clock ticks, for the track's resolution)
 		// The playback logic will decrement on the LAST tick and then advance
-		m.SongPlaybackTicksLeft[track] = dtValue
+		m.SongPlaybackTicksLeft[track] = m.TrackTicks(track, dtValue)
 	}
 }
 
 // GetPhrasesDataForTrack returns the appropriate phrases data based on track type
-func (m *Model) GetPhrasesDataForTrack(track int) *[255][][]int {
+func (m *Model) GetPhrasesDataForTrack(track int) *[types.NumPhrases][][]int {
 	if track >= 0 && track < 8 && !m.TrackTypes[track] {
 		return &m.InstrumentPhrasesData
 	}
@@ -2364,8 +2732,10 @@ func (m *Model) GetRecordingTrackMask(fromSongView bool, fromCtrlSpace bool) uin
 		}
 	}
 
-	// Always include track 8 (external input, displayed as Track 9) for multitrack recording
-	trackMask |= (1 << 8)
+	// Include track 8 (external input, displayed as Track 9) for multitrack recording when armed
+	if m.InputArmed {
+		trackMask |= (1 << 8)
+	}
 
 	return trackMask
 }
@@ -2489,7 +2859,7 @@ func (m *Model) performOnsetDetection(filePath string) {
 	// Get the current metadata
 	metadata, exists := m.FileMetadata[filePath]
 	if !exists {
-		log.Printf("Onset detection skipped: no metadata found for %s", filePath)
+		logging.Storage.Debugf("Onset detection skipped: no metadata found for %s", filePath)
 		return
 	}
 
@@ -2501,31 +2871,24 @@ func (m *Model) performOnsetDetection(filePath string) {
 	// Get absolute path
 	absPath, err := filepath.Abs(filePath)
 	if err != nil {
-		log.Printf("Onset detection error: could not resolve path for %s: %v", filePath, err)
+		logging.Storage.Warnf("Onset detection error: could not resolve path for %s: %v", filePath, err)
 		return
 	}
 
-	log.Printf("Starting onset detection for %s with %d slices", absPath, metadata.Slices)
+	logging.Storage.Debugf("Starting onset detection for %s with %d slices", absPath, metadata.Slices)
 
 	// Use waveform file for onset detection if available (works better than FLAC)
 	onsetDetectionFile := absPath
 	if metadata.WaveformFile != "" {
 		onsetDetectionFile = metadata.WaveformFile
-		log.Printf("Using waveform file for onset detection: %s", onsetDetectionFile)
+		logging.Storage.Debugf("Using waveform file for onset detection: %s", onsetDetectionFile)
 	}
 
 	// Perform onset detection in a goroutine to avoid blocking
 	go func() {
-		options := onset.SliceAnalyzerOptions{
-			NumSlices:        metadata.Slices,
-			Method:           "hfc",
-			Optimize:         true,
-			OptimizeWindowMs: 15.0,
-		}
-
-		result, err := onset.AnalyzeSlices(onsetDetectionFile, options)
+		result, err := onset.AnalyzeSlices(onsetDetectionFile, OnsetOptions(metadata.Slices, metadata.OnsetPreset))
 		if err != nil {
-			log.Printf("Onset detection failed for %s: %v", absPath, err)
+			logging.Storage.Warnf("Onset detection failed for %s: %v", absPath, err)
 			return
 		}
 
@@ -2535,14 +2898,14 @@ func (m *Model) performOnsetDetection(filePath string) {
 		
 		currentMetadata, exists := m.FileMetadata[filePath]
 		if !exists {
-			log.Printf("Onset detection completed but metadata was removed for %s", filePath)
+			logging.Storage.Debugf("Onset detection completed but metadata was removed for %s", filePath)
 			return
 		}
 
 		currentMetadata.Onsets = result.Onsets
 		m.FileMetadata[filePath] = currentMetadata
 		
-		log.Printf("Onset detection completed for %s: found %d onsets", filePath, len(result.Onsets))
+		logging.Storage.Debugf("Onset detection completed for %s: found %d onsets", filePath, len(result.Onsets))
 		
 		// Trigger auto-save
 		// Note: This will need to be called through a proper mechanism
@@ -2555,7 +2918,7 @@ func (m *Model) generateEqualSlices(filePath string) {
 	// Get the current metadata
 	metadata, exists := m.FileMetadata[filePath]
 	if !exists {
-		log.Printf("Equal slice generation skipped: no metadata found for %s", filePath)
+		logging.Storage.Debugf("Equal slice generation skipped: no metadata found for %s", filePath)
 		return
 	}
 
@@ -2567,11 +2930,11 @@ func (m *Model) generateEqualSlices(filePath string) {
 	// Get absolute path
 	absPath, err := filepath.Abs(filePath)
 	if err != nil {
-		log.Printf("Equal slice generation error: could not resolve path for %s: %v", filePath, err)
+		logging.Storage.Warnf("Equal slice generation error: could not resolve path for %s: %v", filePath, err)
 		return
 	}
 
-	log.Printf("Generating equal slices for %s with %d slices", absPath, metadata.Slices)
+	logging.Storage.Debugf("Generating equal slices for %s with %d slices", absPath, metadata.Slices)
 
 	// Get the audio file length - use waveform file if available (works better than FLAC)
 	lengthDetectionFile := absPath
@@ -2580,17 +2943,12 @@ func (m *Model) generateEqualSlices(filePath string) {
 	}
 	audioLength, _, _, err := getbpm.Length(lengthDetectionFile)
 	if err != nil {
-		log.Printf("Equal slice generation failed for %s: could not get audio length: %v", absPath, err)
+		logging.Storage.Warnf("Equal slice generation failed for %s: could not get audio length: %v", absPath, err)
 		return
 	}
 
 	// Calculate equal slice positions
-	slices := make([]float64, metadata.Slices)
-	sliceDuration := audioLength / float64(metadata.Slices)
-	
-	for i := 0; i < metadata.Slices; i++ {
-		slices[i] = float64(i) * sliceDuration
-	}
+	slices := EqualSliceOnsets(audioLength, metadata.Slices)
 
 	// Update the metadata with the generated slices
 	m.onsetDetectionMutex.Lock()
@@ -2598,14 +2956,14 @@ func (m *Model) generateEqualSlices(filePath string) {
 	
 	currentMetadata, exists := m.FileMetadata[filePath]
 	if !exists {
-		log.Printf("Equal slice generation completed but metadata was removed for %s", filePath)
+		logging.Storage.Debugf("Equal slice generation completed but metadata was removed for %s", filePath)
 		return
 	}
 
 	currentMetadata.Onsets = slices
 	m.FileMetadata[filePath] = currentMetadata
 	
-	log.Printf("Equal slice generation completed for %s: generated %d slices", filePath, len(slices))
+	logging.Storage.Debugf("Equal slice generation completed for %s: generated %d slices", filePath, len(slices))
 }
 
 // GetSliceForSample returns the slice number to use for a given slice index and file
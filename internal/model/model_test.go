@@ -1,9 +1,15 @@
 package model
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"gitlab.com/gomidi/midi/v2/smf"
 
 	"github.com/schollz/collidertracker/internal/types"
 )
@@ -822,3 +828,1442 @@ func TestGenerateEqualSlicesOnlyInEqualMode(t *testing.T) {
 	assert.Len(t, metadata.Onsets, 0, 
 		"Should not generate slices when in Onset mode (SliceType=1)")
 }
+
+func TestComputeProjectStats(t *testing.T) {
+	m := NewModel(0, "test.json", false)
+	m.BPM = 120
+	m.PPQ = 2
+
+	// Sampler track 0: song row 0 -> chain 1 -> phrase 2 with two played rows
+	m.TrackTypes[0] = true
+	m.SongData[0][0] = 1
+	m.SamplerChainsData[1][0] = 2
+	m.SamplerPhrasesData[2][0][types.ColDeltaTime] = 4
+	m.SamplerPhrasesData[2][1][types.ColDeltaTime] = 4
+	m.SamplerPhrasesData[2][2][types.ColDeltaTime] = -1 // Not played
+
+	stats := m.ComputeProjectStats()
+	assert.Equal(t, 2, stats.TrackEvents[0])
+	assert.Equal(t, 8, stats.SongTicks)
+	assert.Equal(t, 2.0, stats.SongSeconds) // 120 BPM at PPQ 2 is 4 ticks per second
+	assert.Equal(t, 1, stats.SamplerChainsUsed)
+	assert.Equal(t, 1, stats.SamplerPhrasesUsed)
+}
+
+func TestProjectStatsSampleCache(t *testing.T) {
+	m := NewModel(0, t.TempDir(), false)
+	sample := filepath.Join(t.TempDir(), "kick.wav")
+	assert.NoError(t, os.WriteFile(sample, make([]byte, 10), 0644))
+	m.SamplerPhrasesFiles = []string{sample, filepath.Join(t.TempDir(), "gone.wav")}
+
+	stats := m.ComputeProjectStats()
+	assert.Equal(t, 2, stats.SampleFiles)
+	assert.Equal(t, int64(10), stats.SampleBytes)
+	assert.Equal(t, 1, stats.MissingSamples)
+
+	// Sizes are kept between renders and through saves
+	assert.NoError(t, os.WriteFile(sample, make([]byte, 20), 0644))
+	m.Publish(Event{Kind: EventSaved})
+	assert.Equal(t, int64(10), m.ComputeProjectStats().SampleBytes)
+
+	// An edit reads them again
+	m.SetSongCell(0, 0, 1)
+	assert.Equal(t, int64(20), m.ComputeProjectStats().SampleBytes)
+}
+
+func TestOSCLinkHealth(t *testing.T) {
+	m := NewModel(0, "test.json", false)
+
+	// No telemetry yet: never reported as lost
+	assert.False(t, m.CheckOSCLink(time.Now().Add(time.Minute)))
+
+	assert.False(t, m.HandleCPUUsage(0.5))
+	assert.False(t, m.CheckOSCLink(time.Now()))
+	assert.True(t, m.CheckOSCLink(time.Now().Add(OSCLinkTimeout+time.Second)))
+	assert.True(t, m.IsOSCLinkLost())
+
+	// First message after the gap reports the reconnect
+	assert.True(t, m.HandleCPUUsage(0.4))
+	assert.False(t, m.IsOSCLinkLost())
+	assert.Equal(t, float32(0.4), m.CPUUsage)
+}
+
+func TestRecordingsList(t *testing.T) {
+	m := NewModel(0, t.TempDir(), false)
+	assert.NoError(t, os.MkdirAll(m.RecordingsFolder(), 0755))
+
+	older := filepath.Join(m.RecordingsFolder(), "session-a.wav")
+	newer := filepath.Join(m.RecordingsFolder(), "take.wav")
+	assert.NoError(t, os.WriteFile(older, []byte("RIFF"), 0644))
+	assert.NoError(t, os.WriteFile(newer, []byte("RIFF"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(m.RecordingsFolder(), "notes.txt"), []byte("x"), 0644))
+	past := time.Now().Add(-time.Hour)
+	assert.NoError(t, os.Chtimes(older, past, past))
+
+	m.RefreshRecordings()
+	assert.Len(t, m.Recordings, 2, "Only WAV files are listed")
+	assert.Equal(t, "take.wav", m.Recordings[0].Name, "Newest recording first")
+
+	newPath, err := m.RenameRecording(newer, "best take")
+	assert.NoError(t, err)
+	assert.Equal(t, "best take.wav", filepath.Base(newPath))
+
+	_, err = m.RenameRecording(newPath, "session-a")
+	assert.Error(t, err, "Renaming onto an existing recording fails")
+	_, err = m.RenameRecording(newPath, "../escape")
+	assert.Error(t, err)
+
+	m.RecordingsRow = 5
+	m.RefreshRecordings()
+	assert.Equal(t, 1, m.RecordingsRow, "Cursor is clamped to the list")
+}
+
+func TestReverbImpulseChoices(t *testing.T) {
+	m := NewModel(0, t.TempDir(), false)
+	assert.NoError(t, os.MkdirAll(m.ImpulsesFolder(), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(m.ImpulsesFolder(), "church.wav"), []byte("RIFF"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(m.ImpulsesFolder(), "readme.txt"), []byte("x"), 0644))
+
+	choices := m.ReverbImpulseChoices()
+	assert.Equal(t, []string{"", "builtin:room", "builtin:hall", "builtin:plate", "church.wav"}, choices)
+
+	assert.Equal(t, "", m.ReverbImpulse, "Algorithmic reverb by default")
+	m.CycleReverbImpulse(-1)
+	assert.Equal(t, "", m.ReverbImpulse)
+	m.CycleReverbImpulse(1)
+	assert.Equal(t, "room", ReverbImpulseName(m.ReverbImpulse))
+	for i := 0; i < 10; i++ {
+		m.CycleReverbImpulse(1)
+	}
+	assert.Equal(t, "church.wav", ReverbImpulseName(m.ReverbImpulse), "Cycling stops at the last choice")
+}
+
+func TestMasterChain(t *testing.T) {
+	m := NewModel(0, t.TempDir(), false)
+	assert.True(t, m.IsDefaultMasterChain())
+
+	m.MasterChainRow = 0
+	assert.False(t, m.MoveMasterStage(-1), "The first stage cannot move earlier")
+	assert.True(t, m.MoveMasterStage(1))
+	assert.Equal(t, []string{"reverb", "comb", "filter", "tape", "limiter"}, m.MasterChain)
+	assert.Equal(t, 1, m.MasterChainRow, "The moved stage stays selected")
+	assert.False(t, m.IsDefaultMasterChain())
+	assert.Equal(t, []string{"comb", "reverb", "filter", "tape", "limiter"}, MasterStages, "Defaults are not modified")
+
+	assert.True(t, ValidMasterChain(m.MasterChain))
+	assert.False(t, ValidMasterChain([]string{"comb", "comb", "filter", "tape", "limiter"}))
+	assert.False(t, ValidMasterChain([]string{"comb", "reverb"}))
+	assert.False(t, ValidMasterChain(nil))
+}
+
+func TestSampleRateMismatch(t *testing.T) {
+	m := NewModel(0, t.TempDir(), false)
+	testFile := "../getbpm/Break120.wav"
+	rate := m.SampleRate(testFile)
+	assert.Greater(t, rate, 0)
+	assert.Equal(t, 0, m.SampleRate("missing.wav"))
+
+	assert.Equal(t, "", m.SampleRateMismatch(testFile), "No warning before the server reports its rate")
+	m.SetServerAudioInfo("scsynth", rate, 64)
+	assert.Equal(t, "", m.SampleRateMismatch(testFile))
+	m.SetServerAudioInfo("scsynth", rate+1, 64)
+	assert.Contains(t, m.SampleRateMismatch(testFile), "resampled on playback")
+}
+
+func TestRandomSeed(t *testing.T) {
+	m := NewModel(0, t.TempDir(), false)
+	assert.True(t, m.RandomSeed >= 1 && m.RandomSeed <= MaxRandomSeed)
+
+	m.SetRandomSeed(MaxRandomSeed + 1)
+	assert.Equal(t, 1, m.RandomSeed)
+	m.SetRandomSeed(0)
+	assert.Equal(t, MaxRandomSeed, m.RandomSeed)
+
+	// Restarting from the same seed repeats the same choices
+	m.SetRandomSeed(42)
+	first := []int{m.ModulateRngs[0].Intn(1000), m.ModulateRngs[7].Intn(1000), m.EffectRng.Intn(1000)}
+	m.ResetRandom()
+	again := []int{m.ModulateRngs[0].Intn(1000), m.ModulateRngs[7].Intn(1000), m.EffectRng.Intn(1000)}
+	assert.Equal(t, first, again)
+
+	m.RerollRandomSeed()
+	assert.NotEqual(t, 42, m.RandomSeed)
+}
+
+func TestDataAccess(t *testing.T) {
+	m := NewModel(0, t.TempDir(), false)
+	m.TrackTypes[0] = false // Instrument
+	m.TrackTypes[1] = true  // Sampler
+
+	var changes []DataChange
+	m.Subscribe(func(e Event) {
+		if e.Kind == EventData {
+			changes = append(changes, e.Data)
+		}
+	})
+
+	// Out of range reads are empty and writes are refused
+	assert.Equal(t, -1, m.GetSongCell(types.NumTracks, 0))
+	assert.False(t, m.SetSongCell(0, types.SongRows, 1))
+	assert.Equal(t, -1, m.GetChainCell(0, types.NumChains, 0))
+	assert.False(t, m.SetChainCell(0, 0, types.ChainRows, 1))
+	assert.Equal(t, -1, m.GetPhraseCell(0, 0, types.PhraseRows, types.ColNote))
+	assert.False(t, m.SetPhraseCell(0, -1, 0, types.ColNote, 1))
+	assert.False(t, m.SetPhraseCell(0, 0, 0, types.ColCount, 1))
+	assert.Nil(t, m.GetPhraseRow(0, types.NumPhrases, 0))
+	assert.Empty(t, changes)
+
+	assert.True(t, m.SetSongCell(2, 3, 5))
+	assert.Equal(t, 5, m.GetSongCell(2, 3))
+	assert.Equal(t, 5, m.SongData[2][3])
+
+	// Instrument and sampler tracks write to their own pools
+	assert.True(t, m.SetChainCell(0, 4, 1, 7))
+	assert.Equal(t, 7, m.InstrumentChainsData[4][1])
+	assert.Equal(t, -1, m.GetChainCell(1, 4, 1))
+	assert.True(t, m.SetPhraseCell(1, 6, 2, types.ColNote, 60))
+	assert.Equal(t, 60, m.SamplerPhrasesData[6][2][types.ColNote])
+	assert.Equal(t, -1, m.GetPhraseCell(0, 6, 2, types.ColNote))
+
+	// Rewriting the same value does not notify
+	m.SetPhraseCell(1, 6, 2, types.ColNote, 60)
+	assert.Equal(t, []DataChange{
+		{Kind: DataSong, Track: 2, ID: -1, Row: 3, Old: -1, New: 5},
+		{Kind: DataChain, Track: 0, ID: 4, Row: 1, Old: -1, New: 7},
+		{Kind: DataPhrase, Track: 1, ID: 6, Row: 2, Col: types.ColNote, Old: -1, New: 60},
+	}, changes)
+
+	// GetPhraseRow returns a copy
+	row := m.GetPhraseRow(1, 6, 2)
+	row[types.ColNote] = 61
+	assert.Equal(t, 60, m.GetPhraseCell(1, 6, 2, types.ColNote))
+	assert.True(t, m.SetPhraseRow(0, 6, 2, row))
+	assert.Equal(t, 61, m.GetPhraseCell(0, 6, 2, types.ColNote))
+}
+
+func TestEvents(t *testing.T) {
+	m := NewModel(0, t.TempDir(), false)
+	var kinds []EventKind
+	m.Subscribe(func(e Event) { kinds = append(kinds, e.Kind) })
+	assert.False(t, m.IsDirty())
+
+	m.SetSongCell(0, 0, 1)
+	assert.True(t, m.IsDirty())
+	m.Publish(Event{Kind: EventSaved})
+	assert.False(t, m.IsDirty())
+	m.Publish(Event{Kind: EventSettings})
+	assert.True(t, m.IsDirty())
+	m.Publish(Event{Kind: EventLoaded})
+	assert.False(t, m.IsDirty())
+	m.Publish(Event{Kind: EventView})
+	assert.False(t, m.IsDirty(), "Moving around is saved but is not an edit")
+
+	assert.Equal(t, []EventKind{EventData, EventSaved, EventSettings, EventLoaded, EventView}, kinds)
+}
+
+func TestAppConfig(t *testing.T) {
+	m := NewModel(0, t.TempDir(), false)
+
+	m.ToggleVim()
+	assert.True(t, m.VimMode)
+	assert.True(t, m.Config.Vim)
+
+	// Dump turns on to the default file, and back on to the last file used
+	assert.Equal(t, "off", m.DumpName())
+	m.ToggleDump()
+	assert.Equal(t, DefaultDumpPath, m.Config.Dump)
+	m.Config.Dump = filepath.Join("frames", "dump.txt")
+	m.ToggleDump()
+	assert.Equal(t, "", m.Config.Dump)
+	m.ToggleDump()
+	assert.Equal(t, "dump.txt", m.DumpName())
+}
+
+func TestTrackResolution(t *testing.T) {
+	m := NewModel(0, t.TempDir(), false)
+	assert.Equal(t, 1, m.ClockResolution())
+	assert.Equal(t, 3, m.TrackTicks(0, 3))
+
+	m.CycleTrackResolution(2, 1)
+	m.CycleTrackResolution(2, 1)
+	assert.Equal(t, 4, m.TrackResolution(2))
+	assert.Equal(t, 4, m.ClockResolution())
+	assert.Equal(t, 12, m.TrackTicks(0, 3))
+	assert.Equal(t, 3, m.TrackTicks(2, 3))
+
+	// Changing the clock while playing rescales the ticks left
+	m.IsPlaying = true
+	m.SongPlaybackTicksLeft[0] = 12
+	m.PlaybackTickCount = 40
+	m.CycleTrackResolution(2, -1)
+	assert.Equal(t, 2, m.ClockResolution())
+	assert.Equal(t, 6, m.SongPlaybackTicksLeft[0])
+	assert.Equal(t, 20, m.PlaybackTickCount)
+
+	assert.True(t, ValidTrackResolution(8))
+	assert.False(t, ValidTrackResolution(3))
+}
+
+func TestKit(t *testing.T) {
+	m := NewModel(0, "", false)
+	m.SamplerPhrasesFiles = []string{"/kit/snare.wav"}
+	m.FileMetadata["/kit/kick.wav"] = types.FileMetadata{BPM: 120, Slices: 16, Playthrough: types.PlaythroughKit, SyncToBPM: 1}
+
+	// Kit files reuse their slot when they already have one
+	m.SetKit("/kit/kick.wav", []string{"/kit/hat.wav", "/kit/kick.wav", "/kit/snare.wav"})
+	assert.Equal(t, []int{1, 2, 0}, m.FileMetadata["/kit/kick.wav"].Kit)
+	assert.Equal(t, []string{"/kit/snare.wav", "/kit/hat.wav", "/kit/kick.wav"}, m.SamplerPhrasesFiles)
+
+	// NN picks the file, wrapping around the kit
+	assert.Equal(t, "/kit/hat.wav", m.KitFile("/kit/kick.wav", 0))
+	assert.Equal(t, "/kit/snare.wav", m.KitFile("/kit/kick.wav", 2))
+	assert.Equal(t, "/kit/kick.wav", m.KitFile("/kit/kick.wav", 4))
+	assert.Equal(t, "", m.KitFile("/kit/kick.wav", -1))
+
+	// Other playthrough modes keep picking slices
+	metadata := m.FileMetadata["/kit/kick.wav"]
+	metadata.Playthrough = 0
+	m.FileMetadata["/kit/kick.wav"] = metadata
+	assert.Equal(t, "", m.KitFile("/kit/kick.wav", 0))
+	assert.Equal(t, "", m.KitFile("/kit/none.wav", 0))
+}
+
+func TestTimeline(t *testing.T) {
+	m := NewModel(0, "", false)
+	m.BPM = 120
+	m.PPQ = 2
+
+	// Track 0: chain 1 plays 8 ticks (2s), chain 2 plays nothing, chain 3 plays 4 ticks (1s)
+	m.TrackTypes[0], m.TrackTypes[1] = false, false
+	m.SongData[0][0] = 1
+	m.SongData[0][1] = 2
+	m.SongData[0][3] = 3
+	m.InstrumentChainsData[1][0] = 1
+	m.InstrumentPhrasesData[1][0][types.ColDeltaTime] = 8
+	m.InstrumentChainsData[3][0] = 3
+	m.InstrumentPhrasesData[3][0][types.ColDeltaTime] = 4
+
+	// Track 1 at double resolution: the same 8 ticks take half as long
+	m.SongData[1][0] = 1
+	m.TrackResolutions[1] = 2
+
+	lanes := m.Timeline()
+	assert.Equal(t, []TimelineBlock{{Row: 0, Chain: 1, Start: 0, Seconds: 2}, {Row: 3, Chain: 3, Start: 2, Seconds: 1}}, lanes[0])
+	assert.Equal(t, []TimelineBlock{{Row: 0, Chain: 1, Start: 0, Seconds: 1}}, lanes[1])
+	assert.Empty(t, lanes[2])
+	assert.Equal(t, 3.0, TimelineSeconds(lanes))
+
+	assert.Equal(t, 1, TimelineBlockIndex(lanes[0], 3))
+	assert.Equal(t, -1, TimelineBlockIndex(lanes[0], 1))
+	assert.Equal(t, 0, TimelineBlockAt(lanes[0], 1.5))
+	assert.Equal(t, 1, TimelineBlockAt(lanes[0], 2))
+	assert.Equal(t, -1, TimelineBlockAt(lanes[2], 0))
+}
+
+func TestPitchTracking(t *testing.T) {
+	assert.Equal(t, 69, FrequencyToNote(440))
+	assert.Equal(t, 60, FrequencyToNote(261.63))
+	assert.Equal(t, -1, FrequencyToNote(0))
+
+	m := NewModel(0, "", false)
+	m.TrackTypes[0] = false
+	m.CurrentTrack, m.CurrentPhrase, m.CurrentRow = 0, 1, 2
+	m.ViewMode = types.PhraseView
+	sing := func(freq, confidence, amp float64, times int) {
+		for i := 0; i < times; i++ {
+			m.HandleInputPitch(freq, confidence, amp)
+		}
+	}
+
+	// Nothing is tracked while pitch tracking is off
+	sing(440, 1, 0.5, PitchTrackStableReads)
+	assert.Equal(t, 0, m.EnterTrackedNotes())
+
+	// A steady note step-enters at the cursor, once
+	m.TogglePitchTracking()
+	sing(440, 1, 0.5, PitchTrackStableReads-1)
+	assert.Equal(t, -1, m.PitchTrackingNote())
+	sing(440, 1, 0.5, 10)
+	assert.Equal(t, 69, m.PitchTrackingNote())
+	assert.Equal(t, 1, m.EnterTrackedNotes())
+	assert.Equal(t, 69, m.GetPhraseCell(0, 1, 2, types.ColNote))
+	assert.Equal(t, 1, m.GetPhraseCell(0, 1, 2, types.ColDeltaTime))
+	assert.Equal(t, 3, m.CurrentRow)
+
+	// Unpitched or quiet input ends the note, so the same note can be entered again
+	sing(440, 0.2, 0.5, PitchTrackStableReads)
+	assert.Equal(t, -1, m.PitchTrackingNote())
+	sing(440, 1, 0.5, PitchTrackStableReads)
+	assert.Equal(t, 1, m.EnterTrackedNotes())
+	assert.Equal(t, 69, m.GetPhraseCell(0, 1, 3, types.ColNote))
+
+	// While the phrase plays, notes late in a row snap to the next row
+	m.IsPlaying = true
+	m.PlaybackMode = types.PhraseView
+	m.PlaybackPhrase, m.PlaybackRow, m.PlaybackTicksLeft = 1, 5, 1
+	m.SetPhraseCell(0, 1, 5, types.ColDeltaTime, 4)
+	sing(523.25, 1, 0.5, PitchTrackStableReads)
+	assert.Equal(t, 1, m.EnterTrackedNotes())
+	assert.Equal(t, 72, m.GetPhraseCell(0, 1, 6, types.ColNote))
+	assert.Equal(t, 4, m.CurrentRow, "The cursor stays put while playing")
+
+	// Sampler tracks do not take tracked notes
+	m.TrackTypes[0] = true
+	sing(440, 1, 0.5, PitchTrackStableReads)
+	assert.Equal(t, 0, m.EnterTrackedNotes())
+}
+
+func TestTuner(t *testing.T) {
+	note, cents := TuneNote(440, 440)
+	assert.Equal(t, 69, note)
+	assert.InDelta(t, 0, cents, 0.01)
+	note, cents = TuneNote(445, 440) // About 20 cents sharp
+	assert.Equal(t, 69, note)
+	assert.InDelta(t, 19.56, cents, 0.01)
+	note, cents = TuneNote(432, 432)
+	assert.Equal(t, 69, note)
+	assert.InDelta(t, 0, cents, 0.01)
+	note, _ = TuneNote(0, 440)
+	assert.Equal(t, -1, note)
+
+	m := NewModel(0, "", false)
+	assert.Equal(t, TunerDefaultReference, m.TunerReference)
+	now := time.Now()
+	_, _, _, ok := m.TunerPitch(now)
+	assert.False(t, ok)
+
+	// The tuner shows steady readings even while pitch tracking is off, and holds them briefly
+	m.HandleInputPitch(261, 1, 0.5)
+	freq, note, cents, ok := m.TunerPitch(time.Now())
+	assert.True(t, ok)
+	assert.Equal(t, 261.0, freq)
+	assert.Equal(t, 60, note)
+	assert.InDelta(t, -4.14, cents, 0.01)
+	m.HandleInputPitch(440, 0.2, 0.5) // Unpitched
+	_, note, _, _ = m.TunerPitch(time.Now())
+	assert.Equal(t, 60, note)
+	_, _, _, ok = m.TunerPitch(time.Now().Add(2 * TunerHold))
+	assert.False(t, ok)
+
+	// The reference stays within a semitone of 440
+	m.SetTunerReference(500)
+	assert.Equal(t, TunerMaxReference, m.TunerReference)
+	m.SetTunerReference(TunerMinReference - 10)
+	assert.Equal(t, TunerMinReference, m.TunerReference)
+}
+
+func TestRecordQuantize(t *testing.T) {
+	m := NewModel(0, "", false)
+	m.PPQ = 4 // A 1/16 grid is one tick, 1/8 two
+	for row := 0; row < 8; row++ {
+		m.SetPhraseCell(0, 1, row, types.ColDeltaTime, 1)
+	}
+	m.SetPhraseCell(0, 1, 2, types.ColDeltaTime, 3) // Row 2 spans ticks 2-4, row 3 starts at 5
+
+	// Off: the nearest row
+	assert.Equal(t, 2, m.quantizeRecordedRow(0, 1, 2, 1))
+	assert.Equal(t, 3, m.quantizeRecordedRow(0, 1, 2, 2))
+
+	assert.Equal(t, 3, m.quantizeRecordedRow(0, 1, 3, 0))
+
+	// 1/8 (grid lines every two ticks) at full strength: tick 3 snaps to the line at tick 4, in
+	// row 3, and tick 5 to the line at tick 6, in row 4
+	m.CycleRecordQuantize(0, 1)
+	m.CycleRecordQuantize(0, 1)
+	assert.Equal(t, "1/8", types.GetRecordQuantizeName(m.RecordQuantize[0]))
+	m.AdjustRecordQuantizeStrength(0, 500)
+	assert.Equal(t, 100, m.RecordQuantizeStrength[0])
+	assert.Equal(t, 3, m.quantizeRecordedRow(0, 1, 2, 1))
+	assert.Equal(t, 4, m.quantizeRecordedRow(0, 1, 3, 0))
+
+	// At half strength only notes within half a tick of a line snap, so these keep their feel
+	m.AdjustRecordQuantizeStrength(0, -50)
+	assert.Equal(t, 2, m.quantizeRecordedRow(0, 1, 2, 1))
+	assert.Equal(t, 3, m.quantizeRecordedRow(0, 1, 3, 0))
+
+	// The grid stops at the ends of the list
+	m.CycleRecordQuantize(0, 1)
+	assert.Equal(t, "1/8", types.GetRecordQuantizeName(m.RecordQuantize[0]))
+	m.CycleRecordQuantize(0, -5)
+	assert.Equal(t, "off", types.GetRecordQuantizeName(m.RecordQuantize[0]))
+
+	// Other tracks keep their own setting
+	assert.Equal(t, 0, m.RecordQuantize[1])
+	assert.Equal(t, DefaultRecordQuantizeStrength, m.RecordQuantizeStrength[1])
+}
+
+func TestHumanize(t *testing.T) {
+	m := NewModel(0, "", false)
+
+	// Off: velocities and timing are as written
+	assert.Equal(t, 100, m.HumanizedVelocity(0, 100))
+	played := false
+	m.PlayHumanized(0, 0.1, func() { played = true })
+	assert.True(t, played, "Rows play at once without humanize")
+
+	m.AdjustHumanizeVelocity(0, 10)
+	m.AdjustHumanizeTiming(0, 100)
+	assert.Equal(t, MaxHumanizeTiming, m.HumanizeTiming[0])
+	for i := 0; i < 100; i++ {
+		v := m.HumanizedVelocity(0, 120)
+		assert.True(t, v >= 110 && v <= 127, "velocity %d out of range", v)
+		assert.GreaterOrEqual(t, m.HumanizedVelocity(0, 3), 1, "Velocities stay audible")
+	}
+	assert.Equal(t, 0, m.HumanizedVelocity(0, 0), "Rests stay silent")
+	assert.Equal(t, 100, m.HumanizedVelocity(1, 100), "Other tracks keep their velocities")
+
+	// Tracks without humanize play the lookahead late, so humanized ones can land early
+	m.BPM = 6000
+	done := make(chan struct{})
+	m.PlayHumanized(1, 0.1, func() { close(done) })
+	select {
+	case <-done:
+		t.Fatal("Rows play after the lookahead while a track humanizes its timing")
+	default:
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Delayed row never played")
+	}
+}
+
+// delayClock records the delays rows are played after, without playing them
+type delayClock struct {
+	delays []time.Duration
+}
+
+func (c *delayClock) Now() time.Time { return time.Time{} }
+
+func (c *delayClock) AfterFunc(d time.Duration, f func()) { c.delays = append(c.delays, d) }
+
+func TestHumanizeSeed(t *testing.T) {
+	// The same project seed humanizes a render the same way
+	humanize := func(seed int) ([]int, []time.Duration) {
+		m := NewModel(0, "", false)
+		clock := &delayClock{}
+		m.clock = clock
+		m.BPM = 120
+		m.AdjustHumanizeVelocity(0, 20)
+		m.AdjustHumanizeTiming(0, MaxHumanizeTiming)
+		m.SetRandomSeed(seed)
+		var velocities []int
+		for i := 0; i < 32; i++ {
+			velocities = append(velocities, m.HumanizedVelocity(0, 100))
+			m.PlayHumanized(0, 0.5, func() {})
+		}
+		return velocities, clock.delays
+	}
+	velocities, delays := humanize(42)
+	againVelocities, againDelays := humanize(42)
+	assert.Equal(t, velocities, againVelocities)
+	assert.Equal(t, delays, againDelays)
+	assert.NotEqual(t, slices.Min(delays), slices.Max(delays), "Rows are moved by different amounts")
+
+	otherVelocities, otherDelays := humanize(43)
+	assert.False(t, slices.Equal(velocities, otherVelocities) && slices.Equal(delays, otherDelays), "Another seed humanizes differently")
+}
+
+func TestMixerCompare(t *testing.T) {
+	m := NewModel(0, "", false)
+	assert.Equal(t, "A", m.MixerSlotName())
+	assert.False(t, m.MixerSidesDiffer(), "Both sides start with the same mix")
+
+	// Mix A, then switch to B: B still has the mix from before
+	m.TrackSetLevels[2] = -12
+	m.HumanizeVelocity[2] = 8
+	assert.True(t, m.MixerSidesDiffer())
+	m.ToggleMixerCompare()
+	assert.Equal(t, "B", m.MixerSlotName())
+	assert.Equal(t, float32(-6), m.TrackSetLevels[2])
+	assert.Equal(t, 0, m.HumanizeVelocity[2])
+
+	// Mix B, switch back to A and forth again: each side keeps its own mix
+	m.TrackSetLevels[5] = 3
+	m.ToggleMixerCompare()
+	assert.Equal(t, float32(-12), m.TrackSetLevels[2])
+	assert.Equal(t, float32(-6), m.TrackSetLevels[5])
+	m.ToggleMixerCompare()
+	assert.Equal(t, float32(3), m.TrackSetLevels[5])
+
+	// Copying B to A makes them the same
+	m.CopyMixerToOther()
+	assert.False(t, m.MixerSidesDiffer())
+	m.ToggleMixerCompare()
+	assert.Equal(t, "A", m.MixerSlotName())
+	assert.Equal(t, float32(3), m.TrackSetLevels[5])
+	assert.Equal(t, float32(-6), m.TrackSetLevels[2])
+}
+
+func TestMidiSync(t *testing.T) {
+	m := NewModel(0, "", false)
+	m.PPQ = 2
+	assert.Equal(t, MidiSyncBellNote, m.MidiSyncBeatNote(0))
+	assert.Equal(t, -1, m.MidiSyncBeatNote(1))
+	assert.Equal(t, MidiSyncClickNote, m.MidiSyncBeatNote(2))
+	assert.Equal(t, MidiSyncBellNote, m.MidiSyncBeatNote(8))
+
+	// Beats follow the playback clock when a track runs at a finer resolution
+	m.TrackResolutions[3] = 2
+	assert.Equal(t, -1, m.MidiSyncBeatNote(2))
+	assert.Equal(t, MidiSyncClickNote, m.MidiSyncBeatNote(4))
+
+	// Nothing is sent without a device, and each mode sends its own notes
+	assert.False(t, m.midiSyncSends(false))
+	m.MidiSync.Mode = types.MidiSyncClick
+	assert.False(t, m.midiSyncSends(false))
+	m.MidiSync.Device = "Drum Machine"
+	assert.True(t, m.midiSyncSends(false))
+	assert.False(t, m.midiSyncSends(true))
+	m.MidiSync.Mode = types.MidiSyncBoth
+	assert.True(t, m.midiSyncSends(true))
+}
+
+func TestSongPosition(t *testing.T) {
+	m := NewModel(0, "", false)
+	m.BPM = 120
+	m.PPQ = 2
+	m.TrackTypes[0], m.TrackTypes[1] = false, false
+
+	// Track 0 plays chain 1 twice: phrase 1 (2s) then phrase 2 (2s), 8s in all
+	m.SongData[0][0], m.SongData[0][1] = 1, 1
+	m.InstrumentChainsData[1][0], m.InstrumentChainsData[1][1] = 1, 2
+	m.InstrumentPhrasesData[1][0][types.ColDeltaTime] = 4
+	m.InstrumentPhrasesData[1][1][types.ColDeltaTime] = 4
+	m.InstrumentPhrasesData[2][0][types.ColDeltaTime] = 8
+	// Track 1 is shorter and does not set the position
+	m.SongData[1][0] = 3
+	m.InstrumentChainsData[3][0] = 3
+	m.InstrumentPhrasesData[3][0][types.ColDeltaTime] = 4
+
+	_, _, ok := m.SongPosition()
+	assert.False(t, ok, "No position while stopped")
+
+	// Second pass through the chain, 5 of phrase 2's 8 ticks played
+	m.IsPlaying = true
+	m.PlaybackMode = types.SongView
+	m.SongPlaybackActive[0], m.SongPlaybackActive[1] = true, true
+	m.SongPlaybackRow[0], m.SongPlaybackChain[0], m.SongPlaybackChainRow[0] = 1, 1, 1
+	m.SongPlaybackPhrase[0], m.SongPlaybackRowInPhrase[0], m.SongPlaybackTicksLeft[0] = 2, 0, 3
+	position, length, ok := m.SongPosition()
+	assert.True(t, ok)
+	assert.Equal(t, 7.25, position)
+	assert.Equal(t, 8.0, length)
+
+	bar, beat, tick := m.BarsBeatsTicks(position)
+	assert.Equal(t, []int{4, 3, 2}, []int{bar, beat, tick})
+	bar, beat, tick = m.BarsBeatsTicks(0)
+	assert.Equal(t, []int{1, 1, 1}, []int{bar, beat, tick})
+}
+
+func TestSongCues(t *testing.T) {
+	m := NewModel(0, "", false)
+	m.BPM = 120
+	m.PPQ = 2
+	m.TrackTypes[0] = false
+
+	m.SetCue(0, "  INTRO  ")
+	assert.Equal(t, "INTRO", m.SongCues[0], "Cue names are trimmed")
+	m.SetCue(1, "BREAKDOWN!")
+	assert.Equal(t, "BREAKDOW", m.SongCues[1], "Cue names are cut to MaxCueNameLength")
+	m.SetCue(types.SongRows, "OUT")
+	m.SetCue(1, "")
+	assert.Equal(t, "", m.SongCues[1], "An empty name removes the cue")
+	assert.True(t, m.HasCues())
+
+	// Track 0 plays chain 1 on rows 0 and 1, 4s each, with 2s bars
+	m.SongData[0][0], m.SongData[0][1] = 1, 1
+	m.InstrumentChainsData[1][0], m.InstrumentChainsData[1][1] = 1, 2
+	m.InstrumentPhrasesData[1][0][types.ColDeltaTime] = 4
+	m.InstrumentPhrasesData[1][1][types.ColDeltaTime] = 4
+	m.InstrumentPhrasesData[2][0][types.ColDeltaTime] = 8
+
+	_, _, ok := m.NextCue()
+	assert.False(t, ok, "No countdown while stopped")
+
+	// 7.25s in: the cue on row 0 comes around as the song loops
+	m.IsPlaying = true
+	m.PlaybackMode = types.SongView
+	m.SongPlaybackActive[0] = true
+	m.SongPlaybackRow[0], m.SongPlaybackChain[0], m.SongPlaybackChainRow[0] = 1, 1, 1
+	m.SongPlaybackPhrase[0], m.SongPlaybackRowInPhrase[0], m.SongPlaybackTicksLeft[0] = 2, 0, 3
+	name, bars, ok := m.NextCue()
+	assert.True(t, ok)
+	assert.Equal(t, "INTRO", name)
+	assert.Equal(t, 1, bars)
+
+	// From the start, the cue on row 1 is 2 bars away
+	m.SetCue(1, "DROP")
+	m.SongPlaybackRow[0], m.SongPlaybackChainRow[0] = 0, 0
+	m.SongPlaybackPhrase[0], m.SongPlaybackTicksLeft[0] = 1, 4
+	name, bars, ok = m.NextCue()
+	assert.True(t, ok)
+	assert.Equal(t, "DROP", name)
+	assert.Equal(t, 2, bars)
+}
+
+func TestJumpCrossfadeSeconds(t *testing.T) {
+	m := NewModel(0, "", false)
+	m.BPM = 120
+	m.PPQ = 2
+	assert.Equal(t, 0.0, m.JumpCrossfadeSeconds(0), "Jumps hard-switch by default")
+
+	m.JumpCrossfade = 4
+	assert.Equal(t, 1.0, m.JumpCrossfadeSeconds(0))
+	m.TrackResolutions[1] = 4
+	assert.Equal(t, 0.25, m.JumpCrossfadeSeconds(1), "Ticks follow the track's resolution")
+}
+
+func TestChainTranspose(t *testing.T) {
+	m := NewModel(0, "", false)
+	m.TrackTypes[0] = false
+
+	assert.Equal(t, 0, m.GetChainTranspose(0, 1, 2))
+	assert.False(t, m.HasChainTransposes())
+	assert.True(t, m.SetChainTranspose(0, 1, 2, 7))
+	assert.Equal(t, 7, m.GetChainTranspose(0, 1, 2))
+	assert.Equal(t, 0, m.GetChainTranspose(1, 1, 2), "Sampler tracks have their own chains")
+	m.SetChainTranspose(0, 1, 3, -100)
+	assert.Equal(t, -MaxChainTranspose, m.GetChainTranspose(0, 1, 3))
+	assert.False(t, m.SetChainTranspose(0, 1, types.ChainRows, 1))
+	assert.True(t, m.HasChainTransposes())
+
+	// Song playback transposes by the slot each track is playing
+	assert.Equal(t, 0, m.PlayingChainTranspose(0), "No transpose while stopped")
+	m.IsPlaying = true
+	m.PlaybackMode = types.SongView
+	m.SongPlaybackActive[0] = true
+	m.SongPlaybackChain[0], m.SongPlaybackChainRow[0] = 1, 2
+	assert.Equal(t, 7, m.PlayingChainTranspose(0))
+
+	// Chain playback plays the current track
+	m.PlaybackMode = types.ChainView
+	m.CurrentTrack = 0
+	m.PlaybackChain, m.PlaybackChainRow = 1, 3
+	assert.Equal(t, -MaxChainTranspose, m.PlayingChainTranspose(0))
+	assert.Equal(t, 0, m.PlayingChainTranspose(1))
+}
+
+func TestChainFX(t *testing.T) {
+	m := NewModel(0, "", false)
+	m.TrackTypes[0] = false
+
+	assert.Equal(t, types.NoChainFX, m.GetChainFX(0, 1, 2))
+	assert.False(t, m.HasChainFX())
+	assert.True(t, m.SetChainFX(0, 1, 2, types.ChainFX{Reverb: 300, LowPass: 0x40}))
+	assert.Equal(t, types.ChainFX{Reverb: 254, LowPass: 0x40}, m.GetChainFX(0, 1, 2), "Values are clamped to FE")
+	assert.Equal(t, types.NoChainFX, m.GetChainFX(1, 1, 2), "Sampler tracks have their own chains")
+	assert.True(t, m.HasChainFX())
+
+	m.IsPlaying = true
+	m.PlaybackMode = types.SongView
+	m.SongPlaybackActive[0] = true
+	m.SongPlaybackChain[0], m.SongPlaybackChainRow[0] = 1, 2
+	assert.Equal(t, m.GetChainFX(0, 1, 2), m.PlayingChainFX(0))
+	assert.Equal(t, types.NoChainFX, m.PlayingChainFX(1))
+
+	// The reverb send is raised to the override and the cutoff lowered to it
+	fx := types.ChainFX{Reverb: 0x80, LowPass: 0x40}
+	reverb, lowPass := ApplyChainFX(fx, -1, -1)
+	assert.Equal(t, []int{0x80, 0x40}, []int{reverb, lowPass})
+	reverb, lowPass = ApplyChainFX(fx, 0xC0, 0x20)
+	assert.Equal(t, []int{0xC0, 0x20}, []int{reverb, lowPass})
+	reverb, lowPass = ApplyChainFX(types.NoChainFX, 0x10, -1)
+	assert.Equal(t, []int{0x10, -1}, []int{reverb, lowPass})
+}
+
+func TestTempoKeyPreview(t *testing.T) {
+	m := NewModel(0, "", false)
+	m.BPM = 120
+	m.TrackTypes[0] = false
+	m.InstrumentChainsData[1][0] = 5
+	m.SetChainTranspose(0, 1, 0, 3)
+
+	m.PreviewTempo(10)
+	assert.Equal(t, float32(120), m.BPM, "Nothing changes outside a preview")
+
+	// The proposed key is heard on top of the slot's transpose
+	m.StartPreview()
+	m.PreviewTempo(10)
+	m.PreviewKey(-2)
+	m.IsPlaying = true
+	m.PlaybackMode = types.SongView
+	m.SongPlaybackActive[0] = true
+	m.SongPlaybackChain[0], m.SongPlaybackChainRow[0] = 1, 0
+	assert.Equal(t, float32(130), m.BPM)
+	assert.Equal(t, 1, m.PlayingChainTranspose(0))
+
+	m.RevertPreview()
+	assert.False(t, m.Preview.Active)
+	assert.Equal(t, float32(120), m.BPM)
+	assert.Equal(t, 3, m.PlayingChainTranspose(0))
+
+	// Keeping the key change transposes every slot holding a phrase
+	m.StartPreview()
+	m.PreviewKey(20)
+	assert.Equal(t, MaxPreviewTranspose, m.Preview.Transpose)
+	m.AcceptPreview()
+	assert.Equal(t, 15, m.GetChainTranspose(0, 1, 0))
+	assert.Equal(t, 0, m.GetChainTranspose(0, 1, 1), "Empty slots are left alone")
+	assert.Equal(t, 15, m.PlayingChainTranspose(0))
+}
+
+func TestPLocks(t *testing.T) {
+	m := NewModel(0, "", false)
+	assert.Nil(t, m.RowPLocks(1, 3))
+	assert.Nil(t, m.PLockList())
+
+	m.SetPLock(1, 3, "resonance", 2.5)
+	m.SetPLock(1, 3, "monophonic", 1)
+	m.SetPLock(0, 5, "resonance", 0.5)
+	m.SetPLock(1, 999, "resonance", 1) // Out of range rows are ignored
+	value, ok := m.PLock(1, 3, "resonance")
+	assert.True(t, ok)
+	assert.Equal(t, float32(2.5), value)
+	assert.Len(t, m.RowPLocks(1, 3), 2)
+
+	list := m.PLockList()
+	assert.Equal(t, []types.PLock{
+		{Phrase: 0, Row: 5, Param: "resonance", Value: 0.5},
+		{Phrase: 1, Row: 3, Param: "monophonic", Value: 1},
+		{Phrase: 1, Row: 3, Param: "resonance", Value: 2.5},
+	}, list)
+
+	m.ClearPLock(0, 5, "resonance")
+	assert.Nil(t, m.RowPLocks(0, 5))
+	_, ok = m.PLock(0, 5, "resonance")
+	assert.False(t, ok)
+
+	m.LoadPLocks(list)
+	assert.Len(t, m.PLockList(), 3)
+}
+
+func TestInputMeter(t *testing.T) {
+	m := NewModel(0, "", false)
+	now := time.Now()
+
+	// The meters sit at the floor until SuperCollider reports
+	levels := m.InputLevels(now)
+	assert.Equal(t, float32(InputMeterFloorDB), levels.Peak[0])
+	assert.False(t, levels.Clipping)
+
+	// Peaks are held for a while, then follow the input again
+	m.HandleInputLevel(-6, -12, now)
+	m.HandleInputLevel(-20, -90, now.Add(time.Second))
+	levels = m.InputLevels(now.Add(time.Second))
+	assert.Equal(t, [2]float32{-20, InputMeterFloorDB}, levels.Peak)
+	assert.Equal(t, [2]float32{-6, -12}, levels.Hold)
+	levels = m.InputLevels(now.Add(3 * time.Second))
+	assert.Equal(t, levels.Peak, levels.Hold)
+
+	// Clipping lights up, is counted and fades, and the count can be reset
+	m.HandleInputLevel(0, -6, now)
+	assert.True(t, m.InputLevels(now).Clipping)
+	assert.Equal(t, 1, m.InputLevels(now).Clips)
+	assert.False(t, m.InputLevels(now.Add(3*time.Second)).Clipping)
+	m.ResetInputClips()
+	assert.Equal(t, 0, m.InputLevels(now).Clips)
+}
+
+func TestInputArming(t *testing.T) {
+	m := NewModel(0, "", false)
+	m.ViewMode = types.PhraseView
+	m.CurrentTrack = 2
+	assert.True(t, m.InputArmed)
+	assert.Equal(t, uint16(1<<2|1<<8), m.GetRecordingTrackMask(true, false))
+
+	m.ToggleInputArmed()
+	assert.Equal(t, uint16(1<<2), m.GetRecordingTrackMask(true, false))
+}
+
+func TestFrameRate(t *testing.T) {
+	m := NewModel(0, "", false)
+	m.ViewMode = types.PhraseView
+	assert.Equal(t, 30, m.FrameRate())
+	m.UIFrameRate = 10
+	assert.Equal(t, 10, m.FrameRate())
+
+	// Without the header waveform only views with live levels keep the chosen rate
+	m.WaveformDetail = types.WaveformDetailOff
+	assert.Equal(t, IdleFrameRate, m.FrameRate())
+	m.ViewMode = types.MixerView
+	assert.Equal(t, 10, m.FrameRate())
+	m.UIFrameRate = 2
+	assert.Equal(t, 2, m.FrameRate())
+}
+
+func TestEcoModeFrameRate(t *testing.T) {
+	m := NewModel(0, "", false)
+	m.ViewMode = types.MixerView
+	m.ToggleEcoMode()
+	assert.Equal(t, EcoFrameRate, m.FrameRate())
+	m.UIFrameRate = 5
+	assert.Equal(t, 5, m.FrameRate())
+	m.ToggleEcoMode()
+	m.UIFrameRate = 30
+	assert.Equal(t, 30, m.FrameRate())
+}
+
+func TestDiagnostics(t *testing.T) {
+	m := NewModel(0, "", false)
+	now := time.Now()
+	m.SampleDiagnostics(now)
+	assert.Empty(t, m.Diagnostics(), "The first call only starts the interval")
+
+	for i := 0; i < 10; i++ {
+		m.CountOSCReceived()
+	}
+	m.HandleCPUUsage(12.5)
+	m.HandleCPUPeak(40)
+	m.SampleDiagnostics(now.Add(time.Second / 2))
+	assert.Empty(t, m.Diagnostics())
+	m.SampleDiagnostics(now.Add(2 * time.Second))
+	samples := m.Diagnostics()
+	assert.Len(t, samples, 1)
+	assert.InDelta(t, 5, samples[0].OSCIn, 0.001)
+	assert.Equal(t, float32(12.5), samples[0].SCAvgCPU)
+	assert.Equal(t, float32(40), samples[0].SCPeakCPU)
+	assert.Positive(t, samples[0].Goroutines)
+	assert.Positive(t, samples[0].HeapBytes)
+
+	// Only the recent history is kept
+	for i := 0; i < DiagnosticsHistory+5; i++ {
+		m.SampleDiagnostics(now.Add(time.Duration(i+3) * time.Second))
+	}
+	assert.Len(t, m.Diagnostics(), DiagnosticsHistory)
+}
+
+func TestQueuedCountdown(t *testing.T) {
+	m := NewModel(0, "", false)
+	m.TrackTypes[0], m.TrackTypes[1] = false, false
+	m.IsPlaying = true
+	m.PlaybackMode = types.SongView
+
+	// Track 0 plays chain 1: phrase 1 (rows of DT 4, 0 and 2) then phrase 2 (one row of DT 8)
+	m.InstrumentChainsData[1][0], m.InstrumentChainsData[1][1] = 1, 2
+	m.InstrumentPhrasesData[1][0][types.ColDeltaTime] = 4
+	m.InstrumentPhrasesData[1][1][types.ColDeltaTime] = 0
+	m.InstrumentPhrasesData[1][2][types.ColDeltaTime] = 2
+	m.InstrumentPhrasesData[2][0][types.ColDeltaTime] = 8
+	m.SongPlaybackActive[0] = true
+	m.SongPlaybackChain[0], m.SongPlaybackPhrase[0] = 1, 1
+	m.SongPlaybackTicksLeft[0] = m.TrackTicks(0, 3)
+
+	_, ok := m.QueuedCountdown(0)
+	assert.False(t, ok, "Nothing queued")
+
+	// A queued stop runs once the chain is finished: the rest of row 0, row 2 and phrase 2
+	m.SongPlaybackQueued[0] = -1
+	countdown, ok := m.QueuedCountdown(0)
+	assert.True(t, ok)
+	assert.Equal(t, QueueCountdown{Rows: 3, Ticks: m.TrackTicks(0, 3+2+8)}, countdown)
+
+	// A queued start waits for the first playing track to finish its chain
+	m.SongPlaybackQueued[1] = 1
+	countdown, ok = m.QueuedCountdown(1)
+	assert.True(t, ok)
+	assert.Equal(t, 3, countdown.Rows)
+	m.SongPlaybackActive[0] = false
+	_, ok = m.QueuedCountdown(1)
+	assert.False(t, ok, "No track is playing to reach a boundary")
+}
+
+func TestTrackActivity(t *testing.T) {
+	m := NewModel(0, "", false)
+	now := time.Now()
+	assert.False(t, m.TrackTriggered(2, now))
+	m.MarkTrackTriggered(2, now)
+	m.MarkTrackTriggered(types.NumTracks, now) // Ignored
+	assert.True(t, m.TrackTriggered(2, now.Add(ActivityFlash/2)))
+	assert.False(t, m.TrackTriggered(2, now.Add(ActivityFlash)))
+
+	assert.False(t, m.TrackSilenced(2))
+	m.TrackSetLevels[2] = -96
+	assert.True(t, m.TrackSilenced(2))
+}
+
+func TestLatencyTest(t *testing.T) {
+	m := NewModel(0, "", false)
+	assert.Zero(t, m.LatencyReport().Sent)
+
+	now := time.Now()
+	assert.True(t, m.StartLatencyTest())
+	assert.False(t, m.StartLatencyTest(), "A measurement is in progress")
+	for probe := 0; probe < LatencyProbes; probe++ {
+		more := m.SendLatencyProbe(now.Add(time.Duration(probe) * LatencyProbeInterval))
+		assert.Equal(t, probe < LatencyProbes-1, more)
+	}
+	assert.False(t, m.SendLatencyProbe(now))
+
+	// The language answers in 1ms, the server in 2ms or 4ms; one server reply is lost
+	for probe := 0; probe < LatencyProbes; probe++ {
+		sent := now.Add(time.Duration(probe) * LatencyProbeInterval)
+		m.HandleLatencyReply(1, probe, LatencyPathLanguage, sent.Add(time.Millisecond))
+		if probe > 0 {
+			m.HandleLatencyReply(1, probe, LatencyPathServer, sent.Add(time.Duration(2+2*(probe%2))*time.Millisecond))
+		}
+	}
+	m.HandleLatencyReply(0, 0, LatencyPathServer, now) // From an earlier measurement
+	report := m.LatencyReport()
+	assert.True(t, report.Running)
+	assert.Equal(t, LatencyStats{Received: LatencyProbes, Min: time.Millisecond, Mean: time.Millisecond, Max: time.Millisecond},
+		report.Paths[LatencyPathLanguage])
+	server := report.Paths[LatencyPathServer]
+	assert.Equal(t, LatencyProbes-1, server.Received)
+	assert.Equal(t, 2*time.Millisecond, server.Min)
+	assert.Equal(t, 4*time.Millisecond, server.Max)
+	assert.InDelta(t, float64(time.Millisecond), float64(server.Jitter), float64(10*time.Microsecond))
+
+	m.FinishLatencyTest()
+	m.HandleLatencyReply(1, 0, LatencyPathServer, now) // Too late
+	report = m.LatencyReport()
+	assert.False(t, report.Running)
+	assert.True(t, report.Done)
+	assert.Equal(t, LatencyProbes-1, report.Paths[LatencyPathServer].Received)
+}
+
+func TestTimedStart(t *testing.T) {
+	now := time.Date(2026, 5, 1, 21, 0, 0, 0, time.Local)
+	at, err := ParseStartTime("90s", now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(90*time.Second), at)
+	at, err = ParseStartTime("21:30", now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(30*time.Minute), at)
+	at, err = ParseStartTime("20:59:30", now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(24*time.Hour-30*time.Second), at, "A time already past is tomorrow")
+	_, err = ParseStartTime("soon", now)
+	assert.Error(t, err)
+
+	m := NewModel(0, "", false)
+	_, _, ok := m.TimedStartCount(now)
+	assert.False(t, ok)
+
+	// At 120 BPM a 4 beat count-in takes the last 2 seconds of a 10 second countdown
+	m.BPM = 120
+	m.PreRoll = 4
+	m.ArmTimedStart(now.Add(10 * time.Second))
+	left, beat, ok := m.TimedStartCount(now)
+	assert.True(t, ok)
+	assert.Equal(t, 8*time.Second, left)
+	assert.Zero(t, beat)
+	_, beat, _ = m.TimedStartCount(now.Add(8 * time.Second))
+	assert.Equal(t, 4, beat)
+	_, beat, _ = m.TimedStartCount(now.Add(9*time.Second + 600*time.Millisecond))
+	assert.Equal(t, 1, beat)
+
+	m.DisarmTimedStart()
+	assert.False(t, m.TimedStartArmed())
+}
+
+func TestPickSongRow(t *testing.T) {
+	m := NewModel(0, "", false)
+	all := func(int) bool { return true }
+	assert.False(t, m.HasSongWeights())
+
+	// Only playable rows with weight are chosen, in proportion to their weight
+	for row := range m.SongWeights[0] {
+		m.SetSongWeight(0, row, 0)
+	}
+	assert.Equal(t, -1, m.PickSongRow(0, all))
+	m.SetSongWeight(0, 2, 3)
+	m.SetSongWeight(0, 7, 1)
+	m.SetSongWeight(0, 9, MaxSongWeight+5)
+	assert.Equal(t, MaxSongWeight, m.SongWeights[0][9])
+	assert.True(t, m.HasSongWeights())
+	counts := map[int]int{}
+	for i := 0; i < 1000; i++ {
+		counts[m.PickSongRow(0, func(row int) bool { return row != 9 })]++
+	}
+	assert.Len(t, counts, 2)
+	assert.InDelta(t, 750, counts[2], 60)
+
+	// The same seed makes the same choices
+	m.ResetRandom()
+	first := []int{m.PickSongRow(0, all), m.PickSongRow(0, all), m.PickSongRow(0, all)}
+	m.ResetRandom()
+	assert.Equal(t, first, []int{m.PickSongRow(0, all), m.PickSongRow(0, all), m.PickSongRow(0, all)})
+}
+
+func TestNotesEditing(t *testing.T) {
+	m := NewModel(0, "", false)
+	assert.Equal(t, "", m.NotesText())
+
+	m.TypeNotes("intro 8 bars")
+	m.NotesNewline()
+	m.TypeNotes("drop\tx2\nend")
+	assert.Equal(t, []string{"intro 8 bars", "drop    x2", "end"}, m.Notes)
+	assert.Equal(t, 2, m.NotesRow)
+	assert.Equal(t, 3, m.NotesCol)
+	assert.True(t, m.IsDirty())
+
+	// Backspace at the start of a line joins it to the line above
+	m.MoveNotesCursor(0, -3)
+	m.NotesBackspace()
+	assert.Equal(t, []string{"intro 8 bars", "drop    x2end"}, m.Notes)
+	assert.Equal(t, 10, m.NotesCol)
+
+	// Moving up keeps the cursor within the shorter line, moving right wraps to the next line
+	m.MoveNotesCursor(-1, 0)
+	m.MoveNotesToLineEnd()
+	assert.Equal(t, 12, m.NotesCol)
+	m.MoveNotesCursor(0, 1)
+	assert.Equal(t, 1, m.NotesRow)
+	assert.Equal(t, 0, m.NotesCol)
+	m.NotesDelete()
+	assert.Equal(t, "intro 8 bars\nrop    x2end", m.NotesText())
+
+	m.SetNotesText("a\r\nb\n\n")
+	assert.Equal(t, "a\nb", m.NotesText())
+	assert.Zero(t, m.NotesRow)
+}
+
+func TestChainAndPhraseNames(t *testing.T) {
+	m := NewModel(0, "", false)
+	m.TrackTypes[0] = false // Instrument pool
+	m.TrackTypes[4] = true  // Sampler pool
+
+	m.SetPhraseName(0, 3, "  bass line #low ")
+	m.SetChainName(0, 1, "verse a very long chain name")
+	assert.Equal(t, "bass line #low", m.PhraseName(0, 3))
+	assert.Equal(t, "verse a very lon", m.ChainName(0, 1))
+	assert.Equal(t, "", m.PhraseName(4, 3), "Sampler phrases have their own names")
+	assert.True(t, m.IsDirty())
+
+	// Sampler phrases are named after their first sample until they are given a name
+	m.SamplerPhrasesFiles = []string{"/samples/kick_01.wav"}
+	m.SamplerPhrasesData[2][1][types.ColFilename] = 0
+	name, auto := m.DisplayPhraseName(4, 2)
+	assert.Equal(t, "kick_01", name)
+	assert.True(t, auto)
+	m.SetChainCell(4, 5, 0, 2)
+	name, auto = m.DisplayChainName(4, 5)
+	assert.Equal(t, "kick_01", name)
+	assert.True(t, auto)
+
+	matches := m.SearchNames(0, "BASS")
+	assert.Equal(t, []NameMatch{{Kind: DataPhrase, ID: 3, Name: "bass line #low"}}, matches)
+	assert.Len(t, m.SearchNames(0, "#lo"), 1)
+	assert.Empty(t, m.SearchNames(0, "#line"), "Tag searches only match tags")
+	assert.Len(t, m.SearchNames(4, "kick"), 2)
+
+	// Renumbering the pool moves the names with their phrases
+	chainMap, phraseMap := m.CompactionMaps(false)
+	m.RenumberPool(false, chainMap, phraseMap)
+	assert.Equal(t, "bass line #low", m.PhraseName(0, phraseMap[3]))
+
+	m.SetPhraseName(0, phraseMap[3], "")
+	assert.Empty(t, m.InstrumentNames.Phrases)
+}
+
+func TestPhraseMIDIClip(t *testing.T) {
+	m := NewModel(0, "", false)
+	m.TrackTypes[0] = false
+	m.PPQ = 2
+	m.BPM = 120
+
+	_, ok, err := m.PhraseMIDIClip(0, 1)
+	assert.NoError(t, err)
+	assert.False(t, ok, "An empty phrase has nothing to export")
+
+	rows := m.InstrumentPhrasesData[1]
+	rows[0][types.ColNote], rows[0][types.ColDeltaTime], rows[0][types.ColVelocity] = 60, 2, 100
+	rows[0][types.ColChord] = int(types.ChordMajor)
+	rows[1][types.ColDeltaTime] = 0 // Skipped
+	rows[1][types.ColNote] = 50
+	rows[2][types.ColNote], rows[2][types.ColDeltaTime], rows[2][types.ColGate] = 62, 1, 0x40
+
+	data, ok, err := m.PhraseMIDIClip(0, 1)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	file, err := smf.ReadFrom(bytes.NewReader(data))
+	assert.NoError(t, err)
+	type note struct{ tick, key, velocity int }
+	var ons, offs []note
+	end, tick := 0, 0
+	for _, event := range file.Tracks[0] {
+		tick += int(event.Delta)
+		var ch, key, velocity uint8
+		switch {
+		case event.Message.GetNoteStart(&ch, &key, &velocity):
+			ons = append(ons, note{tick, int(key), int(velocity)})
+		case event.Message.GetNoteEnd(&ch, &key):
+			offs = append(offs, note{tick: tick, key: int(key)})
+		case event.Message.Is(smf.MetaEndOfTrackMsg):
+			end = tick
+		}
+	}
+	// 960 ticks per quarter at PPQ 2 is 480 per row: the chord holds its 2 rows, the last note half its row
+	assert.Equal(t, []note{{0, 60, 100}, {0, 64, 100}, {0, 67, 100}, {960, 62, 100}}, ons)
+	assert.Equal(t, []note{{960, 60, 0}, {960, 64, 0}, {960, 67, 0}, {1200, 62, 0}}, offs)
+	assert.Equal(t, 1440, end, "The clip lasts the whole phrase")
+}
+
+func TestOSCEngineTracks(t *testing.T) {
+	m := NewModel(0, "test.json", false)
+	m.Config.OSCEngines = []types.OSCEngine{{Name: "pd", Port: 9000, Address: "/pd/"}, {Name: "tidal", Port: 6010}}
+	m.TrackTypes[0] = false
+
+	// Instrument -> pd -> tidal -> Sampler -> Instrument
+	var codes []string
+	for i := 0; i < 4; i++ {
+		m.CycleTrackType(0)
+		codes = append(codes, m.TrackTypeCode(0))
+	}
+	assert.Equal(t, []string{"PD", "TI", "SA", "IN"}, codes)
+
+	m.CycleTrackType(0)
+	engine, ok := m.TrackEngine(0)
+	assert.True(t, ok)
+	assert.Equal(t, "pd", engine.Name)
+
+	params := NewInstrumentOSCParams(0, 100, 0, 0, 0, 64, 0.5, 0, 0, 0, 0, 0, -1, -1, 0, 0, -1, -1, -1, -1, [9]int{5, -1, -1, -1, -1, -1, -1, -1, -1})
+	params.Notes = []float32{60, 64}
+	var addresses []string
+	for _, msg := range m.EngineMessages(engine, params) {
+		addresses = append(addresses, msg.Address)
+	}
+	assert.Equal(t, []string{"/pd/cc", "/pd/param", "/pd/param", "/pd/note", "/pd/note"}, addresses)
+	note := m.EngineMessages(engine, params)[3]
+	assert.Equal(t, []interface{}{int32(0), float32(60), float32(100), float32(0.25)}, note.Arguments)
+
+	params.Update = 1
+	assert.Len(t, m.EngineMessages(engine, params), 3, "Updates don't retrigger notes")
+
+	off := m.EngineMessages(engine, InstrumentOSCParams{TrackId: 0, NoteOn: 0})
+	assert.Equal(t, "/pd/off", off[0].Address)
+
+	// A project opened where its engine isn't configured plays SuperCollider
+	m.Config.OSCEngines = nil
+	_, ok = m.TrackEngine(0)
+	assert.False(t, ok)
+	assert.Equal(t, "IN", m.TrackTypeCode(0))
+	assert.Equal(t, []string{"pd", "", "", "", "", "", "", ""}, m.SavedTrackEngines())
+}
+
+func TestBeatFlash(t *testing.T) {
+	m := NewModel(0, "test.json", false)
+	now := time.Now()
+	m.FlashBeat(0)
+	lit, _ := m.BeatFlashing(now)
+	assert.False(t, lit, "The flash is off by default")
+
+	m.BeatFlash = types.BeatFlashBeat
+	m.FlashBeat(m.PPQ * m.ClockResolution()) // Second beat
+	lit, bar := m.BeatFlashing(time.Now())
+	assert.True(t, lit)
+	assert.False(t, bar)
+	lit, _ = m.BeatFlashing(time.Now().Add(2 * BeatFlashDuration))
+	assert.False(t, lit, "The flash goes out")
+
+	m.FlashBeat(4 * m.PPQ * m.ClockResolution()) // Next bar
+	_, bar = m.BeatFlashing(time.Now())
+	assert.True(t, bar)
+
+	// Bar mode only flashes the first beat of each bar, including the count-in's
+	m.BeatFlash = types.BeatFlashBar
+	m.beatFlashAt = time.Time{}
+	m.FlashBeat(m.PPQ * m.ClockResolution())
+	lit, _ = m.BeatFlashing(time.Now())
+	assert.False(t, lit)
+	m.PreRoll = 4
+	m.TimedStartClick(4)
+	lit, bar = m.BeatFlashing(time.Now())
+	assert.True(t, lit)
+	assert.True(t, bar)
+
+	// Playing keeps the frame rate up so the flash goes out on time
+	m.UIFrameRate = 30
+	m.WaveformDetail = types.WaveformDetailOff
+	m.IsPlaying = true
+	assert.Equal(t, 30, m.FrameRate())
+	m.BeatFlash = types.BeatFlashOff
+	assert.Equal(t, IdleFrameRate, m.FrameRate())
+}
+
+func TestPhraseGenerator(t *testing.T) {
+	m := NewModel(0, "test.json", false)
+	m.TrackTypes[0] = false
+	m.CurrentTrack, m.CurrentPhrase = 0, 0x03
+	m.OpenGenerator()
+	assert.Equal(t, types.GeneratorView, m.ViewMode)
+
+	// Notes come from the scale within the range
+	m.Generator = types.GeneratorSettings{Rhythm: 1, Density: 100, Low: 60, High: 67, Scale: "major", Root: 2, Length: 32}
+	assert.Equal(t, []int{61, 62, 64, 66, 67}, m.GeneratorNotes())
+
+	// Four on the floor at full density plays every fourth row, the others rest
+	m.InstrumentPhrasesData[0x03][4][types.ColVelocity] = 90
+	playing, err := m.GeneratePhrase(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, playing)
+	for row := 0; row < 32; row++ {
+		note := m.InstrumentPhrasesData[0x03][row][types.ColNote]
+		if row%4 == 0 {
+			assert.Contains(t, m.GeneratorNotes(), note)
+		} else {
+			assert.Equal(t, -1, note)
+		}
+		assert.Equal(t, 1, m.InstrumentPhrasesData[0x03][row][types.ColDeltaTime], "Rests keep their step")
+	}
+	assert.Equal(t, 90, m.InstrumentPhrasesData[0x03][4][types.ColVelocity], "Other columns are kept")
+	assert.Equal(t, -1, m.InstrumentPhrasesData[0x03][32][types.ColDeltaTime], "Rows past the length are left alone")
+
+	// Locked rows survive a re-roll
+	m.InstrumentPhrasesData[0x03][1][types.ColNote] = 50
+	m.GeneratorState.PhraseRow = 1
+	m.ToggleGeneratorLock()
+	m.Generator.Density = 0
+	playing, _ = m.GeneratePhrase(2)
+	assert.Equal(t, 0, playing)
+	assert.Equal(t, 50, m.InstrumentPhrasesData[0x03][1][types.ColNote])
+	assert.Equal(t, -1, m.InstrumentPhrasesData[0x03][0][types.ColNote])
+
+	// The locks belong to the phrase
+	m.CurrentPhrase = 0x04
+	m.OpenGenerator()
+	assert.False(t, m.GeneratorState.Locked[1])
+
+	// Settings stay within their ranges
+	m.ChangeGeneratorSetting(GeneratorRowLow, 20) // Up to the high note
+	assert.Equal(t, 67, m.Generator.Low)
+	m.ChangeGeneratorSetting(GeneratorRowRoot, -3)
+	assert.Equal(t, 11, m.Generator.Root)
+	m.Generator.Low = 60
+	m.Generator.High = 60
+	_, err = m.GeneratePhrase(3)
+	assert.Error(t, err, "C is not in B major")
+
+	// Sampler phrases play slices of the phrase's sample
+	m.TrackTypes[0] = true
+	m.Generator = types.DefaultGeneratorSettings()
+	m.Generator.SliceLow, m.Generator.SliceHigh = 4, 7
+	_, err = m.GeneratePhrase(4)
+	assert.ErrorIs(t, err, errNoSample)
+	m.SamplerPhrasesData[0x04][5][types.ColFilename] = 2
+	m.Generator.Density = 100
+	playing, err = m.GeneratePhrase(4)
+	assert.NoError(t, err)
+	assert.Equal(t, 16, playing)
+	for row := 0; row < 16; row++ {
+		assert.Contains(t, []int{4, 5, 6, 7}, m.SamplerPhrasesData[0x04][row][types.ColNote])
+		assert.Equal(t, 2, m.SamplerPhrasesData[0x04][row][types.ColFilename])
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+	m := NewModel(0, t.TempDir(), false)
+	m.TrackTypes[0] = false
+	m.TrackTypes[1] = true
+
+	// Repeated edits of one cell are one entry from the first value to the last
+	m.SetPhraseCell(0, 0x0A, 4, types.ColNote, 60)
+	m.SetPhraseCell(0, 0x0A, 4, types.ColNote, 61)
+	m.SetPhraseCell(0, 0x0A, 4, types.ColNote, 62)
+	m.SetSongCell(0, 3, 1)
+	m.SetPhraseCell(1, 2, 0, types.ColNote, 0x10)
+	m.SetChainTranspose(0, 1, 2, 5)
+
+	var texts []string
+	for _, entry := range m.AuditEntries() {
+		texts = append(texts, entry.Text)
+	}
+	assert.Equal(t, []string{
+		"phrase 0A row 04: note --- → D-4",
+		"song T1 row 03: chain -- → 01",
+		"sampler phrase 02 row 00: note -- → 10",
+		"chain 01 row 02: transpose +0 → +5",
+	}, texts)
+
+	// Setting a cell back to where it started drops its entry
+	m.SetChainTranspose(0, 1, 2, 0)
+	assert.Len(t, m.AuditEntries(), 3)
+
+	path, err := m.ExportAuditLog()
+	assert.NoError(t, err)
+	exported, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(exported), "  song T1 row 03: chain -- → 01\n")
+
+	m.LoadAuditLog(nil)
+	assert.Empty(t, m.AuditEntries())
+}
+
+func TestTheoryHelper(t *testing.T) {
+	m := NewModel(0, t.TempDir(), false)
+	m.TrackTypes[0] = false
+	m.CurrentTrack, m.CurrentPhrase, m.CurrentRow = 0, 1, 0
+	m.ViewMode = types.PhraseView
+	m.SetPhraseCell(0, 1, 0, types.ColNote, 60)
+	m.SetPhraseCell(0, 1, 0, types.ColChord, int(types.ChordMajor))
+	m.SetPhraseCell(0, 1, 1, types.ColNote, 65)
+	m.SetPhraseCell(0, 1, 2, types.ColNote, 69)
+	m.SetPhraseCell(0, 1, 3, types.ColNote, 61)
+
+	// Without a modulate setting the key is guessed from the phrase
+	help := m.TheoryHelp()
+	assert.True(t, help.Known)
+	assert.True(t, help.Guessed)
+	assert.Equal(t, 0, help.Root)
+	assert.Equal(t, "major", help.Scale)
+	assert.Equal(t, []int{60, 64, 67}, help.Notes)
+	assert.Equal(t, []bool{false, false, false}, help.OutOfKey)
+	assert.Equal(t, 0, help.RowChord, "The row plays the I chord")
+	assert.Equal(t, "vii°", help.Diatonic[6].Numeral)
+
+	m.CurrentRow = 3
+	help = m.TheoryHelp()
+	assert.Equal(t, []bool{true}, help.OutOfKey)
+	assert.Equal(t, -1, help.RowChord)
+
+	// The row's modulate setting gives the scale it is quantized to
+	m.InstrumentModulateSettings[2].Scale = "minor"
+	m.InstrumentModulateSettings[2].ScaleRoot = 9
+	m.SetPhraseCell(0, 1, 3, types.ColModulate, 2)
+	help = m.TheoryHelp()
+	assert.False(t, help.Guessed)
+	assert.Equal(t, 2, help.Modulate)
+	assert.Equal(t, "Am", help.Diatonic[0].Name())
+
+	m.InstrumentModulateSettings[2].Scale = "pentatonic"
+	assert.Nil(t, m.TheoryHelp().Diatonic)
+
+	// An empty phrase has no key to show
+	m.CurrentPhrase = 2
+	assert.False(t, m.TheoryHelp().Known)
+
+	// The helper takes its lines from the phrase
+	rows := m.GetVisibleRows()
+	m.ToggleTheoryHelper()
+	assert.Equal(t, rows-TheoryHelperLines, m.GetVisibleRows())
+	m.TrackTypes[0] = true
+	assert.Equal(t, rows, m.GetVisibleRows(), "Sampler phrases have no helper")
+}
+
+func TestReleasedSamples(t *testing.T) {
+	previous := NewModel(0, "", false)
+	previous.SamplerPhrasesFiles = []string{"/a/kick.wav", "/shared/hat.wav", "", "/a/kick.wav"}
+	m := NewModel(0, "", false)
+	m.SamplerPhrasesFiles = []string{"/shared/hat.wav", "/b/bass.wav"}
+
+	assert.Equal(t, []string{"/a/kick.wav"}, m.releasedSamples(previous), "Shared samples stay loaded")
+	assert.Empty(t, m.releasedSamples(m))
+}
+
+func TestOnsetOptions(t *testing.T) {
+	// Drums slices as files were sliced before there were presets
+	drums := OnsetOptions(16, 0)
+	assert.Equal(t, 16, drums.NumSlices)
+	assert.Equal(t, "hfc", drums.Method)
+	assert.Equal(t, 15.0, drums.OptimizeWindowMs)
+	assert.False(t, drums.UseMinimumSpacing)
+
+	ambient := OnsetOptions(8, 3)
+	assert.Equal(t, "Ambient", OnsetPresets[3].Name)
+	assert.True(t, ambient.UseMinimumSpacing)
+	assert.Greater(t, ambient.MinimumSpacing, OnsetOptions(8, 1).MinimumSpacing, "Swells are further apart than notes")
+
+	assert.Equal(t, drums, OnsetOptions(16, len(OnsetPresets)), "Unknown presets detect like Drums")
+}
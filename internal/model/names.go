@@ -0,0 +1,245 @@
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// MaxNameLength is the longest chain or phrase name, so names fit beside the chain rows
+const MaxNameLength = 16
+
+// NameEntry is a chain or phrase name, or a name search, being typed
+type NameEntry struct {
+	Active bool   // Whether typed keys go to the entry
+	Search bool   // Whether the text searches the names instead of naming the chain or phrase being viewed
+	Buffer string // Characters typed so far
+	Match  int    // Search match chosen with tab and up/down
+}
+
+// NameMatch is a chain or phrase whose name matches a search
+type NameMatch struct {
+	Kind DataKind // DataChain or DataPhrase
+	ID   int
+	Name string // Name, or the automatic name when it has none
+}
+
+// poolNames returns the chain and phrase names of the instrument or sampler pool
+func (m *Model) poolNames(sampler bool) *types.PoolNames {
+	if sampler {
+		return &m.SamplerNames
+	}
+	return &m.InstrumentNames
+}
+
+// cleanName trims a name and cuts it to MaxNameLength
+func cleanName(name string) string {
+	name = strings.TrimSpace(name)
+	if runes := []rune(name); len(runes) > MaxNameLength {
+		name = strings.TrimSpace(string(runes[:MaxNameLength]))
+	}
+	return name
+}
+
+// setName names an ID in one of a pool's name maps; an empty name removes it
+func (m *Model) setName(names *map[int]string, kind string, id int, name string) {
+	name = cleanName(name)
+	if (*names)[id] == name {
+		return
+	}
+	if name == "" {
+		delete(*names, id)
+		logging.UI.Debugf("Removed name of %s %02X", kind, id)
+	} else {
+		if *names == nil {
+			*names = make(map[int]string)
+		}
+		(*names)[id] = name
+		logging.UI.Debugf("Named %s %02X %q", kind, id, name)
+	}
+	m.Publish(Event{Kind: EventSettings})
+}
+
+// ChainName returns the name of a chain in track's pool ("" when it has none)
+func (m *Model) ChainName(track, chain int) string {
+	return m.poolNames(m.isSamplerTrack(track)).Chains[chain]
+}
+
+// PhraseName returns the name of a phrase in track's pool ("" when it has none)
+func (m *Model) PhraseName(track, phrase int) string {
+	return m.poolNames(m.isSamplerTrack(track)).Phrases[phrase]
+}
+
+// SetChainName names a chain in track's pool; an empty name removes it
+func (m *Model) SetChainName(track, chain int, name string) {
+	if chain < 0 || chain >= types.NumChains {
+		return
+	}
+	m.setName(&m.poolNames(m.isSamplerTrack(track)).Chains, "chain", chain, name)
+}
+
+// SetPhraseName names a phrase in track's pool; an empty name removes it
+func (m *Model) SetPhraseName(track, phrase int, name string) {
+	if phrase < 0 || phrase >= types.NumPhrases {
+		return
+	}
+	m.setName(&m.poolNames(m.isSamplerTrack(track)).Phrases, "phrase", phrase, name)
+}
+
+// isSamplerTrack reports whether a track uses the sampler pool (invalid tracks default to it,
+// like GetChainsDataForTrack)
+func (m *Model) isSamplerTrack(track int) bool {
+	return track < 0 || track >= types.NumTracks || m.TrackTypes[track]
+}
+
+// AutoPhraseName names an unnamed sampler phrase after the first sample it plays, without its
+// extension ("" for instrument phrases and phrases without samples)
+func (m *Model) AutoPhraseName(track, phrase int) string {
+	if !m.isSamplerTrack(track) || phrase < 0 || phrase >= types.NumPhrases {
+		return ""
+	}
+	for _, row := range m.SamplerPhrasesData[phrase] {
+		if int(types.ColFilename) >= len(row) {
+			continue
+		}
+		if slot := row[types.ColFilename]; slot >= 0 && slot < len(m.SamplerPhrasesFiles) && m.SamplerPhrasesFiles[slot] != "" {
+			base := filepath.Base(m.SamplerPhrasesFiles[slot])
+			return cleanName(strings.TrimSuffix(base, filepath.Ext(base)))
+		}
+	}
+	return ""
+}
+
+// DisplayPhraseName returns the name of a phrase, or its automatic name; auto reports which
+func (m *Model) DisplayPhraseName(track, phrase int) (name string, auto bool) {
+	if phrase < 0 || phrase >= types.NumPhrases {
+		return "", false
+	}
+	if name := m.PhraseName(track, phrase); name != "" {
+		return name, false
+	}
+	name = m.AutoPhraseName(track, phrase)
+	return name, name != ""
+}
+
+// DisplayChainName returns the name of a chain, or automatically the name of the first of its
+// phrases that has one; auto reports which
+func (m *Model) DisplayChainName(track, chain int) (name string, auto bool) {
+	if chain < 0 || chain >= types.NumChains {
+		return "", false
+	}
+	if name := m.ChainName(track, chain); name != "" {
+		return name, false
+	}
+	for row := 0; row < types.ChainRows; row++ {
+		if name, _ := m.DisplayPhraseName(track, m.GetChainCell(track, chain, row)); name != "" {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// NameTags returns the #tags in a name, without the #
+func NameTags(name string) []string {
+	var tags []string
+	for _, word := range strings.Fields(name) {
+		if len(word) > 1 && strings.HasPrefix(word, "#") {
+			tags = append(tags, word[1:])
+		}
+	}
+	return tags
+}
+
+// SearchNames returns the chains and then the phrases in track's bank whose names (or automatic
+// names) contain query, ignoring case. A #tag query only matches names with a tag starting with it.
+func (m *Model) SearchNames(track int, query string) []NameMatch {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+	matches := func(name string) bool {
+		name = strings.ToLower(name)
+		if tag, ok := strings.CutPrefix(query, "#"); ok && tag != "" {
+			for _, t := range NameTags(name) {
+				if strings.HasPrefix(t, tag) {
+					return true
+				}
+			}
+			return false
+		}
+		return strings.Contains(name, query)
+	}
+
+	var found []NameMatch
+	first, last := m.BankRange(track)
+	for chain := first; chain <= last && chain < types.NumChains; chain++ {
+		if name, _ := m.DisplayChainName(track, chain); name != "" && matches(name) {
+			found = append(found, NameMatch{Kind: DataChain, ID: chain, Name: name})
+		}
+	}
+	for phrase := first; phrase <= last && phrase < types.NumPhrases; phrase++ {
+		if name, _ := m.DisplayPhraseName(track, phrase); name != "" && matches(name) {
+			found = append(found, NameMatch{Kind: DataPhrase, ID: phrase, Name: name})
+		}
+	}
+	return found
+}
+
+// String describes a search match for the footer
+func (match NameMatch) String() string {
+	kind := "Chain"
+	if match.Kind == DataPhrase {
+		kind = "Phrase"
+	}
+	return fmt.Sprintf("%s %02X %s", kind, match.ID, match.Name)
+}
+
+// renameIDs moves names to new IDs after a pool is renumbered (old ID -> new ID)
+func renameIDs(names map[int]string, mapping []int) map[int]string {
+	if len(names) == 0 {
+		return names
+	}
+	renamed := make(map[int]string, len(names))
+	for id, name := range names {
+		if id >= 0 && id < len(mapping) {
+			id = mapping[id]
+		}
+		renamed[id] = name
+	}
+	return renamed
+}
+
+// SavedNames returns the names of the instrument or sampler pool to save, nil when none is named
+func (m *Model) SavedNames(sampler bool) *types.PoolNames {
+	names := m.poolNames(sampler)
+	if len(names.Chains) == 0 && len(names.Phrases) == 0 {
+		return nil
+	}
+	return names
+}
+
+// LoadNames replaces the names of the instrument or sampler pool with saved ones (nil for none),
+// dropping IDs out of range and cutting names that are too long
+func (m *Model) LoadNames(sampler bool, saved *types.PoolNames) {
+	names := types.PoolNames{}
+	if saved != nil {
+		for _, pool := range []struct {
+			from map[int]string
+			to   *map[int]string
+			size int
+		}{{saved.Chains, &names.Chains, types.NumChains}, {saved.Phrases, &names.Phrases, types.NumPhrases}} {
+			for id, name := range pool.from {
+				if name = cleanName(name); id >= 0 && id < pool.size && name != "" {
+					if *pool.to == nil {
+						*pool.to = make(map[int]string)
+					}
+					(*pool.to)[id] = name
+				}
+			}
+		}
+	}
+	*m.poolNames(sampler) = names
+}
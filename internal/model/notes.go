@@ -0,0 +1,127 @@
+package model
+
+import (
+	"strings"
+)
+
+// MaxNotesLines is the most lines the project notes hold
+const MaxNotesLines = 999
+
+// NotesText returns the project notes as one text
+func (m *Model) NotesText() string {
+	return strings.TrimRight(strings.Join(m.Notes, "\n"), "\n")
+}
+
+// SetNotesText replaces the project notes with a text and puts the cursor at its start
+func (m *Model) SetNotesText(text string) {
+	m.Notes = strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if len(m.Notes) > MaxNotesLines {
+		m.Notes = m.Notes[:MaxNotesLines]
+	}
+	m.NotesRow, m.NotesCol = 0, 0
+}
+
+// notesLine returns the line under the notes cursor, making sure the notes have one
+func (m *Model) notesLine() []rune {
+	if len(m.Notes) == 0 {
+		m.Notes = []string{""}
+	}
+	m.NotesRow = max(0, min(len(m.Notes)-1, m.NotesRow))
+	line := []rune(m.Notes[m.NotesRow])
+	m.NotesCol = max(0, min(len(line), m.NotesCol))
+	return line
+}
+
+// TypeNotes inserts typed or pasted text at the notes cursor
+func (m *Model) TypeNotes(text string) {
+	if text == "" {
+		return
+	}
+	for i, part := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if i > 0 && !m.splitNotesLine() {
+			break
+		}
+		line := m.notesLine()
+		runes := []rune(strings.ReplaceAll(part, "\t", "    "))
+		m.Notes[m.NotesRow] = string(line[:m.NotesCol]) + string(runes) + string(line[m.NotesCol:])
+		m.NotesCol += len(runes)
+	}
+	m.Publish(Event{Kind: EventSettings})
+}
+
+// NotesNewline breaks the line at the notes cursor
+func (m *Model) NotesNewline() {
+	if m.splitNotesLine() {
+		m.Publish(Event{Kind: EventSettings})
+	}
+}
+
+// splitNotesLine breaks the line at the notes cursor and moves the cursor to the start of the
+// new line, reporting false when the notes are full
+func (m *Model) splitNotesLine() bool {
+	line := m.notesLine()
+	if len(m.Notes) >= MaxNotesLines {
+		return false
+	}
+	m.Notes = append(m.Notes[:m.NotesRow+1], m.Notes[m.NotesRow:]...)
+	m.Notes[m.NotesRow] = string(line[:m.NotesCol])
+	m.Notes[m.NotesRow+1] = string(line[m.NotesCol:])
+	m.NotesRow, m.NotesCol = m.NotesRow+1, 0
+	return true
+}
+
+// NotesBackspace deletes the character before the notes cursor, joining the line to the one
+// above at its start
+func (m *Model) NotesBackspace() {
+	line := m.notesLine()
+	switch {
+	case m.NotesCol > 0:
+		m.Notes[m.NotesRow] = string(line[:m.NotesCol-1]) + string(line[m.NotesCol:])
+		m.NotesCol--
+	case m.NotesRow > 0:
+		m.NotesCol = len([]rune(m.Notes[m.NotesRow-1]))
+		m.Notes[m.NotesRow-1] += m.Notes[m.NotesRow]
+		m.Notes = append(m.Notes[:m.NotesRow], m.Notes[m.NotesRow+1:]...)
+		m.NotesRow--
+	default:
+		return
+	}
+	m.Publish(Event{Kind: EventSettings})
+}
+
+// NotesDelete deletes the character under the notes cursor, joining the next line at the end
+// of a line
+func (m *Model) NotesDelete() {
+	line := m.notesLine()
+	switch {
+	case m.NotesCol < len(line):
+		m.Notes[m.NotesRow] = string(line[:m.NotesCol]) + string(line[m.NotesCol+1:])
+	case m.NotesRow < len(m.Notes)-1:
+		m.Notes[m.NotesRow] += m.Notes[m.NotesRow+1]
+		m.Notes = append(m.Notes[:m.NotesRow+1], m.Notes[m.NotesRow+2:]...)
+	default:
+		return
+	}
+	m.Publish(Event{Kind: EventSettings})
+}
+
+// MoveNotesCursor moves the notes cursor by lines and characters. Moving past either end of
+// a line goes on to the line before or after.
+func (m *Model) MoveNotesCursor(rows, cols int) {
+	m.notesLine()
+	m.NotesRow = max(0, min(len(m.Notes)-1, m.NotesRow+rows))
+	m.NotesCol += cols
+	if m.NotesCol < 0 && m.NotesRow > 0 {
+		m.NotesRow--
+		m.NotesCol = len([]rune(m.Notes[m.NotesRow]))
+	} else if cols > 0 && m.NotesCol > len([]rune(m.Notes[m.NotesRow])) && m.NotesRow < len(m.Notes)-1 {
+		m.NotesRow++
+		m.NotesCol = 0
+	}
+	m.notesLine()
+}
+
+// MoveNotesToLineEnd moves the notes cursor to the end of its line
+func (m *Model) MoveNotesToLineEnd() {
+	m.NotesCol = len(m.notesLine())
+}
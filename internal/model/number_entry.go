@@ -0,0 +1,31 @@
+package model
+
+// MaxNumberEntryLength is the most characters a typed value can have
+const MaxNumberEntryLength = 6
+
+// Nudge step limits for hex cells
+const (
+	DefaultNudgeFine   = 1
+	DefaultNudgeCoarse = 16
+	MaxNudgeFine       = 16
+	MaxNudgeCoarse     = 64
+)
+
+// NumberEntry is a value being typed into the cell under the cursor
+type NumberEntry struct {
+	Active bool   // Whether digits go to the entry instead of the usual key actions
+	Hex    bool   // Whether the value is hexadecimal (cells) rather than decimal (BPM)
+	Buffer string // Characters typed so far
+}
+
+// NudgeDelta maps the fine (±1) and coarse (±16) steps of hex cells to the configured steps;
+// other deltas are left alone
+func (m *Model) NudgeDelta(delta int) int {
+	switch delta {
+	case 1, -1:
+		return delta * m.NudgeFine
+	case 16, -16:
+		return delta / 16 * m.NudgeCoarse
+	}
+	return delta
+}
@@ -0,0 +1,226 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hypebeast/go-osc/osc"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// Engine returns the OSC engine of the config with a name
+func (m *Model) Engine(name string) (types.OSCEngine, bool) {
+	if name == "" {
+		return types.OSCEngine{}, false
+	}
+	for _, engine := range m.Config.OSCEngines {
+		if engine.Name == name && engine.Port > 0 {
+			return engine, true
+		}
+	}
+	return types.OSCEngine{}, false
+}
+
+// EngineNames returns the names of the OSC engines of the config, in config order
+func (m *Model) EngineNames() []string {
+	var names []string
+	for _, engine := range m.Config.OSCEngines {
+		if engine.Name != "" && engine.Port > 0 {
+			names = append(names, engine.Name)
+		}
+	}
+	return names
+}
+
+// TrackEngine returns the OSC engine an instrument track plays. Tracks whose engine is missing
+// from this machine's config play SuperCollider, keeping the name for when it is back.
+func (m *Model) TrackEngine(track int) (types.OSCEngine, bool) {
+	if track < 0 || track >= len(m.TrackEngines) || m.TrackTypes[track] {
+		return types.OSCEngine{}, false
+	}
+	return m.Engine(m.TrackEngines[track])
+}
+
+// CycleTrackType steps a track from Instrument through the configured OSC engines to Sampler and
+// back to Instrument
+func (m *Model) CycleTrackType(track int) {
+	if track < 0 || track >= types.NumTracks {
+		return
+	}
+	engines := m.EngineNames()
+	switch {
+	case m.TrackTypes[track]:
+		m.TrackTypes[track] = false
+		m.TrackEngines[track] = ""
+	case m.TrackEngines[track] == "":
+		if len(engines) > 0 {
+			m.TrackEngines[track] = engines[0]
+		} else {
+			m.TrackTypes[track] = true
+		}
+	default:
+		next := ""
+		for i, name := range engines {
+			if name == m.TrackEngines[track] && i+1 < len(engines) {
+				next = engines[i+1]
+			}
+		}
+		m.TrackEngines[track] = next
+		m.TrackTypes[track] = next == ""
+	}
+}
+
+// TrackTypeCode returns the two-letter track type shown above the song columns: SA, IN, or the
+// first letters of the track's OSC engine
+func (m *Model) TrackTypeCode(track int) string {
+	if m.TrackTypes[track] {
+		return "SA"
+	}
+	if engine, ok := m.TrackEngine(track); ok {
+		code := []rune(strings.ToUpper(strings.ReplaceAll(engine.Name, " ", "")) + "  ")
+		return string(code[:2])
+	}
+	return "IN"
+}
+
+// TrackTypeName returns the track type for status lines
+func (m *Model) TrackTypeName(track int) string {
+	if m.TrackTypes[track] {
+		return "Sampler"
+	}
+	if engine, ok := m.TrackEngine(track); ok {
+		return fmt.Sprintf("OSC %s (%s)", engine.Name, engineTarget(engine))
+	}
+	if m.TrackEngines[track] != "" {
+		return fmt.Sprintf("Instrument (OSC %s is not configured)", m.TrackEngines[track])
+	}
+	return "Instrument"
+}
+
+// engineTarget returns the host and port an engine listens on
+func engineTarget(engine types.OSCEngine) string {
+	host := engine.Host
+	if host == "" {
+		host = "localhost"
+	}
+	return fmt.Sprintf("%s:%d", host, engine.Port)
+}
+
+// engineAddress returns the prefix of an engine's messages, "" for none
+func engineAddress(engine types.OSCEngine) string {
+	address := "/" + strings.Trim(engine.Address, "/")
+	if address == "/" {
+		return ""
+	}
+	return address
+}
+
+// EngineMessages returns the messages an OSC engine gets for instrument parameters, addressed
+// under the engine's prefix:
+//
+//	<address>/cc    track cc value                  one per CC column set on the row
+//	<address>/param track key value                 pan, volume and the row's parameter locks
+//	<address>/note  track note velocity duration    one per note of the row, duration in seconds
+//	<address>/off   track notes...                  releases the notes (all of them when empty)
+//
+// Updates to a playing row only send its CCs and parameters, so edits don't retrigger notes.
+func (m *Model) EngineMessages(engine types.OSCEngine, params InstrumentOSCParams) []*osc.Message {
+	address := engineAddress(engine)
+	track := params.TrackId
+	var msgs []*osc.Message
+	if params.NoteOn == 0 {
+		msg := osc.NewMessage(address+"/off", track)
+		for _, note := range params.Notes {
+			msg.Append(note)
+		}
+		return append(msgs, msg)
+	}
+
+	for i := 0; i < 9; i++ {
+		if value := params.MidiCC[i]; value >= 0 {
+			msgs = append(msgs, osc.NewMessage(address+"/cc", track, int32(m.MidiCCNumbers[i]), int32(value)))
+		}
+	}
+	keys := make([]string, 0, len(params.PLocks))
+	for key := range params.PLocks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	msgs = append(msgs,
+		osc.NewMessage(address+"/param", track, "pan", params.Pan),
+		osc.NewMessage(address+"/param", track, "volume", m.TrackSetLevels[track]))
+	for _, key := range keys {
+		msgs = append(msgs, osc.NewMessage(address+"/param", track, key, params.PLocks[key]))
+	}
+	if params.Update == 1 {
+		return msgs
+	}
+
+	duration := params.DeltaTime * float32(params.Gate) / 128.0
+	for _, note := range params.Notes {
+		if note < 0 || note > 127 {
+			continue // Rows without a note
+		}
+		msgs = append(msgs, osc.NewMessage(address+"/note", track, note, params.Velocity, duration))
+	}
+	return msgs
+}
+
+// engineClient returns the client sending to an engine, made on first use
+func (m *Model) engineClient(engine types.OSCEngine) *osc.Client {
+	m.engineMutex.Lock()
+	defer m.engineMutex.Unlock()
+	target := engineTarget(engine)
+	client, ok := m.engineClients[target]
+	if !ok {
+		if m.engineClients == nil {
+			m.engineClients = make(map[string]*osc.Client)
+		}
+		host, _, _ := strings.Cut(target, ":")
+		client = osc.NewClient(host, engine.Port)
+		m.engineClients[target] = client
+	}
+	return client
+}
+
+// sendEngineMessages sends instrument parameters to an OSC engine
+func (m *Model) sendEngineMessages(engine types.OSCEngine, params InstrumentOSCParams) {
+	var client oscSender = m.engineClient(engine)
+	if m.output != nil {
+		client = outputSender{to: "engine:" + engine.Name, output: m.output}
+	}
+	for _, msg := range m.EngineMessages(engine, params) {
+		if err := client.Send(msg); err != nil {
+			logging.OSC.Errorf("Error sending %s to OSC engine %s: %v", msg.Address, engine.Name, err)
+			return
+		}
+	}
+}
+
+// sendEngineStop releases every note of the tracks playing OSC engines
+func (m *Model) sendEngineStop() {
+	for track := 0; track < types.NumTracks; track++ {
+		if engine, ok := m.TrackEngine(track); ok {
+			m.sendEngineMessages(engine, InstrumentOSCParams{TrackId: int32(track), NoteOn: 0})
+		}
+	}
+}
+
+// SavedTrackEngines returns the OSC engines of the tracks to save, nil when no track plays one
+func (m *Model) SavedTrackEngines() []string {
+	for _, name := range m.TrackEngines {
+		if name != "" {
+			return m.TrackEngines[:]
+		}
+	}
+	return nil
+}
+
+// LoadTrackEngines replaces the OSC engines of the tracks with saved ones (nil for none)
+func (m *Model) LoadTrackEngines(saved []string) {
+	m.TrackEngines = [types.NumTracks]string{}
+	copy(m.TrackEngines[:], saved)
+}
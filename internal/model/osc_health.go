@@ -0,0 +1,118 @@
+package model
+
+import (
+	"time"
+
+	"github.com/schollz/collidertracker/internal/logging"
+)
+
+// OSCLinkTimeout is how long /cpuusage may be silent before the link is considered lost.
+// SuperCollider reports CPU usage once per second.
+const OSCLinkTimeout = 3 * time.Second
+
+// HandleCPUUsage records a /cpuusage message from SuperCollider.
+// It returns true when the message ends a period where the link was lost.
+func (m *Model) HandleCPUUsage(usage float32) bool {
+	m.oscHealthMutex.Lock()
+	defer m.oscHealthMutex.Unlock()
+
+	m.CPUUsage = usage
+	m.LastCPUUsageTime = time.Now()
+	if m.OSCLinkLost {
+		m.OSCLinkLost = false
+		logging.OSC.Debugf("OSC link restored")
+		return true
+	}
+	return false
+}
+
+// CheckOSCLink marks the link as lost when /cpuusage has been silent for too long.
+// Nothing is reported before the first message arrives (e.g. with --skip-sc).
+func (m *Model) CheckOSCLink(now time.Time) bool {
+	m.oscHealthMutex.Lock()
+	defer m.oscHealthMutex.Unlock()
+
+	if m.LastCPUUsageTime.IsZero() || m.OSCLinkLost {
+		return m.OSCLinkLost
+	}
+	if now.Sub(m.LastCPUUsageTime) > OSCLinkTimeout {
+		m.OSCLinkLost = true
+		logging.OSC.Debugf("OSC link lost: no /cpuusage for %v", now.Sub(m.LastCPUUsageTime).Round(time.Second))
+	}
+	return m.OSCLinkLost
+}
+
+// IsOSCLinkLost reports whether SuperCollider stopped sending telemetry
+func (m *Model) IsOSCLinkLost() bool {
+	m.oscHealthMutex.Lock()
+	defer m.oscHealthMutex.Unlock()
+	return m.OSCLinkLost
+}
+
+// HasOSCLink reports whether SuperCollider has been heard from and the link is not lost
+func (m *Model) HasOSCLink() bool {
+	m.oscHealthMutex.Lock()
+	defer m.oscHealthMutex.Unlock()
+	return !m.LastCPUUsageTime.IsZero() && !m.OSCLinkLost
+}
+
+// SendAllPreferences sends the global effect settings and track levels to SuperCollider
+// and asks it to report its sample rate and block size
+func (m *Model) SendAllPreferences() {
+	m.SendOSCServerInfoRequest()
+	m.SendOSCPregainMessage()
+	m.SendOSCPostgainMessage()
+	m.SendOSCBiasMessage()
+	m.SendOSCSaturationMessage()
+	m.SendOSCDriveMessage()
+	m.SendOSCInputLevelMessage()
+	m.SendOSCReverbSendMessage()
+	m.SendOSCTapeMessage()
+	m.SendOSCShimmerMessage()
+	m.SendOSCFadeMessage()
+	m.SendOSCInputMonitorMessage()
+	m.SendOSCInputInsertMessage()
+	m.SendOSCPitchTrackMessage()
+	m.SendOSCReverbImpulseMessage()
+	m.SendOSCReverbSettingsMessage()
+	m.SendOSCTelemetryRateMessage()
+	if !m.IsDefaultMasterChain() {
+		m.SendOSCMasterChainMessage() // SuperCollider starts with the default order
+	}
+
+	// Send track set levels too, including the input track's monitor level
+	for track := 0; track < 9; track++ {
+		m.SendOSCTrackSetLevelMessage(track)
+	}
+}
+
+// Reconnect re-handshakes with a restarted SuperCollider: listener port, preferences and sample buffers
+func (m *Model) Reconnect() {
+	logging.OSC.Debugf("Re-handshaking with SuperCollider")
+	m.SendOSCListenerPortMessage()
+	m.SendAllPreferences()
+	m.ReloadSampleBuffers()
+}
+
+// ReloadSampleBuffers asks SuperCollider to load every sample used by the project
+func (m *Model) ReloadSampleBuffers() {
+	seen := make(map[string]bool)
+	for _, file := range m.SamplerPhrasesFiles {
+		if file == "" || seen[file] {
+			continue
+		}
+		seen[file] = true
+		m.SendOSCPreloadMessage(file)
+	}
+}
+
+// SendOSCPreloadMessage loads a sample into SuperCollider's buffer cache without playing it
+func (m *Model) SendOSCPreloadMessage(filename string) {
+	config := OSCMessageConfig{
+		Address:    "/preload",
+		Parameters: []interface{}{filename},
+		LogFormat:  "OSC preload message sent: /preload %s",
+		LogArgs:    []interface{}{filename},
+	}
+	m.sendOSCMessage(config)
+}
@@ -0,0 +1,32 @@
+package model
+
+// MinPasswordLength is the shortest password a project can be protected with
+const MinPasswordLength = 8
+
+// ProjectKey encrypts a password-protected project's save file, snapshots and files. It is derived
+// from the password and the salt, which is stored in the clear at the start of each file.
+type ProjectKey struct {
+	Salt []byte
+	Key  []byte
+}
+
+// PasswordEntry is a project password being typed, twice to catch typos
+type PasswordEntry struct {
+	Active bool   // Whether typed keys go to the entry
+	Buffer string // Characters typed so far
+	First  string // The password typed the first time ("" while typing it)
+}
+
+// IsLocked reports whether the project is saved encrypted
+func (m *Model) IsLocked() bool {
+	return m.ProjectKey != nil
+}
+
+// ProjectFolder returns where the project's samples, recordings and clips are read and written:
+// the save folder, or the work folder of a password-protected project
+func (m *Model) ProjectFolder() string {
+	if m.WorkFolder != "" {
+		return m.WorkFolder
+	}
+	return m.SaveFolder
+}
@@ -0,0 +1,180 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/modulation"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// GeneratorRhythm is a rhythm template of the phrase generator: the steps of a 16-row bar
+// that may play ('x'), repeated down the phrase
+type GeneratorRhythm struct {
+	Name  string
+	Steps string
+}
+
+// GeneratorRhythms are the rhythm templates, in the order the generator view cycles them
+var GeneratorRhythms = []GeneratorRhythm{
+	{"any", "xxxxxxxxxxxxxxxx"},
+	{"four", "x...x...x...x..."},
+	{"eighths", "x.x.x.x.x.x.x.x."},
+	{"offbeat", "..x...x...x...x."},
+	{"backbeat", "....x.......x..."},
+	{"clave", "x..x..x...x.x..."},
+	{"tresillo", "x..x..x.x..x..x."},
+}
+
+// GeneratorScales are the scales the phrase generator picks notes from, as in the modulate settings
+var GeneratorScales = []string{"all", "major", "minor", "dorian", "mixolydian", "pentatonic", "blues"}
+
+// Phrase generator settings, in the order of the generator view
+const (
+	GeneratorRowRhythm = iota
+	GeneratorRowDensity
+	GeneratorRowLow
+	GeneratorRowHigh
+	GeneratorRowScale
+	GeneratorRowRoot
+	GeneratorRowLength
+	GeneratorRowCount
+)
+
+// PhraseGenerator is where the generator view's cursor is and which rows of the phrase it
+// fills are locked, so re-rolls keep them
+type PhraseGenerator struct {
+	Row       int  // Setting under the cursor
+	OnRows    bool // The cursor is on the phrase's rows, to lock them, rather than on the settings
+	PhraseRow int  // Phrase row under the cursor
+	Track     int  // Track of the phrase the generator fills
+	Phrase    int  // Phrase the generator fills
+	Locked    [types.PhraseRows]bool
+	Rolled    bool // Whether the phrase was generated since the view opened
+}
+
+// errNoSample is returned when a sampler phrase has no sample for generated rows to play
+var errNoSample = errors.New("choose a sample in the phrase first")
+
+// OpenGenerator points the phrase generator at the phrase being edited. Locks carry over
+// when it is the phrase the generator last filled.
+func (m *Model) OpenGenerator() {
+	g := &m.GeneratorState
+	if g.Track != m.CurrentTrack || g.Phrase != m.CurrentPhrase {
+		g.Locked = [types.PhraseRows]bool{}
+		g.PhraseRow = 0
+	}
+	g.Track, g.Phrase, g.Rolled = m.CurrentTrack, m.CurrentPhrase, false
+	m.ViewMode = types.GeneratorView
+}
+
+// ToggleGeneratorLock locks or unlocks the phrase row under the generator's row cursor
+func (m *Model) ToggleGeneratorLock() {
+	g := &m.GeneratorState
+	if g.PhraseRow >= 0 && g.PhraseRow < types.PhraseRows {
+		g.Locked[g.PhraseRow] = !g.Locked[g.PhraseRow]
+	}
+}
+
+// ChangeGeneratorSetting steps a setting of the phrase generator, within its range
+func (m *Model) ChangeGeneratorSetting(row, delta int) {
+	s := &m.Generator
+	sampler := m.TrackTypes[m.GeneratorState.Track]
+	switch row {
+	case GeneratorRowRhythm:
+		s.Rhythm = max(0, min(len(GeneratorRhythms)-1, s.Rhythm+delta))
+	case GeneratorRowDensity:
+		s.Density = max(0, min(100, s.Density+5*delta))
+	case GeneratorRowLow:
+		if sampler {
+			s.SliceLow = max(0, min(s.SliceHigh, s.SliceLow+delta))
+		} else {
+			s.Low = max(0, min(s.High, s.Low+delta))
+		}
+	case GeneratorRowHigh:
+		if sampler {
+			s.SliceHigh = max(s.SliceLow, min(254, s.SliceHigh+delta))
+		} else {
+			s.High = max(s.Low, min(127, s.High+delta))
+		}
+	case GeneratorRowScale:
+		current := max(0, slices.Index(GeneratorScales, s.Scale))
+		s.Scale = GeneratorScales[max(0, min(len(GeneratorScales)-1, current+delta))]
+	case GeneratorRowRoot:
+		s.Root = ((s.Root+delta)%12 + 12) % 12
+	case GeneratorRowLength:
+		s.Length = max(1, min(types.PhraseRows, s.Length+delta))
+	}
+}
+
+// GeneratorNotes returns the notes the generator picks from: for instrument phrases the notes
+// of the scale within the range, for sampler phrases the slices of the range
+func (m *Model) GeneratorNotes() []int {
+	s := m.Generator
+	if m.TrackTypes[m.GeneratorState.Track] {
+		var slicesInRange []int
+		for slice := s.SliceLow; slice <= s.SliceHigh; slice++ {
+			slicesInRange = append(slicesInRange, slice)
+		}
+		return slicesInRange
+	}
+	scale, ok := modulation.Scales[s.Scale]
+	if !ok {
+		scale = modulation.Scales["all"]
+	}
+	var notes []int
+	for note := s.Low; note <= s.High; note++ {
+		if slices.Contains(scale.Notes, ((note-s.Root)%12+12)%12) {
+			notes = append(notes, note)
+		}
+	}
+	return notes
+}
+
+// GeneratePhrase fills the generator's phrase within its settings, from a seed: the first
+// Length rows become steps of the rhythm, of which about Density percent play a note picked
+// from GeneratorNotes and the rest are rests. Locked rows and the other columns are kept. It
+// returns how many rows play.
+func (m *Model) GeneratePhrase(seed int64) (int, error) {
+	g, s := m.GeneratorState, m.Generator
+	rhythm := GeneratorRhythms[max(0, min(len(GeneratorRhythms)-1, s.Rhythm))]
+	notes := m.GeneratorNotes()
+	if len(notes) == 0 {
+		return 0, fmt.Errorf("no notes of the scale between %d and %d", s.Low, s.High)
+	}
+	sampler := m.TrackTypes[g.Track]
+	file := -1
+	if sampler {
+		for row := 0; row < types.PhraseRows && file == -1; row++ {
+			file = m.GetPhraseCell(g.Track, g.Phrase, row, types.ColFilename)
+		}
+		if file == -1 {
+			return 0, errNoSample
+		}
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	playing := 0
+	for row := 0; row < min(s.Length, types.PhraseRows); row++ {
+		if g.Locked[row] {
+			continue
+		}
+		note := -1
+		if rhythm.Steps[row%len(rhythm.Steps)] == 'x' && rng.Intn(100) < s.Density {
+			note = notes[rng.Intn(len(notes))]
+			playing++
+		}
+		if dt := m.GetPhraseCell(g.Track, g.Phrase, row, types.ColDeltaTime); dt <= 0 {
+			m.SetPhraseCell(g.Track, g.Phrase, row, types.ColDeltaTime, 1) // Rests keep their step
+		}
+		m.SetPhraseCell(g.Track, g.Phrase, row, types.ColNote, note)
+		if sampler && note != -1 && m.GetPhraseCell(g.Track, g.Phrase, row, types.ColFilename) == -1 {
+			m.SetPhraseCell(g.Track, g.Phrase, row, types.ColFilename, file)
+		}
+	}
+	logging.UI.Debugf("Generated phrase %02X: %s rhythm, %d%% density, %d of %d rows playing", g.Phrase, rhythm.Name, s.Density, playing, s.Length)
+	return playing, nil
+}
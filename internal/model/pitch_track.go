@@ -0,0 +1,151 @@
+package model
+
+import (
+	"math"
+	"sync"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/music"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// Pitch tracking thresholds for the readings SuperCollider sends 30 times a second
+const (
+	PitchTrackMinConfidence = 0.9  // Pitch.kr hasFreq below which a reading is treated as unpitched
+	PitchTrackMinAmp        = 0.02 // Input amplitude below which a reading is treated as silence
+	PitchTrackStableReads   = 3    // Readings of the same note before it is entered (about 100ms)
+)
+
+// pitchTracker turns pitch readings of the audio input into note onsets
+type pitchTracker struct {
+	mu        sync.Mutex
+	candidate int   // Note seen in the latest readings (-1 for none)
+	count     int   // Consecutive readings of the candidate
+	held      int   // Note entered last, until the input goes quiet or changes note (-1 for none)
+	pending   []int // Notes detected but not entered into the phrase yet
+	tuner     tunerReading
+}
+
+// FrequencyToNote converts a frequency in Hz into the nearest MIDI note (-1 when out of range)
+func FrequencyToNote(freq float64) int {
+	if freq <= 0 {
+		return -1
+	}
+	note := int(math.Round(69 + 12*math.Log2(freq/440.0)))
+	if note < 0 || note > 127 {
+		return -1
+	}
+	return note
+}
+
+// TogglePitchTracking turns pitch tracking of the audio input on or off
+func (m *Model) TogglePitchTracking() {
+	m.PitchTracking = !m.PitchTracking
+	m.pitchTracker.mu.Lock()
+	m.pitchTracker.candidate, m.pitchTracker.count, m.pitchTracker.held = -1, 0, -1
+	m.pitchTracker.pending = nil
+	m.pitchTracker.mu.Unlock()
+	m.SendOSCPitchTrackMessage()
+	logging.UI.Debugf("Pitch tracking: %v", m.PitchTracking)
+}
+
+// PitchTrackingNote returns the note the input is holding (-1 when quiet or unpitched)
+func (m *Model) PitchTrackingNote() int {
+	m.pitchTracker.mu.Lock()
+	defer m.pitchTracker.mu.Unlock()
+	return m.pitchTracker.held
+}
+
+// HandleInputPitch takes a pitch reading of the audio input. A pitched reading is what the
+// tuner shows. For pitch tracking, a note is queued for entry once it has held for
+// PitchTrackStableReads readings; it is not queued again until the input goes quiet or moves
+// to another note.
+func (m *Model) HandleInputPitch(freq, confidence, amp float64) {
+	note := -1
+	if confidence >= PitchTrackMinConfidence && amp >= PitchTrackMinAmp {
+		note = FrequencyToNote(freq)
+	}
+
+	t := &m.pitchTracker
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if note != -1 {
+		t.tuner = tunerReading{freq: freq, at: m.Now()}
+	}
+	if !m.PitchTracking {
+		return
+	}
+	if note != t.candidate {
+		t.candidate, t.count = note, 0
+	}
+	t.count++
+	if t.count < PitchTrackStableReads || note == t.held {
+		return
+	}
+	t.held = note
+	if note != -1 {
+		t.pending = append(t.pending, note)
+		logging.UI.Debugf("Pitch tracking detected %s (%.1f Hz)", music.MidiToNoteName(note), freq)
+	}
+}
+
+// EnterTrackedNotes writes the notes detected since the last call into the current phrase,
+// returning how many were entered. Only instrument tracks take tracked notes.
+func (m *Model) EnterTrackedNotes() int {
+	m.pitchTracker.mu.Lock()
+	notes := m.pitchTracker.pending
+	m.pitchTracker.pending = nil
+	m.pitchTracker.mu.Unlock()
+
+	if len(notes) == 0 || m.GetPhraseViewType() != types.InstrumentPhraseView {
+		return 0
+	}
+	for _, note := range notes {
+		row, step := m.trackedNoteRow()
+		m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.ColNote, note)
+		if m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.ColDeltaTime) == -1 {
+			m.SetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.ColDeltaTime, 1)
+		}
+		if step && m.ViewMode == types.PhraseView && m.CurrentRow < types.PhraseRows-1 {
+			m.CurrentRow++
+		}
+	}
+	return len(notes)
+}
+
+// trackedNoteRow picks the row a tracked note goes into. While the current phrase plays, the
+// note snaps to the nearest row (the playing row in its first half, the next row after) or to
+// the track's record quantize grid. When the phrase is not playing, notes step-enter at the
+// cursor, which then moves down (step is true).
+func (m *Model) trackedNoteRow() (row int, step bool) {
+	playing, row, ticksLeft := false, 0, 0
+	if m.IsPlaying && m.PlaybackMode == types.SongView {
+		track := m.CurrentTrack
+		if track >= 0 && track < types.NumTracks && m.SongPlaybackActive[track] && m.SongPlaybackPhrase[track] == m.CurrentPhrase {
+			playing, row, ticksLeft = true, m.SongPlaybackRowInPhrase[track], m.SongPlaybackTicksLeft[track]
+		}
+	} else if m.IsPlaying && m.PlaybackPhrase == m.CurrentPhrase {
+		playing, row, ticksLeft = true, m.PlaybackRow, m.PlaybackTicksLeft
+	}
+	if !playing {
+		return m.CurrentRow, true
+	}
+
+	rowTicks := m.TrackTicks(m.CurrentTrack, m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, row, types.ColDeltaTime))
+	return m.quantizeRecordedRow(m.CurrentTrack, m.CurrentPhrase, row, max(0, rowTicks-ticksLeft)), false
+}
+
+// SendOSCPitchTrackMessage turns pitch readings of the audio input on or off in SuperCollider
+func (m *Model) SendOSCPitchTrackMessage() {
+	pitchTrack := float32(0)
+	if m.pitchReports() {
+		pitchTrack = 1
+	}
+	config := OSCMessageConfig{
+		Address:    "/set_track",
+		Parameters: []interface{}{int32(8), "pitchTrack", pitchTrack},
+		LogFormat:  "OSC pitch track message sent: /set_track 8 'pitchTrack' %.0f",
+		LogArgs:    []interface{}{pitchTrack},
+	}
+	m.sendOSCMessage(config)
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"sort"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// PLockRow is an instrument phrase row that can carry parameter locks
+type PLockRow struct {
+	Phrase int
+	Row    int
+}
+
+// RowPLocks returns the SoundMaker parameters locked on an instrument phrase row (nil when none)
+func (m *Model) RowPLocks(phrase, row int) map[string]float32 {
+	return m.PLocks[PLockRow{phrase, row}]
+}
+
+// PLock returns the value a parameter is locked to on an instrument phrase row
+func (m *Model) PLock(phrase, row int, key string) (float32, bool) {
+	value, ok := m.PLocks[PLockRow{phrase, row}][key]
+	return value, ok
+}
+
+// SetPLock locks a SoundMaker parameter to value on an instrument phrase row
+func (m *Model) SetPLock(phrase, row int, key string, value float32) {
+	if phrase < 0 || phrase >= types.NumPhrases || row < 0 || row >= len(m.InstrumentPhrasesData[phrase]) || key == "" {
+		return
+	}
+	if m.PLocks == nil {
+		m.PLocks = make(map[PLockRow]map[string]float32)
+	}
+	locks := m.PLocks[PLockRow{phrase, row}]
+	if locks == nil {
+		locks = make(map[string]float32)
+		m.PLocks[PLockRow{phrase, row}] = locks
+	}
+	if old, ok := locks[key]; ok && old == value {
+		return
+	}
+	locks[key] = value
+	logging.UI.Debugf("P-lock %s=%.2f on phrase %02X row %02X", key, value, phrase, row)
+	m.Publish(Event{Kind: EventSettings})
+}
+
+// ClearPLock removes a parameter lock from an instrument phrase row
+func (m *Model) ClearPLock(phrase, row int, key string) {
+	locks := m.PLocks[PLockRow{phrase, row}]
+	if _, ok := locks[key]; !ok {
+		return
+	}
+	delete(locks, key)
+	if len(locks) == 0 {
+		delete(m.PLocks, PLockRow{phrase, row})
+	}
+	logging.UI.Debugf("Removed p-lock %s on phrase %02X row %02X", key, phrase, row)
+	m.Publish(Event{Kind: EventSettings})
+}
+
+// PLockTarget returns the instrument phrase row the SoundMaker view was opened from, whose
+// parameter locks the view edits
+func (m *Model) PLockTarget() (phrase, row int, ok bool) {
+	if m.ViewMode != types.SoundMakerView || m.GetPhraseViewType() != types.InstrumentPhraseView {
+		return 0, 0, false
+	}
+	phrase, row = m.CurrentPhrase, m.LastPhraseRow
+	if phrase < 0 || phrase >= types.NumPhrases || row < 0 || row >= len(m.InstrumentPhrasesData[phrase]) {
+		return 0, 0, false
+	}
+	return phrase, row, true
+}
+
+// PLockList returns every parameter lock in phrase, row and key order, as saved (nil when none)
+func (m *Model) PLockList() []types.PLock {
+	var list []types.PLock
+	for at, locks := range m.PLocks {
+		for key, value := range locks {
+			list = append(list, types.PLock{Phrase: at.Phrase, Row: at.Row, Param: key, Value: value})
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if a.Phrase != b.Phrase {
+			return a.Phrase < b.Phrase
+		}
+		if a.Row != b.Row {
+			return a.Row < b.Row
+		}
+		return a.Param < b.Param
+	})
+	return list
+}
+
+// LoadPLocks replaces the parameter locks with saved ones
+func (m *Model) LoadPLocks(list []types.PLock) {
+	m.PLocks = make(map[PLockRow]map[string]float32)
+	for _, lock := range list {
+		if lock.Phrase < 0 || lock.Phrase >= types.NumPhrases || lock.Param == "" {
+			continue
+		}
+		at := PLockRow{lock.Phrase, lock.Row}
+		if m.PLocks[at] == nil {
+			m.PLocks[at] = make(map[string]float32)
+		}
+		m.PLocks[at][lock.Param] = lock.Value
+	}
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"github.com/schollz/collidertracker/internal/logging"
+)
+
+// MaxPreviewTranspose is the largest key change a preview proposes, in semitones either way
+const MaxPreviewTranspose = 12
+
+// TempoKeyPreview is a proposed tempo and key change heard while playback runs, before it is
+// kept or reverted. The proposed tempo is the model's BPM; the key change is heard as a
+// transpose on top of every chain slot's.
+type TempoKeyPreview struct {
+	Active    bool
+	BPM       float32 // Tempo before the preview, restored on revert
+	Transpose int     // Proposed key change in semitones
+}
+
+// StartPreview starts previewing tempo and key changes from the current tempo and key
+func (m *Model) StartPreview() {
+	if m.Preview.Active {
+		return
+	}
+	m.Preview = TempoKeyPreview{Active: true, BPM: m.BPM}
+	logging.UI.Debugf("Tempo/key preview started at %.2f BPM", m.BPM)
+}
+
+// PreviewTempo changes the previewed tempo by delta BPM (1-999, as in the settings)
+func (m *Model) PreviewTempo(delta float32) {
+	if !m.Preview.Active {
+		return
+	}
+	m.BPM = max(1, min(999, m.BPM+delta))
+}
+
+// PreviewKey changes the previewed key by delta semitones
+func (m *Model) PreviewKey(delta int) {
+	if !m.Preview.Active {
+		return
+	}
+	m.Preview.Transpose = max(-MaxPreviewTranspose, min(MaxPreviewTranspose, m.Preview.Transpose+delta))
+}
+
+// previewTranspose returns the key change being previewed (0 when not previewing)
+func (m *Model) previewTranspose() int {
+	if !m.Preview.Active {
+		return 0
+	}
+	return m.Preview.Transpose
+}
+
+// AcceptPreview keeps the previewed tempo and commits the key change by transposing every
+// chain slot that holds a phrase
+func (m *Model) AcceptPreview() {
+	if !m.Preview.Active {
+		return
+	}
+	transpose := m.Preview.Transpose
+	m.Preview = TempoKeyPreview{}
+	if transpose != 0 {
+		pools := []struct {
+			chains     [][]int
+			transposes [][]int
+		}{
+			{m.InstrumentChainsData, m.InstrumentChainTransposes},
+			{m.SamplerChainsData, m.SamplerChainTransposes},
+		}
+		for _, pool := range pools {
+			for chain := range pool.chains {
+				for row, phrase := range pool.chains[chain] {
+					if phrase != -1 && chain < len(pool.transposes) && row < len(pool.transposes[chain]) {
+						pool.transposes[chain][row] = max(-MaxChainTranspose, min(MaxChainTranspose, pool.transposes[chain][row]+transpose))
+					}
+				}
+			}
+		}
+	}
+	logging.UI.Debugf("Tempo/key preview kept: %.2f BPM, %+d semitones", m.BPM, transpose)
+	m.Publish(Event{Kind: EventSettings})
+}
+
+// RevertPreview drops the previewed changes, restoring the tempo
+func (m *Model) RevertPreview() {
+	if !m.Preview.Active {
+		return
+	}
+	m.BPM = m.Preview.BPM
+	m.Preview = TempoKeyPreview{}
+	logging.UI.Debugf("Tempo/key preview reverted to %.2f BPM", m.BPM)
+}
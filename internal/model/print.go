@@ -0,0 +1,81 @@
+package model
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// PrintSoundMakerName returns the SoundMaker a phrase of an instrument track plays, taken from
+// the row under the cursor or else the first row that sets one ("" when the phrase sets none)
+func (m *Model) PrintSoundMakerName(phrase, row int) string {
+	if phrase < 0 || phrase >= types.NumPhrases {
+		return ""
+	}
+	rows := m.InstrumentPhrasesData[phrase]
+	index := -1
+	if row >= 0 && row < len(rows) {
+		index = rows[row][types.ColSoundMaker]
+	}
+	for i := 0; index < 0 && i < len(rows); i++ {
+		index = rows[i][types.ColSoundMaker]
+	}
+	if index < 0 || index >= len(m.SoundMakerSettings) {
+		return ""
+	}
+	return m.SoundMakerSettings[index].Name
+}
+
+// PrintFileLabel turns a SoundMaker name into a file name part ("PolyPerc" -> "polyperc")
+func PrintFileLabel(name string) string {
+	label := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return '-'
+	}, strings.TrimSpace(name))
+	label = strings.Trim(label, "-")
+	if label == "" {
+		return "instrument"
+	}
+	return label
+}
+
+// AddPrintToSamples adds a printed WAV to the sampler files as a one-shot, so any sampler
+// phrase row can pick it, and returns its file slot
+func (m *Model) AddPrintToSamples(file string) int {
+	slot := m.SamplerFileSlot(file)
+	if _, exists := m.FileMetadata[file]; !exists {
+		m.FileMetadata[file] = types.FileMetadata{BPM: m.BPM, Slices: 1, Playthrough: 1, SyncToBPM: 0}
+	}
+	logging.UI.Debugf("Print %s added as sampler file %02X", filepath.Base(file), slot)
+	m.Publish(Event{Kind: EventSettings})
+	return slot
+}
+
+// SendOSCPrintMessage starts or stops recording the output of one track alone to filename
+func (m *Model) SendOSCPrintMessage(track int, filename string, recording bool) {
+	recordingInt := int32(0)
+	if recording {
+		recordingInt = 1
+	}
+	absolutePath, err := filepath.Abs(filename)
+	if err != nil {
+		logging.OSC.Errorf("Error converting filename to absolute path: %v", err)
+		absolutePath = filename
+	}
+
+	config := OSCMessageConfig{
+		Address:    "/print",
+		Parameters: []interface{}{int32(track), absolutePath, recordingInt},
+		LogFormat:  "OSC print message sent: /print %d '%s' %d",
+		LogArgs:    []interface{}{track, absolutePath, int(recordingInt)},
+	}
+
+	m.sendOSCMessage(config)
+}
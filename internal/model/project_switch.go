@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+// HandOverTimeout is how long a project opened while the previous one plays waits for
+// SuperCollider to report its samples loaded before taking over playback anyway
+const HandOverTimeout = 5 * time.Second
+
+// TakeOverServer readies a SuperCollider that kept running after the previous project closed,
+// while the previous project still plays: it loads this project's samples in the background
+// and puts the master output back in this project's order. SuperCollider replies with
+// /preloaded once the samples are loaded.
+func (m *Model) TakeOverServer() {
+	m.ReloadSampleBuffers()
+	m.SendOSCMasterChainMessage()
+	m.sendOSCMessage(OSCMessageConfig{
+		Address:   "/preload_sync",
+		LogFormat: "OSC preload sync message sent: /preload_sync",
+	})
+}
+
+// ReleaseServer frees the samples only the previous project used, once it stopped playing
+func (m *Model) ReleaseServer(previous *Model) {
+	for _, file := range m.releasedSamples(previous) {
+		m.SendOSCUnloadMessage(file)
+	}
+}
+
+// releasedSamples returns the samples of the previous project that this project does not use
+func (m *Model) releasedSamples(previous *Model) []string {
+	used := make(map[string]bool)
+	for _, file := range m.SamplerPhrasesFiles {
+		used[file] = true
+	}
+	var released []string
+	for _, file := range previous.SamplerPhrasesFiles {
+		if file != "" && !used[file] {
+			used[file] = true // Once per file
+			released = append(released, file)
+		}
+	}
+	return released
+}
+
+// SendOSCUnloadMessage frees a sample from SuperCollider's buffer cache
+func (m *Model) SendOSCUnloadMessage(filename string) {
+	config := OSCMessageConfig{
+		Address:    "/unload",
+		Parameters: []interface{}{filename},
+		LogFormat:  "OSC unload message sent: /unload %s",
+		LogArgs:    []interface{}{filename},
+	}
+	m.sendOSCMessage(config)
+}
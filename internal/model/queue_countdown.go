@@ -0,0 +1,57 @@
+package model
+
+import (
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// QueueCountdown is how long until a queued start, stop or jump runs
+type QueueCountdown struct {
+	Rows  int // Phrase rows left to play, the current one included
+	Ticks int // Playback clock ticks left
+}
+
+// chainCountdown returns how long until a playing track finishes its chain, which is when
+// queued actions run. Phrases and rows without playable DT are skipped as in playback.
+func (m *Model) chainCountdown(track int) QueueCountdown {
+	countdown := QueueCountdown{Rows: 1, Ticks: m.SongPlaybackTicksLeft[track]}
+	addRows := func(phrase, from int) {
+		for row := from; row < types.PhraseRows; row++ {
+			if dt := m.GetPhraseCell(track, phrase, row, types.ColDeltaTime); dt >= 1 {
+				countdown.Rows++
+				countdown.Ticks += m.TrackTicks(track, dt)
+			}
+		}
+	}
+	addRows(m.SongPlaybackPhrase[track], m.SongPlaybackRowInPhrase[track]+1)
+	chain := m.SongPlaybackChain[track]
+	for chainRow := m.SongPlaybackChainRow[track] + 1; chainRow < types.ChainRows; chainRow++ {
+		if phrase := m.GetChainCell(track, chain, chainRow); phrase != -1 {
+			addRows(phrase, 0)
+		}
+	}
+	return countdown
+}
+
+// QueuedCountdown returns how long until the action queued on a track runs in song playback.
+// Stops and jumps wait for the track to finish its chain; starts wait for the first playing
+// track to finish its chain, counted in that track's rows. ok is false when nothing is queued
+// or no track is playing to reach a boundary.
+func (m *Model) QueuedCountdown(track int) (countdown QueueCountdown, ok bool) {
+	if track < 0 || track >= types.NumTracks || !m.IsPlaying || m.PlaybackMode != types.SongView {
+		return countdown, false
+	}
+	switch {
+	case m.SongPlaybackQueued[track] == -1 && m.SongPlaybackActive[track]:
+		return m.chainCountdown(track), true
+	case m.SongPlaybackQueued[track] == 1:
+		for t := 0; t < types.NumTracks; t++ {
+			if !m.SongPlaybackActive[t] {
+				continue
+			}
+			if c := m.chainCountdown(t); !ok || c.Ticks < countdown.Ticks {
+				countdown, ok = c, true
+			}
+		}
+	}
+	return countdown, ok
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"path/filepath"
+
+	"github.com/schollz/collidertracker/internal/logging"
+)
+
+// QuickSlotCount is how many quick-slots a project has, one per digit key
+const QuickSlotCount = 10
+
+// BindQuickSlot binds a sample file to a quick-slot, or unbinds the slot when the file is
+// already bound to it, and reports whether the file is bound afterwards
+func (m *Model) BindQuickSlot(slot int, file string) bool {
+	if slot < 0 || slot >= QuickSlotCount {
+		return false
+	}
+	bound := m.QuickSlots[slot] != file
+	if bound {
+		m.QuickSlots[slot] = file
+		logging.UI.Debugf("Quick-slot %d: %s", slot, file)
+	} else {
+		m.QuickSlots[slot] = ""
+		logging.UI.Debugf("Quick-slot %d cleared", slot)
+	}
+	m.Publish(Event{Kind: EventSettings})
+	return bound
+}
+
+// QuickSlotOf returns the first quick-slot a file is bound to, or -1
+func (m *Model) QuickSlotOf(file string) int {
+	for slot, bound := range m.QuickSlots {
+		if bound != "" && filepath.Clean(bound) == filepath.Clean(file) {
+			return slot
+		}
+	}
+	return -1
+}
+
+// HasQuickSlots reports whether any quick-slot has a sample bound
+func (m *Model) HasQuickSlots() bool {
+	for _, file := range m.QuickSlots {
+		if file != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"math/rand"
+
+	"github.com/schollz/collidertracker/internal/logging"
+)
+
+// MaxRandomSeed is the largest project seed; seeds run from 1 so a saved 0 means "none yet"
+const MaxRandomSeed = 0xFFFF
+
+// NewRandomSeed picks a project seed
+func NewRandomSeed() int {
+	return rand.Intn(MaxRandomSeed) + 1
+}
+
+// ResetRandom restarts every playback RNG from the project seed, so playing the same
+// song from the same place makes the same random choices
+func (m *Model) ResetRandom() {
+	for i := range m.ModulateRngs {
+		m.ModulateRngs[i] = rand.New(rand.NewSource(int64(m.RandomSeed)*8 + int64(i)))
+	}
+	m.EffectRng = rand.New(rand.NewSource(-int64(m.RandomSeed)))
+	m.SongRng = rand.New(rand.NewSource(int64(m.RandomSeed) + MaxRandomSeed*8))
+	m.HumanizeRng = rand.New(rand.NewSource(-int64(m.RandomSeed) - MaxRandomSeed))
+}
+
+// SetRandomSeed sets the project seed, wrapping it into 1-FFFF, and restarts the RNGs
+func (m *Model) SetRandomSeed(seed int) {
+	seed = (seed-1)%MaxRandomSeed + 1
+	if seed <= 0 {
+		seed += MaxRandomSeed
+	}
+	m.RandomSeed = seed
+	m.ResetRandom()
+	logging.Playback.Debugf("Random seed: %04X", m.RandomSeed)
+}
+
+// RerollRandomSeed replaces the project seed with a new one
+func (m *Model) RerollRandomSeed() {
+	seed := NewRandomSeed()
+	for seed == m.RandomSeed {
+		seed = NewRandomSeed()
+	}
+	m.SetRandomSeed(seed)
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"math"
+
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// DefaultRecordQuantizeStrength is how far, in percent of half the grid, a live note is pulled
+// onto the grid by default
+const DefaultRecordQuantizeStrength = 50
+
+// recordQuantizeDivisions are the notes per beat of each record quantize grid (0 for off)
+var recordQuantizeDivisions = []int{0, 4, 2}
+
+// CycleRecordQuantize steps a track's record quantize grid through off, 1/16 and 1/8
+func (m *Model) CycleRecordQuantize(track int, delta int) {
+	if track < 0 || track >= len(m.RecordQuantize) {
+		return
+	}
+	m.RecordQuantize[track] = max(0, min(len(types.RecordQuantizeNames)-1, m.RecordQuantize[track]+delta))
+}
+
+// AdjustRecordQuantizeStrength changes a track's record quantize strength, from 0 to 100 percent
+func (m *Model) AdjustRecordQuantizeStrength(track int, delta int) {
+	if track < 0 || track >= len(m.RecordQuantizeStrength) {
+		return
+	}
+	m.RecordQuantizeStrength[track] = max(0, min(100, m.RecordQuantizeStrength[track]+delta))
+}
+
+// recordQuantizeGrid returns the length in clock ticks of a track's record quantize grid (0 when
+// off). It can be shorter than a tick at low PPQ, in which case notes land on the nearest row.
+func (m *Model) recordQuantizeGrid(track int) float64 {
+	if track < 0 || track >= len(m.RecordQuantize) {
+		return 0
+	}
+	grid := m.RecordQuantize[track]
+	if grid <= 0 || grid >= len(recordQuantizeDivisions) {
+		return 0
+	}
+	return float64(m.PPQ*m.ClockResolution()) / float64(recordQuantizeDivisions[grid])
+}
+
+// quantizeRecordedRow picks the row of a phrase for a note played elapsed clock ticks into row.
+// The note goes to the nearest row, unless it is within the track's strength of a grid line,
+// in which case it goes to the row nearest that line.
+func (m *Model) quantizeRecordedRow(track, phrase, row, elapsed int) int {
+	starts := make([]int, types.PhraseRows)
+	ticks := 0
+	for r := range starts {
+		starts[r] = ticks
+		if dt := m.GetPhraseCell(track, phrase, r, types.ColDeltaTime); dt > 0 {
+			ticks += m.TrackTicks(track, dt)
+		}
+	}
+	pos := float64(starts[row] + elapsed)
+	target := pos
+	if grid := m.recordQuantizeGrid(track); grid > 0 {
+		line := math.Round(pos/grid) * grid
+		if math.Abs(pos-line) <= float64(m.RecordQuantizeStrength[track])/100*grid/2 {
+			target = line
+		}
+	}
+
+	// Ties stay with the playing row, or else go to the earliest row, so a note exactly halfway
+	// through a row stays in it and one late in the last row goes to the empty row after it
+	best := row
+	for r := range starts {
+		if math.Abs(float64(starts[r])-target) < math.Abs(float64(starts[best])-target) {
+			best = r
+		}
+	}
+	return best
+}
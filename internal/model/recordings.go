@@ -0,0 +1,187 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/schollz/collidertracker/internal/getbpm"
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/loudness"
+)
+
+// RecordingsFolderName is the project subfolder holding session and track recordings
+const RecordingsFolderName = "recordings"
+
+// PreRollChoices are the beats of silence a session take can start with, for the Settings view
+var PreRollChoices = []int{0, 1, 2, 4, 8}
+
+// PreRollName returns the session pre-roll for the Settings view
+func (m *Model) PreRollName() string {
+	switch m.SessionPreRoll {
+	case 0:
+		return "off"
+	case 1:
+		return "1 beat"
+	default:
+		return fmt.Sprintf("%d beats", m.SessionPreRoll)
+	}
+}
+
+// PreRollSeconds returns how long the session pre-roll lasts at the current tempo
+func (m *Model) PreRollSeconds() float64 {
+	if m.SessionPreRoll <= 0 || m.BPM <= 0 {
+		return 0
+	}
+	return float64(m.SessionPreRoll) * 60 / float64(m.BPM)
+}
+
+// SessionMarker is where a song section starts in a session take
+type SessionMarker struct {
+	Name    string    // Cue of the section, or "Start" before the first cue, with the pass when it repeats ("DROP 2")
+	Section int       // First song row of the section
+	At      time.Time // When playback entered it
+}
+
+// sessionTakeSound notes when the first sound of a session take went out: SuperCollider starts
+// the take's file with it
+func (m *Model) sessionTakeSound() {
+	if m.SessionRecording && m.SessionTakeStart.IsZero() {
+		m.SessionTakeStart = m.Now()
+	}
+}
+
+// MarkSessionSection marks the song section playback is in on the session take, unless it is
+// the section marked last. Sections start at the song rows with cues; without cues every song
+// row is a section. Only song playback has sections.
+func (m *Model) MarkSessionSection() {
+	row := m.tempoRow()
+	if !m.SessionRecording || row < 0 {
+		return
+	}
+	section, name := row, fmt.Sprintf("Row %02X", row)
+	if m.HasCues() {
+		section, name = 0, "Start"
+		for r := row; r >= 0; r-- {
+			if m.SongCues[r] != "" {
+				section, name = r, m.SongCues[r]
+				break
+			}
+		}
+	}
+	if section == m.SessionSection {
+		return
+	}
+	m.SessionSection = section
+	pass := 1
+	for _, marker := range m.SessionMarkers {
+		if marker.Section == section {
+			pass++
+		}
+	}
+	if pass > 1 {
+		name = fmt.Sprintf("%s %d", name, pass)
+	}
+	m.SessionMarkers = append(m.SessionMarkers, SessionMarker{Name: name, Section: section, At: m.Now()})
+	logging.UI.Debugf("Session take marker %q", name)
+}
+
+// RecordingInfo describes a recording on disk
+type RecordingInfo struct {
+	Path     string    // Full path of the WAV file
+	Name     string    // File name shown in the list
+	Size     int64     // File size in bytes
+	Duration float64   // Duration in seconds (0 if it could not be read)
+	ModTime  time.Time // Last modification (end of the recording)
+}
+
+// RecordingsFolder returns where recordings of this project are written
+func (m *Model) RecordingsFolder() string {
+	return filepath.Join(m.ProjectFolder(), RecordingsFolderName)
+}
+
+// FilesRecording returns the files SuperCollider is still writing: the recording, the session
+// take and the loop bounce in progress
+func (m *Model) FilesRecording() []string {
+	var files []string
+	if m.RecordingActive {
+		files = append(files, m.CurrentRecordingFile)
+	}
+	if m.SessionRecording {
+		files = append(files, m.SessionRecordingFile)
+	}
+	if m.Bounce != nil {
+		files = append(files, m.Bounce.File)
+	}
+	return files
+}
+
+// RefreshRecordings reloads the recordings list, newest first, and keeps the cursor in range
+func (m *Model) RefreshRecordings() {
+	m.Recordings = m.Recordings[:0]
+	entries, err := os.ReadDir(m.RecordingsFolder())
+	if err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".wav") {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+			path := filepath.Join(m.RecordingsFolder(), entry.Name())
+			duration, _, _, _ := getbpm.Length(path)
+			m.Recordings = append(m.Recordings, RecordingInfo{
+				Path:     path,
+				Name:     entry.Name(),
+				Size:     info.Size(),
+				Duration: duration,
+				ModTime:  info.ModTime(),
+			})
+		}
+	}
+	sort.Slice(m.Recordings, func(i, j int) bool {
+		return m.Recordings[i].ModTime.After(m.Recordings[j].ModTime)
+	})
+
+	if m.RecordingsRow >= len(m.Recordings) {
+		m.RecordingsRow = len(m.Recordings) - 1
+	}
+	if m.RecordingsRow < 0 {
+		m.RecordingsRow = 0
+	}
+}
+
+// SelectedRecording returns the recording under the cursor
+func (m *Model) SelectedRecording() (RecordingInfo, bool) {
+	if m.RecordingsRow < 0 || m.RecordingsRow >= len(m.Recordings) {
+		return RecordingInfo{}, false
+	}
+	return m.Recordings[m.RecordingsRow], true
+}
+
+// RenameRecording renames a recording within the recordings folder, keeping the .wav extension
+func (m *Model) RenameRecording(path, newName string) (string, error) {
+	newName = strings.TrimSpace(newName)
+	if newName == "" || strings.ContainsAny(newName, `/\`) {
+		return "", fmt.Errorf("invalid recording name %q", newName)
+	}
+	if !strings.EqualFold(filepath.Ext(newName), ".wav") {
+		newName += ".wav"
+	}
+	newPath := filepath.Join(filepath.Dir(path), newName)
+	if _, err := os.Stat(newPath); err == nil {
+		return "", fmt.Errorf("%s already exists", newName)
+	}
+	if err := os.Rename(path, newPath); err != nil {
+		return "", err
+	}
+	// The loudness report of a bounce follows it
+	if _, err := os.Stat(loudness.ReportPath(path)); err == nil {
+		os.Rename(loudness.ReportPath(path), loudness.ReportPath(newPath))
+	}
+	return newPath, nil
+}
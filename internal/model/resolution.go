@@ -0,0 +1,92 @@
+package model
+
+import (
+	"github.com/schollz/collidertracker/internal/logging"
+)
+
+// trackResolutionSteps are the resolutions a track can be set to, in ticks per PPQ tick
+var trackResolutionSteps = []int{1, 2, 4, 8}
+
+// TrackResolution returns how many times per PPQ tick a track's rows tick (1 for invalid tracks)
+func (m *Model) TrackResolution(track int) int {
+	if track < 0 || track >= len(m.TrackResolutions) || m.TrackResolutions[track] < 1 {
+		return 1
+	}
+	return m.TrackResolutions[track]
+}
+
+// ClockResolution returns how many playback clock ticks make up one PPQ tick: the finest track
+// resolution, so every track's ticks fall on clock ticks
+func (m *Model) ClockResolution() int {
+	clock := 1
+	for track := range m.TrackResolutions {
+		if res := m.TrackResolution(track); res > clock {
+			clock = res
+		}
+	}
+	return clock
+}
+
+// TrackTicks converts a DT of track's rows into playback clock ticks
+func (m *Model) TrackTicks(track, dt int) int {
+	return dt * m.ClockResolution() / m.TrackResolution(track)
+}
+
+// SetTrackResolution sets a track's resolution. When this changes the playback clock while
+// playing, the ticks left in each row and the tick count are rescaled so timing continues.
+func (m *Model) SetTrackResolution(track, res int) {
+	if track < 0 || track >= len(m.TrackResolutions) {
+		return
+	}
+	oldClock := m.ClockResolution()
+	m.TrackResolutions[track] = res
+	newClock := m.ClockResolution()
+	logging.Playback.Debugf("Track %d resolution: x%d", track+1, res)
+	if !m.IsPlaying || newClock == oldClock {
+		return
+	}
+	rescale := func(ticks int) int {
+		if ticks <= 0 {
+			return ticks
+		}
+		return (ticks*newClock + oldClock - 1) / oldClock // Round up so a playing row is not cut short
+	}
+	for t := range m.SongPlaybackTicksLeft {
+		m.SongPlaybackTicksLeft[t] = rescale(m.SongPlaybackTicksLeft[t])
+	}
+	m.PlaybackTicksLeft = rescale(m.PlaybackTicksLeft)
+	m.PlaybackTickCount = m.PlaybackTickCount * newClock / oldClock
+	if m.PlaybackTempo.Resolution == oldClock { // Keep the clock's anchor in step with the tick count
+		m.PlaybackTempo.Tick = m.PlaybackTempo.Tick * newClock / oldClock
+		m.PlaybackTempo.Resolution = newClock
+	}
+}
+
+// CycleTrackResolution steps a track to the next finer (delta > 0) or coarser resolution
+func (m *Model) CycleTrackResolution(track int, delta int) {
+	current := 0
+	for i, res := range trackResolutionSteps {
+		if res == m.TrackResolution(track) {
+			current = i
+		}
+	}
+	next := current
+	if delta > 0 && current < len(trackResolutionSteps)-1 {
+		next++
+	} else if delta < 0 && current > 0 {
+		next--
+	}
+	if next != current {
+		m.SetTrackResolution(track, trackResolutionSteps[next])
+	}
+}
+
+// ValidTrackResolution reports whether res is one of the track resolution steps
+func ValidTrackResolution(res int) bool {
+	for _, step := range trackResolutionSteps {
+		if res == step {
+			return true
+		}
+	}
+	return false
+}
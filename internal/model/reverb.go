@@ -0,0 +1,113 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ImpulsesFolderName is the project subfolder scanned for impulse response WAVs
+const ImpulsesFolderName = "impulses"
+
+// BuiltinImpulses are the impulse responses SuperCollider synthesizes itself
+var BuiltinImpulses = []string{"room", "hall", "plate"}
+
+// builtinImpulsePrefix marks a ReverbImpulse value naming one of BuiltinImpulses
+const builtinImpulsePrefix = "builtin:"
+
+// ImpulsesFolder returns where this project's impulse responses are read from
+func (m *Model) ImpulsesFolder() string {
+	return filepath.Join(m.ProjectFolder(), ImpulsesFolderName)
+}
+
+// ReverbImpulseChoices lists the values ReverbImpulse can take: "" for the algorithmic
+// reverb, then the built-in impulse responses, then the WAV files in the impulses folder
+func (m *Model) ReverbImpulseChoices() []string {
+	choices := []string{""}
+	for _, name := range BuiltinImpulses {
+		choices = append(choices, builtinImpulsePrefix+name)
+	}
+	var files []string
+	entries, err := os.ReadDir(m.ImpulsesFolder())
+	if err == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".wav") {
+				files = append(files, entry.Name())
+			}
+		}
+	}
+	sort.Strings(files)
+	return append(choices, files...)
+}
+
+// CycleReverbImpulse selects the next (delta > 0) or previous reverb impulse response
+func (m *Model) CycleReverbImpulse(delta float32) {
+	choices := m.ReverbImpulseChoices()
+	current := 0
+	for i, choice := range choices {
+		if choice == m.ReverbImpulse {
+			current = i
+			break
+		}
+	}
+	if delta > 0 && current < len(choices)-1 {
+		current++
+	} else if delta < 0 && current > 0 {
+		current--
+	}
+	m.ReverbImpulse = choices[current]
+}
+
+// ReverbImpulseName returns how a ReverbImpulse value is shown in the settings view
+func ReverbImpulseName(impulse string) string {
+	if impulse == "" {
+		return "off"
+	}
+	return strings.TrimPrefix(impulse, builtinImpulsePrefix)
+}
+
+// SendOSCReverbSettingsMessage sends the algorithmic reverb and shimmer voicing settings
+func (m *Model) SendOSCReverbSettingsMessage() {
+	params := []struct {
+		name  string
+		value float32
+	}{
+		{"reverbAlgo", float32(m.Reverb.Algorithm)},
+		{"reverbSize", float32(m.Reverb.Size) / 100.0},
+		{"reverbDamp", float32(m.Reverb.Damp) / 100.0},
+		{"reverbPreDelay", float32(m.Reverb.PreDelayMS) / 1000.0},
+		{"shimmerVoicing", float32(m.Reverb.ShimmerVoicing)},
+	}
+	for _, param := range params {
+		m.sendOSCMessage(OSCMessageConfig{
+			Address:    "/set",
+			Parameters: []interface{}{param.name, param.value},
+			LogFormat:  "OSC reverb message sent: /set '%s' %.3f",
+			LogArgs:    []interface{}{param.name, param.value},
+		})
+	}
+}
+
+// SendOSCReverbImpulseMessage loads the selected impulse response into the convolution
+// reverb, or switches back to the algorithmic reverb
+func (m *Model) SendOSCReverbImpulseMessage() {
+	kind, value := "off", ""
+	switch {
+	case strings.HasPrefix(m.ReverbImpulse, builtinImpulsePrefix):
+		kind, value = "builtin", strings.TrimPrefix(m.ReverbImpulse, builtinImpulsePrefix)
+	case m.ReverbImpulse != "":
+		kind = "file"
+		value = filepath.Join(m.ImpulsesFolder(), m.ReverbImpulse)
+		if abs, err := filepath.Abs(value); err == nil {
+			value = abs
+		}
+	}
+	config := OSCMessageConfig{
+		Address:    "/reverb_ir",
+		Parameters: []interface{}{kind, value},
+		LogFormat:  "OSC reverb impulse message sent: /reverb_ir %s '%s'",
+		LogArgs:    []interface{}{kind, value},
+	}
+	m.sendOSCMessage(config)
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/schollz/collidertracker/internal/getbpm"
+)
+
+// SetServerAudioInfo records the server program, sample rate and block size reported by SuperCollider
+func (m *Model) SetServerAudioInfo(program string, sampleRate, blockSize int) {
+	m.oscHealthMutex.Lock()
+	defer m.oscHealthMutex.Unlock()
+	m.ServerProgram = program
+	m.ServerSampleRate = sampleRate
+	m.ServerBlockSize = blockSize
+}
+
+// ServerProgramName returns the server program SuperCollider reported ("" until reported)
+func (m *Model) ServerProgramName() string {
+	m.oscHealthMutex.Lock()
+	defer m.oscHealthMutex.Unlock()
+	return m.ServerProgram
+}
+
+// ServerAudioInfo returns the server sample rate and block size (zero until reported)
+func (m *Model) ServerAudioInfo() (sampleRate, blockSize int) {
+	m.oscHealthMutex.Lock()
+	defer m.oscHealthMutex.Unlock()
+	return m.ServerSampleRate, m.ServerBlockSize
+}
+
+// SendOSCServerInfoRequest asks SuperCollider to report its server program, sample rate and block size
+func (m *Model) SendOSCServerInfoRequest() {
+	config := OSCMessageConfig{
+		Address:   "/server_info",
+		LogFormat: "OSC server info request sent: /server_info",
+	}
+	m.sendOSCMessage(config)
+}
+
+// SampleRate returns the sample rate of an audio file, reading the waveform copy
+// when the file itself is not a WAV. It returns 0 when the rate cannot be read.
+func (m *Model) SampleRate(path string) int {
+	_, rate, _, err := getbpm.Length(path)
+	if err != nil {
+		if meta, ok := m.FileMetadata[path]; ok && meta.WaveformFile != "" {
+			_, rate, _, err = getbpm.Length(meta.WaveformFile)
+		}
+	}
+	if err != nil {
+		return 0
+	}
+	return int(rate)
+}
+
+// SampleRateMismatch returns a warning when a sample's rate differs from the server's,
+// or "" when they match or either rate is unknown
+func (m *Model) SampleRateMismatch(path string) string {
+	serverRate, _ := m.ServerAudioInfo()
+	rate := m.SampleRate(path)
+	if serverRate == 0 || rate == 0 || rate == serverRate {
+		return ""
+	}
+	return fmt.Sprintf("Sample is %d Hz, server runs at %d Hz (resampled on playback)", rate, serverRate)
+}
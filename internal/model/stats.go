@@ -0,0 +1,182 @@
+package model
+
+import (
+	"os"
+
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// ProjectStats summarizes song length, slot usage and sample disk usage
+type ProjectStats struct {
+	TrackTicks            [8]int  // Ticks for one pass through the song per track (at the track's resolution)
+	TrackEvents           [8]int  // Played rows for one pass through the song per track
+	SongTicks             int     // PPQ ticks of the longest track
+	SongSeconds           float64 // Duration of the longest track at the current BPM/PPQ
+	InstrumentChainsUsed  int     // Used chains in the instrument pool (of 255)
+	SamplerChainsUsed     int     // Used chains in the sampler pool (of 255)
+	InstrumentPhrasesUsed int     // Used phrases in the instrument pool (of 255)
+	SamplerPhrasesUsed    int     // Used phrases in the sampler pool (of 255)
+	SampleFiles           int     // Number of distinct sample files referenced
+	SampleBytes           int64   // Total size of referenced sample files on disk
+	MissingSamples        int     // Referenced sample files that could not be found
+	MismatchedSamples     int     // Referenced sample files whose sample rate differs from the server's
+}
+
+// ComputeProjectStats walks the song, chains and phrases to build the project statistics
+func (m *Model) ComputeProjectStats() ProjectStats {
+	var stats ProjectStats
+
+	// Song length and events: every non-empty song row is played once per pass
+	for track := 0; track < types.NumTracks; track++ {
+		chainsData := m.GetChainsDataForTrack(track)
+		phrasesData := m.GetPhrasesDataForTrack(track)
+		for row := 0; row < types.SongRows; row++ {
+			chainID := m.SongData[track][row]
+			if chainID < 0 || chainID >= len(*chainsData) {
+				continue
+			}
+			for chainRow := 0; chainRow < types.ChainRows; chainRow++ {
+				phraseID := (*chainsData)[chainID][chainRow]
+				if phraseID < 0 || phraseID >= types.NumPhrases {
+					continue
+				}
+				for _, rowData := range (*phrasesData)[phraseID] {
+					if dt := rowData[types.ColDeltaTime]; dt > 0 {
+						stats.TrackTicks[track] += dt
+						stats.TrackEvents[track]++
+					}
+				}
+			}
+		}
+		res := m.TrackResolution(track) // Compare tracks in PPQ ticks
+		if ticks := (stats.TrackTicks[track] + res - 1) / res; ticks > stats.SongTicks {
+			stats.SongTicks = ticks
+		}
+	}
+	if m.BPM > 0 && m.PPQ > 0 {
+		ticksPerSecond := float64(m.BPM) / 60.0 * float64(m.PPQ)
+		for track, ticks := range stats.TrackTicks {
+			if seconds := float64(ticks) / (ticksPerSecond * float64(m.TrackResolution(track))); seconds > stats.SongSeconds {
+				stats.SongSeconds = seconds
+			}
+		}
+	}
+
+	// Slot usage per pool
+	stats.InstrumentChainsUsed, stats.InstrumentPhrasesUsed = m.countUsedSlots(false)
+	stats.SamplerChainsUsed, stats.SamplerPhrasesUsed = m.countUsedSlots(true)
+
+	// Sample disk usage (each file counted once)
+	seen := make(map[string]bool)
+	serverRate, _ := m.ServerAudioInfo()
+	for _, file := range m.SamplerPhrasesFiles {
+		if file == "" || seen[file] {
+			continue
+		}
+		seen[file] = true
+		stats.SampleFiles++
+		if sample := m.sampleStat(file); !sample.missing {
+			stats.SampleBytes += sample.bytes
+			if serverRate > 0 && sample.rate > 0 && sample.rate != serverRate {
+				stats.MismatchedSamples++
+			}
+		} else {
+			stats.MissingSamples++
+		}
+	}
+
+	return stats
+}
+
+// sampleStat is what the statistics need from a sample file on disk
+type sampleStat struct {
+	bytes   int64 // File size
+	rate    int   // Sample rate (0 when unknown)
+	missing bool  // The file could not be found
+}
+
+// sampleStat returns the size and rate of a sample file, reading them from disk only the first
+// time the file is asked for after the project loaded or was edited
+func (m *Model) sampleStat(file string) sampleStat {
+	m.sampleStatsMutex.Lock()
+	sample, ok := m.sampleStats[file]
+	m.sampleStatsMutex.Unlock()
+	if ok {
+		return sample
+	}
+
+	if info, err := os.Stat(file); err == nil {
+		sample = sampleStat{bytes: info.Size(), rate: m.SampleRate(file)}
+	} else {
+		sample = sampleStat{missing: true}
+	}
+	m.sampleStatsMutex.Lock()
+	if m.sampleStats == nil {
+		m.sampleStats = make(map[string]sampleStat)
+	}
+	m.sampleStats[file] = sample
+	m.sampleStatsMutex.Unlock()
+	return sample
+}
+
+// forgetSampleStats drops the cached sample sizes when a project loads or changes, since an edit
+// can point a slot at another file or replace a file on disk
+func (m *Model) forgetSampleStats(e Event) {
+	if e.Kind == EventSaved || e.Kind == EventView {
+		return
+	}
+	m.sampleStatsMutex.Lock()
+	defer m.sampleStatsMutex.Unlock()
+	m.sampleStats = nil
+}
+
+// countUsedSlots returns how many chains and phrases of a pool hold data or are referenced
+func (m *Model) countUsedSlots(sampler bool) (chainsUsed, phrasesUsed int) {
+	chainsData := &m.InstrumentChainsData
+	phrasesData := &m.InstrumentPhrasesData
+	if sampler {
+		chainsData = &m.SamplerChainsData
+		phrasesData = &m.SamplerPhrasesData
+	}
+
+	var chainUsed [types.NumChains]bool
+	var phraseUsed [types.NumPhrases]bool
+	for track := 0; track < types.NumTracks; track++ {
+		if m.TrackTypes[track] != sampler {
+			continue
+		}
+		for row := 0; row < types.SongRows; row++ {
+			if chainID := m.SongData[track][row]; chainID >= 0 && chainID < types.NumChains {
+				chainUsed[chainID] = true
+			}
+		}
+	}
+	for chainID := 0; chainID < types.NumChains && chainID < len(*chainsData); chainID++ {
+		for _, phraseID := range (*chainsData)[chainID] {
+			if phraseID >= 0 && phraseID < types.NumPhrases {
+				chainUsed[chainID] = true
+				phraseUsed[phraseID] = true
+			}
+		}
+	}
+	for phraseID := 0; phraseID < types.NumPhrases; phraseID++ {
+		for _, rowData := range (*phrasesData)[phraseID] {
+			if rowData[types.ColDeltaTime] > 0 || rowData[types.ColNote] != -1 {
+				phraseUsed[phraseID] = true
+				break
+			}
+		}
+	}
+
+	for _, used := range chainUsed {
+		if used {
+			chainsUsed++
+		}
+	}
+	for _, used := range phraseUsed {
+		if used {
+			phrasesUsed++
+		}
+	}
+	return chainsUsed, phrasesUsed
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/schollz/collidertracker/internal/logging"
+)
+
+// SynthsFolderName is the project subfolder watched for user SynthDefs in developer mode
+const SynthsFolderName = "synths"
+
+// SynthsFolder returns where this project's user SynthDefs are read from
+func (m *Model) SynthsFolder() string {
+	return filepath.Join(m.SaveFolder, SynthsFolderName)
+}
+
+// SendOSCSynthDefReloadMessage makes SuperCollider evaluate a changed SynthDef and add it to the server
+func (m *Model) SendOSCSynthDefReloadMessage(name, code string) {
+	config := OSCMessageConfig{
+		Address:    "/synthdef_reload",
+		Parameters: []interface{}{name, code},
+		LogFormat:  "OSC synthdef reload message sent: /synthdef_reload '%s' (%d bytes)",
+		LogArgs:    []interface{}{name, len(code)},
+	}
+	m.sendOSCMessage(config)
+}
+
+// HandleSynthDefReloaded reports the result of a /synthdef_reload in the footer
+func (m *Model) HandleSynthDefReloaded(name string, ok bool) {
+	if ok {
+		logging.OSC.Debugf("Hot reload: SynthDef %s reloaded", name)
+		m.Notice = fmt.Sprintf("Reloaded SynthDef %s", name)
+	} else {
+		logging.OSC.Warnf("Hot reload: SynthDef %s failed to reload", name)
+		m.Notice = fmt.Sprintf("SynthDef %s failed to reload, see the SuperCollider log", name)
+	}
+}
@@ -0,0 +1,183 @@
+package model
+
+import (
+	"time"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// GrooveTemplate moves the ticks of the playback clock off the straight grid
+type GrooveTemplate struct {
+	Name  string
+	Steps []float64 // Relative lengths of successive PPQ ticks, repeating (nil for straight time)
+}
+
+// GrooveTemplates are the choices of the song groove and of song row overrides. Swing makes the
+// first PPQ tick of every pair longer and the second shorter: 8th notes at PPQ 2, 16ths at PPQ 4.
+var GrooveTemplates = []GrooveTemplate{
+	{Name: "straight"},
+	{Name: "swing 54", Steps: []float64{54, 46}},
+	{Name: "swing 58", Steps: []float64{58, 42}},
+	{Name: "swing 62", Steps: []float64{62, 38}},
+	{Name: "triplet", Steps: []float64{2, 1}},
+}
+
+// GrooveName returns the name of a groove template ("straight" when out of range)
+func GrooveName(groove int) string {
+	if groove < 0 || groove >= len(GrooveTemplates) {
+		groove = 0
+	}
+	return GrooveTemplates[groove].Name
+}
+
+// SetSongRowBPM sets the tempo a song row plays at; 0 returns it to the song's tempo
+func (m *Model) SetSongRowBPM(row int, bpm float32) {
+	if row < 0 || row >= types.SongRows {
+		return
+	}
+	if bpm > 0 {
+		bpm = max(1, min(999, bpm))
+	} else {
+		bpm = 0
+	}
+	if m.SongRowTempos[row].BPM == bpm {
+		return
+	}
+	m.SongRowTempos[row].BPM = bpm
+	if bpm == 0 {
+		logging.Playback.Debugf("Song row %02X plays at the song tempo", row)
+	} else {
+		logging.Playback.Debugf("Song row %02X plays at %.2f BPM", row, bpm)
+	}
+	m.Publish(Event{Kind: EventSettings})
+}
+
+// SetSongRowGroove sets the groove a song row plays with: 1 + the index of a groove template,
+// or 0 for the song's groove
+func (m *Model) SetSongRowGroove(row, groove int) {
+	if row < 0 || row >= types.SongRows {
+		return
+	}
+	groove = max(0, min(len(GrooveTemplates), groove))
+	if m.SongRowTempos[row].Groove == groove {
+		return
+	}
+	m.SongRowTempos[row].Groove = groove
+	if groove == 0 {
+		logging.Playback.Debugf("Song row %02X plays with the song groove", row)
+	} else {
+		logging.Playback.Debugf("Song row %02X plays with groove %s", row, GrooveName(groove-1))
+	}
+	m.Publish(Event{Kind: EventSettings})
+}
+
+// HasSongRowTempos reports whether any song row overrides the tempo or groove
+func (m *Model) HasSongRowTempos() bool {
+	for _, tempo := range m.SongRowTempos {
+		if tempo != (types.SongRowTempo{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// tempoRow returns the song row whose overrides playback follows: the row of the first track
+// playing in song playback (-1 when the song is not playing)
+func (m *Model) tempoRow() int {
+	if !m.IsPlaying || m.PlaybackMode != types.SongView {
+		return -1
+	}
+	for track := 0; track < types.NumTracks; track++ {
+		if m.SongPlaybackActive[track] {
+			row := m.SongPlaybackRow[track]
+			if row < 0 || row >= types.SongRows {
+				return -1
+			}
+			return row
+		}
+	}
+	return -1
+}
+
+// PlaybackBPM returns the tempo playback runs at: that of the song row playing when it has
+// one, else the song's
+func (m *Model) PlaybackBPM() float32 {
+	if row := m.tempoRow(); row >= 0 && m.SongRowTempos[row].BPM > 0 {
+		return m.SongRowTempos[row].BPM
+	}
+	return m.BPM
+}
+
+// PlaybackGroove returns the index of the groove template playback runs with: that of the song
+// row playing when it has one, else the song's
+func (m *Model) PlaybackGroove() int {
+	groove := m.Groove
+	if row := m.tempoRow(); row >= 0 && m.SongRowTempos[row].Groove > 0 {
+		groove = m.SongRowTempos[row].Groove - 1
+	}
+	if groove < 0 || groove >= len(GrooveTemplates) {
+		return 0
+	}
+	return groove
+}
+
+// TickTempo is what the playback clock runs at from one of its ticks on
+type TickTempo struct {
+	BPM        float32
+	PPQ        int
+	Resolution int       // Clock ticks per PPQ tick
+	Groove     int       // Index into GrooveTemplates
+	Tick       int       // Tick it took over at
+	At         time.Time // When that tick fell (zero before playback's first tick is scheduled)
+}
+
+// TickTime returns when a tick of the playback clock falls. A change of tempo, PPQ, resolution or
+// groove, from the settings or the song row playing, takes over from the tick before the one asked
+// for, the last one played, so the ticks already played keep their times and the row that tick
+// started lasts as long as its own tempo says.
+func (m *Model) TickTime(tick int) time.Time {
+	tempo := TickTempo{BPM: m.PlaybackBPM(), PPQ: m.PPQ, Resolution: m.ClockResolution(), Groove: m.PlaybackGroove()}
+	running := m.PlaybackTempo
+	switch {
+	case running.At.IsZero():
+		tempo.At = m.PlaybackStartTime
+		m.PlaybackTempo = tempo
+	case running.BPM != tempo.BPM || running.PPQ != tempo.PPQ || running.Resolution != tempo.Resolution || running.Groove != tempo.Groove:
+		from := max(running.Tick, tick-1)
+		tempo.Tick, tempo.At = from, running.timeOf(from)
+		m.PlaybackTempo = tempo
+	}
+	return m.PlaybackTempo.timeOf(tick)
+}
+
+// timeOf returns when a tick falls at this tempo
+func (t TickTempo) timeOf(tick int) time.Time {
+	if t.BPM <= 0 || t.PPQ <= 0 || t.Resolution <= 0 {
+		return t.At
+	}
+	tickSeconds := 60 / (float64(t.BPM) * float64(t.PPQ*t.Resolution))
+	ticks := grooveTicks(t.Groove, tick, t.Resolution) - grooveTicks(t.Groove, t.Tick, t.Resolution)
+	return t.At.Add(time.Duration(ticks * tickSeconds * float64(time.Second)))
+}
+
+// grooveTicks returns where a clock tick falls under a groove, counted in straight clock ticks
+// from tick 0
+func grooveTicks(groove, tick, resolution int) float64 {
+	if groove < 0 || groove >= len(GrooveTemplates) || len(GrooveTemplates[groove].Steps) == 0 {
+		return float64(tick)
+	}
+	steps := GrooveTemplates[groove].Steps
+	total := 0.0
+	for _, length := range steps {
+		total += length
+	}
+	scale := float64(len(steps)) / total // Steps in PPQ ticks
+	ppqTick, sub := tick/resolution, tick%resolution
+	at := float64(ppqTick / len(steps) * len(steps))
+	for _, length := range steps[:ppqTick%len(steps)] {
+		at += length * scale
+	}
+	at += steps[ppqTick%len(steps)] * scale * float64(sub) / float64(resolution)
+	return at * float64(resolution)
+}
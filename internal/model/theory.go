@@ -0,0 +1,92 @@
+package model
+
+import (
+	"github.com/schollz/collidertracker/internal/modulation"
+	"github.com/schollz/collidertracker/internal/music"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// TheoryHelperLines is how many lines the chord and scale helper takes under the phrase
+const TheoryHelperLines = 3
+
+// TheoryHelp is what the chord and scale helper shows for the cursor row of an instrument phrase
+type TheoryHelp struct {
+	Notes    []int         // Notes the row plays (chord and stacked notes), nil without a note
+	Root     int           // Pitch class of the scale's root
+	Scale    string        // Scale name, as in the modulate settings (e.g. "minor")
+	Steps    []int         // Steps of the scale above its root
+	Modulate int           // Modulate setting the scale comes from, -1 when it was guessed
+	Guessed  bool          // Whether the scale was guessed from the phrase's notes
+	Known    bool          // Whether there is a scale at all (a phrase without notes has none)
+	Diatonic []music.Chord // Triads of the scale, nil for scales without seven notes
+	RowChord int           // Index in Diatonic of the triad the row plays, -1 if none
+	OutOfKey []bool        // For each of Notes, whether it is outside the scale
+}
+
+// ToggleTheoryHelper shows or hides the chord and scale helper in instrument phrases
+func (m *Model) ToggleTheoryHelper() {
+	m.TheoryHelper = !m.TheoryHelper
+}
+
+// TheoryHelperShown reports whether the chord and scale helper is on screen
+func (m *Model) TheoryHelperShown() bool {
+	return m.TheoryHelper && m.ViewMode == types.PhraseView && m.GetPhraseViewType() == types.InstrumentPhraseView
+}
+
+// rowNotes returns the notes a phrase row plays: its chord and stacked notes
+func (m *Model) rowNotes(track, phrase, row int) []int {
+	cells := m.phraseRow(track, phrase, row)
+	if cells == nil || cells[types.ColNote] == -1 {
+		return nil
+	}
+	chord := types.GetChordNotes(cells[types.ColNote], types.ChordType(cells[types.ColChord]),
+		types.ChordAddition(cells[types.ColChordAddition]), types.ChordTransposition(cells[types.ColChordTransposition]))
+	return types.AddStackedNotes(chord, cells[types.ColNote2], cells[types.ColNote3], cells[types.ColNote4])
+}
+
+// TheoryHelp works out the helper for the cursor row. The scale is the one the row's modulate
+// setting quantizes to; without one it is the major or minor key that fits the phrase best.
+func (m *Model) TheoryHelp() TheoryHelp {
+	track, phrase, row := m.CurrentTrack, m.CurrentPhrase, max(0, m.CurrentRow)
+	help := TheoryHelp{Notes: m.rowNotes(track, phrase, row), Modulate: -1, RowChord: -1}
+
+	if modulate := m.GetPhraseCell(track, phrase, row, types.ColModulate); modulate >= 0 && modulate < len(m.InstrumentModulateSettings) {
+		settings := m.InstrumentModulateSettings[modulate]
+		if scale, ok := modulation.Scales[settings.Scale]; ok && len(scale.Notes) < 12 {
+			help.Root, help.Scale, help.Steps, help.Modulate, help.Known = settings.ScaleRoot, settings.Scale, scale.Notes, modulate, true
+		}
+	}
+	if !help.Known {
+		var notes []int
+		for r := 0; r < types.PhraseRows; r++ {
+			notes = append(notes, m.rowNotes(track, phrase, r)...)
+		}
+		root, minor, ok := music.GuessKey(notes)
+		help.Root, help.Scale, help.Steps, help.Guessed, help.Known = root, "major", music.MajorScale, true, ok
+		if minor {
+			help.Scale, help.Steps = "minor", music.MinorScale
+		}
+	}
+	if !help.Known {
+		return help
+	}
+
+	help.Diatonic = music.DiatonicChords(help.Root, help.Steps)
+	help.OutOfKey = make([]bool, len(help.Notes))
+	for i, note := range help.Notes {
+		help.OutOfKey[i] = !music.InScale(note, help.Root, help.Steps)
+	}
+	if len(help.Notes) > 0 {
+		played := map[int]bool{}
+		for _, note := range help.Notes {
+			played[((note%12)+12)%12] = true
+		}
+		for i, chord := range help.Diatonic {
+			classes := chord.PitchClasses()
+			if classes[0] == ((help.Notes[0]%12)+12)%12 && played[classes[1]] && played[classes[2]] {
+				help.RowChord = i
+			}
+		}
+	}
+	return help
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/schollz/collidertracker/internal/logging"
+)
+
+// ArmTimedStart arms playback to start from the top of the song at a moment
+func (m *Model) ArmTimedStart(at time.Time) {
+	m.TimedStart = at
+	logging.Playback.Debugf("Timed start armed for %s", at.Format("15:04:05.000"))
+}
+
+// DisarmTimedStart cancels an armed timed start
+func (m *Model) DisarmTimedStart() {
+	m.TimedStart = time.Time{}
+}
+
+// TimedStartArmed reports whether playback is armed to start at a moment
+func (m *Model) TimedStartArmed() bool {
+	return !m.TimedStart.IsZero()
+}
+
+// BeatDuration returns the length of a beat at the song tempo
+func (m *Model) BeatDuration() time.Duration {
+	if m.BPM <= 0 {
+		return 0
+	}
+	return time.Duration(float64(time.Minute) / float64(m.BPM))
+}
+
+// PreRollBeatAt returns when a count-in beat falls: beat n is n beats before the timed start
+func (m *Model) PreRollBeatAt(beat int) time.Time {
+	return m.TimedStart.Add(-time.Duration(beat) * m.BeatDuration())
+}
+
+// TimedStartCount returns what an armed timed start counts at a moment: the time left before
+// the count-in, or once the count-in has begun the beat being counted (PreRoll down to 1)
+func (m *Model) TimedStartCount(now time.Time) (left time.Duration, beat int, ok bool) {
+	if !m.TimedStartArmed() {
+		return 0, 0, false
+	}
+	for beat := m.PreRoll; beat >= 1; beat-- {
+		if !now.Before(m.PreRollBeatAt(beat)) && now.Before(m.PreRollBeatAt(beat-1)) {
+			return 0, beat, true
+		}
+	}
+	return max(0, m.PreRollBeatAt(m.PreRoll).Sub(now)), 0, true
+}
+
+// TimedStartClick flashes a count-in beat and sends it as a MIDI sync metronome note, the bell on the first
+func (m *Model) TimedStartClick(beat int) {
+	m.flashBeat((m.PreRoll-beat)%midiSyncBeatsPerBar + 1)
+	if !m.midiSyncSends(false) {
+		return
+	}
+	note := MidiSyncClickNote
+	if beat == m.PreRoll {
+		note = MidiSyncBellNote
+	}
+	m.sendMidiSyncNote(note)
+}
+
+// ParseStartTime reads when a timed start is due: a countdown such as "90s" or "2m", or a
+// wall-clock time "15:04" or "15:04:05", taken as its next occurrence
+func ParseStartTime(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("countdown %q is negative", s)
+		}
+		return now.Add(d), nil
+	}
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		clock, err := time.ParseInLocation(layout, s, now.Location())
+		if err != nil {
+			continue
+		}
+		at := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, now.Location())
+		if !at.After(now) {
+			at = at.AddDate(0, 0, 1)
+		}
+		return at, nil
+	}
+	return time.Time{}, fmt.Errorf("start time %q is neither a countdown (90s) nor a time of day (15:04 or 15:04:05)", s)
+}
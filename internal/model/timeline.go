@@ -0,0 +1,153 @@
+package model
+
+import (
+	"github.com/schollz/collidertracker/internal/ticks"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// TimelineBeatsPerBar is the bar length of the timeline ruler and the song position
+const TimelineBeatsPerBar = 4
+
+// TimelineBlock is a song cell placed on the timeline
+type TimelineBlock struct {
+	Row     int     // Song row
+	Chain   int     // Chain ID
+	Start   float64 // Seconds from the start of one pass through the song
+	Seconds float64 // Duration of the chain at the current BPM
+}
+
+// Timeline lays each track's song cells end to end in play order, sized by chain duration.
+// Cells whose chain plays nothing are skipped, as in song playback.
+func (m *Model) Timeline() [types.NumTracks][]TimelineBlock {
+	var lanes [types.NumTracks][]TimelineBlock
+	if m.BPM <= 0 || m.PPQ <= 0 {
+		return lanes
+	}
+	ticksPerSecond := float64(m.BPM) / 60.0 * float64(m.PPQ)
+	for track := 0; track < types.NumTracks; track++ {
+		chainsData := m.GetChainsDataForTrack(track)
+		phrasesData := m.GetPhrasesDataForTrack(track)
+		start := 0.0
+		for row := 0; row < types.SongRows; row++ {
+			chain := m.GetSongCell(track, row)
+			if chain < 0 {
+				continue
+			}
+			chainTicks := ticks.CalculateChainTicks(chainsData, phrasesData, chain)
+			if chainTicks == 0 {
+				continue
+			}
+			seconds := float64(chainTicks) / (ticksPerSecond * float64(m.TrackResolution(track)))
+			lanes[track] = append(lanes[track], TimelineBlock{Row: row, Chain: chain, Start: start, Seconds: seconds})
+			start += seconds
+		}
+	}
+	return lanes
+}
+
+// TimelineSeconds returns the length of the longest timeline lane
+func TimelineSeconds(lanes [types.NumTracks][]TimelineBlock) float64 {
+	longest := 0.0
+	for _, lane := range lanes {
+		if len(lane) == 0 {
+			continue
+		}
+		if end := lane[len(lane)-1].Start + lane[len(lane)-1].Seconds; end > longest {
+			longest = end
+		}
+	}
+	return longest
+}
+
+// TimelineBlockIndex returns the index of the block at a song row in a lane (-1 when there is none)
+func TimelineBlockIndex(lane []TimelineBlock, row int) int {
+	for i, block := range lane {
+		if block.Row == row {
+			return i
+		}
+	}
+	return -1
+}
+
+// TimelineBlockAt returns the index of the block playing at a time in a lane: the last block
+// starting at or before it (-1 for an empty lane)
+func TimelineBlockAt(lane []TimelineBlock, seconds float64) int {
+	if len(lane) == 0 {
+		return -1
+	}
+	index := 0
+	for i, block := range lane {
+		if block.Start <= seconds {
+			index = i
+		}
+	}
+	return index
+}
+
+// SongPosition returns how far song playback is into one pass through the song and the song's
+// length, in seconds. The position follows the playing track with the longest lane, since that
+// track spans the whole song; ok is false when the song is not playing.
+func (m *Model) SongPosition() (position, length float64, ok bool) {
+	if !m.IsPlaying || m.PlaybackMode != types.SongView || m.BPM <= 0 || m.PPQ <= 0 {
+		return 0, 0, false
+	}
+	lanes := m.Timeline()
+	track := m.songPositionTrack(lanes)
+	if track == -1 {
+		return 0, 0, false
+	}
+	index := TimelineBlockIndex(lanes[track], m.SongPlaybackRow[track])
+	if index == -1 {
+		return 0, TimelineSeconds(lanes), true
+	}
+
+	// Ticks played in the chain: earlier phrases, earlier rows and the part of the playing row
+	phrasesData := m.GetPhrasesDataForTrack(track)
+	chain, phrase := m.SongPlaybackChain[track], m.SongPlaybackPhrase[track]
+	trackTicks := 0
+	for row := 0; row < m.SongPlaybackChainRow[track]; row++ {
+		if phraseID := m.GetChainCell(track, chain, row); phraseID != -1 {
+			trackTicks += ticks.CalculatePhraseTicks(phrasesData, phraseID)
+		}
+	}
+	for row := 0; row < m.SongPlaybackRowInPhrase[track]; row++ {
+		if dt := m.GetPhraseCell(track, phrase, row, types.ColDeltaTime); dt > 0 {
+			trackTicks += dt
+		}
+	}
+	clockTicks := 0
+	if dt := m.GetPhraseCell(track, phrase, m.SongPlaybackRowInPhrase[track], types.ColDeltaTime); dt > 0 {
+		clockTicks = max(0, m.TrackTicks(track, dt)-m.SongPlaybackTicksLeft[track])
+	}
+
+	ticksPerSecond := float64(m.BPM) / 60.0 * float64(m.PPQ)
+	block := lanes[track][index]
+	position = block.Start +
+		float64(trackTicks)/(ticksPerSecond*float64(m.TrackResolution(track))) +
+		float64(clockTicks)/(ticksPerSecond*float64(m.ClockResolution()))
+	return min(position, block.Start+block.Seconds), TimelineSeconds(lanes), true
+}
+
+// songPositionTrack returns the playing track with the longest lane (-1 when none is playing)
+func (m *Model) songPositionTrack(lanes [types.NumTracks][]TimelineBlock) int {
+	track, longest := -1, 0.0
+	for t, lane := range lanes {
+		if !m.SongPlaybackActive[t] || len(lane) == 0 {
+			continue
+		}
+		if end := lane[len(lane)-1].Start + lane[len(lane)-1].Seconds; end > longest {
+			track, longest = t, end
+		}
+	}
+	return track
+}
+
+// BarsBeatsTicks converts a song position in seconds into 1-based bars, beats and PPQ ticks
+// at the current tempo
+func (m *Model) BarsBeatsTicks(seconds float64) (bar, beat, tick int) {
+	if m.PPQ <= 0 || m.BPM <= 0 || seconds < 0 {
+		return 1, 1, 1
+	}
+	ppqTicks := int(seconds*float64(m.BPM)/60.0*float64(m.PPQ) + 1e-6) // Guard against rounding just below a tick
+	return ppqTicks/(m.PPQ*TimelineBeatsPerBar) + 1, ppqTicks/m.PPQ%TimelineBeatsPerBar + 1, ppqTicks%m.PPQ + 1
+}
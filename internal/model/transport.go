@@ -0,0 +1,26 @@
+package model
+
+import "github.com/schollz/collidertracker/internal/types"
+
+// TransportState returns where playback is, for resuming it when the project is opened
+// again; nil when stopped
+func (m *Model) TransportState() *types.TransportState {
+	if !m.IsPlaying {
+		return nil
+	}
+	state := &types.TransportState{
+		Mode:     m.PlaybackMode,
+		Track:    m.CurrentTrack,
+		Chain:    m.PlaybackChain,
+		ChainRow: m.PlaybackChainRow,
+		Phrase:   m.PlaybackPhrase,
+		Row:      m.PlaybackRow,
+	}
+	for track := range state.SongRows {
+		state.SongRows[track] = -1
+		if m.PlaybackMode == types.SongView && m.SongPlaybackActive[track] {
+			state.SongRows[track] = m.SongPlaybackRow[track]
+		}
+	}
+	return state
+}
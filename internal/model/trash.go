@@ -0,0 +1,52 @@
+package model
+
+import "github.com/schollz/collidertracker/internal/logging"
+
+// maxTrashEntries caps how many deleted items are kept for restoring
+const maxTrashEntries = 64
+
+// ConfirmPrompt is a pending yes/no question for a destructive operation
+type ConfirmPrompt struct {
+	Message   string // Question shown in the footer
+	OnConfirm func() // Runs when the user answers yes
+	OnDecline func() // Runs when the user answers no (nil: no cancels like any other key)
+	Quit      bool   // Quit once the user answers yes or no
+	// Slow work run in the background after OnConfirm; its result comes back to the UI as a
+	// message (nil for none)
+	Background func() any
+}
+
+// TrashEntry keeps enough state to undo a single destructive operation
+type TrashEntry struct {
+	Label   string // Human readable description of what was deleted
+	Restore func() // Puts the deleted data back
+}
+
+// PushTrash records a deleted item so it can be restored until the next save
+func (m *Model) PushTrash(label string, restore func()) {
+	m.Trash = append(m.Trash, TrashEntry{Label: label, Restore: restore})
+	if len(m.Trash) > maxTrashEntries {
+		m.Trash = m.Trash[len(m.Trash)-maxTrashEntries:]
+	}
+	logging.Storage.Debugf("Moved to trash: %s (%d items)", label, len(m.Trash))
+}
+
+// RestoreLastTrash restores the most recently deleted item and returns its label
+func (m *Model) RestoreLastTrash() (string, bool) {
+	if len(m.Trash) == 0 {
+		return "", false
+	}
+	entry := m.Trash[len(m.Trash)-1]
+	m.Trash = m.Trash[:len(m.Trash)-1]
+	entry.Restore()
+	logging.Storage.Debugf("Restored from trash: %s", entry.Label)
+	return entry.Label, true
+}
+
+// ClearTrash empties the trash, deleted items can no longer be restored
+func (m *Model) ClearTrash() {
+	if len(m.Trash) > 0 {
+		logging.Storage.Debugf("Emptied trash (%d items)", len(m.Trash))
+	}
+	m.Trash = nil
+}
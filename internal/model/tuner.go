@@ -0,0 +1,70 @@
+package model
+
+import (
+	"math"
+	"time"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// Tuner reference pitch of A4 in Hz
+const (
+	TunerDefaultReference = 440
+	TunerMinReference     = 415 // A semitone below 440, for baroque pitch
+	TunerMaxReference     = 466 // A semitone above
+)
+
+// TunerHold is how long the tuner keeps showing a pitch after the input goes quiet or unpitched
+const TunerHold = 500 * time.Millisecond
+
+// tunerReading is the input's last steady pitch reading
+type tunerReading struct {
+	freq float64
+	at   time.Time
+}
+
+// TuneNote returns the note nearest to a frequency and how many cents sharp (positive) or flat
+// it is, with A4 tuned to reference Hz. note is -1 when the frequency is out of the MIDI range.
+func TuneNote(freq, reference float64) (note int, cents float64) {
+	if freq <= 0 || reference <= 0 {
+		return -1, 0
+	}
+	pitch := 69 + 12*math.Log2(freq/reference)
+	note = int(math.Round(pitch))
+	if note < 0 || note > 127 {
+		return -1, 0
+	}
+	return note, (pitch - float64(note)) * 100
+}
+
+// TunerPitch returns what the tuner shows: the input's last steady pitch, the note nearest to
+// it and how far off that note it is in cents. ok is false once the input has been quiet or
+// unpitched for TunerHold.
+func (m *Model) TunerPitch(now time.Time) (freq float64, note int, cents float64, ok bool) {
+	m.pitchTracker.mu.Lock()
+	reading := m.pitchTracker.tuner
+	m.pitchTracker.mu.Unlock()
+	if reading.freq <= 0 || now.Sub(reading.at) > TunerHold {
+		return 0, -1, 0, false
+	}
+	note, cents = TuneNote(reading.freq, float64(m.TunerReference))
+	return reading.freq, note, cents, note != -1
+}
+
+// SetTunerReference sets the pitch of A4 the tuner measures against, within the tuner's range
+func (m *Model) SetTunerReference(hz int) {
+	hz = max(TunerMinReference, min(TunerMaxReference, hz))
+	if hz == m.TunerReference {
+		return
+	}
+	m.TunerReference = hz
+	logging.UI.Debugf("Tuner reference: A4 = %d Hz", hz)
+	m.Publish(Event{Kind: EventSettings})
+}
+
+// pitchReports reports whether SuperCollider should send pitch readings of the input: for
+// pitch tracking, or for the tuner while it is shown
+func (m *Model) pitchReports() bool {
+	return m.PitchTracking || m.ViewMode == types.TunerView
+}
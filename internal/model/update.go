@@ -0,0 +1,49 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/schollz/collidertracker/internal/update"
+)
+
+// AvailableUpdate returns the newest release newer than the running version, if the last
+// update check found one
+func (m *Model) AvailableUpdate() (update.Release, bool) {
+	newer := update.NewerReleases(m.Releases, m.Version)
+	if len(newer) == 0 || newer[0].Tag == m.UpdateInstalled {
+		return update.Release{}, false
+	}
+	return newer[0], true
+}
+
+// ChangelogLines lays out the notes of the releases found by the last update check for the
+// changelog view: a heading per release, its notes indented below it
+func (m *Model) ChangelogLines() []string {
+	var lines []string
+	for i, release := range m.Releases {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		heading := release.Tag
+		if !release.Published.IsZero() {
+			heading += "  " + release.Published.Format("2006-01-02")
+		}
+		if release.Name != "" && release.Name != release.Tag {
+			heading += "  " + release.Name
+		}
+		lines = append(lines, heading)
+		notes := strings.TrimSpace(strings.ReplaceAll(release.Notes, "\r", ""))
+		if notes == "" {
+			continue
+		}
+		for _, line := range strings.Split(notes, "\n") {
+			lines = append(lines, "  "+line)
+		}
+	}
+	return lines
+}
+
+// ScrollChangelog moves the changelog view by a number of lines, staying within the notes
+func (m *Model) ScrollChangelog(lines int) {
+	m.ChangelogScroll = max(0, min(m.ChangelogScroll+lines, len(m.ChangelogLines())-1))
+}
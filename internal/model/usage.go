@@ -0,0 +1,244 @@
+package model
+
+import (
+	"slices"
+
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// SlotUsage is how a chain or phrase ID of a pool is used
+type SlotUsage int
+
+const (
+	SlotEmpty        SlotUsage = iota // Nothing in it
+	SlotUnreferenced                  // Has data, but no song cell (chains) or chain (phrases) points at it
+	SlotUsed                          // Referenced from the song (chains) or from a chain (phrases)
+)
+
+// poolChains returns the chains of the instrument or sampler pool
+func (m *Model) poolChains(sampler bool) [][]int {
+	if sampler {
+		return m.SamplerChainsData
+	}
+	return m.InstrumentChainsData
+}
+
+// poolPhrases returns the phrases of the instrument or sampler pool
+func (m *Model) poolPhrases(sampler bool) *[types.NumPhrases][][]int {
+	if sampler {
+		return &m.SamplerPhrasesData
+	}
+	return &m.InstrumentPhrasesData
+}
+
+// chainHasData reports whether a chain of a pool has a phrase, transpose or effect override
+func (m *Model) chainHasData(sampler bool, chain int) bool {
+	transposes, fx := m.InstrumentChainTransposes, m.InstrumentChainFX
+	if sampler {
+		transposes, fx = m.SamplerChainTransposes, m.SamplerChainFX
+	}
+	for row := 0; row < types.ChainRows; row++ {
+		if m.poolChains(sampler)[chain][row] != -1 || transposes[chain][row] != 0 || fx[chain][row] != types.NoChainFX {
+			return true
+		}
+	}
+	return false
+}
+
+// phraseHasData reports whether a phrase of a pool has a row that plays
+func (m *Model) phraseHasData(sampler bool, phrase int) bool {
+	for _, row := range m.poolPhrases(sampler)[phrase] {
+		if row[types.ColDeltaTime] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// poolChainOrder returns the chains of a pool in the order the song uses them, one track
+// after the other, followed by the chains that have data but are not in the song
+func (m *Model) poolChainOrder(sampler bool) []int {
+	var order []int
+	for track := 0; track < types.NumTracks; track++ {
+		if m.TrackTypes[track] != sampler {
+			continue
+		}
+		for row := 0; row < types.SongRows; row++ {
+			if chain := m.SongData[track][row]; chain >= 0 && chain < types.NumChains && !slices.Contains(order, chain) {
+				order = append(order, chain)
+			}
+		}
+	}
+	for chain := 0; chain < types.NumChains; chain++ {
+		if !slices.Contains(order, chain) && m.chainHasData(sampler, chain) {
+			order = append(order, chain)
+		}
+	}
+	return order
+}
+
+// poolPhraseOrder returns the phrases of a pool in the order the chains, listed in order, use
+// them, followed by the phrases that have data but are in no chain
+func (m *Model) poolPhraseOrder(sampler bool, chainOrder []int) []int {
+	var order []int
+	chains := m.poolChains(sampler)
+	for _, chain := range chainOrder {
+		for _, phrase := range chains[chain] {
+			if phrase >= 0 && phrase < types.NumPhrases && !slices.Contains(order, phrase) {
+				order = append(order, phrase)
+			}
+		}
+	}
+	for phrase := 0; phrase < types.NumPhrases; phrase++ {
+		if !slices.Contains(order, phrase) && m.phraseHasData(sampler, phrase) {
+			order = append(order, phrase)
+		}
+	}
+	return order
+}
+
+// ChainUsage returns how each chain ID of the instrument or sampler pool is used
+func (m *Model) ChainUsage(sampler bool) []SlotUsage {
+	usage := make([]SlotUsage, types.NumChains)
+	for chain := range usage {
+		if m.chainHasData(sampler, chain) {
+			usage[chain] = SlotUnreferenced
+		}
+	}
+	for track := 0; track < types.NumTracks; track++ {
+		if m.TrackTypes[track] != sampler {
+			continue
+		}
+		for row := 0; row < types.SongRows; row++ {
+			if chain := m.SongData[track][row]; chain >= 0 && chain < types.NumChains {
+				usage[chain] = SlotUsed
+			}
+		}
+	}
+	return usage
+}
+
+// PhraseUsage returns how each phrase ID of the instrument or sampler pool is used
+func (m *Model) PhraseUsage(sampler bool) []SlotUsage {
+	usage := make([]SlotUsage, types.NumPhrases)
+	for phrase := range usage {
+		if m.phraseHasData(sampler, phrase) {
+			usage[phrase] = SlotUnreferenced
+		}
+	}
+	for _, chain := range m.poolChains(sampler) {
+		for _, phrase := range chain {
+			if phrase >= 0 && phrase < types.NumPhrases {
+				usage[phrase] = SlotUsed
+			}
+		}
+	}
+	return usage
+}
+
+// compactionMap renumbers the listed IDs contiguously from the start of each bank, in order,
+// and moves the IDs that are not listed after them. Every ID stays in its bank, so the result
+// is a permutation old ID -> new ID.
+func (m *Model) compactionMap(order []int, size int) []int {
+	mapping := make([]int, size)
+	for id := range mapping {
+		mapping[id] = -1
+	}
+	for track := 0; track < types.NumTracks; track++ {
+		first, last := m.BankRange(track)
+		if !m.PerTrackBanks && track > 0 {
+			break // One bank covers the shared pool
+		}
+		last = min(last, size-1)
+		next := first
+		for _, id := range order {
+			if id >= first && id <= last {
+				mapping[id] = next
+				next++
+			}
+		}
+		for id := first; id <= last; id++ {
+			if mapping[id] == -1 {
+				mapping[id] = next
+				next++
+			}
+		}
+	}
+	return mapping
+}
+
+// CompactionMaps returns how compacting a pool would renumber its chains and phrases (old ID
+// -> new ID): chains in song order, then phrases in the order those chains use them
+func (m *Model) CompactionMaps(sampler bool) ([]int, []int) {
+	chainOrder := m.poolChainOrder(sampler)
+	return m.compactionMap(chainOrder, types.NumChains), m.compactionMap(m.poolPhraseOrder(sampler, chainOrder), types.NumPhrases)
+}
+
+// InvertMap returns the inverse of a renumbering permutation
+func InvertMap(mapping []int) []int {
+	inverse := make([]int, len(mapping))
+	for old, id := range mapping {
+		inverse[id] = old
+	}
+	return inverse
+}
+
+// RenumberPool moves the chains and phrases of the instrument or sampler pool to new IDs
+// (old ID -> new ID, both permutations) and updates the song, the chains, parameter locks
+// and the chain and phrase being edited to match
+func (m *Model) RenumberPool(sampler bool, chainMap, phraseMap []int) {
+	renumber := func(id int, mapping []int) int {
+		if id < 0 || id >= len(mapping) {
+			return id
+		}
+		return mapping[id]
+	}
+
+	chains, transposes, fx := &m.InstrumentChainsData, &m.InstrumentChainTransposes, &m.InstrumentChainFX
+	if sampler {
+		chains, transposes, fx = &m.SamplerChainsData, &m.SamplerChainTransposes, &m.SamplerChainFX
+	}
+	newChains := make([][]int, len(*chains))
+	newTransposes := make([][]int, len(*transposes))
+	newFX := make([][]types.ChainFX, len(*fx))
+	for old, id := range chainMap {
+		newChains[id], newTransposes[id], newFX[id] = (*chains)[old], (*transposes)[old], (*fx)[old]
+	}
+	*chains, *transposes, *fx = newChains, newTransposes, newFX
+	for _, chain := range *chains {
+		for row := range chain {
+			chain[row] = renumber(chain[row], phraseMap)
+		}
+	}
+
+	phrases := m.poolPhrases(sampler)
+	var newPhrases [types.NumPhrases][][]int
+	for old, id := range phraseMap {
+		newPhrases[id] = phrases[old]
+	}
+	*phrases = newPhrases
+
+	names := m.poolNames(sampler)
+	names.Chains, names.Phrases = renameIDs(names.Chains, chainMap), renameIDs(names.Phrases, phraseMap)
+
+	for track := 0; track < types.NumTracks; track++ {
+		if m.TrackTypes[track] != sampler {
+			continue
+		}
+		for row := 0; row < types.SongRows; row++ {
+			m.SongData[track][row] = renumber(m.SongData[track][row], chainMap)
+		}
+	}
+	if !sampler {
+		plocks := make(map[PLockRow]map[string]float32, len(m.PLocks))
+		for key, params := range m.PLocks {
+			plocks[PLockRow{Phrase: renumber(key.Phrase, phraseMap), Row: key.Row}] = params
+		}
+		m.PLocks = plocks
+	}
+	if m.CurrentTrack >= 0 && m.CurrentTrack < types.NumTracks && m.TrackTypes[m.CurrentTrack] == sampler {
+		m.CurrentChain = renumber(m.CurrentChain, chainMap)
+		m.CurrentPhrase = renumber(m.CurrentPhrase, phraseMap)
+	}
+	m.Publish(Event{Kind: EventSettings})
+}
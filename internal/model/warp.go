@@ -0,0 +1,253 @@
+package model
+
+import (
+	"math"
+	"sort"
+
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// warpMinGap keeps warp markers apart in time (seconds) so every stretch between two has a tempo
+const warpMinGap = 0.001
+
+// warpSourceBPM returns the tempo a file plays at outside its warp markers
+func warpSourceBPM(metadata types.FileMetadata) float64 {
+	if metadata.BPM > 0 {
+		return float64(metadata.BPM)
+	}
+	return 120.0
+}
+
+// WarpBeatAt returns the beat a time in a file plays on. Between warp markers the beat is
+// interpolated, and before the first or after the last marker it follows the file BPM.
+func WarpBeatAt(metadata types.FileMetadata, time float64) float64 {
+	beatsPerSecond := warpSourceBPM(metadata) / 60.0
+	warp := metadata.Warp
+	if len(warp) == 0 {
+		return time * beatsPerSecond
+	}
+	if time <= warp[0].Time {
+		return warp[0].Beat + (time-warp[0].Time)*beatsPerSecond
+	}
+	for i := 1; i < len(warp); i++ {
+		if time <= warp[i].Time {
+			prev, next := warp[i-1], warp[i]
+			return prev.Beat + (time-prev.Time)/(next.Time-prev.Time)*(next.Beat-prev.Beat)
+		}
+	}
+	last := warp[len(warp)-1]
+	return last.Beat + (time-last.Time)*beatsPerSecond
+}
+
+// WarpTimeAt returns the time in a file that plays on a beat, the inverse of WarpBeatAt
+func WarpTimeAt(metadata types.FileMetadata, beat float64) float64 {
+	secondsPerBeat := 60.0 / warpSourceBPM(metadata)
+	warp := metadata.Warp
+	if len(warp) == 0 {
+		return beat * secondsPerBeat
+	}
+	if beat <= warp[0].Beat {
+		return warp[0].Time + (beat-warp[0].Beat)*secondsPerBeat
+	}
+	for i := 1; i < len(warp); i++ {
+		if beat <= warp[i].Beat {
+			prev, next := warp[i-1], warp[i]
+			return prev.Time + (beat-prev.Beat)/(next.Beat-prev.Beat)*(next.Time-prev.Time)
+		}
+	}
+	last := warp[len(warp)-1]
+	return last.Time + (beat-last.Beat)*secondsPerBeat
+}
+
+// WarpedBPM returns the tempo a stretch of a file is played at according to its warp markers,
+// so playing it synced to the song BPM locks the stretch to the grid
+func WarpedBPM(metadata types.FileMetadata, start, end float64) float32 {
+	if len(metadata.Warp) == 0 || end <= start {
+		return metadata.BPM
+	}
+	beats := WarpBeatAt(metadata, end) - WarpBeatAt(metadata, start)
+	if beats <= 0 {
+		return metadata.BPM
+	}
+	return float32(60.0 * beats / (end - start))
+}
+
+// WarpGridOnsets returns the time of every whole beat in a file of the given duration,
+// following its warp markers
+func WarpGridOnsets(metadata types.FileMetadata, duration float64) []float64 {
+	var onsets []float64
+	last := WarpBeatAt(metadata, duration)
+	for beat := math.Ceil(WarpBeatAt(metadata, 0)); beat < last; beat++ {
+		onsets = append(onsets, math.Max(0, WarpTimeAt(metadata, beat)))
+	}
+	return onsets
+}
+
+// warpNeighbours returns the times and beats a warp marker must stay strictly between
+func (m *Model) warpNeighbours(warp []types.WarpMarker, i int) (minTime, maxTime, minBeat, maxBeat float64) {
+	minTime, maxTime = 0, m.WaveformDuration
+	minBeat, maxBeat = math.Inf(-1), math.Inf(1)
+	if i > 0 {
+		minTime, minBeat = warp[i-1].Time+warpMinGap, warp[i-1].Beat
+	}
+	if i < len(warp)-1 {
+		maxTime, maxBeat = warp[i+1].Time-warpMinGap, warp[i+1].Beat
+	}
+	return
+}
+
+// selectedWarp returns the metadata of the waveform file and whether a warp marker of it is selected
+func (m *Model) selectedWarp() (types.FileMetadata, bool) {
+	metadata, exists := m.FileMetadata[m.WaveformFile]
+	return metadata, exists && m.WaveformSelectedWarp >= 0 && m.WaveformSelectedWarp < len(metadata.Warp)
+}
+
+// AddWarpMarker adds a warp marker at the selected slice marker, or at the midpoint of the
+// view, pinned to the nearest whole beat, and selects it
+func (m *Model) AddWarpMarker() {
+	file := m.WaveformFile
+	if file == "" {
+		return
+	}
+
+	metadata, exists := m.FileMetadata[file]
+	if !exists {
+		metadata = types.FileMetadata{
+			BPM:         120.0,
+			Slices:      16,
+			Playthrough: 0,
+			SyncToBPM:   1,
+			SliceType:   0,
+			Onsets:      []float64{},
+		}
+	}
+
+	time := (m.WaveformStart + m.WaveformEnd) / 2.0
+	if m.WaveformSelectedSlice >= 0 && m.WaveformSelectedSlice < len(metadata.Onsets) {
+		time = metadata.Onsets[m.WaveformSelectedSlice]
+	}
+
+	// Select the marker already there rather than stacking a second one
+	for i, marker := range metadata.Warp {
+		if math.Abs(marker.Time-time) < warpMinGap {
+			m.WaveformSelectedWarp = i
+			m.WaveformSelectedSlice = -1
+			return
+		}
+	}
+
+	beat := WarpBeatAt(metadata, time)
+	i := sort.Search(len(metadata.Warp), func(i int) bool { return metadata.Warp[i].Time > time })
+	warp := append(append(append([]types.WarpMarker{}, metadata.Warp[:i]...), types.WarpMarker{Time: time, Beat: beat}), metadata.Warp[i:]...)
+
+	// Snap to the nearest beat when that keeps the beats in order
+	_, _, minBeat, maxBeat := m.warpNeighbours(warp, i)
+	if rounded := math.Round(beat); rounded > minBeat && rounded < maxBeat {
+		warp[i].Beat = rounded
+	}
+
+	metadata.Warp = warp
+	m.FileMetadata[file] = metadata
+	m.WaveformSelectedWarp = i
+	m.WaveformSelectedSlice = -1
+}
+
+// DeleteSelectedWarpMarker deletes the selected warp marker
+func (m *Model) DeleteSelectedWarpMarker() {
+	metadata, ok := m.selectedWarp()
+	if !ok {
+		return
+	}
+
+	metadata.Warp = append(append([]types.WarpMarker{}, metadata.Warp[:m.WaveformSelectedWarp]...), metadata.Warp[m.WaveformSelectedWarp+1:]...)
+	if len(metadata.Warp) == 0 {
+		metadata.Warp = nil
+		m.WaveformSelectedWarp = -1
+	} else if m.WaveformSelectedWarp >= len(metadata.Warp) {
+		m.WaveformSelectedWarp = len(metadata.Warp) - 1
+	}
+	m.FileMetadata[m.WaveformFile] = metadata
+}
+
+// SelectNextWarpMarker selects the next visible warp marker
+func (m *Model) SelectNextWarpMarker() {
+	metadata := m.FileMetadata[m.WaveformFile]
+	var visible []int
+	for i, marker := range metadata.Warp {
+		if marker.Time >= m.WaveformStart && marker.Time <= m.WaveformEnd {
+			visible = append(visible, i)
+		}
+	}
+	if len(visible) == 0 {
+		m.WaveformSelectedWarp = -1
+		return
+	}
+
+	m.WaveformSelectedSlice = -1
+	for _, i := range visible {
+		if i > m.WaveformSelectedWarp {
+			m.WaveformSelectedWarp = i
+			return
+		}
+	}
+	m.WaveformSelectedWarp = visible[0]
+}
+
+// JogWarpMarker moves the selected warp marker in time, keeping its beat, so the audio around
+// it stretches; it stays between its neighbours
+func (m *Model) JogWarpMarker(direction float64, fast bool) {
+	metadata, ok := m.selectedWarp()
+	if !ok {
+		return
+	}
+
+	stepPercent := 0.005 // 0.5%
+	if fast {
+		stepPercent = 0.05 // 5%
+	}
+	step := (m.WaveformEnd - m.WaveformStart) * stepPercent * direction
+
+	warp := append([]types.WarpMarker{}, metadata.Warp...)
+	minTime, maxTime, _, _ := m.warpNeighbours(warp, m.WaveformSelectedWarp)
+	warp[m.WaveformSelectedWarp].Time = math.Max(minTime, math.Min(maxTime, warp[m.WaveformSelectedWarp].Time+step))
+	metadata.Warp = warp
+	m.FileMetadata[m.WaveformFile] = metadata
+}
+
+// NudgeWarpBeat moves the beat of the selected warp marker, unless that would pass a neighbour's beat
+func (m *Model) NudgeWarpBeat(delta float64) {
+	metadata, ok := m.selectedWarp()
+	if !ok {
+		return
+	}
+
+	warp := append([]types.WarpMarker{}, metadata.Warp...)
+	_, _, minBeat, maxBeat := m.warpNeighbours(warp, m.WaveformSelectedWarp)
+	beat := warp[m.WaveformSelectedWarp].Beat + delta
+	if beat <= minBeat || beat >= maxBeat {
+		return
+	}
+	warp[m.WaveformSelectedWarp].Beat = beat
+	metadata.Warp = warp
+	m.FileMetadata[m.WaveformFile] = metadata
+}
+
+// SliceToWarpGrid replaces the slice markers of the waveform file with one per whole beat of
+// its warped grid, and reports whether there was a grid to slice to
+func (m *Model) SliceToWarpGrid() bool {
+	metadata, exists := m.FileMetadata[m.WaveformFile]
+	if !exists || len(metadata.Warp) == 0 || m.WaveformDuration <= 0 {
+		return false
+	}
+	onsets := WarpGridOnsets(metadata, m.WaveformDuration)
+	if len(onsets) == 0 {
+		return false
+	}
+
+	metadata.Onsets = onsets
+	metadata.Slices = len(onsets)
+	metadata.SliceType = 1 // Onsets mode, as when editing markers by hand
+	m.FileMetadata[m.WaveformFile] = metadata
+	m.WaveformSelectedSlice = -1
+	return true
+}
@@ -207,9 +207,11 @@ func (m *Model) ZoomWaveformView(zoomIn bool) {
 
 				// Progressively move center towards selected slice (30% per zoom)
 				// This creates a smooth centering effect over multiple zoom operations
-				center = center + (selectedSliceTime - center) * 0.3
+				center = center + (selectedSliceTime-center)*0.3
 			}
 		}
+	} else if metadata, ok := m.selectedWarp(); ok {
+		center = center + (metadata.Warp[m.WaveformSelectedWarp].Time-center)*0.3
 	}
 
 	var newDuration float64
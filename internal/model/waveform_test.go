@@ -232,3 +232,75 @@ func TestGetCurrentTrackFile(t *testing.T) {
 		assert.Equal(t, "", file, "Should return empty string when no file is assigned")
 	})
 }
+
+// TestWarpMarkers tests mapping sample times to beats and editing warp markers
+func TestWarpMarkers(t *testing.T) {
+	metadata := types.FileMetadata{BPM: 120.0}
+
+	t.Run("NoMarkersFollowFileBPM", func(t *testing.T) {
+		assert.InDelta(t, 4.0, WarpBeatAt(metadata, 2.0), 1e-9)
+		assert.InDelta(t, 2.0, WarpTimeAt(metadata, 4.0), 1e-9)
+		assert.Equal(t, float32(120.0), WarpedBPM(metadata, 0, 1))
+	})
+
+	t.Run("InterpolateBetweenMarkers", func(t *testing.T) {
+		// Played slightly late: beat 4 falls at 2.2s instead of 2.0s
+		warped := metadata
+		warped.Warp = []types.WarpMarker{{Time: 0.1, Beat: 0}, {Time: 2.2, Beat: 4}}
+		assert.InDelta(t, 2.0, WarpBeatAt(warped, 1.15), 1e-9)
+		assert.InDelta(t, 1.15, WarpTimeAt(warped, 2.0), 1e-9)
+		assert.InDelta(t, 5.0, WarpBeatAt(warped, 2.7), 1e-9, "After the last marker the file BPM applies")
+		assert.InDelta(t, -0.2, WarpBeatAt(warped, 0.0), 1e-9, "Before the first marker the file BPM applies")
+		assert.InDelta(t, 60.0*4/2.1, WarpedBPM(warped, 0.1, 2.2), 1e-3)
+
+		onsets := WarpGridOnsets(warped, 2.7)
+		assert.Len(t, onsets, 5, "Beats 0 to 4 start in the file")
+		assert.InDelta(t, 0.1, onsets[0], 1e-9)
+		assert.InDelta(t, 2.2, onsets[4], 1e-9)
+	})
+
+	t.Run("EditMarkers", func(t *testing.T) {
+		m := NewModel(0, "/tmp/test", false)
+		testFile := "../getbpm/Break120.wav"
+		m.WaveformFile = testFile
+		m.WaveformStart, m.WaveformEnd, m.WaveformDuration = 0.0, 4.0, 4.0
+		m.FileMetadata[testFile] = types.FileMetadata{BPM: 120.0, Slices: 2, SliceType: 1, Onsets: []float64{0.0, 1.1}}
+
+		// Pinned to the nearest beat of the selected slice marker
+		m.WaveformSelectedSlice = 1
+		m.AddWarpMarker()
+		assert.Equal(t, []types.WarpMarker{{Time: 1.1, Beat: 2}}, m.FileMetadata[testFile].Warp)
+		assert.Equal(t, 0, m.WaveformSelectedWarp)
+		assert.Equal(t, -1, m.WaveformSelectedSlice)
+
+		// Added at the midpoint, before the first marker
+		m.WaveformEnd = 1.0
+		m.AddWarpMarker()
+		assert.Equal(t, []types.WarpMarker{{Time: 0.5, Beat: 1}, {Time: 1.1, Beat: 2}}, m.FileMetadata[testFile].Warp)
+		assert.Equal(t, 0, m.WaveformSelectedWarp)
+
+		// Beats cannot pass a neighbour
+		m.NudgeWarpBeat(1)
+		assert.Equal(t, 1.0, m.FileMetadata[testFile].Warp[0].Beat)
+		m.NudgeWarpBeat(0.25)
+		assert.Equal(t, 1.25, m.FileMetadata[testFile].Warp[0].Beat)
+
+		// Times stay between the neighbours
+		m.WaveformEnd = 4.0
+		for i := 0; i < 20; i++ {
+			m.JogWarpMarker(1, true)
+		}
+		assert.Less(t, m.FileMetadata[testFile].Warp[0].Time, 1.1)
+
+		m.SelectNextWarpMarker()
+		assert.Equal(t, 1, m.WaveformSelectedWarp)
+		m.DeleteSelectedWarpMarker()
+		assert.Len(t, m.FileMetadata[testFile].Warp, 1)
+		assert.Equal(t, 0, m.WaveformSelectedWarp)
+
+		assert.True(t, m.SliceToWarpGrid())
+		metadata := m.FileMetadata[testFile]
+		assert.Equal(t, len(metadata.Onsets), metadata.Slices)
+		assert.Equal(t, 1, metadata.SliceType)
+	})
+}
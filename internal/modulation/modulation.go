@@ -1,14 +1,14 @@
 package modulation
 
 import (
-	"log"
 	"math/rand"
-	"time"
+
+	"github.com/schollz/collidertracker/internal/logging"
 )
 
 // ModulateSettings represents the settings for a single modulation entry
 type ModulateSettings struct {
-	Seed        int    `json:"seed"`        // Random seed: -1 for "none" (no randomization), 0 for "random" (time seeding), 1-128 for fixed seed
+	Seed        int    `json:"seed"`        // Random seed: -1 for "none" (no randomization), 0 for "random" (track RNG), 1-128 for fixed seed
 	IRandom     int    `json:"irandom"`     // Random range: 0-128 (0 means no randomization)
 	Sub         int    `json:"sub"`         // Subtract value: 0-120
 	Add         int    `json:"add"`         // Add value: 0-120
@@ -82,7 +82,7 @@ func GetNoteNames() []string {
 // This should be called before other modulation operations
 func ApplyIncrement(originalNote int, incrementCounter int, incrementValue int, wrapValue int) int {
 	if incrementCounter > -1 && incrementValue > 0 {
-		log.Printf("DEBUG: ApplyIncrement - originalNote=%d, incrementCounter=%d, incrementValue=%d, wrapValue=%d",
+		logging.Playback.Debugf("DEBUG: ApplyIncrement - originalNote=%d, incrementCounter=%d, incrementValue=%d, wrapValue=%d",
 			originalNote, incrementCounter, incrementValue, wrapValue)
 
 		// Apply wrapping logic if wrap value is greater than 0
@@ -90,12 +90,12 @@ func ApplyIncrement(originalNote int, incrementCounter int, incrementValue int,
 		if wrapValue > 0 && incrementCounter >= wrapValue {
 			// Subtract wrap value until counter is less than wrap value
 			wrappedCounter = incrementCounter % wrapValue
-			log.Printf("DEBUG: ApplyIncrement - applied wrapping: %d -> %d (wrap=%d)",
+			logging.Playback.Debugf("DEBUG: ApplyIncrement - applied wrapping: %d -> %d (wrap=%d)",
 				incrementCounter, wrappedCounter, wrapValue)
 		}
 
 		result := originalNote + wrappedCounter
-		log.Printf("DEBUG: ApplyIncrement - result=%d", result)
+		logging.Playback.Debugf("DEBUG: ApplyIncrement - result=%d", result)
 		return result
 	}
 	return originalNote
@@ -103,7 +103,7 @@ func ApplyIncrement(originalNote int, incrementCounter int, incrementValue int,
 
 // ApplyModulation applies modulation to a MIDI note value using the provided RNG
 func ApplyModulation(originalNote int, settings ModulateSettings, rng *rand.Rand) int {
-	log.Printf("DEBUG: ApplyModulation - Seed=%d, IRandom=%d, Sub=%d, Add=%d, Probability=%d",
+	logging.Playback.Debugf("DEBUG: ApplyModulation - Seed=%d, IRandom=%d, Sub=%d, Add=%d, Probability=%d",
 		settings.Seed, settings.IRandom, settings.Sub, settings.Add, settings.Probability)
 
 	// Check probability first - determine if modulation should occur at all
@@ -111,39 +111,37 @@ func ApplyModulation(originalNote int, settings ModulateSettings, rng *rand.Rand
 		// Generate a random number 1-100 to compare against probability
 		probabilityRoll := rng.Intn(100) + 1
 		if probabilityRoll > settings.Probability {
-			log.Printf("DEBUG: Modulation skipped - probabilityRoll=%d > probability=%d", probabilityRoll, settings.Probability)
+			logging.Playback.Debugf("DEBUG: Modulation skipped - probabilityRoll=%d > probability=%d", probabilityRoll, settings.Probability)
 			return originalNote // Return original note without any modulation
 		}
-		log.Printf("DEBUG: Modulation proceeding - probabilityRoll=%d <= probability=%d", probabilityRoll, settings.Probability)
+		logging.Playback.Debugf("DEBUG: Modulation proceeding - probabilityRoll=%d <= probability=%d", probabilityRoll, settings.Probability)
 	}
 
 	// Start with the original note
 	result := originalNote
-	log.Printf("DEBUG: Start with originalNote=%d", result)
+	logging.Playback.Debugf("DEBUG: Start with originalNote=%d", result)
 
 	// Step 1: Apply random variation if IRandom > 0
 	if settings.IRandom > 0 {
 
-		// Use fixed seed if specified (> 0), or time seeding for seed=0 ("random")
+		// Use fixed seed if specified (> 0); seed=0 ("random") keeps drawing from the
+		// track RNG, which follows the project seed
 		if settings.Seed > 0 {
 			// Create a new random source with the specified seed for reproducible results
 			rng.Seed(int64(settings.Seed))
-		} else if settings.Seed == 0 {
-			// time based seed
-			rng.Seed(time.Now().UnixNano())
 		}
 
 		result += rng.Intn(settings.IRandom + 1)
-		log.Printf("DEBUG: After IRandom, result=%d", result)
+		logging.Playback.Debugf("DEBUG: After IRandom, result=%d", result)
 	}
 
 	// Step 2: Subtract the Sub value
 	result -= settings.Sub
-	log.Printf("DEBUG: After Sub-%d, result=%d", settings.Sub, result)
+	logging.Playback.Debugf("DEBUG: After Sub-%d, result=%d", settings.Sub, result)
 
 	// Step 3: Add the Add value
 	result += settings.Add
-	log.Printf("DEBUG: After Add+%d, result=%d", settings.Add, result)
+	logging.Playback.Debugf("DEBUG: After Add+%d, result=%d", settings.Add, result)
 
 	// Step 4: Apply scale quantization if a scale is selected
 	if settings.Scale != "all" && settings.Scale != "" {
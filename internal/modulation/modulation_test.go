@@ -124,7 +124,7 @@ func TestApplyModulationWithTimeSeed(t *testing.T) {
 }
 
 func TestApplyModulationWithRandomSeed(t *testing.T) {
-	// Test that Seed=0 ("random") uses time seeding (track RNG)
+	// Test that Seed=0 ("random") draws from the track RNG
 	settings := ModulateSettings{
 		Seed:        0, // "random" - should use track RNG
 		IRandom:     20,
@@ -305,7 +305,7 @@ func TestQuantizeToScale(t *testing.T) {
 }
 
 func TestSeedBehavior(t *testing.T) {
-	// Test that Seed=0 is treated as "random" (time seeding), not fixed seed
+	// Test that Seed=0 is treated as "random" (track RNG), not fixed seed
 	settings0 := ModulateSettings{
 		Seed:        0,
 		IRandom:     10,
@@ -400,25 +400,18 @@ func TestTrackIsolation(t *testing.T) {
 		t.Logf("This is possible but very unlikely with different RNG seeds")
 	}
 
-	// Test that using the same track RNG does NOT produce deterministic results
-	// when Seed=0 (time seeding) because each call reseeds with current time
+	// Test that a track RNG started from the same seed repeats its results
+	// when Seed=0, so renders from the same project seed are reproducible
 	trackRng3 := rand.New(rand.NewSource(100)) // Same seed as trackRng1
 	results3 := make([]int, 10)
 	for i := 0; i < 10; i++ {
 		results3[i] = ApplyModulation(60, settings, trackRng3)
 	}
 
-	// With Seed=0 (time seeding), results will likely be different even with same track RNG
-	// because each call reseeds with time.Now().UnixNano()
-	allSame := true
 	for i := 0; i < 10; i++ {
 		if results1[i] != results3[i] {
-			allSame = false
+			t.Errorf("Same track RNG seed should repeat results: %v vs %v", results1, results3)
 			break
 		}
 	}
-	if allSame {
-		t.Logf("Note: All results from same track RNG were identical despite time seeding: %v", results1)
-		t.Logf("This is very unlikely but possible if calls happened at same nanosecond")
-	}
 }
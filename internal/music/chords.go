@@ -0,0 +1,130 @@
+package music
+
+import "math"
+
+// Chord detection works on the chroma of a recording: how strongly each of the 12 pitch
+// classes sounds, measured on the notes from C3 to B6
+const (
+	chromaLowNote  = 48
+	chromaHighNote = 95
+	chromaFrames   = 4   // Frames per second the energy of each note is measured over
+	chordMatch     = 0.8 // Least match with a triad for the chroma to count as a chord
+)
+
+// Chroma returns how strongly each pitch class sounds in mono samples. The energy of every
+// note is measured with the Goertzel algorithm over short Hann windowed frames, so samples a
+// little out of tune are still heard on their notes.
+func Chroma(samples []float64, sampleRate int) [12]float64 {
+	var chroma [12]float64
+	frame := sampleRate / chromaFrames
+	if len(samples) < frame {
+		frame = len(samples)
+	}
+	if frame < 2 || sampleRate <= 0 {
+		return chroma
+	}
+
+	window := make([]float64, frame)
+	for i := range window {
+		window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(frame-1))
+	}
+	var coeffs []float64 // Of the notes from chromaLowNote up that are below the Nyquist frequency
+	for note := chromaLowNote; note <= chromaHighNote && noteFreq(note) < float64(sampleRate)/2; note++ {
+		coeffs = append(coeffs, 2*math.Cos(2*math.Pi*noteFreq(note)/float64(sampleRate)))
+	}
+
+	windowed := make([]float64, frame)
+	for start := 0; start+frame <= len(samples); start += frame {
+		for i := range windowed {
+			windowed[i] = samples[start+i] * window[i]
+		}
+		for i, coeff := range coeffs {
+			var s1, s2 float64
+			for _, x := range windowed {
+				s1, s2 = x+coeff*s1-s2, s1
+			}
+			power := s1*s1 + s2*s2 - coeff*s1*s2
+			chroma[(chromaLowNote+i)%12] += math.Sqrt(max(0, power))
+		}
+	}
+	return chroma
+}
+
+// MatchChord returns the major or minor triad that best matches a chroma, or false when none
+// matches well: for silence, a single note, drums or noise
+func MatchChord(chroma [12]float64) (Chord, bool) {
+	norm := 0.0
+	for _, c := range chroma {
+		norm += c * c
+	}
+	if norm == 0 {
+		return Chord{}, false
+	}
+	norm = math.Sqrt(norm * 3)
+
+	var best Chord
+	bestScore := 0.0
+	for root := 0; root < 12; root++ {
+		for _, quality := range []string{"", "m"} {
+			chord := Chord{Root: root, Quality: quality}
+			score := 0.0
+			for _, pc := range chord.PitchClasses() {
+				score += chroma[pc]
+			}
+			if score /= norm; score > bestScore {
+				best, bestScore = chord, score
+			}
+		}
+	}
+	return best, bestScore >= chordMatch
+}
+
+// ChordProgression returns the chord heard in each stretch of seconds of mono samples, by
+// name ("" where no chord is heard), and how much each pitch class is held by those chords, for
+// GuessKeyOfWeights. Roots count double, so a progression names its own key rather than the
+// relative major or minor with the same notes.
+func ChordProgression(samples []float64, sampleRate int, seconds float64) ([]string, [12]float64) {
+	var weights [12]float64
+	length := int(seconds * float64(sampleRate))
+	if length <= 0 {
+		return nil, weights
+	}
+	var chords []string
+	for start := 0; start < len(samples); start += length {
+		end := min(len(samples), start+length)
+		if end-start < length/2 && len(chords) > 0 {
+			break // Too little of a stretch is left to hear a chord
+		}
+		chord, ok := MatchChord(Chroma(samples[start:end], sampleRate))
+		if !ok {
+			chords = append(chords, "")
+			continue
+		}
+		chords = append(chords, chord.Name())
+		for _, pc := range chord.PitchClasses() {
+			weights[pc]++
+		}
+		weights[chord.Root]++
+	}
+	return chords, weights
+}
+
+// ChordsAndKey returns the chord heard in each stretch of seconds of mono samples, as
+// ChordProgression does, and the key they are in, like "A minor". Both are empty when no chord
+// is heard, as in drum loops.
+func ChordsAndKey(samples []float64, sampleRate int, seconds float64) ([]string, string) {
+	chords, weights := ChordProgression(samples, sampleRate, seconds)
+	root, minor, ok := GuessKeyOfWeights(weights)
+	if !ok {
+		return nil, ""
+	}
+	if minor {
+		return chords, PitchClassName(root) + " minor"
+	}
+	return chords, PitchClassName(root) + " major"
+}
+
+// noteFreq returns the frequency of a MIDI note in Hz, tuned to A4 at 440 Hz
+func noteFreq(note int) float64 {
+	return 440 * math.Pow(2, float64(note-69)/12)
+}
@@ -0,0 +1,68 @@
+package music
+
+import (
+	"math"
+	"testing"
+)
+
+// tones returns seconds of the notes sounding together, with a second harmonic and a little
+// detuning like a real instrument
+func tones(sampleRate int, seconds float64, notes ...int) []float64 {
+	samples := make([]float64, int(seconds*float64(sampleRate)))
+	for _, note := range notes {
+		freq := noteFreq(note) * 1.003
+		for i := range samples {
+			t := float64(i) / float64(sampleRate)
+			samples[i] += 0.2*math.Sin(2*math.Pi*freq*t) + 0.05*math.Sin(4*math.Pi*freq*t)
+		}
+	}
+	return samples
+}
+
+func TestMatchChord(t *testing.T) {
+	const rate = 8000
+	tests := []struct {
+		name  string
+		notes []int
+		want  string
+	}{
+		{"A minor", []int{57, 60, 64}, "Am"},
+		{"F major", []int{53, 57, 60}, "F"},
+		{"G major inverted", []int{59, 62, 67}, "G"},
+		{"C# minor with bass", []int{37, 61, 64, 68}, "C#m"},
+		{"single note", []int{60}, ""},
+		{"silence", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if chord, ok := MatchChord(Chroma(tones(rate, 1, tt.notes...), rate)); ok {
+				got = chord.Name()
+			}
+			if got != tt.want {
+				t.Errorf("MatchChord() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChordProgression(t *testing.T) {
+	const rate = 8000
+	var samples []float64
+	for _, chord := range [][]int{{60, 64, 67}, {57, 60, 64}, {53, 57, 60}, {55, 59, 62}, {}} {
+		samples = append(samples, tones(rate, 0.5, chord...)...)
+	}
+	chords, weights := ChordProgression(samples, rate, 0.5)
+	want := []string{"C", "Am", "F", "G", ""}
+	if len(chords) != len(want) {
+		t.Fatalf("ChordProgression() = %q, want %q", chords, want)
+	}
+	for i := range want {
+		if chords[i] != want[i] {
+			t.Errorf("ChordProgression()[%d] = %q, want %q", i, chords[i], want[i])
+		}
+	}
+	if root, minor, ok := GuessKeyOfWeights(weights); !ok || root != 0 || minor {
+		t.Errorf("GuessKeyOfWeights() = %s minor=%v, want C major", PitchClassName(root), minor)
+	}
+}
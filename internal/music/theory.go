@@ -0,0 +1,128 @@
+package music
+
+import (
+	"math"
+	"slices"
+)
+
+// pitchClassNames are the names of the 12 pitch classes, from C
+var pitchClassNames = []string{"C", "C#", "D", "D#", "E", "F", "F#", "G", "G#", "A", "A#", "B"}
+
+// Scale steps of the keys GuessKey chooses from
+var (
+	MajorScale = []int{0, 2, 4, 5, 7, 9, 11}
+	MinorScale = []int{0, 2, 3, 5, 7, 8, 10}
+)
+
+// romanNumerals name the degrees of a seven-note scale
+var romanNumerals = []string{"I", "II", "III", "IV", "V", "VI", "VII"}
+
+// PitchClassName returns the name of a note without its octave, e.g. "F#"
+func PitchClassName(note int) string {
+	return pitchClassNames[((note%12)+12)%12]
+}
+
+// InScale reports whether a note belongs to the scale with the given root
+func InScale(note, root int, scale []int) bool {
+	return slices.Contains(scale, ((note-root)%12+12)%12)
+}
+
+// Chord is a triad built on a degree of a scale
+type Chord struct {
+	Root    int    // Pitch class of the root (0 is C)
+	Quality string // "" for major, "m" minor, "°" diminished, "+" augmented
+	Numeral string // Degree in roman numerals, upper case for major, e.g. "ii" or "vii°"
+}
+
+// Name returns the chord's symbol, e.g. "Dm"
+func (c Chord) Name() string {
+	return PitchClassName(c.Root) + c.Quality
+}
+
+// PitchClasses returns the pitch classes of the chord's root, third and fifth
+func (c Chord) PitchClasses() []int {
+	third, fifth := 4, 7
+	switch c.Quality {
+	case "m":
+		third = 3
+	case "°":
+		third, fifth = 3, 6
+	case "+":
+		fifth = 8
+	}
+	return []int{c.Root, (c.Root + third) % 12, (c.Root + fifth) % 12}
+}
+
+// DiatonicChords returns the triads stacked in thirds on each degree of a seven-note scale,
+// or nil for scales with another number of notes
+func DiatonicChords(root int, scale []int) []Chord {
+	if len(scale) != 7 {
+		return nil
+	}
+	chords := make([]Chord, 7)
+	for degree := range chords {
+		third := (scale[(degree+2)%7] - scale[degree] + 12) % 12
+		fifth := (scale[(degree+4)%7] - scale[degree] + 12) % 12
+		chord := Chord{Root: (root + scale[degree]) % 12, Numeral: romanNumerals[degree]}
+		switch {
+		case third == 4 && fifth == 7:
+		case third == 3 && fifth == 7:
+			chord.Quality, chord.Numeral = "m", toLower(chord.Numeral)
+		case third == 3 && fifth == 6:
+			chord.Quality, chord.Numeral = "°", toLower(chord.Numeral)+"°"
+		case third == 4 && fifth == 8:
+			chord.Quality, chord.Numeral = "+", chord.Numeral+"+"
+		}
+		chords[degree] = chord
+	}
+	return chords
+}
+
+// toLower lowers the case of a roman numeral
+func toLower(numeral string) string {
+	lower := []byte(numeral)
+	for i, c := range lower {
+		lower[i] = c + 'a' - 'A'
+	}
+	return string(lower)
+}
+
+// GuessKey returns the major or minor key whose scale holds the most of the notes. Ties go to
+// the key whose root is played most, then to major. ok is false without notes.
+func GuessKey(notes []int) (root int, minor bool, ok bool) {
+	var counts [12]float64
+	for _, note := range notes {
+		counts[((note%12)+12)%12]++
+	}
+	return GuessKeyOfWeights(counts)
+}
+
+// GuessKeyOfWeights is GuessKey for how much each pitch class is heard, such as the chroma of
+// a recording. ok is false when nothing is heard.
+func GuessKeyOfWeights(weights [12]float64) (root int, minor bool, ok bool) {
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+	if total <= 0 {
+		return 0, false, false
+	}
+	epsilon := total * 1e-9 // Keys with the same notes sum them in another order
+	best, bestRootWeight := -1.0, -1.0
+	for _, isMinor := range []bool{false, true} {
+		scale := MajorScale
+		if isMinor {
+			scale = MinorScale
+		}
+		for r := 0; r < 12; r++ {
+			score := 0.0
+			for _, step := range scale {
+				score += weights[(r+step)%12]
+			}
+			if score > best+epsilon || math.Abs(score-best) <= epsilon && weights[r] > bestRootWeight {
+				best, bestRootWeight, root, minor = score, weights[r], r, isMinor
+			}
+		}
+	}
+	return root, minor, true
+}
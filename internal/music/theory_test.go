@@ -0,0 +1,75 @@
+package music
+
+import (
+	"testing"
+)
+
+func TestDiatonicChords(t *testing.T) {
+	tests := []struct {
+		name   string
+		root   int
+		scale  []int
+		chords string
+	}{
+		{"C major", 0, MajorScale, "I C, ii Dm, iii Em, IV F, V G, vi Am, vii° B°"},
+		{"A minor", 9, MinorScale, "i Am, ii° B°, III C, iv Dm, v Em, VI F, VII G"},
+		{"D major", 2, MajorScale, "I D, ii Em, iii F#m, IV G, V A, vi Bm, vii° C#°"},
+		{"harmonic minor", 0, []int{0, 2, 3, 5, 7, 8, 11}, "i Cm, ii° D°, III+ D#+, iv Fm, V G, VI G#, vii° B°"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			for i, chord := range DiatonicChords(tt.root, tt.scale) {
+				if i > 0 {
+					got += ", "
+				}
+				got += chord.Numeral + " " + chord.Name()
+			}
+			if got != tt.chords {
+				t.Errorf("DiatonicChords() = %q, want %q", got, tt.chords)
+			}
+		})
+	}
+
+	if chords := DiatonicChords(0, []int{0, 2, 4, 7, 9}); chords != nil {
+		t.Errorf("Pentatonic scale should have no diatonic chords, got %v", chords)
+	}
+}
+
+func TestGuessKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		notes []int
+		root  int
+		minor bool
+	}{
+		{"C major triad", []int{60, 64, 67}, 0, false},
+		{"A minor with its root played most", []int{57, 57, 60, 64, 69}, 9, true},
+		{"G major with F#", []int{67, 71, 74, 66, 72}, 7, false},
+		{"notes below C", []int{-3, -3, 0, 4}, 9, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, minor, ok := GuessKey(tt.notes)
+			if !ok || root != tt.root || minor != tt.minor {
+				t.Errorf("GuessKey() = %s minor=%v ok=%v, want %s minor=%v", PitchClassName(root), minor, ok, PitchClassName(tt.root), tt.minor)
+			}
+		})
+	}
+
+	if _, _, ok := GuessKey(nil); ok {
+		t.Error("GuessKey() without notes should not find a key")
+	}
+}
+
+func TestInScale(t *testing.T) {
+	if !InScale(66, 7, MajorScale) {
+		t.Error("F# should be in G major")
+	}
+	if InScale(65, 7, MajorScale) {
+		t.Error("F should not be in G major")
+	}
+	if !InScale(-1, 0, MajorScale) {
+		t.Error("B below note 0 should be in C major")
+	}
+}
@@ -0,0 +1,116 @@
+package project
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/schollz/collidertracker/internal/logging"
+)
+
+// maxPasswordTries is how many wrong passwords the prompt takes before giving up
+const maxPasswordTries = 3
+
+// PasswordPrompt asks for the password of a password-protected project
+type PasswordPrompt struct {
+	name     string             // Project name
+	unlock   func(string) error // Tries a password
+	buffer   string
+	err      error
+	tries    int
+	unlocked bool
+	width    int
+	height   int
+}
+
+// NewPasswordPrompt returns a prompt for the project name, unlocked by unlock
+func NewPasswordPrompt(name string, unlock func(string) error) *PasswordPrompt {
+	return &PasswordPrompt{name: name, unlock: unlock}
+}
+
+// Init is required for tea.Model interface
+func (pp *PasswordPrompt) Init() tea.Cmd {
+	return nil
+}
+
+// Update takes the typed password; enter tries it, esc and ctrl+c give up
+func (pp *PasswordPrompt) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		pp.width, pp.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC, tea.KeyEsc:
+			return pp, tea.Quit
+		case tea.KeyEnter:
+			if pp.buffer == "" {
+				return pp, nil
+			}
+			pp.err = pp.unlock(pp.buffer)
+			pp.buffer = ""
+			if pp.err == nil {
+				pp.unlocked = true
+				return pp, tea.Quit
+			}
+			pp.tries++
+			logging.Storage.Errorf("Failed to unlock %s: %v", pp.name, pp.err)
+			if pp.tries >= maxPasswordTries {
+				return pp, tea.Quit
+			}
+		case tea.KeyBackspace:
+			if runes := []rune(pp.buffer); len(runes) > 0 {
+				pp.buffer = string(runes[:len(runes)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			pp.buffer += string(msg.Runes)
+		}
+	}
+	return pp, nil
+}
+
+// View shows the masked password in a centered dialog
+func (pp *PasswordPrompt) View() string {
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1, 2).
+		Width(52)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("15")).
+		Background(lipgloss.Color("205"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	var content strings.Builder
+	content.WriteString(lipgloss.NewStyle().Bold(true).Render(pp.name) + " is password protected\n\n")
+	content.WriteString("  " + selectedStyle.Render(strings.Repeat("*", len([]rune(pp.buffer)))+" ") + "\n\n")
+	if pp.err != nil {
+		content.WriteString(errorStyle.Render(pp.err.Error()) + "\n\n")
+	}
+	content.WriteString(helpStyle.Render("type the password | enter: open | esc: quit"))
+
+	return lipgloss.NewStyle().
+		Width(pp.width).
+		Height(pp.height).
+		Align(lipgloss.Center).
+		AlignVertical(lipgloss.Center).
+		Render(dialogStyle.Render(content.String()))
+}
+
+// Unlocked reports whether a password opened the project
+func (pp *PasswordPrompt) Unlocked() bool {
+	return pp.unlocked
+}
+
+// RunPasswordPrompt asks for the password of the project name until unlock accepts one, and
+// reports whether it did (false when the user gave up or after too many wrong passwords)
+func RunPasswordPrompt(name string, unlock func(string) error) bool {
+	prompt := NewPasswordPrompt(name, unlock)
+	p := tea.NewProgram(prompt, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		logging.Storage.Errorf("Error running password prompt: %v", err)
+		return false
+	}
+	return prompt.Unlocked()
+}
@@ -0,0 +1,50 @@
+package project
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// previewPlayers are the command line players tried, in order, to play a bounce while
+// SuperCollider is not running yet. The file replaces %s, or is added at the end.
+var previewPlayers = map[string][][]string{
+	"darwin":  {{"afplay"}},
+	"linux":   {{"paplay"}, {"aplay", "-q"}, {"ffplay", "-nodisp", "-autoexit", "-loglevel", "quiet"}},
+	"windows": {{"powershell", "-NoProfile", "-Command", "(New-Object Media.SoundPlayer '%s').PlaySync()"}},
+}
+
+// previewCommand returns the command that plays a WAV file with the first player installed
+func previewCommand(goos, path string) (*exec.Cmd, error) {
+	for _, player := range previewPlayers[goos] {
+		if _, err := exec.LookPath(player[0]); err != nil {
+			continue
+		}
+		args, placed := []string{}, false
+		for _, arg := range player[1:] {
+			if strings.Contains(arg, "%s") {
+				arg, placed = fmt.Sprintf(arg, strings.ReplaceAll(path, "'", "''")), true
+			}
+			args = append(args, arg)
+		}
+		if !placed {
+			args = append(args, path)
+		}
+		return exec.Command(player[0], args...), nil
+	}
+	return nil, fmt.Errorf("no audio player found")
+}
+
+// startPreview starts playing a WAV file in the background
+func startPreview(path string) (*exec.Cmd, error) {
+	cmd, err := previewCommand(runtime.GOOS, path)
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	go cmd.Wait() // Reap the player when it finishes or is stopped
+	return cmd, nil
+}
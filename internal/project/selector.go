@@ -2,8 +2,8 @@ package project
 
 import (
 	"fmt"
-	"log"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
 	"sort"
@@ -12,6 +12,8 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/schollz/collidertracker/internal/logging"
 )
 
 // Project represents a found project
@@ -29,6 +31,9 @@ type ProjectSelector struct {
 	searching      bool
 	width          int
 	height         int
+	summaries      map[string]*Summary // Summaries read so far by project path (nil when unreadable)
+	preview        *exec.Cmd           // Player of the bounce being previewed (nil if none)
+	previewError   string              // Why the last preview could not start
 }
 
 type searchCompleteMsg struct {
@@ -36,6 +41,12 @@ type searchCompleteMsg struct {
 	err      error
 }
 
+// summaryMsg carries a project's summary back from the background read
+type summaryMsg struct {
+	path    string
+	summary *Summary
+}
+
 // ProjectNameInput is a dialog for entering a new project name
 type ProjectNameInput struct {
 	projectName string
@@ -219,6 +230,7 @@ func NewProjectSelector() *ProjectSelector {
 		selectedIndex:  0,
 		searchComplete: false,
 		searching:      true,
+		summaries:      map[string]*Summary{},
 	}
 	return ps
 }
@@ -228,7 +240,7 @@ func SearchProjects() ([]Project, error) {
 	var projects []Project
 	searchPaths := getSearchPaths()
 
-	log.Printf("Searching for projects in: %v", searchPaths)
+	logging.Storage.Debugf("Searching for projects in: %v", searchPaths)
 
 	for _, basePath := range searchPaths {
 		found := searchInDirectory(basePath, 3) // Max depth of 3 levels
@@ -243,13 +255,19 @@ func SearchProjects() ([]Project, error) {
 	// Remove duplicates (same path)
 	projects = removeDuplicates(projects)
 
-	log.Printf("Found %d projects", len(projects))
+	logging.Storage.Debugf("Found %d projects", len(projects))
 	return projects, nil
 }
 
+// ProjectsDir is the folder new projects are created in, searched first ("" for none)
+var ProjectsDir string
+
 // getSearchPaths returns common paths where projects might be located
 func getSearchPaths() []string {
 	paths := []string{}
+	if ProjectsDir != "" {
+		paths = append(paths, ProjectsDir)
+	}
 
 	// Current working directory
 	if cwd, err := os.Getwd(); err == nil {
@@ -292,7 +310,7 @@ func searchInDirectory(dir string, maxDepth int) []Project {
 			Modified: stat.ModTime(),
 		}
 		projects = append(projects, project)
-		log.Printf("Found project: %s at %s", project.Name, project.Path)
+		logging.Storage.Debugf("Found project: %s at %s", project.Name, project.Path)
 		return projects // Don't search subdirectories of a project
 	}
 
@@ -346,6 +364,65 @@ func (ps *ProjectSelector) searchForProjects() tea.Cmd {
 	}
 }
 
+// loadSummary reads the summary of the selected project in the background, once per project
+func (ps *ProjectSelector) loadSummary() tea.Cmd {
+	if ps.selectedIndex < 0 || ps.selectedIndex >= len(ps.projects) {
+		return nil
+	}
+	path := ps.projects[ps.selectedIndex].Path
+	if _, ok := ps.summaries[path]; ok {
+		return nil
+	}
+	ps.summaries[path] = nil
+	return func() tea.Msg {
+		summary, err := LoadSummary(path)
+		if err != nil {
+			logging.Storage.Errorf("Error reading project summary: %v", err)
+		}
+		return summaryMsg{path: path, summary: summary}
+	}
+}
+
+// selectedSummary returns the summary of the selected project (nil if not read yet or unreadable)
+func (ps *ProjectSelector) selectedSummary() *Summary {
+	if ps.selectedIndex < 0 || ps.selectedIndex >= len(ps.projects) {
+		return nil
+	}
+	return ps.summaries[ps.projects[ps.selectedIndex].Path]
+}
+
+// togglePreview plays the selected project's last bounce, or stops the one playing
+func (ps *ProjectSelector) togglePreview() {
+	if ps.preview != nil {
+		ps.stopPreview()
+		return
+	}
+	summary := ps.selectedSummary()
+	if summary == nil || summary.Bounce == "" {
+		return
+	}
+	cmd, err := startPreview(summary.Bounce)
+	if err != nil {
+		logging.Storage.Errorf("Error previewing %s: %v", summary.Bounce, err)
+		ps.previewError = err.Error()
+		return
+	}
+	logging.Storage.Debugf("Previewing bounce: %s", summary.Bounce)
+	ps.preview = cmd
+}
+
+// stopPreview stops the bounce being previewed, if any
+func (ps *ProjectSelector) stopPreview() {
+	ps.previewError = ""
+	if ps.preview == nil {
+		return
+	}
+	if ps.preview.Process != nil {
+		ps.preview.Process.Kill()
+	}
+	ps.preview = nil
+}
+
 // Update handles messages
 func (ps *ProjectSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -357,12 +434,13 @@ func (ps *ProjectSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		ps.searching = false
 		ps.searchComplete = true
 		if msg.err != nil {
-			log.Printf("Error searching for projects: %v", msg.err)
+			logging.Storage.Errorf("Error searching for projects: %v", msg.err)
 		} else {
 			ps.projects = msg.projects
 			// Select the first project by default
 			if len(ps.projects) > 0 {
 				ps.selectedIndex = 0
+				return ps, ps.loadSummary()
 			} else {
 				// No projects found, automatically transition to create new project
 				nameInput := NewProjectNameInput()
@@ -372,11 +450,17 @@ func (ps *ProjectSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case summaryMsg:
+		ps.summaries[msg.path] = msg.summary
+
 	case tea.KeyMsg:
 		if !ps.searchComplete {
 			return ps, nil // Ignore keys while searching
 		}
 
+		if msg.String() != "p" {
+			ps.stopPreview()
+		}
 		switch msg.String() {
 		case "q", "ctrl+c", "ctrl+q", "esc":
 			return ps, tea.Quit
@@ -385,11 +469,16 @@ func (ps *ProjectSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if ps.selectedIndex > 0 {
 				ps.selectedIndex--
 			}
+			return ps, ps.loadSummary()
 
 		case "down", "j":
 			if ps.selectedIndex < len(ps.projects)-1 {
 				ps.selectedIndex++
 			}
+			return ps, ps.loadSummary()
+
+		case "p":
+			ps.togglePreview()
 
 		case "enter":
 			if len(ps.projects) > 0 {
@@ -470,6 +559,13 @@ func (ps *ProjectSelector) renderProjectList() string {
 	if len(ps.projects) > 0 {
 		instructions += "↑/↓ or k/j: Navigate  •  Enter: Select  •  "
 	}
+	if summary := ps.selectedSummary(); summary != nil && summary.Bounce != "" {
+		if ps.preview != nil {
+			instructions += "p: Stop  •  "
+		} else {
+			instructions += "p: Play last bounce  •  "
+		}
+	}
 	instructions += "n: New project  •  q/Esc: Quit"
 
 	content.WriteString(instructionsStyle.Render(instructions))
@@ -515,7 +611,35 @@ func (ps *ProjectSelector) renderProject(content *strings.Builder, project Proje
 			Padding(0, 1)
 		content.WriteString(timeStyle.Render(fmt.Sprintf("  %s", timeInfo)))
 		content.WriteString("\n")
+		for _, line := range ps.summaryLines(ps.summaries[project.Path]) {
+			content.WriteString(timeStyle.Render(fmt.Sprintf("  %s", line)))
+			content.WriteString("\n")
+		}
+	}
+}
+
+// summaryLines describes a project under its entry: tempo, song length, the song rows each
+// track plays and the last bounce
+func (ps *ProjectSelector) summaryLines(summary *Summary) []string {
+	if summary == nil {
+		return nil
+	}
+	if summary.Locked {
+		return []string{"Password protected"}
+	}
+	lines := []string{fmt.Sprintf("BPM %.1f  •  Song %d rows", summary.BPM, summary.SongRows)}
+	lines = append(lines, summary.ActivityGrid()...)
+	switch {
+	case summary.Bounce == "":
+		lines = append(lines, "No loop bounce")
+	case ps.previewError != "":
+		lines = append(lines, fmt.Sprintf("Bounce: %s (%s)", filepath.Base(summary.Bounce), ps.previewError))
+	case ps.preview != nil:
+		lines = append(lines, fmt.Sprintf("Bounce: %s (playing)", filepath.Base(summary.Bounce)))
+	default:
+		lines = append(lines, fmt.Sprintf("Bounce: %s", filepath.Base(summary.Bounce)))
 	}
+	return lines
 }
 
 // ProjectResult represents the result of project selection
@@ -546,8 +670,9 @@ func RunProjectSelector() (string, bool, bool) {
 	p := tea.NewProgram(selector, tea.WithAltScreen())
 
 	finalModel, err := p.Run()
+	selector.stopPreview()
 	if err != nil {
-		log.Printf("Error running project selector: %v", err)
+		logging.Storage.Errorf("Error running project selector: %v", err)
 		return "", true, false // cancelled
 	}
 
@@ -1,7 +1,16 @@
 package project
 
 import (
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/types"
 )
 
 // Test to verify the RunProjectSelector function signature and basic functionality
@@ -41,3 +50,76 @@ func TestProjectNameInputCreation(t *testing.T) {
 		t.Error("Expected cancelled to be false initially")
 	}
 }
+
+func TestLoadSummary(t *testing.T) {
+	dir := t.TempDir()
+	var song [types.NumTracks][types.SongRows]int
+	for track := range song {
+		for row := range song[track] {
+			song[track][row] = -1
+		}
+	}
+	song[0][0], song[0][1], song[2][5] = 0, 1, 3
+	data, _ := json.Marshal(map[string]any{"bpm": 96.5, "songData": song})
+	file, err := os.Create(filepath.Join(dir, "data.json.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gzWriter := gzip.NewWriter(file)
+	gzWriter.Write(data)
+	gzWriter.Close()
+	file.Close()
+
+	summary, err := LoadSummary(dir)
+	if err != nil {
+		t.Fatalf("LoadSummary failed: %v", err)
+	}
+	if summary.BPM != 96.5 || summary.SongRows != 6 {
+		t.Errorf("Expected BPM 96.5 and 6 song rows, got %.1f and %d", summary.BPM, summary.SongRows)
+	}
+	if grid := summary.ActivityGrid(); grid[0] != "T1 ■■··············" || grid[2] != "T3 ·····■··········" {
+		t.Errorf("Unexpected activity grid %q", grid)
+	}
+	if summary.Bounce != "" {
+		t.Errorf("Expected no bounce, got %q", summary.Bounce)
+	}
+
+	// The newest loop bounce is offered for preview, not prints
+	recordings := filepath.Join(dir, "recordings")
+	os.MkdirAll(recordings, 0755)
+	for i, name := range []string{"loop-phrase01-a.wav", "loop-chain02-b.wav", "print-bass-phrase03-c.wav"} {
+		path := filepath.Join(recordings, name)
+		os.WriteFile(path, nil, 0644)
+		when := time.Now().Add(time.Duration(i) * time.Minute)
+		os.Chtimes(path, when, when)
+	}
+	summary, _ = LoadSummary(dir)
+	if filepath.Base(summary.Bounce) != "loop-chain02-b.wav" {
+		t.Errorf("Expected the newest loop bounce, got %q", summary.Bounce)
+	}
+
+	if _, err := LoadSummary(t.TempDir()); err == nil {
+		t.Error("Expected an error for a folder without a save")
+	}
+}
+
+func TestSelectorSummary(t *testing.T) {
+	ps := NewProjectSelector()
+	ps.Update(searchCompleteMsg{projects: []Project{{Name: "a", Path: "/a"}, {Name: "b", Path: "/b"}}})
+	ps.Update(summaryMsg{path: "/a", summary: &Summary{BPM: 120, SongRows: 2}})
+	lines := ps.summaryLines(ps.selectedSummary())
+	if len(lines) != 2+types.NumTracks || lines[0] != "BPM 120.0  •  Song 2 rows" || lines[len(lines)-1] != "No loop bounce" {
+		t.Errorf("Unexpected summary %q", lines)
+	}
+
+	// Moving to a project asks for its summary once
+	if _, cmd := ps.Update(tea.KeyMsg{Type: tea.KeyDown}); cmd == nil {
+		t.Error("Expected the next project's summary to be read")
+	}
+	if ps.selectedSummary() != nil || ps.summaryLines(nil) != nil {
+		t.Error("Expected no summary until it is read")
+	}
+	if _, cmd := ps.Update(tea.KeyMsg{Type: tea.KeyUp}); cmd != nil {
+		t.Error("Expected the summary read before to be kept")
+	}
+}
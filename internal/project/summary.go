@@ -0,0 +1,91 @@
+package project
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/schollz/collidertracker/internal/storage"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// Summary is what the project selector shows about the highlighted project
+type Summary struct {
+	BPM      float32                               // Song tempo
+	SongRows int                                   // Song rows up to the last one any track uses
+	Active   [types.NumTracks][types.SongRows]bool // Song rows each track has a chain on
+	Bounce   string                                // Newest loop bounce in the recordings folder ("" for none)
+	Locked   bool                                  // Whether the project is password protected, so nothing else is known
+}
+
+// LoadSummary reads the summary of the project in dir from its save file
+func LoadSummary(dir string) (*Summary, error) {
+	if storage.IsProjectLocked(dir) {
+		return &Summary{Locked: true}, nil // Even its bounces are encrypted
+	}
+	file, err := os.Open(filepath.Join(dir, "data.json.gz"))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	gzReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer gzReader.Close()
+
+	// Only the fields shown are decoded
+	var saveData struct {
+		BPM      float32                              `json:"bpm"`
+		SongData [types.NumTracks][types.SongRows]int `json:"songData"`
+	}
+	if err := json.NewDecoder(gzReader).Decode(&saveData); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", dir, err)
+	}
+
+	summary := &Summary{BPM: saveData.BPM, Bounce: lastBounce(dir)}
+	for track, rows := range saveData.SongData {
+		for row, chain := range rows {
+			if chain >= 0 {
+				summary.Active[track][row] = true
+				summary.SongRows = max(summary.SongRows, row+1)
+			}
+		}
+	}
+	return summary, nil
+}
+
+// lastBounce returns the newest loop bounce in a project's recordings folder ("" for none)
+func lastBounce(dir string) string {
+	// Loop bounces are named loop-<kind><id>-<time>.wav; prints and session recordings are not
+	matches, _ := filepath.Glob(filepath.Join(dir, "recordings", "loop-*.wav"))
+	newest := ""
+	var newestTime int64
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && (newest == "" || info.ModTime().UnixNano() >= newestTime) {
+			newest, newestTime = match, info.ModTime().UnixNano()
+		}
+	}
+	return newest
+}
+
+// ActivityGrid draws which song rows each track plays, one line per track
+func (s *Summary) ActivityGrid() []string {
+	lines := make([]string, types.NumTracks)
+	for track, rows := range s.Active {
+		var line strings.Builder
+		fmt.Fprintf(&line, "T%d ", track+1)
+		for _, active := range rows {
+			if active {
+				line.WriteString("■")
+			} else {
+				line.WriteString("·")
+			}
+		}
+		lines[track] = line.String()
+	}
+	return lines
+}
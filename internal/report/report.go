@@ -0,0 +1,312 @@
+// Package report packs what a bug report needs into one zip: the latest log lines, a summary
+// of the project without its audio, song data or notes, and details of the system and
+// SuperCollider. Paths under the home folder and the user and host names are redacted, and
+// nothing is sent anywhere: the zip is left for the user to attach.
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/supercollider"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// Names inside a report
+const (
+	logEntry     = "log.jsonl"
+	projectEntry = "project.json"
+	systemEntry  = "system.json"
+)
+
+// Project summarizes a project and the tracker's state
+type Project struct {
+	Version        string                    `json:"version"` // Version of collidertracker
+	View           int                       `json:"view"`    // types.ViewMode being shown
+	Playing        bool                      `json:"playing"` // Whether playback runs
+	BPM            float32                   `json:"bpm"`
+	PPQ            int                       `json:"ppq"`
+	Tracks         []Track                   `json:"tracks"`
+	Instrument     Pool                      `json:"instrument"` // Chains and phrases of the instrument pool
+	Sampler        Pool                      `json:"sampler"`    // Chains and phrases of the sampler pool
+	Samples        int                       `json:"samples"`    // Sample files with metadata
+	PerTrackBanks  bool                      `json:"perTrackBanks"`
+	GenerativeSong bool                      `json:"generativeSong"`
+	EcoMode        bool                      `json:"ecoMode"`
+	Autosave       bool                      `json:"autosave"`
+	Protected      bool                      `json:"protected"` // Whether the project has a password
+	Server         Server                    `json:"server"`
+	Config         types.AppConfig           `json:"config"`      // Startup options, oscEngines included
+	LastUICrash    time.Time                 `json:"lastUICrash"` // Zero when the interface never recovered from a panic
+	Diagnostics    []model.DiagnosticsSample `json:"diagnostics"` // Load over the last minute, oldest first
+}
+
+// Track summarizes one track of the song
+type Track struct {
+	Sampler    bool    `json:"sampler"`          // Sampler or instrument track
+	Engine     string  `json:"engine,omitempty"` // OSC engine it plays instead of SuperCollider
+	Level      float32 `json:"level"`            // Set level in dB
+	Resolution int     `json:"resolution"`       // Ticks per PPQ tick of its rows
+	SongRows   int     `json:"songRows"`         // Song rows with a chain
+}
+
+// Pool counts the chain and phrase IDs of a pool by how they are used
+type Pool struct {
+	Chains        int `json:"chains"`        // Chains the song plays
+	UnusedChains  int `json:"unusedChains"`  // Chains with data the song does not play
+	Phrases       int `json:"phrases"`       // Phrases a chain plays
+	UnusedPhrases int `json:"unusedPhrases"` // Phrases with data no chain plays
+}
+
+// Server is the SuperCollider server as the tracker sees it
+type Server struct {
+	Program    string  `json:"program"`    // scsynth or supernova ("" until reported)
+	SampleRate int     `json:"sampleRate"` // 0 until reported
+	BlockSize  int     `json:"blockSize"`  // 0 until reported
+	CPUUsage   float32 `json:"cpuUsage"`   // Last CPU usage reported
+	LinkLost   bool    `json:"linkLost"`   // Whether its reports stopped arriving
+}
+
+// System describes the computer and the SuperCollider installation
+type System struct {
+	OS            string        `json:"os"`
+	Arch          string        `json:"arch"`
+	GoVersion     string        `json:"goVersion"`
+	CPUs          int           `json:"cpus"`
+	Terminal      string        `json:"terminal"` // TERM
+	Locale        string        `json:"locale"`   // LANG
+	SuperCollider SuperCollider `json:"superCollider"`
+}
+
+// SuperCollider describes the SuperCollider installation
+type SuperCollider struct {
+	Sclang        string      `json:"sclang"`        // Path of sclang ("" when not found)
+	Version       string      `json:"version"`       // Version sclang reports
+	StartedBySelf bool        `json:"startedBySelf"` // Whether collidertracker started the running SuperCollider
+	Supernova     bool        `json:"supernova"`     // Whether a managed SuperCollider boots supernova
+	Jack          bool        `json:"jack"`          // Whether JACK is used
+	Extensions    []Extension `json:"extensions"`
+}
+
+// Extension is how a required SuperCollider extension is installed
+type Extension struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Installed string `json:"installed,omitempty"` // Version collidertracker installed
+}
+
+// Folder returns where reports are written, next to config.json ("" when there is no config
+// directory)
+func Folder() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "collidertracker", "reports")
+}
+
+// FileName returns the name of a report written at t
+func FileName(t time.Time) string {
+	return "collidertracker-report-" + t.Format("2006-01-02-15-04-05") + ".zip"
+}
+
+// Summarize summarizes a project. Call it from the goroutine that updates the model.
+func Summarize(m *model.Model) Project {
+	sampleRate, blockSize := m.ServerAudioInfo()
+	p := Project{
+		Version:        m.Version,
+		View:           int(m.ViewMode),
+		Playing:        m.IsPlaying,
+		BPM:            m.BPM,
+		PPQ:            m.PPQ,
+		Instrument:     summarizePool(m, false),
+		Sampler:        summarizePool(m, true),
+		Samples:        len(m.FileMetadata),
+		PerTrackBanks:  m.PerTrackBanks,
+		GenerativeSong: m.GenerativeSong,
+		EcoMode:        m.EcoMode,
+		Autosave:       m.Autosave,
+		Protected:      m.ProjectKey != nil,
+		Server: Server{
+			Program:    m.ServerProgramName(),
+			SampleRate: sampleRate,
+			BlockSize:  blockSize,
+			CPUUsage:   m.CPUUsage,
+			LinkLost:   m.IsOSCLinkLost(),
+		},
+		Config:      m.Config,
+		LastUICrash: m.LastUICrash,
+		Diagnostics: append([]model.DiagnosticsSample(nil), m.Diagnostics()...),
+	}
+	for track := 0; track < types.NumTracks; track++ {
+		t := Track{
+			Sampler:    m.TrackTypes[track],
+			Engine:     m.TrackEngines[track],
+			Level:      m.TrackSetLevels[track],
+			Resolution: m.TrackResolutions[track],
+		}
+		for _, chain := range m.SongData[track] {
+			if chain >= 0 {
+				t.SongRows++
+			}
+		}
+		p.Tracks = append(p.Tracks, t)
+	}
+	return p
+}
+
+// summarizePool counts the chains and phrases of the instrument or sampler pool
+func summarizePool(m *model.Model, sampler bool) Pool {
+	var pool Pool
+	for _, usage := range m.ChainUsage(sampler) {
+		switch usage {
+		case model.SlotUsed:
+			pool.Chains++
+		case model.SlotUnreferenced:
+			pool.UnusedChains++
+		}
+	}
+	for _, usage := range m.PhraseUsage(sampler) {
+		switch usage {
+		case model.SlotUsed:
+			pool.Phrases++
+		case model.SlotUnreferenced:
+			pool.UnusedPhrases++
+		}
+	}
+	return pool
+}
+
+// CollectSystem describes the computer and SuperCollider. It runs sclang to ask its version,
+// so call it off the UI goroutine.
+func CollectSystem() System {
+	sc := SuperCollider{
+		Sclang:        supercollider.SclangPath(),
+		Version:       supercollider.SclangVersion(),
+		StartedBySelf: supercollider.WasStartedBySelf(),
+		Supernova:     supercollider.UsesSupernova(),
+		Jack:          supercollider.IsJackEnabled(),
+	}
+	for _, state := range supercollider.CheckExtensions() {
+		sc.Extensions = append(sc.Extensions, Extension{
+			Name:      state.Extension.Name,
+			Status:    state.Status.String(),
+			Installed: state.InstalledVersion,
+		})
+	}
+	return System{
+		OS:            runtime.GOOS,
+		Arch:          runtime.GOARCH,
+		GoVersion:     runtime.Version(),
+		CPUs:          runtime.NumCPU(),
+		Terminal:      os.Getenv("TERM"),
+		Locale:        os.Getenv("LANG"),
+		SuperCollider: sc,
+	}
+}
+
+// Write writes a report to out with the latest log lines, redacted
+func Write(out string, project Project, system System) error {
+	projectJSON, err := json.MarshalIndent(project, "", "  ")
+	if err != nil {
+		return err
+	}
+	systemJSON, err := json.MarshalIndent(system, "", "  ")
+	if err != nil {
+		return err
+	}
+	entries := []struct {
+		name string
+		data []byte
+	}{
+		{logEntry, logging.Recent()},
+		{projectEntry, projectJSON},
+		{systemEntry, systemJSON},
+	}
+
+	if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(out), ".report-*")
+	if err != nil {
+		return fmt.Errorf("cannot write next to %s: %v", out, err)
+	}
+	defer os.Remove(tmp.Name())
+	zw := zip.NewWriter(tmp)
+	r := newRedactor()
+	for _, entry := range entries {
+		w, err := zw.Create(entry.name)
+		if err == nil {
+			_, err = w.Write(r.redact(entry.data))
+		}
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	err = zw.Close()
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %v", out, err)
+	}
+	return os.Rename(tmp.Name(), out)
+}
+
+// redactor replaces what identifies the user in a report: the home folder, then the user and
+// host names wherever else they appear
+type redactor struct {
+	replacements []string // Pairs of old and new, as for strings.NewReplacer
+}
+
+func newRedactor() redactor {
+	var r redactor
+	if home, err := os.UserHomeDir(); err == nil && len(home) > 1 {
+		r.add(home, "~")
+		if slashed := filepath.ToSlash(home); slashed != home {
+			r.add(slashed, "~")
+		}
+		// JSON escapes the backslashes of Windows paths
+		if escaped, _ := json.Marshal(home); len(escaped) > 2 {
+			r.add(string(escaped[1:len(escaped)-1]), "~")
+		}
+	}
+	if u, err := user.Current(); err == nil {
+		name := u.Username
+		if i := strings.LastIndexAny(name, `\/`); i >= 0 {
+			name = name[i+1:] // DOMAIN\user on Windows
+		}
+		r.add(name, "<user>")
+	}
+	if host, err := os.Hostname(); err == nil && host != "localhost" {
+		r.add(host, "<host>")
+	}
+	return r
+}
+
+// add redacts old as new. Names too short to tell apart from other text are kept.
+func (r *redactor) add(old, new string) {
+	if len(old) >= 3 {
+		r.replacements = append(r.replacements, old, new)
+	}
+}
+
+func (r redactor) redact(data []byte) []byte {
+	if len(r.replacements) == 0 {
+		return data
+	}
+	var out bytes.Buffer
+	_, _ = strings.NewReplacer(r.replacements...).WriteString(&out, string(data))
+	return out.Bytes()
+}
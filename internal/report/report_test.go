@@ -0,0 +1,62 @@
+package report
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// readEntries reads the files of a report by name
+func readEntries(t *testing.T, path string) map[string]string {
+	zr, err := zip.OpenReader(path)
+	assert.NoError(t, err)
+	defer zr.Close()
+	entries := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		assert.NoError(t, err)
+		rc.Close()
+		entries[f.Name] = string(data)
+	}
+	return entries
+}
+
+func TestWrite(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	m := model.NewModel(0, filepath.Join(home, "songs", "first"), false)
+	m.TrackTypes[0] = false
+	m.SongData[0][0] = 1
+	m.InstrumentChainsData[1][0] = 2
+	m.InstrumentPhrasesData[2][0][0] = 60
+	m.Config.ProjectDir = filepath.Join(home, "songs")
+	logging.Storage.Error("Cannot save", "path", filepath.Join(home, "songs", "first", "data.json.gz"))
+
+	project := Summarize(m)
+	assert.Len(t, project.Tracks, 8)
+	assert.Equal(t, 1, project.Tracks[0].SongRows)
+	assert.Equal(t, 1, project.Instrument.Chains)
+
+	out := filepath.Join(t.TempDir(), "reports", FileName(project.LastUICrash))
+	assert.NoError(t, Write(out, project, System{OS: "plan9"}))
+	entries := readEntries(t, out)
+	assert.Len(t, entries, 3)
+
+	assert.Contains(t, entries[logEntry], `"path":"~/songs/first/data.json.gz"`)
+	assert.NotContains(t, entries[projectEntry], home, "Paths in the home folder are redacted")
+	var written Project
+	assert.NoError(t, json.Unmarshal([]byte(entries[projectEntry]), &written))
+	assert.Equal(t, "~/songs", written.Config.ProjectDir)
+	assert.Contains(t, entries[systemEntry], `"os": "plan9"`)
+}
@@ -0,0 +1,440 @@
+// Package setup is the first-run wizard. It checks the SuperCollider installation and its
+// extensions, then asks for the projects folder, the default MIDI device and the key scheme,
+// which are written to the config file.
+package setup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/midiconnector"
+	"github.com/schollz/collidertracker/internal/supercollider"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// Step is a page of the wizard
+type Step int
+
+const (
+	StepAudio      Step = iota // SuperCollider, started by ColliderTracker or by the user
+	StepExtensions             // Required SuperCollider extensions
+	StepProjects               // Folder for new projects
+	StepMidi                   // Default MIDI device of instruments
+	StepKeys                   // Standard or vim-style cursor keys
+	StepSummary                // Review and save
+)
+
+// Environment is what the wizard found on this machine
+type Environment struct {
+	Sclang      string                         // Path of sclang ("" when SuperCollider is not installed)
+	NeedsJack   bool                           // Whether SuperCollider plays through a JACK server here
+	JackRunning bool                           // Whether a JACK server is running
+	SCRunning   bool                           // Whether sclang is already running
+	Extensions  []supercollider.ExtensionState // Required extensions and whether they are installed
+	MidiDevices []string                       // MIDI devices connected
+}
+
+// Detect looks for SuperCollider, JACK, the extensions and the MIDI devices
+func Detect() Environment {
+	env := Environment{
+		Sclang:      supercollider.SclangPath(),
+		NeedsJack:   runtime.GOOS == "linux",
+		SCRunning:   supercollider.IsSuperColliderEnabled(),
+		Extensions:  supercollider.CheckExtensions(),
+		MidiDevices: midiconnector.Devices(),
+	}
+	if env.NeedsJack {
+		env.JackRunning = supercollider.IsJackEnabled()
+	}
+	return env
+}
+
+// DefaultProjectDir is the projects folder offered when none is set
+func DefaultProjectDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, "ColliderTracker")
+}
+
+// Wizard is the first-run setup: one step per page, each ending with enter
+type Wizard struct {
+	width      int
+	height     int
+	env        Environment
+	config     types.AppConfig
+	step       Step
+	selected   int                       // Choice on the current step
+	projectDir string                    // Projects folder being typed
+	installing string                    // Extension being installed ("" when idle)
+	queue      []supercollider.Extension // Extensions left to install
+	message    string                    // Result of the last install
+	err        error                     // Error of the last failed install
+	done       bool                      // Whether the wizard was completed
+}
+
+// NewWizard starts the setup from the options in effect
+func NewWizard(env Environment, cfg types.AppConfig) *Wizard {
+	w := &Wizard{env: env, config: cfg, projectDir: cfg.ProjectDir}
+	if w.projectDir == "" {
+		w.projectDir = DefaultProjectDir()
+	}
+	w.selectCurrent()
+	return w
+}
+
+// Init is required for tea.Model interface
+func (w *Wizard) Init() tea.Cmd {
+	return nil
+}
+
+// audioChoices are the ways SuperCollider can run, by AppConfig.SkipSC
+var audioChoices = []string{"Start SuperCollider with ColliderTracker", "I start SuperCollider myself"}
+
+// keyChoices are the key schemes, by AppConfig.Vim
+var keyChoices = []string{"Standard (arrow keys)", "Vim (h/j/k/l, arrows also work)"}
+
+// midiChoices are the MIDI devices instruments can default to; "" is the first one found
+func (w *Wizard) midiChoices() []string {
+	return append([]string{"", "None"}, w.env.MidiDevices...)
+}
+
+// choices returns the options of the current step (none for steps that are not a list)
+func (w *Wizard) choices() []string {
+	switch w.step {
+	case StepAudio:
+		return audioChoices
+	case StepMidi:
+		return w.midiChoices()
+	case StepKeys:
+		return keyChoices
+	}
+	return nil
+}
+
+// selectCurrent selects the choice the config holds on the current step
+func (w *Wizard) selectCurrent() {
+	w.selected = 0
+	switch w.step {
+	case StepAudio:
+		if w.config.SkipSC {
+			w.selected = 1
+		}
+	case StepMidi:
+		for i, device := range w.midiChoices() {
+			if device == w.config.MidiDevice {
+				w.selected = i
+			}
+		}
+	case StepKeys:
+		if w.config.Vim {
+			w.selected = 1
+		}
+	}
+}
+
+// accept takes the choice of the current step into the config and moves to the next step
+func (w *Wizard) accept() tea.Cmd {
+	switch w.step {
+	case StepAudio:
+		w.config.SkipSC = w.selected == 1
+	case StepProjects:
+		w.config.ProjectDir = expandHome(strings.TrimSpace(w.projectDir))
+	case StepMidi:
+		w.config.MidiDevice = w.midiChoices()[w.selected]
+	case StepKeys:
+		w.config.Vim = w.selected == 1
+	case StepSummary:
+		w.done = true
+		return tea.Quit
+	}
+	w.step++
+	w.selectCurrent()
+	return nil
+}
+
+// expandHome replaces a leading ~ with the home folder
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// installCompleteMsg reports the end of one extension install
+type installCompleteMsg struct {
+	name string
+	err  error
+}
+
+// startInstall begins installing the next extension in the queue
+func (w *Wizard) startInstall() tea.Cmd {
+	if len(w.queue) == 0 {
+		return nil
+	}
+	ext := w.queue[0]
+	w.queue = w.queue[1:]
+	w.installing = ext.Name
+	w.message = ""
+	w.err = nil
+	return func() tea.Msg {
+		return installCompleteMsg{name: ext.Name, err: supercollider.InstallExtension(ext)}
+	}
+}
+
+// missingExtensions counts the extensions that need to be installed or updated
+func (w *Wizard) missingExtensions() int {
+	missing := 0
+	for _, state := range w.env.Extensions {
+		if state.Status.NeedsInstall() {
+			missing++
+		}
+	}
+	return missing
+}
+
+// Update handles one step at a time; esc goes back a step and ctrl+c quits
+func (w *Wizard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		w.width = msg.Width
+		w.height = msg.Height
+		return w, nil
+
+	case installCompleteMsg:
+		w.installing = ""
+		w.env.Extensions = supercollider.CheckExtensions()
+		if msg.err != nil {
+			logging.UI.Errorf("Failed to install %s: %v", msg.name, msg.err)
+			w.err = msg.err
+			w.queue = nil
+			return w, nil
+		}
+		w.message = fmt.Sprintf("%s installed", msg.name)
+		return w, w.startInstall()
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return w, tea.Quit
+		}
+		if w.installing != "" {
+			return w, nil // Wait for the running install
+		}
+
+		if w.step == StepProjects {
+			switch msg.Type {
+			case tea.KeyRunes:
+				w.projectDir += string(msg.Runes)
+				return w, nil
+			case tea.KeySpace:
+				w.projectDir += " "
+				return w, nil
+			case tea.KeyBackspace:
+				if runes := []rune(w.projectDir); len(runes) > 0 {
+					w.projectDir = string(runes[:len(runes)-1])
+				}
+				return w, nil
+			}
+		}
+
+		switch msg.String() {
+		case "up", "k":
+			if w.selected > 0 {
+				w.selected--
+			}
+		case "down", "j":
+			if w.selected < len(w.choices())-1 {
+				w.selected++
+			}
+		case "a", "i":
+			if w.step == StepExtensions {
+				w.queue = nil
+				for _, state := range w.env.Extensions {
+					if state.Status.NeedsInstall() {
+						w.queue = append(w.queue, state.Extension)
+					}
+				}
+				return w, w.startInstall()
+			}
+		case "enter":
+			return w, w.accept()
+		case "esc":
+			if w.step > StepAudio {
+				w.step--
+				w.selectCurrent()
+			}
+		}
+	}
+	return w, nil
+}
+
+// View shows the current step in a centered dialog
+func (w *Wizard) View() string {
+	dialogWidth := 72
+	if w.width > 0 && dialogWidth > w.width-4 {
+		dialogWidth = w.width - 4
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1, 2).
+		Width(dialogWidth - 4)
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("15")).
+		Background(lipgloss.Color("205"))
+	okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(fmt.Sprintf("ColliderTracker setup (%d/%d)", int(w.step)+1, int(StepSummary)+1)))
+	content.WriteString("\n\n")
+
+	switch w.step {
+	case StepAudio:
+		content.WriteString("Audio\n\n")
+		if w.env.Sclang != "" {
+			content.WriteString(okStyle.Render("SuperCollider found: "+w.env.Sclang) + "\n")
+		} else {
+			content.WriteString(errorStyle.Render("SuperCollider not found. Install it from supercollider.github.io") + "\n")
+		}
+		if w.env.NeedsJack {
+			if w.env.JackRunning {
+				content.WriteString(okStyle.Render("JACK server running") + "\n")
+			} else {
+				content.WriteString(errorStyle.Render("JACK server not running; start it before playing") + "\n")
+			}
+		}
+		if w.env.SCRunning {
+			content.WriteString("sclang is already running\n")
+		}
+		content.WriteString("\n")
+	case StepExtensions:
+		content.WriteString("SuperCollider extensions\n\n")
+		for _, state := range w.env.Extensions {
+			status := state.Status.String()
+			if state.Extension.Name == w.installing {
+				status = "installing..."
+			}
+			line := fmt.Sprintf("  %-14s %-16s %s", state.Extension.Name, state.Extension.RequiredVersion(), status)
+			if state.Status.NeedsInstall() {
+				line = errorStyle.Render(line)
+			}
+			content.WriteString(line + "\n")
+		}
+		content.WriteString("\n")
+		switch {
+		case w.installing != "":
+			content.WriteString(fmt.Sprintf("Installing %s...", w.installing))
+		case w.err != nil:
+			content.WriteString(errorStyle.Render(fmt.Sprintf("Install failed: %v", w.err)))
+		case w.message != "":
+			content.WriteString(okStyle.Render(w.message))
+		case w.missingExtensions() == 0:
+			content.WriteString(okStyle.Render("All extensions are installed"))
+		}
+		content.WriteString("\n")
+	case StepProjects:
+		content.WriteString("Folder for new projects\n\n")
+		content.WriteString("  " + selectedStyle.Render(w.projectDir+" ") + "\n\n")
+		content.WriteString("Leave it empty to create projects in the working directory.\n")
+	case StepMidi:
+		content.WriteString("MIDI device instruments play to by default\n\n")
+		if len(w.env.MidiDevices) == 0 {
+			content.WriteString("No MIDI devices are connected.\n\n")
+		}
+	case StepKeys:
+		content.WriteString("Cursor keys\n\n")
+	case StepSummary:
+		content.WriteString("Settings to save\n\n")
+		content.WriteString(fmt.Sprintf("  %-14s %s\n", "SuperCollider", audioChoices[boolIndex(w.config.SkipSC)]))
+		content.WriteString(fmt.Sprintf("  %-14s %s\n", "Projects", orDefault(w.config.ProjectDir, "working directory")))
+		content.WriteString(fmt.Sprintf("  %-14s %s\n", "MIDI", orDefault(w.config.MidiDevice, "first device found")))
+		content.WriteString(fmt.Sprintf("  %-14s %s\n", "Keys", keyChoices[boolIndex(w.config.Vim)]))
+		content.WriteString("\nRun collidertracker --setup to change them later. SC and Vim are also\nin the App column of the Settings view.\n")
+	}
+
+	for i, choice := range w.choices() {
+		if w.step == StepMidi && choice == "" {
+			choice = "First device found"
+		}
+		if i == w.selected {
+			content.WriteString("> " + selectedStyle.Render(choice) + "\n")
+		} else {
+			content.WriteString("  " + choice + "\n")
+		}
+	}
+
+	help := "up/down: choose | enter: next | esc: back | ctrl+c: quit"
+	switch w.step {
+	case StepExtensions:
+		help = "a: install missing | enter: next | esc: back | ctrl+c: quit"
+	case StepProjects:
+		help = "type the folder | enter: next | esc: back | ctrl+c: quit"
+	case StepSummary:
+		help = "enter: save and start | esc: back | ctrl+c: quit"
+	}
+	content.WriteString("\n" + helpStyle.Render(help))
+
+	return lipgloss.NewStyle().
+		Width(w.width).
+		Height(w.height).
+		Align(lipgloss.Center).
+		AlignVertical(lipgloss.Center).
+		Render(dialogStyle.Render(content.String()))
+}
+
+// boolIndex is 1 for true, to pick from two choices
+func boolIndex(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// orDefault returns value, or what an empty value stands for
+func orDefault(value, empty string) string {
+	if value == "" {
+		return empty
+	}
+	return value
+}
+
+// Done reports whether the wizard was completed
+func (w *Wizard) Done() bool {
+	return w.done
+}
+
+// Config returns the options chosen in the wizard
+func (w *Wizard) Config() types.AppConfig {
+	return w.config
+}
+
+// Run shows the wizard starting from cfg and returns the options chosen, or false when the
+// user quit
+func Run(cfg types.AppConfig) (types.AppConfig, bool) {
+	wizard := NewWizard(Detect(), cfg)
+	p := tea.NewProgram(wizard, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		logging.UI.Errorf("Error running setup: %v", err)
+		return cfg, false
+	}
+	if !wizard.Done() {
+		return cfg, false
+	}
+	return wizard.Config(), true
+}
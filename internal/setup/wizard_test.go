@@ -0,0 +1,76 @@
+package setup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+func press(w *Wizard, keys ...tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	for _, key := range keys {
+		_, cmd = w.Update(key)
+	}
+	return cmd
+}
+
+var (
+	enter = tea.KeyMsg{Type: tea.KeyEnter}
+	down  = tea.KeyMsg{Type: tea.KeyDown}
+	esc   = tea.KeyMsg{Type: tea.KeyEsc}
+)
+
+func TestWizard(t *testing.T) {
+	env := Environment{NeedsJack: true, MidiDevices: []string{"Keystep", "Volca"}}
+	w := NewWizard(env, types.AppConfig{Port: 57120})
+	w.width, w.height = 100, 40
+	assert.Contains(t, w.View(), "SuperCollider not found")
+	assert.Contains(t, w.View(), "JACK server not running")
+
+	// Audio: run SuperCollider myself
+	press(w, down, enter)
+	assert.True(t, w.Config().SkipSC)
+	assert.Equal(t, StepExtensions, w.step)
+
+	// Extensions, then the projects folder, typed over the default
+	press(w, enter)
+	assert.Equal(t, StepProjects, w.step)
+	for range []rune(w.projectDir) {
+		press(w, tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	press(w, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("~/songs")}, enter)
+	home, _ := os.UserHomeDir()
+	assert.Equal(t, filepath.Join(home, "songs"), w.Config().ProjectDir)
+
+	// MIDI: first choice is the first device found, then None, then the devices
+	assert.Contains(t, w.View(), "First device found")
+	press(w, down, down, enter)
+	assert.Equal(t, "Keystep", w.Config().MidiDevice)
+
+	// Going back keeps the choice
+	press(w, esc)
+	assert.Equal(t, StepMidi, w.step)
+	assert.Equal(t, 2, w.selected)
+	press(w, enter)
+
+	// Keys: vim
+	press(w, down, enter)
+	assert.True(t, w.Config().Vim)
+	assert.Equal(t, StepSummary, w.step)
+	assert.Contains(t, w.View(), "Keystep")
+
+	assert.NotNil(t, press(w, enter))
+	assert.True(t, w.Done())
+	assert.Equal(t, 57120, w.Config().Port, "Options the wizard does not ask about are kept")
+}
+
+func TestWizardQuit(t *testing.T) {
+	w := NewWizard(Environment{}, types.AppConfig{})
+	assert.NotNil(t, press(w, tea.KeyMsg{Type: tea.KeyCtrlC}))
+	assert.False(t, w.Done())
+}
@@ -0,0 +1,185 @@
+// Package simulate plays a project on a simulated clock, without SuperCollider, MIDI devices or
+// a terminal, and records every message playback sends out. Tests use it to pin down the exact
+// events the sequencer produces, and `collidertracker simulate` prints them.
+package simulate
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/schollz/collidertracker/internal/input"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// Epoch is when simulated playback starts
+var Epoch = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Clock is a playback clock that only moves when it is advanced
+type Clock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []timer
+	seq    int // Orders timers due at the same time by when they were set
+}
+
+// timer is a function waiting for the clock to reach at
+type timer struct {
+	at  time.Time
+	seq int
+	f   func()
+}
+
+// NewClock returns a clock that stands at start
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *Clock) AfterFunc(d time.Duration, f func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq++
+	c.timers = append(c.timers, timer{at: c.now.Add(d), seq: c.seq, f: f})
+}
+
+// AdvanceTo moves the clock to t, calling the functions that fall due on the way in order, each
+// at its time. Functions set while advancing run too when they fall due by t.
+func (c *Clock) AdvanceTo(t time.Time) {
+	for {
+		c.mu.Lock()
+		next := -1
+		for i, tm := range c.timers {
+			if tm.at.After(t) {
+				continue
+			}
+			if next < 0 || tm.at.Before(c.timers[next].at) || (tm.at.Equal(c.timers[next].at) && tm.seq < c.timers[next].seq) {
+				next = i
+			}
+		}
+		if next < 0 {
+			if t.After(c.now) {
+				c.now = t
+			}
+			c.mu.Unlock()
+			return
+		}
+		due := c.timers[next]
+		c.timers = append(c.timers[:next], c.timers[next+1:]...)
+		if due.at.After(c.now) {
+			c.now = due.at
+		}
+		c.mu.Unlock()
+		due.f()
+	}
+}
+
+// Event is a message playback sent, and when
+type Event struct {
+	Tick int           // Tick of the playback clock that sent it, or the last tick before it (0 for playback start)
+	Time time.Duration // Since playback started
+	model.Sent
+}
+
+// String formats an event as one line: tick, seconds, destination, address and arguments
+func (e Event) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%5d %8.3fs %s %s", e.Tick, e.Time.Seconds(), e.To, e.Address)
+	for _, arg := range e.Args {
+		switch v := arg.(type) {
+		case string:
+			fmt.Fprintf(&b, " %q", v)
+		case float32:
+			fmt.Fprintf(&b, " %g", v)
+		default:
+			fmt.Fprintf(&b, " %v", v)
+		}
+	}
+	return b.String()
+}
+
+// Start is where simulated playback starts, like Space in a view
+type Start struct {
+	Mode   types.ViewMode // types.SongView, ChainView or PhraseView
+	Track  int            // Track whose chain or phrase plays (chain and phrase playback)
+	Chain  int            // Chain to play (chain playback)
+	Phrase int            // Phrase to play (phrase playback)
+	Row    int            // Row to start on: of the song, the chain or the phrase
+}
+
+// Simulation plays a model on a simulated clock and records the messages it sends. Between
+// Advance calls tests can act on the model like keys do, such as queueing a jump.
+type Simulation struct {
+	Model  *model.Model
+	Clock  *Clock
+	mu     sync.Mutex // Messages can come from the functions the clock calls
+	events []Event
+	tick   int // Tick of the playback clock being run
+}
+
+// New sends everything m plays to a new simulation instead of out. m keeps sending to the
+// simulation afterwards, so use a model loaded for it only.
+func New(m *model.Model) *Simulation {
+	s := &Simulation{Model: m, Clock: NewClock(Epoch)}
+	m.Simulate(s.Clock, func(sent model.Sent) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.events = append(s.events, Event{Tick: s.tick, Time: s.Clock.Now().Sub(Epoch), Sent: sent})
+	})
+	return s
+}
+
+// Start starts playback, stopping any playback first
+func (s *Simulation) Start(start Start) {
+	m := s.Model
+	if m.IsPlaying {
+		input.TogglePlayback(m)
+	}
+	m.ViewMode = start.Mode
+	m.CurrentTrack, m.CurrentChain, m.CurrentPhrase, m.CurrentRow = start.Track, start.Chain, start.Phrase, start.Row
+	input.TogglePlayback(m) // The command only schedules the first tick, which Advance runs
+}
+
+// Advance runs the next ticks of the playback clock, fewer when playback stops by itself, and
+// returns how many ran. Playback starts on tick 0, so Advance(1) runs tick 1.
+func (s *Simulation) Advance(ticks int) int {
+	m := s.Model
+	ran := 0
+	for ; ran < ticks && m.IsPlaying; ran++ {
+		s.Clock.AdvanceTo(input.NextTickAt(m))
+		s.mu.Lock()
+		s.tick = m.PlaybackTickCount
+		s.mu.Unlock()
+		input.StepPlayback(m)
+	}
+	return ran
+}
+
+// Finish lets the arpeggio notes and humanized rows still waiting play
+func (s *Simulation) Finish() {
+	s.Clock.AdvanceTo(s.Clock.Now().Add(time.Minute))
+}
+
+// Events returns the messages sent so far, oldest first
+func (s *Simulation) Events() []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Event(nil), s.events...)
+}
+
+// Run plays m from start up to the given tick of the playback clock, or until playback stops
+// by itself, and returns every message sent, those of playback start on tick 0 included
+func Run(m *model.Model, start Start, ticks int) []Event {
+	s := New(m)
+	s.Start(start)
+	s.Advance(ticks)
+	s.Finish()
+	return s.Events()
+}
@@ -0,0 +1,166 @@
+package simulate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/schollz/collidertracker/internal/input"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// newSong returns a model whose track 0 plays PolyPerc: song row r plays chain r, whose one
+// phrase r plays rows 0 and 2 with notes base+r*12 and base+r*12+2, one tick each
+func newSong(t *testing.T, songRows ...int) *model.Model {
+	m := model.NewModel(0, t.TempDir(), false)
+	m.BPM = 120
+	m.TrackTypes[0] = false
+	m.SoundMakerSettings[0] = types.SoundMakerSettings{Name: "PolyPerc"}
+	m.SoundMakerSettings[0].InitializeParameters()
+	for _, r := range songRows {
+		m.SongData[0][r] = r
+		m.InstrumentChainsData[r][0] = r
+		for _, row := range []int{0, 2} {
+			m.InstrumentPhrasesData[r][row][types.ColNote] = 60 + r*12 + row
+			m.InstrumentPhrasesData[r][row][types.ColDeltaTime] = 1
+			m.InstrumentPhrasesData[r][row][types.ColSoundMaker] = 0
+		}
+	}
+	return m
+}
+
+// notes formats the instrument notes among events as tick, time and note
+func notes(events []Event) []string {
+	var out []string
+	for _, e := range events {
+		if e.To == "sc" && e.Address == "/instrument" {
+			out = append(out, fmt.Sprintf("%d %v %v", e.Tick, e.Time, e.Args[3]))
+		}
+	}
+	return out
+}
+
+func TestRun(t *testing.T) {
+	events := Run(newSong(t, 0, 1), Start{Mode: types.SongView}, 5)
+	if assert.NotEmpty(t, events) {
+		assert.Equal(t, "    0    0.000s sc /start", events[0].String())
+	}
+	// Song rows 0 and 1, then back to row 0 since row 2 is empty
+	assert.Equal(t, []string{
+		"0 0s 60",
+		"1 250ms 62",
+		"2 500ms 72",
+		"3 750ms 74",
+		"4 1s 60",
+		"5 1.25s 62",
+	}, notes(events))
+
+	// The same project plays the same way every run
+	assert.Equal(t, events, Run(newSong(t, 0, 1), Start{Mode: types.SongView}, 5))
+
+	// Phrase playback loops the phrase
+	assert.Equal(t, []string{"0 0s 72", "1 250ms 74", "2 500ms 72"},
+		notes(Run(newSong(t, 0, 1), Start{Mode: types.PhraseView, Phrase: 1}, 2)))
+}
+
+func TestRowTempo(t *testing.T) {
+	// Song row 1 at half the tempo: its ticks are twice as long, from the end of row 0's last tick
+	m := newSong(t, 0, 1)
+	m.SetSongRowBPM(1, 60)
+	assert.Equal(t, []string{
+		"0 0s 60",
+		"1 250ms 62",
+		"2 500ms 72",
+		"3 1s 74",
+		"4 1.5s 60",
+		"5 1.75s 62",
+	}, notes(Run(m, Start{Mode: types.SongView}, 5)))
+
+	// Swing 58 makes the first tick of every pair longer, and row 1 plays it straight
+	m = newSong(t, 0, 1)
+	m.Groove = 2
+	m.SetSongRowGroove(1, 1)
+	assert.Equal(t, []string{
+		"0 0s 60",
+		"1 290ms 62",
+		"2 500ms 72",
+		"3 750ms 74",
+		"4 1s 60",
+		"5 1.29s 62",
+	}, notes(Run(m, Start{Mode: types.SongView}, 5)))
+
+	// Overrides only apply to song playback
+	assert.Equal(t, []string{"0 0s 72", "1 250ms 74", "2 500ms 72"},
+		notes(Run(newSong(t, 0, 1), Start{Mode: types.PhraseView, Phrase: 1}, 2)))
+}
+
+func TestSessionMarkers(t *testing.T) {
+	m := newSong(t, 0, 1, 2)
+	m.SetCue(1, "DROP")
+	s := New(m)
+	input.ToggleSessionRecording(m) // Stopped: the take starts with the first row played
+	s.Start(Start{Mode: types.SongView})
+	s.Advance(9)
+
+	var markers []string
+	for _, marker := range m.SessionMarkers {
+		markers = append(markers, fmt.Sprintf("%s %v", marker.Name, marker.At.Sub(m.SessionTakeStart)))
+	}
+	// Rows 1 and 2 are the drop, and the song loops back to the start after row 2
+	assert.Equal(t, []string{"Start 0s", "DROP 500ms", "Start 2 1.5s", "DROP 2 2s"}, markers)
+}
+
+func TestBeatFlash(t *testing.T) {
+	pulses := func(flash int) []string {
+		m := newSong(t, 0, 1)
+		m.Config.OSCEngines = []types.OSCEngine{{Name: "light", Port: 9000, Address: "/light"}}
+		m.BeatFlash, m.BeatPulse = flash, "light"
+		var out []string
+		for _, e := range Run(m, Start{Mode: types.SongView}, 9) {
+			if e.To == "engine:light" {
+				out = append(out, fmt.Sprintf("%v %s %v", e.Time, e.Address, e.Args[0]))
+			}
+		}
+		return out
+	}
+	// A beat is two ticks at PPQ 2
+	assert.Equal(t, []string{"0s /light/beat 1", "500ms /light/beat 2", "1s /light/beat 3", "1.5s /light/beat 4", "2s /light/beat 1"}, pulses(types.BeatFlashBeat))
+	assert.Equal(t, []string{"0s /light/beat 1", "2s /light/beat 1"}, pulses(types.BeatFlashBar))
+	assert.Empty(t, pulses(types.BeatFlashOff))
+}
+
+func TestJump(t *testing.T) {
+	s := New(newSong(t, 0, 1, 3))
+	s.Start(Start{Mode: types.SongView})
+	s.Advance(1)
+
+	// Space on song row 3 of the playing track jumps there, over row 1, once the playing chain ends
+	m := s.Model
+	m.CurrentCol, m.CurrentRow = 0, 3
+	input.ToggleSingleTrackPlayback(m)
+	s.Advance(3)
+	s.Finish()
+	assert.Equal(t, []string{"0 0s 60", "1 250ms 62", "2 500ms 96", "3 750ms 98", "4 1s 60"}, notes(s.Events()))
+}
+
+func TestClock(t *testing.T) {
+	c := NewClock(Epoch)
+	var got []string
+	c.AfterFunc(2*time.Second, func() { got = append(got, "b") })
+	c.AfterFunc(time.Second, func() {
+		got = append(got, "a")
+		c.AfterFunc(time.Second, func() { got = append(got, "c") }) // Due with b, set after it
+	})
+	c.AfterFunc(3*time.Second, func() { got = append(got, "d") })
+
+	c.AdvanceTo(Epoch.Add(2 * time.Second))
+	assert.Equal(t, []string{"a", "b", "c"}, got)
+	assert.Equal(t, Epoch.Add(2*time.Second), c.Now())
+	c.AdvanceTo(Epoch.Add(time.Second)) // The clock never goes back
+	assert.Equal(t, Epoch.Add(2*time.Second), c.Now())
+	c.AdvanceTo(Epoch.Add(time.Hour))
+	assert.Equal(t, []string{"a", "b", "c", "d"}, got)
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// ConfigPath returns where the startup options are kept ("" when there is no config directory)
+func ConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "collidertracker", "config.json")
+}
+
+// LoadConfig reads the config file at path into cfg; options missing from the file keep their value
+func LoadConfig(path string, cfg *types.AppConfig) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, cfg)
+}
+
+// SaveConfig writes cfg to the config file at path, replacing it atomically
+func SaveConfig(path string, cfg types.AppConfig) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, path)
+}
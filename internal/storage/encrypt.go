@@ -0,0 +1,254 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// A password-protected project keeps its save file, snapshots and other files (see workfolder.go)
+// sealed with AES-256-GCM. Each file starts with lockedMagic and the salt the key was derived
+// with, followed by the nonce and the sealed contents; the save data is gzipped first.
+const (
+	lockedMagic   = "CTLOCK1\n"
+	saltSize      = 16
+	keyIterations = 600000 // PBKDF2-SHA256 rounds, so guessing passwords is slow
+)
+
+// sealOverhead is how many bytes sealing adds to a file: the header, the GCM nonce and its tag
+const sealOverhead = len(lockedMagic) + saltSize + 12 + 16
+
+var (
+	// ErrPasswordRequired is returned when a password-protected project is read without its key
+	ErrPasswordRequired = errors.New("the project is password protected")
+	// ErrWrongPassword is returned when a project's key does not open its save file
+	ErrWrongPassword = errors.New("wrong password")
+)
+
+// deriveKey derives the key of a password with a salt
+func deriveKey(password string, salt []byte) (*model.ProjectKey, error) {
+	key, err := pbkdf2.Key(sha256.New, password, salt, keyIterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ProjectKey{Salt: salt, Key: key}, nil
+}
+
+// newGCM returns the cipher of a key
+func newGCM(key *model.ProjectKey) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key.Key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// packSaveData gzips save data, and seals it when key is not nil
+func packSaveData(key *model.ProjectKey, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	gzWriter := gzip.NewWriter(&buf)
+	if _, err := gzWriter.Write(data); err != nil {
+		return nil, err
+	}
+	if err := gzWriter.Close(); err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return buf.Bytes(), nil
+	}
+	return sealData(key, buf.Bytes())
+}
+
+// unpackSaveData returns the save data of a file's contents, opening them with key when sealed
+func unpackSaveData(key *model.ProjectKey, raw []byte) ([]byte, error) {
+	if isSealed(raw) {
+		var err error
+		if raw, err = openData(key, raw); err != nil {
+			return nil, err
+		}
+	}
+	gzReader, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	defer gzReader.Close()
+	return io.ReadAll(gzReader)
+}
+
+// sealData seals data with key behind a header holding the key's salt
+func sealData(key *model.ProjectKey, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	header := append([]byte(lockedMagic), key.Salt...)
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return append(append(header, nonce...), gcm.Seal(nil, nonce, data, header)...), nil
+}
+
+// openData returns the data sealed in raw (ErrWrongPassword when key does not open it)
+func openData(key *model.ProjectKey, raw []byte) ([]byte, error) {
+	if key == nil {
+		return nil, ErrPasswordRequired
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	headerSize := len(lockedMagic) + saltSize
+	if len(raw) < headerSize+gcm.NonceSize() || !bytes.Equal(raw[len(lockedMagic):headerSize], key.Salt) {
+		return nil, ErrWrongPassword
+	}
+	nonce := raw[headerSize : headerSize+gcm.NonceSize()]
+	data, err := gcm.Open(nil, nonce, raw[headerSize+gcm.NonceSize():], raw[:headerSize])
+	if err != nil {
+		return nil, ErrWrongPassword
+	}
+	return data, nil
+}
+
+// isSealed reports whether a file's contents are sealed with a project key
+func isSealed(raw []byte) bool {
+	return bytes.HasPrefix(raw, []byte(lockedMagic))
+}
+
+// IsProjectLocked reports whether the project in saveFolder is password protected
+func IsProjectLocked(saveFolder string) bool {
+	file, err := os.Open(filepath.Join(saveFolder, "data.json.gz"))
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	header := make([]byte, len(lockedMagic))
+	_, err = io.ReadFull(file, header)
+	return err == nil && string(header) == lockedMagic
+}
+
+// UnlockProject derives the key of the password-protected project in saveFolder from its
+// password, and keeps it in m when it opens the save file (ErrWrongPassword when it doesn't)
+func UnlockProject(m *model.Model, saveFolder, password string) error {
+	raw, err := os.ReadFile(filepath.Join(saveFolder, "data.json.gz"))
+	if err != nil {
+		return err
+	}
+	if len(raw) < len(lockedMagic)+saltSize || string(raw[:len(lockedMagic)]) != lockedMagic {
+		return fmt.Errorf("%s is not password protected", saveFolder)
+	}
+	key, err := deriveKey(password, raw[len(lockedMagic):len(lockedMagic)+saltSize])
+	if err != nil {
+		return err
+	}
+	if _, err := unpackSaveData(key, raw); err != nil {
+		return err
+	}
+	m.ProjectKey = key
+	return nil
+}
+
+// SetProjectPassword protects the project with a password, or removes the protection when
+// password is "". The project is saved at once, its files and snapshots are rewritten with the
+// new key and the backup of the previous save is removed, so no copy is left readable the old
+// way. Protecting the project moves its files to a work folder (see workfolder.go), and
+// removing the protection moves them back.
+func SetProjectPassword(m *model.Model, password string) error {
+	var key *model.ProjectKey
+	if password != "" {
+		salt := make([]byte, saltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return err
+		}
+		var err error
+		if key, err = deriveKey(password, salt); err != nil {
+			return err
+		}
+	}
+
+	// Snapshots and files are read with the old key before it is replaced
+	oldKey := m.ProjectKey
+	snapshots := make(map[string][]byte)
+	for _, path := range listSnapshots(m) {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		data, err := unpackSaveData(oldKey, raw)
+		if err != nil {
+			logging.Storage.Debugf("Removing unreadable snapshot %s: %v", path, err)
+			os.Remove(path)
+			continue
+		}
+		snapshots[path] = data
+	}
+	CancelAutoSave()
+	if m.WorkFolder != "" {
+		sealWorkFolder(m, false, m.FilesRecording()...)
+	}
+
+	// The project is reloaded from the folder its files move to
+	moved := (key != nil) != (m.WorkFolder != "")
+	data, err := encodeSaveData(m)
+	if err != nil {
+		return err
+	}
+	if key == nil && m.WorkFolder != "" {
+		unsealWorkFolder(m)
+	} else if key != nil && m.WorkFolder == "" {
+		if err := openWorkFolder(m, m.SaveFolder); err != nil {
+			return err
+		}
+	}
+	m.ProjectKey = key
+	if moved {
+		var saveData types.SaveData
+		if err := json.Unmarshal(data, &saveData); err != nil {
+			return err
+		}
+		view, row, col, scroll := m.ViewMode, m.CurrentRow, m.CurrentCol, m.ScrollOffset
+		if err := applySaveData(m, &saveData, m.ProjectFolder()); err != nil {
+			return err
+		}
+		m.ViewMode, m.CurrentRow, m.CurrentCol, m.ScrollOffset = view, row, col, scroll // Stay in the Settings view
+	}
+	if key != nil {
+		sealWorkFolder(m, true)
+	}
+
+	DoSave(m)
+	for path, data := range snapshots {
+		payload, err := packSaveData(key, data)
+		if err == nil {
+			err = os.WriteFile(path, payload, 0644)
+		}
+		if err != nil {
+			logging.Storage.Errorf("Error rewriting snapshot %s: %v", path, err)
+			os.Remove(path)
+		}
+	}
+	os.Remove(filepath.Join(m.SaveFolder, "data.json.gz.bak"))
+	if IsProjectLocked(m.SaveFolder) != (key != nil) {
+		return fmt.Errorf("could not save %s", m.SaveFolder)
+	}
+
+	if key == nil {
+		logging.Storage.Debugf("Removed the password of %s", m.SaveFolder)
+	} else {
+		logging.Storage.Debugf("Password protected %s", m.SaveFolder)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// KitExtension is the file extension of track kits
+const KitExtension = ".ctkit"
+
+// Names inside a track kit: the kit itself, and the folder of its samples
+const (
+	kitEntry      = "kit.json"
+	kitSamplesDir = "samples/"
+)
+
+// KitsFolder returns the library track kits are written to and imported from, shared by all
+// projects ("" when there is no config directory)
+func KitsFolder() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "collidertracker", "kits")
+}
+
+// ListTrackKits returns the track kits in folder, sorted by name
+func ListTrackKits(folder string) []string {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil
+	}
+	var kits []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && strings.EqualFold(filepath.Ext(entry.Name()), KitExtension) {
+			kits = append(kits, filepath.Join(folder, entry.Name()))
+		}
+	}
+	sort.Strings(kits)
+	return kits
+}
+
+// WriteTrackKit writes kit to path as a zip holding the kit and its samples. samples maps the
+// names in kit.Files to the sample files to bundle.
+func WriteTrackKit(path string, kit types.TrackKit, samples map[string]string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tempPath := path + ".tmp"
+	file, err := os.Create(tempPath)
+	if err != nil {
+		return err
+	}
+	err = writeTrackKit(file, kit, samples)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	return os.Rename(tempPath, path)
+}
+
+// writeTrackKit writes the zip of a track kit to w
+func writeTrackKit(w io.Writer, kit types.TrackKit, samples map[string]string) error {
+	zw := zip.NewWriter(w)
+	entry, err := zw.Create(kitEntry)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(entry).Encode(kit); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(samples))
+	for name := range samples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		data, err := os.ReadFile(samples[name])
+		if err != nil {
+			return fmt.Errorf("failed to read sample %s: %w", samples[name], err)
+		}
+		entry, err := zw.CreateHeader(&zip.FileHeader{Name: kitSamplesDir + name, Method: zip.Deflate})
+		if err != nil {
+			return err
+		}
+		if _, err := entry.Write(data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// ReadTrackKit reads the kit of the track kit at path
+func ReadTrackKit(path string) (types.TrackKit, error) {
+	var kit types.TrackKit
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return kit, err
+	}
+	defer r.Close()
+	entry, err := r.Open(kitEntry)
+	if err != nil {
+		return kit, fmt.Errorf("%s is not a track kit", filepath.Base(path))
+	}
+	defer entry.Close()
+	if err := json.NewDecoder(entry).Decode(&kit); err != nil {
+		return kit, fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	return kit, nil
+}
+
+// UnpackKitSamples copies the samples of the track kit at path into a project folder and
+// returns where each sample name went. A sample already in the folder with the same contents
+// is reused; a different file of the same name is kept and the sample gets a numbered name.
+func UnpackKitSamples(path, saveFolder string) (map[string]string, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	placed := make(map[string]string)
+	for _, f := range r.File {
+		name, ok := strings.CutPrefix(f.Name, kitSamplesDir)
+		if !ok || name == "" || name != filepath.Base(name) || name == ".." {
+			continue
+		}
+		src, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(src)
+		src.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sample %s: %w", name, err)
+		}
+		dest, err := placeSample(saveFolder, name, data)
+		if err != nil {
+			return nil, err
+		}
+		placed[name] = dest
+	}
+	return placed, nil
+}
+
+// placeSample writes a sample into the project folder under name, or under name-2, name-3,
+// ... when a different file has the name, and returns its path
+func placeSample(saveFolder, name string, data []byte) (string, error) {
+	if err := os.MkdirAll(saveFolder, 0755); err != nil {
+		return "", err
+	}
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for n := 1; ; n++ {
+		candidate := name
+		if n > 1 {
+			candidate = fmt.Sprintf("%s-%d%s", base, n, ext)
+		}
+		dest := filepath.Join(saveFolder, candidate)
+		existing, err := os.ReadFile(dest)
+		if err == nil {
+			if bytes.Equal(existing, data) {
+				return dest, nil
+			}
+			continue
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		if err := os.WriteFile(dest, data, 0644); err != nil {
+			return "", err
+		}
+		return dest, nil
+	}
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// maxSnapshots is how many snapshots a project keeps before the oldest are removed
+const maxSnapshots = 10
+
+// snapshotTimeFormat names snapshots so they sort oldest first
+const snapshotTimeFormat = "20060102150405.000"
+
+// snapshotLabelChars are the characters of an operation label that are kept in file names
+var snapshotLabelChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// SnapshotFolder returns where a project keeps the snapshots taken before bulk operations
+func SnapshotFolder(m *model.Model) string {
+	return filepath.Join(m.SaveFolder, "snapshots")
+}
+
+// Snapshot writes the project as it is now to its snapshots folder, named after the
+// operation about to change it, and removes the oldest snapshots beyond maxSnapshots
+func Snapshot(m *model.Model, operation string) (string, error) {
+	data, err := encodeSaveData(m)
+	if err != nil {
+		return "", err
+	}
+	folder := SnapshotFolder(m)
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return "", err
+	}
+	label := strings.Trim(snapshotLabelChars.ReplaceAllString(strings.ToLower(operation), "-"), "-")
+	path := filepath.Join(folder, time.Now().Format(snapshotTimeFormat)+"-"+label+".json.gz")
+
+	payload, err := packSaveData(m.ProjectKey, data)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, payload, 0644); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	logging.Storage.Debugf("Snapshot before %s: %s", operation, path)
+
+	snapshots := listSnapshots(m)
+	for len(snapshots) > maxSnapshots {
+		os.Remove(snapshots[0])
+		snapshots = snapshots[1:]
+	}
+	return path, nil
+}
+
+// listSnapshots returns a project's snapshot files, oldest first
+func listSnapshots(m *model.Model) []string {
+	paths, _ := filepath.Glob(filepath.Join(SnapshotFolder(m), "*.json.gz"))
+	sort.Strings(paths)
+	return paths
+}
+
+// SnapshotOperation returns the operation a snapshot was taken before, from its file name
+func SnapshotOperation(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), ".json.gz")
+	if _, label, ok := strings.Cut(name, "-"); ok {
+		return strings.ReplaceAll(label, "-", " ")
+	}
+	return name
+}
+
+// LatestSnapshot returns the most recent snapshot of a project
+func LatestSnapshot(m *model.Model) (string, bool) {
+	snapshots := listSnapshots(m)
+	if len(snapshots) == 0 {
+		return "", false
+	}
+	return snapshots[len(snapshots)-1], true
+}
+
+// RevertSnapshot restores the project from its most recent snapshot, undoing the operation
+// it was taken before, and removes it so the next revert goes one operation further back.
+// It returns the operation that was reverted.
+func RevertSnapshot(m *model.Model) (string, error) {
+	path, ok := LatestSnapshot(m)
+	if !ok {
+		return "", fmt.Errorf("no snapshots in %s", SnapshotFolder(m))
+	}
+	saveData, err := loadSaveData(path, m.ProjectKey)
+	if err != nil {
+		return "", err
+	}
+	if err := applySaveData(m, saveData, m.ProjectFolder()); err != nil {
+		return "", err
+	}
+	os.Remove(path)
+	m.Publish(model.Event{Kind: model.EventSettings}) // The reverted project is not saved yet
+	operation := SnapshotOperation(path)
+	logging.Storage.Debugf("Reverted %s from %s", operation, path)
+	return operation, nil
+}
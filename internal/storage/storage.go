@@ -1,19 +1,20 @@
 package storage
 
 import (
-	"compress/gzip"
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
-	"log"
-	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
+	"github.com/schollz/collidertracker/internal/logging"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/types"
 )
@@ -21,12 +22,19 @@ import (
 var json = jsoniter.ConfigCompatibleWithStandardLibrary
 
 var (
-	mu           sync.Mutex
-	timer        *time.Timer
-	debounceTime = 1 * time.Second
+	mu       sync.Mutex
+	timer    *time.Timer
+	lastSave time.Time // When the project was last written
 )
 
+// AutoSave schedules a save once the project's autosave delay passes without another change,
+// and no sooner than its autosave interval after the last save. In eco mode the save waits for
+// playback to stop. It does nothing when the project is saved manually.
 func AutoSave(m *model.Model) {
+	if !m.Autosave {
+		return
+	}
+
 	mu.Lock()
 	defer mu.Unlock()
 
@@ -35,32 +43,73 @@ func AutoSave(m *model.Model) {
 		timer.Stop()
 	}
 
+	delay := m.AutosaveDelay()
+	if wait := time.Until(lastSave.Add(m.AutosaveInterval())); wait > delay {
+		delay = wait
+	}
+
 	// Start a new timer
-	timer = time.AfterFunc(debounceTime, func() {
-		// Place your actual save logic here
-		go func() {
-			startTime := time.Now()
-			DoSave(m)
-			elapsed := time.Since(startTime).Milliseconds()
-			log.Printf("autosaved in %d ms", elapsed)
-		}()
+	timer = time.AfterFunc(delay, func() { autoSaveNow(m) })
+}
+
+// ecoSaveRetry is how often an autosave held back by eco mode checks whether playback stopped
+const ecoSaveRetry = time.Second
+
+// autoSaveNow writes the project, unless eco mode holds the save back until playback stops
+func autoSaveNow(m *model.Model) {
+	if m.EcoMode && m.IsPlaying {
+		mu.Lock()
+		timer = time.AfterFunc(ecoSaveRetry, func() { autoSaveNow(m) })
+		mu.Unlock()
+		return
+	}
+	go func() {
+		startTime := time.Now()
+		DoSave(m)
+		elapsed := time.Since(startTime).Milliseconds()
+		logging.Storage.Debugf("autosaved in %d ms", elapsed)
+	}()
+}
+
+// AutoSaveOnChange autosaves whenever song data, settings or the view change
+func AutoSaveOnChange(m *model.Model) {
+	m.Subscribe(func(e model.Event) {
+		if e.Kind == model.EventData || e.Kind == model.EventSettings || e.Kind == model.EventView {
+			AutoSave(m)
+		}
 	})
 }
 
-func DoSave(m *model.Model) {
-	log.Printf("doing save")
+// CancelAutoSave drops a pending autosave
+func CancelAutoSave() {
+	mu.Lock()
+	defer mu.Unlock()
+	if timer != nil {
+		timer.Stop()
+		timer = nil
+	}
+}
 
+// Flush cancels a pending autosave and saves immediately (used before exiting)
+func Flush(m *model.Model) {
+	CancelAutoSave()
+	DoSave(m)
+}
+
+// encodeSaveData bundles the project's sampler files into its save folder and returns the
+// project as save file JSON
+func encodeSaveData(m *model.Model) ([]byte, error) {
 	// Create save folder and copy sampler files, then get relative paths
-	log.Printf("Saving SamplerPhrasesFiles: %v", m.SamplerPhrasesFiles)
-	relativePaths, err := createSaveFolder(m.SaveFolder, m.SamplerPhrasesFiles, m.FileMetadata)
+	logging.Storage.Debugf("Saving SamplerPhrasesFiles: %v", m.SamplerPhrasesFiles)
+	relativePaths, err := createSaveFolder(m.ProjectFolder(), m.SamplerPhrasesFiles, m.FileMetadata)
 	if err != nil {
-		log.Printf("Error creating save folder: %v", err)
+		logging.Storage.Errorf("Error creating save folder: %v", err)
 		// Continue with normal save without bundling
 		relativePaths = m.SamplerPhrasesFiles
 	} else {
-		log.Printf("Created save folder: %s", m.SaveFolder)
+		logging.Storage.Debugf("Created save folder: %s", m.ProjectFolder())
 	}
-	log.Printf("Relative paths for save: %v", relativePaths)
+	logging.Storage.Debugf("Relative paths for save: %v", relativePaths)
 
 	// Prepare FileMetadata with relative WaveformFile paths for portability
 	portableFileMetadata := make(map[string]types.FileMetadata)
@@ -70,11 +119,11 @@ func DoSave(m *model.Model) {
 		
 		// Convert WaveformFile to relative path if it's within save folder
 		if portableMetadata.WaveformFile != "" {
-			relPath, err := filepath.Rel(m.SaveFolder, portableMetadata.WaveformFile)
+			relPath, err := filepath.Rel(m.ProjectFolder(), portableMetadata.WaveformFile)
 			if err == nil && !strings.HasPrefix(relPath, "..") {
 				// It's within the save folder, store as relative path
 				portableMetadata.WaveformFile = relPath
-				log.Printf("Storing WaveformFile as relative path for %s: %s", filepath.Base(filePath), relPath)
+				logging.Storage.Debugf("Storing WaveformFile as relative path for %s: %s", filepath.Base(filePath), relPath)
 			}
 		}
 		
@@ -130,16 +179,106 @@ func DoSave(m *model.Model) {
 		CurrentTrack:               m.CurrentTrack,
 		TrackSetLevels:             m.TrackSetLevels,
 		TrackTypes:                 m.TrackTypes,
+		TrackEngines:               m.SavedTrackEngines(),
+		TrackResolutions:           m.TrackResolutions,
+		RecordQuantize:             m.RecordQuantize,
+		RecordQuantizeStrength:     &m.RecordQuantizeStrength,
+		HumanizeTiming:             m.HumanizeTiming,
+		HumanizeVelocity:           m.HumanizeVelocity,
 		CurrentMixerTrack:          m.CurrentMixerTrack,
 		DuckingSettings:            m.DuckingSettings,
 		DuckingEditingIndex:        m.DuckingEditingIndex,
 		SOColumnMode:               m.SOColumnMode,
 		MidiCCNumbers:              m.MidiCCNumbers,
+		SkipDeleteConfirm:          !m.ConfirmDeletes,
+		SkipAudition:               !m.AuditionEdits,
+		BounceRepeats:              m.BounceRepeats,
+		BounceNormalize:            m.BounceNormalize,
+		SessionPreRoll:             m.SessionPreRoll,
+		NudgeFine:                  m.NudgeFine,
+		NudgeCoarse:                m.NudgeCoarse,
+		SplashMode:                 m.SplashMode,
+		ImportMode:                 m.ImportMode,
+		FrameRate:                  m.UIFrameRate,
+		StartCountdown:             m.StartCountdown,
+		PreRoll:                    m.PreRoll,
+		WaveformDetail:             m.WaveformDetail,
+		NoAnimations:               !m.Animations,
+		Palette:                    m.Palette,
+		ManualSave:                 !m.Autosave,
+		AutosaveDelayMS:            m.AutosaveDelayMS,
+		AutosaveIntervalS:          m.AutosaveIntervalS,
+		RandomSeed:                 m.RandomSeed,
+		FadeMS:                     m.FadeMS,
+		JumpCrossfade:              m.JumpCrossfade,
+		PerTrackBanks:              m.PerTrackBanks,
+		InputMonitor:               m.InputMonitor,
+		InputDisarmed:              !m.InputArmed,
+		InputInsert:                m.InputInsert,
+		ReverbImpulse:              m.ReverbImpulse,
+		Reverb:                     &m.Reverb,
+		MidiSync:                   &m.MidiSync,
+		MixerSlot:                  m.MixerSlot,
+		MixerOther:                 &m.MixerOther,
+		MasterChain:                m.MasterChain,
+		GenerativeSong:             m.GenerativeSong,
+		Notes:                      m.NotesText(),
+		Groove:                     m.Groove,
+		TunerReference:             m.TunerReference,
+		BeatFlash:                  m.BeatFlash,
+		BeatPulse:                  m.BeatPulse,
+		Generator:                  &m.Generator,
+		AuditLog:                   m.AuditEntries(),
+	}
+
+	if m.Preview.Active {
+		saveData.BPM = m.Preview.BPM // A previewed tempo is only saved once it is kept
+	}
+	if m.HasCues() {
+		saveData.SongCues = m.SongCues[:]
+	}
+	if m.HasSongRowTempos() {
+		saveData.SongRowTempos = m.SongRowTempos[:]
+	}
+	if m.HasQuickSlots() {
+		saveData.QuickSlots = make([]string, model.QuickSlotCount)
+		for slot, file := range m.QuickSlots {
+			saveData.QuickSlots[slot] = file
+			if rel, err := filepath.Rel(m.ProjectFolder(), file); file != "" && err == nil && !strings.HasPrefix(rel, "..") {
+				saveData.QuickSlots[slot] = rel // Samples in the project folder move with it
+			}
+		}
+	}
+	if m.HasSongWeights() {
+		for _, weights := range m.SongWeights {
+			saveData.SongWeights = append(saveData.SongWeights, weights[:])
+		}
+	}
+	if m.HasChainTransposes() {
+		saveData.InstrumentChainTransposes = m.InstrumentChainTransposes
+		saveData.SamplerChainTransposes = m.SamplerChainTransposes
+	}
+	if m.HasChainFX() {
+		saveData.InstrumentChainFX = m.InstrumentChainFX
+		saveData.SamplerChainFX = m.SamplerChainFX
 	}
+	saveData.PLocks = m.PLockList()
+	saveData.InstrumentNames = m.SavedNames(false)
+	saveData.SamplerNames = m.SavedNames(true)
+	if m.ResumePlayback {
+		saveData.ResumePlayback = true
+		saveData.Transport = m.TransportState()
+	}
+
+	return json.Marshal(saveData)
+}
+
+func DoSave(m *model.Model) {
+	logging.Storage.Debugf("doing save")
 
-	data, err := json.Marshal(saveData)
+	data, err := encodeSaveData(m)
 	if err != nil {
-		log.Printf("Error marshaling save data: %v", err)
+		logging.Storage.Errorf("Error marshaling save data: %v", err)
 		return
 	}
 
@@ -150,27 +289,19 @@ func DoSave(m *model.Model) {
 	// Write to temporary file first
 	file, err := os.Create(tempFilePath)
 	if err != nil {
-		log.Printf("Error creating temporary save file: %v", err)
+		logging.Storage.Errorf("Error creating temporary save file: %v", err)
 		return
 	}
 
-	gzWriter := gzip.NewWriter(file)
-
-	_, err = gzWriter.Write(data)
-	if err != nil {
-		gzWriter.Close()
-		file.Close()
-		os.Remove(tempFilePath) // Clean up temp file on error
-		log.Printf("Error writing gzipped save data: %v", err)
-		return
+	// Password-protected projects are sealed with their key
+	payload, err := packSaveData(m.ProjectKey, data)
+	if err == nil {
+		_, err = file.Write(payload)
 	}
-
-	// Close gzip writer to flush all data
-	err = gzWriter.Close()
 	if err != nil {
 		file.Close()
-		os.Remove(tempFilePath)
-		log.Printf("Error closing gzip writer: %v", err)
+		os.Remove(tempFilePath) // Clean up temp file on error
+		logging.Storage.Errorf("Error writing gzipped save data: %v", err)
 		return
 	}
 
@@ -179,7 +310,7 @@ func DoSave(m *model.Model) {
 	if err != nil {
 		file.Close()
 		os.Remove(tempFilePath)
-		log.Printf("Error syncing save file: %v", err)
+		logging.Storage.Errorf("Error syncing save file: %v", err)
 		return
 	}
 
@@ -187,18 +318,90 @@ func DoSave(m *model.Model) {
 	err = file.Close()
 	if err != nil {
 		os.Remove(tempFilePath)
-		log.Printf("Error closing save file: %v", err)
+		logging.Storage.Errorf("Error closing save file: %v", err)
+		return
+	}
+
+	// Read the file back before it replaces the previous save
+	if err := verifySaveFile(tempFilePath, m.ProjectKey, data); err != nil {
+		os.Remove(tempFilePath)
+		logging.Storage.Errorf("Error verifying save file, keeping the previous save: %v", err)
 		return
 	}
 
+	// Keep the previous save as a backup
+	backupSaveFile(dataFilePath)
+
 	// Atomically rename temp file to final file
 	// This is an atomic operation on most filesystems
 	err = os.Rename(tempFilePath, dataFilePath)
 	if err != nil {
 		os.Remove(tempFilePath)
-		log.Printf("Error renaming save file: %v", err)
+		logging.Storage.Errorf("Error renaming save file: %v", err)
 		return
 	}
+
+	// A password-protected project's files are sealed along with it
+	if m.WorkFolder != "" {
+		sealWorkFolder(m, false, m.FilesRecording()...)
+	}
+
+	mu.Lock()
+	lastSave = time.Now()
+	mu.Unlock()
+	m.Publish(model.Event{Kind: model.EventSaved})
+}
+
+// verifySaveFile checks that the file at path opens with key and decompresses to data
+func verifySaveFile(path string, key *model.ProjectKey, data []byte) error {
+	written, err := readSaveFile(path, key)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(written, data) {
+		return fmt.Errorf("%s does not match the saved data", path)
+	}
+	return nil
+}
+
+// backupSaveFile keeps the save at path as path.bak. A hard link leaves the save in place
+// until the new one is renamed over it; file systems without links (FAT SD cards) fall back
+// to a rename.
+func backupSaveFile(path string) {
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+	backupPath := path + ".bak"
+	os.Remove(backupPath)
+	if err := os.Link(path, backupPath); err == nil {
+		return
+	}
+	if err := os.Rename(path, backupPath); err != nil {
+		logging.Storage.Errorf("Error backing up save file: %v", err)
+	}
+}
+
+// readSaveFile returns the decompressed contents of a save file, opening it with key when it
+// is password protected
+func readSaveFile(path string, key *model.ProjectKey) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return unpackSaveData(key, raw)
+}
+
+// loadSaveData reads and decodes a save file
+func loadSaveData(path string, key *model.ProjectKey) (*types.SaveData, error) {
+	data, err := readSaveFile(path, key)
+	if err != nil {
+		return nil, err
+	}
+	var saveData types.SaveData
+	if err := json.Unmarshal(data, &saveData); err != nil {
+		return nil, err
+	}
+	return &saveData, nil
 }
 
 func LoadState(m *model.Model, oscPort int, saveFolder string) error {
@@ -212,34 +415,39 @@ func LoadState(m *model.Model, oscPort int, saveFolder string) error {
 	// Construct path to data.json.gz inside save folder
 	dataFilePath := filepath.Join(saveFolder, "data.json.gz")
 
-	// Open the gzipped save file
-	file, err := os.Open(dataFilePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	gzReader, err := gzip.NewReader(file)
-	if err != nil {
-		return err
+	// Fall back to the backup when the save is missing or damaged (e.g. power lost mid-save)
+	saveData, err := loadSaveData(dataFilePath, m.ProjectKey)
+	if errors.Is(err, ErrPasswordRequired) || errors.Is(err, ErrWrongPassword) {
+		return err // The backup is protected the same way
 	}
-	defer gzReader.Close()
-
-	// Read the decompressed data
-	data, err := io.ReadAll(gzReader)
 	if err != nil {
-		return err
+		backup, backupErr := loadSaveData(dataFilePath+".bak", m.ProjectKey)
+		if backupErr != nil {
+			return err
+		}
+		logging.Storage.Debugf("Save file unreadable (%v), loaded the backup", err)
+		saveData = backup
 	}
 
-	var saveData types.SaveData
-	if err := json.Unmarshal(data, &saveData); err != nil {
-		return err
+	// The files of a password-protected project are read from their decrypted copies
+	if m.ProjectKey != nil {
+		if err := openWorkFolder(m, saveFolder); err != nil {
+			return err
+		}
+		saveFolder = m.WorkFolder
 	}
+	return applySaveData(m, saveData, saveFolder)
+}
 
+// applySaveData restores the project from decoded save data, resolving its files against saveFolder
+func applySaveData(m *model.Model, saveData *types.SaveData, saveFolder string) error {
 	// Force-return to PhraseView from non-main views (keep SongView, ChainView, and MixerView)
 	if saveData.ViewMode == types.FileView ||
 		saveData.ViewMode == types.SettingsView ||
 		saveData.ViewMode == types.FileMetadataView ||
+		saveData.ViewMode == types.LibraryView ||
+		saveData.ViewMode == types.TunerView ||
+		saveData.ViewMode == types.GeneratorView ||
 		saveData.ViewMode == types.RetriggerView ||
 		saveData.ViewMode == types.TimestrechView ||
 		saveData.ViewMode == types.WaveformView {
@@ -279,9 +487,9 @@ func LoadState(m *model.Model, oscPort int, saveFolder string) error {
 			if _, err := os.Stat(resolvedPath); err == nil {
 				metadata.WaveformFile = resolvedPath
 				m.FileMetadata[filePath] = metadata
-				log.Printf("Resolved WaveformFile for %s: %s", filepath.Base(filePath), resolvedPath)
+				logging.Storage.Debugf("Resolved WaveformFile for %s: %s", filepath.Base(filePath), resolvedPath)
 			} else {
-				log.Printf("Warning: WaveformFile not found for %s at: %s", filepath.Base(filePath), resolvedPath)
+				logging.Storage.Warnf("Warning: WaveformFile not found for %s at: %s", filepath.Base(filePath), resolvedPath)
 			}
 		}
 	}
@@ -344,8 +552,163 @@ func LoadState(m *model.Model, oscPort int, saveFolder string) error {
 	m.CurrentTrack = saveData.CurrentTrack
 	m.TrackSetLevels = saveData.TrackSetLevels
 	m.TrackTypes = saveData.TrackTypes
+	m.LoadTrackEngines(saveData.TrackEngines)
+	for track, res := range saveData.TrackResolutions {
+		m.TrackResolutions[track] = 1
+		if model.ValidTrackResolution(res) {
+			m.TrackResolutions[track] = res
+		}
+	}
+	for track, grid := range saveData.RecordQuantize {
+		m.RecordQuantize[track] = max(0, min(len(types.RecordQuantizeNames)-1, grid))
+	}
+	if saveData.RecordQuantizeStrength != nil {
+		for track, strength := range saveData.RecordQuantizeStrength {
+			m.RecordQuantizeStrength[track] = max(0, min(100, strength))
+		}
+	}
+	for track := range m.HumanizeTiming {
+		m.HumanizeTiming[track] = max(0, min(model.MaxHumanizeTiming, saveData.HumanizeTiming[track]))
+		m.HumanizeVelocity[track] = max(0, min(model.MaxHumanizeVelocity, saveData.HumanizeVelocity[track]))
+	}
 	m.CurrentMixerTrack = saveData.CurrentMixerTrack
 	m.SOColumnMode = saveData.SOColumnMode
+	m.ConfirmDeletes = !saveData.SkipDeleteConfirm
+	m.AuditionEdits = !saveData.SkipAudition
+	if saveData.BounceRepeats > 0 {
+		m.BounceRepeats = saveData.BounceRepeats
+	}
+	m.BounceNormalize = 0
+	if saveData.BounceNormalize > 0 && saveData.BounceNormalize < len(model.LoudnessTargets) {
+		m.BounceNormalize = saveData.BounceNormalize
+	}
+	m.SessionPreRoll = 0
+	if slices.Contains(model.PreRollChoices, saveData.SessionPreRoll) {
+		m.SessionPreRoll = saveData.SessionPreRoll
+	}
+	if saveData.NudgeFine > 0 {
+		m.NudgeFine = saveData.NudgeFine
+	}
+	if saveData.NudgeCoarse > 0 {
+		m.NudgeCoarse = saveData.NudgeCoarse
+	}
+	if saveData.SplashMode >= 0 && saveData.SplashMode < len(types.SplashModeNames) {
+		m.SplashMode = saveData.SplashMode
+	}
+	if saveData.ImportMode >= 0 && saveData.ImportMode < len(types.ImportModeNames) {
+		m.ImportMode = saveData.ImportMode
+	}
+	if slices.Contains(types.FrameRates, saveData.FrameRate) {
+		m.UIFrameRate = saveData.FrameRate
+	}
+	if slices.Contains(types.StartCountdowns, saveData.StartCountdown) {
+		m.StartCountdown = saveData.StartCountdown
+	}
+	if slices.Contains(types.PreRolls, saveData.PreRoll) {
+		m.PreRoll = saveData.PreRoll
+	}
+	if saveData.WaveformDetail >= 0 && saveData.WaveformDetail < len(types.WaveformDetailNames) {
+		m.WaveformDetail = saveData.WaveformDetail
+	}
+	m.Animations = !saveData.NoAnimations
+	if saveData.Palette >= 0 && saveData.Palette < len(types.PaletteNames) {
+		m.Palette = saveData.Palette
+	}
+	m.Autosave = !saveData.ManualSave
+	if saveData.AutosaveDelayMS >= model.MinAutosaveDelayMS && saveData.AutosaveDelayMS <= model.MaxAutosaveDelayMS {
+		m.AutosaveDelayMS = saveData.AutosaveDelayMS
+	}
+	if saveData.AutosaveIntervalS >= 0 && saveData.AutosaveIntervalS <= model.MaxAutosaveIntervalS {
+		m.AutosaveIntervalS = saveData.AutosaveIntervalS
+	}
+	if saveData.RandomSeed > 0 && saveData.RandomSeed <= model.MaxRandomSeed {
+		m.RandomSeed = saveData.RandomSeed
+	}
+	if saveData.FadeMS > 0 {
+		m.FadeMS = saveData.FadeMS
+	}
+	if saveData.JumpCrossfade >= 0 && saveData.JumpCrossfade <= model.MaxJumpCrossfade {
+		m.JumpCrossfade = saveData.JumpCrossfade
+	}
+	m.PerTrackBanks = saveData.PerTrackBanks
+	m.InputMonitor = saveData.InputMonitor
+	m.InputArmed = !saveData.InputDisarmed
+	m.ReverbImpulse = saveData.ReverbImpulse
+	if saveData.Reverb != nil {
+		m.Reverb = *saveData.Reverb
+	}
+	if saveData.MidiSync != nil {
+		m.MidiSync = *saveData.MidiSync
+	}
+	m.MixerSlot = 0
+	m.MixerOther = m.MixerState()
+	if saveData.MixerOther != nil {
+		m.MixerSlot = saveData.MixerSlot & 1
+		m.MixerOther = *saveData.MixerOther
+		for track := range m.MixerOther.HumanizeTiming {
+			m.MixerOther.HumanizeTiming[track] = max(0, min(model.MaxHumanizeTiming, m.MixerOther.HumanizeTiming[track]))
+			m.MixerOther.HumanizeVelocity[track] = max(0, min(model.MaxHumanizeVelocity, m.MixerOther.HumanizeVelocity[track]))
+		}
+	}
+	m.SongCues = [types.SongRows]string{}
+	copy(m.SongCues[:], saveData.SongCues)
+	m.Groove = 0
+	if saveData.Groove >= 0 && saveData.Groove < len(model.GrooveTemplates) {
+		m.Groove = saveData.Groove
+	}
+	m.SongRowTempos = [types.SongRows]types.SongRowTempo{}
+	for row, tempo := range saveData.SongRowTempos {
+		if row < types.SongRows {
+			m.SongRowTempos[row] = types.SongRowTempo{
+				BPM:    max(0, min(999, tempo.BPM)),
+				Groove: max(0, min(len(model.GrooveTemplates), tempo.Groove)),
+			}
+		}
+	}
+	m.TunerReference = model.TunerDefaultReference
+	if saveData.TunerReference >= model.TunerMinReference && saveData.TunerReference <= model.TunerMaxReference {
+		m.TunerReference = saveData.TunerReference
+	}
+	m.BeatFlash = types.BeatFlashOff
+	if saveData.BeatFlash >= 0 && saveData.BeatFlash < len(types.BeatFlashNames) {
+		m.BeatFlash = saveData.BeatFlash
+	}
+	m.BeatPulse = saveData.BeatPulse
+	m.Generator = types.DefaultGeneratorSettings()
+	if saveData.Generator != nil {
+		m.Generator = *saveData.Generator
+	}
+	m.QuickSlots = [model.QuickSlotCount]string{}
+	copy(m.QuickSlots[:], resolvePortablePaths(saveFolder, saveData.QuickSlots))
+	m.GenerativeSong = saveData.GenerativeSong
+	m.SetNotesText(saveData.Notes)
+	m.LoadAuditLog(saveData.AuditLog)
+	m.ResumePlayback = saveData.ResumePlayback
+	m.PendingResume = nil
+	if m.ResumePlayback && saveData.Transport != nil {
+		m.PendingResume = saveData.Transport
+	}
+	for track := range m.SongWeights {
+		for row := range m.SongWeights[track] {
+			m.SongWeights[track][row] = model.DefaultSongWeight
+			if track < len(saveData.SongWeights) && row < len(saveData.SongWeights[track]) {
+				m.SongWeights[track][row] = max(0, min(model.MaxSongWeight, saveData.SongWeights[track][row]))
+			}
+		}
+	}
+	m.InstrumentChainTransposes = loadChainTransposes(saveData.InstrumentChainTransposes)
+	m.SamplerChainTransposes = loadChainTransposes(saveData.SamplerChainTransposes)
+	m.InstrumentChainFX = loadChainFX(saveData.InstrumentChainFX)
+	m.SamplerChainFX = loadChainFX(saveData.SamplerChainFX)
+	m.LoadPLocks(saveData.PLocks)
+	m.LoadNames(false, saveData.InstrumentNames)
+	m.LoadNames(true, saveData.SamplerNames)
+	if model.ValidMasterChain(saveData.MasterChain) {
+		m.MasterChain = saveData.MasterChain
+	}
+	if saveData.InputInsert >= 0 && saveData.InputInsert < len(types.InputInsertNames) {
+		m.InputInsert = saveData.InputInsert
+	}
 
 	// Load MIDI CC numbers with defaults (0-8) for backward compatibility
 	if saveData.MidiCCNumbers == [9]int{} {
@@ -376,9 +739,9 @@ func LoadState(m *model.Model, oscPort int, saveFolder string) error {
 	}
 	if saveData.SamplerPhrasesFiles != nil {
 		// Convert relative paths to absolute paths for portable bundles
-		log.Printf("Loading SamplerPhrasesFiles: %v", saveData.SamplerPhrasesFiles)
+		logging.Storage.Debugf("Loading SamplerPhrasesFiles: %v", saveData.SamplerPhrasesFiles)
 		resolvedPaths := resolvePortablePaths(saveFolder, saveData.SamplerPhrasesFiles)
-		log.Printf("Resolved SamplerPhrasesFiles: %v", resolvedPaths)
+		logging.Storage.Debugf("Resolved SamplerPhrasesFiles: %v", resolvedPaths)
 		m.SamplerPhrasesFiles = append([]string(nil), resolvedPaths...)
 	}
 
@@ -392,9 +755,9 @@ func LoadState(m *model.Model, oscPort int, saveFolder string) error {
 	m.PhrasesFiles = append([]string(nil), saveData.PhrasesFiles...)
 
 	// Load metadata for files in save folder
-	err = LoadMetadataFromSaveFolder(saveFolder, m.FileMetadata)
+	err := LoadMetadataFromSaveFolder(saveFolder, m.FileMetadata)
 	if err != nil {
-		log.Printf("Warning: Failed to load metadata from save folder: %v", err)
+		logging.Storage.Warnf("Warning: Failed to load metadata from save folder: %v", err)
 	}
 
 	// Refresh file browser
@@ -408,25 +771,21 @@ func LoadState(m *model.Model, oscPort int, saveFolder string) error {
 	m.SendOSCReverbSendMessage()
 
 	// Send track set levels to OSC on load
-	for track := 0; track < 8; track++ {
+	for track := 0; track < types.NumTracks; track++ {
 		m.SendOSCTrackSetLevelMessage(track)
 	}
 
-	// Initialize per-track RNGs for modulation (if not already initialized)
-	if m.ModulateRngs[0] == nil {
-		for i := 0; i < 8; i++ {
-			m.ModulateRngs[i] = rand.New(rand.NewSource(time.Now().UnixNano() + int64(i)))
-		}
-		log.Printf("Initialized per-track modulation RNGs on load")
-	}
+	// Restart the playback RNGs from the loaded project seed
+	m.ResetRandom()
 
+	m.Publish(model.Event{Kind: model.EventLoaded})
 	return nil
 }
 
 func LoadFiles(m *model.Model) {
 	entries, err := os.ReadDir(m.CurrentDir)
 	if err != nil {
-		log.Printf("Error reading directory %s: %v", m.CurrentDir, err)
+		logging.Storage.Errorf("Error reading directory %s: %v", m.CurrentDir, err)
 		m.Files = []string{}
 		return
 	}
@@ -458,8 +817,7 @@ func LoadFiles(m *model.Model) {
 
 			// Check if it's a regular file or a symlink to a file
 			if stat, err := os.Stat(fullPath); err == nil && !stat.IsDir() {
-				ext := strings.ToLower(filepath.Ext(entry.Name()))
-				if ext == ".wav" || ext == ".flac" {
+				if IsAudioFile(entry.Name()) {
 					files = append(files, entry.Name())
 				}
 			}
@@ -468,7 +826,30 @@ func LoadFiles(m *model.Model) {
 
 	sort.Strings(files[1:]) // Sort everything except ".."
 	m.Files = files
-	log.Printf("Loaded %d files in %s", len(files), m.CurrentDir)
+	logging.Storage.Debugf("Loaded %d files in %s", len(files), m.CurrentDir)
+}
+
+// IsAudioFile reports whether name is a file the sampler can play
+func IsAudioFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".wav" || ext == ".flac"
+}
+
+// ListAudioFiles returns the audio files in dir, sorted by name
+func ListAudioFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		fullPath := filepath.Join(dir, entry.Name())
+		if stat, err := os.Stat(fullPath); err == nil && !stat.IsDir() && IsAudioFile(entry.Name()) {
+			files = append(files, fullPath)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
 }
 
 // createSaveFolder creates the save folder and copies sampler files into it
@@ -499,24 +880,24 @@ func createSaveFolder(saveFolder string, samplerFiles []string, fileMetadata map
 		// Check if the file is already in the save folder by comparing absolute paths
 		absOriginal, err := filepath.Abs(originalPath)
 		if err != nil {
-			log.Printf("Warning: Failed to get absolute path for %s: %v", originalPath, err)
+			logging.Storage.Warnf("Warning: Failed to get absolute path for %s: %v", originalPath, err)
 			absOriginal = originalPath
 		}
 		absDest, err2 := filepath.Abs(destPath)
 		if err2 != nil {
-			log.Printf("Warning: Failed to get absolute path for %s: %v", destPath, err2)
+			logging.Storage.Warnf("Warning: Failed to get absolute path for %s: %v", destPath, err2)
 			absDest = destPath
 		}
 
 		// If source and destination are the same, skip the copy but still save metadata
 		if absOriginal == absDest {
 			relativePaths[i] = fileName
-			log.Printf("File already in save folder: %s (relative: %s)", originalPath, fileName)
+			logging.Storage.Debugf("File already in save folder: %s (relative: %s)", originalPath, fileName)
 			// Still need to save metadata even though we're not copying the file
 			if metadata, exists := fileMetadata[originalPath]; exists {
 				err = saveFileMetadata(saveFolder, originalPath, metadata)
 				if err != nil {
-					log.Printf("Warning: Failed to save metadata for %s: %v", originalPath, err)
+					logging.Storage.Warnf("Warning: Failed to save metadata for %s: %v", originalPath, err)
 				}
 			}
 			continue
@@ -525,7 +906,7 @@ func createSaveFolder(saveFolder string, samplerFiles []string, fileMetadata map
 		// Copy file to save folder
 		err = copyFile(originalPath, destPath)
 		if err != nil {
-			log.Printf("Warning: Failed to copy file %s to %s: %v", originalPath, destPath, err)
+			logging.Storage.Warnf("Warning: Failed to copy file %s to %s: %v", originalPath, destPath, err)
 			// Use original path if copy fails
 			relativePaths[i] = originalPath
 			continue
@@ -535,14 +916,14 @@ func createSaveFolder(saveFolder string, samplerFiles []string, fileMetadata map
 		if metadata, exists := fileMetadata[originalPath]; exists {
 			err = saveFileMetadata(saveFolder, originalPath, metadata)
 			if err != nil {
-				log.Printf("Warning: Failed to save metadata for %s: %v", originalPath, err)
+				logging.Storage.Warnf("Warning: Failed to save metadata for %s: %v", originalPath, err)
 			}
 		}
 
 		// Store just the filename as relative path (since files are in the same folder as data.json.gz)
 		relativePaths[i] = fileName
 
-		log.Printf("Copied file to save folder: %s -> %s (relative: %s)", originalPath, destPath, fileName)
+		logging.Storage.Debugf("Copied file to save folder: %s -> %s (relative: %s)", originalPath, destPath, fileName)
 	}
 
 	return relativePaths, nil
@@ -562,7 +943,7 @@ func copyFile(src, dst string) error {
 
 	// Check if source and destination are the same file
 	if absSrc == absDst {
-		log.Printf("Source and destination are the same file, skipping copy: %s", absSrc)
+		logging.Storage.Debugf("Source and destination are the same file, skipping copy: %s", absSrc)
 		return nil // Not an error, just skip the copy
 	}
 
@@ -608,6 +989,12 @@ func copyFile(src, dst string) error {
 		return fmt.Errorf("failed to set file permissions: %w", err)
 	}
 
+	// Keep the modification time, so an unchanged copy in a work folder is not sealed again
+	err = os.Chtimes(dst, srcInfo.ModTime(), srcInfo.ModTime())
+	if err != nil {
+		return fmt.Errorf("failed to set file times: %w", err)
+	}
+
 	return nil
 }
 
@@ -626,7 +1013,7 @@ func saveFileMetadata(saveFolder, originalPath string, metadata types.FileMetada
 		if err == nil && !strings.HasPrefix(relPath, "..") {
 			// It's within the save folder, store as relative path
 			metadataToSave.WaveformFile = relPath
-			log.Printf("Storing WaveformFile as relative path: %s", relPath)
+			logging.Storage.Debugf("Storing WaveformFile as relative path: %s", relPath)
 		}
 	}
 
@@ -640,7 +1027,7 @@ func saveFileMetadata(saveFolder, originalPath string, metadata types.FileMetada
 		return fmt.Errorf("failed to write metadata file: %w", err)
 	}
 
-	log.Printf("Saved metadata for %s to %s", fileName, metadataPath)
+	logging.Storage.Debugf("Saved metadata for %s to %s", fileName, metadataPath)
 	return nil
 }
 
@@ -671,13 +1058,13 @@ func loadFileMetadata(saveFolder, fileName string) (types.FileMetadata, error) {
 		// Check if the resolved file exists
 		if _, err := os.Stat(resolvedPath); err == nil {
 			metadata.WaveformFile = resolvedPath
-			log.Printf("Resolved WaveformFile: %s -> %s", originalRelPath, resolvedPath)
+			logging.Storage.Debugf("Resolved WaveformFile: %s -> %s", originalRelPath, resolvedPath)
 		} else {
-			log.Printf("Warning: WaveformFile not found at resolved path: %s", resolvedPath)
+			logging.Storage.Warnf("Warning: WaveformFile not found at resolved path: %s", resolvedPath)
 		}
 	}
 
-	log.Printf("Loaded metadata for %s from %s", fileName, metadataPath)
+	logging.Storage.Debugf("Loaded metadata for %s from %s", fileName, metadataPath)
 	return metadata, nil
 }
 
@@ -707,11 +1094,11 @@ func resolvePortablePaths(saveFolder string, paths []string) []string {
 		// Check if the file exists in save folder
 		if _, err := os.Stat(absolutePath); err == nil {
 			resolvedPaths[i] = absolutePath
-			log.Printf("Resolved file from save folder: %s -> %s", path, absolutePath)
+			logging.Storage.Debugf("Resolved file from save folder: %s -> %s", path, absolutePath)
 		} else {
 			// File doesn't exist in save folder, keep original relative path
 			// This handles cases where files were saved before bundling feature
-			log.Printf("Warning: File not found in save folder: %s", absolutePath)
+			logging.Storage.Warnf("Warning: File not found in save folder: %s", absolutePath)
 			resolvedPaths[i] = path
 		}
 	}
@@ -739,14 +1126,14 @@ func LoadMetadataFromSaveFolder(saveFolder string, fileMetadata map[string]types
 			filePath := filepath.Join(saveFolder, fileName)
 			metadata, err := loadFileMetadata(saveFolder, fileName)
 			if err != nil {
-				log.Printf("Warning: Failed to load metadata for %s: %v", fileName, err)
+				logging.Storage.Warnf("Warning: Failed to load metadata for %s: %v", fileName, err)
 				continue
 			}
 
 			// Only add metadata if it has meaningful data (non-zero BPM or slices)
 			if metadata.BPM > 0 || metadata.Slices > 0 {
 				fileMetadata[filePath] = metadata
-				log.Printf("Loaded metadata for %s: BPM=%.1f, Slices=%d", fileName, metadata.BPM, metadata.Slices)
+				logging.Storage.Debugf("Loaded metadata for %s: BPM=%.1f, Slices=%d", fileName, metadata.BPM, metadata.Slices)
 			}
 		}
 	}
@@ -755,8 +1142,8 @@ func LoadMetadataFromSaveFolder(saveFolder string, fileMetadata map[string]types
 }
 
 // migratePhrasesDataColumns expands column arrays to support new columns added in updates
-func migratePhrasesDataColumns(phrasesData *[255][][]int) {
-	for p := 0; p < 255; p++ {
+func migratePhrasesDataColumns(phrasesData *[types.NumPhrases][][]int) {
+	for p := 0; p < types.NumPhrases; p++ {
 		if phrasesData[p] == nil {
 			continue
 		}
@@ -779,6 +1166,32 @@ func migratePhrasesDataColumns(phrasesData *[255][][]int) {
 	}
 }
 
+// loadChainTransposes copies saved chain slot transposes into a full-size set, so saves from
+// before chain transposes (nil) or with a different shape load untransposed where they lack data
+func loadChainTransposes(saved [][]int) [][]int {
+	transposes := model.NewChainTransposes()
+	for chain := 0; chain < len(saved) && chain < len(transposes); chain++ {
+		for row := 0; row < len(saved[chain]) && row < len(transposes[chain]); row++ {
+			transposes[chain][row] = max(-model.MaxChainTranspose, min(model.MaxChainTranspose, saved[chain][row]))
+		}
+	}
+	return transposes
+}
+
+// loadChainFX copies saved chain slot effect overrides into a full-size set, like loadChainTransposes
+func loadChainFX(saved [][]types.ChainFX) [][]types.ChainFX {
+	fx := model.NewChainFX()
+	for chain := 0; chain < len(saved) && chain < len(fx); chain++ {
+		for row := 0; row < len(saved[chain]) && row < len(fx[chain]); row++ {
+			fx[chain][row] = types.ChainFX{
+				Reverb:  max(-1, min(254, saved[chain][row].Reverb)),
+				LowPass: max(-1, min(254, saved[chain][row].LowPass)),
+			}
+		}
+	}
+	return fx
+}
+
 // SaveMetadataForFile saves metadata for a specific file if it exists in the FileMetadata map
 // This can be called whenever a wav file is created to save its associated metadata
 func SaveMetadataForFile(filePath string, fileMetadata map[string]types.FileMetadata) error {
@@ -67,6 +67,504 @@ func TestLoadState(t *testing.T) {
 		assert.Equal(t, types.ChainView, m2.ViewMode)
 	})
 
+	t.Run("fade time round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_fade")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		assert.Equal(t, model.DefaultFadeMS, m1.FadeMS)
+		m1.FadeMS = 40
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, 40, m2.FadeMS)
+	})
+
+	t.Run("splash mode round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_splash")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		assert.Equal(t, types.SplashModeFull, m1.SplashMode)
+		m1.SplashMode = types.SplashModeOff
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, types.SplashModeOff, m2.SplashMode)
+	})
+
+	t.Run("UI performance settings round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_ui")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		DoSave(m1)
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, 30, m2.UIFrameRate)
+		assert.True(t, m2.Animations)
+
+		m1.UIFrameRate = 10
+		m1.WaveformDetail = types.WaveformDetailOff
+		m1.Animations = false
+		DoSave(m1)
+		m3 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m3, 0, saveFolder))
+		assert.Equal(t, 10, m3.UIFrameRate)
+		assert.Equal(t, types.WaveformDetailOff, m3.WaveformDetail)
+		assert.False(t, m3.Animations)
+	})
+
+	t.Run("autosave settings round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_autosave")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.Autosave = false
+		m1.AutosaveDelayMS = 5000
+		m1.AutosaveIntervalS = 60
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.False(t, m2.Autosave)
+		assert.Equal(t, 5000, m2.AutosaveDelayMS)
+		assert.Equal(t, 60, m2.AutosaveIntervalS)
+	})
+
+	t.Run("random seed round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_seed")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.SetRandomSeed(0x1234)
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, 0x1234, m2.RandomSeed)
+		assert.Equal(t, m1.ModulateRngs[3].Int63(), m2.ModulateRngs[3].Int63())
+	})
+
+	t.Run("track resolution round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_resolution")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.SetTrackResolution(5, 4)
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, 4, m2.TrackResolution(5))
+		assert.Equal(t, 1, m2.TrackResolution(0))
+	})
+
+	t.Run("record quantize round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_record_quantize")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.CycleRecordQuantize(3, 1)
+		m1.AdjustRecordQuantizeStrength(3, 25)
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, 1, m2.RecordQuantize[3])
+		assert.Equal(t, 75, m2.RecordQuantizeStrength[3])
+		assert.Equal(t, model.DefaultRecordQuantizeStrength, m2.RecordQuantizeStrength[0])
+	})
+
+	t.Run("humanize round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_humanize")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.AdjustHumanizeTiming(2, 6)
+		m1.AdjustHumanizeVelocity(2, 100)
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, 6, m2.HumanizeTiming[2])
+		assert.Equal(t, model.MaxHumanizeVelocity, m2.HumanizeVelocity[2])
+		assert.Zero(t, m2.HumanizeTiming[0])
+	})
+
+	t.Run("mixer compare round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_mixer_compare")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.TrackSetLevels[1] = -20
+		m1.ToggleMixerCompare()
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, "B", m2.MixerSlotName())
+		assert.Equal(t, float32(-6), m2.TrackSetLevels[1])
+		m2.ToggleMixerCompare()
+		assert.Equal(t, float32(-20), m2.TrackSetLevels[1])
+	})
+
+	t.Run("input monitor round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_monitor")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		assert.False(t, m1.InputMonitor, "monitoring should be off by default")
+		m1.InputMonitor = true
+		m1.InputInsert = 2
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.True(t, m2.InputMonitor)
+		assert.Equal(t, "chorus", types.GetInputInsertName(m2.InputInsert))
+	})
+
+	t.Run("midi sync round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_midi_sync")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		assert.Equal(t, types.DefaultMidiSyncSettings(), m1.MidiSync)
+		m1.MidiSync = types.MidiSyncSettings{Mode: types.MidiSyncTracks, Device: "Drum Machine", Channel: 3}
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, m1.MidiSync, m2.MidiSync)
+	})
+
+	t.Run("song cues round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_song_cues")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.SetCue(4, "DROP")
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, m1.SongCues, m2.SongCues)
+	})
+
+	t.Run("quick-slots round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_quick_slots")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.BindQuickSlot(0, filepath.Join(tmpDir, "kick.wav"))
+		m1.BindQuickSlot(7, filepath.Join(saveFolder, "snare.wav"))
+		DoSave(m1)
+		assert.NoError(t, os.WriteFile(filepath.Join(saveFolder, "snare.wav"), nil, 0644))
+
+		// Samples in the project folder are found where the project is moved to
+		moved := filepath.Join(tmpDir, "moved")
+		assert.NoError(t, os.Rename(saveFolder, moved))
+		m2 := model.NewModel(0, moved, false)
+		assert.NoError(t, LoadState(m2, 0, moved))
+		assert.Equal(t, filepath.Join(tmpDir, "kick.wav"), m2.QuickSlots[0])
+		assert.Equal(t, filepath.Join(moved, "snare.wav"), m2.QuickSlots[7])
+		assert.Empty(t, m2.QuickSlots[1])
+	})
+
+	t.Run("groove and song row tempos round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_row_tempos")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.Groove = 3
+		m1.SetSongRowBPM(4, 70)
+		m1.SetSongRowGroove(5, 1)
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, 3, m2.Groove)
+		assert.Equal(t, types.SongRowTempo{BPM: 70}, m2.SongRowTempos[4])
+		assert.Equal(t, types.SongRowTempo{Groove: 1}, m2.SongRowTempos[5])
+		assert.Equal(t, types.SongRowTempo{}, m2.SongRowTempos[0])
+	})
+
+	t.Run("beat flash round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_beat_flash")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.BeatFlash = types.BeatFlashBar
+		m1.BeatPulse = "light"
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, types.BeatFlashBar, m2.BeatFlash)
+		assert.Equal(t, "light", m2.BeatPulse)
+	})
+
+	t.Run("phrase generator round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_generator")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.Generator.Rhythm = 2
+		m1.Generator.Scale = "dorian"
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, m1.Generator, m2.Generator)
+	})
+
+	t.Run("jump crossfade round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_jump_crossfade")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.JumpCrossfade = 6
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, 6, m2.JumpCrossfade)
+	})
+
+	t.Run("timed start round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_timed_start")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.StartCountdown = 30
+		m1.PreRoll = 4
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, 30, m2.StartCountdown)
+		assert.Equal(t, 4, m2.PreRoll)
+	})
+
+	t.Run("generative song round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_generative_song")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.GenerativeSong = true
+		m1.SetSongWeight(3, 4, 0)
+		m1.SetSongWeight(7, 15, 9)
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.True(t, m2.GenerativeSong)
+		assert.Equal(t, m1.SongWeights, m2.SongWeights)
+	})
+
+	t.Run("palette round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_palette")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.Palette = types.PaletteProtan
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, types.PaletteProtan, m2.Palette)
+	})
+
+	t.Run("notes round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_notes")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.SetNotesText("verse: \"lights out\"\n\n- bring the 303")
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, m1.Notes, m2.Notes)
+	})
+
+	t.Run("bounce normalization round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_normalize")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.BounceNormalize = 2
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, "-9 LUFS", m2.BounceTargetName())
+	})
+
+	t.Run("session pre-roll round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_preroll")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.SessionPreRoll = 4
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, "4 beats", m2.PreRollName())
+	})
+
+	t.Run("edit history round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_history")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.TrackTypes[0] = false
+		m1.SetSongCell(2, 0, 5)
+		m1.SetPhraseCell(0, 1, 0, types.ColGate, 0x40)
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		entries := m2.AuditEntries()
+		assert.Len(t, entries, 2)
+		assert.Equal(t, "phrase 01 row 00: gate -- → 40", entries[1].Text)
+		assert.True(t, entries[1].Time.Equal(m1.AuditEntries()[1].Time))
+	})
+
+	t.Run("transport round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_transport")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.IsPlaying = true
+		m1.PlaybackMode = types.SongView
+		m1.SongPlaybackActive[0], m1.SongPlaybackRow[0] = true, 3
+		m1.SongPlaybackActive[2], m1.SongPlaybackRow[2] = true, 5
+		DoSave(m1)
+
+		// Playback is only kept when the project resumes it
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.False(t, m2.ResumePlayback)
+		assert.Nil(t, m2.PendingResume)
+
+		m1.ResumePlayback = true
+		DoSave(m1)
+		m2 = model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.True(t, m2.ResumePlayback)
+		if assert.NotNil(t, m2.PendingResume) {
+			assert.Equal(t, types.SongView, m2.PendingResume.Mode)
+			assert.Equal(t, [types.NumTracks]int{3, -1, 5, -1, -1, -1, -1, -1}, m2.PendingResume.SongRows)
+		}
+	})
+
+	t.Run("names round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_names")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.TrackTypes[0] = false
+		m1.SetChainName(0, 2, "verse")
+		m1.SetPhraseName(0, 9, "lead #hook")
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, m1.InstrumentNames, m2.InstrumentNames)
+		assert.Empty(t, m2.SamplerNames.Chains)
+	})
+
+	t.Run("track engines round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_track_engines")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.TrackTypes[2] = false
+		m1.TrackEngines[2] = "pd"
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, m1.TrackEngines, m2.TrackEngines)
+	})
+
+	t.Run("chain transpose round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_chain_transpose")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.TrackTypes[0] = false
+		m1.SetChainTranspose(0, 3, 1, -5)
+		m1.SetChainTranspose(4, 2, 0, 12)
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, m1.InstrumentChainTransposes, m2.InstrumentChainTransposes)
+		assert.Equal(t, m1.SamplerChainTransposes, m2.SamplerChainTransposes)
+	})
+
+	t.Run("chain fx round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_chain_fx")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.SetChainFX(4, 2, 0, types.ChainFX{Reverb: 0xA0, LowPass: -1})
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, m1.SamplerChainFX, m2.SamplerChainFX)
+		assert.Equal(t, types.NoChainFX, m2.GetChainFX(4, 2, 1))
+	})
+
+	t.Run("p-locks round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_plocks")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.SetPLock(2, 7, "resonance", 2.25)
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, m1.PLockList(), m2.PLockList())
+	})
+
+	t.Run("previewed tempo is not saved", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_preview")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		m1.BPM = 100
+		m1.StartPreview()
+		m1.PreviewTempo(20)
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, float32(100), m2.BPM)
+	})
+
+	t.Run("reverb settings round trip", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		saveFolder := filepath.Join(tmpDir, "test_reverb")
+
+		m1 := model.NewModel(0, saveFolder, false)
+		assert.Equal(t, types.DefaultReverbSettings(), m1.Reverb)
+		m1.Reverb = types.ReverbSettings{Algorithm: 2, Size: 90, Damp: 10, PreDelayMS: 0, ShimmerVoicing: 1}
+		DoSave(m1)
+
+		m2 := model.NewModel(0, saveFolder, false)
+		assert.NoError(t, LoadState(m2, 0, saveFolder))
+		assert.Equal(t, m1.Reverb, m2.Reverb, "zero pre-delay must survive a reload")
+	})
+
 	t.Run("load nonexistent file", func(t *testing.T) {
 		m := model.NewModel(0, "", false)
 		err := LoadState(m, 0, "/path/that/does/not/exist")
@@ -176,6 +674,265 @@ func TestAutoSave(t *testing.T) {
 	})
 }
 
+func TestManualSave(t *testing.T) {
+	tmpDir := t.TempDir()
+	saveFolder := filepath.Join(tmpDir, "manual_test")
+
+	m := model.NewModel(0, saveFolder, false)
+	m.Autosave = false
+	m.AutosaveDelayMS = model.MinAutosaveDelayMS
+	AutoSaveOnChange(m)
+
+	// Changes are only written by an explicit save
+	m.SetSongCell(0, 0, 3)
+	time.Sleep(3 * m.AutosaveDelay())
+	_, err := os.Stat(filepath.Join(saveFolder, "data.json.gz"))
+	assert.True(t, os.IsNotExist(err))
+	assert.True(t, m.IsDirty())
+
+	Flush(m)
+	assert.False(t, m.IsDirty())
+}
+
+func TestAutoSaveOnChange(t *testing.T) {
+	tmpDir := t.TempDir()
+	saveFolder := filepath.Join(tmpDir, "change_test")
+
+	m := model.NewModel(0, saveFolder, false)
+	AutoSaveOnChange(m)
+
+	// Editing a cell schedules an autosave and marks the project dirty until it is written
+	m.SetSongCell(0, 0, 3)
+	assert.True(t, m.IsDirty())
+	Flush(m)
+	assert.False(t, m.IsDirty())
+
+	loaded := model.NewModel(0, saveFolder, false)
+	assert.NoError(t, LoadState(loaded, 0, saveFolder))
+	assert.Equal(t, 3, loaded.GetSongCell(0, 0))
+	assert.False(t, loaded.IsDirty())
+}
+
+func TestAutoSaveOnViewChange(t *testing.T) {
+	saveFolder := filepath.Join(t.TempDir(), "view_test")
+
+	m := model.NewModel(0, saveFolder, false)
+	m.AutosaveDelayMS = model.MinAutosaveDelayMS
+	AutoSaveOnChange(m)
+
+	// Moving to another view is saved without counting as an unsaved change
+	m.ViewMode = types.ChainView
+	m.Publish(model.Event{Kind: model.EventView})
+	assert.False(t, m.IsDirty())
+
+	dataFile := filepath.Join(saveFolder, "data.json.gz")
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(dataFile)
+		return err == nil
+	}, 3*time.Second, 50*time.Millisecond)
+}
+
+func TestFlush(t *testing.T) {
+	tmpDir := t.TempDir()
+	saveFolder := filepath.Join(tmpDir, "flush_test")
+
+	m := model.NewModel(0, saveFolder, false)
+	m.BPM = 133
+
+	// A pending autosave is replaced by an immediate save
+	AutoSave(m)
+	Flush(m)
+
+	dataFile := filepath.Join(saveFolder, "data.json.gz")
+	_, err := os.Stat(dataFile)
+	assert.NoError(t, err, "Flush should save synchronously")
+
+	loaded := model.NewModel(0, saveFolder, false)
+	assert.NoError(t, LoadState(loaded, 0, saveFolder))
+	assert.Equal(t, float32(133), loaded.BPM)
+}
+
+func TestSaveBackup(t *testing.T) {
+	tmpDir := t.TempDir()
+	saveFolder := filepath.Join(tmpDir, "backup_test")
+	dataFile := filepath.Join(saveFolder, "data.json.gz")
+
+	m := model.NewModel(0, saveFolder, false)
+	m.BPM = 100
+	DoSave(m)
+	m.BPM = 140
+	DoSave(m)
+
+	// The previous save is kept next to the new one
+	backup, err := loadSaveData(dataFile+".bak", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, float32(100), backup.BPM)
+
+	// A damaged save falls back to the backup
+	assert.NoError(t, os.WriteFile(dataFile, []byte("not gzip"), 0644))
+	loaded := model.NewModel(0, saveFolder, false)
+	assert.NoError(t, LoadState(loaded, 0, saveFolder))
+	assert.Equal(t, float32(100), loaded.BPM)
+
+	// Without a readable backup the error is returned
+	assert.NoError(t, os.Remove(dataFile+".bak"))
+	assert.Error(t, LoadState(model.NewModel(0, saveFolder, false), 0, saveFolder))
+}
+
+func TestSnapshots(t *testing.T) {
+	saveFolder := filepath.Join(t.TempDir(), "snapshot_test")
+	m := model.NewModel(0, saveFolder, false)
+	_, ok := LatestSnapshot(m)
+	assert.False(t, ok)
+
+	m.SongData[0][0] = 0x01
+	path, err := Snapshot(m, "Track duplicate")
+	assert.NoError(t, err)
+	assert.Equal(t, "track duplicate", SnapshotOperation(path))
+	m.SongData[0][0] = 0x05
+	m.SongData[1][0] = 0x06
+
+	operation, err := RevertSnapshot(m)
+	assert.NoError(t, err)
+	assert.Equal(t, "track duplicate", operation)
+	assert.Equal(t, 0x01, m.SongData[0][0])
+	assert.Equal(t, -1, m.SongData[1][0])
+	assert.True(t, m.IsDirty(), "The reverted project still has to be saved")
+	_, ok = LatestSnapshot(m)
+	assert.False(t, ok, "A snapshot is used up by reverting it")
+	_, err = RevertSnapshot(m)
+	assert.Error(t, err)
+
+	// Only the most recent snapshots are kept
+	for i := 0; i < maxSnapshots+3; i++ {
+		_, err := Snapshot(m, "renumbering")
+		assert.NoError(t, err)
+		time.Sleep(2 * time.Millisecond) // Snapshot names have millisecond resolution
+	}
+	assert.Len(t, listSnapshots(m), maxSnapshots)
+}
+
+func TestProjectPassword(t *testing.T) {
+	t.Cleanup(CloseProjects)
+	saveFolder := filepath.Join(t.TempDir(), "password_test")
+	m := model.NewModel(0, saveFolder, false)
+	m.BPM = 133
+	DoSave(m)
+	_, err := Snapshot(m, "renumbering")
+	assert.NoError(t, err)
+	assert.False(t, IsProjectLocked(saveFolder))
+
+	assert.NoError(t, SetProjectPassword(m, "correct horse"))
+	assert.True(t, IsProjectLocked(saveFolder))
+	_, err = os.Stat(filepath.Join(saveFolder, "data.json.gz.bak"))
+	assert.True(t, os.IsNotExist(err), "No unencrypted backup is left")
+	_, err = loadSaveData(listSnapshots(m)[0], nil)
+	assert.ErrorIs(t, err, ErrPasswordRequired, "Snapshots are encrypted too")
+
+	// Opening the project needs the password
+	loaded := model.NewModel(0, saveFolder, false)
+	assert.ErrorIs(t, LoadState(loaded, 0, saveFolder), ErrPasswordRequired)
+	assert.ErrorIs(t, UnlockProject(loaded, saveFolder, "wrong horse"), ErrWrongPassword)
+	assert.NoError(t, UnlockProject(loaded, saveFolder, "correct horse"))
+	assert.NoError(t, LoadState(loaded, 0, saveFolder))
+	assert.Equal(t, float32(133), loaded.BPM)
+	_, err = RevertSnapshot(loaded)
+	assert.NoError(t, err)
+
+	// Later saves stay encrypted until the password is removed
+	DoSave(loaded)
+	assert.True(t, IsProjectLocked(saveFolder))
+	assert.NoError(t, SetProjectPassword(loaded, ""))
+	assert.False(t, IsProjectLocked(saveFolder))
+	assert.NoError(t, LoadState(model.NewModel(0, saveFolder, false), 0, saveFolder))
+}
+
+func TestProjectPasswordFiles(t *testing.T) {
+	t.Cleanup(CloseProjects)
+	saveFolder := filepath.Join(t.TempDir(), "password_files_test")
+	assert.NoError(t, os.MkdirAll(filepath.Join(saveFolder, model.RecordingsFolderName), 0755))
+	sample := filepath.Join(saveFolder, "kick.wav")
+	assert.NoError(t, os.WriteFile(sample, []byte("kick"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(saveFolder, model.RecordingsFolderName, "take.wav"), []byte("take"), 0644))
+	m := model.NewModel(0, saveFolder, false)
+	m.SamplerPhrasesFiles = []string{sample}
+	DoSave(m)
+
+	// Protecting the project seals its files and moves the model to their decrypted copies
+	assert.NoError(t, SetProjectPassword(m, "correct horse"))
+	assert.NotEmpty(t, m.WorkFolder)
+	for _, path := range []string{sample, filepath.Join(saveFolder, model.RecordingsFolderName, "take.wav")} {
+		raw, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.True(t, isSealed(raw), "%s is sealed", path)
+	}
+	assert.Equal(t, filepath.Join(m.WorkFolder, "kick.wav"), m.SamplerPhrasesFiles[0])
+	raw, err := os.ReadFile(m.SamplerPhrasesFiles[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "kick", string(raw))
+
+	// New files are sealed when the project is saved, and deleted ones are removed
+	history, err := m.ExportAuditLog()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Remove(filepath.Join(m.RecordingsFolder(), "take.wav")))
+	DoSave(m)
+	raw, err = os.ReadFile(filepath.Join(saveFolder, filepath.Base(history)))
+	assert.NoError(t, err)
+	assert.True(t, isSealed(raw), "The exported history is sealed")
+	_, err = os.Stat(filepath.Join(saveFolder, model.RecordingsFolderName, "take.wav"))
+	assert.True(t, os.IsNotExist(err), "A deleted recording is removed")
+
+	// Opening the project decrypts its files
+	loaded := model.NewModel(0, saveFolder, false)
+	assert.NoError(t, UnlockProject(loaded, saveFolder, "correct horse"))
+	assert.NoError(t, LoadState(loaded, 0, saveFolder))
+	assert.Equal(t, filepath.Join(loaded.WorkFolder, "kick.wav"), loaded.SamplerPhrasesFiles[0])
+	raw, err = os.ReadFile(loaded.SamplerPhrasesFiles[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "kick", string(raw))
+
+	// Removing the password puts the files back as they were
+	workFolder := loaded.WorkFolder
+	assert.NoError(t, SetProjectPassword(loaded, ""))
+	assert.Empty(t, loaded.WorkFolder)
+	assert.NoDirExists(t, workFolder)
+	assert.Equal(t, sample, loaded.SamplerPhrasesFiles[0])
+	raw, err = os.ReadFile(sample)
+	assert.NoError(t, err)
+	assert.Equal(t, "kick", string(raw))
+
+	// Closing leaves no decrypted copies behind
+	workFolder = m.WorkFolder
+	CloseProjects()
+	assert.Empty(t, m.WorkFolder)
+	assert.NoDirExists(t, workFolder)
+}
+
+func TestConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "collidertracker", "config.json")
+
+	cfg := types.AppConfig{Port: 57120}
+	assert.True(t, os.IsNotExist(LoadConfig(path, &cfg)))
+	assert.Equal(t, 57120, cfg.Port)
+
+	assert.NoError(t, SaveConfig(path, types.AppConfig{Port: 57200, Vim: true, Dump: "dump.txt", SkipSC: true, Locale: "es", CheckUpdates: true, ProjectDir: "songs", MidiDevice: "Keystep"}))
+	var loaded types.AppConfig
+	assert.NoError(t, LoadConfig(path, &loaded))
+	assert.Equal(t, types.AppConfig{Port: 57200, Vim: true, Dump: "dump.txt", SkipSC: true, Locale: "es", CheckUpdates: true, ProjectDir: "songs", MidiDevice: "Keystep"}, loaded)
+}
+
+func TestListAudioFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"snare.WAV", "kick.wav", "notes.txt", "hat.flac"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "more.wav"), 0755))
+
+	files, err := ListAudioFiles(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "hat.flac"), filepath.Join(dir, "kick.wav"), filepath.Join(dir, "snare.WAV")}, files)
+}
+
 func TestWaveformFileResolution(t *testing.T) {
 	t.Run("waveform file path is resolved on load", func(t *testing.T) {
 		tmpDir := t.TempDir()
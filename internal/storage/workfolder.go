@@ -0,0 +1,216 @@
+package storage
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/schollz/collidertracker/internal/logging"
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// A password-protected project keeps its samples, recordings, clips and every other file in its
+// folder sealed (see encrypt.go). While it is open they are used from decrypted copies in a
+// private work folder, and each save seals the copies that changed back into the project folder.
+
+var (
+	workMu     sync.Mutex
+	workModels []*model.Model // Projects with an open work folder
+)
+
+// unsealedEntries are the entries of a project folder that are not sealed as files: the save
+// file and its backup and temporary file are sealed whole, snapshots are sealed by Snapshot,
+// and the synths are compiled SynthDefs SuperCollider reads itself
+var unsealedEntries = []string{"data.json.gz", "data.json.gz.bak", "data.json.gz.tmp", "snapshots", model.SynthsFolderName}
+
+// projectFiles returns the paths of the files in folder that are sealed in a password-protected
+// project, relative to folder
+func projectFiles(folder string) []string {
+	var files []string
+	filepath.WalkDir(folder, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Unreadable entries are skipped
+		}
+		rel, relErr := filepath.Rel(folder, path)
+		if relErr != nil || rel == "." {
+			return nil
+		}
+		if slices.Contains(unsealedEntries, rel) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Type().IsRegular() {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files
+}
+
+// openWorkFolder decrypts the files of the password-protected project in saveFolder into a new
+// work folder, which m then reads and writes them in
+func openWorkFolder(m *model.Model, saveFolder string) error {
+	closeWorkFolder(m)
+	workFolder, err := os.MkdirTemp("", "collidertracker-")
+	if err != nil {
+		return err
+	}
+	for _, rel := range projectFiles(saveFolder) {
+		path := filepath.Join(saveFolder, rel)
+		raw, err := os.ReadFile(path)
+		if err == nil && isSealed(raw) {
+			raw, err = openData(m.ProjectKey, raw)
+		}
+		if err != nil {
+			logging.Storage.Errorf("Error opening %s: %v", path, err)
+			continue
+		}
+		if err := writeWorkFile(filepath.Join(workFolder, rel), raw, path); err != nil {
+			os.RemoveAll(workFolder)
+			return err
+		}
+	}
+
+	m.WorkFolder = workFolder
+	workMu.Lock()
+	workModels = append(workModels, m)
+	workMu.Unlock()
+	logging.Storage.Debugf("Opened %s in %s", saveFolder, workFolder)
+	return nil
+}
+
+// writeWorkFile writes a decrypted copy with the modification time of the file it was read from,
+// so it is not sealed again until it changes. Only the work folder's owner can open it (0700).
+func writeWorkFile(path string, data []byte, from string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+	if info, err := os.Stat(from); err == nil {
+		os.Chtimes(path, info.ModTime(), info.ModTime())
+	}
+	return nil
+}
+
+// sealWorkFolder seals the files of m's work folder into its project folder: those that changed
+// since they were last sealed, or all of them when force is set. The skipped files, still being
+// recorded, wait for a later save. Sealed files whose copies were deleted are removed.
+func sealWorkFolder(m *model.Model, force bool, skipped ...string) {
+	for _, rel := range projectFiles(m.WorkFolder) {
+		path := filepath.Join(m.WorkFolder, rel)
+		dest := filepath.Join(m.SaveFolder, rel)
+		info, err := os.Stat(path)
+		if err != nil || slices.Contains(skipped, path) {
+			continue
+		}
+		if sealed, err := os.Stat(dest); !force && err == nil &&
+			sealed.Size() == info.Size()+int64(sealOverhead) && !info.ModTime().After(sealed.ModTime()) {
+			continue
+		}
+		if err := sealFile(m.ProjectKey, path, dest); err != nil {
+			logging.Storage.Errorf("Error sealing %s: %v", dest, err)
+		}
+	}
+	removeDeletedFiles(m)
+}
+
+// sealFile writes the file at path to dest sealed with key, replacing dest only once it is written
+func sealFile(key *model.ProjectKey, path, dest string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	sealed, err := sealData(key, data)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(dest+".tmp", sealed, 0644); err != nil {
+		os.Remove(dest + ".tmp")
+		return err
+	}
+	return os.Rename(dest+".tmp", dest)
+}
+
+// removeDeletedFiles removes the files of m's project folder sealed with its key whose copies
+// were deleted from its work folder. Other files were put there from outside, or could not be
+// opened, and are kept.
+func removeDeletedFiles(m *model.Model) {
+	for _, rel := range projectFiles(m.SaveFolder) {
+		if _, err := os.Stat(filepath.Join(m.WorkFolder, rel)); !os.IsNotExist(err) {
+			continue
+		}
+		path := filepath.Join(m.SaveFolder, rel)
+		if sealedWith(path, m.ProjectKey) {
+			logging.Storage.Debugf("Removing %s, deleted from the project", path)
+			os.Remove(path)
+		}
+	}
+}
+
+// sealedWith reports whether the file at path is sealed with key
+func sealedWith(path string, key *model.ProjectKey) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	header := make([]byte, len(lockedMagic)+saltSize)
+	if _, err := io.ReadFull(file, header); err != nil {
+		return false
+	}
+	return key != nil && isSealed(header) && bytes.Equal(header[len(lockedMagic):], key.Salt)
+}
+
+// unsealWorkFolder writes the decrypted files of m's work folder back into its project folder
+// and closes the work folder, when the project's password is removed
+func unsealWorkFolder(m *model.Model) {
+	for _, rel := range projectFiles(m.WorkFolder) {
+		dest := filepath.Join(m.SaveFolder, rel)
+		err := os.MkdirAll(filepath.Dir(dest), 0755)
+		if err == nil {
+			err = copyFile(filepath.Join(m.WorkFolder, rel), dest)
+		}
+		if err != nil {
+			logging.Storage.Errorf("Error decrypting %s: %v", dest, err)
+		}
+	}
+	removeDeletedFiles(m)
+	closeWorkFolder(m)
+}
+
+// closeWorkFolder removes m's work folder
+func closeWorkFolder(m *model.Model) {
+	if m.WorkFolder == "" {
+		return
+	}
+	if err := os.RemoveAll(m.WorkFolder); err != nil {
+		logging.Storage.Errorf("Error removing %s: %v", m.WorkFolder, err)
+	}
+	m.WorkFolder = ""
+	workMu.Lock()
+	workModels = slices.DeleteFunc(workModels, func(open *model.Model) bool { return open == m })
+	workMu.Unlock()
+}
+
+// CloseProjects seals the files of the open password-protected projects and removes their work
+// folders, so no decrypted copies are left behind. Call it before exiting.
+func CloseProjects() {
+	workMu.Lock()
+	open := slices.Clone(workModels)
+	workMu.Unlock()
+	for _, m := range open {
+		sealWorkFolder(m, false)
+		closeWorkFolder(m)
+	}
+}
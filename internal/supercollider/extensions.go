@@ -0,0 +1,277 @@
+package supercollider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+
+	"github.com/schollz/collidertracker/internal/logging"
+)
+
+// Extension is a SuperCollider extension ColliderTracker depends on
+type Extension struct {
+	Name       string
+	installed  func() bool   // Whether the extension's files are present
+	url        func() string // Release zip for this platform ("" when unsupported)
+	installDir func() string // Where the zip is extracted
+	executable string        // Binary in the zip that needs exec permission ("" for none)
+}
+
+// RequiredExtensions are the extensions checked and installed at startup
+var RequiredExtensions = []Extension{
+	{
+		Name:       "PortedPlugins",
+		installed:  func() bool { return hasExtension("Fverb.sc") && hasExtension("AnalogTape.sc") },
+		url:        getPortedPluginsURL,
+		installDir: getLocalExtensionDir,
+	},
+	{
+		Name:       "mi-UGens",
+		installed:  func() bool { return hasExtension("MiBraids.sc") },
+		url:        getMiUGensURL,
+		installDir: getLocalExtensionDir,
+	},
+	{
+		Name:       "Open303",
+		installed:  hasOpen303,
+		url:        getOpen303URL,
+		installDir: getOpen303InstallDir,
+		executable: "Open303",
+	},
+	{
+		Name:       "Juno60",
+		installed:  hasJuno60,
+		url:        getJuno60URL,
+		installDir: getJuno60InstallDir,
+		executable: "Juno60",
+	},
+	{
+		Name:       "SC3 plugins",
+		installed:  hasSC3Plugins,
+		url:        getSC3PluginsURL,
+		installDir: getLocalExtensionDir,
+	},
+}
+
+// releaseVersionPattern extracts the release tag from a GitHub release download URL
+var releaseVersionPattern = regexp.MustCompile(`/releases/download/([^/]+)/`)
+
+// RequiredVersion returns the release this platform's download URL points at ("latest" when unpinned)
+func (e Extension) RequiredVersion() string {
+	return releaseVersion(e.url())
+}
+
+// releaseVersion returns the release tag of a download URL
+func releaseVersion(url string) string {
+	if matches := releaseVersionPattern.FindStringSubmatch(url); len(matches) > 1 {
+		return matches[1]
+	}
+	return "latest"
+}
+
+// ExtensionStatus is how an installed extension compares with the required one
+type ExtensionStatus int
+
+const (
+	ExtensionMissing   ExtensionStatus = iota // Not found in any extension folder
+	ExtensionOutdated                         // Installed by ColliderTracker at another version
+	ExtensionInstalled                        // Installed by ColliderTracker at the required version
+	ExtensionUnmanaged                        // Found, but installed by hand so its version is unknown
+)
+
+// String returns the status as shown in the extension manager
+func (s ExtensionStatus) String() string {
+	switch s {
+	case ExtensionMissing:
+		return "missing"
+	case ExtensionOutdated:
+		return "outdated"
+	case ExtensionInstalled:
+		return "ok"
+	case ExtensionUnmanaged:
+		return "ok (manual)"
+	}
+	return "unknown"
+}
+
+// NeedsInstall reports whether the extension must be installed or updated before starting
+func (s ExtensionStatus) NeedsInstall() bool {
+	return s == ExtensionMissing || s == ExtensionOutdated
+}
+
+// ExtensionState is the result of checking one required extension
+type ExtensionState struct {
+	Extension        Extension
+	Status           ExtensionStatus
+	InstalledVersion string // Version recorded when ColliderTracker installed it ("" when unknown)
+}
+
+// CheckExtensions checks every required extension against its required version
+func CheckExtensions() []ExtensionState {
+	versions := loadInstalledVersions()
+	states := make([]ExtensionState, len(RequiredExtensions))
+	for i, ext := range RequiredExtensions {
+		states[i] = checkExtension(ext, versions)
+	}
+	return states
+}
+
+// checkExtension compares one extension with the versions recorded at install time
+func checkExtension(ext Extension, versions map[string]string) ExtensionState {
+	state := ExtensionState{Extension: ext, InstalledVersion: versions[ext.Name]}
+	switch {
+	case !ext.installed():
+		state.Status = ExtensionMissing
+	case state.InstalledVersion == "":
+		state.Status = ExtensionUnmanaged
+	case state.InstalledVersion != ext.RequiredVersion():
+		state.Status = ExtensionOutdated
+	default:
+		state.Status = ExtensionInstalled
+	}
+	return state
+}
+
+// InstallExtension downloads (or reuses the cached download of) an extension, extracts it
+// and records the installed version
+func InstallExtension(ext Extension) error {
+	url := ext.url()
+	if url == "" {
+		return fmt.Errorf("unsupported platform for %s: %s/%s", ext.Name, runtime.GOOS, runtime.GOARCH)
+	}
+	installDir := ext.installDir()
+	if installDir == "" {
+		return fmt.Errorf("could not determine %s installation directory", ext.Name)
+	}
+	if err := os.MkdirAll(installDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %v", ext.Name, err)
+	}
+
+	version := ext.RequiredVersion()
+	zipPath, err := fetchExtensionZip(ext.Name, version, url)
+	if err != nil {
+		return err
+	}
+	if ext.executable != "" {
+		err = extractZipWithExecutable(zipPath, installDir, ext.executable)
+	} else {
+		err = extractZip(zipPath, installDir)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %v", ext.Name, err)
+	}
+
+	versions := loadInstalledVersions()
+	versions[ext.Name] = version
+	if err := saveInstalledVersions(versions); err != nil {
+		logging.OSC.Warnf("Could not record %s version: %v", ext.Name, err)
+	}
+	logging.OSC.Debugf("Installed %s %s into %s", ext.Name, version, installDir)
+	return nil
+}
+
+// extensionStateDir overrides where the version manifest and download cache live (tests)
+var extensionStateDir = ""
+
+// getExtensionStateDir returns the folder holding the version manifest and download cache
+func getExtensionStateDir() string {
+	if extensionStateDir != "" {
+		return extensionStateDir
+	}
+	if dir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(dir, "collidertracker", "extensions")
+	}
+	return filepath.Join(os.TempDir(), "collidertracker-extensions")
+}
+
+// installedVersionsPath is the manifest of extension versions installed by ColliderTracker
+func installedVersionsPath() string {
+	return filepath.Join(getExtensionStateDir(), "installed.json")
+}
+
+// loadInstalledVersions reads the manifest, returning an empty one when it does not exist
+func loadInstalledVersions() map[string]string {
+	versions := make(map[string]string)
+	data, err := os.ReadFile(installedVersionsPath())
+	if err != nil {
+		return versions
+	}
+	if err := json.Unmarshal(data, &versions); err != nil {
+		logging.OSC.Debugf("Ignoring unreadable extension manifest: %v", err)
+		return make(map[string]string)
+	}
+	return versions
+}
+
+// saveInstalledVersions writes the manifest
+func saveInstalledVersions(versions map[string]string) error {
+	if err := os.MkdirAll(getExtensionStateDir(), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(versions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(installedVersionsPath(), data, 0644)
+}
+
+// cachedZipPath is where the download of an extension release is kept for offline installs
+func cachedZipPath(name, version, url string) string {
+	return filepath.Join(getExtensionStateDir(), "downloads", fmt.Sprintf("%s-%s-%s", name, version, filepath.Base(url)))
+}
+
+// fetchExtensionZip returns the path of an extension's release zip. Pinned releases are
+// downloaded once and reused; "latest" is downloaded again, falling back to the cached
+// copy when offline.
+func fetchExtensionZip(name, version, url string) (string, error) {
+	cached := cachedZipPath(name, version, url)
+	if version != "latest" && fileExists(cached) {
+		logging.OSC.Debugf("Using cached %s download: %s", name, cached)
+		return cached, nil
+	}
+
+	err := downloadFile(url, cached)
+	if err == nil {
+		return cached, nil
+	}
+	if fileExists(cached) {
+		logging.OSC.Warnf("Download of %s failed (%v), using cached copy %s", name, err, cached)
+		return cached, nil
+	}
+	return "", err
+}
+
+// downloadFile downloads url to dest, replacing it only when the download completes
+func downloadFile(url, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create download directory: %v", err)
+	}
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: status %d", url, resp.StatusCode)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(dest), "download-*.zip")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = io.Copy(tmpFile, resp.Body)
+	tmpFile.Close()
+	if err != nil {
+		return fmt.Errorf("failed to save downloaded file: %v", err)
+	}
+	return os.Rename(tmpFile.Name(), dest)
+}
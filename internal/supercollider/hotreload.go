@@ -0,0 +1,151 @@
+package supercollider
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/schollz/collidertracker/internal/logging"
+)
+
+// SynthDefBlock is one SynthDef definition taken from a SuperCollider source file
+type SynthDefBlock struct {
+	Name string
+	Code string // The SynthDef(...) expression including its .add (or similar) call
+}
+
+// synthDefStartPattern matches a SynthDef with a literal name; names built at runtime
+// (e.g. "sampler"++(ch+1) inside a loop) cannot be reloaded on their own
+var synthDefStartPattern = regexp.MustCompile(`SynthDef\s*\(\s*(?:"([^"]+)"|\\(\w+))\s*,`)
+
+// synthDefCallPattern matches the call that registers a SynthDef with the server
+var synthDefCallPattern = regexp.MustCompile(`^\s*\.\s*(add|send|store|load)\b(\s*\([^()]*\))?`)
+
+// hotReloadSkip are SynthDefs that only make sense inside the script that builds them
+var hotReloadSkip = map[string]bool{
+	"out": true, // Built by ~addOutSynthDef from the master chain
+}
+
+// ExtractSynthDefBlocks returns the SynthDef expressions with literal names in scdContent
+func ExtractSynthDefBlocks(scdContent string) []SynthDefBlock {
+	var blocks []SynthDefBlock
+	for _, loc := range synthDefStartPattern.FindAllStringSubmatchIndex(scdContent, -1) {
+		name := ""
+		if loc[2] >= 0 {
+			name = scdContent[loc[2]:loc[3]]
+		} else {
+			name = scdContent[loc[4]:loc[5]]
+		}
+		if hotReloadSkip[name] {
+			continue
+		}
+		openParen := strings.Index(scdContent[loc[0]:], "(") + loc[0]
+		end := matchingParen(scdContent, openParen)
+		if end < 0 {
+			continue
+		}
+		code := scdContent[loc[0] : end+1]
+		if call := synthDefCallPattern.FindString(scdContent[end+1:]); call != "" {
+			code += strings.TrimSpace(call)
+		} else {
+			code += ".add"
+		}
+		blocks = append(blocks, SynthDefBlock{Name: name, Code: code})
+	}
+	return blocks
+}
+
+// matchingParen returns the index of the parenthesis closing the one at open, skipping
+// strings, quoted symbols, characters and comments, or -1 when it is not closed
+func matchingParen(content string, open int) int {
+	depth := 0
+	for i := open; i < len(content); i++ {
+		switch c := content[i]; c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		case '"', '\'':
+			// Skip to the closing quote, honouring escapes
+			for i++; i < len(content) && content[i] != c; i++ {
+				if content[i] == '\\' {
+					i++
+				}
+			}
+		case '$':
+			i++ // Character literal such as $(
+		case '/':
+			if i+1 < len(content) && content[i+1] == '/' {
+				for i < len(content) && content[i] != '\n' {
+					i++
+				}
+			} else if i+1 < len(content) && content[i+1] == '*' {
+				if end := strings.Index(content[i+2:], "*/"); end >= 0 {
+					i += end + 3
+				} else {
+					return -1
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// SynthDefWatcher polls folders of .scd files and reports the SynthDefs whose code changed
+type SynthDefWatcher struct {
+	dirs   []string
+	loaded map[string]string    // SynthDef name -> code the server was last given
+	mtimes map[string]time.Time // .scd file -> modification time when last read
+}
+
+// NewSynthDefWatcher watches the .scd files in dirs. The SynthDefs in the embedded scripts
+// count as loaded, since SuperCollider was started with them.
+func NewSynthDefWatcher(dirs ...string) *SynthDefWatcher {
+	w := &SynthDefWatcher{
+		dirs:   dirs,
+		loaded: make(map[string]string),
+		mtimes: make(map[string]time.Time),
+	}
+	for _, embedded := range [][]byte{embeddedSamplerSCD, embeddedDX7SCD} {
+		for _, block := range ExtractSynthDefBlocks(string(embedded)) {
+			w.loaded[block.Name] = block.Code
+		}
+	}
+	return w
+}
+
+// Scan reads the .scd files modified since the last scan and returns the SynthDefs that
+// are new or differ from what the server was last given
+func (w *SynthDefWatcher) Scan() []SynthDefBlock {
+	var changed []SynthDefBlock
+	for _, dir := range w.dirs {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.scd"))
+		sort.Strings(files)
+		for _, file := range files {
+			info, err := os.Stat(file)
+			if err != nil || info.ModTime().Equal(w.mtimes[file]) {
+				continue
+			}
+			w.mtimes[file] = info.ModTime()
+			content, err := os.ReadFile(file)
+			if err != nil {
+				logging.OSC.Debugf("Hot reload: could not read %s: %v", file, err)
+				continue
+			}
+			for _, block := range ExtractSynthDefBlocks(string(content)) {
+				if w.loaded[block.Name] == block.Code {
+					continue
+				}
+				w.loaded[block.Name] = block.Code
+				changed = append(changed, block)
+			}
+		}
+	}
+	return changed
+}
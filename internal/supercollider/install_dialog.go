@@ -2,24 +2,30 @@ package supercollider
 
 import (
 	"fmt"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// InstallDialogModel is the extension manager: it lists the required extensions with their
+// versions and installs, updates or reinstalls them one at a time or all at once
 type InstallDialogModel struct {
-	width    int
-	height   int
-	selected int // 0 = Yes, 1 = No
-	done     bool
-	install  bool
-	err      error
+	width      int
+	height     int
+	states     []ExtensionState
+	selected   int         // Selected extension row
+	installing string      // Extension being installed ("" when idle)
+	queue      []Extension // Extensions left to install for "install all"
+	message    string      // Result of the last install
+	err        error       // Error of the last failed install
+	done       bool
+	proceed    bool // Whether ColliderTracker should start after the dialog closes
 }
 
 func NewInstallDialogModel() InstallDialogModel {
 	return InstallDialogModel{
-		selected: 0,
-		done:     false,
+		states: CheckExtensions(),
 	}
 }
 
@@ -27,6 +33,42 @@ func (m InstallDialogModel) Init() tea.Cmd {
 	return nil
 }
 
+// installCompleteMsg reports the end of one extension install
+type installCompleteMsg struct {
+	name string
+	err  error
+}
+
+// installCmd installs one extension in the background
+func installCmd(ext Extension) tea.Cmd {
+	return func() tea.Msg {
+		return installCompleteMsg{name: ext.Name, err: InstallExtension(ext)}
+	}
+}
+
+// allSatisfied reports whether no extension needs to be installed or updated
+func (m InstallDialogModel) allSatisfied() bool {
+	for _, state := range m.states {
+		if state.Status.NeedsInstall() {
+			return false
+		}
+	}
+	return true
+}
+
+// startInstall begins installing the next extension in the queue
+func (m InstallDialogModel) startInstall() (InstallDialogModel, tea.Cmd) {
+	if len(m.queue) == 0 {
+		return m, nil
+	}
+	ext := m.queue[0]
+	m.queue = m.queue[1:]
+	m.installing = ext.Name
+	m.message = ""
+	m.err = nil
+	return m, installCmd(ext)
+}
+
 func (m InstallDialogModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
@@ -34,34 +76,57 @@ func (m InstallDialogModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
-	case downloadCompleteMsg:
-		m.err = msg.err
-		m.done = true
-		return m, nil
+	case installCompleteMsg:
+		m.installing = ""
+		m.states = CheckExtensions()
+		if msg.err != nil {
+			m.err = msg.err
+			m.queue = nil
+			return m, nil
+		}
+		m.message = fmt.Sprintf("%s installed", msg.name)
+		return m.startInstall()
 
 	case tea.KeyMsg:
-		if m.done && (m.install || m.err != nil) {
-			// After installation success/failure, any key exits
+		if msg.String() == "ctrl+c" {
+			m.done = true
+			m.proceed = false
 			return m, tea.Quit
 		}
+		if m.installing != "" {
+			return m, nil // Wait for the running install
+		}
 
 		switch msg.String() {
-		case "left", "h":
-			m.selected = 0
-		case "right", "l":
-			m.selected = 1
-		case "enter":
-			if m.selected == 0 {
-				m.install = true
-				return m, m.downloadExtensions
-			} else {
+		case "up", "k":
+			if m.selected > 0 {
+				m.selected--
+			}
+		case "down", "j":
+			if m.selected < len(m.states)-1 {
+				m.selected++
+			}
+		case "enter", "i":
+			// Install, update or reinstall the selected extension
+			m.queue = []Extension{m.states[m.selected].Extension}
+			return m.startInstall()
+		case "a":
+			m.queue = nil
+			for _, state := range m.states {
+				if state.Status.NeedsInstall() {
+					m.queue = append(m.queue, state.Extension)
+				}
+			}
+			return m.startInstall()
+		case "c":
+			if m.allSatisfied() {
 				m.done = true
-				m.install = false
+				m.proceed = true
 				return m, tea.Quit
 			}
-		case "q", "ctrl+c":
+		case "q", "esc":
 			m.done = true
-			m.install = false
+			m.proceed = false
 			return m, tea.Quit
 		}
 	}
@@ -69,84 +134,73 @@ func (m InstallDialogModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m InstallDialogModel) downloadExtensions() tea.Msg {
-	err := DownloadRequiredExtensions()
-	return downloadCompleteMsg{err: err}
-}
-
-type downloadCompleteMsg struct {
-	err error
-}
-
 func (m InstallDialogModel) View() string {
-	if m.done && m.install && m.err == nil {
-		return m.renderSuccess()
-	}
-
-	if m.err != nil {
-		return m.renderError()
-	}
-
 	// Calculate dimensions for centered dialog
-	dialogWidth := 60
+	dialogWidth := 72
 
 	if m.width > 0 && dialogWidth > m.width-4 {
 		dialogWidth = m.width - 4
 	}
 
-	// Create the message
-	message := "SuperCollider extensions are required but not found.\nWould you like to automatically install them?"
-
-	// Create buttons
-	yesButton := " Yes "
-	noButton := " No "
-
-	if m.selected == 0 {
-		yesButton = "> Yes <"
-	} else {
-		noButton = "> No <"
-	}
-
 	// Style definitions
 	dialogStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color("62")).
 		Padding(1, 2).
 		Width(dialogWidth - 4)
-
-	buttonStyle := lipgloss.NewStyle().
+	selectedStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("15")).
-		Background(lipgloss.Color("62")).
-		Padding(0, 1).
-		Margin(0, 1)
-
-	selectedButtonStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("15")).
-		Background(lipgloss.Color("205")).
-		Padding(0, 1).
-		Margin(0, 1)
-
-	// Apply button styles
-	if m.selected == 0 {
-		yesButton = selectedButtonStyle.Render(yesButton)
-		noButton = buttonStyle.Render(noButton)
+		Background(lipgloss.Color("205"))
+	okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	var content strings.Builder
+	if m.allSatisfied() {
+		content.WriteString("SuperCollider extensions\n\n")
 	} else {
-		yesButton = buttonStyle.Render(yesButton)
-		noButton = selectedButtonStyle.Render(noButton)
+		content.WriteString("Some SuperCollider extensions are missing or outdated.\n\n")
+	}
+	content.WriteString(fmt.Sprintf("  %-14s %-16s %-16s %s\n", "Extension", "Required", "Installed", "Status"))
+	for i, state := range m.states {
+		installed := state.InstalledVersion
+		if installed == "" {
+			installed = "-"
+		}
+		status := state.Status.String()
+		if state.Extension.Name == m.installing {
+			status = "installing..."
+		}
+		line := fmt.Sprintf("%-14s %-16s %-16s %s", state.Extension.Name, state.Extension.RequiredVersion(), installed, status)
+		switch {
+		case i == m.selected:
+			content.WriteString("> " + selectedStyle.Render(line))
+		case state.Status.NeedsInstall():
+			content.WriteString("  " + errorStyle.Render(line))
+		default:
+			content.WriteString("  " + line)
+		}
+		content.WriteString("\n")
 	}
 
-	// Combine buttons
-	buttons := lipgloss.JoinHorizontal(lipgloss.Center, yesButton, noButton)
+	content.WriteString("\n")
+	switch {
+	case m.installing != "":
+		content.WriteString(fmt.Sprintf("Installing %s...", m.installing))
+	case m.err != nil:
+		content.WriteString(errorStyle.Render(fmt.Sprintf("Install failed: %v", m.err)))
+	case m.message != "":
+		content.WriteString(okStyle.Render(m.message))
+	}
+	content.WriteString("\n\n")
 
-	// Create dialog content
-	content := lipgloss.JoinVertical(
-		lipgloss.Center,
-		message,
-		"",
-		buttons,
-	)
+	help := "up/down: select | enter: install/update | a: install all needed | q: quit"
+	if m.allSatisfied() {
+		help = "up/down: select | enter: reinstall | c: continue | q: quit"
+	}
+	content.WriteString(helpStyle.Render(help))
 
-	dialog := dialogStyle.Render(content)
+	dialog := dialogStyle.Render(content.String())
 
 	// Center the dialog on screen
 	return lipgloss.NewStyle().
@@ -157,52 +211,13 @@ func (m InstallDialogModel) View() string {
 		Render(dialog)
 }
 
-func (m InstallDialogModel) renderSuccess() string {
-	message := "SuperCollider extensions installed successfully!\nPress any key to continue..."
-
-	style := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("46")).
-		Foreground(lipgloss.Color("46")).
-		Padding(1, 2).
-		Width(50)
-
-	dialog := style.Render(message)
-
-	return lipgloss.NewStyle().
-		Width(m.width).
-		Height(m.height).
-		Align(lipgloss.Center).
-		AlignVertical(lipgloss.Center).
-		Render(dialog)
-}
-
-func (m InstallDialogModel) renderError() string {
-	message := fmt.Sprintf("Failed to install SuperCollider extensions:\n%v\n\nPress any key to exit...", m.err)
-
-	style := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("196")).
-		Foreground(lipgloss.Color("196")).
-		Padding(1, 2).
-		Width(60)
-
-	dialog := style.Render(message)
-
-	return lipgloss.NewStyle().
-		Width(m.width).
-		Height(m.height).
-		Align(lipgloss.Center).
-		AlignVertical(lipgloss.Center).
-		Render(dialog)
-}
-
 func (m InstallDialogModel) Done() bool {
 	return m.done
 }
 
-func (m InstallDialogModel) ShouldInstall() bool {
-	return m.install
+// ShouldContinue reports whether the user continued with every extension in place
+func (m InstallDialogModel) ShouldContinue() bool {
+	return m.proceed
 }
 
 func (m InstallDialogModel) Error() error {
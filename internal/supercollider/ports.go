@@ -0,0 +1,128 @@
+package supercollider
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultOSCPort is sclang's default language port
+const DefaultOSCPort = 57120
+
+// oscPort is the port sclang should listen on; ColliderTracker listens on oscPort+1 (atomic access)
+var oscPort = int32(DefaultOSCPort)
+
+// SetOSCPort sets the port a managed sclang listens on and replies to (port+1)
+func SetOSCPort(port int) {
+	atomic.StoreInt32(&oscPort, int32(port))
+}
+
+// GetOSCPort returns the port a managed sclang listens on
+func GetOSCPort() int {
+	return int(atomic.LoadInt32(&oscPort))
+}
+
+// IsUDPPortFree reports whether a UDP port can be bound on all interfaces
+func IsUDPPortFree(port int) bool {
+	conn, err := net.ListenPacket("udp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// FindFreePortPair returns the first port >= start where both port and port+1 are free
+func FindFreePortPair(start int) (int, error) {
+	for port := start; port < start+200 && port < 65535; port += 2 {
+		if IsUDPPortFree(port) && IsUDPPortFree(port+1) {
+			return port, nil
+		}
+	}
+	return 0, fmt.Errorf("no free UDP port pair found from %d", start)
+}
+
+// NegotiateOSCPorts checks the requested port pair and picks a free one if needed.
+// port+1 is always bound by ColliderTracker. port itself only has to be free when
+// we will start our own sclang (willStartSC); an existing sclang owning it is fine.
+// Returns the port to use and whether it differs from the requested one.
+func NegotiateOSCPorts(port int, willStartSC bool) (int, bool, error) {
+	listenerBusy := !IsUDPPortFree(port + 1)
+	scPortBusy := willStartSC && !IsUDPPortFree(port)
+	if !listenerBusy && !scPortBusy {
+		SetOSCPort(port)
+		return port, false, nil
+	}
+
+	newPort, err := FindFreePortPair(port + 2)
+	if err != nil {
+		return port, false, err
+	}
+	SetOSCPort(newPort)
+	return newPort, true, nil
+}
+
+// DefaultServerPort is scsynth's default port
+const DefaultServerPort = 57110
+
+// Lines in collidertracker.scd that are rewritten for a non-default instance
+const (
+	listenerPortLine = `~listener = NetAddr.new("127.0.0.1", 57121);`
+	langPortLine     = `thisProcess.openUDPPort(57120);`
+	serverPortLine   = `s.addr = NetAddr("127.0.0.1", 57110);`
+)
+
+// FindFreeServerPort returns a free port for this instance's scsynth, preferring the default
+func FindFreeServerPort() (int, error) {
+	for port := DefaultServerPort; port < DefaultServerPort+100; port++ {
+		// Stay clear of ColliderTracker's own OSC port pair
+		if oscPort := GetOSCPort(); port == oscPort || port == oscPort+1 {
+			continue
+		}
+		if IsUDPPortFree(port) {
+			return port, nil
+		}
+	}
+	return 0, fmt.Errorf("no free scsynth port found from %d", DefaultServerPort)
+}
+
+// sessionRecordingPath is where --record writes the session WAV ("" uses SuperCollider's default)
+var sessionRecordingPath string
+
+// SetSessionRecordingPath sets the WAV file the session recording is written to
+func SetSessionRecordingPath(path string) {
+	sessionRecordingPath = path
+}
+
+// GetSessionRecordingPath returns the WAV file the startup session recording is written to
+func GetSessionRecordingPath() string {
+	return sessionRecordingPath
+}
+
+// buildSCDContent returns the sampler script with recording, OSC ports, server port and server program applied
+func buildSCDContent(enableRecording bool, serverPort int) []byte {
+	content := string(embeddedSamplerSCD)
+	if enableRecording {
+		// Replace "//Server.default.record;" with a take armed to start on the first row played
+		record := "~armSessionRecord.(nil);"
+		if sessionRecordingPath != "" {
+			path := strings.ReplaceAll(filepath.ToSlash(sessionRecordingPath), `"`, `\"`)
+			record = fmt.Sprintf(`~armSessionRecord.("%s");`, path)
+		}
+		content = strings.Replace(content, "//Server.default.record;", record, 1)
+	}
+	if port := GetOSCPort(); port != DefaultOSCPort {
+		content = strings.Replace(content, langPortLine,
+			fmt.Sprintf(`thisProcess.openUDPPort(%d);`, port), 1)
+		content = strings.Replace(content, listenerPortLine,
+			fmt.Sprintf(`~listener = NetAddr.new("127.0.0.1", %d);`, port+1), 1)
+	}
+	if serverPort != DefaultServerPort {
+		content = strings.Replace(content, serverPortLine,
+			fmt.Sprintf(`s.addr = NetAddr("127.0.0.1", %d);`, serverPort), 1)
+	}
+	content = applySupernova(content)
+	return []byte(content)
+}
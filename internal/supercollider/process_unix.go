@@ -3,10 +3,11 @@
 package supercollider
 
 import (
-	"log"
 	"os/exec"
 	"syscall"
 	"time"
+
+	"github.com/schollz/collidertracker/internal/logging"
 )
 
 // setupProcessGroup sets up platform-specific process attributes for Unix systems
@@ -22,13 +23,13 @@ func killProcessGroup(cmd *exec.Cmd) {
 		return
 	}
 
-	log.Printf("Attempting graceful shutdown of sclang process (PID: %d)", cmd.Process.Pid)
+	logging.OSC.Debugf("Attempting graceful shutdown of sclang process (PID: %d)", cmd.Process.Pid)
 
 	// First, try to terminate the entire process group to catch child processes
 	pgid, err := syscall.Getpgid(cmd.Process.Pid)
 	if err == nil {
 		// Send SIGTERM to the process group first for graceful shutdown
-		log.Printf("Sending SIGTERM to process group %d", pgid)
+		logging.OSC.Debugf("Sending SIGTERM to process group %d", pgid)
 		syscall.Kill(-pgid, syscall.SIGTERM)
 
 		// Wait a bit for graceful shutdown
@@ -36,14 +37,14 @@ func killProcessGroup(cmd *exec.Cmd) {
 
 		// Check if process is still running
 		if isProcessStillRunning(cmd.Process.Pid) {
-			log.Printf("Process still running, sending SIGKILL to process group %d", pgid)
+			logging.OSC.Debugf("Process still running, sending SIGKILL to process group %d", pgid)
 			// If still running, force kill the process group
 			syscall.Kill(-pgid, syscall.SIGKILL)
 		} else {
-			log.Printf("Process gracefully terminated")
+			logging.OSC.Debugf("Process gracefully terminated")
 		}
 	} else {
-		log.Printf("Could not get process group, falling back to single process termination")
+		logging.OSC.Warnf("Could not get process group, falling back to single process termination")
 		// Fallback: try graceful termination of the main process
 		cmd.Process.Signal(syscall.SIGTERM)
 
@@ -52,10 +53,10 @@ func killProcessGroup(cmd *exec.Cmd) {
 
 		// If still running, force kill
 		if isProcessStillRunning(cmd.Process.Pid) {
-			log.Printf("Process still running, sending SIGKILL")
+			logging.OSC.Debugf("Process still running, sending SIGKILL")
 			cmd.Process.Kill()
 		} else {
-			log.Printf("Process gracefully terminated")
+			logging.OSC.Debugf("Process gracefully terminated")
 		}
 	}
 
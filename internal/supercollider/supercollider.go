@@ -2,12 +2,11 @@ package supercollider
 
 import (
 	"archive/zip"
+	"context"
 	_ "embed"
 	"fmt"
 	"io"
 	"log"
-	"net"
-	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -19,6 +18,8 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schollz/collidertracker/internal/logging"
 )
 
 //go:embed collidertracker.scd
@@ -35,6 +36,7 @@ var (
 	tempSamplerFile = ""
 	tempDX7AFXFile  = ""
 	tempDX7SCDFile  = ""
+	tempInstanceDir = "" // Per-instance directory holding the temporary scd files
 	sclangProcess   *exec.Cmd
 	cleanupCalled   = false
 	detectedPort    = int32(0) // Port detected from SuperCollider output, 0 means not detected yet (atomic access)
@@ -59,7 +61,7 @@ func tryExtractPort(matches []string, line string) bool {
 	if len(matches) > 1 {
 		if port, parseErr := strconv.Atoi(matches[1]); parseErr == nil {
 			atomic.StoreInt32(&detectedPort, int32(port))
-			log.Printf("Detected SuperCollider using port %d from output: %s", port, strings.TrimSpace(line))
+			logging.OSC.Debugf("Detected SuperCollider using port %d from output: %s", port, strings.TrimSpace(line))
 			return true
 		}
 	}
@@ -120,25 +122,6 @@ func IsSuperColliderEnabled() bool {
 	return isProcessRunning("sclang")
 }
 
-// findFreePort finds an available UDP port on the system in a platform-independent way
-func findFreePort() (int, error) {
-	// Listen on UDP port 0, which tells the OS to pick a free port
-	addr, err := net.ResolveUDPAddr("udp", "127.0.0.1:0")
-	if err != nil {
-		return 0, fmt.Errorf("failed to resolve UDP address: %v", err)
-	}
-
-	conn, err := net.ListenUDP("udp", addr)
-	if err != nil {
-		return 0, fmt.Errorf("failed to listen on UDP: %v", err)
-	}
-	defer conn.Close()
-
-	// Get the port that was assigned
-	port := conn.LocalAddr().(*net.UDPAddr).Port
-	return port, nil
-}
-
 func StartSuperCollider() error {
 	return StartSuperColliderWithRecording(false)
 }
@@ -156,24 +139,34 @@ func StartSuperColliderWithRecording(enableRecording bool) error {
 
 	// Create temporary files from embedded SuperCollider files
 	// Create collidertracker.scd with optional recording modification
-	tempFile, err := os.CreateTemp("", "sampler-*.scd")
+	// Each instance runs its own scsynth on its own port
+	serverPort, err := FindFreeServerPort()
 	if err != nil {
+		return err
+	}
+	logging.OSC.Debugf("Using scsynth port %d", serverPort)
+
+	// Each instance gets its own directory so concurrent instances never share DX7 files
+	tempInstanceDir, err = os.MkdirTemp("", "collidertracker-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %v", err)
+	}
+	tempFile, err := os.CreateTemp(tempInstanceDir, "sampler-*.scd")
+	if err != nil {
+		removeTempFiles()
 		return fmt.Errorf("failed to create temporary sampler file: %v", err)
 	}
 
-	// Modify the embedded content if recording is enabled
-	scdContent := embeddedSamplerSCD
+	// Modify the embedded content for recording and the negotiated ports
 	if enableRecording {
-		log.Printf("enableRecording is true")
-		// Replace "//Server.default.record;" with "Server.default.record;"
-		modified := strings.Replace(string(embeddedSamplerSCD), "//Server.default.record;", "Server.default.record;", 1)
-		scdContent = []byte(modified)
+		logging.OSC.Debugf("enableRecording is true")
 	}
+	scdContent := buildSCDContent(enableRecording, serverPort)
 
 	_, err = tempFile.Write(scdContent)
 	if err != nil {
 		tempFile.Close()
-		os.Remove(tempFile.Name())
+		removeTempFiles()
 		return fmt.Errorf("failed to write sampler content: %v", err)
 	}
 	tempFile.Close()
@@ -186,7 +179,7 @@ func StartSuperColliderWithRecording(enableRecording bool) error {
 	dx7AFXPath := filepath.Join(tempDir, "DX7.afx")
 	err = os.WriteFile(dx7AFXPath, embeddedDX7AFX, 0644)
 	if err != nil {
-		os.Remove(tempSamplerFile)
+		removeTempFiles()
 		return fmt.Errorf("failed to write DX7.afx: %v", err)
 	}
 	tempDX7AFXFile = dx7AFXPath
@@ -195,14 +188,17 @@ func StartSuperColliderWithRecording(enableRecording bool) error {
 	dx7SCDPath := filepath.Join(tempDir, "DX7.scd")
 	err = os.WriteFile(dx7SCDPath, embeddedDX7SCD, 0644)
 	if err != nil {
-		os.Remove(tempSamplerFile)
-		os.Remove(tempDX7AFXFile)
+		removeTempFiles()
 		return fmt.Errorf("failed to write DX7.scd: %v", err)
 	}
 	tempDX7SCDFile = dx7SCDPath
 
-	// Start sclang with the temporary scd file
-	sclangProcess = exec.Command(sclangPath, tempSamplerFile)
+	// Start sclang with the temporary scd file, on the negotiated port if it is not the default
+	if port := GetOSCPort(); port != DefaultOSCPort {
+		sclangProcess = exec.Command(sclangPath, "-u", strconv.Itoa(port), tempSamplerFile)
+	} else {
+		sclangProcess = exec.Command(sclangPath, tempSamplerFile)
+	}
 
 	// On Windows, set working directory to sclang's directory so it can find scsynth
 	if runtime.GOOS == "windows" {
@@ -223,12 +219,7 @@ func StartSuperColliderWithRecording(enableRecording bool) error {
 	// Start the process but don't wait for it to complete
 	err = sclangProcess.Start()
 	if err != nil {
-		os.Remove(tempSamplerFile)
-		os.Remove(tempDX7AFXFile)
-		os.Remove(tempDX7SCDFile)
-		tempSamplerFile = ""
-		tempDX7AFXFile = ""
-		tempDX7SCDFile = ""
+		removeTempFiles()
 		return fmt.Errorf("failed to start SuperCollider: %v", err)
 	}
 
@@ -237,17 +228,12 @@ func StartSuperColliderWithRecording(enableRecording bool) error {
 
 	// Wait a moment and check if it's actually running
 	time.Sleep(2 * time.Second)
-	if !IsSuperColliderEnabled() {
+	if !IsOwnSuperColliderRunning() {
 		// Clean up if it failed to start
 		if sclangProcess != nil && sclangProcess.Process != nil {
 			sclangProcess.Process.Kill()
 		}
-		os.Remove(tempSamplerFile)
-		os.Remove(tempDX7AFXFile)
-		os.Remove(tempDX7SCDFile)
-		tempSamplerFile = ""
-		tempDX7AFXFile = ""
-		tempDX7SCDFile = ""
+		removeTempFiles()
 		startedBySelf = false
 		return fmt.Errorf("SuperCollider failed to start properly")
 	}
@@ -257,41 +243,60 @@ func StartSuperColliderWithRecording(enableRecording bool) error {
 
 // StartSuperColliderOnFreePort starts a new sclang instance on a free port
 // when another sclang instance is already running. This allows ColliderTracker
-// to coexist with an existing sclang process.
-func StartSuperColliderOnFreePort(enableRecording bool) error {
-	// Find a free UDP port
-	freePort, err := findFreePort()
-	if err != nil {
-		return fmt.Errorf("failed to find free port: %v", err)
+// to coexist with an existing sclang process. It returns the port sclang listens
+// on, which ColliderTracker has to send to (and listen on the port after).
+func StartSuperColliderOnFreePort(enableRecording bool) (int, error) {
+	// Prefer the negotiated port so replies reach this ColliderTracker instance,
+	// otherwise move to a free port pair
+	freePort := GetOSCPort()
+	if !IsUDPPortFree(freePort) {
+		newPort, err := FindFreePortPair(freePort + 2)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find free port: %v", err)
+		}
+		logging.OSC.Warnf("Warning: negotiated port %d is taken, using port %d", freePort, newPort)
+		SetOSCPort(newPort)
+		freePort = newPort
 	}
 
-	log.Printf("Found free port %d for new SuperCollider instance", freePort)
+	logging.OSC.Debugf("Found free port %d for new SuperCollider instance", freePort)
 
 	// Find sclang executable
 	sclangPath, err := findSclangPath()
 	if err != nil {
-		return fmt.Errorf("sclang not found: %v", err)
+		return 0, fmt.Errorf("sclang not found: %v", err)
 	}
 
 	// Create temporary files from embedded SuperCollider files
-	tempFile, err := os.CreateTemp("", "sampler-*.scd")
+	// Each instance runs its own scsynth on its own port
+	serverPort, err := FindFreeServerPort()
 	if err != nil {
-		return fmt.Errorf("failed to create temporary sampler file: %v", err)
+		return 0, err
 	}
+	logging.OSC.Debugf("Using scsynth port %d", serverPort)
 
-	// Modify the embedded content if recording is enabled
-	scdContent := embeddedSamplerSCD
+	// Each instance gets its own directory so concurrent instances never share DX7 files
+	tempInstanceDir, err = os.MkdirTemp("", "collidertracker-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create temporary directory: %v", err)
+	}
+	tempFile, err := os.CreateTemp(tempInstanceDir, "sampler-*.scd")
+	if err != nil {
+		removeTempFiles()
+		return 0, fmt.Errorf("failed to create temporary sampler file: %v", err)
+	}
+
+	// Modify the embedded content for recording and the negotiated ports
 	if enableRecording {
-		log.Printf("enableRecording is true")
-		modified := strings.Replace(string(embeddedSamplerSCD), "//Server.default.record;", "Server.default.record;", 1)
-		scdContent = []byte(modified)
+		logging.OSC.Debugf("enableRecording is true")
 	}
+	scdContent := buildSCDContent(enableRecording, serverPort)
 
 	_, err = tempFile.Write(scdContent)
 	if err != nil {
 		tempFile.Close()
-		os.Remove(tempFile.Name())
-		return fmt.Errorf("failed to write sampler content: %v", err)
+		removeTempFiles()
+		return 0, fmt.Errorf("failed to write sampler content: %v", err)
 	}
 	tempFile.Close()
 	tempSamplerFile = tempFile.Name()
@@ -303,8 +308,8 @@ func StartSuperColliderOnFreePort(enableRecording bool) error {
 	dx7AFXPath := filepath.Join(tempDir, "DX7.afx")
 	err = os.WriteFile(dx7AFXPath, embeddedDX7AFX, 0644)
 	if err != nil {
-		os.Remove(tempSamplerFile)
-		return fmt.Errorf("failed to write DX7.afx: %v", err)
+		removeTempFiles()
+		return 0, fmt.Errorf("failed to write DX7.afx: %v", err)
 	}
 	tempDX7AFXFile = dx7AFXPath
 
@@ -312,9 +317,8 @@ func StartSuperColliderOnFreePort(enableRecording bool) error {
 	dx7SCDPath := filepath.Join(tempDir, "DX7.scd")
 	err = os.WriteFile(dx7SCDPath, embeddedDX7SCD, 0644)
 	if err != nil {
-		os.Remove(tempSamplerFile)
-		os.Remove(tempDX7AFXFile)
-		return fmt.Errorf("failed to write DX7.scd: %v", err)
+		removeTempFiles()
+		return 0, fmt.Errorf("failed to write DX7.scd: %v", err)
 	}
 	tempDX7SCDFile = dx7SCDPath
 
@@ -339,38 +343,28 @@ func StartSuperColliderOnFreePort(enableRecording bool) error {
 	// Start the process but don't wait for it to complete
 	err = sclangProcess.Start()
 	if err != nil {
-		os.Remove(tempSamplerFile)
-		os.Remove(tempDX7AFXFile)
-		os.Remove(tempDX7SCDFile)
-		tempSamplerFile = ""
-		tempDX7AFXFile = ""
-		tempDX7SCDFile = ""
-		return fmt.Errorf("failed to start SuperCollider on port %d: %v", freePort, err)
+		removeTempFiles()
+		return 0, fmt.Errorf("failed to start SuperCollider on port %d: %v", freePort, err)
 	}
 
 	// Mark that we started it
 	startedBySelf = true
 
-	log.Printf("Started SuperCollider on custom port %d", freePort)
+	logging.OSC.Debugf("Started SuperCollider on custom port %d", freePort)
 
 	// Wait a moment and check if it's actually running
 	time.Sleep(2 * time.Second)
-	if !IsSuperColliderEnabled() {
+	if !IsOwnSuperColliderRunning() {
 		// Clean up if it failed to start
 		if sclangProcess != nil && sclangProcess.Process != nil {
 			sclangProcess.Process.Kill()
 		}
-		os.Remove(tempSamplerFile)
-		os.Remove(tempDX7AFXFile)
-		os.Remove(tempDX7SCDFile)
-		tempSamplerFile = ""
-		tempDX7AFXFile = ""
-		tempDX7SCDFile = ""
+		removeTempFiles()
 		startedBySelf = false
-		return fmt.Errorf("SuperCollider failed to start properly on port %d", freePort)
+		return 0, fmt.Errorf("SuperCollider failed to start properly on port %d", freePort)
 	}
 
-	return nil
+	return freePort, nil
 }
 
 func StartSuperColliderWithProgress(readyChannel <-chan struct{}) error {
@@ -415,6 +409,12 @@ func Cleanup() {
 	}
 	cleanupCalled = true
 
+	StopOwnSuperCollider()
+}
+
+// StopOwnSuperCollider stops the SuperCollider this instance started (if any) and removes its
+// temporary files, so it can be started again
+func StopOwnSuperCollider() {
 	if startedBySelf {
 		// Stop SuperCollider process if we started it
 		if sclangProcess != nil && sclangProcess.Process != nil {
@@ -430,7 +430,7 @@ func Cleanup() {
 				// Process finished normally
 			case <-time.After(2 * time.Second):
 				// Timeout - process may still be running but we've done our best
-				log.Printf("Timeout waiting for sclang process to terminate")
+				logging.OSC.Debugf("Timeout waiting for sclang process to terminate")
 			}
 		}
 		startedBySelf = false
@@ -441,20 +441,29 @@ func Cleanup() {
 	ResetDetectedPort()
 
 	// Remove temporary files if we created them
-	if tempSamplerFile != "" {
-		os.Remove(tempSamplerFile)
-		tempSamplerFile = ""
-	}
-	if tempDX7AFXFile != "" {
-		os.Remove(tempDX7AFXFile)
-		tempDX7AFXFile = ""
+	removeTempFiles()
+}
+
+// removeTempFiles removes this instance's temporary scd files and their directory
+func removeTempFiles() {
+	for _, file := range []*string{&tempSamplerFile, &tempDX7AFXFile, &tempDX7SCDFile} {
+		if *file != "" {
+			os.Remove(*file)
+			*file = ""
+		}
 	}
-	if tempDX7SCDFile != "" {
-		os.Remove(tempDX7SCDFile)
-		tempDX7SCDFile = ""
+	if tempInstanceDir != "" {
+		os.RemoveAll(tempInstanceDir)
+		tempInstanceDir = ""
 	}
 }
 
+// IsOwnSuperColliderRunning reports whether the sclang started by this instance is still alive.
+// Unlike IsSuperColliderEnabled it ignores sclang processes belonging to other instances.
+func IsOwnSuperColliderRunning() bool {
+	return sclangProcess != nil && sclangProcess.Process != nil && isProcessStillRunning(sclangProcess.Process.Pid)
+}
+
 func WasStartedBySelf() bool {
 	return startedBySelf
 }
@@ -502,6 +511,29 @@ func ExtractSynthDefNames(scdContent string) []string {
 	return filteredNames
 }
 
+// SclangPath returns where sclang is installed, "" when SuperCollider is not found
+func SclangPath() string {
+	path, err := findSclangPath()
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// SclangVersion returns the version sclang reports, "" when SuperCollider is not found or does
+// not answer within a few seconds
+func SclangVersion() string {
+	path := SclangPath()
+	if path == "" {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out, _ := exec.CommandContext(ctx, path, "-v").Output()
+	line, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	return strings.TrimSpace(line)
+}
+
 func findSclangPath() (string, error) {
 	// First try to find sclang in PATH
 	if path, err := exec.LookPath("sclang"); err == nil {
@@ -605,30 +637,13 @@ func isProcessRunning(processName string) bool {
 	return len(strings.TrimSpace(string(output))) > 0
 }
 
+// HasRequiredExtensions reports whether every required extension is installed and up to date
 func HasRequiredExtensions() bool {
-	extensions := []string{"Fverb.sc", "AnalogTape.sc", "MiBraids.sc"}
-
-	for _, ext := range extensions {
-		if !hasExtension(ext) {
+	for _, state := range CheckExtensions() {
+		if state.Status.NeedsInstall() {
 			return false
 		}
 	}
-
-	// Also check for Open303
-	if !hasOpen303() {
-		return false
-	}
-
-	// Also check for Juno60
-	if !hasJuno60() {
-		return false
-	}
-
-	// Also check for SC3 plugins
-	if !hasSC3Plugins() {
-		return false
-	}
-
 	return true
 }
 
@@ -713,109 +728,19 @@ func findSuperColliderDir(baseDir string) string {
 	return ""
 }
 
+// DownloadRequiredExtensions installs every extension that is missing or outdated
 func DownloadRequiredExtensions() error {
-	extensionDir := getLocalExtensionDir()
-	if extensionDir == "" {
-		return fmt.Errorf("could not determine local extension directory")
-	}
-
-	// Create extension directory if it doesn't exist
-	if err := os.MkdirAll(extensionDir, 0755); err != nil {
-		return fmt.Errorf("failed to create extension directory: %v", err)
-	}
-
-	// Check for PortedPlugins extensions
-	if !hasExtension("Fverb.sc") || !hasExtension("AnalogTape.sc") {
-		fmt.Println("Downloading PortedPlugins extensions...")
-		downloadURL := getPortedPluginsURL()
-		if downloadURL == "" {
-			return fmt.Errorf("unsupported platform for PortedPlugins: %s/%s", runtime.GOOS, runtime.GOARCH)
-		}
-
-		if err := downloadAndExtract(downloadURL, extensionDir); err != nil {
-			return fmt.Errorf("failed to download PortedPlugins: %v", err)
-		}
-		fmt.Println("PortedPlugins downloaded successfully")
-	}
-
-	// Check for mi-UGens extensions
-	if !hasExtension("MiBraids.sc") {
-		fmt.Println("Downloading mi-UGens extensions...")
-		downloadURL := getMiUGensURL()
-		if downloadURL == "" {
-			return fmt.Errorf("unsupported platform for mi-UGens: %s/%s", runtime.GOOS, runtime.GOARCH)
-		}
-
-		if err := downloadAndExtract(downloadURL, extensionDir); err != nil {
-			return fmt.Errorf("failed to download mi-UGens: %v", err)
-		}
-		fmt.Println("mi-UGens downloaded successfully")
-	}
-
-	// Check for Open303
-	if !hasOpen303() {
-		fmt.Println("Downloading Open303...")
-		downloadURL := getOpen303URL()
-		if downloadURL == "" {
-			return fmt.Errorf("unsupported platform for Open303: %s/%s", runtime.GOOS, runtime.GOARCH)
-		}
-
-		open303Dir := getOpen303InstallDir()
-		if open303Dir == "" {
-			return fmt.Errorf("could not determine Open303 installation directory")
-		}
-
-		// Create Open303 directory if it doesn't exist
-		if err := os.MkdirAll(open303Dir, 0755); err != nil {
-			return fmt.Errorf("failed to create Open303 directory: %v", err)
-		}
-
-		if err := downloadAndExtractOpen303(downloadURL, open303Dir); err != nil {
-			return fmt.Errorf("failed to download Open303: %v", err)
-		}
-		fmt.Println("Open303 downloaded successfully")
-	}
-
-	// Check for Juno60
-	if !hasJuno60() {
-		fmt.Println("Downloading Juno60...")
-		downloadURL := getJuno60URL()
-		if downloadURL == "" {
-			return fmt.Errorf("unsupported platform for Juno60: %s/%s", runtime.GOOS, runtime.GOARCH)
-		}
-
-		juno60Dir := getJuno60InstallDir()
-		if juno60Dir == "" {
-			return fmt.Errorf("could not determine Juno60 installation directory")
-		}
-
-		// Create Juno60 directory if it doesn't exist
-		if err := os.MkdirAll(juno60Dir, 0755); err != nil {
-			return fmt.Errorf("failed to create Juno60 directory: %v", err)
-		}
-
-		if err := downloadAndExtractJuno60(downloadURL, juno60Dir); err != nil {
-			return fmt.Errorf("failed to download Juno60: %v", err)
-		}
-		fmt.Println("Juno60 downloaded successfully")
-	}
-
-	// Check for SC3 plugins (check for MoogLadder.schelp)
-	if !hasSC3Plugins() {
-		fmt.Println("Downloading SC3 plugins...")
-		downloadURL := getSC3PluginsURL()
-		if downloadURL == "" {
-			return fmt.Errorf("unsupported platform for SC3 plugins: %s/%s", runtime.GOOS, runtime.GOARCH)
+	for _, state := range CheckExtensions() {
+		if !state.Status.NeedsInstall() {
+			continue
 		}
-
-		if err := downloadAndExtract(downloadURL, extensionDir); err != nil {
-			return fmt.Errorf("failed to download SC3 plugins: %v", err)
+		if err := InstallExtension(state.Extension); err != nil {
+			return err
 		}
-		fmt.Println("SC3 plugins downloaded successfully")
 	}
 
 	if HasRequiredExtensions() {
-		fmt.Println("All required extensions are now available")
+		logging.OSC.Debugf("All required extensions are now available")
 		return nil
 	}
 
@@ -1070,39 +995,6 @@ func getLocalExtensionDir() string {
 	return ""
 }
 
-func downloadAndExtract(url, destDir string) error {
-	// Download the file
-	resp, err := http.Get(url)
-	if err != nil {
-		return fmt.Errorf("failed to download %s: %v", url, err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download %s: status %d", url, resp.StatusCode)
-	}
-
-	// Create temporary file
-	tmpFile, err := os.CreateTemp("", "portedplugins-*.zip")
-	if err != nil {
-		return fmt.Errorf("failed to create temp file: %v", err)
-	}
-	defer os.Remove(tmpFile.Name())
-	defer tmpFile.Close()
-
-	// Copy response body to temp file
-	_, err = io.Copy(tmpFile, resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to save downloaded file: %v", err)
-	}
-
-	// Close temp file before reading
-	tmpFile.Close()
-
-	// Extract zip file
-	return extractZip(tmpFile.Name(), destDir)
-}
-
 func extractZip(src, dest string) error {
 	r, err := zip.OpenReader(src)
 	if err != nil {
@@ -1153,84 +1045,6 @@ func extractZip(src, dest string) error {
 	return nil
 }
 
-// downloadAndExtractOpen303 downloads and extracts Open303, similar to downloadAndExtract
-// but with special handling to set executable permissions on the binary
-func downloadAndExtractOpen303(url, destDir string) error {
-	// Download the file
-	resp, err := http.Get(url)
-	if err != nil {
-		return fmt.Errorf("failed to download %s: %v", url, err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download %s: status %d", url, resp.StatusCode)
-	}
-
-	// Create temporary file
-	tmpFile, err := os.CreateTemp("", "open303-*.zip")
-	if err != nil {
-		return fmt.Errorf("failed to create temp file: %v", err)
-	}
-	defer os.Remove(tmpFile.Name())
-	defer tmpFile.Close()
-
-	// Copy response body to temp file
-	_, err = io.Copy(tmpFile, resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to save downloaded file: %v", err)
-	}
-
-	// Close temp file before reading
-	tmpFile.Close()
-
-	// Extract zip file and set executable permissions
-	if err := extractZipWithExecutable(tmpFile.Name(), destDir, "Open303"); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// downloadAndExtractJuno60 downloads and extracts Juno60, similar to downloadAndExtractOpen303
-// but with special handling to set executable permissions on the binary
-func downloadAndExtractJuno60(url, destDir string) error {
-	// Download the file
-	resp, err := http.Get(url)
-	if err != nil {
-		return fmt.Errorf("failed to download %s: %v", url, err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download %s: status %d", url, resp.StatusCode)
-	}
-
-	// Create temporary file
-	tmpFile, err := os.CreateTemp("", "juno60-*.zip")
-	if err != nil {
-		return fmt.Errorf("failed to create temp file: %v", err)
-	}
-	defer os.Remove(tmpFile.Name())
-	defer tmpFile.Close()
-
-	// Copy response body to temp file
-	_, err = io.Copy(tmpFile, resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to save downloaded file: %v", err)
-	}
-
-	// Close temp file before reading
-	tmpFile.Close()
-
-	// Extract zip file and set executable permissions
-	if err := extractZipWithExecutable(tmpFile.Name(), destDir, "Juno60"); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func extractZipWithExecutable(src, dest, executableName string) error {
 	r, err := zip.OpenReader(src)
 	if err != nil {
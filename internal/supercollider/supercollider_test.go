@@ -1,10 +1,14 @@
 package supercollider
 
 import (
+	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -89,6 +93,51 @@ func TestHasRequiredExtensions(t *testing.T) {
 	})
 }
 
+func TestReleaseVersion(t *testing.T) {
+	assert.Equal(t, "v0.0.8", releaseVersion("https://github.com/v7b1/mi-UGens/releases/download/v0.0.8/mi-UGens-Linux.zip"))
+	assert.Equal(t, "latest", releaseVersion("https://github.com/schollz/open303/releases/latest/download/Open303-Linux-x64.zip"))
+}
+
+func TestCheckExtension(t *testing.T) {
+	ext := Extension{
+		Name:      "Test",
+		installed: func() bool { return true },
+		url:       func() string { return "https://example.com/releases/download/v2/Test.zip" },
+	}
+
+	assert.Equal(t, ExtensionUnmanaged, checkExtension(ext, map[string]string{}).Status)
+	assert.Equal(t, ExtensionInstalled, checkExtension(ext, map[string]string{"Test": "v2"}).Status)
+	assert.Equal(t, ExtensionOutdated, checkExtension(ext, map[string]string{"Test": "v1"}).Status)
+	assert.True(t, ExtensionOutdated.NeedsInstall())
+
+	ext.installed = func() bool { return false }
+	assert.Equal(t, ExtensionMissing, checkExtension(ext, map[string]string{"Test": "v2"}).Status)
+}
+
+func TestExtensionCache(t *testing.T) {
+	extensionStateDir = t.TempDir()
+	defer func() { extensionStateDir = "" }()
+
+	t.Run("installed versions round trip", func(t *testing.T) {
+		assert.Empty(t, loadInstalledVersions())
+		assert.NoError(t, saveInstalledVersions(map[string]string{"mi-UGens": "v0.0.8"}))
+		assert.Equal(t, map[string]string{"mi-UGens": "v0.0.8"}, loadInstalledVersions())
+	})
+
+	t.Run("cached download is used offline", func(t *testing.T) {
+		url := "http://127.0.0.1:1/releases/download/v1/Test.zip"
+		_, err := fetchExtensionZip("Test", "latest", url)
+		assert.Error(t, err, "Nothing cached and no network")
+
+		cached := cachedZipPath("Test", "latest", url)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(cached), 0755))
+		assert.NoError(t, os.WriteFile(cached, []byte("zip"), 0644))
+		path, err := fetchExtensionZip("Test", "latest", url)
+		assert.NoError(t, err)
+		assert.Equal(t, cached, path)
+	})
+}
+
 func TestGetMiUGensURL(t *testing.T) {
 	t.Run("returns correct URL for platform", func(t *testing.T) {
 		url := getMiUGensURL()
@@ -429,3 +478,148 @@ func TestResetDetectedPort(t *testing.T) {
 		assert.Equal(t, 0, GetDetectedPort())
 	})
 }
+
+func TestNegotiateOSCPorts(t *testing.T) {
+	defer SetOSCPort(DefaultOSCPort)
+
+	start, err := FindFreePortPair(40000)
+	assert.NoError(t, err)
+
+	t.Run("free pair is kept", func(t *testing.T) {
+		port, changed, err := NegotiateOSCPorts(start, true)
+		assert.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, start, port)
+	})
+
+	t.Run("busy listener port moves the pair", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", fmt.Sprintf(":%d", start+1))
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		port, changed, err := NegotiateOSCPorts(start, false)
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Greater(t, port, start+1)
+		assert.Equal(t, port, GetOSCPort())
+	})
+
+	t.Run("busy sclang port only matters when starting sclang", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", fmt.Sprintf(":%d", start))
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		_, changed, err := NegotiateOSCPorts(start, false)
+		assert.NoError(t, err)
+		assert.False(t, changed, "An existing sclang may own the port")
+
+		_, changed, err = NegotiateOSCPorts(start, true)
+		assert.NoError(t, err)
+		assert.True(t, changed)
+	})
+}
+
+func TestBuildSCDContentListenerPort(t *testing.T) {
+	defer SetOSCPort(DefaultOSCPort)
+
+	SetOSCPort(DefaultOSCPort)
+	content := string(buildSCDContent(false, DefaultServerPort))
+	assert.Contains(t, content, listenerPortLine)
+	assert.Contains(t, content, serverPortLine)
+
+	SetOSCPort(57130)
+	content = string(buildSCDContent(true, 57112))
+	assert.Contains(t, content, `~listener = NetAddr.new("127.0.0.1", 57131);`)
+	assert.Contains(t, content, `thisProcess.openUDPPort(57130);`)
+	assert.Contains(t, content, `s.addr = NetAddr("127.0.0.1", 57112);`)
+	assert.False(t, strings.Contains(content, "//Server.default.record;"))
+
+	SetSessionRecordingPath("/tmp/project/recordings/session.wav")
+	defer SetSessionRecordingPath("")
+	content = string(buildSCDContent(true, DefaultServerPort))
+	assert.Contains(t, content, `~armSessionRecord.("/tmp/project/recordings/session.wav");`)
+}
+
+func TestBuildSCDContentSupernova(t *testing.T) {
+	defer SetSupernova(false)
+
+	content := string(buildSCDContent(false, DefaultServerPort))
+	assert.Contains(t, content, supernovaLine, "scsynth by default")
+
+	SetSupernova(true)
+	content = string(buildSCDContent(false, DefaultServerPort))
+	assert.Contains(t, content, `~supernova = true;`)
+	assert.NotContains(t, content, supernovaLine)
+}
+
+func TestRemoveTempFiles(t *testing.T) {
+	dir, err := os.MkdirTemp("", "collidertracker-test-*")
+	assert.NoError(t, err)
+	tempInstanceDir = dir
+	tempSamplerFile = filepath.Join(dir, "sampler.scd")
+	os.WriteFile(tempSamplerFile, []byte("test"), 0644)
+
+	removeTempFiles()
+	assert.Empty(t, tempInstanceDir)
+	assert.Empty(t, tempSamplerFile)
+	assert.False(t, fileExists(dir), "Instance directory should be removed")
+	assert.False(t, IsOwnSuperColliderRunning(), "No sclang was started by the test")
+}
+
+func TestExtractSynthDefBlocks(t *testing.T) {
+	t.Run("literal names with their add call", func(t *testing.T) {
+		scdContent := `
+			SynthDef("Ping", { arg freq=440; // a ) in a comment
+				Out.ar(0, SinOsc.ar(freq) * ")".size);
+			}).add;
+			SynthDef(\Pong, { Out.ar(0, Saw.ar($( .ascii)) }).send(s);
+			[1, 2].do({ arg ch;
+				SynthDef("looped"++ch, { Out.ar(0, DC.ar(0)) }).add;
+			});
+			SynthDef("out", { Out.ar(0, DC.ar(0)) }).add;
+			SynthDef("bare", { Out.ar(0, DC.ar(0)) });
+		`
+		blocks := ExtractSynthDefBlocks(scdContent)
+		assert.Len(t, blocks, 3, "Runtime names and the master out are skipped")
+		assert.Equal(t, "Ping", blocks[0].Name)
+		assert.True(t, strings.HasSuffix(blocks[0].Code, "}).add"))
+		assert.Contains(t, blocks[0].Code, `")".size`)
+		assert.Equal(t, "Pong", blocks[1].Name)
+		assert.True(t, strings.HasSuffix(blocks[1].Code, "}).send(s)"))
+		assert.Equal(t, "bare", blocks[2].Name)
+		assert.True(t, strings.HasSuffix(blocks[2].Code, "}).add"), "An add call is appended when missing")
+	})
+
+	t.Run("embedded script", func(t *testing.T) {
+		names := make(map[string]bool)
+		for _, block := range ExtractSynthDefBlocks(string(embeddedSamplerSCD)) {
+			names[block.Name] = true
+		}
+		assert.True(t, names["SuperSaw"])
+		assert.True(t, names["MollyThePoly"])
+		assert.False(t, names["out"])
+	})
+}
+
+func TestSynthDefWatcher(t *testing.T) {
+	dir := t.TempDir()
+	watcher := NewSynthDefWatcher(dir)
+	assert.Empty(t, watcher.Scan())
+
+	// An unchanged copy of the embedded script reloads nothing
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "collidertracker.scd"), embeddedSamplerSCD, 0644))
+	assert.Empty(t, watcher.Scan())
+
+	path := filepath.Join(dir, "user.scd")
+	assert.NoError(t, os.WriteFile(path, []byte(`SynthDef("Blip", { Out.ar(0, Impulse.ar(1)) }).add;`), 0644))
+	changed := watcher.Scan()
+	assert.Len(t, changed, 1)
+	assert.Equal(t, "Blip", changed[0].Name)
+	assert.Empty(t, watcher.Scan(), "Unmodified files are not read again")
+
+	assert.NoError(t, os.WriteFile(path, []byte(`SynthDef("Blip", { Out.ar(0, Impulse.ar(2)) }).add;`), 0644))
+	assert.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+	changed = watcher.Scan()
+	assert.Len(t, changed, 1)
+	assert.Contains(t, changed[0].Code, "Impulse.ar(2)")
+}
@@ -0,0 +1,30 @@
+package supercollider
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// supernova selects the multicore supernova server for the SuperCollider we start (atomic access)
+var supernova atomic.Bool
+
+// supernovaLine is the line in collidertracker.scd that picks the server program
+const supernovaLine = `~supernova = false;`
+
+// SetSupernova makes a managed SuperCollider boot supernova instead of scsynth
+func SetSupernova(enabled bool) {
+	supernova.Store(enabled)
+}
+
+// UsesSupernova reports whether a managed SuperCollider boots supernova
+func UsesSupernova() bool {
+	return supernova.Load()
+}
+
+// applySupernova switches the script to supernova, which runs the track groups in parallel
+func applySupernova(content string) string {
+	if !UsesSupernova() {
+		return content
+	}
+	return strings.Replace(content, supernovaLine, `~supernova = true;`, 1)
+}
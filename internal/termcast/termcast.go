@@ -0,0 +1,96 @@
+// Package termcast records rendered terminal frames as an asciinema v2 (.cast) file
+package termcast
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// Default terminal size used when a frame arrives before the first window size message
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// clearScreen moves the cursor home and clears the screen before each full frame
+const clearScreen = "\x1b[H\x1b[2J"
+
+// header is the first line of an asciicast v2 file
+type header struct {
+	Version   int    `json:"version"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Title     string `json:"title,omitempty"`
+}
+
+// Recorder writes whole frames as asciicast output events, skipping frames that did not change
+type Recorder struct {
+	w       io.Writer
+	title   string
+	start   time.Time // Time of the first frame; event times are relative to it
+	width   int
+	height  int
+	started bool
+	last    string // Last frame written
+}
+
+// NewRecorder records to w. The header is written with the first frame, once the terminal
+// size is known.
+func NewRecorder(w io.Writer, title string) *Recorder {
+	return &Recorder{w: w, title: title}
+}
+
+// Frame records view as displayed at now on a width x height terminal
+func (r *Recorder) Frame(view string, width, height int, now time.Time) error {
+	if width <= 0 || height <= 0 {
+		width, height = defaultWidth, defaultHeight
+	}
+
+	if !r.started {
+		r.started = true
+		r.start = now
+		r.width, r.height = width, height
+		line, err := json.Marshal(header{
+			Version:   2,
+			Width:     width,
+			Height:    height,
+			Timestamp: now.Unix(),
+			Title:     r.title,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(r.w, "%s\n", line); err != nil {
+			return err
+		}
+	} else if width != r.width || height != r.height {
+		r.width, r.height = width, height
+		r.last = "" // Redraw after a resize
+		if err := r.event(now, "r", fmt.Sprintf("%dx%d", width, height)); err != nil {
+			return err
+		}
+	}
+
+	if view == r.last {
+		return nil
+	}
+	r.last = view
+	// The terminal is in raw mode, so lines need an explicit carriage return
+	return r.event(now, "o", clearScreen+strings.ReplaceAll(view, "\n", "\r\n"))
+}
+
+// event writes one [time, code, data] event line
+func (r *Recorder) event(now time.Time, code, data string) error {
+	elapsed := math.Round(now.Sub(r.start).Seconds()*1e6) / 1e6
+	line, err := json.Marshal([]interface{}{elapsed, code, data})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(r.w, "%s\n", line)
+	return err
+}
@@ -0,0 +1,42 @@
+package termcast
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRecorder(&buf, "collidertracker")
+	start := time.Unix(1700000000, 0)
+
+	assert.NoError(t, r.Frame("one\ntwo", 0, 0, start))
+	assert.NoError(t, r.Frame("one\ntwo", 0, 0, start.Add(100*time.Millisecond))) // unchanged, skipped
+	assert.NoError(t, r.Frame("three", 0, 0, start.Add(500*time.Millisecond)))
+	assert.NoError(t, r.Frame("three", 100, 30, start.Add(time.Second))) // resize redraws
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !assert.Len(t, lines, 5) {
+		return
+	}
+
+	var h header
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &h))
+	assert.Equal(t, header{Version: 2, Width: 80, Height: 24, Timestamp: 1700000000, Title: "collidertracker"}, h)
+
+	var events [][]interface{}
+	for _, line := range lines[1:] {
+		var event []interface{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &event))
+		events = append(events, event)
+	}
+	assert.Equal(t, []interface{}{0.0, "o", clearScreen + "one\r\ntwo"}, events[0])
+	assert.Equal(t, []interface{}{0.5, "o", clearScreen + "three"}, events[1])
+	assert.Equal(t, []interface{}{1.0, "r", "100x30"}, events[2])
+	assert.Equal(t, []interface{}{1.0, "o", clearScreen + "three"}, events[3])
+}
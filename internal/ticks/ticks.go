@@ -5,7 +5,7 @@ import (
 )
 
 // CalculatePhraseTicks calculates the total ticks in a phrase by summing all DT values
-func CalculatePhraseTicks(phrasesData *[255][][]int, phraseID int) int {
+func CalculatePhraseTicks(phrasesData *[types.NumPhrases][][]int, phraseID int) int {
 	if phraseID < 0 || phraseID >= 255 || phrasesData == nil {
 		return 0
 	}
@@ -24,7 +24,7 @@ func CalculatePhraseTicks(phrasesData *[255][][]int, phraseID int) int {
 }
 
 // CalculateChainTicks calculates the total ticks in a chain by summing all phrase ticks
-func CalculateChainTicks(chainsData *[][]int, phrasesData *[255][][]int, chainID int) int {
+func CalculateChainTicks(chainsData *[][]int, phrasesData *[types.NumPhrases][][]int, chainID int) int {
 	if chainID < 0 || chainsData == nil || chainID >= len(*chainsData) || phrasesData == nil {
 		return 0
 	}
@@ -40,7 +40,7 @@ func CalculateChainTicks(chainsData *[][]int, phrasesData *[255][][]int, chainID
 }
 
 // CalculateTrackTicks calculates the total ticks in a track by summing all chain ticks
-func CalculateTrackTicks(songData *[8][16]int, chainsData *[][]int, phrasesData *[255][][]int, trackID int) int {
+func CalculateTrackTicks(songData *[types.NumTracks][types.SongRows]int, chainsData *[][]int, phrasesData *[types.NumPhrases][][]int, trackID int) int {
 	if trackID < 0 || trackID >= 8 || songData == nil || chainsData == nil || phrasesData == nil {
 		return 0
 	}
@@ -2,6 +2,8 @@ package types
 
 import (
 	"math"
+	"slices"
+	"time"
 )
 
 type ViewMode int
@@ -22,6 +24,20 @@ const (
 	SoundMakerView
 	DuckingView
 	WaveformView
+	StatsView
+	RecordingsView
+	MasterChainView
+	VisualizerView
+	TimelineView
+	UsageView
+	InputView
+	DiagnosticsView
+	NotesView
+	ChangelogView
+	HistoryView
+	LibraryView
+	TunerView
+	GeneratorView
 )
 
 type PhraseViewType int
@@ -52,6 +68,22 @@ const (
 	SOModeMIDI                      // SO column shows MIDI
 )
 
+// Song, chain and phrase dimensions
+const (
+	NumTracks  = 8   // Tracks 0-3 and 4-7
+	SongRows   = 16  // Rows in the song grid
+	NumChains  = 255 // Chains 00-FE in each pool
+	ChainRows  = 16  // Phrase slots in a chain
+	NumPhrases = 255 // Phrases 00-FE in each pool
+	PhraseRows = 255 // Rows in a phrase
+)
+
+// Settings dimensions
+const (
+	NumSettings  = 255 // Retrigger, timestretch, modulate, ducking, arpeggio, MIDI and SoundMaker settings 00-FE
+	ArpeggioRows = 16  // Rows in an arpeggio
+)
+
 type PhraseColumn int
 
 const (
@@ -91,7 +123,11 @@ const (
 	ColMidiCC6 // Column 32: MIDI CC 6 (00-7F, 0-127)
 	ColMidiCC7 // Column 33: MIDI CC 7 (00-7F, 0-127)
 	ColMidiCC8 // Column 34: MIDI CC 8 (00-7F, 0-127)
-	ColCount   // Total number of columns
+	// Stacked note columns (Instrument view only, played together with NOT)
+	ColNote2 // Column 35: Note 2 (00-7F, -1 = none)
+	ColNote3 // Column 36: Note 3 (00-7F, -1 = none)
+	ColNote4 // Column 37: Note 4 (00-7F, -1 = none)
+	ColCount // Total number of columns
 )
 
 // ChordType represents different chord types for instrument tracks
@@ -197,6 +233,9 @@ const (
 	InstrumentColAR    InstrumentUIColumn = 18 // AR - Arpeggio
 	InstrumentColSOMI  InstrumentUIColumn = 19 // SO/MI - SoundMaker/MIDI (toggleable)
 	InstrumentColDU    InstrumentUIColumn = 20 // DU - Ducking
+	InstrumentColN2    InstrumentUIColumn = 21 // N2 - Note 2
+	InstrumentColN3    InstrumentUIColumn = 22 // N3 - Note 3
+	InstrumentColN4    InstrumentUIColumn = 23 // N4 - Note 4
 )
 
 // UI Column positions for Sampler Phrase View - to prevent hardcoding issues
@@ -313,14 +352,43 @@ func GetChordNotes(root int, ctype ChordType, add ChordAddition, transpose Chord
 	return notes
 }
 
+// StackedNoteColumns are the extra note columns (N2-N4) played together with a row's NOT
+var StackedNoteColumns = []PhraseColumn{ColNote2, ColNote3, ColNote4}
+
+// AddStackedNotes appends a row's stacked notes to its chord notes, skipping empty cells and notes already sounding
+func AddStackedNotes(notes []int, stacked ...int) []int {
+	for _, note := range stacked {
+		if note < 0 || note > 127 || slices.Contains(notes, note) {
+			continue
+		}
+		notes = append(notes, note)
+	}
+	return notes
+}
+
+// PlaythroughKit is the Playthrough mode where NN picks a whole file from the kit instead of a slice
+const PlaythroughKit = 4
+
 type FileMetadata struct {
-	BPM          float32   `json:"bpm"`          // Source BPM for the file
-	Slices       int       `json:"slices"`       // Number of slices in the file
-	Playthrough  int       `json:"playthrough"`  // 0=Sliced, 1=Oneshot, 2=Slice Bounce, 3=Slice Stop
-	SyncToBPM    int       `json:"synctobpm"`    // 0=No, 1=Yes (default)
-	SliceType    int       `json:"slicetype"`    // 0=Even (default), 1=Onsets
-	Onsets       []float64 `json:"onsets"`       // Onset times in seconds (populated when SliceType=1)
-	WaveformFile string    `json:"waveformfile"` // Path to 16-bit mono .wav file for waveform visualization (generated by audiomorph)
+	BPM          float32      `json:"bpm"`                   // Source BPM for the file
+	Slices       int          `json:"slices"`                // Number of slices in the file
+	Playthrough  int          `json:"playthrough"`           // 0=Sliced, 1=Oneshot, 2=Slice Bounce, 3=Slice Stop, 4=Kit
+	SyncToBPM    int          `json:"synctobpm"`             // 0=No, 1=Yes (default)
+	SliceType    int          `json:"slicetype"`             // 0=Even (default), 1=Onsets
+	OnsetPreset  int          `json:"onsetpreset,omitempty"` // 0=Drums (default), 1=Melodic, 2=Vocal, 3=Ambient: how onsets are detected
+	Onsets       []float64    `json:"onsets"`                // Onset times in seconds (populated when SliceType=1)
+	WaveformFile string       `json:"waveformfile"`          // Path to 16-bit mono .wav file for waveform visualization (generated by audiomorph)
+	Kit          []int        `json:"kit,omitempty"`         // Sampler file slots NN 00, 01, ... play when Playthrough=4 (Kit)
+	Warp         []WarpMarker `json:"warp,omitempty"`        // Warp markers pinning sample times to beats, sorted by time
+	Key          string       `json:"key,omitempty"`         // Key the detected chords are in, e.g. "A minor" ("" when none were heard)
+	Chords       []string     `json:"chords,omitempty"`      // Chord detected in each bar at the file's BPM ("" for bars without one)
+}
+
+// WarpMarker pins a time in a sample file to a beat, so a loosely played recording can be
+// stretched onto the grid between markers
+type WarpMarker struct {
+	Time float64 `json:"time"` // Time in the file in seconds
+	Beat float64 `json:"beat"` // Beat the time plays on, counted from the start of the file
 }
 
 type RetriggerSettings struct {
@@ -345,7 +413,7 @@ type TimestrechSettings struct {
 }
 
 type ModulateSettings struct {
-	Seed        int    `json:"seed"`        // Random seed: -1 for "none" (no randomization), 0 for "random" (time seeding), 1-128 for fixed seed
+	Seed        int    `json:"seed"`        // Random seed: -1 for "none" (no randomization), 0 for "random" (track RNG), 1-128 for fixed seed
 	IRandom     int    `json:"irandom"`     // Random range: 0-128 (0 means no randomization)
 	Sub         int    `json:"sub"`         // Subtract value: 0-120
 	Add         int    `json:"add"`         // Add value: 0-120
@@ -365,6 +433,92 @@ type DuckingSettings struct {
 	Thresh  float32 `json:"thresh"`  // Threshold: 0.0-1.0, default 0.02
 }
 
+// ReverbSettings shapes the algorithmic reverb on the reverb send
+type ReverbSettings struct {
+	Algorithm      int `json:"algorithm"`      // Index into ReverbAlgorithmNames
+	Size           int `json:"size"`           // Size/decay: 0-100%
+	Damp           int `json:"damp"`           // High frequency damping: 0-100%
+	PreDelayMS     int `json:"preDelayMs"`     // Pre-delay: 0-300 ms
+	ShimmerVoicing int `json:"shimmerVoicing"` // Index into ShimmerVoicingNames
+}
+
+// DefaultReverbSettings returns the settings that match the original fixed reverb
+func DefaultReverbSettings() ReverbSettings {
+	return ReverbSettings{Size: 70, Damp: 50, PreDelayMS: 200}
+}
+
+// MidiSyncSettings mirror the metronome and each track's row triggers as MIDI notes
+type MidiSyncSettings struct {
+	Mode    int    `json:"mode"`    // What is sent (MidiSyncOff, MidiSyncClick, MidiSyncTracks or MidiSyncBoth)
+	Device  string `json:"device"`  // MIDI device the notes go to ("None" for none)
+	Channel int    `json:"channel"` // MIDI channel 1-16
+}
+
+// DefaultMidiSyncSettings returns MIDI sync turned off, on the General MIDI drum channel
+func DefaultMidiSyncSettings() MidiSyncSettings {
+	return MidiSyncSettings{Device: "None", Channel: 10}
+}
+
+// GeneratorSettings are the constraints the phrase generator fills a phrase within
+type GeneratorSettings struct {
+	Rhythm    int    `json:"rhythm"`    // Rhythm template the rows follow
+	Density   int    `json:"density"`   // Percent of the rhythm's steps that play
+	Low       int    `json:"low"`       // Lowest note of instrument phrases
+	High      int    `json:"high"`      // Highest note of instrument phrases
+	Scale     string `json:"scale"`     // Scale of the notes, as in the modulate settings
+	Root      int    `json:"root"`      // Scale root note: 0-11 (C to B)
+	SliceLow  int    `json:"sliceLow"`  // First slice sampler phrases play
+	SliceHigh int    `json:"sliceHigh"` // Last slice sampler phrases play
+	Length    int    `json:"length"`    // Rows filled from the top of the phrase
+}
+
+// DefaultGeneratorSettings returns half the steps of any rhythm in C minor, over two octaves
+// or the first 16 slices, for one bar at PPQ 4
+func DefaultGeneratorSettings() GeneratorSettings {
+	return GeneratorSettings{Density: 50, Low: 48, High: 72, Scale: "minor", SliceHigh: 15, Length: 16}
+}
+
+// MixerState is one side of the mixer's A/B compare: what the Mixer view sets for each track
+type MixerState struct {
+	Levels           [9]float32 `json:"levels"`           // Set level of each track and the input, in dB
+	HumanizeTiming   [8]int     `json:"humanizeTiming"`   // Humanize timing range of each track, in 1/96 beat
+	HumanizeVelocity [8]int     `json:"humanizeVelocity"` // Humanize velocity range of each track
+}
+
+// ChainFX are the effect overrides of a chain slot, applied to its phrase while it plays.
+// Values use the phrase columns' 00-FE scale; -1 leaves the phrase's own value.
+type ChainFX struct {
+	Reverb  int `json:"reverb"`  // Reverb send floor: rows send at least this much
+	LowPass int `json:"lowPass"` // Low pass cutoff cap: rows are filtered at least this much
+}
+
+// NoChainFX is a chain slot without overrides
+var NoChainFX = ChainFX{Reverb: -1, LowPass: -1}
+
+// PoolNames are the names given to the chains and phrases of one pool, by ID
+type PoolNames struct {
+	Chains  map[int]string `json:"chains,omitempty"`
+	Phrases map[int]string `json:"phrases,omitempty"`
+}
+
+// PLock is a SoundMaker parameter locked to a value on one instrument phrase row
+type PLock struct {
+	Phrase int     `json:"phrase"`
+	Row    int     `json:"row"`
+	Param  string  `json:"param"` // SoundMaker parameter key
+	Value  float32 `json:"value"`
+}
+
+// ChainColumn represents the columns of the chain view
+type ChainColumn int
+
+const (
+	ChainColPhrase    ChainColumn = iota // 0: Phrase ID
+	ChainColTranspose                    // 1: Transpose in semitones
+	ChainColReverb                       // 2: Reverb send override
+	ChainColLowPass                      // 3: Low pass cap override
+)
+
 // ArpeggioDirection represents different arpeggio directions
 type ArpeggioDirection int
 
@@ -398,6 +552,15 @@ const (
 	GlobalSettingsRowDriveDB                                 // 6: DriveDB
 	GlobalSettingsRowTapePercent                             // 7: TapePercent
 	GlobalSettingsRowShimmerPercent                          // 8: ShimmerPercent
+	GlobalSettingsRowFadeMS                                  // 9: FadeMS
+	GlobalSettingsRowSeed                                    // 10: Project random seed
+	GlobalSettingsRowJumpCrossfade                           // 11: Crossfade ticks for song jumps
+	GlobalSettingsRowBanks                                   // 12: Shared or per-track chain and phrase banks
+	GlobalSettingsRowCountdown                               // 13: Countdown of a timed start
+	GlobalSettingsRowPreRoll                                 // 14: Count-in beats before a timed start
+	GlobalSettingsRowSongMode                                // 15: Song rows in order or chosen by weight
+	GlobalSettingsRowGroove                                  // 16: Groove template of the song
+	GlobalSettingsRowLock                                    // 17: Project password protection
 )
 
 // InputSettingsRow represents different rows in the Input settings column
@@ -406,6 +569,55 @@ type InputSettingsRow int
 const (
 	InputSettingsRowInputLevelDB      InputSettingsRow = iota // 0: InputLevelDB
 	InputSettingsRowReverbSendPercent                         // 1: ReverbSendPercent
+	InputSettingsRowMonitor                                   // 2: Monitor on/off
+	InputSettingsRowInsert                                    // 3: Insert effect
+	InputSettingsRowMidiSync                                  // 4: MIDI sync mode
+	InputSettingsRowMidiSyncDevice                            // 5: MIDI sync device
+	InputSettingsRowMidiSyncChannel                           // 6: MIDI sync channel
+	InputSettingsRowBeatFlash                                 // 7: Header flash on beats or bars
+	InputSettingsRowBeatPulse                                 // 8: OSC engine pulsed with the flash
+)
+
+// ReverbSettingsRow represents different rows in the Reverb settings column
+type ReverbSettingsRow int
+
+const (
+	ReverbSettingsRowAlgorithm      ReverbSettingsRow = iota // 0: Reverb algorithm
+	ReverbSettingsRowSize                                    // 1: Size
+	ReverbSettingsRowDamp                                    // 2: Damping
+	ReverbSettingsRowPreDelay                                // 3: Pre-delay
+	ReverbSettingsRowShimmerVoicing                          // 4: Shimmer voicing
+	ReverbSettingsRowImpulse                                 // 5: Impulse response for the convolution reverb
+)
+
+// AppSettingsRow represents different rows in the App settings column
+type AppSettingsRow int
+
+const (
+	AppSettingsRowConfirmDeletes   AppSettingsRow = iota // 0: Confirm destructive operations
+	AppSettingsRowBounceRepeats                          // 1: Loop repetitions for loop bounce
+	AppSettingsRowSplash                                 // 2: Splash screen at startup
+	AppSettingsRowAutosave                               // 3: Autosave or manual saving only
+	AppSettingsRowAutosaveDelay                          // 4: Wait after the last change before autosaving
+	AppSettingsRowAutosaveInterval                       // 5: Shortest time between two autosaves
+	AppSettingsRowPort                                   // 6: OSC port for SuperCollider
+	AppSettingsRowRecord                                 // 7: Record the session from launch
+	AppSettingsRowVim                                    // 8: Vim-style cursor movement
+	AppSettingsRowDump                                   // 9: Periodic terminal dumps
+	AppSettingsRowSkipSC                                 // 10: Leave SuperCollider to the user
+	AppSettingsRowNudge                                  // 11: Fine and coarse steps of hex cells
+	AppSettingsRowImport                                 // 12: Conversion of assigned samples
+	AppSettingsRowFrameRate                              // 13: UI refresh rate
+	AppSettingsRowWaveform                               // 14: Detail of the header waveform
+	AppSettingsRowAnimations                             // 15: Splash screen animation
+	AppSettingsRowPalette                                // 16: Color palette
+	AppSettingsRowLocale                                 // 17: Interface language
+	AppSettingsRowUpdates                                // 18: Check for updates at launch
+	AppSettingsRowResume                                 // 19: Resume playback on opening
+	AppSettingsRowAudition                               // 20: Play notes and velocities as they are edited
+	AppSettingsRowNormalize                              // 21: Loudness target of loop bounces
+	AppSettingsRowPreRoll                                // 22: Silence before beat 1 of session takes
+	AppSettingsRowLog                                    // 23: Level of the log subsystems
 )
 
 // BrailleDotRow represents different rows in a 2x4 Braille cell
@@ -427,6 +639,7 @@ const (
 	FileMetadataRowSliceType                          // 2: Slice Type
 	FileMetadataRowPlaythrough                        // 3: Playthrough
 	FileMetadataRowSyncToBPM                          // 4: Sync to BPM
+	FileMetadataRowOnsetPreset                        // 5: Onset Preset
 )
 
 // MidiSettingsRow represents different rows in the MIDI settings view
@@ -538,61 +751,213 @@ type ClipboardData struct {
 	IsFreshDeepCopy bool
 }
 
+// AppConfig holds the startup options kept in the user's config file; command-line flags override them
+type AppConfig struct {
+	Port   int    `json:"port"`             // OSC port for SuperCollider (ColliderTracker listens on Port+1)
+	Record bool   `json:"record"`           // Record the session from launch
+	Vim    bool   `json:"vim"`              // Vim-style cursor movement (h/j/k/l)
+	Dump   string `json:"dump,omitempty"`   // File terminal frames are written to every 10 seconds ("" disables)
+	SkipSC bool   `json:"skipSC"`           // Skip SuperCollider detection and management
+	Locale string `json:"locale,omitempty"` // Interface language ("" follows the environment)
+
+	LogLevel string `json:"logLevel,omitempty"` // Levels of the log subsystems, like "warn,playback=debug" ("" for info)
+
+	CheckUpdates bool `json:"checkUpdates,omitempty"` // Look for a newer release at launch (opt-in)
+
+	ProjectDir string `json:"projectDir,omitempty"` // Folder new projects are created in ("" for the working directory)
+	MidiDevice string `json:"midiDevice,omitempty"` // MIDI device instruments default to ("" for the first one found)
+
+	OSCEngines []OSCEngine `json:"oscEngines,omitempty"` // External engines instrument tracks can play instead of SuperCollider
+
+	SampleDirs []string `json:"sampleDirs,omitempty"` // Folders the sample library indexes
+}
+
+// OSCEngine is an external synth engine (Pure Data, Tidal, a norns script...) that instrument
+// tracks can send their notes to over OSC instead of the bundled SuperCollider code
+type OSCEngine struct {
+	Name    string `json:"name"`           // Shown as the track type
+	Host    string `json:"host,omitempty"` // "" for localhost
+	Port    int    `json:"port"`
+	Address string `json:"address"` // Prefix of the message addresses, like "/pd"
+}
+
 type SaveData struct {
-	ViewMode      ViewMode     `json:"viewMode"`
-	CurrentRow    int          `json:"currentRow"`
-	CurrentCol    int          `json:"currentCol"`
-	ScrollOffset  int          `json:"scrollOffset"`
-	CurrentPhrase int          `json:"currentPhrase"`
-	FileSelectRow int          `json:"fileSelectRow"`
-	FileSelectCol int          `json:"fileSelectCol"`
-	ChainsData    [][]int      `json:"chainsData"`
-	PhrasesData   [255][][]int `json:"phrasesData"`
+	ViewMode      ViewMode            `json:"viewMode"`
+	CurrentRow    int                 `json:"currentRow"`
+	CurrentCol    int                 `json:"currentCol"`
+	ScrollOffset  int                 `json:"scrollOffset"`
+	CurrentPhrase int                 `json:"currentPhrase"`
+	FileSelectRow int                 `json:"fileSelectRow"`
+	FileSelectCol int                 `json:"fileSelectCol"`
+	ChainsData    [][]int             `json:"chainsData"`
+	PhrasesData   [NumPhrases][][]int `json:"phrasesData"`
 	// New separate data pools for Instruments and Samplers
-	InstrumentChainsData       [][]int                 `json:"instrumentChainsData"`
-	InstrumentPhrasesData      [255][][]int            `json:"instrumentPhrasesData"`
-	SamplerChainsData          [][]int                 `json:"samplerChainsData"`
-	SamplerPhrasesData         [255][][]int            `json:"samplerPhrasesData"`
-	SamplerPhrasesFiles        []string                `json:"samplerPhrasesFiles"`
-	LastEditRow                int                     `json:"lastEditRow"`
-	PhrasesFiles               []string                `json:"phrasesFiles"`
-	CurrentDir                 string                  `json:"currentDir"`
-	BPM                        float32                 `json:"bpm"`
-	PPQ                        int                     `json:"ppq"`
-	PregainDB                  float32                 `json:"pregainDB"`
-	PostgainDB                 float32                 `json:"postgainDB"`
-	BiasDB                     float32                 `json:"biasDB"`
-	SaturationDB               float32                 `json:"saturationDB"`
-	DriveDB                    float32                 `json:"driveDB"`
-	InputLevelDB               float32                 `json:"inputLevelDB"`
-	ReverbSendPercent          float32                 `json:"reverbSendPercent"`
-	TapePercent                float32                 `json:"tapePercent"`
-	ShimmerPercent             float32                 `json:"shimmerPercent"`
-	FileMetadata               map[string]FileMetadata `json:"fileMetadata"`
-	LastChainRow               int                     `json:"lastChainRow"`
-	LastPhraseRow              int                     `json:"lastPhraseRow"`
-	LastPhraseCol              int                     `json:"lastPhraseCol"`
-	RecordingEnabled           bool                    `json:"recordingEnabled"`
-	RetriggerSettings          [255]RetriggerSettings  `json:"retriggerSettings"`
-	TimestrechSettings         [255]TimestrechSettings `json:"timestrechSettings"`
-	ModulateSettings           [255]ModulateSettings   `json:"modulateSettings"`           // Legacy field for backward compatibility
-	InstrumentModulateSettings [255]ModulateSettings   `json:"instrumentModulateSettings"` // New separate pools
-	SamplerModulateSettings    [255]ModulateSettings   `json:"samplerModulateSettings"`    // New separate pools
-	DuckingSettings            [255]DuckingSettings    `json:"duckingSettings"`
-	DuckingEditingIndex        int                     `json:"duckingEditingIndex"`
-	ArpeggioSettings           [255]ArpeggioSettings   `json:"arpeggioSettings"`
-	MidiSettings               [255]MidiSettings       `json:"midiSettings"`
-	SoundMakerSettings         [255]SoundMakerSettings `json:"soundMakerSettings"`
-	SongData                   [8][16]int              `json:"songData"`
-	LastSongRow                int                     `json:"lastSongRow"`
-	LastSongTrack              int                     `json:"lastSongTrack"`
-	CurrentChain               int                     `json:"currentChain"`
-	CurrentTrack               int                     `json:"currentTrack"`
-	TrackSetLevels             [9]float32              `json:"trackSetLevels"`
-	TrackTypes                 [9]bool                 `json:"trackTypes"`
-	CurrentMixerTrack          int                     `json:"currentMixerTrack"`
-	SOColumnMode               SOColumnMode            `json:"soColumnMode"`
-	MidiCCNumbers              [9]int                  `json:"midiCCNumbers"`
+	InstrumentChainsData       [][]int                  `json:"instrumentChainsData"`
+	InstrumentPhrasesData      [NumPhrases][][]int      `json:"instrumentPhrasesData"`
+	SamplerChainsData          [][]int                  `json:"samplerChainsData"`
+	SamplerPhrasesData         [NumPhrases][][]int      `json:"samplerPhrasesData"`
+	SamplerPhrasesFiles        []string                 `json:"samplerPhrasesFiles"`
+	InstrumentChainTransposes  [][]int                  `json:"instrumentChainTransposes,omitempty"` // nil when no chain slot is transposed
+	SamplerChainTransposes     [][]int                  `json:"samplerChainTransposes,omitempty"`
+	InstrumentChainFX          [][]ChainFX              `json:"instrumentChainFx,omitempty"` // nil when no chain slot has overrides
+	SamplerChainFX             [][]ChainFX              `json:"samplerChainFx,omitempty"`
+	PLocks                     []PLock                  `json:"pLocks,omitempty"`          // Parameter locks on instrument phrase rows
+	InstrumentNames            *PoolNames               `json:"instrumentNames,omitempty"` // nil when no instrument chain or phrase is named
+	SamplerNames               *PoolNames               `json:"samplerNames,omitempty"`
+	LastEditRow                int                      `json:"lastEditRow"`
+	PhrasesFiles               []string                 `json:"phrasesFiles"`
+	CurrentDir                 string                   `json:"currentDir"`
+	BPM                        float32                  `json:"bpm"`
+	PPQ                        int                      `json:"ppq"`
+	PregainDB                  float32                  `json:"pregainDB"`
+	PostgainDB                 float32                  `json:"postgainDB"`
+	BiasDB                     float32                  `json:"biasDB"`
+	SaturationDB               float32                  `json:"saturationDB"`
+	DriveDB                    float32                  `json:"driveDB"`
+	InputLevelDB               float32                  `json:"inputLevelDB"`
+	ReverbSendPercent          float32                  `json:"reverbSendPercent"`
+	TapePercent                float32                  `json:"tapePercent"`
+	ShimmerPercent             float32                  `json:"shimmerPercent"`
+	FileMetadata               map[string]FileMetadata  `json:"fileMetadata"`
+	LastChainRow               int                      `json:"lastChainRow"`
+	LastPhraseRow              int                      `json:"lastPhraseRow"`
+	LastPhraseCol              int                      `json:"lastPhraseCol"`
+	RecordingEnabled           bool                     `json:"recordingEnabled"`
+	RetriggerSettings          [255]RetriggerSettings   `json:"retriggerSettings"`
+	TimestrechSettings         [255]TimestrechSettings  `json:"timestrechSettings"`
+	ModulateSettings           [255]ModulateSettings    `json:"modulateSettings"`           // Legacy field for backward compatibility
+	InstrumentModulateSettings [255]ModulateSettings    `json:"instrumentModulateSettings"` // New separate pools
+	SamplerModulateSettings    [255]ModulateSettings    `json:"samplerModulateSettings"`    // New separate pools
+	DuckingSettings            [255]DuckingSettings     `json:"duckingSettings"`
+	DuckingEditingIndex        int                      `json:"duckingEditingIndex"`
+	ArpeggioSettings           [255]ArpeggioSettings    `json:"arpeggioSettings"`
+	MidiSettings               [255]MidiSettings        `json:"midiSettings"`
+	SoundMakerSettings         [255]SoundMakerSettings  `json:"soundMakerSettings"`
+	SongData                   [NumTracks][SongRows]int `json:"songData"`
+	LastSongRow                int                      `json:"lastSongRow"`
+	LastSongTrack              int                      `json:"lastSongTrack"`
+	CurrentChain               int                      `json:"currentChain"`
+	CurrentTrack               int                      `json:"currentTrack"`
+	TrackSetLevels             [9]float32               `json:"trackSetLevels"`
+	TrackTypes                 [9]bool                  `json:"trackTypes"`
+	TrackEngines               []string                 `json:"trackEngines,omitempty"` // nil when no track plays an OSC engine
+	TrackResolutions           [8]int                   `json:"trackResolutions"`       // 0 in older saves means 1
+	RecordQuantize             [8]int                   `json:"recordQuantize"`
+	RecordQuantizeStrength     *[8]int                  `json:"recordQuantizeStrength,omitempty"` // Missing in older saves
+	HumanizeTiming             [8]int                   `json:"humanizeTiming"`
+	HumanizeVelocity           [8]int                   `json:"humanizeVelocity"`
+	CurrentMixerTrack          int                      `json:"currentMixerTrack"`
+	SOColumnMode               SOColumnMode             `json:"soColumnMode"`
+	MidiCCNumbers              [9]int                   `json:"midiCCNumbers"`
+	SkipDeleteConfirm          bool                     `json:"skipDeleteConfirm"` // Inverted so older saves keep confirmations on
+	BounceRepeats              int                      `json:"bounceRepeats,omitempty"`
+	BounceNormalize            int                      `json:"bounceNormalize,omitempty"`
+	SessionPreRoll             int                      `json:"sessionPreRoll,omitempty"`
+	NudgeFine                  int                      `json:"nudgeFine,omitempty"`
+	NudgeCoarse                int                      `json:"nudgeCoarse,omitempty"`
+	SplashMode                 int                      `json:"splashMode,omitempty"`
+	ManualSave                 bool                     `json:"manualSave,omitempty"` // Inverted so older saves keep autosaving
+	AutosaveDelayMS            int                      `json:"autosaveDelayMs,omitempty"`
+	AutosaveIntervalS          int                      `json:"autosaveIntervalS,omitempty"`
+	RandomSeed                 int                      `json:"randomSeed,omitempty"` // Older saves get a new seed on load
+	FadeMS                     int                      `json:"fadeMs,omitempty"`
+	JumpCrossfade              int                      `json:"jumpCrossfade,omitempty"`
+	PerTrackBanks              bool                     `json:"perTrackBanks,omitempty"`
+	ImportMode                 int                      `json:"importMode,omitempty"`
+	FrameRate                  int                      `json:"frameRate,omitempty"`      // 0 in older saves, which keep the default
+	StartCountdown             int                      `json:"startCountdown,omitempty"` // 0 in older saves, which keep the default
+	PreRoll                    int                      `json:"preRoll,omitempty"`
+	WaveformDetail             int                      `json:"waveformDetail,omitempty"`
+	NoAnimations               bool                     `json:"noAnimations,omitempty"` // Inverted so older saves keep animating
+	Palette                    int                      `json:"palette,omitempty"`
+	InputMonitor               bool                     `json:"inputMonitor"`
+	InputDisarmed              bool                     `json:"inputDisarmed,omitempty"` // Inverted so older saves keep recording the input
+	InputInsert                int                      `json:"inputInsert"`
+	ReverbImpulse              string                   `json:"reverbImpulse,omitempty"`
+	Reverb                     *ReverbSettings          `json:"reverb,omitempty"` // nil in saves from before reverb settings
+	MasterChain                []string                 `json:"masterChain,omitempty"`
+	MidiSync                   *MidiSyncSettings        `json:"midiSync,omitempty"`   // nil in saves from before MIDI sync
+	MixerSlot                  int                      `json:"mixerSlot,omitempty"`  // Side of the A/B compare playing (0 A, 1 B)
+	MixerOther                 *MixerState              `json:"mixerOther,omitempty"` // Mixer stored on the other side, nil before A/B compare
+	SongCues                   []string                 `json:"songCues,omitempty"`   // Cue name per song row, nil without cues
+	GenerativeSong             bool                     `json:"generativeSong,omitempty"`
+	SongWeights                [][]int                  `json:"songWeights,omitempty"` // Weight per track and song row, nil while all are the default
+	Notes                      string                   `json:"notes,omitempty"`
+	ResumePlayback             bool                     `json:"resumePlayback,omitempty"`
+	Transport                  *TransportState          `json:"transport,omitempty"`    // Playback at the time of saving, nil when stopped or not resumed
+	SkipAudition               bool                     `json:"skipAudition,omitempty"` // Inverted so older saves keep auditioning on
+	AuditLog                   []AuditEntry             `json:"auditLog,omitempty"`
+	QuickSlots                 []string                 `json:"quickSlots,omitempty"` // Sample file per quick-slot, nil while none is bound
+	Groove                     int                      `json:"groove,omitempty"`
+	SongRowTempos              []SongRowTempo           `json:"songRowTempos,omitempty"`  // Tempo override per song row, nil without overrides
+	TunerReference             int                      `json:"tunerReference,omitempty"` // A4 in Hz for the tuner (0 for 440)
+	BeatFlash                  int                      `json:"beatFlash,omitempty"`
+	BeatPulse                  string                   `json:"beatPulse,omitempty"` // OSC engine pulsed with the beat flash
+	Generator                  *GeneratorSettings       `json:"generator,omitempty"` // nil in saves from before the phrase generator
+}
+
+// SongRowTempo overrides the song's tempo and groove while a song row plays
+type SongRowTempo struct {
+	BPM    float32 `json:"bpm,omitempty"`    // Tempo of the row (0 keeps the song's)
+	Groove int     `json:"groove,omitempty"` // 1 + index of the row's groove template (0 keeps the song's)
+}
+
+// AuditEntry is one edit in a project's history
+type AuditEntry struct {
+	Time time.Time `json:"time"`
+	Text string    `json:"text"` // e.g. "phrase 0A row 04: note C-4 → D-4"
+}
+
+// TransportState is where playback was when a project was saved, to resume from on reopening
+type TransportState struct {
+	Mode     ViewMode       `json:"mode"`     // SongView, ChainView or PhraseView
+	Track    int            `json:"track"`    // Track of chain and phrase playback
+	Chain    int            `json:"chain"`    // Chain of chain playback
+	ChainRow int            `json:"chainRow"` // Row of that chain
+	Phrase   int            `json:"phrase"`   // Phrase of phrase playback
+	Row      int            `json:"row"`      // Row of the phrase being played
+	SongRows [NumTracks]int `json:"songRows"` // Song row of each track in song playback, -1 for stopped tracks
+}
+
+// TrackKit is a track saved on its own to reuse in other projects: the track's settings, the
+// chains and phrases its song column plays, and the settings and sample files those phrases
+// point to. Chains and phrases are numbered from 0 in the kit; settings and files keep the
+// slots they had in the project the kit was made from.
+type TrackKit struct {
+	Name             string                     `json:"name"`                 // Project and track the kit was made from
+	Sampler          bool                       `json:"sampler"`              // Track type
+	Engine           string                     `json:"engine,omitempty"`     // OSC engine of an instrument track
+	SetLevel         float32                    `json:"setLevel"`             // Set level in dB
+	Resolution       int                        `json:"resolution"`           // Ticks per PPQ tick
+	HumanizeTiming   int                        `json:"humanizeTiming"`       // Humanize timing range, in 1/96 beat
+	HumanizeVelocity int                        `json:"humanizeVelocity"`     // Humanize velocity range
+	Song             [SongRows]int              `json:"song"`                 // Kit chain on each song row, -1 for none
+	Chains           []KitChain                 `json:"chains"`               // Chains of the song column
+	Phrases          []KitPhrase                `json:"phrases"`              // Phrases of those chains
+	Retriggers       map[int]RetriggerSettings  `json:"retriggers,omitempty"` // Settings the phrases point to, by slot
+	Timestretches    map[int]TimestrechSettings `json:"timestretches,omitempty"`
+	Modulates        map[int]ModulateSettings   `json:"modulates,omitempty"`
+	Arpeggios        map[int]ArpeggioSettings   `json:"arpeggios,omitempty"`
+	Midis            map[int]MidiSettings       `json:"midis,omitempty"`
+	SoundMakers      map[int]SoundMakerSettings `json:"soundMakers,omitempty"`
+	Duckings         map[int]DuckingSettings    `json:"duckings,omitempty"`
+	Files            map[int]string             `json:"files,omitempty"`        // Name of the sample in each sampler file slot
+	FileMetadata     map[string]FileMetadata    `json:"fileMetadata,omitempty"` // Metadata of the samples, by name
+}
+
+// KitChain is a chain of a track kit
+type KitChain struct {
+	Name       string             `json:"name,omitempty"`
+	Phrases    [ChainRows]int     `json:"phrases"`    // Kit phrase in each slot, -1 for none
+	Transposes [ChainRows]int     `json:"transposes"` // Transpose of each slot in semitones
+	FX         [ChainRows]ChainFX `json:"fx"`         // Effect overrides of each slot
+}
+
+// KitPhrase is a phrase of a track kit
+type KitPhrase struct {
+	Name   string                     `json:"name,omitempty"`
+	Rows   [][]int                    `json:"rows"`
+	PLocks map[int]map[string]float32 `json:"plocks,omitempty"` // Parameter locks of instrument phrase rows, by row
 }
 
 const SaveFile = "tracker-save.json"
@@ -1360,6 +1725,169 @@ func GetMiBraidsModelName(index int) string {
 	return "UNKNOWN"
 }
 
+// InputInsertNames are the insert effects for the monitored input, in SuperCollider order
+var InputInsertNames = []string{"clean", "drive", "chorus", "echo"}
+
+// GetInputInsertName returns the name for a given insert index
+func GetInputInsertName(index int) string {
+	if index >= 0 && index < len(InputInsertNames) {
+		return InputInsertNames[index]
+	}
+	return "UNKNOWN"
+}
+
+// RecordQuantizeNames are the grids notes entered live can be quantized to
+var RecordQuantizeNames = []string{"off", "1/16", "1/8"}
+
+// GetRecordQuantizeName returns the name for a given record quantize grid index
+func GetRecordQuantizeName(index int) string {
+	if index >= 0 && index < len(RecordQuantizeNames) {
+		return RecordQuantizeNames[index]
+	}
+	return "UNKNOWN"
+}
+
+// ReverbAlgorithmNames are the algorithmic reverbs, in SuperCollider order
+var ReverbAlgorithmNames = []string{"fverb", "freeverb", "gverb"}
+
+// GetReverbAlgorithmName returns the name for a given reverb algorithm index
+func GetReverbAlgorithmName(index int) string {
+	if index >= 0 && index < len(ReverbAlgorithmNames) {
+		return ReverbAlgorithmNames[index]
+	}
+	return "UNKNOWN"
+}
+
+// ShimmerVoicingNames are the intervals the shimmer adds to the reverb, in SuperCollider order
+var ShimmerVoicingNames = []string{"octaves", "fifths", "sub", "detune"}
+
+// GetShimmerVoicingName returns the name for a given shimmer voicing index
+func GetShimmerVoicingName(index int) string {
+	if index >= 0 && index < len(ShimmerVoicingNames) {
+		return ShimmerVoicingNames[index]
+	}
+	return "UNKNOWN"
+}
+
+// MIDI sync modes, in the order the Input column cycles through them
+const (
+	MidiSyncOff    = iota // Nothing is sent
+	MidiSyncClick         // Metronome notes on each beat
+	MidiSyncTracks        // A note for each row a track plays
+	MidiSyncBoth          // Metronome and track notes
+)
+
+// MidiSyncModeNames are the MIDI sync modes for display
+var MidiSyncModeNames = []string{"off", "click", "tracks", "both"}
+
+// GetMidiSyncModeName returns the name for a given MIDI sync mode
+func GetMidiSyncModeName(index int) string {
+	if index >= 0 && index < len(MidiSyncModeNames) {
+		return MidiSyncModeNames[index]
+	}
+	return "UNKNOWN"
+}
+
+// Beat flash modes, in the order the Input column cycles through them
+const (
+	BeatFlashOff  = iota // No flash
+	BeatFlashBeat        // Flash on every beat
+	BeatFlashBar         // Flash on the first beat of each bar
+)
+
+// BeatFlashNames are the beat flash modes for display
+var BeatFlashNames = []string{"off", "beat", "bar"}
+
+// GetBeatFlashName returns the name for a given beat flash mode
+func GetBeatFlashName(index int) string {
+	if index >= 0 && index < len(BeatFlashNames) {
+		return BeatFlashNames[index]
+	}
+	return "UNKNOWN"
+}
+
+// Splash screen modes, in the order the App column cycles through them
+const (
+	SplashModeFull  = iota // Full animation until SuperCollider is ready
+	SplashModeShort        // Short animation until SuperCollider is ready
+	SplashModeOff          // No splash screen
+)
+
+// SplashModeNames are the splash screen modes for display
+var SplashModeNames = []string{"full", "short", "off"}
+
+// GetSplashModeName returns the name for a given splash screen mode
+func GetSplashModeName(index int) string {
+	if index >= 0 && index < len(SplashModeNames) {
+		return SplashModeNames[index]
+	}
+	return "UNKNOWN"
+}
+
+// Sample import modes, in the order the App column cycles through them
+const (
+	ImportModeOff  = iota // Assigned samples are used as they are
+	ImportModeRate        // Converted to the server sample rate
+	ImportModeMono        // Folded to mono
+	ImportModeBoth        // Converted to the server sample rate and folded to mono
+)
+
+// ImportModeNames are the sample import modes for display
+var ImportModeNames = []string{"off", "rate", "mono", "rate+mono"}
+
+// GetImportModeName returns the name for a given sample import mode
+func GetImportModeName(index int) string {
+	if index >= 0 && index < len(ImportModeNames) {
+		return ImportModeNames[index]
+	}
+	return "UNKNOWN"
+}
+
+// FrameRates are the UI refresh rates (frames per second), in the order the App column cycles through them
+var FrameRates = []int{30, 20, 15, 10, 5}
+
+// StartCountdowns are the countdowns (seconds) of a timed start, in the order the Global column cycles through them
+var StartCountdowns = []int{5, 10, 15, 30, 60}
+
+// PreRolls are the count-in lengths (beats) before a timed start, in the order the Global column cycles through them
+var PreRolls = []int{0, 1, 2, 4, 8}
+
+// Header waveform details, in the order the App column cycles through them
+const (
+	WaveformDetailFull = iota // Full height waveform
+	WaveformDetailLow         // One row waveform
+	WaveformDetailOff         // No waveform
+)
+
+// WaveformDetailNames are the header waveform details for display
+var WaveformDetailNames = []string{"full", "low", "off"}
+
+// GetWaveformDetailName returns the name for a given header waveform detail
+func GetWaveformDetailName(index int) string {
+	if index >= 0 && index < len(WaveformDetailNames) {
+		return WaveformDetailNames[index]
+	}
+	return "UNKNOWN"
+}
+
+// Color palettes, in the order the App column cycles through them
+const (
+	PaletteDefault = iota // The original colors
+	PaletteDeutan         // Safe for deuteranopia (green-weak)
+	PaletteProtan         // Safe for protanopia (red-weak)
+)
+
+// PaletteNames are the color palettes for display
+var PaletteNames = []string{"default", "deutan", "protan"}
+
+// GetPaletteName returns the name for a given color palette
+func GetPaletteName(index int) string {
+	if index >= 0 && index < len(PaletteNames) {
+		return PaletteNames[index]
+	}
+	return "UNKNOWN"
+}
+
 // MiPlaits engine names for display
 var MiPlaitsEngineNames = []string{
 	"virtual_analog_engine", "waveshaping_engine", "fm_engine", "grain_engine",
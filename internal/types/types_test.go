@@ -110,6 +110,18 @@ func TestGetChordNotes(t *testing.T) {
 	}
 }
 
+func TestAddStackedNotes(t *testing.T) {
+	chord := GetChordNotes(60, ChordMajor, ChordAddNone, ChordTransNone) // [60, 64, 67]
+	got := AddStackedNotes(chord, 72, -1, 64)
+	if !reflect.DeepEqual(got, []int{60, 64, 67, 72}) {
+		t.Fatalf("AddStackedNotes = %v, want [60 64 67 72] (empty and repeated notes skipped)", got)
+	}
+	got = AddStackedNotes([]int{48}, 52, 55, 59)
+	if !reflect.DeepEqual(got, []int{48, 52, 55, 59}) {
+		t.Fatalf("AddStackedNotes = %v, want four notes", got)
+	}
+}
+
 func TestChordTypeToString(t *testing.T) {
 	tests := []struct {
 		chordType ChordType
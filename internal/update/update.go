@@ -0,0 +1,277 @@
+// Package update checks GitHub for newer releases of ColliderTracker and replaces the running
+// binary with the one built for this platform
+package update
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReleasesURL lists the releases of ColliderTracker, newest first
+var ReleasesURL = "https://api.github.com/repos/schollz/collidertracker/releases"
+
+// client is used for all requests, so a stalled network cannot hang a check
+var client = &http.Client{Timeout: 30 * time.Second}
+
+// Release is a published release of ColliderTracker
+type Release struct {
+	Tag        string    `json:"tag_name"`     // Version, e.g. v1.4.0
+	Name       string    `json:"name"`         // Title of the release
+	Notes      string    `json:"body"`         // Changelog in markdown
+	Published  time.Time `json:"published_at"` // When the release was published
+	Prerelease bool      `json:"prerelease"`   // Test builds, never offered as updates
+	Assets     []Asset   `json:"assets"`       // Downloads, one zip per platform and their checksums
+}
+
+// Asset is a download of a release
+type Asset struct {
+	Name string `json:"name"`                 // File name, e.g. collidertracker_linux.zip
+	URL  string `json:"browser_download_url"` // Where it is downloaded from
+}
+
+// FetchReleases returns the published releases, newest first, without prereleases
+func FetchReleases() ([]Release, error) {
+	req, err := http.NewRequest(http.MethodGet, ReleasesURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for updates: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to check for updates: status %d", resp.StatusCode)
+	}
+
+	var releases []Release
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("failed to read releases: %v", err)
+	}
+	published := releases[:0]
+	for _, release := range releases {
+		if !release.Prerelease && parseVersion(release.Tag) != nil {
+			published = append(published, release)
+		}
+	}
+	return published, nil
+}
+
+// parseVersion splits a version such as v1.4.0 or 1.4 into its numbers, nil if it is not one
+// (e.g. "dev" for builds without a version)
+func parseVersion(version string) []int {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	var numbers []int
+	for _, part := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers
+}
+
+// Newer reports whether version is newer than current. Builds without a version (dev builds)
+// are never offered updates.
+func Newer(version, current string) bool {
+	a, b := parseVersion(version), parseVersion(current)
+	if a == nil || b == nil {
+		return false
+	}
+	for i := 0; i < max(len(a), len(b)); i++ {
+		x, y := 0, 0
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			return x > y
+		}
+	}
+	return false
+}
+
+// NewerReleases returns the releases newer than current, newest first
+func NewerReleases(releases []Release, current string) []Release {
+	var newer []Release
+	for _, release := range releases {
+		if Newer(release.Tag, current) {
+			newer = append(newer, release)
+		}
+	}
+	return newer
+}
+
+// ChecksumsName is the release asset listing the SHA-256 of each zip, as written by sha256sum
+const ChecksumsName = "SHA256SUMS"
+
+// AssetName is the release zip built for an operating system and architecture, "" when
+// releases have no build for it. Each operating system is built once, on its CI runner.
+func AssetName(goos, goarch string) string {
+	switch goos + "/" + goarch {
+	case "darwin/arm64":
+		return "collidertracker_macos.zip"
+	case "linux/amd64":
+		return "collidertracker_linux.zip"
+	case "windows/amd64":
+		return "collidertracker_windows.zip"
+	}
+	return ""
+}
+
+// Install downloads the release's zip for this platform, checks it against the release's
+// checksums and replaces the running binary with the one inside. The previous binary is kept
+// next to it with an .old suffix; the new one runs from the next launch.
+func Install(release Release) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the running binary: %v", err)
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return fmt.Errorf("failed to find the running binary: %v", err)
+	}
+	archive, err := downloadAsset(release, AssetName(runtime.GOOS, runtime.GOARCH), filepath.Dir(exe))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archive)
+	return replaceBinary(archive, exe)
+}
+
+// downloadAsset downloads a zip of a release into dir and returns its path once its SHA-256
+// matches the one the release publishes. Nothing is kept when it does not.
+func downloadAsset(release Release, name, dir string) (string, error) {
+	url, sumsURL := "", ""
+	for _, asset := range release.Assets {
+		switch {
+		case name != "" && asset.Name == name:
+			url = asset.URL
+		case asset.Name == ChecksumsName:
+			sumsURL = asset.URL
+		}
+	}
+	if url == "" {
+		return "", fmt.Errorf("release %s has no download for %s/%s", release.Tag, runtime.GOOS, runtime.GOARCH)
+	}
+	if sumsURL == "" {
+		return "", fmt.Errorf("release %s publishes no checksums", release.Tag)
+	}
+	var sums strings.Builder
+	if err := download(sumsURL, &sums); err != nil {
+		return "", err
+	}
+	want, ok := checksum(sums.String(), name)
+	if !ok {
+		return "", fmt.Errorf("release %s has no checksum for %s", release.Tag, name)
+	}
+
+	archive, err := os.CreateTemp(dir, "update-*.zip")
+	if err != nil {
+		return "", fmt.Errorf("cannot write to %s: %v", dir, err)
+	}
+	hash := sha256.New()
+	err = download(url, io.MultiWriter(archive, hash))
+	archive.Close()
+	if err == nil && hex.EncodeToString(hash.Sum(nil)) != want {
+		err = fmt.Errorf("download of %s does not match its checksum", name)
+	}
+	if err != nil {
+		os.Remove(archive.Name())
+		return "", err
+	}
+	return archive.Name(), nil
+}
+
+// checksum finds the SHA-256 of a file in a sha256sum listing
+func checksum(sums, name string) (string, bool) {
+	for _, line := range strings.Split(sums, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), true
+		}
+	}
+	return "", false
+}
+
+// download writes url to dest
+func download(url string, dest io.Writer) error {
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: status %d", url, resp.StatusCode)
+	}
+	if _, err := io.Copy(dest, resp.Body); err != nil {
+		return fmt.Errorf("failed to download %s: %v", url, err)
+	}
+	return nil
+}
+
+// replaceBinary puts the binary from a release zip in the place of exe. The running binary
+// is renamed rather than overwritten, which also works on Windows.
+func replaceBinary(archive, exe string) error {
+	r, err := zip.OpenReader(archive)
+	if err != nil {
+		return fmt.Errorf("failed to open update: %v", err)
+	}
+	defer r.Close()
+
+	var binary *zip.File
+	for _, f := range r.File {
+		if name := filepath.Base(f.Name); name == "collidertracker" || name == "collidertracker.exe" {
+			binary = f
+		}
+	}
+	if binary == nil {
+		return fmt.Errorf("update has no collidertracker binary")
+	}
+	src, err := binary.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open update: %v", err)
+	}
+	defer src.Close()
+
+	next := exe + ".new"
+	dest, err := os.OpenFile(next, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to write update: %v", err)
+	}
+	_, err = io.Copy(dest, src)
+	dest.Close()
+	if err != nil {
+		os.Remove(next)
+		return fmt.Errorf("failed to write update: %v", err)
+	}
+
+	old := exe + ".old"
+	os.Remove(old)
+	if err := os.Rename(exe, old); err != nil {
+		os.Remove(next)
+		return fmt.Errorf("failed to replace %s: %v", exe, err)
+	}
+	if err := os.Rename(next, exe); err != nil {
+		os.Rename(old, exe)
+		return fmt.Errorf("failed to replace %s: %v", exe, err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package update
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewer(t *testing.T) {
+	assert.True(t, Newer("v1.4.0", "v1.3.9"))
+	assert.True(t, Newer("v1.10.0", "v1.9.0"), "Versions compare by number, not text")
+	assert.True(t, Newer("1.4.1", "v1.4"))
+	assert.False(t, Newer("v1.4.0", "v1.4.0"))
+	assert.False(t, Newer("v1.4", "v1.4.0"))
+	assert.False(t, Newer("v1.3.0", "v1.4.0"))
+	assert.False(t, Newer("v1.4.0", "dev"), "Dev builds are never offered updates")
+	assert.False(t, Newer("nightly", "v1.4.0"))
+	assert.True(t, Newer("v1.5.0-rc1", "v1.4.0"))
+}
+
+func TestNewerReleases(t *testing.T) {
+	releases := []Release{{Tag: "v1.6.0"}, {Tag: "v1.5.0"}, {Tag: "v1.4.0"}}
+	newer := NewerReleases(releases, "v1.4.0")
+	assert.Len(t, newer, 2)
+	assert.Equal(t, "v1.6.0", newer[0].Tag)
+	assert.Empty(t, NewerReleases(releases, "v1.6.0"))
+}
+
+func TestAssetName(t *testing.T) {
+	assert.Equal(t, "collidertracker_macos.zip", AssetName("darwin", "arm64"))
+	assert.Equal(t, "collidertracker_windows.zip", AssetName("windows", "amd64"))
+	assert.Equal(t, "collidertracker_linux.zip", AssetName("linux", "amd64"))
+	assert.Empty(t, AssetName("linux", "arm64"), "No release is built for other architectures")
+	assert.Empty(t, AssetName("darwin", "amd64"))
+}
+
+func TestDownloadAsset(t *testing.T) {
+	data := []byte("zip")
+	sum := sha256.Sum256(data)
+	sums := hex.EncodeToString(sum[:]) + "  collidertracker_linux.zip\n" + strings.Repeat("0", 64) + "  collidertracker_macos.zip\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + ChecksumsName:
+			w.Write([]byte(sums))
+		default:
+			w.Write(data)
+		}
+	}))
+	defer server.Close()
+	release := Release{Tag: "v1.5.0", Assets: []Asset{
+		{Name: "collidertracker_linux.zip", URL: server.URL + "/linux"},
+		{Name: "collidertracker_macos.zip", URL: server.URL + "/macos"},
+		{Name: ChecksumsName, URL: server.URL + "/" + ChecksumsName},
+	}}
+	dir := t.TempDir()
+
+	archive, err := downloadAsset(release, "collidertracker_linux.zip", dir)
+	if assert.NoError(t, err) {
+		got, _ := os.ReadFile(archive)
+		assert.Equal(t, data, got)
+		os.Remove(archive)
+	}
+
+	// A download that does not match its checksum is not kept
+	_, err = downloadAsset(release, "collidertracker_macos.zip", dir)
+	assert.ErrorContains(t, err, "checksum")
+	left, _ := os.ReadDir(dir)
+	assert.Empty(t, left)
+
+	// Nothing is installed without a build for the platform or without checksums
+	_, err = downloadAsset(release, "", dir)
+	assert.Error(t, err)
+	release.Assets = release.Assets[:2]
+	_, err = downloadAsset(release, "collidertracker_linux.zip", dir)
+	assert.ErrorContains(t, err, "no checksums")
+}
+
+func TestFetchReleases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode([]Release{
+			{Tag: "v1.6.0-beta", Prerelease: true},
+			{Tag: "v1.5.0", Notes: "- Faster"},
+			{Tag: "nightly"},
+			{Tag: "v1.4.0"},
+		})
+	}))
+	defer server.Close()
+	defer func(url string) { ReleasesURL = url }(ReleasesURL)
+	ReleasesURL = server.URL + "/"
+
+	releases, err := FetchReleases()
+	assert.NoError(t, err)
+	if assert.Len(t, releases, 2, "Prereleases and tags that are not versions are left out") {
+		assert.Equal(t, "v1.5.0", releases[0].Tag)
+		assert.Equal(t, "- Faster", releases[0].Notes)
+	}
+
+	ReleasesURL = server.URL + "/missing"
+	_, err = FetchReleases()
+	assert.Error(t, err)
+}
+
+func TestReplaceBinary(t *testing.T) {
+	dir := t.TempDir()
+	exe := filepath.Join(dir, "collidertracker")
+	assert.NoError(t, os.WriteFile(exe, []byte("old"), 0755))
+
+	archive := filepath.Join(dir, "update.zip")
+	f, err := os.Create(archive)
+	assert.NoError(t, err)
+	w := zip.NewWriter(f)
+	for name, data := range map[string]string{"README.md": "readme", "collidertracker/collidertracker": "new"} {
+		entry, err := w.Create(name)
+		assert.NoError(t, err)
+		entry.Write([]byte(data))
+	}
+	assert.NoError(t, w.Close())
+	f.Close()
+
+	assert.NoError(t, replaceBinary(archive, exe))
+	data, _ := os.ReadFile(exe)
+	assert.Equal(t, "new", string(data))
+	data, _ = os.ReadFile(exe + ".old")
+	assert.Equal(t, "old", string(data), "The previous binary is kept")
+	_, err = os.Stat(exe + ".new")
+	assert.True(t, os.IsNotExist(err))
+
+	// A zip without the binary leaves it alone
+	empty := filepath.Join(dir, "empty.zip")
+	f, _ = os.Create(empty)
+	zip.NewWriter(f).Close()
+	f.Close()
+	assert.Error(t, replaceBinary(empty, exe))
+	data, _ = os.ReadFile(exe)
+	assert.Equal(t, "new", string(data))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/input"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/types"
@@ -43,7 +44,7 @@ func GetArpeggioStatusMessage(m *model.Model) string {
 
 func RenderArpeggioView(m *model.Model) string {
 	statusMsg := GetArpeggioStatusMessage(m)
-	return renderViewWithCommonPattern(m, "Arpeggio Settings", fmt.Sprintf("Arpeggio %02X", m.ArpeggioEditingIndex), func(styles *ViewStyles) string {
+	return renderViewWithCommonPattern(m, i18n.T("Arpeggio Settings"), fmt.Sprintf(i18n.T("Arpeggio %02X"), m.ArpeggioEditingIndex), func(styles *ViewStyles) string {
 		var content strings.Builder
 		content.WriteString("\n")
 
@@ -56,7 +57,7 @@ func RenderArpeggioView(m *model.Model) string {
 		settings := m.ArpeggioSettings[m.ArpeggioEditingIndex]
 
 		// Render 16 rows (00 to 0F), each with its own DI and CO values
-		for row := 0; row < 16; row++ {
+		for row := 0; row < types.ArpeggioRows; row++ {
 			// Row label
 			rowLabel := fmt.Sprintf("%02X", row)
 
@@ -118,5 +119,5 @@ func RenderArpeggioView(m *model.Model) string {
 		}
 
 		return content.String()
-	}, fmt.Sprintf("arrows: navigate | %s+arrows: adjust", input.GetModifierKey()), statusMsg, 18) // 16 rows + 1 header + 1 spacing
+	}, fmt.Sprintf(i18n.T("arrows: navigate | %s+arrows: adjust"), input.GetModifierKey()), statusMsg, 18) // 16 rows + 1 header + 1 spacing
 }
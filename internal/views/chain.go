@@ -3,7 +3,9 @@ package views
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/input"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/ticks"
@@ -11,21 +13,31 @@ import (
 )
 
 func RenderChainView(m *model.Model) string {
+	now := time.Now()
 	return renderViewWithCommonPattern(m, "", "", func(styles *ViewStyles) string {
 		var content strings.Builder
 
 		// Render header with chain name on the right (like Phrase View)
-		columnHeader := "      PH"
+		columnHeader := "      PH  TR RV LP"
 		chainsData := m.GetCurrentChainsData()
 		phrasesData := m.GetCurrentPhrasesData()
 		totalTicks := ticks.CalculateChainTicks(chainsData, phrasesData, m.CurrentChain)
-		chainHeader := fmt.Sprintf("Chain %02X (%d ticks)", m.CurrentChain, totalTicks)
+		chainHeader := fmt.Sprintf("Chain %02X%s (%d ticks)", m.CurrentChain, headerName(m.DisplayChainName(m.CurrentTrack, m.CurrentChain)), totalTicks)
 		content.WriteString(RenderHeader(m, columnHeader, chainHeader))
 
 		// Render 16 rows of the current chain
 		visibleRows := 16            // Always show all 16 rows of a chain
 		chainIndex := m.CurrentChain // We need to track which chain we're viewing
 
+		// Phrase names get a column when any row's phrase has one
+		showNames := false
+		for row := 0; row < visibleRows; row++ {
+			if name, _ := m.DisplayPhraseName(m.CurrentTrack, m.GetChainCell(m.CurrentTrack, chainIndex, row)); name != "" {
+				showNames = true
+				break
+			}
+		}
+
 		for row := 0; row < visibleRows; row++ {
 			// Row indicator with playback arrow
 			rowIndicator := fmt.Sprintf(" %02X ", row)
@@ -55,8 +67,7 @@ func RenderChainView(m *model.Model) string {
 			content.WriteString(rowIndicator)
 
 			// Get phrase ID for this chain row
-			chainsData := m.GetCurrentChainsData()
-			phraseID := (*chainsData)[chainIndex][row]
+			phraseID := m.GetChainCell(m.CurrentTrack, chainIndex, row)
 			var phraseCell string
 
 			// Format the phrase ID
@@ -67,7 +78,7 @@ func RenderChainView(m *model.Model) string {
 			}
 
 			// Determine cell styling
-			isSelected := (m.CurrentRow == row)
+			isSelected := (m.CurrentRow == row && types.ChainColumn(m.CurrentCol) == types.ChainColPhrase)
 
 			if isSelected {
 				// Selected cell
@@ -85,9 +96,56 @@ func RenderChainView(m *model.Model) string {
 			}
 
 			content.WriteString("  " + phraseCell)
+
+			// Transpose in semitones, dimmed when the slot is untransposed
+			transpose := m.GetChainTranspose(m.CurrentTrack, chainIndex, row)
+			transposeCell := fmt.Sprintf("%+03d", transpose)
+			if m.CurrentRow == row && types.ChainColumn(m.CurrentCol) == types.ChainColTranspose {
+				transposeCell = styles.Selected.Render(transposeCell)
+			} else if transpose == 0 {
+				transposeCell = styles.Label.Render(transposeCell)
+			} else {
+				transposeCell = styles.Normal.Render(transposeCell)
+			}
+			content.WriteString(" " + transposeCell)
+
+			// Effect overrides
+			fx := m.GetChainFX(m.CurrentTrack, chainIndex, row)
+			for _, cell := range []struct {
+				col   types.ChainColumn
+				value int
+			}{{types.ChainColReverb, fx.Reverb}, {types.ChainColLowPass, fx.LowPass}} {
+				text := "--"
+				if cell.value != -1 {
+					text = fmt.Sprintf("%02X", cell.value)
+				}
+				if m.CurrentRow == row && types.ChainColumn(m.CurrentCol) == cell.col {
+					text = styles.Selected.Render(text)
+				} else if cell.value == -1 {
+					text = styles.Label.Render(text)
+				} else {
+					text = styles.Normal.Render(text)
+				}
+				content.WriteString(" " + text)
+			}
+
+			// Name of the row's phrase, dimmed when it is automatic
+			if showNames {
+				name, auto := m.DisplayPhraseName(m.CurrentTrack, phraseID)
+				nameCell := fmt.Sprintf("%-*s", model.MaxNameLength, name)
+				if auto {
+					nameCell = styles.Label.Render(nameCell)
+				} else {
+					nameCell = styles.Normal.Render(nameCell)
+				}
+				content.WriteString(" " + nameCell)
+			}
+
+			// Tracks playing or queued on this row in song playback
+			content.WriteString(renderChainPlayheads(m, styles, chainIndex, row, now))
 			content.WriteString("\n")
 		}
 
 		return content.String()
-	}, fmt.Sprintf("arrows: edit | %s+arrows: edit phrase", input.GetModifierKey()), GetChainStatusMessage(m), 16) // 16 rows (undercount waveform like Phrase view)
+	}, fmt.Sprintf(i18n.T("arrows: move | %s+arrows: edit | %s+n: name | /: find"), input.GetModifierKey(), input.GetModifierKey()), GetChainStatusMessage(m), 16) // 16 rows (undercount waveform like Phrase view)
 }
@@ -0,0 +1,47 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/schollz/collidertracker/internal/i18n"
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// RenderChangelogView shows the notes of the published releases, newest first, and whether
+// one is newer than the running version
+func RenderChangelogView(m *model.Model) string {
+	lines := m.ChangelogLines()
+	visibleRows := m.GetVisibleRows()
+	start := max(0, min(m.ChangelogScroll, len(lines)-1))
+	end := min(len(lines), start+visibleRows)
+
+	statusMsg := i18n.T("Up to date")
+	if release, ok := m.AvailableUpdate(); ok {
+		statusMsg = fmt.Sprintf(i18n.T("%s is available | u: download and install"), release.Tag)
+	}
+	switch {
+	case m.UpdateBusy:
+		statusMsg = i18n.T("Checking for updates...")
+	case m.UpdateError != "":
+		statusMsg = fmt.Sprintf(i18n.T("Update failed: %s"), m.UpdateError)
+	case m.UpdateInstalled != "":
+		statusMsg = fmt.Sprintf(i18n.T("Installed %s, restart to use it"), m.UpdateInstalled)
+	case len(lines) == 0:
+		statusMsg = i18n.T("No releases found")
+	}
+
+	return renderViewWithCommonPattern(m, i18n.T("Changelog"), fmt.Sprintf(i18n.T("Running %s"), m.Version), func(styles *ViewStyles) string {
+		var content strings.Builder
+		content.WriteString("\n")
+		for _, line := range lines[start:end] {
+			if line != "" && !strings.HasPrefix(line, " ") {
+				content.WriteString(styles.Label.Render(line))
+			} else {
+				content.WriteString(styles.Normal.Render(line))
+			}
+			content.WriteString("\n")
+		}
+		return content.String()
+	}, i18n.T("up/down: scroll | r: check again | u: install update | C/esc: back"), statusMsg, end-start+1)
+}
@@ -0,0 +1,134 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/schollz/collidertracker/internal/i18n"
+	"github.com/schollz/collidertracker/internal/input"
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// sparkBlocks are the levels of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// RenderDiagnosticsView shows the recent CPU, memory and OSC load of the tracker and the load
+// of the SuperCollider server, one sample a second
+func RenderDiagnosticsView(m *model.Model) string {
+	samples := m.Diagnostics()
+	latency := m.LatencyReport()
+	statusMsg := "Collecting the first sample..."
+	if latency.Running {
+		statusMsg = fmt.Sprintf("Measuring latency: %d/%d test triggers sent", latency.Sent, model.LatencyProbes)
+	} else if len(samples) > 0 {
+		statusMsg = fmt.Sprintf("Last %d seconds, newest on the right", len(samples))
+	}
+
+	// The history fits beside the labels and values on narrow terminals
+	sparkWidth := max(10, min(model.DiagnosticsHistory, m.TermWidth-4-45))
+
+	return renderViewWithCommonPattern(m, i18n.T("Diagnostics"), fmt.Sprintf(i18n.T("%d samples"), len(samples)), func(styles *ViewStyles) string {
+		var content strings.Builder
+		series := func(label string, value func(model.DiagnosticsSample) float64, format func(float64) string) {
+			values := make([]float64, len(samples))
+			for i, sample := range samples {
+				values[i] = value(sample)
+			}
+			current, peak := 0.0, 0.0
+			for _, v := range values {
+				peak = max(peak, v)
+			}
+			if len(values) > 0 {
+				current = values[len(values)-1]
+			}
+			content.WriteString(fmt.Sprintf("%-12s %10s  ", styles.Label.Render(label), styles.Normal.Render(format(current))))
+			content.WriteString(styles.Playback.Render(sparkline(values, sparkWidth, peak)))
+			content.WriteString(styles.Label.Render("  max " + format(peak)))
+			content.WriteString("\n")
+		}
+		percent := func(v float64) string { return fmt.Sprintf("%.1f%%", v) }
+		rate := func(v float64) string { return fmt.Sprintf("%.0f/s", v) }
+
+		content.WriteString("\n")
+		series("Tracker CPU:", func(s model.DiagnosticsSample) float64 { return s.CPU }, percent)
+		series("Heap:", func(s model.DiagnosticsSample) float64 { return float64(s.HeapBytes) },
+			func(v float64) string { return formatBytes(int64(v)) })
+		series("Goroutines:", func(s model.DiagnosticsSample) float64 { return float64(s.Goroutines) },
+			func(v float64) string { return fmt.Sprintf("%.0f", v) })
+		series("OSC in:", func(s model.DiagnosticsSample) float64 { return s.OSCIn }, rate)
+		series("OSC out:", func(s model.DiagnosticsSample) float64 { return s.OSCOut }, rate)
+		series("SC CPU avg:", func(s model.DiagnosticsSample) float64 { return float64(s.SCAvgCPU) }, percent)
+		series("SC CPU peak:", func(s model.DiagnosticsSample) float64 { return float64(s.SCPeakCPU) }, percent)
+		content.WriteString("\n")
+		content.WriteString(renderLatencyReport(m, latency, styles))
+		return content.String()
+	}, fmt.Sprintf(i18n.T("m: measure latency | tab: project stats | %s+T/esc: back"), input.GetModifierKey()), statusMsg, 14)
+}
+
+// renderLatencyReport shows the round trips of the latest latency measurement next to the
+// length of a server block, the smallest step the audio callback can add
+func renderLatencyReport(m *model.Model, report model.LatencyReport, styles *ViewStyles) string {
+	var content strings.Builder
+	ms := func(d time.Duration) string { return fmt.Sprintf("%.2f ms", float64(d)/float64(time.Millisecond)) }
+	sampleRate, blockSize := m.ServerAudioInfo()
+	var block time.Duration
+	blockInfo := "unknown until the server reports"
+	if sampleRate > 0 && blockSize > 0 {
+		block = time.Duration(blockSize) * time.Second / time.Duration(sampleRate)
+		blockInfo = fmt.Sprintf("%d samples, %s at %d Hz", blockSize, ms(block), sampleRate)
+	}
+	content.WriteString(fmt.Sprintf("%-12s %s\n", styles.Label.Render("Block:"), styles.Normal.Render(blockInfo)))
+
+	if report.Sent == 0 {
+		content.WriteString(styles.Label.Render("Press m to send test triggers and measure OSC and audio latency"))
+		content.WriteString("\n")
+		return content.String()
+	}
+	for _, path := range []struct {
+		label string
+		stats model.LatencyStats
+	}{
+		{"OSC trip:", report.Paths[model.LatencyPathLanguage]},
+		{"Audio trip:", report.Paths[model.LatencyPathServer]},
+	} {
+		line := fmt.Sprintf("%d/%d replies", path.stats.Received, report.Sent)
+		if path.stats.Received > 0 {
+			line = fmt.Sprintf("mean %s  min %s  max %s  jitter %s  %s", ms(path.stats.Mean), ms(path.stats.Min),
+				ms(path.stats.Max), ms(path.stats.Jitter), line)
+		}
+		content.WriteString(fmt.Sprintf("%-12s %s\n", styles.Label.Render(path.label), styles.Normal.Render(line)))
+	}
+
+	// Audio jitter beyond a block means the callback itself is late: a larger buffer steadies it
+	audio := report.Paths[model.LatencyPathServer]
+	switch {
+	case !report.Done:
+		content.WriteString(styles.Label.Render("Measuring..."))
+	case audio.Received < report.Sent:
+		content.WriteString(styles.Label.Render("Some test triggers were lost: the server is overloaded or not running"))
+	case block > 0 && audio.Jitter > block:
+		content.WriteString(styles.Label.Render("Audio jitter is over one block: try a larger hardware buffer"))
+	default:
+		content.WriteString(styles.Label.Render("Audio timing is steady"))
+	}
+	content.WriteString("\n")
+	return content.String()
+}
+
+// sparkline draws values scaled to top as a line of block characters, right-aligned in width cells
+func sparkline(values []float64, width int, top float64) string {
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	var line strings.Builder
+	line.WriteString(strings.Repeat(" ", width-len(values)))
+	for _, v := range values {
+		level := 0
+		if top > 0 {
+			level = int(v / top * float64(len(sparkBlocks)-1))
+		}
+		line.WriteRune(sparkBlocks[max(0, min(len(sparkBlocks)-1, level))])
+	}
+	return line.String()
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/input"
 	"github.com/schollz/collidertracker/internal/model"
 )
@@ -23,7 +24,7 @@ func RenderDuckingView(m *model.Model) string {
 	var content strings.Builder
 
 	// Render header
-	header := "Ducking Settings"
+	header := i18n.T("Ducking Settings")
 	duckingHeader := fmt.Sprintf("Ducking %02X", m.DuckingEditingIndex)
 	content.WriteString(RenderHeader(m, header, duckingHeader))
 	content.WriteString("\n")
@@ -119,8 +120,8 @@ func RenderDuckingView(m *model.Model) string {
 	content.WriteString("\n")
 
 	// Footer with status
-	helpText := fmt.Sprintf("arrows: navigate | %s+arrows: adjust", input.GetModifierKey())
-	statusMsg := fmt.Sprintf("Ducking settings")
+	helpText := fmt.Sprintf(i18n.T("arrows: navigate | %s+arrows: adjust"), input.GetModifierKey())
+	statusMsg := i18n.T("Ducking settings")
 	footerPad := 6
 	if settings.Type == 2 {
 		footerPad = 9
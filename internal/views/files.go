@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/input"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/types"
@@ -12,7 +13,7 @@ import (
 
 func RenderFileMetadataView(m *model.Model) string {
 	filename := filepath.Base(m.MetadataEditingFile)
-	header := fmt.Sprintf("File Metadata: %s", filename)
+	header := fmt.Sprintf(i18n.T("File Metadata: %s"), filename)
 
 	return renderViewWithCommonPattern(m, header, "", func(styles *ViewStyles) string {
 		var content strings.Builder
@@ -26,9 +27,14 @@ func RenderFileMetadataView(m *model.Model) string {
 
 		// Helper to get option text
 		sliceTypeOptions := []string{"Even", "Onsets"}
-		playthroughOptions := []string{"Sliced", "Oneshot", "Slice Bounce", "Slice Stop"}
+		playthroughOptions := []string{"Sliced", "Oneshot", "Slice Bounce", "Slice Stop", "Kit"}
 		syncToBPMOptions := []string{"No", "Yes"}
 
+		playthroughName := playthroughOptions[metadata.Playthrough]
+		if metadata.Playthrough == types.PlaythroughKit {
+			playthroughName = fmt.Sprintf("Kit (%d files, NN picks the file)", len(metadata.Kit))
+		}
+
 		// Metadata settings with common rendering pattern
 		settings := []struct {
 			label string
@@ -38,8 +44,9 @@ func RenderFileMetadataView(m *model.Model) string {
 			{"BPM:", fmt.Sprintf("%.2f", metadata.BPM), 0},
 			{"Slices:", fmt.Sprintf("%d", metadata.Slices), 1},
 			{"Slice Type:", sliceTypeOptions[metadata.SliceType], 2},
-			{"Playthrough:", playthroughOptions[metadata.Playthrough], 3},
+			{"Playthrough:", playthroughName, 3},
 			{"Sync to BPM:", syncToBPMOptions[metadata.SyncToBPM], 4},
+			{"Onset Preset:", onsetPresetName(metadata.OnsetPreset), 5},
 		}
 
 		for _, setting := range settings {
@@ -54,6 +61,15 @@ func RenderFileMetadataView(m *model.Model) string {
 			content.WriteString("\n")
 		}
 
+		// Detected when the file was analyzed, for matching loops with written parts
+		key, chords := metadata.Key, chordSummary(metadata.Chords)
+		if key == "" {
+			key = "-"
+		}
+		for _, info := range [][2]string{{"Key:", key}, {"Chords:", chords}} {
+			content.WriteString(fmt.Sprintf("  %-8s %s\n", styles.Label.Render(info[0]), styles.Normal.Render(info[1])))
+		}
+
 		content.WriteString("\n")
 
 		// File info
@@ -62,11 +78,11 @@ func RenderFileMetadataView(m *model.Model) string {
 		content.WriteString("\n\n")
 
 		return content.String()
-	}, fmt.Sprintf("arrows: navigate | %s+arrows: adjust", input.GetModifierKey()), " ", 9) // Space as status to align footer height
+	}, fmt.Sprintf(i18n.T("arrows: navigate | %s+arrows: adjust"), input.GetModifierKey()), " ", 12) // Space as status to align footer height
 }
 
 func RenderFileView(m *model.Model) string {
-	header := fmt.Sprintf("File Browser: %s", m.CurrentDir)
+	header := fmt.Sprintf(i18n.T("File Browser: %s"), m.CurrentDir)
 	visibleRows := m.GetVisibleRows()
 
 	// Only count the rows we actually render so the footer can pad the view
@@ -106,10 +122,42 @@ func RenderFileView(m *model.Model) string {
 			}
 
 			row := fmt.Sprintf("%s %s", arrow, fileCell)
+			if slot := m.QuickSlotOf(filepath.Join(m.CurrentDir, filename)); slot >= 0 {
+				row += " " + styles.Playback.Render(fmt.Sprintf("[%d]", slot))
+			}
+			if metadata, ok := m.FileMetadata[filepath.Join(m.CurrentDir, filename)]; ok && metadata.Key != "" {
+				row += " " + styles.Label.Render(metadata.Key+"  "+chordSummary(metadata.Chords))
+			}
 			content.WriteString(row)
 			content.WriteString("\n")
 		}
 
 		return content.String()
-	}, fmt.Sprintf("space: select | %s+right: play/stop", input.GetModifierKey()), " ", displayedRows) // Space as status to align footer height
+	}, fmt.Sprintf(i18n.T("space: select | %s+right: play/stop | 0-9: quick-slot | /: library | +: index folder"), input.GetModifierKey()), " ", displayedRows) // Space as status to align footer height
+}
+
+// onsetPresetName names the onset detection preset of a file
+func onsetPresetName(preset int) string {
+	if preset < 0 || preset >= len(model.OnsetPresets) {
+		preset = 0
+	}
+	return model.OnsetPresets[preset].Name
+}
+
+// chordSummary shows a chord progression with each run of a repeated chord written once and
+// "-" for bars without a chord, or "-" when there are none
+func chordSummary(chords []string) string {
+	var parts []string
+	for i, chord := range chords {
+		if chord == "" {
+			chord = "-"
+		}
+		if i == 0 || chords[i-1] != chords[i] {
+			parts = append(parts, chord)
+		}
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " ")
 }
@@ -0,0 +1,105 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/schollz/collidertracker/internal/i18n"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/modulation"
+	"github.com/schollz/collidertracker/internal/music"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// generatorVisibleRows is how many rows of the phrase the generator view lists at once
+const generatorVisibleRows = 16
+
+// RenderGeneratorView shows the phrase generator: its settings beside the rows of the phrase
+// it fills, with the locked rows marked
+func RenderGeneratorView(m *model.Model) string {
+	g, s := m.GeneratorState, m.Generator
+	sampler := m.TrackTypes[g.Track]
+	low, high := strings.ToUpper(music.MidiToNoteName(s.Low)), strings.ToUpper(music.MidiToNoteName(s.High))
+	scale, root := s.Scale, modulation.NoteNames[((s.Root%12)+12)%12]
+	if sampler {
+		low, high = fmt.Sprintf("%02X", s.SliceLow), fmt.Sprintf("%02X", s.SliceHigh)
+		scale, root = "-", "-"
+	}
+	rhythm := model.GeneratorRhythms[max(0, min(len(model.GeneratorRhythms)-1, s.Rhythm))]
+	settings := []struct {
+		label string
+		value string
+	}{
+		{"Rhythm:", rhythm.Name},
+		{"Density:", fmt.Sprintf("%d%%", s.Density)},
+		{"Low:", low},
+		{"High:", high},
+		{"Scale:", scale},
+		{"Root:", root},
+		{"Length:", fmt.Sprintf("%d", s.Length)},
+	}
+
+	statusMsg := i18n.T("Enter rolls the phrase again, keeping the locked rows")
+	if !sampler {
+		if notes := m.GeneratorNotes(); len(notes) == 0 {
+			statusMsg = i18n.T("No notes of the scale in the range")
+		}
+	}
+
+	return renderViewWithCommonPattern(m, i18n.T("Phrase Generator"), fmt.Sprintf("Phrase %02X", g.Phrase), func(styles *ViewStyles) string {
+		var left strings.Builder
+		left.WriteString(styles.Label.Render(rhythm.Steps))
+		left.WriteString("\n")
+		for i, setting := range settings {
+			valueStyle := styles.Normal
+			if i == g.Row && !g.OnRows {
+				valueStyle = styles.Selected
+			}
+			left.WriteString(fmt.Sprintf("%-9s %s\n", styles.Label.Render(i18n.T(setting.label)), valueStyle.Render(setting.value)))
+		}
+
+		var right strings.Builder
+		first := max(0, min(g.PhraseRow-generatorVisibleRows/2, s.Length-generatorVisibleRows))
+		for row := first; row < min(s.Length, first+generatorVisibleRows, types.PhraseRows); row++ {
+			line := fmt.Sprintf("%02X %s", row, generatorRowNote(m, row, sampler))
+			if g.Locked[row] {
+				line += " L"
+			} else {
+				line += "  "
+			}
+			style := styles.Normal
+			if g.Locked[row] {
+				style = styles.Playback
+			}
+			if row == g.PhraseRow && g.OnRows {
+				style = styles.Selected
+			}
+			right.WriteString(style.Render(line))
+			right.WriteString("\n")
+		}
+
+		var content strings.Builder
+		content.WriteString("\n")
+		content.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left.String(), "    ", right.String()))
+		content.WriteString("\n")
+		return content.String()
+	}, i18n.T("left/right: change | tab: settings/rows | x: lock row | enter: generate | space: play | esc: back"), statusMsg, generatorVisibleRows+2)
+}
+
+// generatorRowNote shows what a phrase row plays: its note or slice, a rest, or nothing
+func generatorRowNote(m *model.Model, row int, sampler bool) string {
+	g := m.GeneratorState
+	if m.GetPhraseCell(g.Track, g.Phrase, row, types.ColDeltaTime) <= 0 {
+		return "   "
+	}
+	note := m.GetPhraseCell(g.Track, g.Phrase, row, types.ColNote)
+	switch {
+	case note == -1:
+		return "---"
+	case sampler:
+		return fmt.Sprintf("%02X ", note)
+	}
+	return strings.ToUpper(music.MidiToNoteName(note))
+}
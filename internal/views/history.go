@@ -0,0 +1,33 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/schollz/collidertracker/internal/i18n"
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// RenderHistoryView shows the edits made to the project, newest first
+func RenderHistoryView(m *model.Model) string {
+	entries := m.AuditEntries()
+	visibleRows := m.GetVisibleRows()
+	newest := len(entries) - 1 - max(0, min(m.AuditScroll, len(entries)-1))
+	oldest := max(0, newest-visibleRows+1)
+
+	statusMsg := i18n.T("Edits are saved with the project")
+	if len(entries) == 0 {
+		statusMsg = i18n.T("No edits yet")
+	}
+
+	return renderViewWithCommonPattern(m, i18n.T("History"), fmt.Sprintf(i18n.T("%d edits"), len(entries)), func(styles *ViewStyles) string {
+		var content strings.Builder
+		content.WriteString("\n")
+		for i := newest; i >= oldest; i-- {
+			content.WriteString(styles.Label.Render(entries[i].Time.Format(model.AuditTimeFormat) + "  "))
+			content.WriteString(styles.Normal.Render(entries[i].Text))
+			content.WriteString("\n")
+		}
+		return content.String()
+	}, i18n.T("up/down: scroll | e: export to history.txt | G/esc: back"), statusMsg, newest-oldest+2)
+}
@@ -0,0 +1,112 @@
+package views
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/schollz/collidertracker/internal/i18n"
+	"github.com/schollz/collidertracker/internal/input"
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// inputMeterWidth is the width of each input level meter in cells
+const inputMeterWidth = 48
+
+// RenderInputView shows the levels of the audio input with its gain, arming and monitoring,
+// for setting record levels before a take
+func RenderInputView(m *model.Model) string {
+	levels := m.InputLevels(time.Now())
+	armed := "disarmed"
+	if m.InputArmed {
+		armed = "armed"
+	}
+	statusMsg := fmt.Sprintf("Input %s, %d clipped readings", armed, levels.Clips)
+
+	return renderViewWithCommonPattern(m, i18n.T("Input"), fmt.Sprintf("%.1f dB", m.InputLevelDB), func(styles *ViewStyles) string {
+		var content strings.Builder
+		content.WriteString("\n")
+		for ch, name := range []string{"L", "R"} {
+			content.WriteString(styles.Label.Render(name + "  "))
+			content.WriteString(renderInputMeter(levels.Peak[ch], levels.Hold[ch]))
+			content.WriteString(styles.Label.Render(fmt.Sprintf(" %6.1f dB", levels.Hold[ch])))
+			content.WriteString("\n")
+		}
+		content.WriteString(styles.Label.Render("   " + inputMeterScale()))
+		content.WriteString("\n\n")
+
+		clip := styles.Label.Render("CLIP")
+		if levels.Clipping {
+			clip = lipgloss.NewStyle().Background(paletteOf(m).Warning).Foreground(lipgloss.Color("0")).Render("CLIP")
+		}
+		content.WriteString(clip)
+		content.WriteString("\n\n")
+
+		row := func(label, value string, on bool) {
+			style := styles.Label
+			if on {
+				style = styles.Normal
+			}
+			content.WriteString(fmt.Sprintf("%-10s %s\n", styles.Label.Render(label), style.Render(value)))
+		}
+		row("Gain:", fmt.Sprintf("%.1f dB", m.InputLevelDB), true)
+		row("Armed:", onOff(m.InputArmed), m.InputArmed)
+		row("Monitor:", onOff(m.InputMonitor), m.InputMonitor)
+		row("Recording:", onOff(m.RecordingActive), m.RecordingActive)
+		return content.String()
+	}, fmt.Sprintf(i18n.T("up/down: gain ±1 dB | left/right: ±0.1 dB | a: arm | m: monitor | r: reset clips | t: tuner | %s+R: record | I/esc: back"), input.GetModifierKey()),
+		statusMsg, 12)
+}
+
+// renderInputMeter draws a level meter from InputMeterFloorDB to 0 dBFS: green, yellow above
+// -12 dB and red above -3 dB, with the held peak marked
+func renderInputMeter(peak, hold float32) string {
+	cell := func(db float32) int {
+		pos := (db - model.InputMeterFloorDB) / -model.InputMeterFloorDB * inputMeterWidth
+		return int(math.Round(float64(max(0, min(inputMeterWidth, pos)))))
+	}
+	filled, held := cell(peak), cell(hold)-1
+	var meter strings.Builder
+	for i := 0; i < inputMeterWidth; i++ {
+		db := model.InputMeterFloorDB * float32(inputMeterWidth-i-1) / inputMeterWidth
+		color := "10"
+		if db > -3 {
+			color = "9"
+		} else if db > -12 {
+			color = "11"
+		}
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+		switch {
+		case i < filled:
+			meter.WriteString(style.Render("█"))
+		case i == held:
+			meter.WriteString(style.Render("▌"))
+		default:
+			meter.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("238")).Render("░"))
+		}
+	}
+	return meter.String()
+}
+
+// inputMeterScale labels the meter every 12 dB
+func inputMeterScale() string {
+	scale := []byte(strings.Repeat(" ", inputMeterWidth+3))
+	for db := float32(model.InputMeterFloorDB); db <= 0; db += 12 {
+		label := fmt.Sprintf("%d", int(db))
+		pos := int((db - model.InputMeterFloorDB) / -model.InputMeterFloorDB * inputMeterWidth)
+		pos = min(pos, len(scale)-len(label))
+		copy(scale[pos:], label)
+	}
+	return string(scale)
+}
+
+// onOff names a switch setting
+func onOff(on bool) string {
+	if on {
+		return i18n.T("on")
+	}
+	return i18n.T("off")
+}
@@ -3,6 +3,7 @@ package views
 import (
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -21,8 +22,8 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 	normalDefaultStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Bold(true)                          // Dimmed text for default pool values when not selected
 	sliceStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
 	sliceDownbeatStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("7"))                          // Lighter gray for downbeats
-	playbackStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))                              // Green
-	copiedStyle := lipgloss.NewStyle().Background(lipgloss.Color("3")).Foreground(lipgloss.Color("0")) // Yellow background
+	playbackStyle := lipgloss.NewStyle().Foreground(paletteOf(m).Playback)                             // Green by default
+	copiedStyle := lipgloss.NewStyle().Background(paletteOf(m).Copied).Foreground(lipgloss.Color("0")) // Yellow background by default
 
 	// Main container style with padding
 	containerStyle := lipgloss.NewStyle().
@@ -104,15 +105,15 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		}
 	}
 
-	columnHeader := headerStyle.Render("  SL  DT  NOT  MO  CAT  VE  GT ") + adsrHeader + effectHeader + headerStyle.Render("  AR  ") + somiHeader + headerStyle.Render("  DU")
+	columnHeader := headerStyle.Render("  SL  DT  NOT  MO  CAT  VE  GT ") + adsrHeader + effectHeader + headerStyle.Render("  AR  ") + somiHeader + headerStyle.Render("  DU  N2  N3  N4")
 	phrasesData := m.GetCurrentPhrasesData()
 	totalTicks := ticks.CalculatePhraseTicks(phrasesData, m.CurrentPhrase)
-	phraseHeader := headerStyle.Render(fmt.Sprintf("Instrument %02X (%d ticks)", m.CurrentPhrase, totalTicks))
+	phraseHeader := headerStyle.Render(fmt.Sprintf("Instrument %02X%s (%d ticks)", m.CurrentPhrase, headerName(m.DisplayPhraseName(m.CurrentTrack, m.CurrentPhrase)), totalTicks))
 	content.WriteString(RenderHeader(m, columnHeader, phraseHeader))
 
 	// Data rows
 	visibleRows := m.GetVisibleRows()
-	for i := 0; i < visibleRows && i+m.ScrollOffset < 255; i++ {
+	for i := 0; i < visibleRows && i+m.ScrollOffset < types.PhraseRows; i++ {
 		dataIndex := i + m.ScrollOffset
 
 		// Arrow for current row or playback
@@ -132,8 +133,8 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 				}
 			}
 		} else if m.CurrentRow == dataIndex {
-			// Not playing - show cursor arrow
-			arrow = "▶"
+			// Not playing - show the hollow cursor arrow, unlike the playback arrow
+			arrow = "▷"
 		}
 
 		// Slice number (hex)
@@ -146,8 +147,7 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		}
 
 		// Delta Time (DT) column - unified playback control for both Sampler and Instrument views
-		phrasesData := m.GetCurrentPhrasesData()
-		dtValue := (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColDeltaTime]
+		dtValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColDeltaTime)
 		dtText := input.GetEffectiveDTValue(dtValue)
 
 		var dtCell string
@@ -165,7 +165,7 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 
 		// Note (NOT) - use ColNote but display as note name
 		// For Instrument view, we're using the Note column to store MIDI note values (0-127)
-		noteValue := (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColNote]
+		noteValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColNote)
 		noteText := "---"
 		if noteValue != -1 {
 			noteText = music.MidiToNoteName(noteValue)
@@ -185,7 +185,7 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		}
 
 		// Modulation (MO) - display modulation index
-		modulateValue := (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColModulate]
+		modulateValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColModulate)
 		modulateText := "--"
 		if modulateValue != -1 {
 			modulateText = fmt.Sprintf("%02X", modulateValue)
@@ -219,7 +219,7 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		}
 
 		// Chord (C) - display chord type
-		chordValue := (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColChord]
+		chordValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColChord)
 		chordText := types.ChordTypeToString(types.ChordType(chordValue))
 
 		var chordCell string
@@ -236,7 +236,7 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		}
 
 		// Chord Addition (A) - display chord addition
-		chordAddValue := (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColChordAddition]
+		chordAddValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColChordAddition)
 		chordAddText := types.ChordAdditionToString(types.ChordAddition(chordAddValue))
 
 		var chordAddCell string
@@ -253,7 +253,7 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		}
 
 		// Chord Transposition (T) - display transposition value
-		chordTransValue := (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColChordTransposition]
+		chordTransValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColChordTransposition)
 		chordTransText := types.ChordTranspositionToString(types.ChordTransposition(chordTransValue))
 
 		var chordTransCell string
@@ -270,7 +270,7 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		}
 
 		// Velocity (VE) - display velocity value (00-7F)
-		velocityValue := (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColVelocity]
+		velocityValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColVelocity)
 		velocityText := "--"
 		if velocityValue != -1 {
 			velocityText = fmt.Sprintf("%02X", velocityValue)
@@ -290,7 +290,7 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		}
 
 		// Gate (GT) - display gate value
-		gateValue := (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColGate]
+		gateValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColGate)
 		gateText := "--"
 		if gateValue != -1 {
 			gateText = fmt.Sprintf("%02X", gateValue)
@@ -312,9 +312,9 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		// Attack (A) or MIDI CC 0 - display based on mode
 		var attackValue int
 		if m.SOColumnMode == types.SOModeMIDI {
-			attackValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColMidiCC0]
+			attackValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColMidiCC0)
 		} else {
-			attackValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColAttack]
+			attackValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColAttack)
 		}
 		attackText := "--"
 		if attackValue != -1 {
@@ -337,9 +337,9 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		// Decay (D) or MIDI CC 1 - display based on mode
 		var decayValue int
 		if m.SOColumnMode == types.SOModeMIDI {
-			decayValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColMidiCC1]
+			decayValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColMidiCC1)
 		} else {
-			decayValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColDecay]
+			decayValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColDecay)
 		}
 		decayText := "--"
 		if decayValue != -1 {
@@ -362,9 +362,9 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		// Sustain (S) or MIDI CC 2 - display based on mode
 		var sustainValue int
 		if m.SOColumnMode == types.SOModeMIDI {
-			sustainValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColMidiCC2]
+			sustainValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColMidiCC2)
 		} else {
-			sustainValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColSustain]
+			sustainValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColSustain)
 		}
 		sustainText := "--"
 		if sustainValue != -1 {
@@ -387,9 +387,9 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		// Release (R) or MIDI CC 3 - display based on mode
 		var releaseValue int
 		if m.SOColumnMode == types.SOModeMIDI {
-			releaseValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColMidiCC3]
+			releaseValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColMidiCC3)
 		} else {
-			releaseValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColRelease]
+			releaseValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColRelease)
 		}
 		releaseText := "--"
 		if releaseValue != -1 {
@@ -412,9 +412,9 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		// Reverb (RE) or MIDI CC 4 - display based on mode
 		var reverbValue int
 		if m.SOColumnMode == types.SOModeMIDI {
-			reverbValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColMidiCC4]
+			reverbValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColMidiCC4)
 		} else {
-			reverbValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColEffectReverb]
+			reverbValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColEffectReverb)
 		}
 		reverbText := "--"
 		if reverbValue != -1 {
@@ -437,9 +437,9 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		// Comb (CO) or MIDI CC 5 - display based on mode
 		var combValue int
 		if m.SOColumnMode == types.SOModeMIDI {
-			combValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColMidiCC5]
+			combValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColMidiCC5)
 		} else {
-			combValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColEffectComb]
+			combValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColEffectComb)
 		}
 		combText := "--"
 		if combValue != -1 {
@@ -462,9 +462,9 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		// Pan (PA) or MIDI CC 6 - display based on mode
 		var panValue int
 		if m.SOColumnMode == types.SOModeMIDI {
-			panValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColMidiCC6]
+			panValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColMidiCC6)
 		} else {
-			panValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColPan]
+			panValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColPan)
 		}
 		panText := "--"
 		if panValue != -1 {
@@ -487,9 +487,9 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		// LowPass (LP) or MIDI CC 7 - display based on mode
 		var lpValue int
 		if m.SOColumnMode == types.SOModeMIDI {
-			lpValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColMidiCC7]
+			lpValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColMidiCC7)
 		} else {
-			lpValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColLowPassFilter]
+			lpValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColLowPassFilter)
 		}
 		lpText := "--"
 		if lpValue != -1 {
@@ -512,9 +512,9 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		// HighPass (HP) or MIDI CC 8 - display based on mode
 		var hpValue int
 		if m.SOColumnMode == types.SOModeMIDI {
-			hpValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColMidiCC8]
+			hpValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColMidiCC8)
 		} else {
-			hpValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColHighPassFilter]
+			hpValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColHighPassFilter)
 		}
 		hpText := "--"
 		if hpValue != -1 {
@@ -535,7 +535,7 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		}
 
 		// Arpeggio (AR) - display arpeggio index
-		arpeggioValue := (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColArpeggio]
+		arpeggioValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColArpeggio)
 		arpeggioText := "--"
 		if arpeggioValue != -1 {
 			arpeggioText = fmt.Sprintf("%02X", arpeggioValue)
@@ -558,9 +558,9 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		var somiValue int
 		var somiText string
 		if m.SOColumnMode == types.SOModeMIDI {
-			somiValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColMidi]
+			somiValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColMidi)
 		} else {
-			somiValue = (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColSoundMaker]
+			somiValue = m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColSoundMaker)
 		}
 		somiText = "--"
 		if somiValue != -1 {
@@ -595,7 +595,7 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 		}
 
 		// Ducking (DU) - display ducking index
-		duckingValue := (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColEffectDucking]
+		duckingValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColEffectDucking)
 		duckingText := "--"
 		if duckingValue != -1 {
 			duckingText = fmt.Sprintf("%02X", duckingValue)
@@ -628,15 +628,41 @@ func RenderInstrumentPhraseView(m *model.Model) string {
 			}
 		}
 
-		row := fmt.Sprintf("%s %-3s  %s  %s  %s  %s%s%s  %s  %s %s%s%s%s  %s  %s  %s  %s  %s  %s  %s  %s", arrow, sliceCell, dtCell, noteCell, modulateCell, chordCell, chordAddCell, chordTransCell, velocityCell, gateCell, attackCell, decayCell, sustainCell, releaseCell, reverbCell, combCell, panCell, lpCell, hpCell, arpeggioCell, somiCell, duckingCell)
+		// Stacked notes (N2-N4) - display as note names like NOT
+		var stackedCells [3]string
+		for n, col := range types.StackedNoteColumns {
+			uiCol := int(types.InstrumentColN2) + n
+			stackedValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, col)
+			stackedText := "---"
+			if stackedValue != -1 {
+				stackedText = music.MidiToNoteName(stackedValue)
+			}
+			if m.CurrentRow == dataIndex && m.CurrentCol == uiCol {
+				stackedCells[n] = selectedStyle.Render(fmt.Sprintf("%3s", stackedText))
+			} else if m.Clipboard.HasData && m.Clipboard.HighlightView == types.PhraseView && m.Clipboard.HighlightPhrase == m.CurrentPhrase && m.Clipboard.HighlightRow == dataIndex &&
+				(m.Clipboard.Mode == types.RowMode || (m.Clipboard.Mode == types.CellMode && m.Clipboard.HighlightCol == uiCol)) {
+				stackedCells[n] = copiedStyle.Render(fmt.Sprintf("%3s", stackedText))
+			} else {
+				stackedCells[n] = normalStyle.Render(fmt.Sprintf("%3s", stackedText))
+			}
+		}
+
+		row := fmt.Sprintf("%s %-3s  %s  %s  %s  %s%s%s  %s  %s %s%s%s%s  %s  %s  %s  %s  %s  %s  %s  %s %s %s %s", arrow, sliceCell, dtCell, noteCell, modulateCell, chordCell, chordAddCell, chordTransCell, velocityCell, gateCell, attackCell, decayCell, sustainCell, releaseCell, reverbCell, combCell, panCell, lpCell, hpCell, arpeggioCell, somiCell, duckingCell, stackedCells[0], stackedCells[1], stackedCells[2])
 		content.WriteString(row)
 		content.WriteString("\n")
 	}
 
+	// Chord and scale helper
+	contentLines := visibleRows + 1 // +1 for header
+	if m.TheoryHelperShown() {
+		content.WriteString(renderTheoryHelper(m))
+		contentLines += model.TheoryHelperLines
+	}
+
 	// Footer with status
 	helpText := GetPhraseHelpText(m)
 	statusMsg := GetInstrumentPhraseStatusMessage(m)
-	content.WriteString(RenderFooter(m, visibleRows+1, helpText, statusMsg)) // +1 for header
+	content.WriteString(RenderFooter(m, contentLines, helpText, statusMsg))
 
 	// Apply container padding to entire content
 	return containerStyle.Render(content.String())
@@ -699,17 +725,16 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 
 	// Use centralized column mapping to determine current column
 	columnMapping := m.GetColumnMapping(m.CurrentCol)
-	phrasesData := m.GetCurrentPhrasesData()
 
 	if columnMapping != nil && (columnMapping.DataColumnIndex == int(types.ColNote) ||
 		columnMapping.DataColumnIndex == int(types.ColChord) ||
 		columnMapping.DataColumnIndex == int(types.ColChordAddition) ||
 		columnMapping.DataColumnIndex == int(types.ColChordTransposition)) { // NOT, C, A, or T columns
 		// Get current row data
-		noteValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColNote]
-		chordValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColChord]
-		chordAddValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColChordAddition]
-		chordTransValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColChordTransposition]
+		noteValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColNote)
+		chordValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColChord)
+		chordAddValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColChordAddition)
+		chordTransValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColChordTransposition)
 
 		if noteValue >= 0 && noteValue <= 127 {
 			noteName := music.MidiToNoteName(noteValue)
@@ -767,15 +792,15 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColModulate) { // MO column
 		// Show Modulation info
-		modulateValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColModulate]
-		if modulateValue >= 0 && modulateValue < 255 {
+		modulateValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColModulate)
+		if modulateValue >= 0 && modulateValue < types.NumSettings {
 			statusMsg = fmt.Sprintf("Modulate: %02X", modulateValue)
 		} else {
 			statusMsg = "No modulate selected"
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColDeltaTime) { // DT column
 		// Show DT playback info
-		playbackValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColDeltaTime]
+		playbackValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColDeltaTime)
 		if playbackValue > 0 {
 			statusMsg = fmt.Sprintf("DT: %02X (play %d ticks)", playbackValue, playbackValue)
 		} else {
@@ -783,7 +808,7 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColGate) { // GT column
 		// Show Gate info with percentage (80 = 100% gate)
-		gateValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColGate]
+		gateValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColGate)
 		if gateValue == -1 {
 			// Check for effective (sticky) Gate value
 			effectiveGateValue := input.GetEffectiveValueForTrack(m, m.CurrentPhrase, m.CurrentRow, int(types.ColGate), m.CurrentTrack)
@@ -799,7 +824,7 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColAttack) { // A column
 		// Show Attack info
-		attackValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColAttack]
+		attackValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColAttack)
 		if attackValue == -1 {
 			// Check for effective (sticky) Attack value
 			effectiveAttackValue := input.GetEffectiveValueForTrack(m, m.CurrentPhrase, m.CurrentRow, int(types.ColAttack), m.CurrentTrack)
@@ -815,7 +840,7 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColDecay) { // D column
 		// Show Decay info
-		decayValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColDecay]
+		decayValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColDecay)
 		if decayValue == -1 {
 			// Check for effective (sticky) Decay value
 			effectiveDecayValue := input.GetEffectiveValueForTrack(m, m.CurrentPhrase, m.CurrentRow, int(types.ColDecay), m.CurrentTrack)
@@ -831,7 +856,7 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColSustain) { // S column
 		// Show Sustain info
-		sustainValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColSustain]
+		sustainValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColSustain)
 		if sustainValue == -1 {
 			// Check for effective (sticky) Sustain value
 			effectiveSustainValue := input.GetEffectiveValueForTrack(m, m.CurrentPhrase, m.CurrentRow, int(types.ColSustain), m.CurrentTrack)
@@ -847,7 +872,7 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColRelease) { // R column
 		// Show Release info
-		releaseValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColRelease]
+		releaseValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColRelease)
 		if releaseValue == -1 {
 			// Check for effective (sticky) Release value
 			effectiveReleaseValue := input.GetEffectiveValueForTrack(m, m.CurrentPhrase, m.CurrentRow, int(types.ColRelease), m.CurrentTrack)
@@ -863,7 +888,7 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColEffectReverb) { // RE column
 		// Show Reverb info with sticky behavior
-		reverbValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColEffectReverb]
+		reverbValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColEffectReverb)
 		if reverbValue == -1 {
 			// Check for effective (sticky) Reverb value
 			effectiveReverbValue := input.GetEffectiveValueForTrack(m, m.CurrentPhrase, m.CurrentRow, int(types.ColEffectReverb), m.CurrentTrack)
@@ -879,7 +904,7 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColEffectComb) { // CO column
 		// Show Comb info with sticky behavior
-		combValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColEffectComb]
+		combValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColEffectComb)
 		if combValue == -1 {
 			// Check for effective (sticky) Comb value
 			effectiveCombValue := input.GetEffectiveValueForTrack(m, m.CurrentPhrase, m.CurrentRow, int(types.ColEffectComb), m.CurrentTrack)
@@ -895,7 +920,7 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColPan) { // PA column
 		// Show Pan info with sticky behavior
-		panValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColPan]
+		panValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColPan)
 		if panValue == -1 {
 			// Check for effective (sticky) Pan value - default is 80 (center/0.0)
 			effectivePanValue := input.GetEffectiveValueForTrack(m, m.CurrentPhrase, m.CurrentRow, int(types.ColPan), m.CurrentTrack)
@@ -911,7 +936,7 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColLowPassFilter) { // LP column
 		// Show Low Pass Filter info with sticky behavior
-		lpValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColLowPassFilter]
+		lpValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColLowPassFilter)
 		if lpValue == -1 {
 			// Check for effective (sticky) Low Pass value - default is 20kHz
 			effectiveLpValue := input.GetEffectiveValueForTrack(m, m.CurrentPhrase, m.CurrentRow, int(types.ColLowPassFilter), m.CurrentTrack)
@@ -943,7 +968,7 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColHighPassFilter) { // HP column
 		// Show High Pass Filter info with sticky behavior
-		hpValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColHighPassFilter]
+		hpValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColHighPassFilter)
 		if hpValue == -1 {
 			// Check for effective (sticky) High Pass value - default is 20Hz
 			effectiveHpValue := input.GetEffectiveValueForTrack(m, m.CurrentPhrase, m.CurrentRow, int(types.ColHighPassFilter), m.CurrentTrack)
@@ -975,7 +1000,7 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColArpeggio) { // AR column
 		// Show Arpeggio info
-		arpeggioValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColArpeggio]
+		arpeggioValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColArpeggio)
 		if arpeggioValue == -1 {
 			statusMsg = "Arpeggio: -- (not assigned)"
 		} else {
@@ -983,7 +1008,7 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColMidi) { // MI column
 		// Show MIDI info with sticky behavior
-		midiValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColMidi]
+		midiValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColMidi)
 		if midiValue == -1 {
 			// Check for effective (sticky) MIDI value
 			effectiveMidiValue := input.GetEffectiveMidiValueForTrack(m, m.CurrentPhrase, m.CurrentRow, m.CurrentTrack)
@@ -997,21 +1022,47 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColSoundMaker) { // SO column
 		// Show SoundMaker info with sticky behavior
-		soundMakerValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColSoundMaker]
+		soundMakerValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColSoundMaker)
 		if soundMakerValue == -1 {
 			// Check for effective (sticky) SoundMaker value
 			effectiveSoundMakerValue := input.GetEffectiveValueForTrack(m, m.CurrentPhrase, m.CurrentRow, int(types.ColSoundMaker), m.CurrentTrack)
 			if effectiveSoundMakerValue == -1 {
 				statusMsg = "SoundMaker: -- (sticky)"
 			} else {
-				statusMsg = fmt.Sprintf("SoundMaker: -- (%02X sticky)", effectiveSoundMakerValue)
+				statusMsg = fmt.Sprintf("SoundMaker: -- (%02X %s, sticky)", effectiveSoundMakerValue, m.SoundMakerSettings[effectiveSoundMakerValue].Name)
+			}
+		} else {
+			statusMsg = fmt.Sprintf("SoundMaker: %02X %s (sticky)", soundMakerValue, m.SoundMakerSettings[soundMakerValue].Name)
+		}
+	} else if columnMapping != nil && slices.Contains(types.StackedNoteColumns, types.PhraseColumn(columnMapping.DataColumnIndex)) { // N2-N4 columns
+		// Show the stacked note and every note the row plays
+		stackedValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(columnMapping.DataColumnIndex))
+		stackedText := "--"
+		if stackedValue != -1 {
+			stackedText = music.MidiToNoteName(stackedValue)
+		}
+		statusMsg = fmt.Sprintf("%s: %s", columnMapping.DisplayName, stackedText)
+		noteValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColNote)
+		if noteValue != -1 {
+			notes := types.GetChordNotes(noteValue,
+				types.ChordType(m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColChord)),
+				types.ChordAddition(m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColChordAddition)),
+				types.ChordTransposition(m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColChordTransposition)))
+			notes = types.AddStackedNotes(notes,
+				m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColNote2),
+				m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColNote3),
+				m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColNote4))
+			names := make([]string, len(notes))
+			for i, note := range notes {
+				names[i] = music.MidiToNoteName(note)
 			}
+			statusMsg += " | Row plays " + strings.Join(names, " ")
 		} else {
-			statusMsg = fmt.Sprintf("SoundMaker: %02X (sticky)", soundMakerValue)
+			statusMsg += " | Needs a NOT to play"
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColVelocity) { // VE column
 		// Show Velocity info with sticky behavior
-		velocityValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColVelocity]
+		velocityValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColVelocity)
 		if velocityValue == -1 {
 			// Check for effective (sticky) Velocity value
 			effectiveVelocityValue := input.GetEffectiveValueForTrack(m, m.CurrentPhrase, m.CurrentRow, int(types.ColVelocity), m.CurrentTrack)
@@ -1025,7 +1076,7 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 		}
 	} else if columnMapping != nil && columnMapping.DataColumnIndex == int(types.ColEffectDucking) { // DU column
 		// Show Ducking info with sticky behavior (reuse logic from sampler view)
-		duckingValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColEffectDucking]
+		duckingValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColEffectDucking)
 		if duckingValue == -1 {
 			// Check for effective (sticky) Ducking value
 			effectiveDuckingValue := input.GetEffectiveValueForTrack(m, m.CurrentPhrase, m.CurrentRow, int(types.ColEffectDucking), m.CurrentTrack)
@@ -1040,7 +1091,7 @@ func GetInstrumentPhraseStatusMessage(m *model.Model) string {
 	} else if columnMapping != nil && columnMapping.DataColumnIndex >= int(types.ColMidiCC0) && columnMapping.DataColumnIndex <= int(types.ColMidiCC8) {
 		// Show MIDI CC info with controller number and decimal value
 		ccIndex := columnMapping.DataColumnIndex - int(types.ColMidiCC0)
-		ccValue := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.PhraseColumn(columnMapping.DataColumnIndex)]
+		ccValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(types.PhraseColumn(columnMapping.DataColumnIndex)))
 		ccNumber := m.MidiCCNumbers[ccIndex]
 
 		if ccValue == -1 {
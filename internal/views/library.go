@@ -0,0 +1,80 @@
+package views
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/schollz/collidertracker/internal/i18n"
+	"github.com/schollz/collidertracker/internal/input"
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// RenderLibraryView lists the samples of the library matching the search, with the type, BPM,
+// key and length found for each
+func RenderLibraryView(m *model.Model) string {
+	matches := m.LibraryMatches()
+	visibleRows := m.GetVisibleRows() - 2 // Search line and the blank line under it
+	start := 0
+	if m.CurrentRow >= visibleRows {
+		start = m.CurrentRow - visibleRows + 1
+	}
+	end := min(len(matches), start+visibleRows)
+
+	rightHeader := fmt.Sprintf(i18n.T("%d samples"), len(matches))
+	if scan := m.LibraryScan; scan != nil {
+		rightHeader = "SCAN"
+		if !scan.Listing {
+			rightHeader = fmt.Sprintf("SCAN %d/%d", scan.Done, len(scan.Files))
+		}
+		if scan.Paused {
+			rightHeader += " paused"
+		}
+	}
+
+	statusMsg := " "
+	switch {
+	case len(m.Config.SampleDirs) == 0:
+		statusMsg = i18n.T("No sample folders: press + in the File Browser to index the folder browsed")
+	case len(matches) == 0:
+		statusMsg = i18n.T("No samples match the search")
+	case m.CurrentRow < len(matches):
+		statusMsg = filepath.Dir(matches[m.CurrentRow].Path)
+	}
+
+	return renderViewWithCommonPattern(m, i18n.T("Sample Library"), rightHeader, func(styles *ViewStyles) string {
+		var content strings.Builder
+		content.WriteString(styles.Label.Render(i18n.T("Search: ")))
+		content.WriteString(styles.Normal.Render(m.LibraryQuery + "_"))
+		content.WriteString("\n\n")
+
+		for i := start; i < end; i++ {
+			entry := matches[i]
+			bpm := "-"
+			if entry.BPM > 0 {
+				bpm = fmt.Sprintf("%.1f", entry.BPM)
+			}
+			key := entry.Key
+			if key == "" {
+				key = "-"
+			}
+			line := fmt.Sprintf("%-32s %-7s %6s %-9s %7s", truncateRecordingName(filepath.Base(entry.Path), 32), entry.Type, bpm, key, formatDuration(entry.Duration))
+
+			indicator := "  "
+			if m.CurrentlyPlayingFile == entry.Path {
+				indicator = styles.Playback.Render("▶ ")
+			}
+			switch {
+			case i == m.CurrentRow:
+				content.WriteString(indicator + styles.Selected.Render(line))
+			case IsCurrentRowFile(m, entry.Path):
+				content.WriteString(indicator + styles.AssignedFile.Render(line))
+			default:
+				content.WriteString(indicator + styles.Normal.Render(line))
+			}
+			content.WriteString("\n")
+		}
+		return content.String()
+	}, fmt.Sprintf(i18n.T("type: search | enter: use in phrase row | %s+right: play/stop | esc: back"), input.GetModifierKey()),
+		statusMsg, end-start+2)
+}
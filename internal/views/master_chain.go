@@ -0,0 +1,37 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/schollz/collidertracker/internal/i18n"
+	"github.com/schollz/collidertracker/internal/input"
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// RenderMasterChainView lists the master chain stages in signal order
+func RenderMasterChainView(m *model.Model) string {
+	rightHeader := "custom"
+	if m.IsDefaultMasterChain() {
+		rightHeader = "default"
+	}
+	return renderViewWithCommonPattern(m, i18n.T("Master Chain"), rightHeader, func(styles *ViewStyles) string {
+		var content strings.Builder
+		content.WriteString("\n")
+		content.WriteString(styles.Label.Render("  dry mix"))
+		content.WriteString("\n")
+		for i, stage := range m.MasterChain {
+			line := fmt.Sprintf("%d %-8s %s", i+1, stage, model.MasterStageDescriptions[stage])
+			if i == m.MasterChainRow {
+				content.WriteString("  " + styles.Selected.Render(line))
+			} else {
+				content.WriteString("  " + styles.Normal.Render(line))
+			}
+			content.WriteString("\n")
+		}
+		content.WriteString(styles.Label.Render("  post gain → output"))
+		content.WriteString("\n")
+		return content.String()
+	}, fmt.Sprintf(i18n.T("up/down: select | %s+up/down: move | r: reset | esc: back"), input.GetModifierKey()),
+		"Stages process the mix from top to bottom", len(m.MasterChain)+3)
+}
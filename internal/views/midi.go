@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/input"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/types"
@@ -33,7 +34,7 @@ func GetMidiStatusMessage(m *model.Model) string {
 
 func RenderMidiView(m *model.Model) string {
 	statusMsg := GetMidiStatusMessage(m)
-	return renderViewWithCommonPattern(m, "MIDI Settings", fmt.Sprintf("MIDI %02X", m.MidiEditingIndex), func(styles *ViewStyles) string {
+	return renderViewWithCommonPattern(m, i18n.T("MIDI Settings"), fmt.Sprintf("MIDI %02X", m.MidiEditingIndex), func(styles *ViewStyles) string {
 		var content strings.Builder
 		content.WriteString("\n")
 
@@ -96,5 +97,5 @@ func RenderMidiView(m *model.Model) string {
 		}
 
 		return content.String()
-	}, fmt.Sprintf("arrows: navigate | space: select | %s+arrows: adjust", input.GetModifierKey()), statusMsg, m.GetVisibleRows()) // Use dynamic visible rows
+	}, fmt.Sprintf(i18n.T("arrows: navigate | space: select | %s+arrows: adjust"), input.GetModifierKey()), statusMsg, m.GetVisibleRows()) // Use dynamic visible rows
 }
@@ -7,8 +7,10 @@ import (
 
 	"github.com/lucasb-eyer/go-colorful"
 	"github.com/muesli/termenv"
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/input"
 	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
 )
 
 // getUnicodeBlock returns the appropriate Unicode block character for a fill ratio (0-1)
@@ -151,15 +153,47 @@ func getMixerStatusMessage(m *model.Model) string {
 
 	statusMsg := fmt.Sprintf("%s: Set %.1fdB (Hex %02X)",
 		trackLabel, setLevel, dbToHex(setLevel))
+	if track < types.NumTracks {
+		statusMsg += fmt.Sprintf(" | Res x%d (PPQ %d)", m.TrackResolution(track), m.PPQ*m.TrackResolution(track))
+		if m.RecordQuantize[track] == 0 {
+			statusMsg += " | Rec quantize off"
+		} else {
+			statusMsg += fmt.Sprintf(" | Rec quantize %s %d%%", types.GetRecordQuantizeName(m.RecordQuantize[track]), m.RecordQuantizeStrength[track])
+		}
+		if m.HumanizeTiming[track] == 0 && m.HumanizeVelocity[track] == 0 {
+			statusMsg += " | Humanize off"
+		} else {
+			statusMsg += fmt.Sprintf(" | Humanize ±%d/%d beat, velocity ±%d", m.HumanizeTiming[track], model.HumanizeTicksPerBeat, m.HumanizeVelocity[track])
+		}
+	}
+	if track == 8 {
+		if m.InputMonitor {
+			statusMsg += fmt.Sprintf(" | Monitor on, insert %s", types.GetInputInsertName(m.InputInsert))
+		} else {
+			statusMsg += " | Monitor off (Settings)"
+		}
+	}
 
 	return statusMsg
 }
 
+// recordQuantizeCell shortens a record quantize grid to the two characters of a mixer column
+func recordQuantizeCell(grid int) string {
+	switch types.GetRecordQuantizeName(grid) {
+	case "1/16":
+		return "16"
+	case "1/8":
+		return " 8"
+	default:
+		return "--"
+	}
+}
+
 // RenderMixerView renders a modern, sleek mixer view with vertical level meters
 func RenderMixerView(m *model.Model) string {
 	// Column headers (matching song view format)
 	columnHeader := "    " // 4 spaces for left padding like song view row numbers
-	for track := 0; track < 8; track++ {
+	for track := 0; track < types.NumTracks; track++ {
 		columnHeader += fmt.Sprintf("  T%d", track+1)
 	}
 	// Add Input track (Track 9, index 8)
@@ -171,6 +205,12 @@ func RenderMixerView(m *model.Model) string {
 	} else {
 		mixerHeader = fmt.Sprintf("Track %d", m.CurrentMixerTrack+1)
 	}
+	// The side of the A/B compare playing, marked when the other side holds another mix
+	compare := m.MixerSlotName()
+	if m.MixerSidesDiffer() {
+		compare += "≠" + m.OtherMixerSlotName()
+	}
+	mixerHeader += "  [" + compare + "]"
 
 	// Calculate bar height - smaller to fit with waveform
 	barHeight := 8
@@ -191,7 +231,7 @@ func RenderMixerView(m *model.Model) string {
 		// Render the vertical bars row by row
 		for row := 0; row < barHeight; row++ {
 			content.WriteString("    ") // Left padding like song view
-			for track := 0; track < 8; track++ {
+			for track := 0; track < types.NumTracks; track++ {
 				content.WriteString("  ") // 2 spaces before each track (like song view)
 				content.WriteString(trackBars[track][row])
 			}
@@ -203,7 +243,7 @@ func RenderMixerView(m *model.Model) string {
 
 		// Current level values row (hex codes)
 		content.WriteString("    ")
-		for track := 0; track < 8; track++ {
+		for track := 0; track < types.NumTracks; track++ {
 			content.WriteString("  ")
 			currentLevel := m.TrackVolumes[track]
 			levelHex := fmt.Sprintf("%02X", dbToHex(currentLevel))
@@ -227,7 +267,7 @@ func RenderMixerView(m *model.Model) string {
 
 		// Set level values row (hex codes)
 		content.WriteString("    ")
-		for track := 0; track < 8; track++ {
+		for track := 0; track < types.NumTracks; track++ {
 			content.WriteString("  ")
 			setLevel := m.TrackSetLevels[track]
 			setHex := fmt.Sprintf("%02X", dbToHex(setLevel))
@@ -249,6 +289,46 @@ func RenderMixerView(m *model.Model) string {
 		}
 		content.WriteString("\n")
 
+		// Resolution row (ticks per PPQ tick, Input has none)
+		content.WriteString("    ")
+		for track := 0; track < types.NumTracks; track++ {
+			content.WriteString("  ")
+			resText := fmt.Sprintf("x%d", m.TrackResolution(track))
+			if track == m.CurrentMixerTrack && m.CurrentMixerRow == 1 {
+				content.WriteString(styles.Selected.Render(resText))
+			} else {
+				content.WriteString(styles.Label.Render(resText))
+			}
+		}
+		content.WriteString("\n")
+
+		// Record quantize rows (grid, then strength in hex percent) and humanize rows (timing in
+		// 1/96 beat, then velocity)
+		for row := 2; row <= 5; row++ {
+			content.WriteString("    ")
+			for track := 0; track < types.NumTracks; track++ {
+				content.WriteString("  ")
+				var text string
+				switch row {
+				case 2:
+					text = recordQuantizeCell(m.RecordQuantize[track])
+				case 3:
+					text = fmt.Sprintf("%02X", m.RecordQuantizeStrength[track])
+				case 4:
+					text = fmt.Sprintf("%02X", m.HumanizeTiming[track])
+				default:
+					text = fmt.Sprintf("%02X", m.HumanizeVelocity[track])
+				}
+				if track == m.CurrentMixerTrack && m.CurrentMixerRow == row {
+					content.WriteString(styles.Selected.Render(text))
+				} else {
+					content.WriteString(styles.Label.Render(text))
+				}
+			}
+			content.WriteString("\n")
+		}
+
 		return content.String()
-	}, fmt.Sprintf("left/right: select | %s+arrows: adjust", input.GetModifierKey()), getMixerStatusMessage(m), barHeight+2)
+	}, fmt.Sprintf(i18n.T("left/right: select | %s+arrows: adjust | a: A/B | b: copy %s to %s"),
+		input.GetModifierKey(), m.MixerSlotName(), m.OtherMixerSlotName()), getMixerStatusMessage(m), barHeight+7)
 }
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/input"
 	"github.com/schollz/collidertracker/internal/model"
 )
@@ -23,7 +24,7 @@ func RenderModulateView(m *model.Model) string {
 	var content strings.Builder
 
 	// Render header (includes waveform)
-	header := "Modulate Settings"
+	header := i18n.T("Modulate Settings")
 	modulateHeader := fmt.Sprintf("Modulate %02X", m.ModulateEditingIndex)
 	content.WriteString(RenderHeader(m, header, modulateHeader))
 	content.WriteString("\n")
@@ -164,8 +165,8 @@ func RenderModulateView(m *model.Model) string {
 	content.WriteString("\n\n")
 
 	// Footer with status
-	helpText := fmt.Sprintf("arrows: navigate | %s+arrows: adjust", input.GetModifierKey())
-	statusMsg := fmt.Sprintf("Modulate settings")
+	helpText := fmt.Sprintf(i18n.T("arrows: navigate | %s+arrows: adjust"), input.GetModifierKey())
+	statusMsg := i18n.T("Modulate settings")
 	// contentLines: waveform(2) + header(1) + settings(9) = 12
 	content.WriteString(RenderFooter(m, 11, helpText, statusMsg))
 
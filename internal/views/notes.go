@@ -0,0 +1,64 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/schollz/collidertracker/internal/i18n"
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// notesWidth is how many characters of each notes line are shown
+const notesWidth = 72
+
+// RenderNotesView shows the project notes being edited, with the cursor line scrolled
+// sideways to keep the cursor in sight
+func RenderNotesView(m *model.Model) string {
+	notes := m.Notes
+	if len(notes) == 0 {
+		notes = []string{""}
+	}
+	visibleRows := m.GetVisibleRows()
+	start := 0
+	if m.NotesRow >= visibleRows {
+		start = m.NotesRow - visibleRows + 1
+	}
+	end := min(len(notes), start+visibleRows)
+
+	words := 0
+	for _, line := range notes {
+		words += len(strings.Fields(line))
+	}
+	statusMsg := fmt.Sprintf(i18n.T("Line %d, column %d | Notes are saved with the project"), m.NotesRow+1, m.NotesCol+1)
+
+	return renderViewWithCommonPattern(m, i18n.T("Notes"), fmt.Sprintf(i18n.T("%d lines, %d words"), len(notes), words), func(styles *ViewStyles) string {
+		var content strings.Builder
+		content.WriteString("\n")
+		for i := start; i < end; i++ {
+			content.WriteString(styles.Label.Render(fmt.Sprintf("%3d ", i+1)))
+			line := []rune(notes[i])
+			if i != m.NotesRow {
+				if len(line) > notesWidth {
+					line = append(line[:notesWidth-1], '…')
+				}
+				content.WriteString(styles.Normal.Render(string(line)))
+				content.WriteString("\n")
+				continue
+			}
+			col := max(0, min(len(line), m.NotesCol))
+			from := max(0, col-notesWidth+1)
+			to := min(len(line), from+notesWidth)
+			under := " "
+			if col < len(line) {
+				under = string(line[col])
+			}
+			content.WriteString(styles.Normal.Render(string(line[from:col])))
+			content.WriteString(styles.Selected.Render(under))
+			if col < to {
+				content.WriteString(styles.Normal.Render(string(line[col+1 : to])))
+			}
+			content.WriteString("\n")
+		}
+		return content.String()
+	}, i18n.T("type to edit | enter: new line | arrows/home/end: move | esc: back"), statusMsg, end-start+1)
+}
@@ -0,0 +1,58 @@
+package views
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// palette is the set of colors that carry meaning: playback, warnings and selections. The
+// color-blind palettes are drawn from the Okabe-Ito colors, which stay apart for deuteranopia
+// and protanopia; protanopia darkens reds, so its warnings are orange.
+type palette struct {
+	Playback  lipgloss.Color // Playing rows and tracks, lit activity LEDs
+	Warning   lipgloss.Color // Errors, notices, recording and clipping
+	Attention lipgloss.Color // Countdowns, previews and other states waiting on the user
+	Copied    lipgloss.Color // Background of copied cells and assigned files
+	// Waveform view markers, as ANSI escapes
+	Marker         string // Slice markers
+	SelectedMarker string // Selected slice marker
+	Warp           string // Warp markers
+	SelectedWarp   string // Selected warp marker
+	Playhead       string // Playhead position
+}
+
+// palettes by types.Palette
+var palettes = [...]palette{
+	types.PaletteDefault: {
+		Playback: "10", Warning: "9", Attention: "11", Copied: "3",
+		Marker: "\033[33m", SelectedMarker: "\033[36m", Warp: "\033[35m", SelectedWarp: "\033[95m", Playhead: "\033[91m",
+	},
+	types.PaletteDeutan: {
+		Playback: "39", Warning: "166", Attention: "227", Copied: "214",
+		Marker: "\033[38;5;214m", SelectedMarker: "\033[38;5;39m", Warp: "\033[38;5;175m", SelectedWarp: "\033[38;5;255m", Playhead: "\033[38;5;227m",
+	},
+	types.PaletteProtan: {
+		Playback: "39", Warning: "214", Attention: "227", Copied: "227",
+		Marker: "\033[38;5;227m", SelectedMarker: "\033[38;5;33m", Warp: "\033[38;5;175m", SelectedWarp: "\033[38;5;255m", Playhead: "\033[38;5;214m",
+	},
+}
+
+// paletteOf returns the palette chosen in the settings
+func paletteOf(m *model.Model) palette {
+	if m.Palette >= 0 && m.Palette < len(palettes) {
+		return palettes[m.Palette]
+	}
+	return palettes[types.PaletteDefault]
+}
+
+// Marker glyphs in the waveform view, so selection shows without telling colors apart
+const (
+	markerGlyph            = "│"
+	selectedMarkerGlyph    = "┃"
+	warpGlyph              = "┆"
+	selectedWarpGlyph      = "┇"
+	warpLabelGlyph         = "△"
+	selectedWarpLabelGlyph = "▲"
+)
@@ -0,0 +1,116 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// playheadBlinkPeriod is how long queued playheads stay on and off while blinking
+const playheadBlinkPeriod = 300 * time.Millisecond
+
+// playheadBlinkOn reports whether blinking playheads are shown at a moment. It follows the
+// clock, so the blink is as fast at any tempo and frame rate.
+func playheadBlinkOn(now time.Time) bool {
+	return now.UnixMilli()/playheadBlinkPeriod.Milliseconds()%2 == 0
+}
+
+// songPlaying reports whether a track plays in song playback
+func songPlaying(m *model.Model, track int) bool {
+	return m.IsPlaying && m.PlaybackMode == types.SongView && m.SongPlaybackActive[track]
+}
+
+// songQueuedRow returns the song row a track is queued to start or jump to, or -1
+func songQueuedRow(m *model.Model, track int) int {
+	if !m.IsPlaying || m.PlaybackMode != types.SongView || m.SongPlaybackQueued[track] == 0 {
+		return -1
+	}
+	return m.SongPlaybackQueuedRow[track]
+}
+
+// samePool reports whether two tracks take their chains from the same pool
+func samePool(m *model.Model, a, b int) bool {
+	return a == b || (!m.PerTrackBanks && m.TrackTypes[a] == m.TrackTypes[b])
+}
+
+// renderSongPlayheadRows renders the chain row and phrase row each track is playing, under the
+// song grid: the song row is marked in the grid itself
+func renderSongPlayheadRows(m *model.Model, styles *ViewStyles) string {
+	var content strings.Builder
+	for _, line := range []struct {
+		label string
+		value func(track int) int
+	}{
+		{" CR ", func(track int) int { return m.SongPlaybackChainRow[track] }},
+		{" PR ", func(track int) int { return m.SongPlaybackRowInPhrase[track] }},
+	} {
+		content.WriteString(styles.Label.Render(line.label))
+		for track := 0; track < types.NumTracks; track++ {
+			if songPlaying(m, track) {
+				content.WriteString("  " + styles.Playback.Render(fmt.Sprintf("%02X", line.value(track))))
+			} else {
+				content.WriteString("  " + styles.Label.Render("--"))
+			}
+		}
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// renderChainPlayheads marks the tracks playing a chain row in song playback, with the row they
+// are at in its phrase, and the tracks queued to start the chain there (blinking). The track
+// being edited comes in the playhead style, the others sharing the chain in a dimmer one.
+func renderChainPlayheads(m *model.Model, styles *ViewStyles, chain, row int, now time.Time) string {
+	var marks []string
+	for track := 0; track < types.NumTracks; track++ {
+		if !samePool(m, track, m.CurrentTrack) {
+			continue
+		}
+		style := styles.Chain
+		if track == m.CurrentTrack {
+			style = styles.Playback
+		}
+		if songPlaying(m, track) && m.SongPlaybackChain[track] == chain && m.SongPlaybackChainRow[track] == row {
+			marks = append(marks, style.Render(fmt.Sprintf("T%d·%02X", track+1, m.SongPlaybackRowInPhrase[track])))
+		}
+		// Queued chains start from their first row
+		if queued := songQueuedRow(m, track); row == 0 && queued >= 0 && m.GetSongCell(track, queued) == chain && playheadBlinkOn(now) {
+			marks = append(marks, style.Render(fmt.Sprintf("T%d▸", track+1)))
+		}
+	}
+	if len(marks) == 0 {
+		return ""
+	}
+	return "  " + strings.Join(marks, " ")
+}
+
+// renderQueuedCountdowns marks the tracks with a start, stop or jump queued on a song row,
+// with the phrase rows left until it runs. Stops show on the row playing, starts and jumps on
+// the row they go to.
+func renderQueuedCountdowns(m *model.Model, styles *ViewStyles, row int) string {
+	var marks []string
+	for track := 0; track < types.NumTracks; track++ {
+		countdown, ok := m.QueuedCountdown(track)
+		if !ok {
+			continue
+		}
+		action, at := "starts", m.SongPlaybackQueuedRow[track]
+		if m.SongPlaybackQueued[track] == -1 {
+			if at < 0 || at == m.SongPlaybackRow[track] {
+				action, at = "stops", m.SongPlaybackRow[track]
+			} else {
+				action = "jumps"
+			}
+		}
+		if at == row {
+			marks = append(marks, styles.Playback.Render(fmt.Sprintf("T%d %s in %d", track+1, action, countdown.Rows)))
+		}
+	}
+	if len(marks) == 0 {
+		return ""
+	}
+	return "  " + strings.Join(marks, " ")
+}
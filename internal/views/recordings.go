@@ -0,0 +1,66 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/schollz/collidertracker/internal/i18n"
+	"github.com/schollz/collidertracker/internal/input"
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// RenderRecordingsView lists the project's recordings with duration and size
+func RenderRecordingsView(m *model.Model) string {
+	visibleRows := m.GetVisibleRows()
+	start := 0
+	if m.RecordingsRow >= visibleRows {
+		start = m.RecordingsRow - visibleRows + 1
+	}
+	end := start + visibleRows
+	if end > len(m.Recordings) {
+		end = len(m.Recordings)
+	}
+
+	rightHeader := fmt.Sprintf("%d files", len(m.Recordings))
+	return renderViewWithCommonPattern(m, i18n.T("Recordings"), rightHeader, func(styles *ViewStyles) string {
+		var content strings.Builder
+		content.WriteString("\n")
+
+		if len(m.Recordings) == 0 {
+			content.WriteString(styles.Label.Render("  No recordings in " + m.RecordingsFolder()))
+			content.WriteString("\n")
+			return content.String()
+		}
+
+		for i := start; i < end; i++ {
+			rec := m.Recordings[i]
+			name := rec.Name
+			if m.RenamingRecording && i == m.RecordingsRow {
+				name = m.RenameBuffer + "_"
+			}
+			line := fmt.Sprintf("%-40s %8s %10s", truncateRecordingName(name, 40), formatDuration(rec.Duration), formatBytes(rec.Size))
+
+			indicator := "  "
+			if m.CurrentlyPlayingFile == rec.Path {
+				indicator = styles.Playback.Render("▶ ")
+			}
+			if i == m.RecordingsRow {
+				content.WriteString(indicator + styles.Selected.Render(line))
+			} else {
+				content.WriteString(indicator + styles.Normal.Render(line))
+			}
+			content.WriteString("\n")
+		}
+		return content.String()
+	}, fmt.Sprintf(i18n.T("space: preview | r: rename | d: delete | i: use in phrase row | %s+E/esc: back"), input.GetModifierKey()),
+		"Recordings are saved in "+m.RecordingsFolder(), end-start+1)
+}
+
+// truncateRecordingName shortens a name to width, keeping the end (timestamp and extension)
+func truncateRecordingName(name string, width int) string {
+	runes := []rune(name)
+	if len(runes) <= width {
+		return name
+	}
+	return "…" + string(runes[len(runes)-width+1:])
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/input"
 	"github.com/schollz/collidertracker/internal/model"
 )
@@ -23,7 +24,7 @@ func RenderRetriggerView(m *model.Model) string {
 	var content strings.Builder
 
 	// Render header
-	header := "Retrigger Settings"
+	header := i18n.T("Retrigger Settings")
 	retriggerHeader := fmt.Sprintf("Retrigger %02X", m.RetriggerEditingIndex)
 	content.WriteString(RenderHeader(m, header, retriggerHeader))
 	content.WriteString("\n")
@@ -178,8 +179,8 @@ func RenderRetriggerView(m *model.Model) string {
 	content.WriteString("\n\n")
 
 	// Footer with status
-	helpText := fmt.Sprintf("arrows: navigate | %s+arrows: adjust", input.GetModifierKey())
-	statusMsg := fmt.Sprintf("Retrigger: %d times, %.2f/beat to %.2f/beat", settings.Times, settings.Start, settings.End)
+	helpText := fmt.Sprintf(i18n.T("arrows: navigate | %s+arrows: adjust"), input.GetModifierKey())
+	statusMsg := fmt.Sprintf(i18n.T("Retrigger: %d times, %.2f/beat to %.2f/beat"), settings.Times, settings.Start, settings.End)
 	content.WriteString(RenderFooter(m, 12, helpText, statusMsg))
 
 	// Apply container padding
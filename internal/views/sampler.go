@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/input"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/ticks"
@@ -21,8 +22,8 @@ func RenderSamplerPhraseView(m *model.Model) string {
 	normalNeverEditedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8")) // Dimmed text for never-edited when not selected
 	sliceStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
 	sliceDownbeatStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("7"))                          // Lighter gray for downbeats
-	playbackStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))                              // Green
-	copiedStyle := lipgloss.NewStyle().Background(lipgloss.Color("3")).Foreground(lipgloss.Color("0")) // Yellow background
+	playbackStyle := lipgloss.NewStyle().Foreground(paletteOf(m).Playback)                             // Green by default
+	copiedStyle := lipgloss.NewStyle().Background(paletteOf(m).Copied).Foreground(lipgloss.Color("0")) // Yellow background by default
 
 	// Main container style with padding
 	containerStyle := lipgloss.NewStyle().
@@ -35,12 +36,12 @@ func RenderSamplerPhraseView(m *model.Model) string {
 	columnHeader := "  SL  DT  NN  MO  VE  PI  GT  RT  TS  Я  PA  LP  HP  CO  RE  DU  FI"
 	phrasesData := m.GetCurrentPhrasesData()
 	totalTicks := ticks.CalculatePhraseTicks(phrasesData, m.CurrentPhrase)
-	phraseHeader := fmt.Sprintf("Phrase %02X (%d ticks)", m.CurrentPhrase, totalTicks)
+	phraseHeader := fmt.Sprintf("Phrase %02X%s (%d ticks)", m.CurrentPhrase, headerName(m.DisplayPhraseName(m.CurrentTrack, m.CurrentPhrase)), totalTicks)
 	content.WriteString(RenderHeader(m, columnHeader, phraseHeader))
 
 	// Data rows
 	visibleRows := m.GetVisibleRows()
-	for i := 0; i < visibleRows && i+m.ScrollOffset < 255; i++ {
+	for i := 0; i < visibleRows && i+m.ScrollOffset < types.PhraseRows; i++ {
 		dataIndex := i + m.ScrollOffset
 
 		// Arrow for current row or playback
@@ -60,8 +61,8 @@ func RenderSamplerPhraseView(m *model.Model) string {
 				}
 			}
 		} else if m.CurrentRow == dataIndex {
-			// Not playing - show cursor arrow
-			arrow = "▶"
+			// Not playing - show the hollow cursor arrow, unlike the playback arrow
+			arrow = "▷"
 		}
 
 		// Slice number (hex)
@@ -74,10 +75,9 @@ func RenderSamplerPhraseView(m *model.Model) string {
 		}
 
 		// Delta Time (DT) - now moved to position 1 (replacing P)
-		phrasesData := m.GetCurrentPhrasesData()
 		dtText := "--"
-		if (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColDeltaTime] != -1 {
-			dtText = fmt.Sprintf("%02X", (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColDeltaTime])
+		if m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColDeltaTime) != -1 {
+			dtText = fmt.Sprintf("%02X", m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColDeltaTime))
 		}
 		var dtCell string
 		if m.CurrentRow == dataIndex && m.CurrentCol == 1 {
@@ -94,8 +94,8 @@ func RenderSamplerPhraseView(m *model.Model) string {
 
 		// Note (NN) - now at position 2
 		noteText := "--"
-		if (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColNote] != -1 {
-			noteText = fmt.Sprintf("%02X", (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColNote])
+		if m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColNote) != -1 {
+			noteText = fmt.Sprintf("%02X", m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColNote))
 		}
 		var noteCell string
 		if m.CurrentRow == dataIndex && m.CurrentCol == 2 {
@@ -111,7 +111,7 @@ func RenderSamplerPhraseView(m *model.Model) string {
 		}
 
 		// Modulate (MO) - now at position 3
-		moValue := (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColModulate]
+		moValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColModulate)
 		moText := "--"
 		if moValue != -1 {
 			moText = fmt.Sprintf("%02X", moValue)
@@ -145,8 +145,8 @@ func RenderSamplerPhraseView(m *model.Model) string {
 
 		// Velocity (VE) - now at position 4
 		velocityText := "--"
-		if (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColVelocity] != -1 {
-			velocityText = fmt.Sprintf("%02X", (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColVelocity])
+		if m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColVelocity) != -1 {
+			velocityText = fmt.Sprintf("%02X", m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColVelocity))
 		}
 		var velocityCell string
 		if m.CurrentRow == dataIndex && m.CurrentCol == 4 {
@@ -163,8 +163,8 @@ func RenderSamplerPhraseView(m *model.Model) string {
 
 		// Pitch (PI) - now at position 5
 		pitchText := "--"
-		if (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColPitch] != -1 {
-			pitchText = fmt.Sprintf("%02X", (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColPitch])
+		if m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColPitch) != -1 {
+			pitchText = fmt.Sprintf("%02X", m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColPitch))
 		}
 		var pitchCell string
 		if m.CurrentRow == dataIndex && m.CurrentCol == 5 {
@@ -181,8 +181,8 @@ func RenderSamplerPhraseView(m *model.Model) string {
 
 		// Gate (GT) - now at position 6
 		gtText := "--"
-		if (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColGate] != -1 {
-			gtText = fmt.Sprintf("%02X", (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColGate])
+		if m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColGate) != -1 {
+			gtText = fmt.Sprintf("%02X", m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColGate))
 		}
 		var gtCell string
 		if m.CurrentRow == dataIndex && m.CurrentCol == 6 {
@@ -198,7 +198,7 @@ func RenderSamplerPhraseView(m *model.Model) string {
 		}
 
 		// Retrigger (RT) - now at position 7
-		rtValue := (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColRetrigger]
+		rtValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColRetrigger)
 		rtText := "--"
 		if rtValue != -1 {
 			rtText = fmt.Sprintf("%02X", rtValue)
@@ -231,7 +231,7 @@ func RenderSamplerPhraseView(m *model.Model) string {
 		}
 
 		// Timestretch (TS) - now at position 8
-		tsValue := (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColTimestretch]
+		tsValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColTimestretch)
 		tsText := "--"
 		if tsValue != -1 {
 			tsText = fmt.Sprintf("%02X", tsValue)
@@ -265,8 +265,8 @@ func RenderSamplerPhraseView(m *model.Model) string {
 
 		// Я (EffectReverse) — hex char: "-", "0" to "F" - now at position 9
 		revText := "-"
-		if (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColEffectReverse] != -1 {
-			revText = fmt.Sprintf("%X", (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColEffectReverse])
+		if m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColEffectReverse) != -1 {
+			revText = fmt.Sprintf("%X", m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColEffectReverse))
 		}
 		var revCell string
 		if m.CurrentRow == dataIndex && m.CurrentCol == 9 {
@@ -283,8 +283,8 @@ func RenderSamplerPhraseView(m *model.Model) string {
 
 		// PA (Pan) - now at position 10
 		paText := "--"
-		if (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColPan] != -1 {
-			paText = fmt.Sprintf("%02X", (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColPan])
+		if m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColPan) != -1 {
+			paText = fmt.Sprintf("%02X", m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColPan))
 		}
 		var paCell string
 		if m.CurrentRow == dataIndex && m.CurrentCol == 10 {
@@ -301,8 +301,8 @@ func RenderSamplerPhraseView(m *model.Model) string {
 
 		// LP (LowPassFilter) - now at position 11
 		lpText := "--"
-		if (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColLowPassFilter] != -1 {
-			lpText = fmt.Sprintf("%02X", (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColLowPassFilter])
+		if m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColLowPassFilter) != -1 {
+			lpText = fmt.Sprintf("%02X", m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColLowPassFilter))
 		}
 		var lpCell string
 		if m.CurrentRow == dataIndex && m.CurrentCol == 11 {
@@ -319,8 +319,8 @@ func RenderSamplerPhraseView(m *model.Model) string {
 
 		// HP (HighPassFilter) - now at position 12
 		hpText := "--"
-		if (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColHighPassFilter] != -1 {
-			hpText = fmt.Sprintf("%02X", (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColHighPassFilter])
+		if m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColHighPassFilter) != -1 {
+			hpText = fmt.Sprintf("%02X", m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColHighPassFilter))
 		}
 		var hpCell string
 		if m.CurrentRow == dataIndex && m.CurrentCol == 12 {
@@ -337,8 +337,8 @@ func RenderSamplerPhraseView(m *model.Model) string {
 
 		// CO (EffectComb) - now at position 13
 		combText := "--"
-		if (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColEffectComb] != -1 {
-			combText = fmt.Sprintf("%02X", (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColEffectComb])
+		if m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColEffectComb) != -1 {
+			combText = fmt.Sprintf("%02X", m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColEffectComb))
 		}
 		var combCell string
 		if m.CurrentRow == dataIndex && m.CurrentCol == 13 {
@@ -355,8 +355,8 @@ func RenderSamplerPhraseView(m *model.Model) string {
 
 		// RE (EffectReverb) - now at position 14
 		reverbText := "--"
-		if (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColEffectReverb] != -1 {
-			reverbText = fmt.Sprintf("%02X", (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColEffectReverb])
+		if m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColEffectReverb) != -1 {
+			reverbText = fmt.Sprintf("%02X", m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColEffectReverb))
 		}
 		var reverbCell string
 		if m.CurrentRow == dataIndex && m.CurrentCol == 14 {
@@ -372,7 +372,7 @@ func RenderSamplerPhraseView(m *model.Model) string {
 		}
 
 		// DU (EffectDucking) - now at position 15
-		duckingValue := (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColEffectDucking]
+		duckingValue := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColEffectDucking)
 		duckingText := "--"
 		if duckingValue != -1 {
 			duckingText = fmt.Sprintf("%02X", duckingValue)
@@ -406,7 +406,7 @@ func RenderSamplerPhraseView(m *model.Model) string {
 
 		// Filename (FI) - first 8 characters - now at position 16
 		fiText := "--------"
-		fileIndex := (*phrasesData)[m.CurrentPhrase][dataIndex][types.ColFilename]
+		fileIndex := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, dataIndex, types.ColFilename)
 		phrasesFiles := m.GetCurrentPhrasesFiles()
 		if fileIndex >= 0 && fileIndex < len(*phrasesFiles) && (*phrasesFiles)[fileIndex] != "" {
 			fullPath := (*phrasesFiles)[fileIndex]
@@ -457,27 +457,24 @@ func GetPhraseStatusMessage(m *model.Model) string {
 
 	if m.CurrentCol == rtUI {
 		// On retrigger column - show retrigger info
-		phrasesData := m.GetCurrentPhrasesData()
-		retriggerIndex := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColRetrigger]
-		if retriggerIndex >= 0 && retriggerIndex < 255 {
+		retriggerIndex := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColRetrigger)
+		if retriggerIndex >= 0 && retriggerIndex < types.NumSettings {
 			statusMsg = fmt.Sprintf("Retrigger: %02X", retriggerIndex)
 		} else {
 			statusMsg = "No retrigger selected"
 		}
 	} else if m.CurrentCol == moUI {
 		// On modulate column - show modulate info
-		phrasesData := m.GetCurrentPhrasesData()
-		modulateIndex := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColModulate]
-		if modulateIndex >= 0 && modulateIndex < 255 {
+		modulateIndex := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColModulate)
+		if modulateIndex >= 0 && modulateIndex < types.NumSettings {
 			statusMsg = fmt.Sprintf("Modulate: %02X", modulateIndex)
 		} else {
 			statusMsg = "No modulate selected"
 		}
 	} else if m.CurrentCol == duUI {
 		// On ducking column - show ducking info with sticky behavior
-		phrasesData := m.GetCurrentPhrasesData()
-		duckingIndex := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColEffectDucking]
-		if duckingIndex >= 0 && duckingIndex < 255 {
+		duckingIndex := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColEffectDucking)
+		if duckingIndex >= 0 && duckingIndex < types.NumSettings {
 			statusMsg = fmt.Sprintf("Ducking: %02X (sticky)", duckingIndex)
 		} else {
 			// Check for effective (sticky) Ducking value
@@ -490,8 +487,7 @@ func GetPhraseStatusMessage(m *model.Model) string {
 		}
 	} else if m.CurrentCol == fiUI {
 		// On filename column - show file info
-		phrasesData := m.GetCurrentPhrasesData()
-		fileIndex := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][types.ColFilename]
+		fileIndex := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.ColFilename)
 		phrasesFiles := m.GetCurrentPhrasesFiles()
 		if fileIndex >= 0 && fileIndex < len(*phrasesFiles) && (*phrasesFiles)[fileIndex] != "" {
 			statusMsg = fmt.Sprintf("File: %s", (*phrasesFiles)[fileIndex])
@@ -504,9 +500,8 @@ func GetPhraseStatusMessage(m *model.Model) string {
 		if columnMapping == nil || columnMapping.DataColumnIndex == -1 {
 			statusMsg = "Column info not available"
 		} else {
-			phrasesData := m.GetCurrentPhrasesData()
 			colIndex := columnMapping.DataColumnIndex
-			value := (*phrasesData)[m.CurrentPhrase][m.CurrentRow][colIndex]
+			value := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.CurrentRow, types.PhraseColumn(colIndex))
 			if colIndex == int(types.ColDeltaTime) {
 				// DT (Delta Time) column - show ticks and playback status
 				if value == -1 {
@@ -516,6 +511,10 @@ func GetPhraseStatusMessage(m *model.Model) string {
 				} else {
 					statusMsg = fmt.Sprintf("Delta Time: %02X (%d ticks, row played)", value, value)
 				}
+			} else if colIndex == int(types.ColNote) && value != -1 && m.KitFile(input.GetEffectiveFilenameForTrack(m, m.CurrentPhrase, m.CurrentRow, m.CurrentTrack), value) != "" {
+				// NN on a kit picks a file rather than a slice
+				kitFile := m.KitFile(input.GetEffectiveFilenameForTrack(m, m.CurrentPhrase, m.CurrentRow, m.CurrentTrack), value)
+				statusMsg = fmt.Sprintf("Note: %02X (kit: %s)", value, filepath.Base(kitFile))
 			} else if colIndex == int(types.ColGate) {
 				if value == -1 {
 					// Check for effective (virtual default) Gate value
@@ -660,5 +659,5 @@ func GetPhraseStatusMessage(m *model.Model) string {
 
 // GetPhraseHelpText returns the help text for phrase view based on current column
 func GetPhraseHelpText(m *model.Model) string {
-	return fmt.Sprintf("arrows: navigate | %s+arrows: edit", input.GetModifierKey())
+	return fmt.Sprintf(i18n.T("arrows: navigate | %s+arrows: edit | %s+n: name | /: find"), input.GetModifierKey(), input.GetModifierKey())
 }
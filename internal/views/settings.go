@@ -5,15 +5,20 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/input"
+	"github.com/schollz/collidertracker/internal/logging"
 	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
 )
 
 func RenderSettingsView(m *model.Model) string {
-	return renderViewWithCommonPattern(m, "Options ", "", func(styles *ViewStyles) string {
+	return renderViewWithCommonPattern(m, i18n.T("Options")+" ", "", func(styles *ViewStyles) string {
 		// Column widths
 		const globalColWidth = 18
 		const inputColWidth = 16
+		const reverbColWidth = 16
+		const appColWidth = 18
 
 		// Column styles
 		columnStyle := lipgloss.NewStyle().
@@ -24,23 +29,43 @@ func RenderSettingsView(m *model.Model) string {
 			Width(inputColWidth).
 			Align(lipgloss.Left)
 
+		reverbColumnStyle := lipgloss.NewStyle().
+			Width(reverbColWidth).
+			Align(lipgloss.Left)
+
+		appColumnStyle := lipgloss.NewStyle().
+			Width(appColWidth).
+			Align(lipgloss.Left)
+
 		// Column headers
-		var globalHeader, inputHeader string
+		var globalHeader, inputHeader, reverbHeader, appHeader string
 		if m.CurrentCol == 0 {
-			globalHeader = styles.Selected.Render("Global")
+			globalHeader = styles.Selected.Render(i18n.T("Global"))
 		} else {
-			globalHeader = styles.Label.Render("Global")
+			globalHeader = styles.Label.Render(i18n.T("Global"))
 		}
 		if m.CurrentCol == 1 {
-			inputHeader = styles.Selected.Render("Input")
+			inputHeader = styles.Selected.Render(i18n.T("I/O"))
+		} else {
+			inputHeader = styles.Label.Render(i18n.T("I/O"))
+		}
+		if m.CurrentCol == 2 {
+			reverbHeader = styles.Selected.Render(i18n.T("Reverb"))
+		} else {
+			reverbHeader = styles.Label.Render(i18n.T("Reverb"))
+		}
+		if m.CurrentCol == 3 {
+			appHeader = styles.Selected.Render(i18n.T("App"))
 		} else {
-			inputHeader = styles.Label.Render("Input")
+			appHeader = styles.Label.Render(i18n.T("App"))
 		}
 
 		// Create header row
 		globalHeaderCell := columnStyle.Render(globalHeader)
 		inputHeaderCell := inputColumnStyle.Render(inputHeader)
-		headerRow := lipgloss.JoinHorizontal(lipgloss.Top, globalHeaderCell, inputHeaderCell)
+		reverbHeaderCell := reverbColumnStyle.Render(reverbHeader)
+		appHeaderCell := appColumnStyle.Render(appHeader)
+		headerRow := lipgloss.JoinHorizontal(lipgloss.Top, globalHeaderCell, inputHeaderCell, reverbHeaderCell, appHeaderCell)
 
 		// Global settings (column 0)
 		globalSettings := []struct {
@@ -57,9 +82,27 @@ func RenderSettingsView(m *model.Model) string {
 			{"Drive:", fmt.Sprintf("%.1f dB", m.DriveDB), 6},
 			{"Tape:", fmt.Sprintf("%.1f%%", m.TapePercent), 7},
 			{"Shimmer:", fmt.Sprintf("%.1f%%", m.ShimmerPercent), 8},
+			{"Fade:", fmt.Sprintf("%d ms", m.FadeMS), 9},
+			{"Seed:", fmt.Sprintf("%04X", m.RandomSeed), 10},
+			{"XFade:", jumpCrossfadeValue(m.JumpCrossfade), 11},
+			{"Banks:", model.BankModeName(m.PerTrackBanks), 12},
+			{"Count:", fmt.Sprintf("%d s", m.StartCountdown), 13},
+			{"Roll:", preRollValue(m.PreRoll), 14},
+			{"Song:", songModeValue(m.GenerativeSong), 15},
+			{"Groove:", model.GrooveName(m.Groove), 16},
+			{"Lock:", lockValue(m.IsLocked()), 17},
+		}
+
+		// Input, MIDI sync and beat flash settings (column 1)
+		monitorValue := "off"
+		if m.InputMonitor {
+			monitorValue = "on"
+		}
+		beatPulseValue := "off"
+		if m.BeatPulse != "" {
+			beatPulseValue = truncateRecordingName(m.BeatPulse, 8)
 		}
 
-		// Input settings (column 1)
 		inputSettings := []struct {
 			label string
 			value string
@@ -67,16 +110,102 @@ func RenderSettingsView(m *model.Model) string {
 		}{
 			{"Input:", fmt.Sprintf("%.1f dB", m.InputLevelDB), 0},
 			{"Reverb:", fmt.Sprintf("%.1f%%", m.ReverbSendPercent), 1},
+			{"Monitor:", monitorValue, 2},
+			{"Insert:", types.GetInputInsertName(m.InputInsert), 3},
+			{"Sync:", types.GetMidiSyncModeName(m.MidiSync.Mode), 4},
+			{"To:", truncateRecordingName(m.MidiSync.Device, 8), 5},
+			{"Ch:", fmt.Sprintf("%d", m.MidiSync.Channel), 6},
+			{"Flash:", types.GetBeatFlashName(m.BeatFlash), 7},
+			{"Pulse:", beatPulseValue, 8},
+		}
+
+		// Reverb settings (column 2)
+		reverbSettings := []struct {
+			label string
+			value string
+			row   int
+		}{
+			{"Algo:", types.GetReverbAlgorithmName(m.Reverb.Algorithm), 0},
+			{"Size:", fmt.Sprintf("%d%%", m.Reverb.Size), 1},
+			{"Damp:", fmt.Sprintf("%d%%", m.Reverb.Damp), 2},
+			{"Pre:", fmt.Sprintf("%d ms", m.Reverb.PreDelayMS), 3},
+			{"Shim:", types.GetShimmerVoicingName(m.Reverb.ShimmerVoicing), 4},
+			{"IR:", truncateRecordingName(model.ReverbImpulseName(m.ReverbImpulse), 10), 5},
+		}
+
+		// App settings (column 3)
+		confirmValue := "off"
+		if m.ConfirmDeletes {
+			confirmValue = "on"
+		}
+		recordValue := "off"
+		if m.Config.Record {
+			recordValue = "on"
+		}
+		vimValue := "off"
+		if m.VimMode {
+			vimValue = "on"
+		}
+		resumeValue := "off"
+		if m.ResumePlayback {
+			resumeValue = "on"
+		}
+		auditionValue := "off"
+		if m.AuditionEdits {
+			auditionValue = "on"
+		}
+		updatesValue := "off"
+		if m.Config.CheckUpdates {
+			updatesValue = "on"
+		}
+		animationsValue := "off"
+		if m.Animations {
+			animationsValue = "on"
+		}
+		appSettings := []struct {
+			label string
+			value string
+			row   int
+		}{
+			{"Confirm:", confirmValue, 0},
+			{"Bounce:", fmt.Sprintf("%dx", m.BounceRepeats), 1},
+			{"Splash:", types.GetSplashModeName(m.SplashMode), 2},
+			{"Save:", m.AutosaveModeName(), 3},
+			{"Delay:", fmt.Sprintf("%.2fs", float64(m.AutosaveDelayMS)/1000), 4},
+			{"Every:", m.AutosaveIntervalName(), 5},
+			{"Port:", fmt.Sprintf("%d", m.Config.Port), 6},
+			{"Record:", recordValue, 7},
+			{"Vim:", vimValue, 8},
+			{"Dump:", truncateRecordingName(m.DumpName(), 9), 9},
+			{"SC:", m.SCModeName(), 10},
+			{"Nudge:", fmt.Sprintf("%d/%d", m.NudgeFine, m.NudgeCoarse), 11},
+			{"Import:", types.GetImportModeName(m.ImportMode), 12},
+			{"FPS:", fmt.Sprintf("%d", m.UIFrameRate), 13},
+			{"Wave:", types.GetWaveformDetailName(m.WaveformDetail), 14},
+			{"Anim:", animationsValue, 15},
+			{"Colors:", types.GetPaletteName(m.Palette), 16},
+			{"Lang:", localeValue(m.Config.Locale), 17},
+			{"Update:", updatesValue, 18},
+			{"Resume:", resumeValue, 19},
+			{"Audition:", auditionValue, 20},
+			{"Norm:", m.BounceTargetName(), 21},
+			{"Pre-roll:", m.PreRollName(), 22},
+			{"Log:", logging.BaseLevel(m.Config.LogLevel), 23},
 		}
 
 		// Build column content
 		var globalRows []string
 		var inputRows []string
+		var reverbRows []string
+		var appRows []string
 
 		maxRows := len(globalSettings)
 		if len(inputSettings) > maxRows {
 			maxRows = len(inputSettings)
 		}
+		if len(appSettings) > maxRows {
+			maxRows = len(appSettings)
+		}
 
 		for i := 0; i < maxRows; i++ {
 			// Global column row
@@ -88,7 +217,7 @@ func RenderSettingsView(m *model.Model) string {
 				} else {
 					valueStyle = styles.Normal
 				}
-				row := fmt.Sprintf("%-6s %s", styles.Label.Render(setting.label), valueStyle.Render(setting.value))
+				row := fmt.Sprintf("%-6s %s", styles.Label.Render(i18n.T(setting.label)), valueStyle.Render(setting.value))
 				globalRows = append(globalRows, row)
 			} else {
 				globalRows = append(globalRows, "") // Empty row
@@ -103,25 +232,61 @@ func RenderSettingsView(m *model.Model) string {
 				} else {
 					valueStyle = styles.Normal
 				}
-				row := fmt.Sprintf("%-7s %s", styles.Label.Render(setting.label), valueStyle.Render(setting.value))
+				row := fmt.Sprintf("%-7s %s", styles.Label.Render(i18n.T(setting.label)), valueStyle.Render(setting.value))
 				inputRows = append(inputRows, row)
 			} else {
 				inputRows = append(inputRows, "") // Empty row
 			}
+
+			// Reverb column row
+			if i < len(reverbSettings) {
+				setting := reverbSettings[i]
+				var valueStyle lipgloss.Style
+				if m.CurrentCol == 2 && m.CurrentRow == setting.row {
+					valueStyle = styles.Selected
+				} else {
+					valueStyle = styles.Normal
+				}
+				row := fmt.Sprintf("%-5s %s", styles.Label.Render(i18n.T(setting.label)), valueStyle.Render(setting.value))
+				reverbRows = append(reverbRows, row)
+			} else {
+				reverbRows = append(reverbRows, "") // Empty row
+			}
+
+			// App column row
+			if i < len(appSettings) {
+				setting := appSettings[i]
+				var valueStyle lipgloss.Style
+				if m.CurrentCol == 3 && m.CurrentRow == setting.row {
+					valueStyle = styles.Selected
+				} else {
+					valueStyle = styles.Normal
+				}
+				row := fmt.Sprintf("%-8s %s", styles.Label.Render(i18n.T(setting.label)), valueStyle.Render(setting.value))
+				appRows = append(appRows, row)
+			} else {
+				appRows = append(appRows, "") // Empty row
+			}
 		}
 
 		// Join rows in each column
 		globalColumn := columnStyle.Render(strings.Join(globalRows, "\n"))
 		inputColumn := inputColumnStyle.Render(strings.Join(inputRows, "\n"))
+		reverbColumn := reverbColumnStyle.Render(strings.Join(reverbRows, "\n"))
+		appColumn := appColumnStyle.Render(strings.Join(appRows, "\n"))
 
 		// Join columns horizontally
-		columnsRow := lipgloss.JoinHorizontal(lipgloss.Top, globalColumn, inputColumn)
+		columnsRow := lipgloss.JoinHorizontal(lipgloss.Top, globalColumn, inputColumn, reverbColumn, appColumn)
 
 		// Timing info
 		beatsPerSecond := float64(m.BPM) / 60.0
 		ticksPerSecond := beatsPerSecond * float64(m.PPQ)
 		secondsPerTick := 1.0 / ticksPerSecond
-		timingInfo := styles.Normal.Render(fmt.Sprintf("Timing: %.3f seconds per row", secondsPerTick))
+		timing := fmt.Sprintf(i18n.T("Timing: %.3f seconds per row"), secondsPerTick)
+		if sampleRate, blockSize := m.ServerAudioInfo(); sampleRate > 0 {
+			timing += fmt.Sprintf(i18n.T(" | Server: %s %d Hz, block %d (%.1f ms)"), m.ServerProgramName(), sampleRate, blockSize, float64(blockSize)*1000/float64(sampleRate))
+		}
+		timingInfo := styles.Normal.Render(timing)
 
 		// Join everything vertically
 		content := lipgloss.JoinVertical(
@@ -135,5 +300,45 @@ func RenderSettingsView(m *model.Model) string {
 		)
 
 		return content
-	}, fmt.Sprintf("arrows: navigate | %s+arrows: adjust", input.GetModifierKey()), " ", 13)
+	}, fmt.Sprintf(i18n.T("arrows: navigate | %s+arrows: adjust | shift+right: master chain"), input.GetModifierKey()), " ", 22)
+}
+
+// localeValue names the interface language setting
+func localeValue(locale string) string {
+	if locale == "" {
+		return i18n.T("auto")
+	}
+	return locale
+}
+
+// jumpCrossfadeValue formats the song jump crossfade length
+func jumpCrossfadeValue(ticks int) string {
+	if ticks == 0 {
+		return "off"
+	}
+	return fmt.Sprintf("%d tk", ticks)
+}
+
+// preRollValue shows the count-in before a timed start
+func preRollValue(beats int) string {
+	if beats == 0 {
+		return "off"
+	}
+	return fmt.Sprintf("%d beats", beats)
+}
+
+// songModeValue shows how song playback chooses the next song row
+func songModeValue(generative bool) string {
+	if generative {
+		return "generative"
+	}
+	return "linear"
+}
+
+// lockValue shows whether the project is password protected
+func lockValue(locked bool) string {
+	if locked {
+		return "password"
+	}
+	return "off"
 }
@@ -2,8 +2,11 @@ package views
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/input"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/ticks"
@@ -12,27 +15,26 @@ import (
 
 // RenderSongView renders the new song view with 8 tracks × 16 rows
 func RenderSongView(m *model.Model) string {
+	now := time.Now()
 	return renderViewWithCommonPattern(m, "", "", func(styles *ViewStyles) string {
 		var content strings.Builder
 
 		// Render header with song name on the right (like Phrase View)
 		columnHeader := "    "
-		for track := 0; track < 8; track++ {
+		for track := 0; track < types.NumTracks; track++ {
 			columnHeader += fmt.Sprintf("  T%d", track+1)
 		}
 		songHeader := "Song"
+		if m.GenerativeSong {
+			songHeader = "Song (generative)"
+		}
 		content.WriteString(RenderHeader(m, columnHeader, songHeader))
 
 		// Render track type toggle row (IN/SA)
 		typeRowIndicator := "    "
 		content.WriteString(typeRowIndicator)
-		for track := 0; track < 8; track++ {
-			var trackTypeText string
-			if m.TrackTypes[track] {
-				trackTypeText = " SA" // Sampler
-			} else {
-				trackTypeText = " IN" // Instrument
-			}
+		for track := 0; track < types.NumTracks; track++ {
+			trackTypeText := " " + m.TrackTypeCode(track) // SA, IN or an OSC engine
 
 			// Check if this track type cell is selected
 			// We'll use row -1 to represent the type row
@@ -54,7 +56,7 @@ func RenderSongView(m *model.Model) string {
 			content.WriteString(rowIndicator)
 
 			// Render each track column
-			for track := 0; track < 8; track++ {
+			for track := 0; track < types.NumTracks; track++ {
 				// Check if this specific track is playing and on current song row
 				trackPlaying := false
 				trackQueued := false
@@ -79,7 +81,7 @@ func RenderSongView(m *model.Model) string {
 						}
 					}
 				}
-				chainID := m.SongData[track][row]
+				chainID := m.GetSongCell(track, row)
 				var chainCell string
 
 				// Format the chain ID with playback marker
@@ -92,8 +94,8 @@ func RenderSongView(m *model.Model) string {
 
 				// Determine arrow display based on state
 				if trackQueued {
-					// Track is queued - blink arrow (show every other tick)
-					if m.TickCount%2 == 0 {
+					// Track is queued - blink arrow
+					if playheadBlinkOn(now) {
 						chainCell = styles.Playback.Render("▶") + baseCell
 					} else {
 						chainCell = " " + baseCell
@@ -116,19 +118,19 @@ func RenderSongView(m *model.Model) string {
 					m.Clipboard.HighlightRow == row && m.Clipboard.HighlightCol == track {
 					// Copied cell
 					content.WriteString(" " + styles.Copied.Render(chainCell))
-				} else if chainID == -1 {
-					// Empty chain - dimmed
+				} else if trackPlaying {
+					// Chain the track is playing - playhead style
+					content.WriteString(" " + styles.Playback.Render(chainCell))
+				} else if chainID == -1 || (m.GenerativeSong && m.SongWeights[track][row] == 0) {
+					// Empty chain, or never chosen in generative mode - dimmed
 					content.WriteString(" " + styles.Label.Render(chainCell))
 				} else {
 					// Check if this chain has actual data (any phrase assigned)
 					hasChainData := false
-					chainsData := m.GetChainsDataForTrack(track)
-					if chainID >= 0 && chainID < len(*chainsData) {
-						for row := 0; row < 16; row++ {
-							if (*chainsData)[chainID][row] != -1 {
-								hasChainData = true
-								break
-							}
+					for row := 0; row < types.ChainRows; row++ {
+						if m.GetChainCell(track, chainID, row) != -1 {
+							hasChainData = true
+							break
 						}
 					}
 
@@ -142,11 +144,28 @@ func RenderSongView(m *model.Model) string {
 				}
 			}
 
+			// Cue marker after the row's cells, or the name being typed on the cursor row
+			if m.EditingCue && m.CurrentRow == row {
+				content.WriteString("  " + styles.Selected.Render("◆"+m.CueBuffer+"_"))
+			} else if cue := m.SongCues[row]; cue != "" {
+				content.WriteString("  " + styles.Chain.Render("◆"+cue))
+			}
+			// Tempo and groove overrides, or the tempo being typed on the cursor row
+			if m.EditingRowTempo && m.CurrentRow == row {
+				content.WriteString("  " + styles.Selected.Render("♩"+m.RowTempoBuffer+"_"))
+			} else if text := rowTempoText(m.SongRowTempos[row]); text != "" {
+				content.WriteString("  " + styles.Chain.Render(text))
+			}
+			content.WriteString(renderQueuedCountdowns(m, styles, row))
+
 			content.WriteString("\n")
 		}
 
+		// Where each track is in its chain and phrase
+		content.WriteString(renderSongPlayheadRows(m, styles))
+
 		return content.String()
-	}, fmt.Sprintf("arrows: move | %s+arrows: edit", input.GetModifierKey()), GetSongStatusMessage(m), 17) // 16 rows + 1 type row (undercount waveform like Phrase view)
+	}, fmt.Sprintf(i18n.T("arrows: move | %s+arrows: edit | %s+n: cue | =: row tempo | </>: row groove | /: find"), input.GetModifierKey(), input.GetModifierKey()), GetSongStatusMessage(m), 19) // 16 rows + 1 type row + 2 playhead rows (undercount waveform like Phrase view)
 }
 
 // GetSongStatusMessage returns the status message for song view
@@ -158,24 +177,13 @@ func GetSongStatusMessage(m *model.Model) string {
 
 	// Handle TYPE row (row -1)
 	if songRow == -1 {
-		var trackTypeText string
-		if m.TrackTypes[trackCol] {
-			trackTypeText = "Sampler"
-		} else {
-			trackTypeText = "Instrument"
-		}
-		statusMsg = fmt.Sprintf("Track %d Type: %s", trackCol, trackTypeText)
+		statusMsg = fmt.Sprintf("Track %d Type: %s", trackCol, m.TrackTypeName(trackCol))
 	} else {
 		// Handle normal data rows
-		chainID := m.SongData[trackCol][songRow]
+		chainID := m.GetSongCell(trackCol, songRow)
 
 		// Determine track type
-		var trackType string
-		if m.TrackTypes[trackCol] {
-			trackType = "Sampler"
-		} else {
-			trackType = "Instrument"
-		}
+		trackType := m.TrackTypeName(trackCol)
 
 		// Calculate total ticks for the entire track
 		chainsData := m.GetChainsDataForTrack(trackCol)
@@ -188,13 +196,11 @@ func GetSongStatusMessage(m *model.Model) string {
 			// Check if chain has data and get first phrase for display
 			hasChainData := false
 			firstPhraseID := -1
-			if chainID >= 0 && chainID < len(*chainsData) {
-				for row := 0; row < 16; row++ {
-					if (*chainsData)[chainID][row] != -1 {
-						hasChainData = true
-						if firstPhraseID == -1 {
-							firstPhraseID = (*chainsData)[chainID][row]
-						}
+			for row := 0; row < types.ChainRows; row++ {
+				if m.GetChainCell(trackCol, chainID, row) != -1 {
+					hasChainData = true
+					if firstPhraseID == -1 {
+						firstPhraseID = m.GetChainCell(trackCol, chainID, row)
 					}
 				}
 			}
@@ -204,9 +210,36 @@ func GetSongStatusMessage(m *model.Model) string {
 			} else {
 				statusMsg = fmt.Sprintf("Track %d: %s (%d ticks) (Empty)", trackCol, trackType, totalTicks)
 			}
+			if name, _ := m.DisplayChainName(trackCol, chainID); name != "" {
+				statusMsg += fmt.Sprintf(" | Chain %02X: %s", chainID, name)
+			}
 		}
 	}
 
+	if m.EditingCue {
+		return fmt.Sprintf("Cue on row %02X: type a name, enter to set (empty removes), esc to cancel", songRow)
+	}
+	if m.EditingRowTempo {
+		return fmt.Sprintf("Tempo of row %02X: type a BPM, enter to set (empty returns to the song tempo), esc to cancel", songRow)
+	}
+	if songRow >= 0 && m.SongCues[songRow] != "" {
+		statusMsg += fmt.Sprintf(" | Cue: %s", m.SongCues[songRow])
+	}
+	if songRow >= 0 && m.SongRowTempos[songRow] != (types.SongRowTempo{}) {
+		tempo := m.SongRowTempos[songRow]
+		bpm, groove := m.BPM, m.Groove
+		if tempo.BPM > 0 {
+			bpm = tempo.BPM
+		}
+		if tempo.Groove > 0 {
+			groove = tempo.Groove - 1
+		}
+		statusMsg += fmt.Sprintf(" | Row plays at %.2f BPM, %s", bpm, model.GrooveName(groove))
+	}
+	if songRow >= 0 && m.GenerativeSong {
+		statusMsg += fmt.Sprintf(" | Weight: %X ([/])", m.SongWeights[trackCol][songRow])
+	}
+
 	// Add playback info
 	if m.IsPlaying {
 		if m.PlaybackMode == types.SongView {
@@ -226,3 +259,15 @@ func GetSongStatusMessage(m *model.Model) string {
 
 	return statusMsg
 }
+
+// rowTempoText shows a song row's tempo and groove overrides beside it ("" without any)
+func rowTempoText(tempo types.SongRowTempo) string {
+	var parts []string
+	if tempo.BPM > 0 {
+		parts = append(parts, "♩"+strconv.FormatFloat(float64(tempo.BPM), 'f', -1, 32))
+	}
+	if tempo.Groove > 0 {
+		parts = append(parts, "~"+model.GrooveName(tempo.Groove-1))
+	}
+	return strings.Join(parts, " ")
+}
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/input"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/supercollider"
@@ -17,6 +18,17 @@ func stripAnsiCodes(s string) string {
 	return ansiRegex.ReplaceAllString(s, "")
 }
 
+// soundMakerParamValue returns a parameter's value as the phrase row the view was opened
+// from plays it, and whether the row locks it
+func soundMakerParamValue(m *model.Model, settings *types.SoundMakerSettings, key string) (float32, bool) {
+	if phrase, row, ok := m.PLockTarget(); ok {
+		if value, locked := m.PLock(phrase, row, key); locked {
+			return value, true
+		}
+	}
+	return settings.GetParameterValue(key), false
+}
+
 func GetSoundMakerStatusMessage(m *model.Model) string {
 	settings := m.SoundMakerSettings[m.SoundMakerEditingIndex]
 
@@ -41,7 +53,7 @@ func GetSoundMakerStatusMessage(m *model.Model) string {
 			paramIndex := m.CurrentRow - 1
 			if paramIndex >= 0 && paramIndex < len(params) {
 				param := params[paramIndex]
-				value := settings.GetParameterValue(param.Key)
+				value, locked := soundMakerParamValue(m, &settings, param.Key)
 
 				// Special handling for DX7 preset display
 				if param.Key == "preset" && settings.Name == "DX7" {
@@ -76,6 +88,10 @@ func GetSoundMakerStatusMessage(m *model.Model) string {
 						columnStatus = fmt.Sprintf("%s: %.0f", param.DisplayName, value)
 					}
 				}
+				if locked {
+					_, row, _ := m.PLockTarget()
+					columnStatus += fmt.Sprintf(" (locked on row %02X)", row)
+				}
 			} else {
 				columnStatus = "Use Up/Down to navigate parameters"
 			}
@@ -89,7 +105,7 @@ func GetSoundMakerStatusMessage(m *model.Model) string {
 
 func RenderSoundMakerView(m *model.Model) string {
 	statusMsg := GetSoundMakerStatusMessage(m)
-	return renderViewWithCommonPattern(m, "SoundMaker Settings", fmt.Sprintf("SoundMaker %02X", m.SoundMakerEditingIndex), func(styles *ViewStyles) string {
+	return renderViewWithCommonPattern(m, i18n.T("SoundMaker Settings"), fmt.Sprintf("SoundMaker %02X", m.SoundMakerEditingIndex), func(styles *ViewStyles) string {
 		var content strings.Builder
 		content.WriteString("\n")
 
@@ -124,7 +140,7 @@ func RenderSoundMakerView(m *model.Model) string {
 
 			// Helper function to render a parameter
 			renderParam := func(param types.InstrumentParameterDef, paramIndex int, currentCol int) string {
-				value := settings.GetParameterValue(param.Key)
+				value, locked := soundMakerParamValue(m, &settings, param.Key)
 				var valueStr string
 
 				// Special formatting for DX7 preset
@@ -158,6 +174,9 @@ func RenderSoundMakerView(m *model.Model) string {
 						valueStr = fmt.Sprintf("%.0f", value)
 					}
 				}
+				if locked {
+					valueStr += "*" // Locked on the phrase row
+				}
 
 				var valueCell string
 				// paramIndex is 1-based (row 1, 2, 3...), row 0 is the name
@@ -224,5 +243,5 @@ func RenderSoundMakerView(m *model.Model) string {
 		}
 
 		return content.String()
-	}, fmt.Sprintf("arrows: navigate | space: select | %s+arrows: adjust", input.GetModifierKey()), statusMsg, 15) // Fixed height for stable view
+	}, fmt.Sprintf(i18n.T("arrows: navigate | space: select | %s+arrows: adjust | t: lock to row"), input.GetModifierKey()), statusMsg, 15) // Fixed height for stable view
 }
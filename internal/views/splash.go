@@ -1,9 +1,13 @@
 package views
 
 import (
+	"fmt"
 	"math"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
@@ -13,16 +17,133 @@ import (
 type SplashState struct {
 	StartTime time.Time
 	Duration  time.Duration
+	Art       []string // Custom ASCII art shown instead of the title (nil for the title)
+
+	mu           sync.Mutex   // Guards the startup fields, set from the OSC and startup goroutines
+	stage        StartupStage // Last SuperCollider startup stage reached
+	stageChanged time.Time    // When the stage last advanced
+	failure      string       // Why startup failed ("" while it is progressing)
+}
+
+// maxSplashArtLines limits how much custom ASCII art the splash screen shows
+const maxSplashArtLines = 12
+
+// SplashArtPath returns where custom splash screen ASCII art is read from
+func SplashArtPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "collidertracker", "splash.txt")
+}
+
+// LoadSplashArt reads custom ASCII art for the splash screen, or returns nil when there is none
+func LoadSplashArt(path string) []string {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	text := strings.ReplaceAll(strings.ReplaceAll(string(data), "\r\n", "\n"), "\t", "    ")
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		lines = append(lines, strings.TrimRight(line, " "))
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > maxSplashArtLines {
+		lines = lines[:maxSplashArtLines]
+	}
+	return lines
+}
+
+// StartupStage is how far SuperCollider got while the splash screen is shown
+type StartupStage int
+
+const (
+	StageFindingSclang StartupStage = iota
+	StageSclangFound
+	StageServerBooted
+	StageSynthDefsLoaded
+	StageBuffersReady
+)
+
+// startupStageNames label each stage in the splash screen checklist
+var startupStageNames = []string{"finding sclang", "sclang found", "server booted", "synthdefs loaded", "buffers ready"}
+
+// String returns the stage as shown in the splash screen
+func (s StartupStage) String() string {
+	if s < 0 || int(s) >= len(startupStageNames) {
+		return "unknown"
+	}
+	return startupStageNames[s]
 }
 
 // NewSplashState creates a new splash state
 func NewSplashState(duration time.Duration) *SplashState {
+	now := time.Now()
 	return &SplashState{
-		StartTime: time.Now(),
-		Duration:  duration,
+		StartTime:    now,
+		Duration:     duration,
+		stageChanged: now,
+	}
+}
+
+// SetStage records that SuperCollider reached a startup stage; stages never go backwards
+func (s *SplashState) SetStage(stage StartupStage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stage > s.stage {
+		s.stage = stage
+		s.stageChanged = time.Now()
 	}
 }
 
+// Stage returns the last startup stage reached
+func (s *SplashState) Stage() StartupStage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stage
+}
+
+// Fail shows a startup error on the splash screen
+func (s *SplashState) Fail(reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failure = reason
+}
+
+// Failure returns why startup failed, or "" while it is progressing
+func (s *SplashState) Failure() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failure
+}
+
+// Retry clears a failure and starts the stages again
+func (s *SplashState) Retry() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failure = ""
+	s.stage = StageFindingSclang
+	s.stageChanged = time.Now()
+}
+
+// CheckStalled fails startup when no stage was reached within timeout and reports whether it did
+func (s *SplashState) CheckStalled(now time.Time, timeout time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failure != "" || now.Sub(s.stageChanged) < timeout {
+		return false
+	}
+	next := s.stage + 1
+	s.failure = fmt.Sprintf("SuperCollider stalled after %s: no %s within %d seconds", s.stage, next, int(timeout.Seconds()))
+	return true
+}
+
 // IsComplete returns true if the splash animation is done
 func (s *SplashState) IsComplete() bool {
 	return time.Since(s.StartTime) >= s.Duration
@@ -61,8 +182,8 @@ func RenderSplashScreen(termWidth, termHeight int, state *SplashState, version s
 	centerY := termHeight / 2
 	centerX := termWidth / 2
 
-	// Fill screen with empty lines up to center
-	for i := 0; i < centerY-6; i++ {
+	// Fill screen with empty lines up to center (custom art takes the title's place)
+	for i := 0; i < centerY-6-len(state.Art)/2; i++ {
 		content.WriteString("\n")
 	}
 
@@ -110,18 +231,30 @@ func RenderSplashScreen(termWidth, termHeight int, state *SplashState, version s
 			}
 		}
 
-		titleText := "collidertracker"
-		if titleScale > 0 {
-			titleText = strings.Repeat(" ", titleScale) + titleText + strings.Repeat(" ", titleScale)
-		}
+		if len(state.Art) > 0 {
+			// Custom art keeps its shape: no scaling or side decorations
+			artStyle := titleStyleEnhanced.Align(lipgloss.Left)
+			width := 0
+			for _, line := range state.Art {
+				width = max(width, lipgloss.Width(line))
+			}
+			art := artStyle.Width(width).Render(strings.Join(state.Art, "\n"))
+			content.WriteString(lipgloss.PlaceHorizontal(termWidth, lipgloss.Center, art))
+			content.WriteString("\n")
+		} else {
+			titleText := "collidertracker"
+			if titleScale > 0 {
+				titleText = strings.Repeat(" ", titleScale) + titleText + strings.Repeat(" ", titleScale)
+			}
 
-		// Add side decorations
-		leftDecor, rightDecor := renderSideDecorations(progress, 0)
-		titleTextWithDecor := leftDecor + titleText + rightDecor
+			// Add side decorations
+			leftDecor, rightDecor := renderSideDecorations(progress, 0)
+			titleTextWithDecor := leftDecor + titleText + rightDecor
 
-		titleLine := titleStyleEnhanced.Width(termWidth).Render(titleTextWithDecor)
-		content.WriteString(titleLine)
-		content.WriteString("\n")
+			titleLine := titleStyleEnhanced.Width(termWidth).Render(titleTextWithDecor)
+			content.WriteString(titleLine)
+			content.WriteString("\n")
+		}
 	}
 
 	// Subtitle with typewriter effect
@@ -235,10 +368,10 @@ func RenderSplashScreen(termWidth, termHeight int, state *SplashState, version s
 		dotCount := int(float64(termTime)/240.0) % 4
 		dots := strings.Repeat(".", dotCount)
 
-		// Progress bar effect
+		// Progress bar follows the startup stages
 		if loadingProgress > 0.3 {
 			barLength := 20
-			filledLength := int(float64(barLength) * loadingProgress)
+			filledLength := barLength * int(state.Stage()) / int(StageBuffersReady)
 			bar := "[" + strings.Repeat("█", filledLength) + strings.Repeat("░", barLength-filledLength) + "]"
 			baseText += " " + bar
 		}
@@ -261,31 +394,21 @@ func RenderSplashScreen(termWidth, termHeight int, state *SplashState, version s
 		content.WriteString(loadingLine)
 		content.WriteString("\n")
 
-		// Add status messages during later phases
-		if progress > 0.45 {
-			statusProgress := (progress - 0.45) / 0.15
-
-			var statusText string
-			if statusProgress < 0.3 {
-				statusText = "◦ Loading audio engine..."
-			} else if statusProgress < 0.6 {
-				statusText = "◦ Initializing synthesizers..."
-			} else if statusProgress < 0.8 {
-				statusText = "◦ Setting up MIDI connections..."
-			} else {
-				statusText = ""
-			}
-
-			statusStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("245")).
+		// Stage checklist, or the failure with what can be done about it
+		statusStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245")).
+			Align(lipgloss.Center)
+		if failure := state.Failure(); failure != "" {
+			errorStyle := lipgloss.NewStyle().
+				Foreground(lipgloss.Color("196")).
+				Bold(true).
 				Align(lipgloss.Center)
-
-			if statusProgress > 0.9 {
-				statusStyle = statusStyle.Foreground(lipgloss.Color("82")).Bold(true)
-			}
-
-			statusLine := statusStyle.Width(termWidth).Render(statusText)
-			content.WriteString(statusLine)
+			content.WriteString(errorStyle.Width(termWidth).Render(failure))
+			content.WriteString("\n")
+			content.WriteString(statusStyle.Width(termWidth).Render("r: retry | s: continue without audio | q: quit"))
+			content.WriteString("\n")
+		} else {
+			content.WriteString(statusStyle.Width(termWidth).Render(renderStartupChecklist(state.Stage())))
 			content.WriteString("\n")
 		}
 	}
@@ -298,6 +421,9 @@ func RenderSplashScreen(termWidth, termHeight int, state *SplashState, version s
 
 	// Fill remaining space
 	remainingLines := termHeight - centerY - 12 // Adjust for text and bottom animation
+	if len(state.Art) > 1 {
+		remainingLines -= len(state.Art) - 1 - len(state.Art)/2 // Art lines below the center
+	}
 	if remainingLines > 0 {
 		for i := 0; i < remainingLines; i++ {
 			content.WriteString("\n")
@@ -307,6 +433,19 @@ func RenderSplashScreen(termWidth, termHeight int, state *SplashState, version s
 	return content.String()
 }
 
+// renderStartupChecklist lists the startup stages, ticking the ones reached
+func renderStartupChecklist(reached StartupStage) string {
+	var items []string
+	for stage := StageSclangFound; stage <= StageBuffersReady; stage++ {
+		mark := "◦"
+		if stage <= reached {
+			mark = "✓"
+		}
+		items = append(items, mark+" "+stage.String())
+	}
+	return strings.Join(items, "  ")
+}
+
 // Particle represents a single animated element
 type Particle struct {
 	X, Y       float64
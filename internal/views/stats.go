@@ -0,0 +1,85 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/schollz/collidertracker/internal/i18n"
+	"github.com/schollz/collidertracker/internal/input"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// RenderStatsView renders song length, slot budgets and sample disk usage
+func RenderStatsView(m *model.Model) string {
+	stats := m.ComputeProjectStats()
+
+	return renderViewWithCommonPattern(m, i18n.T("Project Stats"), fmt.Sprintf("%.2f BPM", m.BPM), func(styles *ViewStyles) string {
+		var content strings.Builder
+
+		row := func(label, value string) {
+			content.WriteString(fmt.Sprintf("%-18s %s\n", styles.Label.Render(label), styles.Normal.Render(value)))
+		}
+
+		content.WriteString("\n")
+		row("Song length:", fmt.Sprintf("%s (%d ticks)", formatDuration(stats.SongSeconds), stats.SongTicks))
+		row("Chains IN/SA:", fmt.Sprintf("%3d / %3d used, %3d / %3d free",
+			stats.InstrumentChainsUsed, stats.SamplerChainsUsed, types.NumChains-stats.InstrumentChainsUsed, types.NumChains-stats.SamplerChainsUsed))
+		row("Phrases IN/SA:", fmt.Sprintf("%3d / %3d used, %3d / %3d free",
+			stats.InstrumentPhrasesUsed, stats.SamplerPhrasesUsed, types.NumPhrases-stats.InstrumentPhrasesUsed, types.NumPhrases-stats.SamplerPhrasesUsed))
+		samples := fmt.Sprintf("%d files, %s", stats.SampleFiles, formatBytes(stats.SampleBytes))
+		if stats.MissingSamples > 0 {
+			samples += fmt.Sprintf(" (%d missing)", stats.MissingSamples)
+		}
+		if stats.MismatchedSamples > 0 {
+			serverRate, _ := m.ServerAudioInfo()
+			samples += fmt.Sprintf(" (%d not at %d Hz)", stats.MismatchedSamples, serverRate)
+		}
+		row("Samples:", samples)
+
+		// Per-track breakdown
+		content.WriteString("\n")
+		content.WriteString(styles.Label.Render("Track  Type  Events  Length"))
+		content.WriteString("\n")
+		ticksPerSecond := 0.0
+		if m.BPM > 0 && m.PPQ > 0 {
+			ticksPerSecond = float64(m.BPM) / 60.0 * float64(m.PPQ)
+		}
+		for track := 0; track < types.NumTracks; track++ {
+			trackType := m.TrackTypeCode(track)
+			seconds := 0.0
+			if ticksPerSecond > 0 {
+				seconds = float64(stats.TrackTicks[track]) / (ticksPerSecond * float64(m.TrackResolution(track)))
+			}
+			line := fmt.Sprintf("T%d     %s    %6d  %s", track+1, trackType, stats.TrackEvents[track], formatDuration(seconds))
+			if stats.TrackEvents[track] == 0 {
+				content.WriteString(styles.Label.Render(line))
+			} else {
+				content.WriteString(styles.Normal.Render(line))
+			}
+			content.WriteString("\n")
+		}
+
+		return content.String()
+	}, fmt.Sprintf(i18n.T("tab: diagnostics | %s+T/esc: back"), input.GetModifierKey()), i18n.T("Song length is one pass through all song rows"), 16)
+}
+
+// formatDuration formats seconds as m:ss.t
+func formatDuration(seconds float64) string {
+	minutes := int(seconds) / 60
+	return fmt.Sprintf("%d:%04.1f", minutes, seconds-float64(minutes*60))
+}
+
+// formatBytes formats a byte count using binary units
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
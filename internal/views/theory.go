@@ -0,0 +1,80 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/schollz/collidertracker/internal/i18n"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/music"
+)
+
+// renderTheoryHelper renders the chord and scale helper under an instrument phrase: the
+// notes of the cursor row, the scale they are in and the chords that belong to it. Notes
+// outside the scale are marked with *, and the chord the row plays is highlighted.
+func renderTheoryHelper(m *model.Model) string {
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	normalStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	outStyle := lipgloss.NewStyle().Foreground(paletteOf(m).Warning)
+	chordStyle := lipgloss.NewStyle().Foreground(paletteOf(m).Attention).Bold(true)
+	help := m.TheoryHelp()
+	label := func(s string) string { return labelStyle.Render(fmt.Sprintf("%-8s", i18n.T(s))) }
+
+	var content strings.Builder
+	content.WriteString(label("Row:"))
+	if len(help.Notes) == 0 {
+		content.WriteString(labelStyle.Render("---"))
+	}
+	for i, note := range help.Notes {
+		if i > 0 {
+			content.WriteString(" ")
+		}
+		if help.Known && help.OutOfKey[i] {
+			content.WriteString(outStyle.Render(music.MidiToNoteName(note) + "*"))
+		} else {
+			content.WriteString(normalStyle.Render(music.MidiToNoteName(note)))
+		}
+	}
+	content.WriteString("\n")
+
+	content.WriteString(label("Scale:"))
+	if !help.Known {
+		content.WriteString(labelStyle.Render(i18n.T("none, the phrase has no notes")))
+		content.WriteString("\n")
+		content.WriteString(label("Chords:"))
+		content.WriteString("\n")
+		return content.String()
+	}
+	source := fmt.Sprintf(i18n.T("modulate %02X"), help.Modulate)
+	if help.Guessed {
+		source = i18n.T("guessed")
+	}
+	names := make([]string, len(help.Steps))
+	for i, step := range help.Steps {
+		names[i] = music.PitchClassName(help.Root + step)
+	}
+	content.WriteString(normalStyle.Render(fmt.Sprintf("%s %s", music.PitchClassName(help.Root), help.Scale)))
+	content.WriteString(labelStyle.Render(fmt.Sprintf(" (%s)  ", source)))
+	content.WriteString(normalStyle.Render(strings.Join(names, " ")))
+	content.WriteString("\n")
+
+	content.WriteString(label("Chords:"))
+	if help.Diatonic == nil {
+		content.WriteString(labelStyle.Render(fmt.Sprintf(i18n.T("none for %s"), help.Scale)))
+	}
+	for i, chord := range help.Diatonic {
+		if i > 0 {
+			content.WriteString("  ")
+		}
+		text := chord.Numeral + " " + chord.Name()
+		if i == help.RowChord {
+			content.WriteString(chordStyle.Render(text))
+		} else {
+			content.WriteString(normalStyle.Render(text))
+		}
+	}
+	content.WriteString("\n")
+	return content.String()
+}
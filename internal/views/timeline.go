@@ -0,0 +1,138 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/schollz/collidertracker/internal/i18n"
+	"github.com/schollz/collidertracker/internal/input"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/types"
+)
+
+// timelineLabelWidth is the width of the "T1  " lane labels
+const timelineLabelWidth = 4
+
+// RenderTimelineView renders the song as one lane of blocks per track, each block as long as
+// its chain plays at the current tempo, under rulers in bars and minutes
+func RenderTimelineView(m *model.Model) string {
+	lanes := m.Timeline()
+	total := model.TimelineSeconds(lanes)
+	width := m.TermWidth - timelineLabelWidth - 4
+	if width < 16 {
+		width = 16
+	}
+	scale := 0.0 // Columns per second
+	if total > 0 {
+		scale = float64(width) / total
+	}
+
+	statusMsg := "Song is empty"
+	if track := m.TimelineTrack; track >= 0 && track < types.NumTracks {
+		if index := model.TimelineBlockIndex(lanes[track], m.TimelineRow); index != -1 {
+			block := lanes[track][index]
+			statusMsg = fmt.Sprintf("T%d row %02X: chain %02X%s at %s for %s", track+1, block.Row, block.Chain,
+				headerName(m.DisplayChainName(track, block.Chain)), formatDuration(block.Start), formatDuration(block.Seconds))
+		} else if total > 0 {
+			statusMsg = "Use arrows to select a block"
+		}
+	}
+
+	return renderViewWithCommonPattern(m, i18n.T("Timeline"), formatDuration(total), func(styles *ViewStyles) string {
+		var content strings.Builder
+		if total == 0 {
+			content.WriteString("\n")
+			content.WriteString(styles.Label.Render("Add chains to the song to see them here"))
+			content.WriteString("\n")
+			return content.String()
+		}
+
+		// Rulers
+		indent := strings.Repeat(" ", timelineLabelWidth)
+		secondsPerBar := 60.0 / float64(m.BPM) * model.TimelineBeatsPerBar
+		bars := timelineRuler(width, scale, secondsPerBar, []int{1, 2, 4, 8, 16, 32, 64, 128, 256}, func(n int) string {
+			return fmt.Sprintf("%d", n+1)
+		})
+		minutes := timelineRuler(width, scale, 1, []int{5, 10, 15, 30, 60, 120, 300, 600, 1800}, func(n int) string {
+			return fmt.Sprintf("%d:%02d", n/60, n%60)
+		})
+		content.WriteString(styles.Label.Render(indent + bars))
+		content.WriteString("\n")
+		content.WriteString(styles.Label.Render(indent + minutes))
+		content.WriteString("\n")
+
+		// One lane per track
+		for track := 0; track < types.NumTracks; track++ {
+			content.WriteString(styles.Label.Render(fmt.Sprintf("%-*s", timelineLabelWidth, fmt.Sprintf("T%d", track+1))))
+			col := 0
+			for _, block := range lanes[track] {
+				start := max(int(block.Start*scale), col)
+				end := min(max(int((block.Start+block.Seconds)*scale), start+1), width)
+				if start >= width {
+					break
+				}
+				content.WriteString(strings.Repeat(" ", start-col))
+				name, _ := m.DisplayChainName(track, block.Chain)
+				style, text := styles.Normal, timelineBlockText(block.Chain, name, end-start)
+				playing := m.SongPlaybackActive[track] && m.SongPlaybackRow[track] == block.Row
+				if playing && strings.HasPrefix(text, "[") {
+					text = "▶" + text[1:] // Playing shows by shape too, even when selected
+				}
+				if track == m.TimelineTrack && block.Row == m.TimelineRow {
+					style = styles.Selected
+				} else if playing {
+					style = styles.Playback
+				}
+				content.WriteString(style.Render(text))
+				col = end
+			}
+			content.WriteString("\n")
+		}
+		return content.String()
+	}, fmt.Sprintf(i18n.T("arrows: select | enter: open in song | %s+A/esc: back"), input.GetModifierKey()), statusMsg, types.NumTracks+3)
+}
+
+// timelineBlockText draws a block of chain over width columns, with as much of the chain's
+// name as fits, dropping the brackets and then the chain ID when the block is too short for them
+func timelineBlockText(chain int, name string, width int) string {
+	label := fmt.Sprintf("%02X", chain)
+	if runes := []rune(name); len(runes) > 0 && width >= 6 {
+		label += " " + string(runes[:min(len(runes), width-5)])
+	}
+	switch {
+	case width >= 4:
+		return "[" + label + strings.Repeat("─", width-2-len([]rune(label))) + "]"
+	case width >= 2:
+		return label + strings.Repeat(" ", width-2)
+	default:
+		return "▌"
+	}
+}
+
+// timelineRuler labels every step-th unit of unitSeconds along width columns, using the
+// smallest step that keeps the labels apart
+func timelineRuler(width int, scale, unitSeconds float64, steps []int, label func(n int) string) string {
+	widest := lipgloss.Width(label(steps[len(steps)-1] * 10))
+	step := steps[len(steps)-1]
+	for _, s := range steps {
+		if float64(s)*unitSeconds*scale >= float64(widest+1) {
+			step = s
+			break
+		}
+	}
+	ruler := []rune(strings.Repeat(" ", width))
+	for n := 0; ; n += step {
+		col := int(float64(n) * unitSeconds * scale)
+		if col >= width {
+			break
+		}
+		for i, r := range "|" + label(n) {
+			if col+i < width {
+				ruler[col+i] = r
+			}
+		}
+	}
+	return string(ruler)
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/input"
 	"github.com/schollz/collidertracker/internal/model"
 )
@@ -23,7 +24,7 @@ func RenderTimestrechView(m *model.Model) string {
 	var content strings.Builder
 
 	// Render header
-	header := "Timestretch Settings"
+	header := i18n.T("Timestretch Settings")
 	timestrechHeader := fmt.Sprintf("Timestretch %02X", m.TimestrechEditingIndex)
 	content.WriteString(RenderHeader(m, header, timestrechHeader))
 	content.WriteString("\n")
@@ -97,8 +98,8 @@ func RenderTimestrechView(m *model.Model) string {
 	content.WriteString("\n\n")
 
 	// Footer with status
-	helpText := fmt.Sprintf("arrows: navigate | %s+arrows: adjust", input.GetModifierKey())
-	statusMsg := fmt.Sprintf("Timestretch: %.2fx to %.2fx", settings.Start, settings.End)
+	helpText := fmt.Sprintf(i18n.T("arrows: navigate | %s+arrows: adjust"), input.GetModifierKey())
+	statusMsg := fmt.Sprintf(i18n.T("Timestretch: %.2fx to %.2fx"), settings.Start, settings.End)
 	content.WriteString(RenderFooter(m, 7, helpText, statusMsg))
 
 	// Apply container padding
@@ -0,0 +1,100 @@
+package views
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/schollz/collidertracker/internal/i18n"
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/music"
+)
+
+// Tuner needle: tunerMeterWidth cells from -50 to +50 cents, in tune within tunerInTuneCents
+const (
+	tunerMeterWidth  = 51
+	tunerInTuneCents = 3.0
+)
+
+// RenderTunerView shows the note the audio input is playing and how many cents sharp or flat
+// it is, for tuning an instrument before sampling or monitoring it
+func RenderTunerView(m *model.Model) string {
+	freq, note, cents, ok := m.TunerPitch(time.Now())
+	statusMsg := i18n.T("Play a single note into the input")
+	if ok {
+		switch {
+		case math.Abs(cents) <= tunerInTuneCents:
+			statusMsg = i18n.T("In tune")
+		case cents < 0:
+			statusMsg = i18n.T("Flat: tune up")
+		default:
+			statusMsg = i18n.T("Sharp: tune down")
+		}
+	}
+
+	return renderViewWithCommonPattern(m, i18n.T("Tuner"), fmt.Sprintf("A4 = %d Hz", m.TunerReference), func(styles *ViewStyles) string {
+		var content strings.Builder
+		content.WriteString("\n")
+		name, detail := "---", "-"
+		if ok {
+			name = strings.ToUpper(music.MidiToNoteName(note))
+			detail = fmt.Sprintf("%.1f Hz  %+.0f cents", freq, cents)
+		}
+		noteStyle := styles.Normal.Bold(true)
+		if ok && math.Abs(cents) <= tunerInTuneCents {
+			noteStyle = noteStyle.Foreground(paletteOf(m).Playback)
+		}
+		content.WriteString(fmt.Sprintf("%-8s %s\n\n", noteStyle.Render(name), styles.Label.Render(detail)))
+		content.WriteString(renderTunerMeter(m, cents, ok))
+		content.WriteString("\n")
+		content.WriteString(styles.Label.Render(tunerMeterScale()))
+		content.WriteString("\n")
+		return content.String()
+	}, i18n.T("up/down: A4 ±1 Hz | t/esc: back"), statusMsg, 6)
+}
+
+// renderTunerMeter draws the needle at the cents off the note, over a centre mark. The needle
+// is lit when the note is in tune.
+func renderTunerMeter(m *model.Model, cents float64, ok bool) string {
+	center := tunerMeterWidth / 2
+	needle := -1
+	if ok {
+		needle = center + int(math.Round(cents/100*float64(tunerMeterWidth-1)))
+		needle = max(0, min(tunerMeterWidth-1, needle))
+	}
+	color := paletteOf(m).Warning
+	if math.Abs(cents) <= tunerInTuneCents {
+		color = paletteOf(m).Playback
+	}
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("238"))
+	var meter strings.Builder
+	for i := 0; i < tunerMeterWidth; i++ {
+		switch {
+		case i == needle:
+			meter.WriteString(lipgloss.NewStyle().Foreground(color).Render("█"))
+		case i == center:
+			meter.WriteString(dim.Render("│"))
+		default:
+			meter.WriteString(dim.Render("░"))
+		}
+	}
+	return meter.String()
+}
+
+// tunerMeterScale labels the meter every 25 cents
+func tunerMeterScale() string {
+	scale := []byte(strings.Repeat(" ", tunerMeterWidth+2))
+	for cents := -50; cents <= 50; cents += 25 {
+		label := fmt.Sprintf("%+d", cents)
+		if cents == 0 {
+			label = "0"
+		}
+		pos := (tunerMeterWidth/2 + cents*(tunerMeterWidth-1)/100) - len(label)/2
+		pos = max(0, min(pos, len(scale)-len(label)))
+		copy(scale[pos:], label)
+	}
+	return strings.TrimRight(string(scale), " ")
+}
@@ -0,0 +1,69 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/schollz/collidertracker/internal/i18n"
+	"github.com/schollz/collidertracker/internal/input"
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// RenderUsageView maps which chain and phrase IDs of a pool are used, 16 IDs per line
+func RenderUsageView(m *model.Model) string {
+	chains := m.ChainUsage(m.UsageSampler)
+	phrases := m.PhraseUsage(m.UsageSampler)
+	pool := "Instrument"
+	if m.UsageSampler {
+		pool = "Sampler"
+	}
+
+	count := func(usage []model.SlotUsage, kind model.SlotUsage) int {
+		n := 0
+		for _, u := range usage {
+			if u == kind {
+				n++
+			}
+		}
+		return n
+	}
+	statusMsg := fmt.Sprintf("%s pool: %d chains and %d phrases used, %d and %d unreferenced", pool,
+		count(chains, model.SlotUsed), count(phrases, model.SlotUsed),
+		count(chains, model.SlotUnreferenced), count(phrases, model.SlotUnreferenced))
+
+	return renderViewWithCommonPattern(m, i18n.T("Usage"), pool+" pool", func(styles *ViewStyles) string {
+		cell := func(usage []model.SlotUsage, id int) string {
+			if id >= len(usage) {
+				return "  "
+			}
+			switch usage[id] {
+			case model.SlotUsed:
+				return styles.Normal.Render("■ ")
+			case model.SlotUnreferenced:
+				return styles.Warning.Render("□ ")
+			}
+			return styles.Label.Render("· ")
+		}
+
+		var content strings.Builder
+		content.WriteString("\n")
+		content.WriteString(styles.Label.Render(fmt.Sprintf("    %-32s     %s", "Chains", "Phrases")))
+		content.WriteString("\n")
+		for line := 0; line < 16; line++ {
+			for _, usage := range [][]model.SlotUsage{chains, phrases} {
+				content.WriteString(styles.Label.Render(fmt.Sprintf("%X0  ", line)))
+				for id := line * 16; id < line*16+16; id++ {
+					content.WriteString(cell(usage, id))
+				}
+				content.WriteString(" ")
+			}
+			// Each track's bank is two lines
+			if m.PerTrackBanks && line%2 == 0 {
+				content.WriteString(styles.Label.Render(fmt.Sprintf("T%d", line/2+1)))
+			}
+			content.WriteString("\n")
+		}
+		return content.String()
+	}, fmt.Sprintf(i18n.T("■ used  □ unreferenced | tab: instrument/sampler | c: renumber contiguously | U/esc: back | %s+Z: undo"), input.GetModifierKey()),
+		statusMsg, 18)
+}
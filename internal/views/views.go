@@ -5,10 +5,13 @@ import (
 	"math"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/music"
 	"github.com/schollz/collidertracker/internal/types"
 )
 
@@ -25,28 +28,32 @@ type ViewStyles struct {
 	SliceDownbeat lipgloss.Style
 	Dir           lipgloss.Style
 	AssignedFile  lipgloss.Style
+	Warning       lipgloss.Style
 }
 
-// getCommonStyles returns the standard style definitions used across views
-func getCommonStyles() *ViewStyles {
+// getCommonStyles returns the standard style definitions used across views, in the palette
+// chosen in the settings
+func getCommonStyles(m *model.Model) *ViewStyles {
+	colors := paletteOf(m)
 	return &ViewStyles{
 		Selected:      lipgloss.NewStyle().Background(lipgloss.Color("7")).Foreground(lipgloss.Color("0")),
 		Normal:        lipgloss.NewStyle().Foreground(lipgloss.Color("15")),
 		Label:         lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
 		Container:     lipgloss.NewStyle().Padding(1, 2),
-		Playback:      lipgloss.NewStyle().Foreground(lipgloss.Color("10")),
-		Copied:        lipgloss.NewStyle().Background(lipgloss.Color("3")).Foreground(lipgloss.Color("0")),
+		Playback:      lipgloss.NewStyle().Foreground(colors.Playback),
+		Copied:        lipgloss.NewStyle().Background(colors.Copied).Foreground(lipgloss.Color("0")),
 		Chain:         lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
 		Slice:         lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
 		SliceDownbeat: lipgloss.NewStyle().Foreground(lipgloss.Color("7")),
 		Dir:           lipgloss.NewStyle().Foreground(lipgloss.Color("14")),
-		AssignedFile:  lipgloss.NewStyle().Background(lipgloss.Color("3")).Foreground(lipgloss.Color("0")),
+		AssignedFile:  lipgloss.NewStyle().Background(colors.Copied).Foreground(lipgloss.Color("0")),
+		Warning:       lipgloss.NewStyle().Foreground(colors.Warning),
 	}
 }
 
 // renderViewWithCommonPattern provides a common structure for rendering views
 func renderViewWithCommonPattern(m *model.Model, leftHeader, rightHeader string, renderContent func(styles *ViewStyles) string, helpText string, statusMsg string, contentLines int) string {
-	styles := getCommonStyles()
+	styles := getCommonStyles(m)
 
 	// File views keep a top spacer but no bottom padding to fit the terminal height after footer padding.
 	if m.ViewMode == types.FileView || m.ViewMode == types.FileMetadataView {
@@ -75,15 +82,167 @@ func renderViewWithCommonPattern(m *model.Model, leftHeader, rightHeader string,
 func getRecordingIndicator(m *model.Model) string {
 	if m.RecordingActive {
 		// Closed red circle for active recording
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render("●")
+		return lipgloss.NewStyle().Foreground(paletteOf(m).Warning).Render("●")
 	} else if m.RecordingEnabled {
 		// Open red circle for queued recording
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render("○")
+		return lipgloss.NewStyle().Foreground(paletteOf(m).Warning).Render("○")
 	}
 	// No indicator when recording is disabled
 	return ""
 }
 
+// getSessionRecordingIndicator shows REC while the master output is recorded, rec while armed
+// and BOUNCE (PRINT for a single instrument, CLIP for a phrase export) while a loop is bounced
+func getSessionRecordingIndicator(m *model.Model) string {
+	if m.Bounce != nil && m.Bounce.Clip {
+		return lipgloss.NewStyle().Foreground(paletteOf(m).Warning).Render("CLIP")
+	} else if m.Bounce != nil && m.Bounce.Print {
+		return lipgloss.NewStyle().Foreground(paletteOf(m).Warning).Render("PRINT")
+	} else if m.Bounce != nil {
+		return lipgloss.NewStyle().Foreground(paletteOf(m).Warning).Render("BOUNCE")
+	} else if m.SessionRecording {
+		return lipgloss.NewStyle().Foreground(paletteOf(m).Warning).Render("REC")
+	} else if m.SessionPunchArmed {
+		return lipgloss.NewStyle().Foreground(paletteOf(m).Warning).Render("rec")
+	}
+	return ""
+}
+
+// getAnalysisIndicator shows how far a batch sample analysis has got
+func getAnalysisIndicator(m *model.Model) string {
+	if m.Analysis == nil {
+		return ""
+	}
+	text := fmt.Sprintf("ANALYZE %d/%d", m.Analysis.Done, len(m.Analysis.Files))
+	if m.Analysis.Paused {
+		text += " paused"
+	}
+	return lipgloss.NewStyle().Foreground(paletteOf(m).Attention).Render(text)
+}
+
+// getEcoIndicator shows that eco mode is on
+func getEcoIndicator(m *model.Model) string {
+	if !m.EcoMode {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(paletteOf(m).Playback).Render("ECO")
+}
+
+// getBeatFlash fills the header's gap of width cells with a bar of light while a beat flash is
+// lit, in the warning color on the first beat of a bar
+func getBeatFlash(m *model.Model, width int) string {
+	lit, bar := m.BeatFlashing(time.Now())
+	if !lit || width < 3 {
+		return ""
+	}
+	color := paletteOf(m).Playback
+	if bar {
+		color = paletteOf(m).Warning
+	}
+	return " " + lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", width-2)) + " "
+}
+
+// getUpdateIndicator shows that a newer release is available
+func getUpdateIndicator(m *model.Model) string {
+	release, ok := m.AvailableUpdate()
+	if !ok {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(paletteOf(m).Attention).Render("UPDATE " + release.Tag)
+}
+
+// getActivityIndicator shows a LED per track during playback, lit as each of its rows triggers
+// and dimmed for tracks whose mixer level is all the way down
+func getActivityIndicator(m *model.Model) string {
+	if !m.IsPlaying {
+		return ""
+	}
+	now := time.Now()
+	var leds strings.Builder
+	for track := 0; track < types.NumTracks; track++ {
+		led, color := "○", lipgloss.Color("238")
+		if m.TrackTriggered(track, now) {
+			led, color = "●", paletteOf(m).Playback
+			if m.TrackSilenced(track) {
+				color = "8"
+			}
+		}
+		leds.WriteString(lipgloss.NewStyle().Foreground(color).Render(led))
+	}
+	return leds.String()
+}
+
+// getSongPositionIndicator shows bars:beats:ticks, elapsed and remaining time during song playback
+func getSongPositionIndicator(m *model.Model) string {
+	position, length, ok := m.SongPosition()
+	if !ok {
+		return ""
+	}
+	bar, beat, tick := m.BarsBeatsTicks(position)
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("14")).Render(
+		fmt.Sprintf("%d:%d:%d %s -%s", bar, beat, tick, formatClock(position), formatClock(length-position)))
+}
+
+// getCueIndicator counts down the bars to the next cue marker during song playback
+func getCueIndicator(m *model.Model) string {
+	name, bars, ok := m.NextCue()
+	if !ok {
+		return ""
+	}
+	unit := "bars"
+	if bars == 1 {
+		unit = "bar"
+	}
+	return lipgloss.NewStyle().Foreground(paletteOf(m).Attention).Render(fmt.Sprintf("%s in %d %s", name, bars, unit))
+}
+
+// formatClock formats seconds as m:ss
+func formatClock(seconds float64) string {
+	total := int(max(0, seconds))
+	return fmt.Sprintf("%d:%02d", total/60, total%60)
+}
+
+// getTimedStartIndicator counts down to an armed timed start, then counts its count-in beats
+func getTimedStartIndicator(m *model.Model) string {
+	left, beat, ok := m.TimedStartCount(time.Now())
+	if !ok {
+		return ""
+	}
+	indicator := "START " + formatClock(math.Ceil(left.Seconds()))
+	if beat > 0 {
+		indicator = fmt.Sprintf("START %d", beat)
+	}
+	return lipgloss.NewStyle().Foreground(paletteOf(m).Attention).Render(indicator)
+}
+
+// getPitchTrackingIndicator shows PITCH and the note the input holds while pitch tracking enters notes
+func getPitchTrackingIndicator(m *model.Model) string {
+	if !m.PitchTracking {
+		return ""
+	}
+	indicator := "PITCH"
+	if note := m.PitchTrackingNote(); note != -1 {
+		indicator += " " + music.MidiToNoteName(note)
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("13")).Render(indicator)
+}
+
+// getOSCLinkIndicator warns when SuperCollider stopped sending telemetry
+func getOSCLinkIndicator(m *model.Model) string {
+	if m.IsOSCLinkLost() {
+		return lipgloss.NewStyle().Foreground(paletteOf(m).Warning).Render("SC?")
+	}
+	return ""
+}
+
+// getDirtyIndicator marks a project with changes that are not saved yet
+func getDirtyIndicator(m *model.Model) string {
+	if m.IsDirty() {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Render("*")
+	}
+	return ""
+}
+
 // RenderHeader renders the common waveform + header pattern used by all views
 func RenderHeader(m *model.Model, leftContent, rightContent string) string {
 	var content strings.Builder
@@ -112,7 +271,7 @@ func RenderHeader(m *model.Model, leftContent, rightContent string) string {
 	}
 
 	// Get the appropriate waveform buffer
-	if trackIndex >= 0 && trackIndex < 8 {
+	if trackIndex >= 0 && trackIndex < types.NumTracks {
 		waveformData = m.TrackWaveformBuf[trackIndex]
 	} else {
 		// Fall back to summed waveform for other views
@@ -132,19 +291,72 @@ func RenderHeader(m *model.Model, leftContent, rightContent string) string {
 		}
 	}
 
-	content.WriteString(RenderWaveform(waveWidth, cellsHigh, waveformData))
+	// A lower detail draws fewer rows, keeping the header height
+	switch m.WaveformDetail {
+	case types.WaveformDetailLow:
+		content.WriteString(RenderWaveform(waveWidth, 1, waveformData))
+		content.WriteString(strings.Repeat("\n", cellsHigh-1))
+	case types.WaveformDetailOff:
+		content.WriteString(strings.Repeat("\n", cellsHigh-1))
+	default:
+		content.WriteString(RenderWaveform(waveWidth, cellsHigh, waveformData))
+	}
 	content.WriteString("\n")
 
-	// Build header with recording indicator
+	// Build header with track activity, song position, timed start, recording, session recording, sample analysis, pitch tracking, eco mode, update, OSC link and unsaved changes indicators
+	activityIndicator := getActivityIndicator(m)
+	positionIndicator := getSongPositionIndicator(m)
+	timedStartIndicator := getTimedStartIndicator(m)
+	cueIndicator := getCueIndicator(m)
 	recordingIndicator := getRecordingIndicator(m)
+	sessionIndicator := getSessionRecordingIndicator(m)
+	analysisIndicator := getAnalysisIndicator(m)
+	pitchIndicator := getPitchTrackingIndicator(m)
+	ecoIndicator := getEcoIndicator(m)
+	updateIndicator := getUpdateIndicator(m)
+	linkIndicator := getOSCLinkIndicator(m)
+	dirtyIndicator := getDirtyIndicator(m)
 
 	// Calculate available space for padding (account for container padding)
 	availableWidth := m.TermWidth - 4 // Container padding (2 on each side)
 	leftLen := lipgloss.Width(leftContent)
 	rightLen := lipgloss.Width(rightContent)
 	indicatorLen := 0
+	if activityIndicator != "" {
+		indicatorLen = 1 + lipgloss.Width(activityIndicator)
+	}
+	if positionIndicator != "" {
+		indicatorLen += 1 + lipgloss.Width(positionIndicator)
+	}
+	if timedStartIndicator != "" {
+		indicatorLen += 1 + lipgloss.Width(timedStartIndicator)
+	}
+	if cueIndicator != "" {
+		indicatorLen += 1 + lipgloss.Width(cueIndicator)
+	}
 	if recordingIndicator != "" {
-		indicatorLen = 2 // Space + circle
+		indicatorLen += 2 // Space + circle
+	}
+	if sessionIndicator != "" {
+		indicatorLen += 1 + lipgloss.Width(sessionIndicator)
+	}
+	if analysisIndicator != "" {
+		indicatorLen += 1 + lipgloss.Width(analysisIndicator)
+	}
+	if pitchIndicator != "" {
+		indicatorLen += 1 + lipgloss.Width(pitchIndicator)
+	}
+	if ecoIndicator != "" {
+		indicatorLen += 1 + lipgloss.Width(ecoIndicator)
+	}
+	if updateIndicator != "" {
+		indicatorLen += 1 + lipgloss.Width(updateIndicator)
+	}
+	if linkIndicator != "" {
+		indicatorLen += 1 + lipgloss.Width(linkIndicator)
+	}
+	if dirtyIndicator != "" {
+		indicatorLen += 1 + lipgloss.Width(dirtyIndicator)
 	}
 
 	// Ensure we have enough space
@@ -153,14 +365,49 @@ func RenderHeader(m *model.Model, leftContent, rightContent string) string {
 		paddingSize = 1
 	}
 
-	// Build full header
+	// Build full header, lighting the gap between its sides on a beat flash
 	fullHeader := leftContent
-	if rightContent != "" {
+	if flash := getBeatFlash(m, paddingSize); flash != "" {
+		fullHeader += flash + rightContent
+	} else if rightContent != "" {
 		fullHeader += strings.Repeat(" ", paddingSize) + rightContent
 	}
+	if activityIndicator != "" {
+		fullHeader += " " + activityIndicator
+	}
+	if positionIndicator != "" {
+		fullHeader += " " + positionIndicator
+	}
+	if timedStartIndicator != "" {
+		fullHeader += " " + timedStartIndicator
+	}
+	if cueIndicator != "" {
+		fullHeader += " " + cueIndicator
+	}
 	if recordingIndicator != "" {
 		fullHeader += " " + recordingIndicator
 	}
+	if sessionIndicator != "" {
+		fullHeader += " " + sessionIndicator
+	}
+	if analysisIndicator != "" {
+		fullHeader += " " + analysisIndicator
+	}
+	if pitchIndicator != "" {
+		fullHeader += " " + pitchIndicator
+	}
+	if ecoIndicator != "" {
+		fullHeader += " " + ecoIndicator
+	}
+	if updateIndicator != "" {
+		fullHeader += " " + updateIndicator
+	}
+	if linkIndicator != "" {
+		fullHeader += " " + linkIndicator
+	}
+	if dirtyIndicator != "" {
+		fullHeader += " " + dirtyIndicator
+	}
 
 	content.WriteString(fullHeader)
 	content.WriteString("\n")
@@ -427,6 +674,73 @@ func RenderFooter(m *model.Model, contentLines int, helpText string, statusMsg s
 	statusStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
 	var content strings.Builder
 
+	// A pending confirmation replaces the status message
+	if m.PendingConfirm != nil {
+		statusMsg = i18n.T(m.PendingConfirm.Message) + " (y/n)"
+		statusStyle = lipgloss.NewStyle().Foreground(paletteOf(m).Warning)
+	}
+
+	// A startup notice replaces the status message until the next key press
+	if m.PendingConfirm == nil && m.Notice != "" {
+		statusMsg = i18n.T(m.Notice)
+		statusStyle = lipgloss.NewStyle().Foreground(paletteOf(m).Warning)
+	}
+
+	// A tempo/key preview shows what is proposed and how to keep or revert it
+	if m.PendingConfirm == nil && m.Notice == "" && m.Preview.Active {
+		statusMsg = fmt.Sprintf(i18n.T("PREVIEW %.2f BPM (was %.2f), key %+d | up/down: tempo, left/right: key, enter: keep, esc: revert"),
+			m.BPM, m.Preview.BPM, m.Preview.Transpose)
+		statusStyle = lipgloss.NewStyle().Foreground(paletteOf(m).Attention)
+	}
+
+	// The live keyboard shows its range and the last note played
+	if m.PendingConfirm == nil && m.Notice == "" && m.LiveKeys.Active {
+		statusMsg = fmt.Sprintf(i18n.T("LIVE KEYS %s, last %s | z-m, q-u: play, -/=: octave, esc: leave"), liveKeysRange(m), liveKeysLast(m))
+		statusStyle = lipgloss.NewStyle().Foreground(paletteOf(m).Attention)
+	}
+
+	// The clipboard picker shows the chosen entry of the clipboard history
+	if m.PendingConfirm == nil && m.Notice == "" && m.PickingClipboard && m.ClipboardPick < len(m.ClipboardHistory) {
+		statusMsg = fmt.Sprintf(i18n.T("CLIPBOARD %d/%d: %s | up/down: choose, enter: paste, esc: close"),
+			m.ClipboardPick+1, len(m.ClipboardHistory), model.ClipboardSummary(m.ClipboardHistory[m.ClipboardPick]))
+		statusStyle = lipgloss.NewStyle().Foreground(paletteOf(m).Attention)
+	}
+
+	// The track kit picker shows the chosen kit and the track it goes into
+	if m.PendingConfirm == nil && m.Notice == "" && m.PickingKit && m.KitPick < len(m.KitChoices) {
+		name := strings.TrimSuffix(filepath.Base(m.KitChoices[m.KitPick]), filepath.Ext(m.KitChoices[m.KitPick]))
+		statusMsg = fmt.Sprintf(i18n.T("KIT %d/%d: %s | up/down: choose, enter: import into track %d, esc: close"),
+			m.KitPick+1, len(m.KitChoices), name, m.CurrentCol+1)
+		statusStyle = lipgloss.NewStyle().Foreground(paletteOf(m).Attention)
+	}
+
+	// A typed value shows what has been typed so far
+	if m.PendingConfirm == nil && m.Notice == "" && m.NumberEntry.Active {
+		base := "decimal"
+		if m.NumberEntry.Hex {
+			base = "hex"
+		}
+		statusMsg = fmt.Sprintf(i18n.T("VALUE %s_ (%s) | enter: set, esc: cancel"), strings.ToUpper(m.NumberEntry.Buffer), i18n.T(base))
+		statusStyle = lipgloss.NewStyle().Foreground(paletteOf(m).Attention)
+	}
+
+	// A chain or phrase name, or a name search, shows what has been typed so far
+	if m.PendingConfirm == nil && m.Notice == "" && m.NameEntry.Active {
+		statusMsg = nameEntryStatus(m)
+		statusStyle = lipgloss.NewStyle().Foreground(paletteOf(m).Attention)
+	}
+
+	// A project password shows a mask of what has been typed so far
+	if m.PendingConfirm == nil && m.Notice == "" && m.PasswordEntry.Active {
+		mask := strings.Repeat("*", len([]rune(m.PasswordEntry.Buffer)))
+		if m.PasswordEntry.First == "" {
+			statusMsg = fmt.Sprintf(i18n.T("PASSWORD %s_ | enter: next, esc: cancel"), mask)
+		} else {
+			statusMsg = fmt.Sprintf(i18n.T("REPEAT PASSWORD %s_ | enter: protect the project, esc: cancel"), mask)
+		}
+		statusStyle = lipgloss.NewStyle().Foreground(paletteOf(m).Attention)
+	}
+
 	// Calculate how many lines the navigation and status will take
 	navLines := 3 // Navigation always takes 3 lines
 	statusLines := 0
@@ -471,23 +785,94 @@ func RenderPhraseView(m *model.Model) string {
 }
 
 func GetChainStatusMessage(m *model.Model) string {
-	chainsData := m.GetCurrentChainsData()
-	phraseID := (*chainsData)[m.CurrentChain][m.CurrentRow]
+	phraseID := m.GetChainCell(m.CurrentTrack, m.CurrentChain, m.CurrentRow)
 
 	var statusMsg string
 	if phraseID == -1 {
 		statusMsg = fmt.Sprintf("Chain %02X Row %02X: --", m.CurrentChain, m.CurrentRow)
 	} else {
 		statusMsg = fmt.Sprintf("Chain %02X Row %02X: Phrase %02X", m.CurrentChain, m.CurrentRow, phraseID)
+		if name, _ := m.DisplayPhraseName(m.CurrentTrack, phraseID); name != "" {
+			statusMsg += " " + name
+		}
+	}
+	if transpose := m.GetChainTranspose(m.CurrentTrack, m.CurrentChain, m.CurrentRow); transpose != 0 || types.ChainColumn(m.CurrentCol) == types.ChainColTranspose {
+		statusMsg += fmt.Sprintf(" | Transpose %+d semitones", transpose)
+	}
+	fx := m.GetChainFX(m.CurrentTrack, m.CurrentChain, m.CurrentRow)
+	switch types.ChainColumn(m.CurrentCol) {
+	case types.ChainColReverb:
+		statusMsg += " | Reverb send at least " + chainFXValue(fx.Reverb)
+	case types.ChainColLowPass:
+		statusMsg += " | Low pass at most " + chainFXValue(fx.LowPass)
 	}
 
 	return statusMsg
 }
 
+// headerName formats a chain or phrase name to follow its ID in a header, marking automatic
+// names with ~ ("" for none)
+func headerName(name string, auto bool) string {
+	if name == "" {
+		return ""
+	}
+	if auto {
+		return " ~" + name
+	}
+	return " " + name
+}
+
+// liveKeysRange describes what the live keyboard plays: two octaves of notes on instrument
+// tracks, the first 24 slices on sampler tracks
+func liveKeysRange(m *model.Model) string {
+	if m.GetPhraseViewType() == types.SamplerPhraseView {
+		return i18n.T("slices 00-17")
+	}
+	low, _ := m.LiveKeyValue("z")
+	return strings.ToUpper(music.MidiToNoteName(low) + "-" + music.MidiToNoteName(low+23))
+}
+
+// liveKeysLast shows the last note or slice the live keyboard played
+func liveKeysLast(m *model.Model) string {
+	switch {
+	case m.LiveKeys.Last < 0:
+		return "--"
+	case m.GetPhraseViewType() == types.SamplerPhraseView:
+		return fmt.Sprintf("%02X", m.LiveKeys.Last)
+	default:
+		return strings.ToUpper(music.MidiToNoteName(m.LiveKeys.Last))
+	}
+}
+
+// nameEntryStatus describes a chain or phrase name, or a name search, being typed
+func nameEntryStatus(m *model.Model) string {
+	if !m.NameEntry.Search {
+		what := fmt.Sprintf("Chain %02X", m.CurrentChain)
+		if m.ViewMode == types.PhraseView {
+			what = fmt.Sprintf("Phrase %02X", m.CurrentPhrase)
+		}
+		return fmt.Sprintf(i18n.T("NAME %s: %s_ | #word tags | enter: set (empty removes), esc: cancel"), what, m.NameEntry.Buffer)
+	}
+	matches := m.SearchNames(m.CurrentTrack, m.NameEntry.Buffer)
+	if len(matches) == 0 {
+		return fmt.Sprintf(i18n.T("FIND %s_ | no matches | esc: cancel"), m.NameEntry.Buffer)
+	}
+	index := m.NameEntry.Match % len(matches)
+	return fmt.Sprintf(i18n.T("FIND %s_ | %d/%d: %s | tab: next, enter: open, esc: cancel"),
+		m.NameEntry.Buffer, index+1, len(matches), matches[index])
+}
+
+// chainFXValue formats a chain slot effect override for the status line
+func chainFXValue(value int) string {
+	if value == -1 {
+		return "-- (phrase value)"
+	}
+	return fmt.Sprintf("%02X", value)
+}
+
 func IsCurrentRowFile(m *model.Model, filename string) bool {
 	// Check if this file is assigned to the current fileSelectRow
-	phrasesData := m.GetCurrentPhrasesData()
-	fileIndex := (*phrasesData)[m.CurrentPhrase][m.FileSelectRow][types.ColFilename]
+	fileIndex := m.GetPhraseCell(m.CurrentTrack, m.CurrentPhrase, m.FileSelectRow, types.ColFilename)
 	phrasesFiles := m.GetCurrentPhrasesFiles()
 	if fileIndex >= 0 && fileIndex < len(*phrasesFiles) && (*phrasesFiles)[fileIndex] != "" {
 		assignedFile := (*phrasesFiles)[fileIndex]
@@ -1,6 +1,8 @@
 package views
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -8,8 +10,10 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/types"
+	"github.com/schollz/collidertracker/internal/update"
 )
 
 func createTestModel() *model.Model {
@@ -97,6 +101,19 @@ func TestRenderFileView(t *testing.T) {
 	}
 }
 
+func TestRenderTranslated(t *testing.T) {
+	i18n.SetLocale("es")
+	defer i18n.SetLocale(i18n.DefaultLocale)
+	m := createTestModel()
+	m.ViewMode = types.SettingsView
+
+	view := RenderSettingsView(m)
+	assert.Contains(t, view, "Opciones")
+	assert.Contains(t, view, "Idioma:")
+	assert.Contains(t, view, "flechas: navegar")
+	assert.NotContains(t, view, "Options")
+}
+
 func TestRenderSettingsView(t *testing.T) {
 	m := createTestModel()
 	m.ViewMode = types.SettingsView
@@ -241,7 +258,8 @@ func TestRenderSplashScreen(t *testing.T) {
 }
 
 func TestGetCommonStyles(t *testing.T) {
-	styles := getCommonStyles()
+	m := createTestModel()
+	styles := getCommonStyles(m)
 
 	assert.NotNil(t, styles)
 	assert.NotNil(t, styles.Selected)
@@ -255,6 +273,15 @@ func TestGetCommonStyles(t *testing.T) {
 	assert.NotNil(t, styles.SliceDownbeat)
 	assert.NotNil(t, styles.Dir)
 	assert.NotNil(t, styles.AssignedFile)
+
+	// The color-blind palettes change the colors that carry meaning, not the rest
+	m.Palette = types.PaletteDeutan
+	deutan := getCommonStyles(m)
+	assert.NotEqual(t, styles.Playback.GetForeground(), deutan.Playback.GetForeground())
+	assert.NotEqual(t, styles.Warning.GetForeground(), deutan.Warning.GetForeground())
+	assert.Equal(t, styles.Label.GetForeground(), deutan.Label.GetForeground())
+	m.Palette = len(palettes)
+	assert.Equal(t, styles.Playback.GetForeground(), getCommonStyles(m).Playback.GetForeground())
 }
 
 func TestRenderViewWithCommonPattern(t *testing.T) {
@@ -409,8 +436,85 @@ func TestSplashState(t *testing.T) {
 	assert.LessOrEqual(t, len(lines), 30) // Should be reasonable size
 }
 
+func TestSplashStartupStages(t *testing.T) {
+	splash := NewSplashState(0) // Animation finished, so the status line is shown
+	assert.Equal(t, StageFindingSclang, splash.Stage())
+
+	splash.SetStage(StageServerBooted)
+	splash.SetStage(StageSclangFound)
+	assert.Equal(t, StageServerBooted, splash.Stage(), "Stages never go backwards")
+	assert.Contains(t, RenderSplashScreen(120, 24, splash, "test-version"), "✓ server booted")
+
+	assert.False(t, splash.CheckStalled(time.Now(), time.Minute))
+	assert.True(t, splash.CheckStalled(time.Now().Add(2*time.Minute), time.Minute))
+	assert.Contains(t, splash.Failure(), "no synthdefs loaded")
+	view := RenderSplashScreen(120, 24, splash, "test-version")
+	assert.Contains(t, view, "r: retry")
+
+	splash.Retry()
+	assert.Equal(t, "", splash.Failure())
+	assert.Equal(t, StageFindingSclang, splash.Stage())
+}
+
+func TestLoadSplashArt(t *testing.T) {
+	assert.Nil(t, LoadSplashArt(filepath.Join(t.TempDir(), "missing.txt")))
+
+	path := filepath.Join(t.TempDir(), "splash.txt")
+	art := " /\\_/\\  \r\n( o.o )\r\n\r\n\r\n" + strings.Repeat("x\n", 20)
+	assert.NoError(t, os.WriteFile(path, []byte(art), 0644))
+	lines := LoadSplashArt(path)
+	assert.Len(t, lines, maxSplashArtLines)
+	assert.Equal(t, " /\\_/\\", lines[0], "Trailing spaces and CRLF are trimmed")
+
+	assert.NoError(t, os.WriteFile(path, []byte("( o.o )\n\n"), 0644))
+	splash := NewSplashState(0)
+	splash.Art = LoadSplashArt(path)
+	assert.Equal(t, []string{"( o.o )"}, splash.Art, "Trailing blank lines are dropped")
+	view := RenderSplashScreen(80, 24, splash, "test-version")
+	assert.Contains(t, view, "( o.o )")
+}
+
+func TestRenderVisualizerView(t *testing.T) {
+	m := model.NewModel(0, t.TempDir(), false)
+	m.TermWidth, m.TermHeight = 80, 24
+	m.WaveformBuf = []float64{0, 0.5, -0.5, 0.2}
+	m.TrackWaveformBuf[0] = []float64{0.1, -0.1}
+	m.TrackVolumes[0] = -6
+
+	view := RenderVisualizerView(m)
+	lines := strings.Split(view, "\n")
+	assert.Len(t, lines, 24, "The visualizer fills the terminal")
+	assert.Contains(t, view, "T1")
+	assert.Contains(t, view, "T8")
+	assert.Contains(t, view, "BPM")
+
+	m.TermHeight = 10
+	view = RenderVisualizerView(m)
+	assert.Len(t, strings.Split(view, "\n"), 10)
+	assert.NotContains(t, view, "T1 ", "No track lanes on small terminals")
+}
+
+func TestRenderTimelineView(t *testing.T) {
+	m := model.NewModel(0, t.TempDir(), false)
+	m.TermWidth, m.TermHeight = 80, 24
+	assert.Contains(t, RenderTimelineView(m), "Song is empty")
+
+	m.BPM, m.PPQ = 120, 2
+	m.TrackTypes[0] = false
+	m.SongData[0][0] = 1
+	m.InstrumentChainsData[1][0] = 1
+	m.InstrumentPhrasesData[1][0][types.ColDeltaTime] = 8
+	m.TimelineTrack, m.TimelineRow = 0, 0
+
+	view := RenderTimelineView(m)
+	assert.Contains(t, view, "[01─")
+	assert.Contains(t, view, "|0:00")
+	assert.Contains(t, view, "T8")
+	assert.Contains(t, view, "chain 01 at 0:00.0 for 0:02.0")
+}
+
 func TestViewStylesConsistency(t *testing.T) {
-	styles := getCommonStyles()
+	styles := getCommonStyles(createTestModel())
 
 	// Test that styles are consistently defined
 	styleTests := []struct {
@@ -531,3 +635,174 @@ func BenchmarkRenderWaveform(b *testing.B) {
 		RenderWaveform(width, height, data)
 	}
 }
+
+func TestInstrumentRowSoundMakerStatus(t *testing.T) {
+	m := createTestModel()
+	m.TrackTypes[0] = false // Instrument track
+	m.CurrentTrack = 0
+	m.ViewMode = types.PhraseView
+	m.CurrentCol = int(types.InstrumentColSOMI)
+	m.SoundMakerSettings[1].Name = "PolyPerc"
+	m.SoundMakerSettings[2].Name = "DX7"
+	m.SetPhraseCell(0, 0, 0, types.ColSoundMaker, 1)
+	m.SetPhraseCell(0, 0, 1, types.ColSoundMaker, 2)
+
+	// Each row names the SoundMaker it plays, inheriting from the rows above
+	m.CurrentRow = 0
+	assert.Contains(t, GetInstrumentPhraseStatusMessage(m), "SoundMaker: 01 PolyPerc")
+	m.CurrentRow = 1
+	assert.Contains(t, GetInstrumentPhraseStatusMessage(m), "SoundMaker: 02 DX7")
+	m.CurrentRow = 2
+	assert.Contains(t, GetInstrumentPhraseStatusMessage(m), "(02 DX7, sticky)")
+}
+
+func TestRenderUsageView(t *testing.T) {
+	m := model.NewModel(0, t.TempDir(), false)
+	m.TermWidth, m.TermHeight = 80, 24
+	m.TrackTypes[0] = false
+	m.SongData[0][0] = 1
+	m.InstrumentChainsData[1][0] = 2
+	m.InstrumentPhrasesData[3][0][types.ColDeltaTime] = 1
+
+	view := RenderUsageView(m)
+	assert.Contains(t, view, "Instrument pool: 1 chains and 1 phrases used, 0 and 1 unreferenced")
+	assert.Contains(t, view, "F0")
+}
+
+func TestRenderInputView(t *testing.T) {
+	m := createTestModel()
+	m.ViewMode = types.InputView
+	m.HandleInputLevel(0, -24, time.Now())
+
+	view := RenderInputView(m)
+	assert.Contains(t, view, "Input armed, 1 clipped readings")
+	assert.Contains(t, view, "CLIP")
+	assert.Contains(t, view, "-24.0 dB")
+}
+
+func TestRenderNotesView(t *testing.T) {
+	m := createTestModel()
+	m.ViewMode = types.NotesView
+	m.SetNotesText("gear: mic, cables\n" + strings.Repeat("long ", 20))
+	m.MoveNotesCursor(1, 0)
+	m.MoveNotesToLineEnd()
+
+	view := RenderNotesView(m)
+	assert.Contains(t, view, "2 lines, 23 words")
+	assert.Contains(t, view, "gear: mic, cables")
+	assert.Contains(t, view, "Line 2, column 101")
+}
+
+func TestRenderChangelogView(t *testing.T) {
+	m := createTestModel()
+	m.ViewMode = types.ChangelogView
+	m.Version = "v1.4.0"
+	m.Releases = []update.Release{{Tag: "v1.5.0", Notes: "- Faster sampler"}, {Tag: "v1.4.0"}}
+
+	view := RenderChangelogView(m)
+	assert.Contains(t, view, "Running v1.4.0")
+	assert.Contains(t, view, "- Faster sampler")
+	assert.Contains(t, view, "v1.5.0 is available")
+	assert.Contains(t, RenderHeader(m, "Song", ""), "UPDATE v1.5.0")
+
+	m.Version = "v1.5.0"
+	assert.Contains(t, RenderChangelogView(m), "Up to date")
+	assert.NotContains(t, RenderHeader(m, "Song", ""), "UPDATE")
+}
+
+func TestHeaderWaveformDetail(t *testing.T) {
+	m := createTestModel()
+	full := RenderHeader(m, "Song", "")
+	assert.Contains(t, full, "⠀")
+
+	m.WaveformDetail = types.WaveformDetailOff
+	off := RenderHeader(m, "Song", "")
+	assert.NotContains(t, off, "⠀")
+	assert.Equal(t, strings.Count(full, "\n"), strings.Count(off, "\n"))
+
+	m.WaveformDetail = types.WaveformDetailLow
+	assert.Equal(t, strings.Count(full, "\n"), strings.Count(RenderHeader(m, "Song", ""), "\n"))
+}
+
+func TestActivityIndicator(t *testing.T) {
+	m := createTestModel()
+	assert.Empty(t, getActivityIndicator(m), "Only shown during playback")
+
+	m.IsPlaying = true
+	assert.Equal(t, "○○○○○○○○", getActivityIndicator(m))
+	m.MarkTrackTriggered(1, time.Now())
+	m.MarkTrackTriggered(7, time.Now().Add(-model.ActivityFlash))
+	assert.Equal(t, "○●○○○○○○", getActivityIndicator(m))
+	assert.Contains(t, RenderHeader(m, "Song", ""), "○●○○○○○○")
+}
+
+func TestRenderDiagnosticsView(t *testing.T) {
+	assert.Equal(t, "   ▁▄█", sparkline([]float64{0, 5, 10}, 6, 10))
+	assert.Equal(t, "▁█", sparkline([]float64{7, 0, 10}, 2, 10))
+
+	m := createTestModel()
+	m.ViewMode = types.DiagnosticsView
+	assert.Contains(t, RenderDiagnosticsView(m), "Collecting the first sample")
+	now := time.Now()
+	m.SampleDiagnostics(now)
+	m.SampleDiagnostics(now.Add(time.Second))
+	view := RenderDiagnosticsView(m)
+	assert.Contains(t, view, "Last 1 seconds")
+	assert.Contains(t, view, "Goroutines:")
+	assert.Contains(t, view, "Press m to send test triggers")
+
+	// A finished latency measurement is set against the server block
+	m.SetServerAudioInfo("scsynth", 48000, 480)
+	m.StartLatencyTest()
+	m.SendLatencyProbe(now)
+	m.HandleLatencyReply(1, 0, model.LatencyPathLanguage, now.Add(time.Millisecond))
+	assert.Contains(t, RenderDiagnosticsView(m), "Measuring latency: 1/100")
+	m.FinishLatencyTest()
+	view = RenderDiagnosticsView(m)
+	assert.Contains(t, view, "480 samples, 10.00 ms at 48000 Hz")
+	assert.Contains(t, view, "mean 1.00 ms")
+	assert.Contains(t, view, "0/1 replies")
+	assert.Contains(t, view, "Some test triggers were lost")
+}
+
+func TestPlayheads(t *testing.T) {
+	base := time.UnixMilli(0)
+	assert.True(t, playheadBlinkOn(base))
+	assert.False(t, playheadBlinkOn(base.Add(playheadBlinkPeriod)))
+
+	m := createTestModel()
+	m.TrackTypes[0], m.TrackTypes[1], m.TrackTypes[2] = false, false, true
+	m.SongData[0][0], m.SongData[1][3] = 5, 5
+	m.IsPlaying = true
+	m.PlaybackMode = types.SongView
+	m.SongPlaybackActive[0] = true
+	m.SongPlaybackChain[0], m.SongPlaybackChainRow[0], m.SongPlaybackRowInPhrase[0] = 5, 2, 0x0A
+	m.SongPlaybackActive[2] = true
+	m.SongPlaybackChain[2], m.SongPlaybackChainRow[2] = 5, 2 // Chain 5 of the other pool
+
+	// The song view shows each track's chain row and phrase row under the grid
+	rows := renderSongPlayheadRows(m, getCommonStyles(m))
+	assert.Contains(t, rows, "CR   02  --")
+	assert.Contains(t, rows, "PR   0A  --")
+
+	// The chain view marks the tracks of its pool playing each row, and those queued to start it
+	styles := getCommonStyles(m)
+	m.CurrentTrack = 1
+	assert.Equal(t, "  T1·0A", renderChainPlayheads(m, styles, 5, 2, base))
+	assert.Empty(t, renderChainPlayheads(m, styles, 5, 3, base))
+	m.SongPlaybackQueued[1], m.SongPlaybackQueuedRow[1] = 1, 3
+	assert.Equal(t, "  T2▸", renderChainPlayheads(m, styles, 5, 0, base))
+	assert.Empty(t, renderChainPlayheads(m, styles, 5, 0, base.Add(playheadBlinkPeriod)), "Queued playheads blink")
+
+	// The song view counts down the rows until queued actions run, next to the queued cell
+	m.SongPlaybackActive[2] = false
+	m.InstrumentChainsData[5][2] = 1
+	m.SongPlaybackPhrase[0] = 1
+	m.InstrumentPhrasesData[1][0x0B][types.ColDeltaTime] = 1
+	assert.Equal(t, "  T2 starts in 2", renderQueuedCountdowns(m, styles, 3))
+	assert.Empty(t, renderQueuedCountdowns(m, styles, 0))
+	m.SongPlaybackQueued[0] = -1
+	assert.Equal(t, "  T1 stops in 2", renderQueuedCountdowns(m, styles, 0))
+	m.SongPlaybackQueuedRow[0] = 3
+	assert.Equal(t, "  T1 jumps in 2 T2 starts in 2", renderQueuedCountdowns(m, styles, 3))
+}
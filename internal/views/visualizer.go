@@ -0,0 +1,93 @@
+package views
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/schollz/collidertracker/internal/model"
+)
+
+// visualizerTrackColors colors each track's lane in the visualizer
+var visualizerTrackColors = []string{"39", "45", "49", "118", "226", "214", "204", "171"}
+
+// visualizerMeterWidth is the width of each track's level meter in cells
+const visualizerMeterWidth = 12
+
+// RenderVisualizerView fills the terminal with the master waveform and per-track activity,
+// for projecting behind a performance
+func RenderVisualizerView(m *model.Model) string {
+	width, height := m.TermWidth, m.TermHeight
+	if width < 20 {
+		width = 20
+	}
+	if height < 4 {
+		height = 4
+	}
+
+	// Track lanes only when there is room for them under a decent waveform
+	lanes := 0
+	if height >= 16 {
+		lanes = len(m.TrackWaveformBuf)
+	}
+	waveHeight := height - 1 // status line
+	if lanes > 0 {
+		waveHeight -= lanes + 1 // lanes and a spacer
+	}
+
+	var content strings.Builder
+
+	// Master waveform, colored by its peak
+	data := m.WaveformBuf
+	if len(data) == 0 {
+		data = []float64{0, 0}
+	}
+	peak := 0.0
+	for _, v := range data {
+		peak = math.Max(peak, math.Abs(v))
+	}
+	waveColor := "39"
+	if peak > 0.9 {
+		waveColor = "196"
+	} else if peak > 0.5 {
+		waveColor = "207"
+	}
+	content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(waveColor)).Render(RenderWaveform(width, waveHeight, data)))
+	content.WriteString("\n")
+
+	// Track activity: a small waveform and a level meter per track
+	if lanes > 0 {
+		content.WriteString("\n")
+		laneWidth := width - 4 - visualizerMeterWidth - 1
+		for track := 0; track < lanes; track++ {
+			level := (float64(m.TrackVolumes[track]) + 60.0) / 60.0 // -60..0 dB
+			level = math.Max(0, math.Min(1, level))
+			color := visualizerTrackColors[track%len(visualizerTrackColors)]
+			if level == 0 {
+				color = "238" // Silent tracks fade into the background
+			}
+			style := lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+
+			trackData := m.TrackWaveformBuf[track]
+			if len(trackData) == 0 {
+				trackData = []float64{0, 0}
+			}
+			filled := int(math.Round(level * visualizerMeterWidth))
+			meter := strings.Repeat("█", filled) + strings.Repeat("░", visualizerMeterWidth-filled)
+			content.WriteString(style.Render(fmt.Sprintf("T%d  %s %s", track+1, RenderWaveform(laneWidth, 1, trackData), meter)))
+			content.WriteString("\n")
+		}
+	}
+
+	// Status line
+	state := "■ stopped"
+	if m.IsPlaying {
+		state = "▶ playing"
+	}
+	status := fmt.Sprintf("collidertracker · %.2f BPM · %s", m.BPM, state)
+	content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Width(width).Align(lipgloss.Center).Render(status))
+
+	return content.String()
+}
@@ -26,3 +26,11 @@ func TestBrailleSineWave(t *testing.T) {
 	// Log the waveform so it shows up in test output.
 	t.Log("\n" + out)
 }
+
+func TestGenerateWarpLabels(t *testing.T) {
+	// The selected warp marker has its own glyph as well as its own color
+	labels := generateWarpLabels(20, map[int]string{2: "1", 8: "4"}, 8, "<", ">", "|")
+	if labels != "  <△1|    >▲4|\n" {
+		t.Errorf("generateWarpLabels() = %q", labels)
+	}
+}
@@ -3,19 +3,22 @@ package views
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/schollz/gowaveform"
 
 	"github.com/schollz/collidertracker/internal/getbpm"
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/types"
 )
 
 // RenderWaveformView renders the waveform editing view for the current track's file
 func RenderWaveformView(m *model.Model) string {
-	styles := getCommonStyles()
+	styles := getCommonStyles(m)
 	
 	// Build the view
 	var content strings.Builder
@@ -24,17 +27,17 @@ func RenderWaveformView(m *model.Model) string {
 	file := m.WaveformFile
 	if file == "" {
 		// Return error message if no file
-		content.WriteString(RenderHeader(m, "Waveform View", ""))
+		content.WriteString(RenderHeader(m, i18n.T("Waveform View"), ""))
 		content.WriteString("\n")
-		content.WriteString(styles.Label.Render("No audio file for current track"))
+		content.WriteString(styles.Label.Render(i18n.T("No audio file for current track")))
 		content.WriteString("\n\n")
-		content.WriteString(styles.Label.Render("Press 'w' to return"))
+		content.WriteString(styles.Label.Render(i18n.T("Press 'w' to return")))
 		content.WriteString("\n")
 		return styles.Container.Render(content.String())
 	}
 	
 	filename := filepath.Base(file)
-	header := fmt.Sprintf("Waveform: %s", filename)
+	header := fmt.Sprintf(i18n.T("Waveform: %s"), filename)
 	
 	// Get file metadata for onsets
 	metadata, hasMetadata := m.FileMetadata[file]
@@ -64,8 +67,11 @@ func RenderWaveformView(m *model.Model) string {
 		contentHeight = 10
 	}
 	
-	// Reserve space for controls (3 lines) and info (2 lines)
-	waveformHeight := contentHeight - 5
+	// Reserve space for controls (4 lines), info (2 lines) and warp marker beats (1 line)
+	waveformHeight := contentHeight - 6
+	if len(metadata.Warp) > 0 {
+		waveformHeight--
+	}
 	if waveformHeight < 5 {
 		waveformHeight = 5
 	}
@@ -95,8 +101,8 @@ func RenderWaveformView(m *model.Model) string {
 	showPlayhead := m.PlayheadGate == 1 && m.PlayheadTrackID == m.CurrentTrack && timeSinceUpdate < 1*time.Second
 	
 	waveformStr, err := renderWaveformWithMarkers(waveformFile, waveWidth, waveformHeight, 
-		m.WaveformStart, m.WaveformEnd, metadata.Onsets, m.WaveformSelectedSlice,
-		showPlayhead, m.PlayheadPos, m.PlayheadSliceStart, m.PlayheadSliceEnd, duration)
+		m.WaveformStart, m.WaveformEnd, metadata.Onsets, m.WaveformSelectedSlice, metadata.Warp, m.WaveformSelectedWarp,
+		showPlayhead, m.PlayheadPos, m.PlayheadSliceStart, m.PlayheadSliceEnd, duration, paletteOf(m))
 	if err != nil {
 		content.WriteString(styles.Label.Render(fmt.Sprintf("Error rendering waveform: %v", err)))
 		content.WriteString("\n")
@@ -113,20 +119,44 @@ func RenderWaveformView(m *model.Model) string {
 	if m.WaveformSelectedSlice >= 0 && m.WaveformSelectedSlice < len(metadata.Onsets) {
 		content.WriteString(styles.Selected.Render(fmt.Sprintf(" | Selected: %.3fs", metadata.Onsets[m.WaveformSelectedSlice])))
 	}
+	if len(metadata.Warp) > 0 {
+		content.WriteString(styles.Label.Render(fmt.Sprintf(" | Warp: %d", len(metadata.Warp))))
+	}
+	if i := m.WaveformSelectedWarp; i >= 0 && i < len(metadata.Warp) {
+		// The tempo of the stretch up to the next marker, or from the previous one for the last
+		start, end := metadata.Warp[i].Time, duration
+		if i < len(metadata.Warp)-1 {
+			end = metadata.Warp[i+1].Time
+		} else if i > 0 {
+			start, end = metadata.Warp[i-1].Time, metadata.Warp[i].Time
+		}
+		content.WriteString(styles.Selected.Render(fmt.Sprintf(" | Warp: %.3fs = beat %g (%.1f BPM)",
+			metadata.Warp[i].Time, metadata.Warp[i].Beat, model.WarpedBPM(metadata, start, end))))
+	}
 	content.WriteString("\n")
 	
 	// Display controls
-	content.WriteString(styles.Label.Render("Controls: m (add slice) | Tab (select) | d/Backspace (delete) | Esc (unselect)"))
+	content.WriteString(styles.Label.Render(i18n.T("Controls: m (add slice) | Tab (select) | d/Backspace (delete) | Esc (unselect)")))
 	content.WriteString("\n")
-	content.WriteString(styles.Label.Render("          Space (playback) | c (play row) | ← → (jog) | Shift+← → (fast jog) | ↑ ↓ (zoom) | w (exit)"))
+	content.WriteString(styles.Label.Render("          " + i18n.T("b (add warp) | n (select warp) | [ ] { } (warp beat) | g (slice to warp grid)")))
 	content.WriteString("\n")
+	content.WriteString(styles.Label.Render("          " + i18n.T("Space (playback) | c (play row) | ← → (jog) | Shift+← → (fast jog) | ↑ ↓ (zoom) | w (exit)")))
+	content.WriteString("\n")
+	if m.PendingConfirm != nil {
+		content.WriteString(styles.Warning.Render(m.PendingConfirm.Message + " (y/n)"))
+		content.WriteString("\n")
+	} else if m.Notice != "" {
+		content.WriteString(styles.Warning.Render(m.Notice))
+		content.WriteString("\n")
+	}
 	
 	return styles.Container.Render(content.String())
 }
 
-// renderWaveformWithMarkers renders a waveform with slice markers overlaid
+// renderWaveformWithMarkers renders a waveform with slice and warp markers overlaid as lines,
+// selected ones heavier, in the colors of a palette
 func renderWaveformWithMarkers(filepath string, width, height int, start, end float64, 
-	markers []float64, selectedMarker int, showPlayhead bool, playheadPos, playheadSliceStart, playheadSliceEnd, totalDuration float64) (string, error) {
+	markers []float64, selectedMarker int, warp []types.WarpMarker, selectedWarp int, showPlayhead bool, playheadPos, playheadSliceStart, playheadSliceEnd, totalDuration float64, colors palette) (string, error) {
 	
 	// Load waveform
 	wf, err := gowaveform.LoadWaveform(filepath)
@@ -214,7 +244,7 @@ func renderWaveformWithMarkers(filepath string, width, height int, start, end fl
 	
 	// Calculate marker positions in pixels
 	markerPositions := make(map[int]bool) // x positions of all markers
-	selectedMarkerPos := -1                // x position of selected marker
+	selectedMarkerPos := -1               // x position of selected marker
 	duration := end - start
 	
 	for i, markerTime := range markers {
@@ -230,6 +260,23 @@ func renderWaveformWithMarkers(filepath string, width, height int, start, end fl
 		}
 	}
 	
+	// Calculate warp marker positions in pixels, with their beats for the label line
+	warpPositions := make(map[int]bool)
+	warpLabels := make(map[int]string)
+	selectedWarpPos := -1
+	for i, marker := range warp {
+		if marker.Time >= start && marker.Time <= end {
+			xPos := int(float64(width-1) * (marker.Time - start) / duration)
+			if xPos >= 0 && xPos < width {
+				warpPositions[xPos] = true
+				warpLabels[xPos] = fmt.Sprintf("%g", marker.Beat)
+				if i == selectedWarp {
+					selectedWarpPos = xPos
+				}
+			}
+		}
+	}
+
 	// Calculate playhead positions if playing
 	var playheadPosX int = -1
 	var playheadSliceStartX int = -1
@@ -280,14 +327,11 @@ func renderWaveformWithMarkers(filepath string, width, height int, start, end fl
 	var sb strings.Builder
 	centerY := height / 2
 	
-	// ANSI color codes
+	// ANSI color codes; markers and the playhead come from the palette
 	const (
-		colorReset  = "\033[0m"
-		colorGray   = "\033[90m" // Waveform base (dark gray)
-		colorYellow = "\033[33m" // Slice markers
-		colorCyan   = "\033[36m" // Selected marker
-		colorWhite  = "\033[97m" // Current slice region (bright white)
-		colorRed    = "\033[91m" // Playhead position (bright red)
+		colorReset = "\033[0m"
+		colorGray  = "\033[90m" // Waveform base (dark gray)
+		colorWhite = "\033[97m" // Current slice region (bright white)
 	)
 	
 	for y := 0; y < height; y++ {
@@ -305,8 +349,9 @@ func renderWaveformWithMarkers(filepath string, width, height int, start, end fl
 			// Apply color based on priority (highest priority last):
 			// 1. Waveform base (gray)
 			// 2. Current slice region (white)
-			// 3. Playhead position (red)
-			// 4. Slice markers (yellow/cyan) - ALWAYS visible on top
+			// 3. Playhead position
+			// 4. Warp markers
+			// 5. Slice markers - ALWAYS visible on top
 
 			color := colorGray // Default: gray waveform
 
@@ -319,14 +364,21 @@ func renderWaveformWithMarkers(filepath string, width, height int, start, end fl
 
 			// Check if this is the playhead position
 			if showPlayhead && x == playheadPosX {
-				color = colorRed
+				color = colors.Playhead
+			}
+
+			// Check if this is a warp marker
+			if x == selectedWarpPos {
+				color, char = colors.SelectedWarp, selectedWarpGlyph
+			} else if warpPositions[x] {
+				color, char = colors.Warp, warpGlyph
 			}
 
 			// Check if this is a slice marker (HIGHEST priority - always visible)
 			if x == selectedMarkerPos {
-				color = colorCyan
+				color, char = colors.SelectedMarker, selectedMarkerGlyph
 			} else if markerPositions[x] {
-				color = colorYellow
+				color, char = colors.Marker, markerGlyph
 			}
 			
 			sb.WriteString(color + char + colorReset)
@@ -334,12 +386,44 @@ func renderWaveformWithMarkers(filepath string, width, height int, start, end fl
 		sb.WriteString("\n")
 	}
 	
+	// Label warp markers with their beats
+	if len(warp) > 0 {
+		sb.WriteString(generateWarpLabels(width, warpLabels, selectedWarpPos, colors.Warp, colors.SelectedWarp, colorReset))
+	}
+
 	// Add timestamp ruler
 	sb.WriteString(generateTimestampRuler(width, start, end))
 	
 	return sb.String(), nil
 }
 
+// generateWarpLabels writes the beat of each visible warp marker under it
+func generateWarpLabels(width int, labels map[int]string, selectedPos int, color, selectedColor, reset string) string {
+	positions := make([]int, 0, len(labels))
+	for pos := range labels {
+		positions = append(positions, pos)
+	}
+	sort.Ints(positions)
+
+	var sb strings.Builder
+	column := 0 // Columns written so far
+	for _, pos := range positions {
+		glyph, labelColor := warpLabelGlyph, color
+		if pos == selectedPos {
+			glyph, labelColor = selectedWarpLabelGlyph, selectedColor
+		}
+		label := glyph + labels[pos]
+		if (column > 0 && pos <= column) || pos+utf8.RuneCountInString(label) > width {
+			continue // Would touch the previous label or run off the edge
+		}
+		sb.WriteString(strings.Repeat(" ", pos-column))
+		sb.WriteString(labelColor + label + reset)
+		column = pos + utf8.RuneCountInString(label)
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
 // getUpperHalfChar returns block character for upper half of waveform
 // Uses upper blocks (measuring down from top of character cell)
 func getUpperHalfChar(grid [][]bool, x, y, segmentsPerChar int) string {
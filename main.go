@@ -1,12 +1,18 @@
 package main
 
 import (
+	"errors"
 	"fmt"
-	"io"
 	"log"
+	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"reflect"
+	"runtime/debug"
 	"runtime/pprof"
+	"slices"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -14,13 +20,20 @@ import (
 	"github.com/hypebeast/go-osc/osc"
 	"github.com/spf13/cobra"
 
+	"github.com/schollz/collidertracker/internal/demo"
 	"github.com/schollz/collidertracker/internal/hacks"
+	"github.com/schollz/collidertracker/internal/i18n"
 	"github.com/schollz/collidertracker/internal/input"
+	"github.com/schollz/collidertracker/internal/logging"
 	"github.com/schollz/collidertracker/internal/midiconnector"
+	"github.com/schollz/collidertracker/internal/midiplayer"
 	"github.com/schollz/collidertracker/internal/model"
 	"github.com/schollz/collidertracker/internal/project"
+	"github.com/schollz/collidertracker/internal/setup"
+	"github.com/schollz/collidertracker/internal/simulate"
 	"github.com/schollz/collidertracker/internal/storage"
 	"github.com/schollz/collidertracker/internal/supercollider"
+	"github.com/schollz/collidertracker/internal/termcast"
 	"github.com/schollz/collidertracker/internal/types"
 	"github.com/schollz/collidertracker/internal/views"
 )
@@ -35,14 +48,39 @@ var (
 		projectProvided bool // Track if --project flag was explicitly provided
 		record          bool
 		debug           string
+		logLevel        string // Level spec of the log subsystems, like "warn,playback=debug" (empty for info)
 		skipSC          bool
 		vim             bool
 		dump            string // Path to file for periodic terminal dumps
+		recordTerminal  string // Path to an asciinema .cast file recording every frame (empty disables)
+		supernova       bool   // Boot supernova instead of scsynth
+		extensions      bool   // Open the extension manager even when all extensions are in place
+		dev             string // Folder of .scd sources to hot-reload SynthDefs from (empty disables)
+		startAt         string // Countdown or time of day to start the song at (empty disables)
+		locale          string // Interface language (empty follows the environment)
+		checkUpdates    bool   // Look for a newer release at launch (config file and Settings only)
+		projectDir      string // Folder new projects are created in (empty for the working directory)
+		midiDevice      string // MIDI device instruments default to (empty for the first one found)
+		setup           bool   // Run the setup wizard even when a config file exists
+		firstRun        bool   // No config file was found, so the setup wizard runs
+		exportDemo      string // Write a demo of the project to this .zip or binary and exit (empty disables)
+		demoSamples     bool   // Include the samples and impulse responses in the exported demo
+		demo            string // Demo binary or .zip to open and play (empty disables)
+		playDemo        bool   // The project is an opened demo, so the song plays once SuperCollider is ready
+
+		oscEngines []types.OSCEngine // External engines instrument tracks can play (config file only)
+		sampleDirs []string          // Folders the sample library indexes (config file and File Browser only)
 	}
 )
 
 type scReadyMsg struct{}
 
+// handOverMsg tells a project opened while the previous one plays to take over playback
+type handOverMsg struct{}
+
+// oscPortMsg tells the tracker SuperCollider was started on another port than the negotiated one
+type oscPortMsg struct{ port int }
+
 // DumpTickMsg triggers periodic dumps to file
 type DumpTickMsg struct{}
 
@@ -70,16 +108,52 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&config.record, "record", "r", false,
 		"Enable automatic session recording")
 	rootCmd.PersistentFlags().StringVarP(&config.debug, "log", "l", "",
-		"Write debug logs to specified file (empty disables)")
+		"Write JSON logs to specified file (empty disables)")
+	rootCmd.PersistentFlags().StringVar(&config.logLevel, "log-level", "",
+		"Log levels: one for all subsystems (osc, midi, playback, storage, ui), then single ones, e.g. warn,playback=debug")
 	rootCmd.PersistentFlags().BoolVarP(&config.skipSC, "skip-sc", "s", false,
 		"Skip SuperCollider detection and management entirely")
 	rootCmd.PersistentFlags().BoolVar(&config.vim, "vim", false,
 		"Enable vim-style cursor movement (h/j/k/l)")
 	rootCmd.PersistentFlags().StringVarP(&config.dump, "dump", "d", "",
 		"Write terminal frames to specified file every 10 seconds (empty disables)")
+	rootCmd.PersistentFlags().StringVar(&config.recordTerminal, "record-terminal", "",
+		"Record the terminal to an asciinema v2 .cast file for replay and sharing (empty disables)")
+	rootCmd.PersistentFlags().BoolVar(&config.supernova, "supernova", false,
+		"Boot the multicore supernova server instead of scsynth")
+	rootCmd.PersistentFlags().BoolVar(&config.extensions, "extensions", false,
+		"Open the SuperCollider extension manager before starting")
+	rootCmd.PersistentFlags().StringVar(&config.dev, "dev", "",
+		"Developer mode: hot-reload changed SynthDefs from .scd files in this folder and the project's synths folder")
+	rootCmd.PersistentFlags().StringVar(&config.startAt, "start-at", "",
+		"Start the song from the top after a countdown (90s) or at a time of day (21:30 or 21:30:15)")
+	rootCmd.PersistentFlags().StringVar(&config.locale, "locale", "",
+		"Interface language, e.g. es (default follows LANG)")
+	rootCmd.PersistentFlags().BoolVar(&config.setup, "setup", false,
+		"Run the setup wizard (it runs on its own when there is no config file)")
+	rootCmd.PersistentFlags().StringVar(&config.exportDemo, "export-demo", "",
+		"Export the --project as a demo that plays on start: a .zip for --demo, or else a self-playing binary")
+	rootCmd.PersistentFlags().BoolVar(&config.demoSamples, "demo-samples", false,
+		"Include the samples and impulse responses in the exported demo")
+	rootCmd.PersistentFlags().StringVar(&config.demo, "demo", "",
+		"Open a demo (.zip or demo binary) and play it from the top")
 
 	// Set up a callback to track when --project is explicitly provided
 	rootCmd.PersistentFlags().Lookup("project").Changed = false
+
+	simulateCmd.Flags().IntVar(&simulateConfig.ticks, "ticks", 96,
+		"Ticks of the playback clock to run after the one playback starts on")
+	simulateCmd.Flags().StringVar(&simulateConfig.from, "from", "song",
+		"Where to start playback, like Space in that view: song, chain or phrase")
+	simulateCmd.Flags().IntVar(&simulateConfig.track, "track", 0,
+		"Track whose chain or phrase plays (chain and phrase playback)")
+	simulateCmd.Flags().IntVar(&simulateConfig.chain, "chain", 0,
+		"Chain to play (chain playback)")
+	simulateCmd.Flags().IntVar(&simulateConfig.phrase, "phrase", 0,
+		"Phrase to play (phrase playback)")
+	simulateCmd.Flags().IntVar(&simulateConfig.row, "row", 0,
+		"Row to start on: of the song, the chain or the phrase")
+	rootCmd.AddCommand(simulateCmd)
 }
 
 func main() {
@@ -89,7 +163,6 @@ func main() {
 	}
 }
 
-
 // checkAndUpdatePortIfNeeded checks if SuperCollider detected a different port
 // and updates the OSC client if necessary
 func checkAndUpdatePortIfNeeded(tm *TrackerModel) {
@@ -97,65 +170,524 @@ func checkAndUpdatePortIfNeeded(tm *TrackerModel) {
 	// ColliderTracker sends to 57120, listens on 57121
 	// SuperCollider listens on 57120, sends to 57121
 	/*
-	// Wait a moment for SuperCollider to output its port information
-	time.Sleep(2 * time.Second)
-	// Check if SuperCollider detected a different port
-	if detectedPort := supercollider.GetDetectedPort(); detectedPort > 0 && detectedPort != config.port {
-		log.Printf("SuperCollider started on port %d (expected %d), updating OSC configuration", detectedPort, config.port)
-		tm.model.UpdateOSCPort(detectedPort)
-	}
+		// Wait a moment for SuperCollider to output its port information
+		time.Sleep(2 * time.Second)
+		// Check if SuperCollider detected a different port
+		if detectedPort := supercollider.GetDetectedPort(); detectedPort > 0 && detectedPort != config.port {
+			logging.OSC.Debugf("SuperCollider started on port %d (expected %d), updating OSC configuration", detectedPort, config.port)
+			tm.model.UpdateOSCPort(detectedPort)
+		}
 	*/
 }
 
-func restartWithProject() {
-	// This function restarts the ColliderTracker with the new project
-	// without going through cobra command parsing again
+// negotiateOSCPorts moves config.port to a free port pair when the requested ports are taken.
+// It returns a notice for the status line, or "" when the requested ports are used.
+func negotiateOSCPorts() string {
+	willStartSC := !config.skipSC && !supercollider.IsSuperColliderEnabled()
+	port, changed, err := supercollider.NegotiateOSCPorts(config.port, willStartSC)
+	if err != nil {
+		logging.OSC.Warnf("OSC port negotiation failed: %v", err)
+		return fmt.Sprintf("OSC ports %d/%d are in use and no free pair was found", config.port, config.port+1)
+	}
+	if !changed {
+		return ""
+	}
+	notice := fmt.Sprintf("OSC ports %d/%d in use, using %d/%d", config.port, config.port+1, port, port+1)
+	logging.OSC.Warnf("%s", notice)
+	config.port = port
+	return notice
+}
 
-	// Check JACK and SuperCollider requirements (same as in runColliderTracker)
+// followOSCPort sends to and listens for the SuperCollider started on port, when the negotiated
+// port was taken by the time it started
+func followOSCPort(tm *TrackerModel, port int) {
+	notice := fmt.Sprintf("OSC ports %d/%d in use, using %d/%d", config.port, config.port+1, port, port+1)
+	logging.OSC.Warnf("%s", notice)
+	config.port = port
+	tm.model.UpdateOSCPort(port)
+	startOSCServer(tm, port)
+	tm.model.Notice = notice
+}
 
-	// Check for required SuperCollider extensions before starting
-	if !supercollider.HasRequiredExtensions() {
-		dialog := supercollider.NewInstallDialogModel()
-		p := tea.NewProgram(dialog, tea.WithAltScreen())
+// prepareSessionRecording points --record at a timestamped WAV in the project's recordings folder
+func prepareSessionRecording() {
+	if !config.record {
+		return
+	}
+	dir, err := filepath.Abs(filepath.Join(config.project, model.RecordingsFolderName))
+	if err == nil {
+		err = os.MkdirAll(dir, 0755)
+	}
+	if err != nil {
+		logging.OSC.Errorf("Error preparing recordings folder, using SuperCollider's default: %v", err)
+		return
+	}
+	name := fmt.Sprintf("session-%s.wav", time.Now().Format("2006-01-02-15-04-05"))
+	supercollider.SetSessionRecordingPath(filepath.Join(dir, name))
+}
 
-		finalModel, err := p.Run()
-		if err != nil {
-			log.Printf("Error running install dialog: %v", err)
+// applyConfigFile takes the startup options not given on the command line from the config file
+func applyConfigFile(cmd *cobra.Command) {
+	path := storage.ConfigPath()
+	if path == "" {
+		return
+	}
+	cfg := appConfig()
+	if err := storage.LoadConfig(path, &cfg); err != nil {
+		if os.IsNotExist(err) {
+			config.firstRun = true
+		} else {
+			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", path, err)
+		}
+		return
+	}
+	flags := cmd.PersistentFlags()
+	if !flags.Changed("port") && cfg.Port > 0 {
+		config.port = cfg.Port
+	}
+	if !flags.Changed("record") {
+		config.record = cfg.Record
+	}
+	if !flags.Changed("vim") {
+		config.vim = cfg.Vim
+	}
+	if !flags.Changed("dump") {
+		config.dump = cfg.Dump
+	}
+	if !flags.Changed("skip-sc") {
+		config.skipSC = cfg.SkipSC
+	}
+	if !flags.Changed("locale") {
+		config.locale = cfg.Locale
+	}
+	if !flags.Changed("log-level") {
+		config.logLevel = cfg.LogLevel
+	}
+	config.checkUpdates = cfg.CheckUpdates
+	config.projectDir, config.midiDevice = cfg.ProjectDir, cfg.MidiDevice
+	config.oscEngines = cfg.OSCEngines
+	config.sampleDirs = cfg.SampleDirs
+}
+
+// runSetup shows the setup wizard on the first launch (or with --setup) and saves the options
+// chosen to the config file. Quitting the wizard quits ColliderTracker.
+func runSetup() {
+	path := storage.ConfigPath()
+	if path == "" || (!config.firstRun && !config.setup) {
+		return
+	}
+	cfg, ok := setup.Run(appConfig())
+	if !ok {
+		os.Exit(0)
+	}
+	config.skipSC, config.vim = cfg.SkipSC, cfg.Vim
+	config.projectDir, config.midiDevice = cfg.ProjectDir, cfg.MidiDevice
+	if config.projectDir != "" {
+		if err := os.MkdirAll(config.projectDir, 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating %s: %v\n", config.projectDir, err)
+		}
+	}
+	if err := storage.SaveConfig(path, appConfig()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving %s: %v\n", path, err)
+	}
+	config.firstRun = false
+}
+
+// newProjectPath places a new project named in the project selector in the projects folder
+func newProjectPath(name string) string {
+	if config.projectDir == "" || filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(config.projectDir, name)
+}
+
+// defaultMidiDevice picks the MIDI device instruments start on: the one chosen in setup when
+// it is connected (or "None"), otherwise the first one found
+func defaultMidiDevice(devices []string, preferred string) string {
+	if preferred == "None" || slices.Contains(devices, preferred) {
+		return preferred
+	}
+	if len(devices) > 0 {
+		return devices[0]
+	}
+	return ""
+}
+
+// openLog writes the log to the --log file as JSON lines, at the --log-level levels. Without
+// a file the latest lines are only kept for bug reports.
+func openLog() {
+	if config.debug != "" {
+		if err := logging.Open(config.debug); err != nil {
+			fmt.Fprintf(os.Stderr, "Error opening log file %s: %v\n", config.debug, err)
 			os.Exit(1)
 		}
+	}
+	// Lines still written with the standard log package join their subsystem's records
+	log.SetOutput(logging.StandardWriter())
+	log.SetFlags(0)
+	applyLogLevel(config.logLevel)
+}
+
+// applyLogLevel sets the levels of the log subsystems, keeping the levels in effect when the
+// spec is not valid
+func applyLogLevel(spec string) {
+	if err := logging.SetLevels(spec); err != nil {
+		logging.UI.Warn("Log levels not changed", "error", err)
+	}
+}
+
+// applyLocale switches the interface language, following the environment when none is chosen
+func applyLocale(name string) {
+	if name == "" {
+		name = i18n.DetectLocale()
+	}
+	if !i18n.SetLocale(name) && name != "" {
+		logging.UI.Debugf("No translation for locale %q, using English", name)
+	}
+}
+
+// appConfig returns the startup options in effect
+func appConfig() types.AppConfig {
+	return types.AppConfig{
+		Port:   config.port,
+		Record: config.record,
+		Vim:    config.vim,
+		Dump:   config.dump,
+		SkipSC: config.skipSC,
+		Locale: config.locale,
+
+		LogLevel: config.logLevel,
+
+		CheckUpdates: config.checkUpdates,
+
+		ProjectDir: config.projectDir,
+		MidiDevice: config.midiDevice,
+
+		OSCEngines: config.oscEngines,
+
+		SampleDirs: config.sampleDirs,
+	}
+}
+
+// watchConfig applies startup options edited in the Settings view while running
+func watchConfig(tm *TrackerModel) {
+	tm.model.Config = appConfig()
+	tm.config = tm.model.Config
+	tm.model.Subscribe(func(e model.Event) {
+		if e.Kind == model.EventSettings {
+			tm.applyConfig()
+		}
+	})
+}
+
+// applyConfig saves edited startup options to the config file and applies the ones that can
+// change while running. Vim mode is applied by the Settings view itself; Record and SC take
+// effect on the next launch.
+func (tm *TrackerModel) applyConfig() {
+	cfg := tm.model.Config
+	if reflect.DeepEqual(cfg, tm.config) {
+		return
+	}
+	if path := storage.ConfigPath(); path != "" {
+		if err := storage.SaveConfig(path, cfg); err != nil {
+			logging.Storage.Errorf("Error saving config: %v", err)
+		}
+	}
+
+	if cfg.Port != tm.config.Port {
+		supercollider.SetOSCPort(cfg.Port)
+		tm.model.UpdateOSCPort(cfg.Port)
+		startOSCServer(tm, cfg.Port)
+		tm.model.SendOSCListenerPortMessage()
+		if tm.startSC != nil && supercollider.IsOwnSuperColliderRunning() {
+			// Our sclang is bound to the old port: restart it once the port stops changing
+			if tm.scRestart != nil {
+				tm.scRestart.Stop()
+			}
+			tm.scRestart = time.AfterFunc(time.Second, func() {
+				logging.OSC.Debugf("Restarting SuperCollider on port %d", supercollider.GetOSCPort())
+				supercollider.StopOwnSuperCollider()
+				tm.startSC()
+			})
+		}
+	}
+	if cfg.Dump != tm.config.Dump {
+		closeDumpFile(tm)
+		openDumpFile(tm, cfg.Dump)
+	}
+	if cfg.Locale != tm.config.Locale {
+		applyLocale(cfg.Locale)
+	}
+	if cfg.LogLevel != tm.config.LogLevel {
+		applyLogLevel(cfg.LogLevel)
+	}
+
+	// Keep the options for a return to the project selector
+	config.port, config.record, config.vim, config.dump, config.skipSC = cfg.Port, cfg.Record, cfg.Vim, cfg.Dump, cfg.SkipSC
+	config.locale, config.checkUpdates, config.logLevel = cfg.Locale, cfg.CheckUpdates, cfg.LogLevel
+	config.projectDir, config.midiDevice = cfg.ProjectDir, cfg.MidiDevice
+	config.oscEngines, config.sampleDirs = cfg.OSCEngines, cfg.SampleDirs
+	tm.config = cfg
+}
+
+// startOSCServer listens for SuperCollider on port+1, closing the previous listener
+func startOSCServer(tm *TrackerModel, port int) {
+	if tm.oscConn != nil {
+		tm.oscConn.Close()
+		tm.oscConn = nil
+	}
+	conn, err := net.ListenPacket("udp", fmt.Sprintf(":%d", port+1))
+	if err != nil {
+		logging.OSC.Errorf("Error starting OSC server: %v", err)
+		return
+	}
+	tm.oscConn = conn
+	server := &osc.Server{Dispatcher: tm.dispatcher}
+	go func() {
+		logging.OSC.Debugf("Starting OSC server on port %d", port+1)
+		if err := server.Serve(conn); err != nil && !errors.Is(err, net.ErrClosed) {
+			logging.OSC.Debugf("OSC server stopped: %v", err)
+		}
+	}()
+}
+
+// newProgram makes the program that runs the interface of a tracker
+func newProgram(tm *TrackerModel) *tea.Program {
+	p := tea.NewProgram(tm, tea.WithAltScreen())
+	tm.program.Store(p)
+	return p
+}
+
+// send hands a message to the program running the interface
+func (tm *TrackerModel) send(msg tea.Msg) {
+	tm.program.Load().Send(msg)
+}
+
+// runTUI runs the interface of a tracker. A panic that Update and View could not recover
+// from, such as one in a command, ends the program; the interface then starts again on the
+// same model, so SuperCollider and playback go on.
+func runTUI(tm *TrackerModel, p *tea.Program) (tea.Model, error) {
+	for {
+		finalModel, err := p.Run()
+		if !errors.Is(err, tea.ErrProgramPanic) {
+			return finalModel, err
+		}
+		input.RecoverUI(tm.model, "a command", err, nil)
+		p = newProgram(tm)
+	}
+}
+
+// openDumpFile starts writing terminal frames to path every 10 seconds ("" disables)
+func openDumpFile(tm *TrackerModel, path string) {
+	if path == "" {
+		return
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		logging.UI.Errorf("Error opening dump file %s: %v", path, err)
+		return
+	}
+	tm.dumpFile = f
+	tm.lastDumpTime = time.Now()
+	logging.UI.Debugf("Terminal dump enabled: writing to %s every 10 seconds", path)
+}
+
+// closeDumpFile stops terminal dumps
+func closeDumpFile(tm *TrackerModel) {
+	if tm.dumpFile == nil {
+		return
+	}
+	if err := tm.dumpFile.Close(); err != nil {
+		logging.UI.Errorf("Error closing dump file: %v", err)
+	}
+	tm.dumpFile = nil
+}
+
+// runExtensionManager shows the extension manager when a required extension is missing or
+// outdated (or always, when forced) and exits unless the user continues with all of them in place
+func runExtensionManager(force bool) {
+	if !force && supercollider.HasRequiredExtensions() {
+		return
+	}
+	dialog := supercollider.NewInstallDialogModel()
+	p := tea.NewProgram(dialog, tea.WithAltScreen())
+
+	finalModel, err := p.Run()
+	if err != nil {
+		logging.UI.Errorf("Error running install dialog: %v", err)
+		os.Exit(1)
+	}
+
+	result, ok := finalModel.(supercollider.InstallDialogModel)
+	if !ok {
+		logging.UI.Debugf("Unexpected model type returned from install dialog")
+		os.Exit(1)
+	}
+	if !result.ShouldContinue() {
+		if result.Error() != nil {
+			logging.OSC.Errorf("Failed to install SuperCollider extensions: %v", result.Error())
+		}
+		os.Exit(1)
+	}
+}
+
+// watchSynthDefs polls the developer's .scd sources and the project's synths folder and
+// hot-reloads changed SynthDefs into the running server, re-sending preferences afterwards
+func watchSynthDefs(m *model.Model, sourceDir string) {
+	logging.UI.Debugf("Developer mode: watching %s and %s for SynthDef changes", sourceDir, m.SynthsFolder())
+	watcher := supercollider.NewSynthDefWatcher(sourceDir, m.SynthsFolder())
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !m.HasOSCLink() {
+			continue // Changes are picked up once SuperCollider is reachable
+		}
+		changed := watcher.Scan()
+		for _, block := range changed {
+			m.SendOSCSynthDefReloadMessage(block.Name, block.Code)
+		}
+		if len(changed) > 0 {
+			m.SendAllPreferences()
+		}
+	}
+}
+
+// startupStages maps the stages SuperCollider reports with /startup to splash screen stages
+var startupStages = map[string]views.StartupStage{
+	"booted":    views.StageServerBooted,
+	"synthdefs": views.StageSynthDefsLoaded,
+	"buffers":   views.StageBuffersReady,
+}
+
+// startupStageTimeout is how long the splash screen waits for the next startup stage
+const startupStageTimeout = 30 * time.Second
+
+// startSuperColliderInBackground finds a running ColliderTracker SuperCollider or starts one,
+// reporting on the splash screen when sclang is found or why it could not be started
+func startSuperColliderInBackground(tm *TrackerModel, readyChannel chan struct{}) {
+	go func() {
+		// First, quickly check if sclang process is running
+		if !supercollider.IsSuperColliderEnabled() {
+			// No sclang process found - start SuperCollider immediately
+			logging.OSC.Debugf("No sclang process found, starting SuperCollider")
+			if err := supercollider.StartSuperColliderWithRecording(config.record); err != nil {
+				logging.OSC.Errorf("Failed to start SuperCollider: %v", err)
+				tm.splashState.Fail(fmt.Sprintf("Failed to start SuperCollider: %v", err))
+				return
+			}
+			tm.splashState.SetStage(views.StageSclangFound)
+			checkAndUpdatePortIfNeeded(tm)
+			return
+		}
 
-		if result, ok := finalModel.(supercollider.InstallDialogModel); ok {
-			if !result.ShouldInstall() {
-				os.Exit(1)
+		// sclang is running - wait briefly to see if it has ColliderTracker loaded
+		logging.UI.Debugf("Found sclang process, checking if ColliderTracker is loaded...")
+		tm.splashState.SetStage(views.StageSclangFound)
+		timeout := time.NewTimer(1 * time.Second)
+		defer timeout.Stop()
+
+		select {
+		case <-readyChannel:
+			// SuperCollider with ColliderTracker is already running
+			logging.OSC.Debugf("Found existing SuperCollider instance with ColliderTracker")
+			// Hand the signal on to the splash screen
+			select {
+			case readyChannel <- struct{}{}:
+			default:
 			}
-			if result.Error() != nil {
-				log.Printf("Failed to install SuperCollider extensions: %v", result.Error())
-				os.Exit(1)
+			return
+		case <-timeout.C:
+			// sclang is running but no ColliderTracker - start new instance on a free port
+			logging.UI.Debugf("sclang running but no ColliderTracker detected, starting new instance on free port")
+			port, err := supercollider.StartSuperColliderOnFreePort(config.record)
+			if err != nil {
+				logging.OSC.Errorf("Failed to start SuperCollider on free port: %v", err)
+				tm.splashState.Fail(fmt.Sprintf("Failed to start SuperCollider on a free port: %v", err))
+				return
 			}
-		} else {
-			log.Printf("Unexpected model type returned from install dialog")
-			os.Exit(1)
+			if port != config.port {
+				tm.send(oscPortMsg{port: port})
+			}
+			checkAndUpdatePortIfNeeded(tm)
 		}
+	}()
+}
+
+// returnToProjectSelector runs the project selector after a tracker closed with Ctrl+O and opens
+// the chosen project. A reachable SuperCollider keeps running in between, with the closed
+// project playing on until the new one has loaded its samples and takes over, so the music
+// does not stop; a session take still ends with its project and restarts it. It returns false
+// when the selector is cancelled.
+func returnToProjectSelector(previous *TrackerModel) bool {
+	logging.UI.Debugf("Returning to project selection...")
+	if previous.model.HasOSCLink() && !previous.model.SessionRecording {
+		leaveProject(previous)
+	} else {
+		// Clean up current session
+		supercollider.Cleanup()
+		finishSessionTake(previous)
+		previous = nil
 	}
 
-	// Set up debug logging early
-	if config.debug != "" {
-		f, err := tea.LogToFile(config.debug, "debug")
-		if err != nil {
-			log.Printf("Fatal: %v", err)
-			os.Exit(1)
+	selectedPath, cancelled, isNewProject := project.RunProjectSelector()
+	if cancelled {
+		if previous != nil {
+			stopDetached(previous)
 		}
-		defer f.Close()
-		log.SetOutput(f)
-		// Set log flags to include file and line number for VS Code clickable links
-		log.SetFlags(log.LstdFlags | log.Lshortfile)
+		return false
+	}
+	if isNewProject {
+		// User chose to create new project with provided name
+		config.project = newProjectPath(selectedPath)
 	} else {
-		// send log to io.Discard
-		log.SetOutput(io.Discard)
+		// User selected an existing project
+		config.project = selectedPath
 	}
+	config.projectProvided = true // Mark as provided to skip selector
+	// Restart the main function logic
+	restartWithProject(previous)
+	return true
+}
 
-	log.Println("Debug logging enabled")
-	log.Printf("OSC port configured: %d", config.port)
+// leaveProject keeps a closed tracker playing without its interface and stops listening for
+// SuperCollider, which keeps running for the next project
+func leaveProject(tm *TrackerModel) {
+	tm.detached = input.PlayDetached(tm.model)
+	if tm.oscConn != nil {
+		tm.oscConn.Close()
+		tm.oscConn = nil
+	}
+}
+
+// stopDetached stops a closed tracker that kept playing, when no project takes over from it
+func stopDetached(tm *TrackerModel) {
+	tm.detached()
+	input.StopForExit(tm.model)
+	midiplayer.AllNotesOff()
+}
+
+// restartWithProject starts the tracker again on config.project without going through cobra
+// command parsing again. previous is the tracker that closed, when SuperCollider was kept
+// running for this project, and nil when SuperCollider has to be found or started again.
+func restartWithProject(previous *TrackerModel) {
+	// Check JACK and SuperCollider requirements (same as in runColliderTracker)
+
+	// Check for required SuperCollider extensions before starting
+	runExtensionManager(false) // The manager was offered on the first start
+
+	// Set up logging early
+	openLog()
+	defer logging.Close()
+
+	logging.UI.Info("Tracker started", "version", Version, "levels", config.logLevel)
+	logging.OSC.Debugf("OSC port configured: %d", config.port)
+	portNotice := ""
+	if previous == nil {
+		// The kept SuperCollider still talks on the ports of the previous project
+		portNotice = negotiateOSCPorts()
+	}
+	prepareSessionRecording()
+	supercollider.SetSupernova(config.supernova)
 
 	// Create readiness channel for SuperCollider startup detection
 	readyChannel := make(chan struct{}, 1)
@@ -166,25 +698,24 @@ func restartWithProject() {
 	var initialPreferencesSent = false
 
 	d.AddMsgHandler("/cpuusage", func(msg *osc.Message) {
-		log.Printf("SuperCollider CPU Usage: %v", msg.Arguments[0])
+		logging.OSC.Debug("SuperCollider CPU usage", "cpu", msg.Arguments[0])
+
+		if tm != nil {
+			usage, _ := msg.Arguments[0].(float32)
+			if len(msg.Arguments) > 1 {
+				peak, _ := msg.Arguments[1].(float32)
+				tm.model.HandleCPUPeak(peak)
+			}
+			if tm.model.HandleCPUUsage(usage) {
+				// Telemetry came back after a gap: SC hung or restarted, re-handshake
+				tm.model.Reconnect()
+			}
+		}
 
 		// Send initial preferences on first CPU message (when SC is confirmed ready)
 		if !initialPreferencesSent && tm != nil {
-			log.Printf("Sending initial preferences to SuperCollider")
-			tm.model.SendOSCPregainMessage()
-			tm.model.SendOSCPostgainMessage()
-			tm.model.SendOSCBiasMessage()
-			tm.model.SendOSCSaturationMessage()
-			tm.model.SendOSCDriveMessage()
-			tm.model.SendOSCInputLevelMessage()
-			tm.model.SendOSCReverbSendMessage()
-			tm.model.SendOSCTapeMessage()
-			tm.model.SendOSCShimmerMessage()
-
-			// Send track set levels too
-			for track := 0; track < 8; track++ {
-				tm.model.SendOSCTrackSetLevelMessage(track)
-			}
+			logging.OSC.Debugf("Sending initial preferences to SuperCollider")
+			tm.model.SendAllPreferences()
 			initialPreferencesSent = true
 		}
 
@@ -195,6 +726,35 @@ func restartWithProject() {
 		}
 	})
 
+	d.AddMsgHandler("/server_info", func(msg *osc.Message) {
+		if tm != nil && len(msg.Arguments) >= 2 {
+			sampleRate, _ := msg.Arguments[0].(int32)
+			blockSize, _ := msg.Arguments[1].(int32)
+			program := "scsynth"
+			if len(msg.Arguments) >= 3 {
+				program, _ = msg.Arguments[2].(string)
+			}
+			logging.OSC.Debugf("SuperCollider server: %s, %d Hz, block size %d", program, sampleRate, blockSize)
+			tm.model.SetServerAudioInfo(program, int(sampleRate), int(blockSize))
+		}
+	})
+
+	d.AddMsgHandler("/synthdef_reloaded", func(msg *osc.Message) {
+		if tm != nil && len(msg.Arguments) >= 2 {
+			name, _ := msg.Arguments[0].(string)
+			ok, _ := msg.Arguments[1].(int32)
+			tm.model.HandleSynthDefReloaded(name, ok == 1)
+		}
+	})
+
+	d.AddMsgHandler("/startup", func(msg *osc.Message) {
+		if tm != nil && len(msg.Arguments) > 0 {
+			stage, _ := msg.Arguments[0].(string)
+			logging.OSC.Debugf("SuperCollider startup: %s", stage)
+			tm.splashState.SetStage(startupStages[stage])
+		}
+	})
+
 	d.AddMsgHandler("/track_volume", func(msg *osc.Message) {
 		if tm != nil {
 			for i := 0; i < len(tm.model.TrackVolumes); i++ {
@@ -202,113 +762,182 @@ func restartWithProject() {
 			}
 		}
 	})
+	d.AddMsgHandler("/preloaded", func(msg *osc.Message) {
+		if tm != nil {
+			tm.send(handOverMsg{})
+		}
+	})
 	// Build program
 	tm = initialModel(config.port, config.project, config.vim, d, config.dump)
+	tm.model.Notice = portNotice
+	if previous != nil {
+		// No splash: the new project's samples load while the previous one plays on
+		tm.showingSplash = false
+		tm.previous = previous
+	}
+	if config.dev != "" {
+		go watchSynthDefs(tm.model, config.dev)
+	}
+	tm.dispatcher = d
+	watchConfig(tm)
+	activeModel.Store(tm.model)
+	if config.record && !config.skipSC {
+		tm.model.SessionRecording = true
+		tm.model.SessionRecordingFile = supercollider.GetSessionRecordingPath()
+	}
 
 	// Close dump file when function exits
-	if tm.dumpFile != nil {
-		defer func() {
-			if err := tm.dumpFile.Close(); err != nil {
-				log.Printf("Error closing dump file: %v", err)
-			}
-		}()
-	}
+	defer closeDumpFile(tm)
+	defer openTerminalRecording(tm, config.recordTerminal)()
 
-	p := tea.NewProgram(tm, tea.WithAltScreen())
+	p := newProgram(tm)
 
 	// Start OSC server after p is created but before p.Run()
-	server := &osc.Server{Addr: fmt.Sprintf(":%d", config.port+1), Dispatcher: d}
-	go func() {
-		log.Printf("Starting OSC server on port %d", config.port+1)
-		if err := server.ListenAndServe(); err != nil {
-			log.Printf("Error starting OSC server: %v", err)
-		}
-	}()
-
+	startOSCServer(tm, config.port)
+	if previous != nil {
+		tm.model.TakeOverServer() // Replies with /preloaded to the server just started
+	}
 
 	// Fast SuperCollider detection and startup
 	if !config.skipSC {
-		go func() {
-			// First, quickly check if sclang process is running
-			if !supercollider.IsSuperColliderEnabled() {
-				// No sclang process found - start SuperCollider immediately
-				log.Printf("No sclang process found, starting SuperCollider")
-				if err := supercollider.StartSuperColliderWithRecording(config.record); err != nil {
-					log.Printf("Failed to start SuperCollider: %v", err)
-				}
-			checkAndUpdatePortIfNeeded(tm)
-				return
-			}
-
-			// sclang is running - wait briefly to see if it has ColliderTracker loaded
-			log.Printf("Found sclang process, checking if ColliderTracker is loaded...")
-			timeout := time.NewTimer(1 * time.Second)
-			defer timeout.Stop()
-
-			select {
-			case <-readyChannel:
-				// SuperCollider with ColliderTracker is already running
-				log.Printf("Found existing SuperCollider instance with ColliderTracker")
-				return
-			case <-timeout.C:
-				// sclang is running but no ColliderTracker - start new instance on a free port
-				log.Printf("sclang running but no ColliderTracker detected, starting new instance on free port")
-				if err := supercollider.StartSuperColliderOnFreePort(config.record); err != nil {
-					log.Printf("Failed to start SuperCollider on free port: %v", err)
-				}
-			checkAndUpdatePortIfNeeded(tm)
-			}
-		}()
+		tm.startSC = func() { startSuperColliderInBackground(tm, readyChannel) }
+		if previous == nil {
+			tm.startSC()
+		}
 	} else {
-		log.Printf("Skipping SuperCollider detection and management entirely (--skip-sc flag provided)")
+		logging.OSC.Debugf("Skipping SuperCollider detection and management entirely (--skip-sc flag provided)")
 	}
 
 	// When SC signals readiness via /cpuusage, hide the splash
 	go func() {
-		if config.skipSC {
-			p.Send(scReadyMsg{}) // skip splash if skipping SC management
+		if config.skipSC || previous != nil {
+			tm.send(scReadyMsg{}) // skip splash if skipping SC management or SC kept running
 		} else {
 			<-readyChannel
-			log.Printf("Received SuperCollider ready; hiding splash")
-			p.Send(scReadyMsg{})
+			logging.OSC.Debugf("Received SuperCollider ready; hiding splash")
+			tm.send(scReadyMsg{})
 		}
 	}()
 
 	// hack to make sure Ctrl+V works on Windows
 	hacks.StoreWinClipboard()
 
-	finalModel, err := p.Run()
+	finalModel, err := runTUI(tm, p)
 	if err != nil {
-		log.Printf("Error: %v", err)
+		logging.UI.Errorf("Error: %v", err)
+	}
+	if tm.previous != nil {
+		stopDetached(tm.previous) // Closed before taking over playback
+		tm.previous = nil
 	}
 
-	// Check if we should return to project selection again (recursive)
-	if finalModel != nil {
-		if trackerModel, ok := finalModel.(*TrackerModel); ok && trackerModel.model.ReturnToProjectSelector {
-			log.Printf("Returning to project selection...")
-			// Clean up current session
-			supercollider.Cleanup()
-
-			// Run project selector again
-			selectedPath, cancelled, isNewProject := project.RunProjectSelector()
-			if !cancelled {
-				if isNewProject {
-					// User chose to create new project with provided name
-					config.project = selectedPath
-				} else {
-					// User selected an existing project
-					config.project = selectedPath
-				}
-				config.projectProvided = true // Mark as provided to skip selector
-				// Restart the main function logic
-				restartWithProject()
-				return
-			}
+	// Ctrl+O returns to the project selector, which opens the chosen project
+	if trackerModel, ok := finalModel.(*TrackerModel); ok && trackerModel.model.ReturnToProjectSelector {
+		if returnToProjectSelector(trackerModel) {
+			return
 		}
 	}
 
 	// Always call cleanup when the program exits normally (e.g., Ctrl+Q)
 	supercollider.Cleanup()
+	finishSessionTake(finalModel)
+	storage.CloseProjects()
+}
+
+// exportDemo writes a demo of the --project and exits
+func exportDemo(cmd *cobra.Command) {
+	if !cmd.PersistentFlags().Changed("project") {
+		fmt.Fprintln(os.Stderr, "--export-demo needs the --project to export")
+		os.Exit(1)
+	}
+	if err := demo.Export(config.project, config.exportDemo, config.demoSamples, Version); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote a demo of %s to %s\n", config.project, config.exportDemo)
+	os.Exit(0)
+}
+
+// simulateConfig holds the options of the simulate command
+var simulateConfig struct {
+	ticks  int
+	from   string
+	track  int
+	chain  int
+	phrase int
+	row    int
+}
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Print the messages the --project plays, without SuperCollider or MIDI devices",
+	Long: `Simulate plays the --project on a simulated clock and prints every OSC and MIDI
+message playback sends, one per line: tick, seconds since playback started,
+destination (sc, engine:<name> or midi:<device>), address and arguments.
+The same project always prints the same lines, so runs can be diffed.`,
+	Args: cobra.NoArgs,
+	Run:  runSimulate,
+}
+
+// runSimulate prints the messages the --project plays and exits
+func runSimulate(cmd *cobra.Command, args []string) {
+	views := map[string]types.ViewMode{"song": types.SongView, "chain": types.ChainView, "phrase": types.PhraseView}
+	mode, ok := views[simulateConfig.from]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "--from must be song, chain or phrase, not %q\n", simulateConfig.from)
+		os.Exit(1)
+	}
+	applyConfigFile(cmd.Root())
+	openLog()
+	defer logging.Close()
+
+	m := model.NewModel(0, config.project, false)
+	m.Config = appConfig()
+	if storage.IsProjectLocked(config.project) {
+		unlock := func(password string) error { return storage.UnlockProject(m, config.project, password) }
+		if !project.RunPasswordPrompt(filepath.Base(config.project), unlock) {
+			fmt.Fprintf(os.Stderr, "%s is password protected\n", config.project)
+			os.Exit(1)
+		}
+	}
+	if err := storage.LoadState(m, 0, config.project); err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot load %s: %v\n", config.project, err)
+		os.Exit(1)
+	}
+
+	start := simulate.Start{
+		Mode:   mode,
+		Track:  simulateConfig.track,
+		Chain:  simulateConfig.chain,
+		Phrase: simulateConfig.phrase,
+		Row:    simulateConfig.row,
+	}
+	for _, event := range simulate.Run(m, start, simulateConfig.ticks) {
+		fmt.Println(event)
+	}
+}
+
+// openDemo unpacks the demo given with --demo, or the one this binary carries, and opens its
+// project in place of the --project
+func openDemo() {
+	path := config.demo
+	if path == "" {
+		if !demo.Embedded() {
+			return
+		}
+		exe, err := os.Executable()
+		if err != nil {
+			return
+		}
+		path = exe
+	}
+	dir, manifest, err := demo.Open(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	logging.UI.Debugf("Opened demo of %s (made with %s) in %s", manifest.Project, manifest.Version, dir)
+	config.project, config.projectProvided, config.playDemo = dir, true, true
 }
 
 func runColliderTracker(cmd *cobra.Command, args []string) {
@@ -329,9 +958,35 @@ func runColliderTracker(cmd *cobra.Command, args []string) {
 	// Set up cleanup on exit
 	setupCleanupOnExit()
 
+	// Options not given on the command line come from the config file
+	applyConfigFile(cmd)
+	applyLocale(config.locale)
+
+	// Exporting a demo needs no interface
+	if config.exportDemo != "" {
+		exportDemo(cmd)
+	}
+
+	// The first launch walks through the setup
+	runSetup()
+	project.ProjectsDir = config.projectDir
+
+	// A timed start counts from launch
+	var startAt time.Time
+	if config.startAt != "" {
+		startAt, err = model.ParseStartTime(config.startAt, time.Now())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
 	// Check if --project flag was explicitly provided
 	config.projectProvided = cmd.PersistentFlags().Changed("project")
 
+	// A demo brings its own project
+	openDemo()
+
 	// If no project was specified, show project selector
 	if !config.projectProvided {
 		selectedPath, cancelled, isNewProject := project.RunProjectSelector()
@@ -341,7 +996,7 @@ func runColliderTracker(cmd *cobra.Command, args []string) {
 
 		if isNewProject {
 			// User chose to create new project with provided name
-			config.project = selectedPath
+			config.project = newProjectPath(selectedPath)
 		} else {
 			// User selected an existing project
 			config.project = selectedPath
@@ -349,48 +1004,17 @@ func runColliderTracker(cmd *cobra.Command, args []string) {
 	}
 
 	// Check for required SuperCollider extensions before starting
-	if !supercollider.HasRequiredExtensions() {
-		dialog := supercollider.NewInstallDialogModel()
-		p := tea.NewProgram(dialog, tea.WithAltScreen())
+	runExtensionManager(config.extensions)
 
-		finalModel, err := p.Run()
-		if err != nil {
-			log.Printf("Error running install dialog: %v", err)
-			os.Exit(1)
-		}
-
-		if result, ok := finalModel.(supercollider.InstallDialogModel); ok {
-			if !result.ShouldInstall() {
-				os.Exit(1)
-			}
-			if result.Error() != nil {
-				log.Printf("Failed to install SuperCollider extensions: %v", result.Error())
-				os.Exit(1)
-			}
-		} else {
-			log.Printf("Unexpected model type returned from install dialog")
-			os.Exit(1)
-		}
-	}
-
-	// Set up debug logging early
-	if config.debug != "" {
-		f, err := tea.LogToFile(config.debug, "debug")
-		if err != nil {
-			log.Printf("Fatal: %v", err)
-			os.Exit(1)
-		}
-		defer f.Close()
-		log.SetOutput(f)
-		// Set log flags to include file and line number for VS Code clickable links
-		log.SetFlags(log.LstdFlags | log.Lshortfile)
-	} else {
-		// send log to io.Discard
-		log.SetOutput(io.Discard)
-	}
+	// Set up logging early
+	openLog()
+	defer logging.Close()
 
-	log.Println("Debug logging enabled")
-	log.Printf("OSC port configured: %d", config.port)
+	logging.UI.Info("Tracker started", "version", Version, "levels", config.logLevel)
+	logging.OSC.Debugf("OSC port configured: %d", config.port)
+	portNotice := negotiateOSCPorts()
+	prepareSessionRecording()
+	supercollider.SetSupernova(config.supernova)
 
 	// Create readiness channel for SuperCollider startup detection
 	readyChannel := make(chan struct{}, 1)
@@ -401,25 +1025,24 @@ func runColliderTracker(cmd *cobra.Command, args []string) {
 	var initialPreferencesSent = false
 
 	d.AddMsgHandler("/cpuusage", func(msg *osc.Message) {
-		log.Printf("SuperCollider CPU Usage: %v", msg.Arguments[0])
+		logging.OSC.Debug("SuperCollider CPU usage", "cpu", msg.Arguments[0])
+
+		if tm != nil {
+			usage, _ := msg.Arguments[0].(float32)
+			if len(msg.Arguments) > 1 {
+				peak, _ := msg.Arguments[1].(float32)
+				tm.model.HandleCPUPeak(peak)
+			}
+			if tm.model.HandleCPUUsage(usage) {
+				// Telemetry came back after a gap: SC hung or restarted, re-handshake
+				tm.model.Reconnect()
+			}
+		}
 
 		// Send initial preferences on first CPU message (when SC is confirmed ready)
 		if !initialPreferencesSent && tm != nil {
-			log.Printf("Sending initial preferences to SuperCollider")
-			tm.model.SendOSCPregainMessage()
-			tm.model.SendOSCPostgainMessage()
-			tm.model.SendOSCBiasMessage()
-			tm.model.SendOSCSaturationMessage()
-			tm.model.SendOSCDriveMessage()
-			tm.model.SendOSCInputLevelMessage()
-			tm.model.SendOSCReverbSendMessage()
-			tm.model.SendOSCTapeMessage()
-			tm.model.SendOSCShimmerMessage()
-
-			// Send track set levels too
-			for track := 0; track < 8; track++ {
-				tm.model.SendOSCTrackSetLevelMessage(track)
-			}
+			logging.OSC.Debugf("Sending initial preferences to SuperCollider")
+			tm.model.SendAllPreferences()
 			initialPreferencesSent = true
 		}
 
@@ -437,7 +1060,7 @@ func runColliderTracker(cmd *cobra.Command, args []string) {
 			pos := float64(msg.Arguments[2].(float32))
 			sliceStart := float64(msg.Arguments[3].(float32))
 			sliceEnd := float64(msg.Arguments[4].(float32))
-			log.Printf("Track %d playhead: gate=%d pos=%.2f sliceStart=%.2f sliceEnd=%.2f", trackID, gate, pos, sliceStart, sliceEnd)
+			logging.OSC.Debug("Sampler playhead", "track", trackID, "gate", gate, "pos", pos, "sliceStart", sliceStart, "sliceEnd", sliceEnd)
 			// Update model with playhead data
 			tm.model.PlayheadTrackID = trackID
 			tm.model.PlayheadGate = gate
@@ -448,6 +1071,35 @@ func runColliderTracker(cmd *cobra.Command, args []string) {
 		}
 	})
 
+	d.AddMsgHandler("/server_info", func(msg *osc.Message) {
+		if tm != nil && len(msg.Arguments) >= 2 {
+			sampleRate, _ := msg.Arguments[0].(int32)
+			blockSize, _ := msg.Arguments[1].(int32)
+			program := "scsynth"
+			if len(msg.Arguments) >= 3 {
+				program, _ = msg.Arguments[2].(string)
+			}
+			logging.OSC.Debugf("SuperCollider server: %s, %d Hz, block size %d", program, sampleRate, blockSize)
+			tm.model.SetServerAudioInfo(program, int(sampleRate), int(blockSize))
+		}
+	})
+
+	d.AddMsgHandler("/synthdef_reloaded", func(msg *osc.Message) {
+		if tm != nil && len(msg.Arguments) >= 2 {
+			name, _ := msg.Arguments[0].(string)
+			ok, _ := msg.Arguments[1].(int32)
+			tm.model.HandleSynthDefReloaded(name, ok == 1)
+		}
+	})
+
+	d.AddMsgHandler("/startup", func(msg *osc.Message) {
+		if tm != nil && len(msg.Arguments) > 0 {
+			stage, _ := msg.Arguments[0].(string)
+			logging.OSC.Debugf("SuperCollider startup: %s", stage)
+			tm.splashState.SetStage(startupStages[stage])
+		}
+	})
+
 	d.AddMsgHandler("/track_volume", func(msg *osc.Message) {
 		if tm != nil {
 			for i := 0; i < len(tm.model.TrackVolumes); i++ {
@@ -457,127 +1109,103 @@ func runColliderTracker(cmd *cobra.Command, args []string) {
 	})
 	// Build program
 	tm = initialModel(config.port, config.project, config.vim, d, config.dump)
+	tm.model.Notice = portNotice
+	if !startAt.IsZero() {
+		tm.model.ArmTimedStart(startAt)
+	}
+	tm.model.DemoStart = config.playDemo
+	if config.dev != "" {
+		go watchSynthDefs(tm.model, config.dev)
+	}
+	tm.dispatcher = d
+	watchConfig(tm)
+	activeModel.Store(tm.model)
+	if config.record && !config.skipSC {
+		tm.model.SessionRecording = true
+		tm.model.SessionRecordingFile = supercollider.GetSessionRecordingPath()
+	}
 
 	// Close dump file when function exits
-	if tm.dumpFile != nil {
-		defer func() {
-			if err := tm.dumpFile.Close(); err != nil {
-				log.Printf("Error closing dump file: %v", err)
-			}
-		}()
-	}
+	defer closeDumpFile(tm)
+	defer openTerminalRecording(tm, config.recordTerminal)()
 
-	p := tea.NewProgram(tm, tea.WithAltScreen())
+	p := newProgram(tm)
 
 	// Start OSC server after p is created but before p.Run()
-	server := &osc.Server{Addr: fmt.Sprintf(":%d", config.port+1), Dispatcher: d}
-	go func() {
-		log.Printf("Starting OSC server on port %d", config.port+1)
-		if err := server.ListenAndServe(); err != nil {
-			log.Printf("Error starting OSC server: %v", err)
-		}
-	}()
+	startOSCServer(tm, config.port)
 
 	// Fast SuperCollider detection and startup
 	if !config.skipSC {
-		go func() {
-			// First, quickly check if sclang process is running
-			if !supercollider.IsSuperColliderEnabled() {
-				// No sclang process found - start SuperCollider immediately
-				log.Printf("No sclang process found, starting SuperCollider")
-				if err := supercollider.StartSuperColliderWithRecording(config.record); err != nil {
-					log.Printf("Failed to start SuperCollider: %v", err)
-				}
-			checkAndUpdatePortIfNeeded(tm)
-				return
-			}
-
-			// sclang is running - wait briefly to see if it has ColliderTracker loaded
-			log.Printf("Found sclang process, checking if ColliderTracker is loaded...")
-			timeout := time.NewTimer(1 * time.Second)
-			defer timeout.Stop()
-
-			select {
-			case <-readyChannel:
-				// SuperCollider with ColliderTracker is already running
-				log.Printf("Found existing SuperCollider instance with ColliderTracker")
-				return
-			case <-timeout.C:
-				// sclang is running but no ColliderTracker - start new instance on a free port
-				log.Printf("sclang running but no ColliderTracker detected, starting new instance on free port")
-				if err := supercollider.StartSuperColliderOnFreePort(config.record); err != nil {
-					log.Printf("Failed to start SuperCollider on free port: %v", err)
-				}
-			checkAndUpdatePortIfNeeded(tm)
-			}
-		}()
+		tm.startSC = func() { startSuperColliderInBackground(tm, readyChannel) }
+		tm.startSC()
 	} else {
-		log.Printf("Skipping SuperCollider detection and management entirely (--skip-sc flag provided)")
+		logging.OSC.Debugf("Skipping SuperCollider detection and management entirely (--skip-sc flag provided)")
 	}
 
 	// When SC signals readiness via /cpuusage, hide the splash
 	go func() {
 		if config.skipSC {
-			p.Send(scReadyMsg{}) // skip splash if skipping SC management
+			tm.send(scReadyMsg{}) // skip splash if skipping SC management
 		} else {
 			<-readyChannel
-			log.Printf("Received SuperCollider ready; hiding splash")
-			p.Send(scReadyMsg{})
+			logging.OSC.Debugf("Received SuperCollider ready; hiding splash")
+			tm.send(scReadyMsg{})
 		}
 	}()
 
 	// hack to make sure Ctrl+V works on Windows
 	hacks.StoreWinClipboard()
 
-	finalModel, err := p.Run()
+	finalModel, err := runTUI(tm, p)
 	if err != nil {
-		log.Printf("Error: %v", err)
+		logging.UI.Errorf("Error: %v", err)
 	}
 
-	// Check if we should return to project selection
-	if finalModel != nil {
-		if trackerModel, ok := finalModel.(*TrackerModel); ok && trackerModel.model.ReturnToProjectSelector {
-			log.Printf("Returning to project selection...")
-			// Clean up current session
-			supercollider.Cleanup()
-
-			// Run project selector again
-			selectedPath, cancelled, isNewProject := project.RunProjectSelector()
-			if !cancelled {
-				if isNewProject {
-					// User chose to create new project with provided name
-					config.project = selectedPath
-				} else {
-					// User selected an existing project
-					config.project = selectedPath
-				}
-				config.projectProvided = true // Mark as provided to skip selector
-				// Restart the main function logic
-				restartWithProject()
-				return
-			}
+	// Ctrl+O returns to the project selector, which opens the chosen project
+	if trackerModel, ok := finalModel.(*TrackerModel); ok && trackerModel.model.ReturnToProjectSelector {
+		if returnToProjectSelector(trackerModel) {
+			return
 		}
 	}
 
 	// Always call cleanup when the program exits normally (e.g., Ctrl+Q)
 	supercollider.Cleanup()
+	finishSessionTake(finalModel)
+	storage.CloseProjects()
 }
 
 func initialModel(oscPort int, saveFolder string, vimMode bool, dispatcher *osc.StandardDispatcher, dumpPath string) *TrackerModel {
 	m := model.NewModel(oscPort, saveFolder, vimMode)
+	m.Version = Version
+
+	// A password-protected project needs its password; without it nothing is loaded, so an
+	// empty project never autosaves over it
+	if storage.IsProjectLocked(saveFolder) {
+		unlock := func(password string) error { return storage.UnlockProject(m, saveFolder, password) }
+		if !project.RunPasswordPrompt(filepath.Base(saveFolder), unlock) {
+			fmt.Fprintf(os.Stderr, "%s is password protected\n", saveFolder)
+			supercollider.Cleanup()
+			os.Exit(1)
+		}
+	}
 
 	// Try to load saved state
 	if err := storage.LoadState(m, oscPort, saveFolder); err == nil {
-		log.Printf("Loaded saved state successfully from %s", saveFolder)
+		logging.Storage.Debugf("Loaded saved state successfully from %s", saveFolder)
 	} else {
-		log.Printf("No saved state found or error loading from %s: %v", saveFolder, err)
+		logging.Storage.Warnf("No saved state found or error loading from %s: %v", saveFolder, err)
 		// Load files for new model
 		storage.LoadFiles(m)
 	}
+	storage.AutoSaveOnChange(m)
 
 	// Note: Preference OSC messages are now sent when first CPU message is received
 	// to ensure SuperCollider is ready to receive them
 
+	// Count every message from SuperCollider for the diagnostics view
+	dispatcher.AddMsgHandler("*", func(msg *osc.Message) {
+		m.CountOSCReceived()
+	})
 	// Add waveform handler to the existing OSC dispatcher
 	dispatcher.AddMsgHandler("/waveform", func(msg *osc.Message) {
 		sample := float64(msg.Arguments[0].(float32)) // expected in [-1,+1]
@@ -601,56 +1229,117 @@ func initialModel(oscPort int, saveFolder string, vimMode bool, dispatcher *osc.
 			m.PushTrackWaveformSample(i, float64(msg.Arguments[i].(float32)), maxCols)
 		}
 	})
+	// Add input pitch handler for pitch tracking note entry
+	dispatcher.AddMsgHandler("/input_pitch", func(msg *osc.Message) {
+		if len(msg.Arguments) < 3 {
+			return
+		}
+		freq, _ := msg.Arguments[0].(float32)
+		confidence, _ := msg.Arguments[1].(float32)
+		amp, _ := msg.Arguments[2].(float32)
+		m.HandleInputPitch(float64(freq), float64(confidence), float64(amp))
+	})
+
+	dispatcher.AddMsgHandler("/input_level", func(msg *osc.Message) {
+		if len(msg.Arguments) < 2 {
+			return
+		}
+		left, _ := msg.Arguments[0].(float32)
+		right, _ := msg.Arguments[1].(float32)
+		m.HandleInputLevel(left, right, time.Now())
+	})
+
+	dispatcher.AddMsgHandler("/latency_reply", func(msg *osc.Message) {
+		now := time.Now()
+		if len(msg.Arguments) < 3 {
+			return
+		}
+		run, _ := msg.Arguments[0].(int32)
+		probe, _ := msg.Arguments[1].(int32)
+		path, _ := msg.Arguments[2].(int32)
+		m.HandleLatencyReply(int(run), int(probe), int(path), now)
+	})
 
 	m.AvailableMidiDevices = midiconnector.Devices()
 	for _, device := range m.AvailableMidiDevices {
-		log.Printf("MIDI device found: %+v", device)
+		logging.MIDI.Debugf("MIDI device found: %+v", device)
 	}
 
-	// Set default MIDI device to first available device (only for unset devices)
-	if len(m.AvailableMidiDevices) > 0 {
-		firstDevice := m.AvailableMidiDevices[0]
+	// Set the default MIDI device (chosen in setup, or the first available one) for unset devices
+	if device := defaultMidiDevice(m.AvailableMidiDevices, config.midiDevice); device != "" && device != "None" {
 		// Only update MIDI settings that are still set to "None" (preserve user selections)
 		for i := 0; i < 255; i++ {
 			if m.MidiSettings[i].Device == "None" {
-				m.MidiSettings[i].Device = firstDevice
+				m.MidiSettings[i].Device = device
 				// Channel is already set to "1" by default in initializeDefaultData()
 			}
 		}
-		log.Printf("Default MIDI device set to: %s (for unset devices only)", firstDevice)
+		logging.MIDI.Debugf("Default MIDI device set to: %s (for unset devices only)", device)
 	}
 
+	splashDuration := 36 * time.Second / 10 // 3.6 seconds (20% slower)
+	if m.SplashMode == types.SplashModeShort {
+		splashDuration = time.Second
+	}
+	if !m.Animations {
+		splashDuration = 0 // Show the settled splash screen straight away
+	}
 	tm := &TrackerModel{
 		model:         m,
-		splashState:   views.NewSplashState(36 * time.Second / 10), // 3.6 seconds (20% slower)
-		showingSplash: true,                                        // splash is ALWAYS shown until SC ready
+		splashState:   views.NewSplashState(splashDuration),
+		showingSplash: m.SplashMode != types.SplashModeOff, // when shown, the splash stays until SC is ready
+		program:       new(atomic.Pointer[tea.Program]),
 	}
+	tm.splashState.Art = views.LoadSplashArt(views.SplashArtPath())
 
 	// Open dump file if path is provided
-	if dumpPath != "" {
-		f, err := os.Create(dumpPath)
-		if err != nil {
-			log.Printf("Error opening dump file %s: %v", dumpPath, err)
-		} else {
-			tm.dumpFile = f
-			tm.lastDumpTime = time.Now()
-			log.Printf("Terminal dump enabled: writing to %s every 10 seconds", dumpPath)
-		}
-	}
+	openDumpFile(tm, dumpPath)
 
 	return tm
 }
 
+// openTerminalRecording starts recording every frame to an asciinema .cast file at path,
+// returning the function that closes it
+func openTerminalRecording(tm *TrackerModel, path string) func() {
+	if path == "" {
+		return func() {}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		logging.UI.Errorf("Error opening terminal recording %s: %v", path, err)
+		return func() {}
+	}
+	tm.castFile = f
+	tm.cast = termcast.NewRecorder(f, "collidertracker "+Version)
+	logging.UI.Debugf("Terminal recording enabled: writing to %s", path)
+	return func() {
+		if err := tm.castFile.Close(); err != nil {
+			logging.UI.Errorf("Error closing terminal recording: %v", err)
+		}
+	}
+}
+
 // TrackerModel wraps the model and implements the tea.Model interface
 type TrackerModel struct {
 	model         *model.Model
 	splashState   *views.SplashState
 	showingSplash bool
+	startSC       func()      // Starts SuperCollider again after a failed startup (nil with --skip-sc)
+	scRestart     *time.Timer // Pending SuperCollider restart after a port change
+	dispatcher    *osc.StandardDispatcher
+	oscConn       net.PacketConn  // Connection the OSC server listens on (port+1)
+	config        types.AppConfig // Startup options currently applied
 	dumpFile      *os.File
 	lastDumpTime  time.Time
+	castFile      *os.File                     // Terminal recording file (--record-terminal)
+	cast          *termcast.Recorder           // Writes each changed frame to castFile
+	lastLinkProbe time.Time                    // Last time the listener port was re-sent while the OSC link was lost
+	program       *atomic.Pointer[tea.Program] // Program running the interface, replaced when it restarts
+	previous      *TrackerModel                // Closed project still playing until this one takes over
+	detached      func()                       // Stops playback going on after this tracker closed
 }
 
-// WaveformTickMsg is a special message that fires at a steady UI rate (30fps)
+// WaveformTickMsg is a special message that fires at a steady UI rate (Model.FrameRate)
 // to refresh/redraw waveform and UI without advancing playback.
 type WaveformTickMsg struct{}
 
@@ -668,9 +1357,14 @@ func tickWaveform(fps int) tea.Cmd {
 	})
 }
 
-// tickSplash schedules the next SplashTickMsg for smooth animation
-func tickSplash() tea.Cmd {
-	return tea.Tick(16*time.Millisecond, func(time.Time) tea.Msg {
+// tickSplash schedules the next SplashTickMsg for smooth animation, or at the idle rate
+// while animations are off
+func tickSplash(animate bool) tea.Cmd {
+	interval := 16 * time.Millisecond
+	if !animate {
+		interval = time.Second / model.IdleFrameRate
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
 		return SplashTickMsg{}
 	})
 }
@@ -686,23 +1380,67 @@ func (tm *TrackerModel) Init() tea.Cmd {
 	cmds := []tea.Cmd{}
 	
 	if tm.showingSplash {
-		// Start splash screen animation at 60fps
-		cmds = append(cmds, tickSplash())
+		// Start splash screen animation at 60fps (idle rate without animations)
+		cmds = append(cmds, tickSplash(tm.model.Animations))
 	} else {
-		// Start a 30fps UI loop so the waveform redraws smoothly.
+		// Start the UI loop at the view's frame rate so the waveform redraws smoothly.
 		// Playback advancement stays on its own schedule (input.TickMsg).
-		cmds = append(cmds, tickWaveform(30))
+		cmds = append(cmds, tickWaveform(tm.model.FrameRate()))
 	}
 	
-	// Start dump ticker if dump file is enabled
-	if tm.dumpFile != nil {
-		cmds = append(cmds, tickDump())
+	// Start dump ticker; it writes while a dump file is open (Dump can be turned on in Settings)
+	cmds = append(cmds, tickDump())
+
+	// Playback goes on when the interface restarts after a crash
+	if tm.model.IsPlaying {
+		cmds = append(cmds, input.Tick(tm.model))
+	}
+
+	// Count down to a --start-at start
+	if cmd := input.ScheduleTimedStart(tm.model, time.Now()); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+
+	// Look for a newer release when update checks are turned on
+	if tm.model.Config.CheckUpdates {
+		cmds = append(cmds, input.CheckForUpdates(tm.model))
 	}
 	
 	return tea.Batch(cmds...)
 }
 
-func (tm *TrackerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+// Update handles a message, recovering from a panic in its handling so the interface and
+// playback go on
+func (tm *TrackerModel) Update(msg tea.Msg) (next tea.Model, cmd tea.Cmd) {
+	defer func() {
+		if r := recover(); r != nil {
+			input.RecoverUI(tm.model, fmt.Sprintf("Update(%T)", msg), r, debug.Stack())
+			next, cmd = tm, tm.rearm(msg)
+		}
+	}()
+	return tm.update(msg)
+}
+
+// rearm schedules the next tick of the loop a message belonged to, which stopped when its
+// handling panicked
+func (tm *TrackerModel) rearm(msg tea.Msg) tea.Cmd {
+	switch msg.(type) {
+	case SplashTickMsg:
+		return tickSplash(tm.model.Animations)
+	case WaveformTickMsg:
+		return tickWaveform(tm.model.FrameRate())
+	case DumpTickMsg:
+		return tickDump()
+	case input.TickMsg:
+		if tm.model.IsPlaying {
+			return input.Tick(tm.model)
+		}
+	}
+	return nil
+}
+
+// update handles a message
+func (tm *TrackerModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		tm.model.TermHeight = msg.Height
@@ -716,36 +1454,114 @@ func (tm *TrackerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case SplashTickMsg:
 		// Keep animating the splash; do NOT auto-dismiss on duration.
 		// We'll exit the splash only on scReadyMsg or a keypress.
-		return tm, tickSplash()
+		if tm.startSC != nil && tm.splashState.CheckStalled(time.Now(), startupStageTimeout) {
+			logging.OSC.Debugf("SuperCollider startup stalled after %s", tm.splashState.Stage())
+		}
+		return tm, tickSplash(tm.model.Animations)
 
 	case WaveformTickMsg:
-		// Redraw UI/waveform at 30fps. Do NOT advance playback here.
+		// Redraw UI/waveform at the view's frame rate. Do NOT advance playback here.
 		// Reschedule the next UI tick.
 		if tm.showingSplash {
 			return tm, nil
 		}
-		return tm, tickWaveform(30)
+		// Watch the OSC link so a silent SuperCollider shows up in the header
+		now := time.Now()
+		if tm.model.CheckOSCLink(now) && now.Sub(tm.lastLinkProbe) > time.Second {
+			// Keep telling SC where to reply until telemetry comes back
+			tm.model.SendOSCListenerPortMessage()
+			tm.lastLinkProbe = now
+		}
+		// Enter notes detected by pitch tracking on the UI goroutine
+		tm.model.EnterTrackedNotes()
+		tm.model.SampleDiagnostics(now)
+		return tm, tickWaveform(tm.model.FrameRate())
 
 	case input.TickMsg:
 		// Tempo/engine ticks: only advance playback here, at your musical rate.
 		if tm.model.IsPlaying {
-			// Always call AdvancePlayback:
+			// Always advance playback:
 			// - Song mode: decrements ticksLeft counter
 			// - Phrase/Chain mode: advances to next row
-			// Note: We start with count=1 after emitting the initial row (which represents tick 0)
-			input.AdvancePlayback(tm.model)
-			// Increment tick count AFTER processing the current tick
-			tm.model.PlaybackTickCount++
+			// A finished loop bounce stops playback and records its tail
+			if cmd := input.StepPlayback(tm.model); cmd != nil {
+				return tm, cmd
+			}
 			// Reschedule the next tempo tick according to your input package.
 			return tm, input.Tick(tm.model)
 		}
 		return tm, nil
 
+	case input.BounceTailDoneMsg:
+		return tm, input.HandleBounceTailDone(tm.model)
+
+	case input.LoudnessDoneMsg:
+		input.HandleLoudnessDone(tm.model, msg)
+		return tm, nil
+
+	case input.SessionTakeDoneMsg:
+		input.HandleSessionTakeDone(tm.model, msg)
+		return tm, nil
+
+	case input.AnalysisDoneMsg:
+		return tm, input.HandleAnalysisDone(tm.model, msg)
+
+	case input.LibraryListedMsg:
+		return tm, input.HandleLibraryListed(tm.model, msg)
+
+	case input.LibraryAnalyzedMsg:
+		return tm, input.HandleLibraryAnalyzed(tm.model, msg)
+
+	case input.ReportDoneMsg:
+		input.HandleReportDone(tm.model, msg)
+		return tm, nil
+
+	case input.TimedStartMsg:
+		return tm, input.HandleTimedStart(tm.model, msg)
+
+	case input.LatencyProbeMsg:
+		return tm, input.HandleLatencyProbe(tm.model)
+
+	case input.UpdateCheckMsg:
+		input.HandleUpdateCheck(tm.model, msg)
+		return tm, nil
+
+	case input.UpdateInstallMsg:
+		input.HandleUpdateInstall(tm.model, msg)
+		return tm, nil
+
+	case input.LatencyDoneMsg:
+		tm.model.FinishLatencyTest()
+		return tm, nil
+
 	case scReadyMsg:
-		// SC is ready — leave the splash screen
-		tm.showingSplash = false
+		if tm.previous != nil {
+			// The previous project plays until this one's samples are loaded; SuperCollider
+			// reports them with /preloaded, and one that does not is given a while
+			return tm, tea.Tick(model.HandOverTimeout, func(time.Time) tea.Msg { return handOverMsg{} })
+		}
+		// SC is ready — leave the splash screen and start the UI loop
+		// (which also watches the OSC link), picking up playback where the project was saved
+		resume := tea.Batch(input.StartDemo(tm.model), input.ResumePlayback(tm.model))
+		if tm.showingSplash {
+			tm.showingSplash = false
+			return tm, tea.Batch(tickWaveform(tm.model.FrameRate()), resume)
+		}
+		return tm, resume
+
+	case oscPortMsg:
+		followOSCPort(tm, msg.port)
 		return tm, nil
 
+	case handOverMsg:
+		if tm.previous == nil {
+			return tm, nil // Already taken over
+		}
+		previous := tm.previous
+		tm.previous = nil
+		logging.Playback.Debugf("Taking over playback from %s", previous.model.SaveFolder)
+		return tm, input.HandOver(tm.model, previous.model, previous.detached)
+
 	case DumpTickMsg:
 		// Write current view to dump file
 		if tm.dumpFile != nil {
@@ -759,10 +1575,28 @@ func (tm *TrackerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return tm, tickDump()
 
 	case tea.KeyMsg:
+		// A failed startup can be retried, skipped or quit
+		if tm.showingSplash && tm.splashState.Failure() != "" {
+			switch msg.String() {
+			case "r":
+				logging.OSC.Debugf("Retrying SuperCollider startup")
+				tm.splashState.Retry()
+				go func() {
+					supercollider.StopOwnSuperCollider()
+					tm.startSC()
+				}()
+			case "s":
+				tm.showingSplash = false
+				return tm, tickWaveform(tm.model.FrameRate())
+			case "q", "ctrl+c", "ctrl+q":
+				return tm, tea.Quit
+			}
+			return tm, nil
+		}
 		// Skip splash screen on any key press
 		if tm.showingSplash {
 			tm.showingSplash = false
-			return tm, tickWaveform(30)
+			return tm, tickWaveform(tm.model.FrameRate())
 		}
 		// Keys may toggle playback, change views, etc.
 		return tm, input.HandleKeyInput(tm.model, msg)
@@ -772,6 +1606,29 @@ func (tm *TrackerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (tm TrackerModel) View() string {
+	view := tm.safeRender()
+	if tm.cast != nil {
+		if err := tm.cast.Frame(view, tm.model.TermWidth, tm.model.TermHeight, time.Now()); err != nil {
+			logging.UI.Errorf("Error recording terminal frame: %v", err)
+		}
+	}
+	return view
+}
+
+// safeRender draws like render, recovering from a panic in a view: the frame shows the notice
+// and the next one the Song view
+func (tm TrackerModel) safeRender() (view string) {
+	defer func() {
+		if r := recover(); r != nil {
+			input.RecoverUI(tm.model, fmt.Sprintf("View (view mode %d)", tm.model.ViewMode), r, debug.Stack())
+			view = tm.model.Notice
+		}
+	}()
+	return tm.render()
+}
+
+// render draws the splash screen or the current view
+func (tm TrackerModel) render() string {
 	if tm.showingSplash {
 		return views.RenderSplashScreen(tm.model.TermWidth, tm.model.TermHeight, tm.splashState, Version)
 	}
@@ -805,11 +1662,45 @@ func (tm TrackerModel) View() string {
 		return views.RenderMixerView(tm.model)
 	case types.WaveformView:
 		return views.RenderWaveformView(tm.model)
+	case types.StatsView:
+		return views.RenderStatsView(tm.model)
+	case types.RecordingsView:
+		return views.RenderRecordingsView(tm.model)
+	case types.MasterChainView:
+		return views.RenderMasterChainView(tm.model)
+	case types.VisualizerView:
+		return views.RenderVisualizerView(tm.model)
+	case types.TimelineView:
+		return views.RenderTimelineView(tm.model)
+	case types.UsageView:
+		return views.RenderUsageView(tm.model)
+	case types.InputView:
+		return views.RenderInputView(tm.model)
+	case types.TunerView:
+		return views.RenderTunerView(tm.model)
+	case types.GeneratorView:
+		return views.RenderGeneratorView(tm.model)
+	case types.NotesView:
+		return views.RenderNotesView(tm.model)
+	case types.ChangelogView:
+		return views.RenderChangelogView(tm.model)
+	case types.HistoryView:
+		return views.RenderHistoryView(tm.model)
+	case types.LibraryView:
+		return views.RenderLibraryView(tm.model)
+	case types.DiagnosticsView:
+		return views.RenderDiagnosticsView(tm.model)
 	default: // FileView
 		return views.RenderFileView(tm.model)
 	}
 }
 
+// activeModel is the model of the running session, flushed when an exit signal arrives
+var activeModel atomic.Pointer[model.Model]
+
+// shutdownGrace gives SuperCollider time to release recorders (1s release) and close the files
+const shutdownGrace = 1500 * time.Millisecond
+
 func setupCleanupOnExit() {
 	// Handle cleanup on various exit signals
 	c := make(chan os.Signal, 1)
@@ -817,7 +1708,33 @@ func setupCleanupOnExit() {
 
 	go func() {
 		<-c
+		if m := activeModel.Load(); m != nil {
+			flushBeforeExit(m)
+		}
 		supercollider.Cleanup()
+		if m := activeModel.Load(); m != nil {
+			input.FinishSessionTake(m)
+		}
+		storage.CloseProjects()
 		os.Exit(0)
 	}()
 }
+
+// finishSessionTake puts the pre-roll before the session take of a tracker that has closed
+func finishSessionTake(finalModel tea.Model) {
+	if trackerModel, ok := finalModel.(*TrackerModel); ok {
+		input.FinishSessionTake(trackerModel.model)
+	}
+}
+
+// flushBeforeExit saves the project, silences MIDI and lets SuperCollider finalize recordings
+func flushBeforeExit(m *model.Model) {
+	logging.UI.Debugf("Exit signal received, flushing state")
+	input.StopForExit(m)
+	midiplayer.AllNotesOff()
+	m.SendOSCShutdownMessage()
+	storage.Flush(m)
+	if m.HasOSCLink() {
+		time.Sleep(shutdownGrace)
+	}
+}
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"fmt"
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -11,6 +13,9 @@ import (
 	"github.com/hypebeast/go-osc/osc"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/schollz/collidertracker/internal/model"
+	"github.com/schollz/collidertracker/internal/storage"
+	"github.com/schollz/collidertracker/internal/supercollider"
 	"github.com/schollz/collidertracker/internal/types"
 )
 
@@ -219,10 +224,77 @@ func TestTickFunctions(t *testing.T) {
 	waveCmd = tickWaveform(0) // Should default to 30fps
 	assert.NotNil(t, waveCmd)
 
-	splashCmd := tickSplash()
+	splashCmd := tickSplash(true)
+	assert.NotNil(t, splashCmd)
+
+	splashCmd = tickSplash(false) // Idle rate without animations
 	assert.NotNil(t, splashCmd)
 }
 
+func TestApplyConfig(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("AppData", configDir)
+
+	tm := createTestModel()
+	tm.model.Autosave = false
+	watchConfig(tm)
+	defer closeDumpFile(tm)
+
+	// Turning Dump on in Settings opens the dump file and keeps it in the config file
+	dumpPath := filepath.Join(t.TempDir(), "dump.txt")
+	tm.model.Config.Dump = dumpPath
+	tm.model.Publish(model.Event{Kind: model.EventSettings})
+	assert.NotNil(t, tm.dumpFile)
+	var saved types.AppConfig
+	assert.NoError(t, storage.LoadConfig(storage.ConfigPath(), &saved))
+	assert.Equal(t, dumpPath, saved.Dump)
+
+	tm.model.ToggleDump()
+	tm.model.Publish(model.Event{Kind: model.EventSettings})
+	assert.Nil(t, tm.dumpFile)
+	assert.Equal(t, "", config.dump)
+}
+
+func TestConfigKeepsOSCEngines(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("AppData", configDir)
+	defer func() { config.oscEngines = nil }()
+
+	engines := []types.OSCEngine{{Name: "pd", Port: 9000, Address: "/pd"}}
+	assert.NoError(t, storage.SaveConfig(storage.ConfigPath(), types.AppConfig{Port: config.port, OSCEngines: engines}))
+	applyConfigFile(rootCmd)
+
+	tm := createTestModel()
+	watchConfig(tm)
+	_, ok := tm.model.Engine("pd")
+	assert.True(t, ok, "Engines in the config file reach the model")
+
+	// Saving an edited option keeps the engines in the config file
+	tm.model.Config.Vim = !tm.model.Config.Vim
+	tm.model.Publish(model.Event{Kind: model.EventSettings})
+	var saved types.AppConfig
+	assert.NoError(t, storage.LoadConfig(storage.ConfigPath(), &saved))
+	assert.Equal(t, engines, saved.OSCEngines)
+}
+
+func TestFollowOSCPort(t *testing.T) {
+	defer func(port int) { config.port = port }(config.port)
+	port, err := supercollider.FindFreePortPair(41000)
+	assert.NoError(t, err)
+
+	// SuperCollider started on another port: the tracker listens on the port after it
+	tm := createTestModel()
+	tm.Update(oscPortMsg{port: port})
+	defer tm.oscConn.Close()
+	assert.Equal(t, port, config.port)
+	assert.False(t, supercollider.IsUDPPortFree(port+1))
+	assert.Contains(t, tm.model.Notice, fmt.Sprintf("using %d/%d", port, port+1))
+}
+
 func TestTrackerModelKeyNavigation(t *testing.T) {
 	tm := createTestModel()
 	tm.showingSplash = false
@@ -454,6 +526,7 @@ func TestDumpAllScreens(t *testing.T) {
 	}
 
 	// Create dumps for each screen
+	dumpDir := t.TempDir()
 	for _, screen := range screens {
 		t.Run(screen.name, func(t *testing.T) {
 			// Set up the view mode
@@ -465,7 +538,7 @@ func TestDumpAllScreens(t *testing.T) {
 			assert.NotEmpty(t, view)
 
 			// Write to file
-			filename := "screen_" + screen.name + ".txt"
+			filename := filepath.Join(dumpDir, "screen_"+screen.name+".txt")
 			err := os.WriteFile(filename, []byte(view), 0644)
 			assert.NoError(t, err)
 
@@ -473,3 +546,16 @@ func TestDumpAllScreens(t *testing.T) {
 		})
 	}
 }
+
+func TestSetupDefaults(t *testing.T) {
+	assert.Equal(t, "Volca", defaultMidiDevice([]string{"Keystep", "Volca"}, "Volca"))
+	assert.Equal(t, "Keystep", defaultMidiDevice([]string{"Keystep", "Volca"}, "Unplugged"))
+	assert.Equal(t, "None", defaultMidiDevice([]string{"Keystep"}, "None"))
+	assert.Equal(t, "", defaultMidiDevice(nil, ""))
+
+	defer func(dir string) { config.projectDir = dir }(config.projectDir)
+	config.projectDir = ""
+	assert.Equal(t, "song", newProjectPath("song"))
+	config.projectDir = filepath.Join("home", "ColliderTracker")
+	assert.Equal(t, filepath.Join("home", "ColliderTracker", "song"), newProjectPath("song"))
+}